@@ -1,18 +1,25 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"net/http"
 	"os"
+	"os/signal"
 	"social-platform-backend/config"
 	"social-platform-backend/internal/infrastructure/db"
 	"social-platform-backend/internal/interface/router"
 	"social-platform-backend/internal/wire"
 	"social-platform-backend/package/logger"
 	"strconv"
+	"syscall"
 	"time"
 )
 
 const (
 	DefaultPort = 8045
+	// Time given to in-flight requests when the server stops
+	ShutdownTimeout = 15 * time.Second
 )
 
 func main() {
@@ -38,6 +45,11 @@ func main() {
 	// wire-generated DI container
 	appHandler := wire.InitAppContainer(db.GetDB(), &conf)
 
+	// background jobs (scheduled posts, ...), stopped after the server so their last
+	// runs see every request
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	appHandler.Scheduler.Start(schedulerCtx)
+
 	// set up routes
 	r := router.SetupRoutes(appHandler, &conf)
 
@@ -46,6 +58,31 @@ func main() {
 		port = DefaultPort
 	}
 
-	logger.Infof("[✅] Server starting on PORT %d", port)
-	r.Run(":" + strconv.Itoa(port))
+	server := &http.Server{
+		Addr:    ":" + strconv.Itoa(port),
+		Handler: r,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		logger.Infof("[✅] Server starting on PORT %d", port)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Errorf("[ERROR] Server failed: %v", err)
+			stop()
+		}
+	}()
+
+	<-ctx.Done()
+	logger.Infof("[Info] Shutting down server")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), ShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Errorf("[ERROR] Server shutdown failed: %v", err)
+	}
+
+	stopScheduler()
+	appHandler.Scheduler.Wait()
 }
@@ -9,4 +9,6 @@ type Auth struct {
 	GoogleClientID               string
 	GoogleClientSecret           string
 	GoogleRedirectURL            string
+	// Users who act as platform admins, e.g. to decide escalated reports
+	PlatformAdminIDs []uint64
 }
@@ -0,0 +1,8 @@
+package config
+
+// AutoMod configures the community AutoModerator rules. Comments left by the comment
+// action are posted as BotUserID, the action is disabled while it is not set or the
+// user does not exist when the server starts.
+type AutoMod struct {
+	BotUserID uint64
+}
@@ -24,6 +24,12 @@ type Config struct {
 	AIService AIService
 	Gemini    Gemini
 	Ollama    Ollama
+	Storage   Storage
+	Content   Content
+
+	DuplicateDetection DuplicateDetection
+	Reaction           Reaction
+	AutoMod            AutoMod
 }
 
 func LoadConfig() {
@@ -85,6 +91,7 @@ func bindEnvs() {
 	_ = viper.BindEnv("auth.googleClientID", "GOOGLE_CLIENT_ID")
 	_ = viper.BindEnv("auth.googleClientSecret", "GOOGLE_CLIENT_SECRET")
 	_ = viper.BindEnv("auth.googleRedirectURL", "GOOGLE_REDIRECT_URL")
+	_ = viper.BindEnv("auth.platformAdminIDs", "PLATFORM_ADMIN_IDS")
 
 	// Gemini
 	_ = viper.BindEnv("gemini.apiKey", "GEMINI_API_KEY")
@@ -94,6 +101,25 @@ func bindEnvs() {
 	_ = viper.BindEnv("ollama.model", "OLLAMA_MODEL")
 	_ = viper.BindEnv("ollama.timeout", "OLLAMA_TIMEOUT")
 
+	// Storage
+	_ = viper.BindEnv("storage.driver", "STORAGE_DRIVER")
+	_ = viper.BindEnv("storage.publicURL", "STORAGE_PUBLIC_URL")
+	_ = viper.BindEnv("storage.localDir", "STORAGE_LOCAL_DIR")
+	_ = viper.BindEnv("storage.s3.endpoint", "S3_ENDPOINT")
+	_ = viper.BindEnv("storage.s3.region", "S3_REGION")
+	_ = viper.BindEnv("storage.s3.bucket", "S3_BUCKET")
+	_ = viper.BindEnv("storage.s3.accessKey", "S3_ACCESS_KEY")
+	_ = viper.BindEnv("storage.s3.secretKey", "S3_SECRET_KEY")
+	_ = viper.BindEnv("storage.s3.usePathStyle", "S3_USE_PATH_STYLE")
+
+	// Duplicate detection
+	_ = viper.BindEnv("duplicateDetection.enabled", "DUPLICATE_DETECTION_ENABLED")
+	_ = viper.BindEnv("duplicateDetection.authorAction", "DUPLICATE_DETECTION_AUTHOR_ACTION")
+	_ = viper.BindEnv("duplicateDetection.globalAction", "DUPLICATE_DETECTION_GLOBAL_ACTION")
+
+	// AutoModerator
+	_ = viper.BindEnv("autoMod.botUserID", "AUTOMOD_BOT_USER_ID")
+
 	// Log
 	_ = viper.BindEnv("log.level", "LOG_LEVEL")
 	_ = viper.BindEnv("log.filePath", "LOG_FILE_PATH")
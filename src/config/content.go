@@ -0,0 +1,8 @@
+package config
+
+// Content holds the HTML allowlist applied to user posts and comments. Attributes are
+// keyed by tag name. Empty lists fall back to the built-in defaults.
+type Content struct {
+	AllowedTags       []string
+	AllowedAttributes map[string][]string
+}
@@ -0,0 +1,15 @@
+package config
+
+// DuplicateDetection configures the near-duplicate check run when a post is published.
+// Posts are compared with the same author's posts over AuthorWindowHours and with
+// everyone's posts over GlobalWindowHours. Actions are hold, reject, flag or none.
+// Zero values fall back to the built-in defaults.
+type DuplicateDetection struct {
+	Enabled           bool
+	MinSimilarity     float64
+	MinWords          int
+	AuthorWindowHours int
+	GlobalWindowHours int
+	AuthorAction      string
+	GlobalAction      string
+}
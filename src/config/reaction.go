@@ -0,0 +1,7 @@
+package config
+
+// Reaction holds the emoji set users can react with. Communities may replace it with
+// their own set. An empty list falls back to the built-in defaults.
+type Reaction struct {
+	Emojis []string
+}
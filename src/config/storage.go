@@ -0,0 +1,19 @@
+package config
+
+type Storage struct {
+	// Driver selects the backend: "local" (default) or "s3"
+	Driver    string
+	PublicURL string
+	LocalDir  string
+	S3        S3Storage
+}
+
+type S3Storage struct {
+	Endpoint       string
+	Region         string
+	Bucket         string
+	AccessKey      string
+	SecretKey      string
+	UsePathStyle   bool
+	TimeoutSeconds int
+}
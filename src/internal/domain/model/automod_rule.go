@@ -0,0 +1,41 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// AutoModRule is a check a community runs on new posts, new comments and reports.
+// Conditions holds a payload.AutoModConditions and Actions a list of payload.AutoModAction.
+// Enabled rules run in the order they were created.
+type AutoModRule struct {
+	ID          uint64           `gorm:"column:id;primaryKey"`
+	CommunityID uint64           `gorm:"column:community_id"`
+	Name        string           `gorm:"column:name"`
+	Triggers    pq.StringArray   `gorm:"column:triggers;type:text[]"`
+	Conditions  *json.RawMessage `gorm:"column:conditions"`
+	Actions     *json.RawMessage `gorm:"column:actions"`
+	Enabled     bool             `gorm:"column:enabled"`
+	CreatedBy   uint64           `gorm:"column:created_by"`
+	CreatedAt   time.Time        `gorm:"column:created_at"`
+	UpdatedAt   *time.Time       `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (AutoModRule) TableName() string {
+	return "automod_rules"
+}
+
+// AutoModRuleFiring records that a reported rule fired on a post or comment, so the
+// reports that follow do not fire it again
+type AutoModRuleFiring struct {
+	RuleID     uint64    `gorm:"column:rule_id;primaryKey"`
+	TargetType string    `gorm:"column:target_type;primaryKey"`
+	TargetID   uint64    `gorm:"column:target_id;primaryKey"`
+	FiredAt    time.Time `gorm:"column:fired_at"`
+}
+
+func (AutoModRuleFiring) TableName() string {
+	return "automod_rule_firings"
+}
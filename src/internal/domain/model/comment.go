@@ -8,20 +8,33 @@ type Comment struct {
 	AuthorID        uint64     `gorm:"column:author_id"`
 	ParentCommentID *uint64    `gorm:"column:parent_comment_id"`
 	Content         string     `gorm:"column:content"`
+	ContentText     string     `gorm:"column:content_text"`
 	MediaURL        *string    `gorm:"column:media_url"`
 	CreatedAt       time.Time  `gorm:"column:created_at"`
 	UpdatedAt       *time.Time `gorm:"column:updated_at;autoUpdateTime"`
+	EditedAt        *time.Time `gorm:"column:edited_at"`
+	LockedAt        *time.Time `gorm:"column:locked_at"`
+	LockReason      *string    `gorm:"column:lock_reason"`
+
+	// Set when the comment was deleted but kept as a tombstone for its replies
+	DeletedAt *time.Time `gorm:"column:deleted_at"`
+	DeletedBy *string    `gorm:"column:deleted_by"`
 
 	// Total vote
 	Vote int64 `gorm:"column:vote;<-:false"`
 	// User's vote status (1=upvote, 0=downvote, NULL=no vote)
 	UserVote *int `gorm:"column:user_vote;<-:false"`
+	// Number of direct replies, only selected when loading comment trees
+	ReplyCount int64 `gorm:"column:reply_count;<-:false"`
 
 	// relation
 	Post          *Post      `gorm:"foreignKey:PostID;references:ID"`
 	Author        *User      `gorm:"foreignKey:AuthorID;references:ID"`
 	ParentComment *Comment   `gorm:"foreignKey:ParentCommentID;references:ID"`
 	ChildComments []*Comment `gorm:"foreignKey:ParentCommentID"`
+
+	ReactionCounts []*ReactionCount `gorm:"polymorphic:Target;polymorphicValue:comment"`
+	Mentions       []*Mention       `gorm:"polymorphic:Target;polymorphicValue:comment"`
 }
 
 func (Comment) TableName() string {
@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// CommentRevision keeps the content a comment had before an edit
+type CommentRevision struct {
+	ID        uint64    `gorm:"column:id;primaryKey"`
+	CommentID uint64    `gorm:"column:comment_id"`
+	EditorID  uint64    `gorm:"column:editor_id"`
+	Content   string    `gorm:"column:content"`
+	MediaURL  *string   `gorm:"column:media_url"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+
+	// relations
+	Editor *User `gorm:"foreignKey:EditorID"`
+}
+
+func (CommentRevision) TableName() string {
+	return "comment_revisions"
+}
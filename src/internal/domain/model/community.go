@@ -20,7 +20,14 @@ type Community struct {
 	IsPrivate              bool           `gorm:"column:is_private"`
 	RequiresPostApproval   bool           `gorm:"column:requires_post_approval"`
 	RequiresMemberApproval bool           `gorm:"column:requires_member_approval"`
-	DeletedAt              gorm.DeletedAt `gorm:"column:deleted_at"`
+	// Replaces the configured reaction emoji set when not empty
+	ReactionEmojis pq.StringArray `gorm:"column:reaction_emojis;type:text[]"`
+	DeletedAt      gorm.DeletedAt `gorm:"column:deleted_at"`
+
+	// What members see of the mod log, moderators always see all of it
+	ModLogMemberAccess   bool `gorm:"column:mod_log_member_access"`
+	ModLogShowModerators bool `gorm:"column:mod_log_show_moderators"`
+	ModLogShowReasons    bool `gorm:"column:mod_log_show_reasons"`
 
 	// computed column
 	MemberCount   int64 `gorm:"column:member_count;<-:false"`
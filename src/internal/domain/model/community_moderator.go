@@ -6,11 +6,13 @@ type CommunityModerator struct {
 	CommunityID uint64    `gorm:"column:community_id;primaryKey"`
 	UserID      uint64    `gorm:"column:user_id;primaryKey"`
 	Role        string    `gorm:"column:role"`
+	RoleID      *uint64   `gorm:"column:role_id"` // custom role, set when Role is moderator
 	JoinedAt    time.Time `gorm:"column:joined_at"`
 
 	// relation
-	Community *Community `gorm:"foreignKey:CommunityID;references:ID"`
-	User      *User      `gorm:"foreignKey:UserID;references:ID"`
+	Community  *Community     `gorm:"foreignKey:CommunityID;references:ID"`
+	User       *User          `gorm:"foreignKey:UserID;references:ID"`
+	CustomRole *CommunityRole `gorm:"foreignKey:RoleID;references:ID"`
 }
 
 func (CommunityModerator) TableName() string {
@@ -0,0 +1,23 @@
+package model
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// CommunityRole is a named set of permissions a community gives to some of its
+// moderators, next to the built-in super admin and admin roles
+type CommunityRole struct {
+	ID          uint64         `gorm:"column:id;primaryKey"`
+	CommunityID uint64         `gorm:"column:community_id"`
+	Name        string         `gorm:"column:name"`
+	Permissions pq.StringArray `gorm:"column:permissions;type:text[]"`
+	CreatedBy   uint64         `gorm:"column:created_by"`
+	CreatedAt   time.Time      `gorm:"column:created_at"`
+	UpdatedAt   *time.Time     `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (CommunityRole) TableName() string {
+	return "community_roles"
+}
@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// CommunityRule is one of the ordered rules of a community. Reports and moderator
+// removals can cite it when it applies to the reported or removed content.
+type CommunityRule struct {
+	ID          uint64     `gorm:"column:id;primaryKey"`
+	CommunityID uint64     `gorm:"column:community_id"`
+	Position    int        `gorm:"column:position"`
+	Title       string     `gorm:"column:title"`
+	Description *string    `gorm:"column:description"`
+	AppliesTo   string     `gorm:"column:applies_to"`
+	CreatedBy   uint64     `gorm:"column:created_by"`
+	CreatedAt   time.Time  `gorm:"column:created_at"`
+	UpdatedAt   *time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (CommunityRule) TableName() string {
+	return "community_rules"
+}
+
+// CommunityRuleStat counts the reports citing a rule and the removals made for it
+type CommunityRuleStat struct {
+	RuleID       uint64 `gorm:"column:rule_id"`
+	ReportCount  int64  `gorm:"column:report_count"`
+	RemovalCount int64  `gorm:"column:removal_count"`
+}
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// ContentFlag puts a post or comment in the moderation queue on behalf of an automatic
+// check, such as the AI content check or duplicate detection
+type ContentFlag struct {
+	ID          uint64     `gorm:"column:id;primaryKey"`
+	CommunityID uint64     `gorm:"column:community_id"`
+	TargetType  string     `gorm:"column:target_type"`
+	TargetID    uint64     `gorm:"column:target_id"`
+	Source      string     `gorm:"column:source"`
+	Reason      string     `gorm:"column:reason"`
+	Category    *string    `gorm:"column:category"`
+	CreatedAt   time.Time  `gorm:"column:created_at"`
+	ResolvedAt  *time.Time `gorm:"column:resolved_at"`
+	ResolvedBy  *uint64    `gorm:"column:resolved_by"`
+}
+
+func (ContentFlag) TableName() string {
+	return "content_flags"
+}
@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// LinkPreview caches the OpenGraph metadata of a link post URL. Failed fetches are
+// cached too so broken links are not fetched on every post.
+type LinkPreview struct {
+	URL         string    `gorm:"column:url;primaryKey"`
+	Status      string    `gorm:"column:status"`
+	Title       *string   `gorm:"column:title"`
+	Description *string   `gorm:"column:description"`
+	ImageURL    *string   `gorm:"column:image_url"`
+	SiteName    *string   `gorm:"column:site_name"`
+	FetchedAt   time.Time `gorm:"column:fetched_at"`
+}
+
+func (LinkPreview) TableName() string {
+	return "link_previews"
+}
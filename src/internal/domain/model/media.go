@@ -0,0 +1,46 @@
+package model
+
+import "time"
+
+// Media is a file uploaded through the media API. Objects are stored content addressed,
+// so uploads of identical files share StorageKey and variants while every uploader
+// keeps their own row.
+type Media struct {
+	ID          uint64    `gorm:"column:id;primaryKey"`
+	UploaderID  uint64    `gorm:"column:uploader_id"`
+	UseCase     string    `gorm:"column:use_case"`
+	StorageKey  string    `gorm:"column:storage_key"`
+	URL         string    `gorm:"column:url"`
+	MimeType    string    `gorm:"column:mime_type"`
+	Size        int64     `gorm:"column:size"`
+	Width       *int      `gorm:"column:width"`
+	Height      *int      `gorm:"column:height"`
+	ContentHash string    `gorm:"column:content_hash"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+	UpdatedAt   time.Time `gorm:"column:updated_at"`
+	// Set once the URL is saved in a post, comment, message or profile
+	AttachedAt *time.Time `gorm:"column:attached_at"`
+
+	// Relations
+	Variants []MediaVariant `gorm:"foreignKey:MediaID"`
+}
+
+func (Media) TableName() string {
+	return "media"
+}
+
+// MediaVariant is a resized copy (thumbnail, medium) generated for an image upload
+type MediaVariant struct {
+	ID         uint64 `gorm:"column:id;primaryKey"`
+	MediaID    uint64 `gorm:"column:media_id"`
+	Name       string `gorm:"column:name"`
+	StorageKey string `gorm:"column:storage_key"`
+	URL        string `gorm:"column:url"`
+	Width      int    `gorm:"column:width"`
+	Height     int    `gorm:"column:height"`
+	Size       int64  `gorm:"column:size"`
+}
+
+func (MediaVariant) TableName() string {
+	return "media_variants"
+}
@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// Mention is a user resolved from an @username in a post, comment or message
+type Mention struct {
+	ID          uint64     `gorm:"column:id;primaryKey"`
+	TargetType  string     `gorm:"column:target_type"`
+	TargetID    uint64     `gorm:"column:target_id"`
+	UserID      uint64     `gorm:"column:user_id"`
+	MentionerID uint64     `gorm:"column:mentioner_id"`
+	NotifiedAt  *time.Time `gorm:"column:notified_at"`
+	CreatedAt   time.Time  `gorm:"column:created_at"`
+
+	// relation
+	User *User `gorm:"foreignKey:UserID;references:ID"`
+}
+
+func (Mention) TableName() string {
+	return "mentions"
+}
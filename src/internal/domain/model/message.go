@@ -28,6 +28,9 @@ type Message struct {
 	Sender       *User               `gorm:"foreignKey:SenderID;references:ID"`
 	Conversation *Conversation       `gorm:"foreignKey:ConversationID;references:ID"`
 	Attachments  []MessageAttachment `gorm:"foreignKey:MessageID;references:ID"`
+
+	ReactionCounts []*ReactionCount `gorm:"polymorphic:Target;polymorphicValue:message"`
+	Mentions       []*Mention       `gorm:"polymorphic:Target;polymorphicValue:message"`
 }
 
 func (Message) TableName() string {
@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// ModerationLog records a single action taken by a community moderator. Entries are
+// only ever appended.
+type ModerationLog struct {
+	ID          uint64    `gorm:"column:id;primaryKey"`
+	CommunityID uint64    `gorm:"column:community_id"`
+	ActorID     uint64    `gorm:"column:actor_id"`
+	Action      string    `gorm:"column:action"`
+	TargetType  string    `gorm:"column:target_type"`
+	TargetID    uint64    `gorm:"column:target_id"`
+	Reason      *string   `gorm:"column:reason"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+	// What changed when the action alone doesn't say, e.g. the new value of a setting
+	Details *string `gorm:"column:details"`
+	// Community rule cited for a removal
+	RuleID *uint64 `gorm:"column:rule_id"`
+
+	// relations
+	Actor *User `gorm:"foreignKey:ActorID"`
+}
+
+func (ModerationLog) TableName() string {
+	return "moderation_logs"
+}
@@ -0,0 +1,32 @@
+package model
+
+import "time"
+
+// ModerationQueueItem is a post or comment waiting for a moderator, with the number of
+// open reports and flags that put it there
+type ModerationQueueItem struct {
+	TargetType  string    `gorm:"column:target_type"`
+	TargetID    uint64    `gorm:"column:target_id"`
+	QueuedAt    time.Time `gorm:"column:queued_at"`
+	IsPending   bool      `gorm:"column:is_pending"`
+	ReportCount int64     `gorm:"column:report_count"`
+	FlagCount   int64     `gorm:"column:flag_count"`
+}
+
+// ModerationQueueResolution is a moderator action on a queue item. It is applied
+// together with the resolution of the item's reports and flags and its log entry.
+type ModerationQueueResolution struct {
+	CommunityID uint64
+	TargetType  string
+	TargetID    uint64
+	Action      string
+	ActorID     uint64
+	Reason      *string
+
+	// Status given to the resolved reports, actioned or dismissed
+	ReportStatus string
+	// Log entry recorded for the action
+	ModerationLog *ModerationLog
+	// Only set when the author is banned
+	Restriction *UserRestriction
+}
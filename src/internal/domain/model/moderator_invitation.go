@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// ModeratorInvitation offers a member a moderator role, or the community itself for an
+// ownership transfer. Nothing changes until the invitee accepts it.
+type ModeratorInvitation struct {
+	ID          uint64     `gorm:"column:id;primaryKey"`
+	CommunityID uint64     `gorm:"column:community_id"`
+	InviterID   uint64     `gorm:"column:inviter_id"`
+	InviteeID   uint64     `gorm:"column:invitee_id"`
+	Type        string     `gorm:"column:type"`
+	Role        string     `gorm:"column:role"`    // role offered, super_admin for ownership transfers
+	RoleID      *uint64    `gorm:"column:role_id"` // custom role, set when Role is moderator
+	Status      string     `gorm:"column:status;default:'pending'"`
+	ExpiresAt   time.Time  `gorm:"column:expires_at"`
+	CreatedAt   time.Time  `gorm:"column:created_at"`
+	RespondedAt *time.Time `gorm:"column:responded_at"`
+
+	// relations
+	Community  *Community     `gorm:"foreignKey:CommunityID"`
+	Inviter    *User          `gorm:"foreignKey:InviterID"`
+	Invitee    *User          `gorm:"foreignKey:InviteeID"`
+	CustomRole *CommunityRole `gorm:"foreignKey:RoleID"`
+}
+
+func (ModeratorInvitation) TableName() string {
+	return "moderator_invitations"
+}
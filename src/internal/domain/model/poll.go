@@ -0,0 +1,55 @@
+package model
+
+import "time"
+
+// Poll holds the server side state of a poll post. The definition itself (question,
+// options, settings) stays in posts.poll_data.
+type Poll struct {
+	PostID      uint64     `gorm:"column:post_id;primaryKey"`
+	ExpiresAt   *time.Time `gorm:"column:expires_at"`
+	ClosedAt    *time.Time `gorm:"column:closed_at"`
+	ClosedBy    *uint64    `gorm:"column:closed_by"`
+	FinalizedAt *time.Time `gorm:"column:finalized_at"`
+	CreatedAt   time.Time  `gorm:"column:created_at"`
+
+	// Relations
+	Post *Post `gorm:"foreignKey:PostID"`
+}
+
+func (Poll) TableName() string {
+	return "polls"
+}
+
+// PollOption is one choice of a poll post. VoteCount is maintained together with
+// poll_votes so results never have to be counted on read. For ranked choice polls it
+// counts first preferences only.
+type PollOption struct {
+	PostID      uint64    `gorm:"column:post_id;primaryKey"`
+	OptionID    int       `gorm:"column:option_id;primaryKey"`
+	Text        string    `gorm:"column:text"`
+	VoteCount   int64     `gorm:"column:vote_count;default:0"`
+	Status      string    `gorm:"column:status;default:approved"`
+	SuggestedBy *uint64   `gorm:"column:suggested_by"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+
+	// Relations
+	Suggester *User `gorm:"foreignKey:SuggestedBy"`
+}
+
+func (PollOption) TableName() string {
+	return "poll_options"
+}
+
+// PollVote is a user's vote for one option. Rank is the preference (1 = first) on
+// ranked choice ballots and 0 otherwise.
+type PollVote struct {
+	PostID    uint64    `gorm:"column:post_id;primaryKey"`
+	OptionID  int       `gorm:"column:option_id;primaryKey"`
+	UserID    uint64    `gorm:"column:user_id;primaryKey"`
+	Rank      int       `gorm:"column:rank;default:0"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+func (PollVote) TableName() string {
+	return "poll_votes"
+}
@@ -9,20 +9,30 @@ import (
 )
 
 type Post struct {
-	ID          uint64           `gorm:"column:id;primaryKey"`
-	CommunityID uint64           `gorm:"column:community_id"`
-	AuthorID    uint64           `gorm:"column:author_id"`
-	Title       string           `gorm:"column:title"`
-	Type        string           `gorm:"column:type"`
-	Content     string           `gorm:"column:content"`
-	URL         *string          `gorm:"column:url"`
-	MediaURLs   *pq.StringArray  `gorm:"column:media_urls;type:text[]"`
-	PollData    *json.RawMessage `gorm:"column:poll_data"`
-	Tags        *pq.StringArray  `gorm:"column:tags;type:text[]"`
-	Status      string           `gorm:"column:status;default:'pending'"`
-	CreatedAt   time.Time        `gorm:"column:created_at"`
-	UpdatedAt   *time.Time       `gorm:"column:updated_at;autoUpdateTime"`
-	DeletedAt   gorm.DeletedAt   `gorm:"column:deleted_at"`
+	ID                uint64           `gorm:"column:id;primaryKey"`
+	CommunityID       uint64           `gorm:"column:community_id"`
+	AuthorID          uint64           `gorm:"column:author_id"`
+	Title             string           `gorm:"column:title"`
+	Type              string           `gorm:"column:type"`
+	Content           string           `gorm:"column:content"`
+	ContentText       string           `gorm:"column:content_text"`
+	URL               *string          `gorm:"column:url"`
+	MediaURLs         *pq.StringArray  `gorm:"column:media_urls;type:text[]"`
+	PollData          *json.RawMessage `gorm:"column:poll_data"`
+	Tags              *pq.StringArray  `gorm:"column:tags;type:text[]"`
+	Flair             *string          `gorm:"column:flair"`
+	Status            string           `gorm:"column:status;default:'pending'"`
+	PublishAt         *time.Time       `gorm:"column:publish_at"`
+	CrosspostParentID *uint64          `gorm:"column:crosspost_parent_id"`
+	PinOrder          *int             `gorm:"column:pin_order"`
+	IsAnnouncement    bool             `gorm:"column:is_announcement;default:false"`
+	LockedAt          *time.Time       `gorm:"column:locked_at"`
+	LockReason        *string          `gorm:"column:lock_reason"`
+	CreatedAt         time.Time        `gorm:"column:created_at"`
+	PublishedAt       *time.Time       `gorm:"column:published_at"`
+	UpdatedAt         *time.Time       `gorm:"column:updated_at;autoUpdateTime"`
+	EditedAt          *time.Time       `gorm:"column:edited_at"`
+	DeletedAt         gorm.DeletedAt   `gorm:"column:deleted_at"`
 
 	// Total vote
 	Vote int64 `gorm:"column:vote;<-:false"`
@@ -30,11 +40,26 @@ type Post struct {
 	UserVote *int `gorm:"column:user_vote;<-:false"`
 	// Total comments count
 	CommentCount int64 `gorm:"column:comment_count;<-:false"`
+	// Number of visible crossposts of this post
+	CrosspostCount int64 `gorm:"column:crosspost_count;<-:false"`
+	// Number of distinct users who voted in the poll
+	PollVoterCount int64 `gorm:"column:poll_voter_count;<-:false"`
 
 	// relation
 	Community *Community `gorm:"foreignKey:CommunityID;references:ID"`
 	Author    *User      `gorm:"foreignKey:AuthorID;references:ID"`
 	Comments  []*Comment `gorm:"foreignKey:PostID"`
+
+	CrosspostParent *Post        `gorm:"foreignKey:CrosspostParentID;references:ID"`
+	LinkPreview     *LinkPreview `gorm:"foreignKey:URL;references:URL"`
+
+	Poll        *Poll         `gorm:"foreignKey:PostID"`
+	PollOptions []*PollOption `gorm:"foreignKey:PostID"`
+	// Only the requesting user's poll votes are loaded
+	UserPollVotes []*PollVote `gorm:"foreignKey:PostID"`
+
+	ReactionCounts []*ReactionCount `gorm:"polymorphic:Target;polymorphicValue:post"`
+	Mentions       []*Mention       `gorm:"polymorphic:Target;polymorphicValue:post"`
 }
 
 func (Post) TableName() string {
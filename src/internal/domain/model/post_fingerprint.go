@@ -0,0 +1,44 @@
+package model
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// PostFingerprint is the content signature of a published post used to find reposts.
+// MinHash is empty for texts too short to compare, CanonicalURL is set for link posts.
+type PostFingerprint struct {
+	PostID       uint64        `gorm:"column:post_id;primaryKey"`
+	AuthorID     uint64        `gorm:"column:author_id"`
+	CommunityID  uint64        `gorm:"column:community_id"`
+	MinHash      pq.Int64Array `gorm:"column:min_hash;type:bigint[]"`
+	Bands        pq.Int64Array `gorm:"column:bands;type:bigint[]"`
+	CanonicalURL *string       `gorm:"column:canonical_url"`
+	CreatedAt    time.Time     `gorm:"column:created_at"`
+}
+
+func (PostFingerprint) TableName() string {
+	return "post_fingerprints"
+}
+
+// PostDuplicateMatch links a post to an earlier post it duplicates
+type PostDuplicateMatch struct {
+	ID            uint64    `gorm:"column:id;primaryKey"`
+	PostID        uint64    `gorm:"column:post_id"`
+	MatchedPostID uint64    `gorm:"column:matched_post_id"`
+	CommunityID   uint64    `gorm:"column:community_id"`
+	MatchType     string    `gorm:"column:match_type"`
+	Similarity    float64   `gorm:"column:similarity"`
+	SameAuthor    bool      `gorm:"column:same_author"`
+	Action        string    `gorm:"column:action"`
+	CreatedAt     time.Time `gorm:"column:created_at"`
+
+	// relations
+	Post        *Post `gorm:"foreignKey:PostID;references:ID"`
+	MatchedPost *Post `gorm:"foreignKey:MatchedPostID;references:ID"`
+}
+
+func (PostDuplicateMatch) TableName() string {
+	return "post_duplicate_matches"
+}
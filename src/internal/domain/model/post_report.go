@@ -12,8 +12,17 @@ type PostReport struct {
 	ReporterID uint64         `gorm:"column:reporter_id"`
 	Reasons    pq.StringArray `gorm:"column:reasons;type:text[]"`
 	Note       *string        `gorm:"column:note"`
+	RuleIDs    pq.Int64Array  `gorm:"column:rule_ids;type:bigint[]"` // community rules cited by the reporter
 	CreatedAt  time.Time      `gorm:"column:created_at"`
 
+	// Open until the reported content is actioned or the reports are dismissed.
+	// Resolution is the action taken, ResolvedBy is the moderator or platform admin.
+	Status           string     `gorm:"column:status;default:'open'"`
+	ResolvedAt       *time.Time `gorm:"column:resolved_at"`
+	ResolvedBy       *uint64    `gorm:"column:resolved_by"`
+	Resolution       *string    `gorm:"column:resolution"`
+	ResolutionReason *string    `gorm:"column:resolution_reason"`
+
 	// relations
 	Post     *Post `gorm:"foreignKey:PostID"`
 	Reporter *User `gorm:"foreignKey:ReporterID"`
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// PostRevision keeps the content a post had before an edit
+type PostRevision struct {
+	ID        uint64           `gorm:"column:id;primaryKey"`
+	PostID    uint64           `gorm:"column:post_id"`
+	EditorID  uint64           `gorm:"column:editor_id"`
+	Title     string           `gorm:"column:title"`
+	Content   string           `gorm:"column:content"`
+	URL       *string          `gorm:"column:url"`
+	MediaURLs *pq.StringArray  `gorm:"column:media_urls;type:text[]"`
+	PollData  *json.RawMessage `gorm:"column:poll_data"`
+	Tags      *pq.StringArray  `gorm:"column:tags;type:text[]"`
+	CreatedAt time.Time        `gorm:"column:created_at"`
+
+	// relations
+	Editor *User `gorm:"foreignKey:EditorID"`
+}
+
+func (PostRevision) TableName() string {
+	return "post_revisions"
+}
@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+// PostViewStat holds the deduplicated views of a post in one hour, split by the
+// community the viewers came from (0 when opened directly)
+type PostViewStat struct {
+	PostID              uint64    `gorm:"column:post_id;primaryKey"`
+	BucketStart         time.Time `gorm:"column:bucket_start;primaryKey"`
+	ReferralCommunityID uint64    `gorm:"column:referral_community_id;primaryKey"`
+	Views               int64     `gorm:"column:views"`
+}
+
+func (PostViewStat) TableName() string {
+	return "post_view_stats"
+}
+
+// PostActivityBucket is one point of a post analytics timeline
+type PostActivityBucket struct {
+	BucketStart time.Time `gorm:"column:bucket_start"`
+	Count       int64     `gorm:"column:count"`
+}
+
+// PostVoteBucket counts the votes cast on a post in one timeline bucket
+type PostVoteBucket struct {
+	BucketStart time.Time `gorm:"column:bucket_start"`
+	Upvotes     int64     `gorm:"column:upvotes"`
+	Downvotes   int64     `gorm:"column:downvotes"`
+}
+
+// PostReferral counts the views a post received from one community
+type PostReferral struct {
+	CommunityID   uint64  `gorm:"column:community_id"`
+	CommunityName *string `gorm:"column:community_name"`
+	Views         int64   `gorm:"column:views"`
+}
@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+type Reaction struct {
+	ID         uint64    `gorm:"column:id;primaryKey"`
+	TargetType string    `gorm:"column:target_type"`
+	TargetID   uint64    `gorm:"column:target_id"`
+	UserID     uint64    `gorm:"column:user_id"`
+	Emoji      string    `gorm:"column:emoji"`
+	CreatedAt  time.Time `gorm:"column:created_at"`
+}
+
+func (Reaction) TableName() string {
+	return "reactions"
+}
+
+// ReactionCount is the number of reactions with one emoji on a post, comment or
+// message, aggregated from the reactions table
+type ReactionCount struct {
+	TargetType string `gorm:"column:target_type;<-:false"`
+	TargetID   uint64 `gorm:"column:target_id;<-:false"`
+	Emoji      string `gorm:"column:emoji;<-:false"`
+	Count      int64  `gorm:"column:count;<-:false"`
+	// Whether the requesting user reacted with this emoji
+	Reacted bool `gorm:"column:reacted;<-:false"`
+}
+
+func (ReactionCount) TableName() string {
+	return "reactions"
+}
@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// ReportEscalation hands the reports of a post or comment over to the platform admins,
+// for content a community's moderators cannot or should not decide on alone
+type ReportEscalation struct {
+	ID          uint64    `gorm:"column:id;primaryKey"`
+	CommunityID uint64    `gorm:"column:community_id"`
+	TargetType  string    `gorm:"column:target_type"`
+	TargetID    uint64    `gorm:"column:target_id"`
+	EscalatedBy uint64    `gorm:"column:escalated_by"`
+	Note        *string   `gorm:"column:note"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+
+	// Open until the platform admins decide or a moderator acts on the content from the
+	// queue. Resolution is the action taken, ResolvedBy the platform admin or moderator.
+	Status           string     `gorm:"column:status;default:'open'"`
+	ResolvedAt       *time.Time `gorm:"column:resolved_at"`
+	ResolvedBy       *uint64    `gorm:"column:resolved_by"`
+	Resolution       *string    `gorm:"column:resolution"`
+	ResolutionReason *string    `gorm:"column:resolution_reason"`
+
+	// relations
+	Community *Community `gorm:"foreignKey:CommunityID"`
+	Escalator *User      `gorm:"foreignKey:EscalatedBy"`
+}
+
+func (ReportEscalation) TableName() string {
+	return "report_escalations"
+}
@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+type SavedPostCollection struct {
+	ID         uint64     `gorm:"column:id;primaryKey"`
+	UserID     uint64     `gorm:"column:user_id"`
+	Name       string     `gorm:"column:name"`
+	Position   int        `gorm:"column:position"`
+	IsPublic   bool       `gorm:"column:is_public;default:false"`
+	ShareToken *string    `gorm:"column:share_token"`
+	CreatedAt  time.Time  `gorm:"column:created_at"`
+	UpdatedAt  *time.Time `gorm:"column:updated_at;autoUpdateTime"`
+
+	// computed column
+	ItemCount int64 `gorm:"column:item_count;<-:false"`
+
+	// relation
+	User *User `gorm:"foreignKey:UserID;references:ID"`
+}
+
+func (SavedPostCollection) TableName() string {
+	return "saved_post_collections"
+}
+
+// SavedPostCollectionItem puts a saved post into a collection. The saved post is the
+// user_saved_posts row of the collection owner, so one saved post can be in many collections.
+type SavedPostCollectionItem struct {
+	CollectionID uint64    `gorm:"column:collection_id;primaryKey"`
+	PostID       uint64    `gorm:"column:post_id;primaryKey"`
+	UserID       uint64    `gorm:"column:user_id"`
+	CreatedAt    time.Time `gorm:"column:created_at"`
+}
+
+func (SavedPostCollectionItem) TableName() string {
+	return "saved_post_collection_items"
+}
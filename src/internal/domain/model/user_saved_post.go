@@ -13,9 +13,17 @@ type UserSavedPost struct {
 	CommunityID   uint64    `gorm:"column:community_id"`
 	IsFollowed    bool      `gorm:"column:is_followed"`
 	CreatedAt     time.Time `gorm:"column:created_at"`
+	// Private note of the user, never shown in shared collections
+	Note *string `gorm:"column:note"`
+	// When the title and author above were last copied from the post
+	SnapshotAt time.Time `gorm:"column:snapshot_at"`
+	// Set by the snapshot job when the original post was edited or deleted after saving
+	PostEditedAt  *time.Time `gorm:"column:post_edited_at"`
+	PostDeletedAt *time.Time `gorm:"column:post_deleted_at"`
 
 	// Relation
-	Community *Community `gorm:"foreignKey:CommunityID;references:ID"`
+	Community       *Community                 `gorm:"foreignKey:CommunityID;references:ID"`
+	CollectionItems []*SavedPostCollectionItem `gorm:"foreignKey:UserID,PostID;references:UserID,PostID"`
 }
 
 func (UserSavedPost) TableName() string {
@@ -0,0 +1,16 @@
+package repository
+
+import "social-platform-backend/internal/domain/model"
+
+type AutoModRuleRepository interface {
+	CreateRule(rule *model.AutoModRule) error
+	GetRuleByID(id uint64) (*model.AutoModRule, error)
+	GetRulesByCommunityID(communityID uint64) ([]*model.AutoModRule, error)
+	// GetEnabledRules returns the enabled rules of a community that run on trigger
+	GetEnabledRules(communityID uint64, trigger string) ([]*model.AutoModRule, error)
+	CountRules(communityID uint64) (int64, error)
+	UpdateRule(rule *model.AutoModRule) error
+	DeleteRule(id uint64) error
+	// MarkRuleFired records the firing and reports whether the rule had not fired on the target before
+	MarkRuleFired(firing *model.AutoModRuleFiring) (bool, error)
+}
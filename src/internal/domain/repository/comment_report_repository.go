@@ -5,6 +5,9 @@ import "social-platform-backend/internal/domain/model"
 type CommentReportRepository interface {
 	CreateCommentReport(report *model.CommentReport) error
 	GetCommentReportsByCommunityID(communityID uint64, page, limit int) ([]*model.CommentReport, int64, error)
-	DeleteCommentReport(id uint64) error
+	GetCommentReportByID(id uint64) (*model.CommentReport, error)
+	DismissCommentReports(commentID, resolvedBy uint64) error
+	// Only open reports count, content can be reported again once its reports are resolved
 	IsUserReportedComment(userID, commentID uint64) (bool, error)
+	GetOpenReportsByCommentIDs(commentIDs []uint64) ([]*model.CommentReport, error)
 }
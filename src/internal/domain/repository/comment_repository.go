@@ -1,13 +1,26 @@
 package repository
 
-import "social-platform-backend/internal/domain/model"
+import (
+	"social-platform-backend/internal/domain/model"
+	"time"
+)
 
 type CommentRepository interface {
 	CreateComment(comment *model.Comment) error
 	GetCommentByID(id uint64) (*model.Comment, error)
+	GetCommentsByIDs(ids []uint64) ([]*model.Comment, error)
 	GetCommentsByPostID(postID uint64, sortBy string, limit, offset int, userID *uint64) ([]*model.Comment, int64, error)
-	UpdateComment(id uint64, content string, mediaURL *string) error
-	DeleteComment(commentID uint64, parentCommentID *uint64) error
-	GetRepliesByParentID(parentID uint64, userID *uint64) ([]*model.Comment, error)
+	// UpdateComment saves the previous version as a revision together with the edit
+	UpdateComment(id uint64, content string, mediaURL *string, revision *model.CommentRevision) error
+	DeleteComment(commentID uint64, deletedBy string) error
+	PurgeCommentTombstones(before time.Time) (int64, error)
+	GetCommentSubtrees(postID uint64, parentIDs []uint64, offset, maxDepth, maxReplies int, userID *uint64) ([]*model.Comment, error)
+	GetCommentPath(commentID uint64, maxAncestors int, userID *uint64) ([]*model.Comment, error)
+	CountReplies(parentID uint64) (int64, error)
 	GetCommentsByUserID(userID uint64, sortBy string, page, limit int, requestUserID *uint64) ([]*model.Comment, int64, error)
+	UpdateCommentLock(id uint64, lockedAt *time.Time, lockReason *string) error
+	GetLockedAncestor(commentID uint64) (*model.Comment, error)
+	// GetRecentCommentsByCommunityID returns the newest comments on published posts of a
+	// community, with their authors and posts
+	GetRecentCommentsByCommunityID(communityID uint64, limit int) ([]*model.Comment, error)
 }
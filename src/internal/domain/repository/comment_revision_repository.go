@@ -0,0 +1,7 @@
+package repository
+
+import "social-platform-backend/internal/domain/model"
+
+type CommentRevisionRepository interface {
+	GetCommentRevisionsByCommentID(commentID uint64) ([]*model.CommentRevision, error)
+}
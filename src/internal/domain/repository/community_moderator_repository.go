@@ -6,6 +6,8 @@ type CommunityModeratorRepository interface {
 	CreateModerator(moderator *model.CommunityModerator) error
 	DeleteModerator(communityID, userID uint64) error
 	GetModeratorRole(communityID, userID uint64) (string, error)
+	// GetModeratorCustomRole returns the custom role of a moderator with ROLE_MODERATOR
+	GetModeratorCustomRole(communityID, userID uint64) (*model.CommunityRole, error)
 	GetModeratorCommunitiesByUserID(userID uint64) ([]*model.CommunityModerator, error)
 	GetCommunityModerators(communityID uint64) ([]*model.CommunityModerator, error)
 	UpsertModerator(moderator *model.CommunityModerator) error
@@ -20,4 +20,6 @@ type CommunityRepository interface {
 	IsCommunityNameExists(name string) (bool, error)
 	UpdateRequiresPostApproval(id uint64, requiresPostApproval bool) error
 	UpdateRequiresMemberApproval(id uint64, requiresMemberApproval bool) error
+	UpdateReactionEmojis(id uint64, emojis []string) error
+	UpdateModLogSettings(id uint64, memberAccess, showModerators, showReasons bool) error
 }
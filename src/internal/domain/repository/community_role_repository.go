@@ -0,0 +1,14 @@
+package repository
+
+import "social-platform-backend/internal/domain/model"
+
+type CommunityRoleRepository interface {
+	CreateRole(role *model.CommunityRole) error
+	GetRoleByID(id uint64) (*model.CommunityRole, error)
+	GetRolesByCommunityID(communityID uint64) ([]*model.CommunityRole, error)
+	CountRoles(communityID uint64) (int64, error)
+	UpdateRole(role *model.CommunityRole) error
+	DeleteRole(id uint64) error
+	// CountRoleMembers counts the moderators holding the role
+	CountRoleMembers(roleID uint64) (int64, error)
+}
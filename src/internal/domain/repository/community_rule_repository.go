@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"social-platform-backend/internal/domain/model"
+	"time"
+)
+
+type CommunityRuleRepository interface {
+	// CreateRule adds the rule after the last rule of its community
+	CreateRule(rule *model.CommunityRule) error
+	GetRuleByID(id uint64) (*model.CommunityRule, error)
+	GetRulesByCommunityID(communityID uint64) ([]*model.CommunityRule, error)
+	GetRulesByIDs(ids []uint64) ([]*model.CommunityRule, error)
+	CountRules(communityID uint64) (int64, error)
+	UpdateRule(rule *model.CommunityRule) error
+	DeleteRule(id uint64) error
+	ReorderRules(communityID uint64, ruleIDs []uint64) error // positions follow the order of ruleIDs
+	// GetRuleStats counts the reports and removals citing each rule of a community since the given time
+	GetRuleStats(communityID uint64, since time.Time) ([]*model.CommunityRuleStat, error)
+}
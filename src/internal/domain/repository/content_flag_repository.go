@@ -0,0 +1,8 @@
+package repository
+
+import "social-platform-backend/internal/domain/model"
+
+type ContentFlagRepository interface {
+	CreateContentFlag(flag *model.ContentFlag) error
+	GetOpenFlagsByTargets(targetType string, targetIDs []uint64) ([]*model.ContentFlag, error)
+}
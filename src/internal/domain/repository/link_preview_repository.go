@@ -0,0 +1,8 @@
+package repository
+
+import "social-platform-backend/internal/domain/model"
+
+type LinkPreviewRepository interface {
+	GetLinkPreviewByURL(url string) (*model.LinkPreview, error)
+	UpsertLinkPreview(preview *model.LinkPreview) error
+}
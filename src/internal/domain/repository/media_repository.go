@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"social-platform-backend/internal/domain/model"
+	"time"
+)
+
+type MediaRepository interface {
+	CreateMedia(media *model.Media) error
+	GetMediaByUploaderAndHash(uploaderID uint64, contentHash string) (*model.Media, error)
+	// CreateSharedMedia inserts media with the stored objects of an earlier upload of the
+	// same content. It returns false without inserting when there is none.
+	CreateSharedMedia(media *model.Media) (bool, error)
+	// TouchMedia returns false when the media was deleted in the meantime
+	TouchMedia(id uint64) (bool, error)
+	GetOrphanMedia(olderThan time.Time, limit int) ([]*model.Media, error)
+	// DeleteOrphanMedia deletes the media if it is still unattached and older than the
+	// cutoff. deleteObjects runs before the delete is committed when no other upload
+	// shares the stored objects, an error keeps the media.
+	DeleteOrphanMedia(media *model.Media, olderThan time.Time, deleteObjects func() error) error
+}
@@ -0,0 +1,10 @@
+package repository
+
+import "social-platform-backend/internal/domain/model"
+
+type MentionRepository interface {
+	// ReplaceMentions keeps the mentions of userIDs, adding missing ones and removing the rest
+	ReplaceMentions(targetType string, targetID, mentionerID uint64, userIDs []uint64) error
+	GetUnnotifiedMentions(targetType string, targetID uint64) ([]*model.Mention, error)
+	MarkMentionsNotified(ids []uint64) error
+}
@@ -0,0 +1,10 @@
+package repository
+
+import "social-platform-backend/internal/domain/model"
+
+type ModerationLogRepository interface {
+	CreateModerationLog(log *model.ModerationLog) error
+	GetModerationLogsByCommunityID(communityID uint64, page, limit int) ([]*model.ModerationLog, int64, error)
+	// GetMemberModerationLogs is the members' view, leaving out the hidden actions
+	GetMemberModerationLogs(communityID uint64, hiddenActions []string, page, limit int) ([]*model.ModerationLog, int64, error)
+}
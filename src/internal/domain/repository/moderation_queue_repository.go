@@ -0,0 +1,10 @@
+package repository
+
+import "social-platform-backend/internal/domain/model"
+
+type ModerationQueueRepository interface {
+	// reason and targetType narrow the queue when not empty
+	GetQueueItems(communityID uint64, reason, targetType string, page, limit int) ([]*model.ModerationQueueItem, int64, error)
+	// ResolveQueueItem returns the reporters of the reports it resolved
+	ResolveQueueItem(resolution *model.ModerationQueueResolution) ([]uint64, error)
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"social-platform-backend/internal/domain/model"
+	"time"
+)
+
+type ModeratorInvitationRepository interface {
+	CreateInvitation(invitation *model.ModeratorInvitation) error
+	// GetInvitationByID loads the community, the inviter and the custom role with the invitation
+	GetInvitationByID(id uint64) (*model.ModeratorInvitation, error)
+	// GetPendingInvitation returns nil when the user has no pending invitation of the type in the community
+	GetPendingInvitation(communityID, inviteeID uint64, invitationType string) (*model.ModeratorInvitation, error)
+	GetPendingCommunityInvitations(communityID uint64, now time.Time) ([]*model.ModeratorInvitation, error)
+	GetPendingUserInvitations(inviteeID uint64, now time.Time) ([]*model.ModeratorInvitation, error)
+	// CloseInvitation moves a pending invitation to status. Returns false if it was no longer pending.
+	CloseInvitation(id uint64, status string, at time.Time) (bool, error)
+	// AcceptInvitation gives the invitee the offered role and records the log entry, all or
+	// nothing. An ownership transfer also makes the inviter an admin, and only goes through
+	// while the inviter is still the super admin. A moderator invitation only goes through
+	// while the inviter can still grant the role and the invitee is not a moderator yet.
+	// Returns false if the invitation could not be accepted anymore.
+	AcceptInvitation(invitation *model.ModeratorInvitation, moderationLog *model.ModerationLog, at time.Time) (bool, error)
+	// CancelInviterInvitations cancels the pending invitations a moderator sent in the community
+	CancelInviterInvitations(communityID, inviterID uint64, at time.Time) (int64, error)
+	// ExpireInvitations expires the pending invitations past their expiry time
+	ExpireInvitations(now time.Time) (int64, error)
+}
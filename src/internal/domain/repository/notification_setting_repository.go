@@ -7,6 +7,7 @@ type NotificationSettingRepository interface {
 	CreateNotificationSettings(settings []*model.NotificationSetting) error
 	GetUserNotificationSetting(userID uint64, action string) (*model.NotificationSetting, error)
 	GetUserNotificationSettings(userID uint64) ([]*model.NotificationSetting, error)
+	GetNotificationSettingsByUserIDs(userIDs []uint64, action string) ([]*model.NotificationSetting, error)
 	UpdateNotificationSetting(setting *model.NotificationSetting) error
 	UpsertNotificationSetting(setting *model.NotificationSetting) error
 }
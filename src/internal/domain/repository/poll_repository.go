@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"social-platform-backend/internal/domain/model"
+	"time"
+)
+
+type PollRepository interface {
+	SyncPoll(poll *model.Poll, options []*model.PollOption) error
+	ImportPoll(poll *model.Poll, options []*model.PollOption, votes []*model.PollVote) error
+	GetPollByPostID(postID uint64) (*model.Poll, error)
+	GetPollOptionsByPostID(postID uint64) ([]*model.PollOption, error)
+	AddPollVote(vote *model.PollVote, singleChoice bool) (bool, error)
+	RemovePollVote(postID uint64, optionID int, userID uint64) (bool, error)
+	ReplaceRankedBallot(postID, userID uint64, ballot []*model.PollVote) error
+	RemovePollBallot(postID, userID uint64) (bool, error)
+	GetPollVotes(postID uint64) ([]*model.PollVote, error)
+	GetPollVoterIDs(postID uint64) ([]uint64, error)
+	ClosePoll(postID, closedBy uint64, closedAt time.Time) (bool, error)
+	GetPollsToFinalize(now time.Time, limit int) ([]*model.Poll, error)
+	FinalizePoll(postID uint64, finalizedAt time.Time) (bool, error)
+	CreateWriteInOption(option *model.PollOption) error
+	CountPendingWriteIns(postID, userID uint64) (int64, error)
+	GetPendingWriteIns(postID uint64) ([]*model.PollOption, error)
+	ApproveWriteInOption(postID uint64, optionID int) (bool, error)
+	DeletePendingWriteIn(postID uint64, optionID int) (bool, error)
+}
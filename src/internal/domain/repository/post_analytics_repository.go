@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"social-platform-backend/internal/domain/model"
+	"time"
+)
+
+type PostAnalyticsRepository interface {
+	IncrementPostViews(stats []*model.PostViewStat) error
+	GetPostTotalViews(postID uint64) (int64, error)
+	GetPostViewTimeline(postID uint64, interval string, from time.Time) ([]*model.PostActivityBucket, error)
+	GetPostVoteTimeline(postID uint64, interval string, from time.Time) ([]*model.PostVoteBucket, error)
+	GetPostCommentTimeline(postID uint64, interval string, from time.Time) ([]*model.PostActivityBucket, error)
+	GetPostReferrals(postID uint64) ([]*model.PostReferral, error)
+	CountPostSaves(postID uint64) (int64, error)
+	GetPostVoteScore(postID uint64) (int64, error)
+	CountPostComments(postID uint64) (int64, error)
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"social-platform-backend/internal/domain/model"
+	"time"
+)
+
+type PostFingerprintRepository interface {
+	CreatePostFingerprint(fingerprint *model.PostFingerprint, matches []*model.PostDuplicateMatch) error
+	FindCandidateFingerprints(fingerprint *model.PostFingerprint, authorSince, globalSince time.Time, limit int) ([]*model.PostFingerprint, error)
+	GetDuplicateMatchesByCommunityID(communityID uint64, page, limit int) ([]*model.PostDuplicateMatch, int64, error)
+}
@@ -5,6 +5,9 @@ import "social-platform-backend/internal/domain/model"
 type PostReportRepository interface {
 	CreatePostReport(report *model.PostReport) error
 	GetPostReportsByCommunityID(communityID uint64, page, limit int) ([]*model.PostReport, int64, error)
-	DeletePostReport(id uint64) error
+	GetPostReportByID(id uint64) (*model.PostReport, error)
+	DismissPostReports(postID, resolvedBy uint64) error
+	// Only open reports count, content can be reported again once its reports are resolved
 	IsUserReportedPost(userID, postID uint64) (bool, error)
+	GetOpenReportsByPostIDs(postIDs []uint64) ([]*model.PostReport, error)
 }
@@ -1,21 +1,22 @@
 package repository
 
 import (
-	"encoding/json"
 	"social-platform-backend/internal/domain/model"
 	"social-platform-backend/internal/interface/dto/request"
+	"time"
 )
 
 type PostRepository interface {
 	CreatePost(post *model.Post) error
 	GetPostByID(id uint64) (*model.Post, error)
+	GetPostsByIDs(ids []uint64) ([]*model.Post, error)
 	GetPostDetailByID(id uint64, userID *uint64) (*model.Post, error)
-	UpdatePostText(id uint64, updatePost *request.UpdatePostTextRequest) error
-	UpdatePostLink(id uint64, updatePost *request.UpdatePostLinkRequest) error
-	UpdatePostMedia(id uint64, updatePost *request.UpdatePostMediaRequest) error
-	UpdatePostPoll(id uint64, updatePost *request.UpdatePostPollRequest) error
+	// The update methods save the previous version as a revision together with the edit
+	UpdatePostText(id uint64, updatePost *request.UpdatePostTextRequest, revision *model.PostRevision) error
+	UpdatePostLink(id uint64, updatePost *request.UpdatePostLinkRequest, revision *model.PostRevision) error
+	UpdatePostMedia(id uint64, updatePost *request.UpdatePostMediaRequest, revision *model.PostRevision) error
+	UpdatePostPoll(id uint64, updatePost *request.UpdatePostPollRequest, revision *model.PostRevision) error
 	UpdatePostStatus(id uint64, status string) error
-	UpdatePollData(postID uint64, pollData *json.RawMessage) error
 	DeletePost(id uint64) error
 	GetAllPosts(sortBy string, page, limit int, tags []string, userID *uint64) ([]*model.Post, int64, error)
 	GetPostsByCommunityID(communityID uint64, sortBy string, page, limit int, tags []string, userID *uint64) ([]*model.Post, int64, error)
@@ -23,4 +24,18 @@ type PostRepository interface {
 	SearchPostsByTitle(title, sortBy string, page, limit int, tags []string, userID *uint64) ([]*model.Post, int64, error)
 	GetPostsByUserID(userID uint64, sortBy string, page, limit int) ([]*model.Post, int64, error)
 	GetPostsLastWeekCount(communityID uint64) (int64, error)
+	GetDraftPostsByUserID(userID uint64, page, limit int) ([]*model.Post, int64, error)
+	UpdateDraftPost(id uint64, draft *request.SaveDraftRequest) error
+	PublishPost(id uint64, fromStatuses []string, status string, publishedAt time.Time) (bool, error)
+	GetDueScheduledPosts(now time.Time, limit int) ([]*model.Post, error)
+	IsCrosspostedToCommunity(parentID, communityID uint64) (bool, error)
+	GetCrosspostsByParentID(parentID uint64, userID *uint64) ([]*model.Post, error)
+	// PinPost returns false when the community already has MAX_PINNED_POSTS_PER_COMMUNITY
+	// pinned posts
+	PinPost(id uint64, pinOrder *int, isAnnouncement bool) (bool, error)
+	UpdatePostPin(id uint64, pinOrder *int, isAnnouncement bool) error
+	UpdatePostLock(id uint64, lockedAt *time.Time, lockReason *string) error
+	UpdatePostFlair(id uint64, flair *string) error
+	// GetRecentPostsByCommunityID returns the newest published posts with their authors
+	GetRecentPostsByCommunityID(communityID uint64, limit int) ([]*model.Post, error)
 }
@@ -0,0 +1,7 @@
+package repository
+
+import "social-platform-backend/internal/domain/model"
+
+type PostRevisionRepository interface {
+	GetPostRevisionsByPostID(postID uint64) ([]*model.PostRevision, error)
+}
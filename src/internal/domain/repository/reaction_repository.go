@@ -0,0 +1,8 @@
+package repository
+
+import "social-platform-backend/internal/domain/model"
+
+type ReactionRepository interface {
+	ToggleReaction(reaction *model.Reaction) (bool, error) // returns true if the reaction was added, false if it was removed
+	GetReactionCounts(targetType string, targetID uint64, userID *uint64) ([]*model.ReactionCount, error)
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"social-platform-backend/internal/domain/model"
+	"time"
+)
+
+// ReportRepository covers post and comment reports together
+type ReportRepository interface {
+	// GetReporterStats counts the reports of a user resolved since the given time and
+	// how many of them were dismissed
+	GetReporterStats(reporterID uint64, since time.Time) (resolved int64, dismissed int64, err error)
+	CreateEscalation(escalation *model.ReportEscalation) error
+	GetOpenEscalation(targetType string, targetID uint64) (*model.ReportEscalation, error)
+	GetEscalationByID(id uint64) (*model.ReportEscalation, error)
+	// status narrows the list when not empty
+	GetEscalations(status string, page, limit int) ([]*model.ReportEscalation, int64, error)
+	// ResolveEscalation closes the escalation and the open reports of its target with the same
+	// status. It reports false when the escalation was no longer open.
+	ResolveEscalation(escalation *model.ReportEscalation) (bool, error)
+}
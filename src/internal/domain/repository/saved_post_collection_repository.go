@@ -0,0 +1,17 @@
+package repository
+
+import "social-platform-backend/internal/domain/model"
+
+type SavedPostCollectionRepository interface {
+	CreateCollection(collection *model.SavedPostCollection) error
+	GetCollectionByID(id uint64) (*model.SavedPostCollection, error)
+	GetCollectionByShareToken(shareToken string) (*model.SavedPostCollection, error)
+	GetUserCollections(userID uint64) ([]*model.SavedPostCollection, error)
+	CountUserCollections(userID uint64) (int64, error)
+	UpdateCollection(id uint64, name string, isPublic bool, shareToken *string) error
+	ReorderCollections(userID uint64, collectionIDs []uint64) error // positions follow the order of collectionIDs
+	DeleteCollection(id uint64) error
+	AddCollectionItem(item *model.SavedPostCollectionItem) error
+	RemoveCollectionItem(collectionID, postID uint64) error
+	GetCollectionPosts(collectionID uint64, page, limit int) ([]*model.UserSavedPost, int64, error)
+}
@@ -9,4 +9,6 @@ type SubscriptionRepository interface {
 	GetCommunityMembers(communityID uint64, sortBy, searchName, status string, page, limit int) ([]*model.Subscription, int64, error)
 	GetCommunitiesByUserID(userID uint64) ([]*model.Subscription, error)
 	UpdateSubscriptionStatus(userID, communityID uint64, status string) error
+	GetApprovedSubscriberIDs(communityID uint64) ([]uint64, error)
+	FilterApprovedSubscriberIDs(communityID uint64, userIDs []uint64) ([]uint64, error)
 }
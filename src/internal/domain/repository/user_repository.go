@@ -20,5 +20,7 @@ type UserRepository interface {
 	GetUserPostCount(userID uint64) (uint64, error)
 	GetUserCommentCount(userID uint64) (uint64, error)
 	GetUserBadgeHistory(userID uint64) ([]*model.UserBadge, error)
-	SearchUsers(searchTerm string, page, limit int) ([]*model.User, int64, error)
+	SearchUsers(searchTerm string, communityID *uint64, page, limit int) ([]*model.User, int64, error) // communityID limits the search to approved members
+	GetUsersByUsernames(usernames []string) ([]*model.User, error)
+	GetUsersByIDs(ids []uint64) ([]*model.User, error)
 }
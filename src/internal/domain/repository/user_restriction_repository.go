@@ -4,7 +4,10 @@ import "social-platform-backend/internal/domain/model"
 
 type UserRestrictionRepository interface {
 	CreateRestriction(restriction *model.UserRestriction) error
+	GetRestrictionByID(id uint64) (*model.UserRestriction, error)
 	GetActiveRestrictionByUserAndCommunity(userID, communityID uint64) (*model.UserRestriction, error)
+	// FilterBannedUserIDs returns the users of userIDs with an active ban in the community
+	FilterBannedUserIDs(communityID uint64, userIDs []uint64) ([]uint64, error)
 	GetUserRestrictionHistory(userID uint64, page, limit int) ([]*model.UserRestriction, int64, error)
 	DeleteRestriction(id uint64) error
 }
@@ -6,10 +6,12 @@ import (
 )
 
 type UserSavedPostRepository interface {
-	GetUserSavedPosts(userID uint64, searchTitle string, isFollowed *bool, page, limit int) ([]*model.UserSavedPost, int64, error)
+	GetUserSavedPosts(userID uint64, searchTitle string, isFollowed *bool, collectionID *uint64, page, limit int) ([]*model.UserSavedPost, int64, error)
 	CreateUserSavedPost(userID uint64, savedPost *request.UserSavedPostRequest) error
 	UpdateFollowedStatus(userID, postID uint64, isFollowed bool) error
 	DeleteUserSavedPost(userID, postID uint64) error
 	CheckUserSavedPostExists(userID, postID uint64) (bool, error)
 	GetFollowersByPostID(postID uint64) ([]uint64, error)
+	UpdateNote(userID, postID uint64, note *string) error
+	RefreshSnapshots(batchSize int) (int64, int64, error) // returns the number of refreshed and of flagged deleted snapshots
 }
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/domain/repository"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AutoModRuleRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAutoModRuleRepository(db *gorm.DB) repository.AutoModRuleRepository {
+	return &AutoModRuleRepositoryImpl{db: db}
+}
+
+func (r *AutoModRuleRepositoryImpl) CreateRule(rule *model.AutoModRule) error {
+	return r.db.Create(rule).Error
+}
+
+func (r *AutoModRuleRepositoryImpl) GetRuleByID(id uint64) (*model.AutoModRule, error) {
+	var rule model.AutoModRule
+	if err := r.db.Where("id = ?", id).First(&rule).Error; err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *AutoModRuleRepositoryImpl) GetRulesByCommunityID(communityID uint64) ([]*model.AutoModRule, error) {
+	var rules []*model.AutoModRule
+	err := r.db.Where("community_id = ?", communityID).
+		Order("id ASC").
+		Find(&rules).Error
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (r *AutoModRuleRepositoryImpl) GetEnabledRules(communityID uint64, trigger string) ([]*model.AutoModRule, error) {
+	var rules []*model.AutoModRule
+	err := r.db.Where("community_id = ? AND enabled = true AND ? = ANY(triggers)", communityID, trigger).
+		Order("id ASC").
+		Find(&rules).Error
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (r *AutoModRuleRepositoryImpl) CountRules(communityID uint64) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.AutoModRule{}).
+		Where("community_id = ?", communityID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *AutoModRuleRepositoryImpl) UpdateRule(rule *model.AutoModRule) error {
+	return r.db.Model(&model.AutoModRule{}).
+		Where("id = ?", rule.ID).
+		Updates(map[string]interface{}{
+			"name":       rule.Name,
+			"triggers":   rule.Triggers,
+			"conditions": rule.Conditions,
+			"actions":    rule.Actions,
+			"enabled":    rule.Enabled,
+			"updated_at": rule.UpdatedAt,
+		}).Error
+}
+
+func (r *AutoModRuleRepositoryImpl) DeleteRule(id uint64) error {
+	return r.db.Where("id = ?", id).Delete(&model.AutoModRule{}).Error
+}
+
+func (r *AutoModRuleRepositoryImpl) MarkRuleFired(firing *model.AutoModRuleFiring) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(firing)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
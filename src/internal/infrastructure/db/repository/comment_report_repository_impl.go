@@ -3,6 +3,8 @@ package repository
 import (
 	"social-platform-backend/internal/domain/model"
 	"social-platform-backend/internal/domain/repository"
+	"social-platform-backend/package/constant"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -28,7 +30,7 @@ func (r *CommentReportRepositoryImpl) GetCommentReportsByCommunityID(communityID
 	if err := r.db.Table("comment_reports").
 		Joins("JOIN comments ON comment_reports.comment_id = comments.id").
 		Joins("JOIN posts ON comments.post_id = posts.id").
-		Where("posts.community_id = ?", communityID).
+		Where("posts.community_id = ? AND comment_reports.resolved_at IS NULL", communityID).
 		Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
@@ -37,7 +39,7 @@ func (r *CommentReportRepositoryImpl) GetCommentReportsByCommunityID(communityID
 		Select("comment_reports.*").
 		Joins("JOIN comments ON comment_reports.comment_id = comments.id").
 		Joins("JOIN posts ON comments.post_id = posts.id").
-		Where("posts.community_id = ?", communityID).
+		Where("posts.community_id = ? AND comment_reports.resolved_at IS NULL", communityID).
 		Order("comment_reports.created_at DESC").
 		Offset(offset).
 		Limit(limit).
@@ -54,22 +56,49 @@ func (r *CommentReportRepositoryImpl) GetCommentReportsByCommunityID(communityID
 	return reports, total, nil
 }
 
-func (r *CommentReportRepositoryImpl) DeleteCommentReport(id uint64) error {
+func (r *CommentReportRepositoryImpl) GetCommentReportByID(id uint64) (*model.CommentReport, error) {
 	var report model.CommentReport
-	if err := r.db.Where("id = ?", id).First(&report).Error; err != nil {
-		return err
+	if err := r.db.Where("id = ?", id).Preload("Comment").Preload("Comment.Post").First(&report).Error; err != nil {
+		return nil, err
 	}
+	return &report, nil
+}
 
-	return r.db.Where("comment_id = ?", report.CommentID).Delete(&model.CommentReport{}).Error
+// DismissCommentReports dismisses every open report of the comment, the reports are kept
+func (r *CommentReportRepositoryImpl) DismissCommentReports(commentID, resolvedBy uint64) error {
+	return r.db.Model(&model.CommentReport{}).
+		Where("comment_id = ? AND resolved_at IS NULL", commentID).
+		Updates(map[string]interface{}{
+			"status":      constant.REPORT_STATUS_DISMISSED,
+			"resolved_at": time.Now(),
+			"resolved_by": resolvedBy,
+			"resolution":  constant.MODERATION_ACTION_DISMISS_REPORTS,
+		}).Error
 }
 
 func (r *CommentReportRepositoryImpl) IsUserReportedComment(userID, commentID uint64) (bool, error) {
 	var count int64
 	err := r.db.Model(&model.CommentReport{}).
-		Where("reporter_id = ? AND comment_id = ?", userID, commentID).
+		Where("reporter_id = ? AND comment_id = ? AND resolved_at IS NULL", userID, commentID).
 		Count(&count).Error
 	if err != nil {
 		return false, err
 	}
 	return count > 0, nil
 }
+
+// GetOpenReportsByCommentIDs returns the reports of the comments that no moderator acted on yet
+func (r *CommentReportRepositoryImpl) GetOpenReportsByCommentIDs(commentIDs []uint64) ([]*model.CommentReport, error) {
+	var reports []*model.CommentReport
+	if len(commentIDs) == 0 {
+		return reports, nil
+	}
+	err := r.db.Where("comment_id IN ? AND resolved_at IS NULL", commentIDs).
+		Order("created_at ASC").
+		Preload("Reporter").
+		Find(&reports).Error
+	if err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
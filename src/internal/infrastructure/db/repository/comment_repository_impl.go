@@ -5,6 +5,8 @@ import (
 	"social-platform-backend/internal/domain/model"
 	"social-platform-backend/internal/domain/repository"
 	"social-platform-backend/package/constant"
+	"social-platform-backend/package/util"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -18,7 +20,20 @@ func NewCommentRepository(db *gorm.DB) repository.CommentRepository {
 }
 
 func (r *CommentRepositoryImpl) CreateComment(comment *model.Comment) error {
-	return r.db.Create(comment).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(comment).Error; err != nil {
+			return err
+		}
+		return markMediaAttached(tx, commentMediaURLs(comment.Content, comment.MediaURL))
+	})
+}
+
+// commentMediaURLs returns the URLs of uploads saved in a comment
+func commentMediaURLs(content string, mediaURL *string) []string {
+	if mediaURL != nil {
+		return contentMediaURLs(content, *mediaURL)
+	}
+	return contentMediaURLs(content)
 }
 
 func (r *CommentRepositoryImpl) GetCommentByID(id uint64) (*model.Comment, error) {
@@ -30,6 +45,21 @@ func (r *CommentRepositoryImpl) GetCommentByID(id uint64) (*model.Comment, error
 	return &comment, nil
 }
 
+func (r *CommentRepositoryImpl) GetCommentsByIDs(ids []uint64) ([]*model.Comment, error) {
+	var comments []*model.Comment
+	if len(ids) == 0 {
+		return comments, nil
+	}
+	err := r.db.Where("id IN ?", ids).
+		Preload("Author").
+		Preload("Post").
+		Find(&comments).Error
+	if err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
 func (r *CommentRepositoryImpl) GetCommentsByPostID(postID uint64, sortBy string, limit, offset int, userID *uint64) ([]*model.Comment, int64, error) {
 	var comments []*model.Comment
 	var total int64
@@ -52,8 +82,10 @@ func (r *CommentRepositoryImpl) GetCommentsByPostID(postID uint64, sortBy string
 	}
 
 	selectFields := `comments.id, comments.post_id, comments.author_id, comments.parent_comment_id, 
-		comments.content, comments.media_url, comments.created_at, comments.updated_at,
-		COALESCE((SELECT SUM(CASE WHEN vote = true THEN 1 WHEN vote = false THEN -1 ELSE 0 END) FROM comment_votes WHERE comment_id = comments.id), 0) as vote`
+		comments.content, comments.media_url, comments.created_at, comments.updated_at, comments.edited_at,
+		comments.locked_at, comments.lock_reason, comments.deleted_at, comments.deleted_by,
+		COALESCE((SELECT SUM(CASE WHEN vote = true THEN 1 WHEN vote = false THEN -1 ELSE 0 END) FROM comment_votes WHERE comment_id = comments.id), 0) as vote,
+		(SELECT COUNT(*) FROM comments replies WHERE replies.parent_comment_id = comments.id) as reply_count`
 
 	// Add user_vote field if userID exists
 	if userID != nil {
@@ -66,6 +98,7 @@ func (r *CommentRepositoryImpl) GetCommentsByPostID(postID uint64, sortBy string
 		Where("comments.post_id = ? AND comments.parent_comment_id IS NULL", postID).
 		Order(orderClause).
 		Preload("Author").
+		Scopes(preloadReactionCounts(userID), preloadMentions).
 		Limit(limit).
 		Offset(offset).
 		Find(&comments).Error
@@ -76,24 +109,37 @@ func (r *CommentRepositoryImpl) GetCommentsByPostID(postID uint64, sortBy string
 	return comments, total, nil
 }
 
-func (r *CommentRepositoryImpl) GetRepliesByParentID(parentID uint64, userID *uint64) ([]*model.Comment, error) {
+// GetCommentSubtrees loads the replies below the given comments with a single recursive
+// query. Each level keeps at most maxReplies replies per parent (the first level skips
+// offset of them) and the walk stops maxDepth levels below the parents.
+func (r *CommentRepositoryImpl) GetCommentSubtrees(postID uint64, parentIDs []uint64, offset, maxDepth, maxReplies int, userID *uint64) ([]*model.Comment, error) {
 	var replies []*model.Comment
-
-	selectFields := `comments.id, comments.post_id, comments.author_id, comments.parent_comment_id, 
-		comments.content, comments.media_url, comments.created_at, comments.updated_at,
-		COALESCE((SELECT SUM(CASE WHEN vote = true THEN 1 WHEN vote = false THEN -1 ELSE 0 END) FROM comment_votes WHERE comment_id = comments.id), 0) as vote`
-
-	// Add user_vote field if userID exists
-	if userID != nil {
-		selectFields += fmt.Sprintf(", (SELECT CAST(vote AS INT) FROM comment_votes WHERE comment_id = comments.id AND user_id = %d) as user_vote", *userID)
+	if len(parentIDs) == 0 || maxDepth <= 0 {
+		return replies, nil
 	}
 
-	// Get replies with vote count
+	treeIDs := r.db.Raw(`
+		WITH RECURSIVE ranked AS (
+			SELECT c.id, c.parent_comment_id,
+				ROW_NUMBER() OVER (PARTITION BY c.parent_comment_id ORDER BY c.created_at ASC, c.id ASC) AS position
+			FROM comments c
+			WHERE c.post_id = ? AND c.parent_comment_id IS NOT NULL
+		), tree AS (
+			SELECT rk.id, 1 AS depth FROM ranked rk
+			WHERE rk.parent_comment_id IN ? AND rk.position > ? AND rk.position <= ?
+			UNION ALL
+			SELECT rk.id, t.depth + 1 FROM ranked rk
+			INNER JOIN tree t ON rk.parent_comment_id = t.id
+			WHERE t.depth < ? AND rk.position <= ?
+		)
+		SELECT id FROM tree`, postID, parentIDs, offset, offset+maxReplies, maxDepth, maxReplies)
+
 	err := r.db.Table("comments").
-		Select(selectFields).
-		Where("comments.parent_comment_id = ?", parentID).
-		Order("comments.created_at ASC").
+		Select(commentTreeSelectFields(userID)).
+		Where("comments.id IN (?)", treeIDs).
+		Order("comments.created_at ASC, comments.id ASC").
 		Preload("Author").
+		Scopes(preloadReactionCounts(userID), preloadMentions).
 		Find(&replies).Error
 	if err != nil {
 		return nil, err
@@ -101,35 +147,97 @@ func (r *CommentRepositoryImpl) GetRepliesByParentID(parentID uint64, userID *ui
 	return replies, nil
 }
 
-func (r *CommentRepositoryImpl) UpdateComment(id uint64, content string, mediaURL *string) error {
-	updates := map[string]interface{}{
-		"content":   content,
-		"media_url": mediaURL,
+// GetCommentPath returns the comment and up to maxAncestors of its ancestors, ordered
+// from the topmost ancestor down to the comment
+func (r *CommentRepositoryImpl) GetCommentPath(commentID uint64, maxAncestors int, userID *uint64) ([]*model.Comment, error) {
+	var comments []*model.Comment
+
+	path := r.db.Raw(`
+		WITH RECURSIVE ancestors AS (
+			SELECT c.id, c.parent_comment_id, 0 AS depth FROM comments c WHERE c.id = ?
+			UNION ALL
+			SELECT p.id, p.parent_comment_id, a.depth + 1 FROM comments p
+			INNER JOIN ancestors a ON p.id = a.parent_comment_id
+			WHERE a.depth < ?
+		)
+		SELECT id, depth FROM ancestors`, commentID, maxAncestors)
+
+	err := r.db.Table("comments").
+		Select(commentTreeSelectFields(userID)).
+		Joins("INNER JOIN (?) AS path ON path.id = comments.id", path).
+		Order("path.depth DESC").
+		Preload("Author").
+		Scopes(preloadReactionCounts(userID), preloadMentions).
+		Find(&comments).Error
+	if err != nil {
+		return nil, err
 	}
-	return r.db.Model(&model.Comment{}).Where("id = ?", id).Updates(updates).Error
+	return comments, nil
+}
+
+func (r *CommentRepositoryImpl) CountReplies(parentID uint64) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.Comment{}).
+		Where("parent_comment_id = ?", parentID).
+		Count(&count).Error
+	return count, err
 }
 
-func (r *CommentRepositoryImpl) DeleteComment(commentID uint64, parentCommentID *uint64) error {
+// UpdateComment applies an edit and saves the previous version, both or neither. An
+// edit that leaves the content and the media as they are changes nothing.
+func (r *CommentRepositoryImpl) UpdateComment(id uint64, content string, mediaURL *string, revision *model.CommentRevision) error {
+	updates := map[string]interface{}{
+		"content":      content,
+		"content_text": util.HTMLToText(content),
+		"media_url":    mediaURL,
+		"edited_at":    time.Now(),
+	}
 	return r.db.Transaction(func(tx *gorm.DB) error {
-		// Update parent_comment_id of all direct replies (promotes 1 level up)
-		updates := map[string]interface{}{
-			"parent_comment_id": parentCommentID,
+		result := tx.Model(&model.Comment{}).
+			Where("id = ?", id).
+			Where("(content IS DISTINCT FROM ? OR media_url IS DISTINCT FROM ?)", content, mediaURL).
+			Updates(updates)
+		if result.Error != nil {
+			return result.Error
 		}
-		if err := tx.Model(&model.Comment{}).
-			Where("parent_comment_id = ?", commentID).
-			Updates(updates).Error; err != nil {
-			return err
+		if result.RowsAffected == 0 {
+			return nil
 		}
-
-		// Delete the comment
-		if err := tx.Delete(&model.Comment{}, commentID).Error; err != nil {
+		if err := tx.Create(revision).Error; err != nil {
 			return err
 		}
+		return markMediaAttached(tx, commentMediaURLs(content, mediaURL))
+	})
+}
 
-		return nil
+// DeleteComment removes a comment without replies. A comment with replies is kept as
+// a tombstone without its content so the thread below it stays in place.
+func (r *CommentRepositoryImpl) DeleteComment(commentID uint64, deletedBy string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return deleteComment(tx, commentID, deletedBy)
 	})
 }
 
+// PurgeCommentTombstones removes tombstones deleted before the given time that have no
+// replies left. Purging a tombstone can leave its parent tombstone without replies, so
+// it runs until nothing is left to purge.
+func (r *CommentRepositoryImpl) PurgeCommentTombstones(before time.Time) (int64, error) {
+	var purged int64
+	for {
+		result := r.db.
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+			Where("NOT EXISTS (SELECT 1 FROM comments replies WHERE replies.parent_comment_id = comments.id)").
+			Delete(&model.Comment{})
+		if result.Error != nil {
+			return purged, result.Error
+		}
+		if result.RowsAffected == 0 {
+			return purged, nil
+		}
+		purged += result.RowsAffected
+	}
+}
+
 func (r *CommentRepositoryImpl) GetCommentsByUserID(userID uint64, sortBy string, page, limit int, requestUserID *uint64) ([]*model.Comment, int64, error) {
 	var comments []*model.Comment
 	var total int64
@@ -142,7 +250,8 @@ func (r *CommentRepositoryImpl) GetCommentsByUserID(userID uint64, sortBy string
 	}
 
 	selectFields := `comments.id, comments.post_id, comments.author_id, comments.parent_comment_id, 
-		comments.content, comments.media_url, comments.created_at, comments.updated_at,
+		comments.content, comments.media_url, comments.created_at, comments.updated_at, comments.edited_at,
+		comments.locked_at, comments.lock_reason,
 		COALESCE((SELECT SUM(CASE WHEN vote = true THEN 1 WHEN vote = false THEN -1 ELSE 0 END) FROM comment_votes WHERE comment_id = comments.id), 0) as vote`
 
 	// Add user_vote field if requestUserID exists
@@ -154,7 +263,8 @@ func (r *CommentRepositoryImpl) GetCommentsByUserID(userID uint64, sortBy string
 		Select(selectFields).
 		Where("comments.author_id = ? AND comments.deleted_at IS NULL", userID).
 		Preload("Author").
-		Preload("Post")
+		Preload("Post").
+		Scopes(preloadReactionCounts(requestUserID), preloadMentions)
 
 	switch sortBy {
 	case constant.SORT_TOP:
@@ -172,3 +282,98 @@ func (r *CommentRepositoryImpl) GetCommentsByUserID(userID uint64, sortBy string
 
 	return comments, total, nil
 }
+
+func (r *CommentRepositoryImpl) UpdateCommentLock(id uint64, lockedAt *time.Time, lockReason *string) error {
+	updates := map[string]interface{}{
+		"locked_at":   lockedAt,
+		"lock_reason": lockReason,
+	}
+	return r.db.Model(&model.Comment{}).Where("id = ?", id).UpdateColumns(updates).Error
+}
+
+// Walks up from the comment to the top-level comment and returns the closest
+// locked one (the comment itself included), or nil if the thread is open
+func (r *CommentRepositoryImpl) GetLockedAncestor(commentID uint64) (*model.Comment, error) {
+	var comments []*model.Comment
+	err := r.db.Raw(`
+		WITH RECURSIVE ancestors AS (
+			SELECT c.*, 0 AS depth FROM comments c WHERE c.id = ?
+			UNION ALL
+			SELECT p.*, a.depth + 1 FROM comments p
+			INNER JOIN ancestors a ON p.id = a.parent_comment_id
+		)
+		SELECT * FROM ancestors WHERE locked_at IS NOT NULL ORDER BY depth ASC LIMIT 1`, commentID).
+		Scan(&comments).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(comments) == 0 {
+		return nil, nil
+	}
+	return comments[0], nil
+}
+
+// commentTreeSelectFields selects a comment with its vote, the number of direct replies
+// and, when userID is set, the user's own vote
+func commentTreeSelectFields(userID *uint64) string {
+	selectFields := `comments.id, comments.post_id, comments.author_id, comments.parent_comment_id, 
+		comments.content, comments.media_url, comments.created_at, comments.updated_at, comments.edited_at,
+		comments.locked_at, comments.lock_reason, comments.deleted_at, comments.deleted_by,
+		COALESCE((SELECT SUM(CASE WHEN vote = true THEN 1 WHEN vote = false THEN -1 ELSE 0 END) FROM comment_votes WHERE comment_id = comments.id), 0) as vote,
+		(SELECT COUNT(*) FROM comments replies WHERE replies.parent_comment_id = comments.id) as reply_count`
+
+	if userID != nil {
+		selectFields += fmt.Sprintf(", (SELECT CAST(vote AS INT) FROM comment_votes WHERE comment_id = comments.id AND user_id = %d) as user_vote", *userID)
+	}
+	return selectFields
+}
+
+// deleteComment removes a comment without replies. A comment with replies is kept as
+// a tombstone without its content so the thread below it stays in place.
+func deleteComment(tx *gorm.DB, commentID uint64, deletedBy string) error {
+	// Mentions and earlier revisions belong to the content that is going away
+	if err := tx.Where("target_type = ? AND target_id = ?", constant.MENTION_TARGET_COMMENT, commentID).
+		Delete(&model.Mention{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("comment_id = ?", commentID).Delete(&model.CommentRevision{}).Error; err != nil {
+		return err
+	}
+
+	var replyCount int64
+	if err := tx.Model(&model.Comment{}).
+		Where("parent_comment_id = ?", commentID).
+		Count(&replyCount).Error; err != nil {
+		return err
+	}
+
+	if replyCount == 0 {
+		return tx.Delete(&model.Comment{}, commentID).Error
+	}
+
+	updates := map[string]interface{}{
+		"content":      "",
+		"content_text": "",
+		"media_url":    nil,
+		"deleted_at":   time.Now(),
+		"deleted_by":   deletedBy,
+	}
+	return tx.Model(&model.Comment{}).Where("id = ?", commentID).UpdateColumns(updates).Error
+}
+
+func (r *CommentRepositoryImpl) GetRecentCommentsByCommunityID(communityID uint64, limit int) ([]*model.Comment, error) {
+	var comments []*model.Comment
+	err := r.db.Select("comments.*").
+		Joins("JOIN posts ON posts.id = comments.post_id").
+		Where("posts.community_id = ? AND posts.deleted_at IS NULL AND posts.status NOT IN ?", communityID, constant.UNPUBLISHED_POST_STATUSES).
+		Where("comments.deleted_at IS NULL").
+		Preload("Author").
+		Preload("Post").
+		Order("comments.created_at DESC").
+		Limit(limit).
+		Find(&comments).Error
+	if err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/domain/repository"
+
+	"gorm.io/gorm"
+)
+
+type CommentRevisionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewCommentRevisionRepository(db *gorm.DB) repository.CommentRevisionRepository {
+	return &CommentRevisionRepositoryImpl{db: db}
+}
+
+// Oldest first
+func (r *CommentRevisionRepositoryImpl) GetCommentRevisionsByCommentID(commentID uint64) ([]*model.CommentRevision, error) {
+	var revisions []*model.CommentRevision
+	err := r.db.Where("comment_id = ?", commentID).
+		Order("created_at ASC, id ASC").
+		Preload("Editor").
+		Find(&revisions).Error
+	if err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
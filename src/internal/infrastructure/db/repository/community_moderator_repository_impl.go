@@ -38,6 +38,19 @@ func (r *CommunityModeratorRepositoryImpl) GetModeratorRole(communityID, userID
 	return role, nil
 }
 
+func (r *CommunityModeratorRepositoryImpl) GetModeratorCustomRole(communityID, userID uint64) (*model.CommunityRole, error) {
+	var role model.CommunityRole
+	err := r.db.Model(&model.CommunityRole{}).
+		Select("community_roles.*").
+		Joins("JOIN community_moderators ON community_moderators.role_id = community_roles.id").
+		Where("community_moderators.community_id = ? AND community_moderators.user_id = ?", communityID, userID).
+		First(&role).Error
+	if err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
 func (r *CommunityModeratorRepositoryImpl) GetModeratorCommunitiesByUserID(userID uint64) ([]*model.CommunityModerator, error) {
 	var moderators []*model.CommunityModerator
 	err := r.db.Where("user_id = ?", userID).
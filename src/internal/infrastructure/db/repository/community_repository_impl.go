@@ -22,7 +22,12 @@ func NewCommunityRepository(db *gorm.DB) repository.CommunityRepository {
 }
 
 func (r *CommunityRepositoryImpl) CreateCommunity(community *model.Community) error {
-	return r.db.Create(community).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(community).Error; err != nil {
+			return err
+		}
+		return markMediaAttached(tx, profileImageURLs(community.CommunityAvatar, community.CoverImage))
+	})
 }
 
 func (r *CommunityRepositoryImpl) GetCommunityByID(id uint64) (*model.Community, error) {
@@ -101,7 +106,12 @@ func (r *CommunityRepositoryImpl) UpdateCommunity(id uint64, updateCommunity *re
 	if updateCommunity.IsPrivate != nil {
 		updates["is_private"] = *updateCommunity.IsPrivate
 	}
-	return r.db.Model(&model.Community{}).Where("id = ?", id).Updates(updates).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Community{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+			return err
+		}
+		return markMediaAttached(tx, profileImageURLs(updateCommunity.CommunityAvatar, updateCommunity.CoverImage))
+	})
 }
 
 func (r *CommunityRepositoryImpl) DeleteCommunity(id uint64) error {
@@ -325,3 +335,19 @@ func (r *CommunityRepositoryImpl) UpdateRequiresMemberApproval(id uint64, requir
 		Where("id = ?", id).
 		Update("requires_member_approval", requiresMemberApproval).Error
 }
+
+func (r *CommunityRepositoryImpl) UpdateReactionEmojis(id uint64, emojis []string) error {
+	return r.db.Model(&model.Community{}).
+		Where("id = ?", id).
+		Update("reaction_emojis", pq.StringArray(emojis)).Error
+}
+
+func (r *CommunityRepositoryImpl) UpdateModLogSettings(id uint64, memberAccess, showModerators, showReasons bool) error {
+	return r.db.Model(&model.Community{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"mod_log_member_access":   memberAccess,
+			"mod_log_show_moderators": showModerators,
+			"mod_log_show_reasons":    showReasons,
+		}).Error
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/domain/repository"
+
+	"gorm.io/gorm"
+)
+
+type CommunityRoleRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewCommunityRoleRepository(db *gorm.DB) repository.CommunityRoleRepository {
+	return &CommunityRoleRepositoryImpl{db: db}
+}
+
+func (r *CommunityRoleRepositoryImpl) CreateRole(role *model.CommunityRole) error {
+	return r.db.Create(role).Error
+}
+
+func (r *CommunityRoleRepositoryImpl) GetRoleByID(id uint64) (*model.CommunityRole, error) {
+	var role model.CommunityRole
+	if err := r.db.Where("id = ?", id).First(&role).Error; err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (r *CommunityRoleRepositoryImpl) GetRolesByCommunityID(communityID uint64) ([]*model.CommunityRole, error) {
+	var roles []*model.CommunityRole
+	err := r.db.Where("community_id = ?", communityID).
+		Order("name ASC, id ASC").
+		Find(&roles).Error
+	if err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+func (r *CommunityRoleRepositoryImpl) CountRoles(communityID uint64) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.CommunityRole{}).
+		Where("community_id = ?", communityID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *CommunityRoleRepositoryImpl) UpdateRole(role *model.CommunityRole) error {
+	return r.db.Model(&model.CommunityRole{}).
+		Where("id = ?", role.ID).
+		Updates(map[string]interface{}{
+			"name":        role.Name,
+			"permissions": role.Permissions,
+			"updated_at":  role.UpdatedAt,
+		}).Error
+}
+
+func (r *CommunityRoleRepositoryImpl) DeleteRole(id uint64) error {
+	return r.db.Where("id = ?", id).Delete(&model.CommunityRole{}).Error
+}
+
+func (r *CommunityRoleRepositoryImpl) CountRoleMembers(roleID uint64) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.CommunityModerator{}).
+		Where("role_id = ?", roleID).
+		Count(&count).Error
+	return count, err
+}
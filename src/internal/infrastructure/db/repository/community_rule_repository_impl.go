@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/domain/repository"
+	"social-platform-backend/package/constant"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type CommunityRuleRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewCommunityRuleRepository(db *gorm.DB) repository.CommunityRuleRepository {
+	return &CommunityRuleRepositoryImpl{db: db}
+}
+
+func (r *CommunityRuleRepositoryImpl) CreateRule(rule *model.CommunityRule) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var position int
+		if err := tx.Model(&model.CommunityRule{}).
+			Where("community_id = ?", rule.CommunityID).
+			Select("COALESCE(MAX(position) + 1, 0)").
+			Scan(&position).Error; err != nil {
+			return err
+		}
+		rule.Position = position
+		return tx.Create(rule).Error
+	})
+}
+
+func (r *CommunityRuleRepositoryImpl) GetRuleByID(id uint64) (*model.CommunityRule, error) {
+	var rule model.CommunityRule
+	if err := r.db.Where("id = ?", id).First(&rule).Error; err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *CommunityRuleRepositoryImpl) GetRulesByCommunityID(communityID uint64) ([]*model.CommunityRule, error) {
+	var rules []*model.CommunityRule
+	err := r.db.Where("community_id = ?", communityID).
+		Order("position ASC, id ASC").
+		Find(&rules).Error
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (r *CommunityRuleRepositoryImpl) GetRulesByIDs(ids []uint64) ([]*model.CommunityRule, error) {
+	var rules []*model.CommunityRule
+	if len(ids) == 0 {
+		return rules, nil
+	}
+	err := r.db.Where("id IN ?", ids).
+		Order("position ASC, id ASC").
+		Find(&rules).Error
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (r *CommunityRuleRepositoryImpl) CountRules(communityID uint64) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.CommunityRule{}).
+		Where("community_id = ?", communityID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *CommunityRuleRepositoryImpl) UpdateRule(rule *model.CommunityRule) error {
+	return r.db.Model(&model.CommunityRule{}).
+		Where("id = ?", rule.ID).
+		Updates(map[string]interface{}{
+			"title":       rule.Title,
+			"description": rule.Description,
+			"applies_to":  rule.AppliesTo,
+			"updated_at":  rule.UpdatedAt,
+		}).Error
+}
+
+func (r *CommunityRuleRepositoryImpl) DeleteRule(id uint64) error {
+	return r.db.Where("id = ?", id).Delete(&model.CommunityRule{}).Error
+}
+
+func (r *CommunityRuleRepositoryImpl) ReorderRules(communityID uint64, ruleIDs []uint64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for position, ruleID := range ruleIDs {
+			if err := tx.Model(&model.CommunityRule{}).
+				Where("id = ? AND community_id = ?", ruleID, communityID).
+				Update("position", position).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *CommunityRuleRepositoryImpl) GetRuleStats(communityID uint64, since time.Time) ([]*model.CommunityRuleStat, error) {
+	var stats []*model.CommunityRuleStat
+	err := r.db.Raw(`
+		SELECT r.id AS rule_id,
+			(SELECT COUNT(*) FROM post_reports pr
+				JOIN posts p ON p.id = pr.post_id
+				WHERE p.community_id = r.community_id AND r.id = ANY(pr.rule_ids) AND pr.created_at >= @since)
+			+ (SELECT COUNT(*) FROM comment_reports cr
+				JOIN comments c ON c.id = cr.comment_id
+				JOIN posts p ON p.id = c.post_id
+				WHERE p.community_id = r.community_id AND r.id = ANY(cr.rule_ids) AND cr.created_at >= @since) AS report_count,
+			(SELECT COUNT(*) FROM moderation_logs ml
+				WHERE ml.community_id = r.community_id AND ml.rule_id = r.id
+					AND ml.action IN @removals AND ml.created_at >= @since) AS removal_count
+		FROM community_rules r
+		WHERE r.community_id = @communityID
+		ORDER BY r.position ASC, r.id ASC`,
+		map[string]interface{}{
+			"communityID": communityID,
+			"since":       since,
+			"removals":    []string{constant.MODERATION_ACTION_REMOVE_POST, constant.MODERATION_ACTION_REMOVE_COMMENT},
+		}).Scan(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
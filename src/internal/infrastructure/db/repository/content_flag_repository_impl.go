@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/domain/repository"
+
+	"gorm.io/gorm"
+)
+
+type ContentFlagRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewContentFlagRepository(db *gorm.DB) repository.ContentFlagRepository {
+	return &ContentFlagRepositoryImpl{db: db}
+}
+
+func (r *ContentFlagRepositoryImpl) CreateContentFlag(flag *model.ContentFlag) error {
+	return r.db.Create(flag).Error
+}
+
+func (r *ContentFlagRepositoryImpl) GetOpenFlagsByTargets(targetType string, targetIDs []uint64) ([]*model.ContentFlag, error) {
+	var flags []*model.ContentFlag
+	if len(targetIDs) == 0 {
+		return flags, nil
+	}
+	err := r.db.Where("target_type = ? AND target_id IN ? AND resolved_at IS NULL", targetType, targetIDs).
+		Order("created_at ASC").
+		Find(&flags).Error
+	if err != nil {
+		return nil, err
+	}
+	return flags, nil
+}
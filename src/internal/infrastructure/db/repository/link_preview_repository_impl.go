@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"errors"
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/domain/repository"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LinkPreviewRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewLinkPreviewRepository(db *gorm.DB) repository.LinkPreviewRepository {
+	return &LinkPreviewRepositoryImpl{db: db}
+}
+
+// GetLinkPreviewByURL returns nil if the URL was never fetched
+func (r *LinkPreviewRepositoryImpl) GetLinkPreviewByURL(url string) (*model.LinkPreview, error) {
+	var preview model.LinkPreview
+	err := r.db.Where("url = ?", url).First(&preview).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &preview, nil
+}
+
+func (r *LinkPreviewRepositoryImpl) UpsertLinkPreview(preview *model.LinkPreview) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "url"}},
+		UpdateAll: true,
+	}).Create(preview).Error
+}
@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"errors"
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/domain/repository"
+	"social-platform-backend/package/util"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MediaRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewMediaRepository(db *gorm.DB) repository.MediaRepository {
+	return &MediaRepositoryImpl{db: db}
+}
+
+// CreateMedia inserts the media row together with its variants
+func (r *MediaRepositoryImpl) CreateMedia(media *model.Media) error {
+	return r.db.Create(media).Error
+}
+
+// GetMediaByUploaderAndHash returns nil if the user never uploaded this content
+func (r *MediaRepositoryImpl) GetMediaByUploaderAndHash(uploaderID uint64, contentHash string) (*model.Media, error) {
+	var media model.Media
+	err := r.db.Preload("Variants").
+		Where("uploader_id = ? AND content_hash = ?", uploaderID, contentHash).
+		First(&media).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &media, nil
+}
+
+// lockMediaContent serializes reusing and deleting the stored objects of the same content
+func lockMediaContent(tx *gorm.DB, contentHash string) error {
+	return tx.Exec("SELECT pg_advisory_xact_lock(hashtextextended(?, 0))", "media:"+contentHash).Error
+}
+
+func (r *MediaRepositoryImpl) CreateSharedMedia(media *model.Media) (bool, error) {
+	created := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockMediaContent(tx, media.ContentHash); err != nil {
+			return err
+		}
+
+		var shared model.Media
+		err := tx.Preload("Variants").
+			Where("content_hash = ?", media.ContentHash).
+			Order("id ASC").
+			First(&shared).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		media.StorageKey = shared.StorageKey
+		media.URL = shared.URL
+		media.Size = shared.Size
+		media.Width = shared.Width
+		media.Height = shared.Height
+		media.AttachedAt = shared.AttachedAt
+		media.Variants = nil
+		for _, variant := range shared.Variants {
+			variant.ID = 0
+			variant.MediaID = 0
+			media.Variants = append(media.Variants, variant)
+		}
+		if err := tx.Create(media).Error; err != nil {
+			return err
+		}
+		created = true
+		return nil
+	})
+	return created, err
+}
+
+// TouchMedia restarts the orphan grace period of a re-uploaded file
+func (r *MediaRepositoryImpl) TouchMedia(id uint64) (bool, error) {
+	result := r.db.Model(&model.Media{}).Where("id = ?", id).Update("updated_at", time.Now())
+	return result.RowsAffected > 0, result.Error
+}
+
+// GetOrphanMedia returns uploads older than the cutoff that were never attached
+func (r *MediaRepositoryImpl) GetOrphanMedia(olderThan time.Time, limit int) ([]*model.Media, error) {
+	var media []*model.Media
+	err := r.db.Where("attached_at IS NULL AND updated_at < ?", olderThan).
+		Preload("Variants").
+		Order("id ASC").
+		Limit(limit).
+		Find(&media).Error
+	return media, err
+}
+
+func (r *MediaRepositoryImpl) DeleteOrphanMedia(media *model.Media, olderThan time.Time, deleteObjects func() error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockMediaContent(tx, media.ContentHash); err != nil {
+			return err
+		}
+
+		// skip it when it was attached or re-uploaded since it was found
+		var orphan model.Media
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND attached_at IS NULL AND updated_at < ?", media.ID, olderThan).
+			First(&orphan).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		if err := tx.Where("media_id = ?", media.ID).Delete(&model.MediaVariant{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("id = ?", media.ID).Delete(&model.Media{}).Error; err != nil {
+			return err
+		}
+
+		var remaining int64
+		if err := tx.Model(&model.Media{}).Where("content_hash = ?", media.ContentHash).Count(&remaining).Error; err != nil {
+			return err
+		}
+		if remaining > 0 {
+			return nil
+		}
+		return deleteObjects()
+	})
+}
+
+// markMediaAttached keeps uploads whose URL, or the URL of one of their variants, is
+// saved in content out of orphan cleanup
+func markMediaAttached(tx *gorm.DB, urls []string) error {
+	if len(urls) == 0 {
+		return nil
+	}
+	return tx.Model(&model.Media{}).
+		Where("attached_at IS NULL AND (url IN ? OR id IN (SELECT media_id FROM media_variants WHERE url IN ?))", urls, urls).
+		UpdateColumn("attached_at", time.Now()).Error
+}
+
+// profileImageURLs returns the avatar and cover image of a user or community when set
+func profileImageURLs(avatar, coverImage *string) []string {
+	var urls []string
+	for _, url := range []*string{avatar, coverImage} {
+		if url != nil && *url != "" {
+			urls = append(urls, *url)
+		}
+	}
+	return urls
+}
+
+// contentMediaURLs returns the URLs embedded in HTML content followed by the given URLs
+func contentMediaURLs(content string, urls ...string) []string {
+	return append(util.ContentURLs(content), urls...)
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/domain/repository"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MentionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewMentionRepository(db *gorm.DB) repository.MentionRepository {
+	return &MentionRepositoryImpl{db: db}
+}
+
+func (r *MentionRepositoryImpl) ReplaceMentions(targetType string, targetID, mentionerID uint64, userIDs []uint64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Mentions that are still there keep their notified_at, so editing the
+		// content only notifies newly mentioned users
+		query := tx.Where("target_type = ? AND target_id = ?", targetType, targetID)
+		if len(userIDs) > 0 {
+			query = query.Where("user_id NOT IN ?", userIDs)
+		}
+		if err := query.Delete(&model.Mention{}).Error; err != nil {
+			return err
+		}
+
+		if len(userIDs) == 0 {
+			return nil
+		}
+
+		now := time.Now()
+		mentions := make([]*model.Mention, len(userIDs))
+		for i, userID := range userIDs {
+			mentions[i] = &model.Mention{
+				TargetType:  targetType,
+				TargetID:    targetID,
+				UserID:      userID,
+				MentionerID: mentionerID,
+				CreatedAt:   now,
+			}
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "target_type"}, {Name: "target_id"}, {Name: "user_id"}},
+			DoNothing: true,
+		}).Create(&mentions).Error
+	})
+}
+
+func (r *MentionRepositoryImpl) GetUnnotifiedMentions(targetType string, targetID uint64) ([]*model.Mention, error) {
+	var mentions []*model.Mention
+	err := r.db.Where("target_type = ? AND target_id = ? AND notified_at IS NULL", targetType, targetID).
+		Order("id ASC").
+		Find(&mentions).Error
+	if err != nil {
+		return nil, err
+	}
+	return mentions, nil
+}
+
+func (r *MentionRepositoryImpl) MarkMentionsNotified(ids []uint64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.Model(&model.Mention{}).
+		Where("id IN ?", ids).
+		Update("notified_at", time.Now()).Error
+}
+
+// preloadMentions loads the users mentioned in posts, comments or messages
+func preloadMentions(db *gorm.DB) *gorm.DB {
+	return db.Preload("Mentions", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("id ASC")
+	}).Preload("Mentions.User", func(tx *gorm.DB) *gorm.DB {
+		return tx.Select("id, username")
+	})
+}
@@ -19,7 +19,20 @@ func (r *MessageAttachmentRepositoryImpl) CreateMessageAttachments(attachments [
 	if len(attachments) == 0 {
 		return nil
 	}
-	return r.db.Create(&attachments).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&attachments).Error; err != nil {
+			return err
+		}
+		return markMediaAttached(tx, attachmentURLs(attachments))
+	})
+}
+
+func attachmentURLs(attachments []model.MessageAttachment) []string {
+	urls := make([]string, 0, len(attachments))
+	for _, attachment := range attachments {
+		urls = append(urls, attachment.FileURL)
+	}
+	return urls
 }
 
 func (r *MessageAttachmentRepositoryImpl) GetAttachmentsByMessageID(messageID uint64) ([]model.MessageAttachment, error) {
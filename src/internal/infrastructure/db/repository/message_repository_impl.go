@@ -17,7 +17,12 @@ func NewMessageRepository(db *gorm.DB) repository.MessageRepository {
 }
 
 func (r *MessageRepositoryImpl) CreateMessage(message *model.Message) error {
-	return r.db.Create(message).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(message).Error; err != nil {
+			return err
+		}
+		return markMediaAttached(tx, attachmentURLs(message.Attachments))
+	})
 }
 
 func (r *MessageRepositoryImpl) GetMessageByID(id uint64) (*model.Message, error) {
@@ -25,6 +30,7 @@ func (r *MessageRepositoryImpl) GetMessageByID(id uint64) (*model.Message, error
 	err := r.db.Unscoped().
 		Preload("Sender").
 		Preload("Attachments").
+		Scopes(preloadMentions).
 		Where("id = ?", id).
 		First(&message).Error
 	if err != nil {
@@ -33,7 +39,7 @@ func (r *MessageRepositoryImpl) GetMessageByID(id uint64) (*model.Message, error
 	return &message, nil
 }
 
-func (r *MessageRepositoryImpl) GetConversationMessages(conversationID uint64, page, limit int) ([]*model.Message, int64, error) {
+func (r *MessageRepositoryImpl) GetConversationMessages(conversationID, userID uint64, page, limit int) ([]*model.Message, int64, error) {
 	var messages []*model.Message
 	var total int64
 
@@ -52,6 +58,7 @@ func (r *MessageRepositoryImpl) GetConversationMessages(conversationID uint64, p
 	err := r.db.Unscoped().
 		Preload("Sender").
 		Preload("Attachments").
+		Scopes(preloadReactionCounts(&userID), preloadMentions).
 		Where("conversation_id = ?", conversationID).
 		Order("created_at DESC").
 		Limit(limit).
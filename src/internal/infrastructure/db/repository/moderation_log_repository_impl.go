@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/domain/repository"
+
+	"gorm.io/gorm"
+)
+
+type ModerationLogRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewModerationLogRepository(db *gorm.DB) repository.ModerationLogRepository {
+	return &ModerationLogRepositoryImpl{db: db}
+}
+
+func (r *ModerationLogRepositoryImpl) CreateModerationLog(log *model.ModerationLog) error {
+	return r.db.Create(log).Error
+}
+
+// Newest first
+func (r *ModerationLogRepositoryImpl) GetModerationLogsByCommunityID(communityID uint64, page, limit int) ([]*model.ModerationLog, int64, error) {
+	var logs []*model.ModerationLog
+	var total int64
+
+	query := r.db.Model(&model.ModerationLog{}).Where("community_id = ?", communityID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Order("created_at DESC, id DESC").
+		Offset(offset).
+		Limit(limit).
+		Preload("Actor").
+		Find(&logs).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return logs, total, nil
+}
+
+// Newest first, the actor is loaded so the service can decide whether to show it
+func (r *ModerationLogRepositoryImpl) GetMemberModerationLogs(communityID uint64, hiddenActions []string, page, limit int) ([]*model.ModerationLog, int64, error) {
+	var logs []*model.ModerationLog
+	var total int64
+
+	visible := func(db *gorm.DB) *gorm.DB {
+		db = db.Where("community_id = ?", communityID)
+		if len(hiddenActions) > 0 {
+			db = db.Where("action NOT IN ?", hiddenActions)
+		}
+		return db
+	}
+
+	if err := r.db.Model(&model.ModerationLog{}).Scopes(visible).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := r.db.Scopes(visible).
+		Order("created_at DESC, id DESC").
+		Offset(offset).
+		Limit(limit).
+		Preload("Actor").
+		Find(&logs).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return logs, total, nil
+}
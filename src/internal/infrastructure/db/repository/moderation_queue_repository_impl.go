@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"fmt"
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/domain/repository"
+	"social-platform-backend/package/constant"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ModerationQueueRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewModerationQueueRepository(db *gorm.DB) repository.ModerationQueueRepository {
+	return &ModerationQueueRepositoryImpl{db: db}
+}
+
+// Every open reason for a post or comment to be in the queue, one row each
+const moderationQueueSourcesSQL = `
+	SELECT 'post' AS target_type, posts.id AS target_id, posts.created_at AS queued_at, 'pending' AS source
+	FROM posts
+	WHERE posts.community_id = @communityID AND posts.status = 'pending' AND posts.deleted_at IS NULL
+	UNION ALL
+	SELECT content_flags.target_type, content_flags.target_id, content_flags.created_at, 'flag'
+	FROM content_flags
+	WHERE content_flags.community_id = @communityID AND content_flags.resolved_at IS NULL
+		AND (
+			(content_flags.target_type = 'post' AND EXISTS (
+				SELECT 1 FROM posts WHERE posts.id = content_flags.target_id AND posts.deleted_at IS NULL))
+			OR (content_flags.target_type = 'comment' AND EXISTS (
+				SELECT 1 FROM comments WHERE comments.id = content_flags.target_id AND comments.deleted_at IS NULL))
+		)
+	UNION ALL
+	SELECT 'post', post_reports.post_id, post_reports.created_at, 'report'
+	FROM post_reports
+	JOIN posts ON posts.id = post_reports.post_id
+	WHERE posts.community_id = @communityID AND posts.deleted_at IS NULL AND post_reports.resolved_at IS NULL
+	UNION ALL
+	SELECT 'comment', comment_reports.comment_id, comment_reports.created_at, 'report'
+	FROM comment_reports
+	JOIN comments ON comments.id = comment_reports.comment_id
+	JOIN posts ON posts.id = comments.post_id
+	WHERE posts.community_id = @communityID AND posts.deleted_at IS NULL
+		AND comments.deleted_at IS NULL AND comment_reports.resolved_at IS NULL`
+
+// GetQueueItems groups the open reasons by post or comment, oldest first
+func (r *ModerationQueueRepositoryImpl) GetQueueItems(communityID uint64, reason, targetType string, page, limit int) ([]*model.ModerationQueueItem, int64, error) {
+	var items []*model.ModerationQueueItem
+	var total int64
+
+	args := map[string]interface{}{
+		"communityID": communityID,
+		"targetType":  targetType,
+	}
+
+	where := ""
+	if targetType != "" {
+		where = "WHERE target_type = @targetType"
+	}
+
+	having := ""
+	switch reason {
+	case constant.MODERATION_QUEUE_PENDING:
+		having = "HAVING BOOL_OR(source = 'pending')"
+	case constant.MODERATION_QUEUE_FLAGGED:
+		having = "HAVING COUNT(*) FILTER (WHERE source = 'flag') > 0"
+	case constant.MODERATION_QUEUE_REPORTED:
+		having = "HAVING COUNT(*) FILTER (WHERE source = 'report') > 0"
+	}
+
+	grouped := fmt.Sprintf(`
+		WITH queue AS (%s)
+		SELECT target_type, target_id,
+			MIN(queued_at) AS queued_at,
+			BOOL_OR(source = 'pending') AS is_pending,
+			COUNT(*) FILTER (WHERE source = 'report') AS report_count,
+			COUNT(*) FILTER (WHERE source = 'flag') AS flag_count
+		FROM queue
+		%s
+		GROUP BY target_type, target_id
+		%s`, moderationQueueSourcesSQL, where, having)
+
+	if err := r.db.Raw(fmt.Sprintf("SELECT COUNT(*) FROM (%s) AS items", grouped), args).
+		Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	args["limit"] = limit
+	args["offset"] = (page - 1) * limit
+	err := r.db.Raw(grouped+`
+		ORDER BY queued_at ASC, target_type, target_id
+		LIMIT @limit OFFSET @offset`, args).
+		Scan(&items).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+// ResolveQueueItem applies the action, resolves every open report and flag of the item
+// and records the log entry, all or nothing. The reporters of the resolved reports come
+// from the report update itself, a concurrent resolution of the item does not see them.
+func (r *ModerationQueueRepositoryImpl) ResolveQueueItem(resolution *model.ModerationQueueResolution) ([]uint64, error) {
+	var reporterIDs []uint64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		// Reports are resolved before the content changes, a removed comment without
+		// replies takes its reports with it
+		reportUpdates := map[string]interface{}{
+			"status":            resolution.ReportStatus,
+			"resolved_at":       now,
+			"resolved_by":       resolution.ActorID,
+			"resolution":        resolution.Action,
+			"resolution_reason": resolution.Reason,
+		}
+		returnReporter := clause.Returning{Columns: []clause.Column{{Name: "reporter_id"}}}
+		if resolution.TargetType == constant.MODERATION_TARGET_POST {
+			var reports []*model.PostReport
+			if err := tx.Model(&reports).Clauses(returnReporter).
+				Where("post_id = ? AND resolved_at IS NULL", resolution.TargetID).
+				Updates(reportUpdates).Error; err != nil {
+				return err
+			}
+			for _, report := range reports {
+				reporterIDs = append(reporterIDs, report.ReporterID)
+			}
+		} else {
+			var reports []*model.CommentReport
+			if err := tx.Model(&reports).Clauses(returnReporter).
+				Where("comment_id = ? AND resolved_at IS NULL", resolution.TargetID).
+				Updates(reportUpdates).Error; err != nil {
+				return err
+			}
+			for _, report := range reports {
+				reporterIDs = append(reporterIDs, report.ReporterID)
+			}
+		}
+
+		// The moderators acted on the content themselves, the admins no longer need to
+		if err := tx.Model(&model.ReportEscalation{}).
+			Where("target_type = ? AND target_id = ? AND status = ?", resolution.TargetType, resolution.TargetID, constant.REPORT_STATUS_OPEN).
+			Updates(map[string]interface{}{
+				"status":            resolution.ReportStatus,
+				"resolved_at":       now,
+				"resolved_by":       resolution.ActorID,
+				"resolution":        resolution.Action,
+				"resolution_reason": resolution.Reason,
+			}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&model.ContentFlag{}).
+			Where("target_type = ? AND target_id = ? AND resolved_at IS NULL", resolution.TargetType, resolution.TargetID).
+			Updates(map[string]interface{}{
+				"resolved_at": now,
+				"resolved_by": resolution.ActorID,
+			}).Error; err != nil {
+			return err
+		}
+
+		if err := applyQueueAction(tx, resolution, now); err != nil {
+			return err
+		}
+
+		if resolution.Restriction != nil {
+			if err := tx.Create(resolution.Restriction).Error; err != nil {
+				return err
+			}
+		}
+		if resolution.ModerationLog != nil {
+			if err := tx.Create(resolution.ModerationLog).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reporterIDs, nil
+}
+
+// applyQueueAction changes the post or comment itself. Ignoring reports and banning
+// the author leave the content as it is.
+func applyQueueAction(tx *gorm.DB, resolution *model.ModerationQueueResolution, now time.Time) error {
+	isPost := resolution.TargetType == constant.MODERATION_TARGET_POST
+
+	switch resolution.Action {
+	case constant.QUEUE_ACTION_APPROVE:
+		if !isPost {
+			return nil
+		}
+		return tx.Model(&model.Post{}).
+			Where("id = ? AND status IN ?", resolution.TargetID, []string{constant.POST_STATUS_PENDING, constant.POST_STATUS_REJECTED}).
+			Update("status", constant.POST_STATUS_APPROVED).Error
+	case constant.QUEUE_ACTION_REMOVE:
+		if isPost {
+			return tx.Model(&model.Post{}).Where("id = ?", resolution.TargetID).Update("deleted_at", now).Error
+		}
+		return deleteComment(tx, resolution.TargetID, constant.COMMENT_DELETED_BY_MODERATOR)
+	case constant.QUEUE_ACTION_LOCK:
+		updates := map[string]interface{}{
+			"locked_at":   now,
+			"lock_reason": resolution.Reason,
+		}
+		if isPost {
+			return tx.Model(&model.Post{}).Where("id = ?", resolution.TargetID).Updates(updates).Error
+		}
+		return tx.Model(&model.Comment{}).Where("id = ?", resolution.TargetID).Updates(updates).Error
+	}
+	return nil
+}
@@ -0,0 +1,251 @@
+package repository
+
+import (
+	"errors"
+	"slices"
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/domain/repository"
+	"social-platform-backend/package/constant"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ModeratorInvitationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewModeratorInvitationRepository(db *gorm.DB) repository.ModeratorInvitationRepository {
+	return &ModeratorInvitationRepositoryImpl{db: db}
+}
+
+func (r *ModeratorInvitationRepositoryImpl) CreateInvitation(invitation *model.ModeratorInvitation) error {
+	return r.db.Create(invitation).Error
+}
+
+func (r *ModeratorInvitationRepositoryImpl) GetInvitationByID(id uint64) (*model.ModeratorInvitation, error) {
+	var invitation model.ModeratorInvitation
+	err := r.db.Preload("Community").
+		Preload("Inviter").
+		Preload("CustomRole").
+		Where("id = ?", id).
+		First(&invitation).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func (r *ModeratorInvitationRepositoryImpl) GetPendingInvitation(communityID, inviteeID uint64, invitationType string) (*model.ModeratorInvitation, error) {
+	var invitation model.ModeratorInvitation
+	err := r.db.Where("community_id = ? AND invitee_id = ? AND type = ? AND status = ? AND expires_at > ?",
+		communityID, inviteeID, invitationType, constant.MODERATOR_INVITATION_STATUS_PENDING, time.Now()).
+		First(&invitation).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func (r *ModeratorInvitationRepositoryImpl) GetPendingCommunityInvitations(communityID uint64, now time.Time) ([]*model.ModeratorInvitation, error) {
+	var invitations []*model.ModeratorInvitation
+	err := r.db.Preload("Inviter").
+		Preload("Invitee").
+		Preload("CustomRole").
+		Where("community_id = ? AND status = ? AND expires_at > ?", communityID, constant.MODERATOR_INVITATION_STATUS_PENDING, now).
+		Order("created_at DESC").
+		Find(&invitations).Error
+	if err != nil {
+		return nil, err
+	}
+	return invitations, nil
+}
+
+func (r *ModeratorInvitationRepositoryImpl) GetPendingUserInvitations(inviteeID uint64, now time.Time) ([]*model.ModeratorInvitation, error) {
+	var invitations []*model.ModeratorInvitation
+	err := r.db.Preload("Community").
+		Preload("Inviter").
+		Preload("CustomRole").
+		Where("invitee_id = ? AND status = ? AND expires_at > ?", inviteeID, constant.MODERATOR_INVITATION_STATUS_PENDING, now).
+		Order("created_at DESC").
+		Find(&invitations).Error
+	if err != nil {
+		return nil, err
+	}
+	return invitations, nil
+}
+
+func (r *ModeratorInvitationRepositoryImpl) CloseInvitation(id uint64, status string, at time.Time) (bool, error) {
+	result := r.db.Model(&model.ModeratorInvitation{}).
+		Where("id = ? AND status = ?", id, constant.MODERATOR_INVITATION_STATUS_PENDING).
+		Updates(map[string]interface{}{
+			"status":       status,
+			"responded_at": at,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *ModeratorInvitationRepositoryImpl) AcceptInvitation(invitation *model.ModeratorInvitation, moderationLog *model.ModerationLog, at time.Time) (bool, error) {
+	accepted := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// The super admin row is locked so two transfers cannot both go through
+		if invitation.Type == constant.MODERATOR_INVITATION_TYPE_OWNERSHIP {
+			var owner model.CommunityModerator
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("community_id = ? AND role = ?", invitation.CommunityID, constant.ROLE_SUPER_ADMIN).
+				First(&owner).Error
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return nil
+				}
+				return err
+			}
+			if owner.UserID != invitation.InviterID {
+				return nil
+			}
+		} else {
+			canGrant, err := inviterCanGrant(tx, invitation)
+			if err != nil || !canGrant {
+				return err
+			}
+		}
+
+		var current model.CommunityModerator
+		if err := tx.Where("community_id = ? AND user_id = ?", invitation.CommunityID, invitation.InviteeID).
+			Limit(1).
+			Find(&current).Error; err != nil {
+			return err
+		}
+		// Accepting a moderator invitation never changes the role of an existing moderator
+		if current.Role == constant.ROLE_SUPER_ADMIN ||
+			(invitation.Type == constant.MODERATOR_INVITATION_TYPE_MODERATOR && current.Role != "") {
+			return nil
+		}
+
+		result := tx.Model(&model.ModeratorInvitation{}).
+			Where("id = ? AND status = ? AND expires_at > ?", invitation.ID, constant.MODERATOR_INVITATION_STATUS_PENDING, at).
+			Updates(map[string]interface{}{
+				"status":       constant.MODERATOR_INVITATION_STATUS_ACCEPTED,
+				"responded_at": at,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		// The old owner stays on as an admin, the community keeps exactly one super admin
+		if invitation.Type == constant.MODERATOR_INVITATION_TYPE_OWNERSHIP {
+			if err := tx.Model(&model.CommunityModerator{}).
+				Where("community_id = ? AND user_id = ?", invitation.CommunityID, invitation.InviterID).
+				Updates(map[string]interface{}{
+					"role":    constant.ROLE_ADMIN,
+					"role_id": nil,
+				}).Error; err != nil {
+				return err
+			}
+		}
+
+		moderator := &model.CommunityModerator{
+			CommunityID: invitation.CommunityID,
+			UserID:      invitation.InviteeID,
+			Role:        invitation.Role,
+			RoleID:      invitation.RoleID,
+			JoinedAt:    at,
+		}
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "community_id"}, {Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"role", "role_id"}),
+		}).Create(moderator).Error; err != nil {
+			return err
+		}
+
+		if moderationLog != nil {
+			if err := tx.Create(moderationLog).Error; err != nil {
+				return err
+			}
+		}
+		accepted = true
+		return nil
+	})
+	return accepted, err
+}
+
+// inviterCanGrant tells whether the inviter still holds manage_roles and every permission of
+// the offered role. The inviter's row is locked so a role change waits for the acceptance.
+func inviterCanGrant(tx *gorm.DB, invitation *model.ModeratorInvitation) (bool, error) {
+	var inviter model.CommunityModerator
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("community_id = ? AND user_id = ?", invitation.CommunityID, invitation.InviterID).
+		First(&inviter).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	held, found, err := moderatorRolePermissions(tx, invitation.CommunityID, inviter.Role, inviter.RoleID)
+	if err != nil || !found {
+		return false, err
+	}
+	offered, found, err := moderatorRolePermissions(tx, invitation.CommunityID, invitation.Role, invitation.RoleID)
+	if err != nil || !found {
+		return false, err
+	}
+
+	for _, permission := range append([]string{constant.COMMUNITY_PERMISSION_MANAGE_ROLES}, offered...) {
+		if !slices.Contains(held, permission) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// moderatorRolePermissions returns the permissions of a built-in role or of the community's
+// custom role roleID. found is false for unknown roles and custom roles deleted since.
+func moderatorRolePermissions(tx *gorm.DB, communityID uint64, role string, roleID *uint64) ([]string, bool, error) {
+	switch role {
+	case constant.ROLE_SUPER_ADMIN, constant.ROLE_ADMIN:
+		return constant.COMMUNITY_ROLE_PERMISSIONS[role], true, nil
+	case constant.ROLE_MODERATOR:
+		if roleID == nil {
+			return nil, false, nil
+		}
+		var customRole model.CommunityRole
+		err := tx.Where("id = ? AND community_id = ?", *roleID, communityID).First(&customRole).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, false, nil
+			}
+			return nil, false, err
+		}
+		return customRole.Permissions, true, nil
+	}
+	return nil, false, nil
+}
+
+func (r *ModeratorInvitationRepositoryImpl) CancelInviterInvitations(communityID, inviterID uint64, at time.Time) (int64, error) {
+	result := r.db.Model(&model.ModeratorInvitation{}).
+		Where("community_id = ? AND inviter_id = ? AND status = ?", communityID, inviterID, constant.MODERATOR_INVITATION_STATUS_PENDING).
+		Updates(map[string]interface{}{
+			"status":       constant.MODERATOR_INVITATION_STATUS_CANCELLED,
+			"responded_at": at,
+		})
+	return result.RowsAffected, result.Error
+}
+
+func (r *ModeratorInvitationRepositoryImpl) ExpireInvitations(now time.Time) (int64, error) {
+	result := r.db.Model(&model.ModeratorInvitation{}).
+		Where("status = ? AND expires_at <= ?", constant.MODERATOR_INVITATION_STATUS_PENDING, now).
+		Update("status", constant.MODERATOR_INVITATION_STATUS_EXPIRED)
+	return result.RowsAffected, result.Error
+}
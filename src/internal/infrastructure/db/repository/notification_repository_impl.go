@@ -20,6 +20,13 @@ func (r *notificationRepositoryImpl) CreateNotification(notification *model.Noti
 	return r.db.Create(notification).Error
 }
 
+func (r *notificationRepositoryImpl) CreateNotifications(notifications []*model.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	return r.db.Create(notifications).Error
+}
+
 func (r *notificationRepositoryImpl) GetNotificationByID(id uint64) (*model.Notification, error) {
 	var notification model.Notification
 	err := r.db.Preload("User").Where("id = ?", id).First(&notification).Error
@@ -36,6 +36,18 @@ func (r *notificationSettingRepositoryImpl) GetUserNotificationSetting(userID ui
 	return &setting, nil
 }
 
+func (r *notificationSettingRepositoryImpl) GetNotificationSettingsByUserIDs(userIDs []uint64, action string) ([]*model.NotificationSetting, error) {
+	var settings []*model.NotificationSetting
+	if len(userIDs) == 0 {
+		return settings, nil
+	}
+	err := r.db.Where("user_id IN ? AND action = ?", userIDs, action).Find(&settings).Error
+	if err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
 func (r *notificationSettingRepositoryImpl) GetUserNotificationSettings(userID uint64) ([]*model.NotificationSetting, error) {
 	var settings []*model.NotificationSetting
 	err := r.db.Where("user_id = ?", userID).
@@ -0,0 +1,448 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/domain/repository"
+	"social-platform-backend/package/constant"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// recountPollOptionsSQL recomputes vote_count for every option of a poll. Ranked
+// ballots only count towards the option the voter ranked highest.
+const recountPollOptionsSQL = `UPDATE poll_options SET vote_count = (
+		SELECT COUNT(*) FROM poll_votes
+		WHERE poll_votes.post_id = poll_options.post_id AND poll_votes.option_id = poll_options.option_id
+			AND (poll_votes.rank = 0 OR poll_votes.rank = (
+				SELECT MIN(ballot.rank) FROM poll_votes ballot
+				WHERE ballot.post_id = poll_votes.post_id AND ballot.user_id = poll_votes.user_id
+			))
+	) WHERE post_id = ?`
+
+type PollRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewPollRepository(db *gorm.DB) repository.PollRepository {
+	return &PollRepositoryImpl{db: db}
+}
+
+// lockPollVoter serializes concurrent votes of the same user on the same poll
+func lockPollVoter(tx *gorm.DB, postID, userID uint64) error {
+	lockKey := fmt.Sprintf("poll_vote:%d:%d", postID, userID)
+	return tx.Exec("SELECT pg_advisory_xact_lock(hashtextextended(?, 0))", lockKey).Error
+}
+
+// SyncPoll makes polls and poll_options match the poll definition: new options are
+// added, existing ones keep their votes and get the new text, removed ones lose their
+// votes. Pending write-ins are left alone.
+func (r *PollRepositoryImpl) SyncPoll(poll *model.Poll, options []*model.PollOption) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if poll.CreatedAt.IsZero() {
+			poll.CreatedAt = time.Now()
+		}
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "post_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"expires_at"}),
+		}).Create(poll).Error; err != nil {
+			return err
+		}
+
+		optionIDs := make([]int, len(options))
+		for i, option := range options {
+			option.PostID = poll.PostID
+			option.VoteCount = 0
+			option.Status = constant.POLL_OPTION_STATUS_APPROVED
+			if option.CreatedAt.IsZero() {
+				option.CreatedAt = time.Now()
+			}
+			optionIDs[i] = option.OptionID
+		}
+
+		if len(options) > 0 {
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "post_id"}, {Name: "option_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"text", "status"}),
+			}).Create(&options).Error; err != nil {
+				return err
+			}
+		}
+
+		removedOptions := tx.Model(&model.PollOption{}).
+			Where("post_id = ? AND status = ?", poll.PostID, constant.POLL_OPTION_STATUS_APPROVED)
+		if len(optionIDs) > 0 {
+			removedOptions = removedOptions.Where("option_id NOT IN ?", optionIDs)
+		}
+		var removedOptionIDs []int
+		if err := removedOptions.Pluck("option_id", &removedOptionIDs).Error; err != nil {
+			return err
+		}
+		if len(removedOptionIDs) == 0 {
+			return nil
+		}
+
+		if err := tx.Where("post_id = ? AND option_id IN ?", poll.PostID, removedOptionIDs).
+			Delete(&model.PollVote{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("post_id = ? AND option_id IN ?", poll.PostID, removedOptionIDs).
+			Delete(&model.PollOption{}).Error; err != nil {
+			return err
+		}
+
+		// Ranked ballots may have lost their first preference
+		return tx.Exec(recountPollOptionsSQL, poll.PostID).Error
+	})
+}
+
+// ImportPoll backfills a poll whose votes were still stored in the post's poll_data
+func (r *PollRepositoryImpl) ImportPoll(poll *model.Poll, options []*model.PollOption, votes []*model.PollVote) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(poll).Error; err != nil {
+			return err
+		}
+		if len(options) > 0 {
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&options).Error; err != nil {
+				return err
+			}
+		}
+		if len(votes) > 0 {
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&votes).Error; err != nil {
+				return err
+			}
+		}
+
+		// Recount from the imported rows so concurrent imports cannot double count
+		return tx.Exec(recountPollOptionsSQL, poll.PostID).Error
+	})
+}
+
+// GetPollByPostID returns nil if the poll has no state yet
+func (r *PollRepositoryImpl) GetPollByPostID(postID uint64) (*model.Poll, error) {
+	var poll model.Poll
+	err := r.db.Where("post_id = ?", postID).First(&poll).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &poll, nil
+}
+
+// GetPollOptionsByPostID includes pending write-ins
+func (r *PollRepositoryImpl) GetPollOptionsByPostID(postID uint64) ([]*model.PollOption, error) {
+	var options []*model.PollOption
+	err := r.db.Where("post_id = ?", postID).Order("option_id ASC").Find(&options).Error
+	if err != nil {
+		return nil, err
+	}
+	return options, nil
+}
+
+// AddPollVote records the vote and bumps the option count in one transaction. For
+// single choice polls the user's other votes on the poll are moved away atomically.
+// Returns false if the user had already voted for this option.
+func (r *PollRepositoryImpl) AddPollVote(vote *model.PollVote, singleChoice bool) (bool, error) {
+	added := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockPollVoter(tx, vote.PostID, vote.UserID); err != nil {
+			return err
+		}
+
+		if singleChoice {
+			var previousOptionIDs []int
+			if err := tx.Model(&model.PollVote{}).
+				Where("post_id = ? AND user_id = ? AND option_id <> ?", vote.PostID, vote.UserID, vote.OptionID).
+				Pluck("option_id", &previousOptionIDs).Error; err != nil {
+				return err
+			}
+			if len(previousOptionIDs) > 0 {
+				if err := tx.Where("post_id = ? AND user_id = ? AND option_id IN ?", vote.PostID, vote.UserID, previousOptionIDs).
+					Delete(&model.PollVote{}).Error; err != nil {
+					return err
+				}
+				if err := tx.Model(&model.PollOption{}).
+					Where("post_id = ? AND option_id IN ?", vote.PostID, previousOptionIDs).
+					UpdateColumn("vote_count", gorm.Expr("GREATEST(vote_count - 1, 0)")).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		vote.CreatedAt = time.Now()
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(vote)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		added = true
+		return tx.Model(&model.PollOption{}).
+			Where("post_id = ? AND option_id = ?", vote.PostID, vote.OptionID).
+			UpdateColumn("vote_count", gorm.Expr("vote_count + 1")).Error
+	})
+	return added, err
+}
+
+// RemovePollVote returns false if the user had not voted for this option
+func (r *PollRepositoryImpl) RemovePollVote(postID uint64, optionID int, userID uint64) (bool, error) {
+	removed := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("post_id = ? AND option_id = ? AND user_id = ?", postID, optionID, userID).
+			Delete(&model.PollVote{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		removed = true
+		return tx.Model(&model.PollOption{}).
+			Where("post_id = ? AND option_id = ?", postID, optionID).
+			UpdateColumn("vote_count", gorm.Expr("GREATEST(vote_count - 1, 0)")).Error
+	})
+	return removed, err
+}
+
+// firstPreference returns the option the user ranked highest, 0 if the user has no ballot
+func firstPreference(tx *gorm.DB, postID, userID uint64) (int, error) {
+	var optionIDs []int
+	err := tx.Model(&model.PollVote{}).
+		Where("post_id = ? AND user_id = ?", postID, userID).
+		Order("rank ASC").Limit(1).
+		Pluck("option_id", &optionIDs).Error
+	if err != nil || len(optionIDs) == 0 {
+		return 0, err
+	}
+	return optionIDs[0], nil
+}
+
+// ReplaceRankedBallot swaps the user's whole ballot and moves the first preference count
+func (r *PollRepositoryImpl) ReplaceRankedBallot(postID, userID uint64, ballot []*model.PollVote) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockPollVoter(tx, postID, userID); err != nil {
+			return err
+		}
+
+		previousFirst, err := firstPreference(tx, postID, userID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("post_id = ? AND user_id = ?", postID, userID).Delete(&model.PollVote{}).Error; err != nil {
+			return err
+		}
+		if previousFirst != 0 {
+			if err := tx.Model(&model.PollOption{}).
+				Where("post_id = ? AND option_id = ?", postID, previousFirst).
+				UpdateColumn("vote_count", gorm.Expr("GREATEST(vote_count - 1, 0)")).Error; err != nil {
+				return err
+			}
+		}
+		if len(ballot) == 0 {
+			return nil
+		}
+
+		now := time.Now()
+		newFirst := ballot[0]
+		for _, vote := range ballot {
+			vote.PostID = postID
+			vote.UserID = userID
+			vote.CreatedAt = now
+			if vote.Rank < newFirst.Rank {
+				newFirst = vote
+			}
+		}
+		if err := tx.Create(&ballot).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&model.PollOption{}).
+			Where("post_id = ? AND option_id = ?", postID, newFirst.OptionID).
+			UpdateColumn("vote_count", gorm.Expr("vote_count + 1")).Error
+	})
+}
+
+// RemovePollBallot deletes all of the user's votes on a ranked poll. Returns false if
+// the user had not voted.
+func (r *PollRepositoryImpl) RemovePollBallot(postID, userID uint64) (bool, error) {
+	removed := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockPollVoter(tx, postID, userID); err != nil {
+			return err
+		}
+
+		first, err := firstPreference(tx, postID, userID)
+		if err != nil || first == 0 {
+			return err
+		}
+		if err := tx.Where("post_id = ? AND user_id = ?", postID, userID).Delete(&model.PollVote{}).Error; err != nil {
+			return err
+		}
+
+		removed = true
+		return tx.Model(&model.PollOption{}).
+			Where("post_id = ? AND option_id = ?", postID, first).
+			UpdateColumn("vote_count", gorm.Expr("GREATEST(vote_count - 1, 0)")).Error
+	})
+	return removed, err
+}
+
+// GetPollVotes returns the votes grouped by user, each ballot ordered by rank
+func (r *PollRepositoryImpl) GetPollVotes(postID uint64) ([]*model.PollVote, error) {
+	var votes []*model.PollVote
+	err := r.db.Where("post_id = ?", postID).Order("user_id ASC, rank ASC").Find(&votes).Error
+	if err != nil {
+		return nil, err
+	}
+	return votes, nil
+}
+
+func (r *PollRepositoryImpl) GetPollVoterIDs(postID uint64) ([]uint64, error) {
+	var userIDs []uint64
+	err := r.db.Model(&model.PollVote{}).
+		Where("post_id = ?", postID).
+		Distinct().
+		Pluck("user_id", &userIDs).Error
+	if err != nil {
+		return nil, err
+	}
+	return userIDs, nil
+}
+
+// ClosePoll returns false if the poll was already closed
+func (r *PollRepositoryImpl) ClosePoll(postID, closedBy uint64, closedAt time.Time) (bool, error) {
+	result := r.db.Model(&model.Poll{}).
+		Where("post_id = ? AND closed_at IS NULL", postID).
+		UpdateColumns(map[string]interface{}{
+			"closed_at": closedAt,
+			"closed_by": closedBy,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// GetPollsToFinalize returns closed or expired polls whose voters were not notified yet
+func (r *PollRepositoryImpl) GetPollsToFinalize(now time.Time, limit int) ([]*model.Poll, error) {
+	var polls []*model.Poll
+	err := r.db.
+		Preload("Post").
+		Where("finalized_at IS NULL AND (closed_at IS NOT NULL OR expires_at <= ?)", now).
+		Order("post_id ASC").
+		Limit(limit).
+		Find(&polls).Error
+	if err != nil {
+		return nil, err
+	}
+	return polls, nil
+}
+
+// FinalizePoll marks the poll as finalized, closing expired polls at their expiry
+// time. Returns false if another run finalized it first.
+func (r *PollRepositoryImpl) FinalizePoll(postID uint64, finalizedAt time.Time) (bool, error) {
+	result := r.db.Model(&model.Poll{}).
+		Where("post_id = ? AND finalized_at IS NULL", postID).
+		UpdateColumns(map[string]interface{}{
+			"closed_at":    gorm.Expr("COALESCE(closed_at, expires_at, ?)", finalizedAt),
+			"finalized_at": finalizedAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// CreateWriteInOption stores a pending option with the next free option id of the poll
+func (r *PollRepositoryImpl) CreateWriteInOption(option *model.PollOption) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		lockKey := fmt.Sprintf("poll_options:%d", option.PostID)
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtextextended(?, 0))", lockKey).Error; err != nil {
+			return err
+		}
+
+		var maxOptionID int
+		if err := tx.Model(&model.PollOption{}).
+			Where("post_id = ?", option.PostID).
+			Select("COALESCE(MAX(option_id), 0)").
+			Scan(&maxOptionID).Error; err != nil {
+			return err
+		}
+
+		option.OptionID = maxOptionID + 1
+		option.Status = constant.POLL_OPTION_STATUS_PENDING
+		option.CreatedAt = time.Now()
+		return tx.Create(option).Error
+	})
+}
+
+func (r *PollRepositoryImpl) CountPendingWriteIns(postID, userID uint64) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.PollOption{}).
+		Where("post_id = ? AND suggested_by = ? AND status = ?", postID, userID, constant.POLL_OPTION_STATUS_PENDING).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *PollRepositoryImpl) GetPendingWriteIns(postID uint64) ([]*model.PollOption, error) {
+	var options []*model.PollOption
+	err := r.db.
+		Preload("Suggester").
+		Where("post_id = ? AND status = ?", postID, constant.POLL_OPTION_STATUS_PENDING).
+		Order("created_at ASC").
+		Find(&options).Error
+	if err != nil {
+		return nil, err
+	}
+	return options, nil
+}
+
+// ApproveWriteInOption approves a pending option and appends it to the poll definition
+// in posts.poll_data in the same transaction. Returns false if there is no such
+// pending option.
+func (r *PollRepositoryImpl) ApproveWriteInOption(postID uint64, optionID int) (bool, error) {
+	approved := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var option model.PollOption
+		result := tx.Clauses(clause.Returning{}).
+			Model(&option).
+			Where("post_id = ? AND option_id = ? AND status = ?", postID, optionID, constant.POLL_OPTION_STATUS_PENDING).
+			UpdateColumn("status", constant.POLL_OPTION_STATUS_APPROVED)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		approved = true
+		return tx.Exec(`UPDATE posts SET poll_data = jsonb_set(
+				poll_data::jsonb,
+				'{options}',
+				COALESCE(poll_data::jsonb -> 'options', '[]'::jsonb) || jsonb_build_array(jsonb_build_object('id', ?::int, 'text', ?::text))
+			) WHERE id = ?`, option.OptionID, option.Text, postID).Error
+	})
+	return approved, err
+}
+
+// DeletePendingWriteIn rejects a write-in. Returns false if there is no such pending option.
+func (r *PollRepositoryImpl) DeletePendingWriteIn(postID uint64, optionID int) (bool, error) {
+	result := r.db.
+		Where("post_id = ? AND option_id = ? AND status = ?", postID, optionID, constant.POLL_OPTION_STATUS_PENDING).
+		Delete(&model.PollOption{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/domain/repository"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostAnalyticsRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewPostAnalyticsRepository(db *gorm.DB) repository.PostAnalyticsRepository {
+	return &PostAnalyticsRepositoryImpl{db: db}
+}
+
+// IncrementPostViews adds the buffered counts to the hourly rows in one statement
+func (r *PostAnalyticsRepositoryImpl) IncrementPostViews(stats []*model.PostViewStat) error {
+	if len(stats) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "post_id"}, {Name: "bucket_start"}, {Name: "referral_community_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"views": gorm.Expr("post_view_stats.views + EXCLUDED.views"),
+		}),
+	}).Create(&stats).Error
+}
+
+func (r *PostAnalyticsRepositoryImpl) GetPostTotalViews(postID uint64) (int64, error) {
+	var total int64
+	err := r.db.Model(&model.PostViewStat{}).
+		Where("post_id = ?", postID).
+		Select("COALESCE(SUM(views), 0)").
+		Scan(&total).Error
+	return total, err
+}
+
+// The timelines group by position because date_trunc takes the interval as a
+// parameter, which postgres does not match against a second placeholder
+func (r *PostAnalyticsRepositoryImpl) GetPostViewTimeline(postID uint64, interval string, from time.Time) ([]*model.PostActivityBucket, error) {
+	var buckets []*model.PostActivityBucket
+	err := r.db.Raw(`
+		SELECT date_trunc(?, bucket_start) AS bucket_start, SUM(views) AS count
+		FROM post_view_stats
+		WHERE post_id = ? AND bucket_start >= ?
+		GROUP BY 1
+		ORDER BY 1`, interval, postID, from).
+		Scan(&buckets).Error
+	return buckets, err
+}
+
+// GetPostVoteTimeline buckets the current votes by when they were cast, a changed
+// vote moves to the time of the change
+func (r *PostAnalyticsRepositoryImpl) GetPostVoteTimeline(postID uint64, interval string, from time.Time) ([]*model.PostVoteBucket, error) {
+	var buckets []*model.PostVoteBucket
+	err := r.db.Raw(`
+		SELECT date_trunc(?, voted_at) AS bucket_start,
+			COUNT(*) FILTER (WHERE vote) AS upvotes,
+			COUNT(*) FILTER (WHERE NOT vote) AS downvotes
+		FROM post_votes
+		WHERE post_id = ? AND voted_at >= ?
+		GROUP BY 1
+		ORDER BY 1`, interval, postID, from).
+		Scan(&buckets).Error
+	return buckets, err
+}
+
+func (r *PostAnalyticsRepositoryImpl) GetPostCommentTimeline(postID uint64, interval string, from time.Time) ([]*model.PostActivityBucket, error) {
+	var buckets []*model.PostActivityBucket
+	err := r.db.Raw(`
+		SELECT date_trunc(?, created_at) AS bucket_start, COUNT(*) AS count
+		FROM comments
+		WHERE post_id = ? AND created_at >= ?
+		GROUP BY 1
+		ORDER BY 1`, interval, postID, from).
+		Scan(&buckets).Error
+	return buckets, err
+}
+
+// GetPostReferrals returns views per referring community, most first. Direct views
+// have community 0 and no name.
+func (r *PostAnalyticsRepositoryImpl) GetPostReferrals(postID uint64) ([]*model.PostReferral, error) {
+	var referrals []*model.PostReferral
+	err := r.db.Raw(`
+		SELECT s.referral_community_id AS community_id, c.name AS community_name, SUM(s.views) AS views
+		FROM post_view_stats s
+		LEFT JOIN communities c ON c.id = s.referral_community_id AND c.deleted_at IS NULL
+		WHERE s.post_id = ?
+		GROUP BY s.referral_community_id, c.name
+		ORDER BY views DESC`, postID).
+		Scan(&referrals).Error
+	return referrals, err
+}
+
+func (r *PostAnalyticsRepositoryImpl) CountPostSaves(postID uint64) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.UserSavedPost{}).Where("post_id = ?", postID).Count(&count).Error
+	return count, err
+}
+
+func (r *PostAnalyticsRepositoryImpl) GetPostVoteScore(postID uint64) (int64, error) {
+	var score int64
+	err := r.db.Model(&model.PostVote{}).
+		Where("post_id = ?", postID).
+		Select("COALESCE(SUM(CASE WHEN vote THEN 1 ELSE -1 END), 0)").
+		Scan(&score).Error
+	return score, err
+}
+
+func (r *PostAnalyticsRepositoryImpl) CountPostComments(postID uint64) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.Comment{}).Where("post_id = ? AND deleted_at IS NULL", postID).Count(&count).Error
+	return count, err
+}
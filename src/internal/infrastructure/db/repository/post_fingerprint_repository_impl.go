@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/domain/repository"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PostFingerprintRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewPostFingerprintRepository(db *gorm.DB) repository.PostFingerprintRepository {
+	return &PostFingerprintRepositoryImpl{db: db}
+}
+
+// CreatePostFingerprint saves the fingerprint of a post with the matches found for it.
+// A republished post replaces its previous fingerprint.
+func (r *PostFingerprintRepositoryImpl) CreatePostFingerprint(fingerprint *model.PostFingerprint, matches []*model.PostDuplicateMatch) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("post_id = ?", fingerprint.PostID).Delete(&model.PostFingerprint{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(fingerprint).Error; err != nil {
+			return err
+		}
+		if len(matches) == 0 {
+			return nil
+		}
+		return tx.Create(&matches).Error
+	})
+}
+
+// FindCandidateFingerprints returns fingerprints sharing a band or the canonical URL
+// with the given one, from the same author since authorSince or anyone since
+// globalSince. Candidates still have to be compared by similarity.
+func (r *PostFingerprintRepositoryImpl) FindCandidateFingerprints(fingerprint *model.PostFingerprint, authorSince, globalSince time.Time, limit int) ([]*model.PostFingerprint, error) {
+	var candidates []*model.PostFingerprint
+
+	var conditions []string
+	var args []interface{}
+	if len(fingerprint.Bands) > 0 {
+		conditions = append(conditions, "bands && ?")
+		args = append(args, fingerprint.Bands)
+	}
+	if fingerprint.CanonicalURL != nil {
+		conditions = append(conditions, "canonical_url = ?")
+		args = append(args, *fingerprint.CanonicalURL)
+	}
+	if len(conditions) == 0 {
+		return candidates, nil
+	}
+
+	err := r.db.Where("post_id <> ?", fingerprint.PostID).
+		Where(strings.Join(conditions, " OR "), args...).
+		Where(r.db.Where("created_at >= ?", globalSince).
+			Or("author_id = ? AND created_at >= ?", fingerprint.AuthorID, authorSince)).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&candidates).Error
+	return candidates, err
+}
+
+func (r *PostFingerprintRepositoryImpl) GetDuplicateMatchesByCommunityID(communityID uint64, page, limit int) ([]*model.PostDuplicateMatch, int64, error) {
+	var matches []*model.PostDuplicateMatch
+	var total int64
+
+	offset := (page - 1) * limit
+
+	if err := r.db.Model(&model.PostDuplicateMatch{}).
+		Where("community_id = ?", communityID).
+		Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := r.db.Where("community_id = ?", communityID).
+		Order("created_at DESC, similarity DESC").
+		Offset(offset).
+		Limit(limit).
+		Preload("Post").
+		Preload("Post.Author").
+		Preload("MatchedPost").
+		Preload("MatchedPost.Author").
+		Preload("MatchedPost.Community").
+		Find(&matches).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return matches, total, nil
+}
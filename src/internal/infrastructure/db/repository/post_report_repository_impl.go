@@ -3,6 +3,8 @@ package repository
 import (
 	"social-platform-backend/internal/domain/model"
 	"social-platform-backend/internal/domain/repository"
+	"social-platform-backend/package/constant"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -28,7 +30,7 @@ func (r *PostReportRepositoryImpl) GetPostReportsByCommunityID(communityID uint6
 	if err := r.db.Table("post_reports").
 		Select("COUNT(DISTINCT post_reports.post_id)").
 		Joins("JOIN posts ON post_reports.post_id = posts.id").
-		Where("posts.community_id = ? AND posts.deleted_at IS NULL", communityID).
+		Where("posts.community_id = ? AND posts.deleted_at IS NULL AND post_reports.resolved_at IS NULL", communityID).
 		Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
@@ -37,7 +39,7 @@ func (r *PostReportRepositoryImpl) GetPostReportsByCommunityID(communityID uint6
 	err := r.db.Table("post_reports").
 		Select("post_reports.*").
 		Joins("JOIN posts ON post_reports.post_id = posts.id").
-		Where("posts.community_id = ? AND posts.deleted_at IS NULL", communityID).
+		Where("posts.community_id = ? AND posts.deleted_at IS NULL AND post_reports.resolved_at IS NULL", communityID).
 		Order("post_reports.created_at DESC").
 		Offset(offset).
 		Limit(limit).
@@ -53,23 +55,49 @@ func (r *PostReportRepositoryImpl) GetPostReportsByCommunityID(communityID uint6
 	return reports, total, nil
 }
 
-func (r *PostReportRepositoryImpl) DeletePostReport(id uint64) error {
-
+func (r *PostReportRepositoryImpl) GetPostReportByID(id uint64) (*model.PostReport, error) {
 	var report model.PostReport
-	if err := r.db.Where("id = ?", id).First(&report).Error; err != nil {
-		return err
+	if err := r.db.Where("id = ?", id).Preload("Post").First(&report).Error; err != nil {
+		return nil, err
 	}
+	return &report, nil
+}
 
-	return r.db.Where("post_id = ?", report.PostID).Delete(&model.PostReport{}).Error
+// DismissPostReports dismisses every open report of the post, the reports are kept
+func (r *PostReportRepositoryImpl) DismissPostReports(postID, resolvedBy uint64) error {
+	return r.db.Model(&model.PostReport{}).
+		Where("post_id = ? AND resolved_at IS NULL", postID).
+		Updates(map[string]interface{}{
+			"status":      constant.REPORT_STATUS_DISMISSED,
+			"resolved_at": time.Now(),
+			"resolved_by": resolvedBy,
+			"resolution":  constant.MODERATION_ACTION_DISMISS_REPORTS,
+		}).Error
 }
 
 func (r *PostReportRepositoryImpl) IsUserReportedPost(userID, postID uint64) (bool, error) {
 	var count int64
 	err := r.db.Model(&model.PostReport{}).
-		Where("reporter_id = ? AND post_id = ?", userID, postID).
+		Where("reporter_id = ? AND post_id = ? AND resolved_at IS NULL", userID, postID).
 		Count(&count).Error
 	if err != nil {
 		return false, err
 	}
 	return count > 0, nil
 }
+
+// GetOpenReportsByPostIDs returns the reports of the posts that no moderator acted on yet
+func (r *PostReportRepositoryImpl) GetOpenReportsByPostIDs(postIDs []uint64) ([]*model.PostReport, error) {
+	var reports []*model.PostReport
+	if len(postIDs) == 0 {
+		return reports, nil
+	}
+	err := r.db.Where("post_id IN ? AND resolved_at IS NULL", postIDs).
+		Order("created_at ASC").
+		Preload("Reporter").
+		Find(&reports).Error
+	if err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
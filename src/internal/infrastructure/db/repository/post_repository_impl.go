@@ -1,13 +1,13 @@
 package repository
 
 import (
-	"encoding/json"
 	"fmt"
 	"social-platform-backend/internal/domain/model"
 	"social-platform-backend/internal/domain/repository"
 	"social-platform-backend/internal/interface/dto/request"
 	"social-platform-backend/package/constant"
 	"social-platform-backend/package/util"
+	"strings"
 	"time"
 
 	"github.com/lib/pq"
@@ -23,7 +23,16 @@ func NewPostRepository(db *gorm.DB) repository.PostRepository {
 }
 
 func (r *PostRepositoryImpl) CreatePost(post *model.Post) error {
-	return r.db.Create(post).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(post).Error; err != nil {
+			return err
+		}
+		var mediaURLs []string
+		if post.MediaURLs != nil {
+			mediaURLs = *post.MediaURLs
+		}
+		return markMediaAttached(tx, contentMediaURLs(post.Content, mediaURLs...))
+	})
 }
 
 func (r *PostRepositoryImpl) GetPostByID(id uint64) (*model.Post, error) {
@@ -35,15 +44,31 @@ func (r *PostRepositoryImpl) GetPostByID(id uint64) (*model.Post, error) {
 	return &post, nil
 }
 
+func (r *PostRepositoryImpl) GetPostsByIDs(ids []uint64) ([]*model.Post, error) {
+	var posts []*model.Post
+	if len(ids) == 0 {
+		return posts, nil
+	}
+	err := r.db.Where("id IN ?", ids).
+		Preload("Author").
+		Find(&posts).Error
+	if err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
 func (r *PostRepositoryImpl) GetPostDetailByID(id uint64, userID *uint64) (*model.Post, error) {
 	var post model.Post
 
 	// Select explicit columns to avoid ambiguity
 	selectFields := `posts.id, posts.community_id, posts.author_id, posts.title, posts.type, 
-		posts.content, posts.url, posts.media_urls, posts.poll_data, posts.tags, 
-		posts.status, posts.created_at, posts.updated_at, posts.deleted_at,
+		posts.content, posts.url, posts.media_urls, posts.poll_data, posts.tags, posts.flair, 
+		posts.status, posts.crosspost_parent_id, posts.pin_order, posts.is_announcement, posts.locked_at, posts.lock_reason, posts.created_at, posts.published_at, posts.updated_at, posts.edited_at, posts.deleted_at,
+		(SELECT COUNT(DISTINCT user_id) FROM poll_votes WHERE post_id = posts.id) as poll_voter_count,
 		COALESCE((SELECT SUM(CASE WHEN vote = true THEN 1 WHEN vote = false THEN -1 ELSE 0 END) FROM post_votes WHERE post_id = posts.id), 0) as vote,
-		COUNT(DISTINCT comments.id) as comment_count`
+		COUNT(DISTINCT comments.id) as comment_count,
+		(SELECT COUNT(*) FROM posts cp WHERE cp.crosspost_parent_id = posts.id AND cp.status = 'approved' AND cp.deleted_at IS NULL) as crosspost_count`
 
 	// Add user_vote field if userID exists
 	if userID != nil {
@@ -67,6 +92,9 @@ func (r *PostRepositoryImpl) GetPostDetailByID(id uint64, userID *uint64) (*mode
 	err := query.Group("posts.id").
 		Preload("Community").
 		Preload("Author").
+		Preload("CrosspostParent.Community").
+		Preload("CrosspostParent.Author").
+		Scopes(preloadPostExtras(userID)).
 		First(&post).Error
 	if err != nil {
 		return nil, err
@@ -74,31 +102,29 @@ func (r *PostRepositoryImpl) GetPostDetailByID(id uint64, userID *uint64) (*mode
 	return &post, nil
 }
 
-func (r *PostRepositoryImpl) UpdatePostText(id uint64, updatePost *request.UpdatePostTextRequest) error {
+func (r *PostRepositoryImpl) UpdatePostText(id uint64, updatePost *request.UpdatePostTextRequest, revision *model.PostRevision) error {
 	updates := make(map[string]interface{})
 	if updatePost.Title != nil {
 		updates["title"] = *updatePost.Title
 	}
 	if updatePost.Content != nil {
 		updates["content"] = *updatePost.Content
+		updates["content_text"] = util.HTMLToText(*updatePost.Content)
 	}
 	if updatePost.Tags != nil {
 		updates["tags"] = *updatePost.Tags
 	}
-	if len(updates) > 0 {
-		now := time.Now()
-		updates["updated_at"] = now
-	}
-	return r.db.Model(&model.Post{}).Where("id = ?", id).Updates(updates).Error
+	return r.updatePost(id, updates, revision)
 }
 
-func (r *PostRepositoryImpl) UpdatePostLink(id uint64, updatePost *request.UpdatePostLinkRequest) error {
+func (r *PostRepositoryImpl) UpdatePostLink(id uint64, updatePost *request.UpdatePostLinkRequest, revision *model.PostRevision) error {
 	updates := make(map[string]interface{})
 	if updatePost.Title != nil {
 		updates["title"] = *updatePost.Title
 	}
 	if updatePost.Content != nil {
 		updates["content"] = *updatePost.Content
+		updates["content_text"] = util.HTMLToText(*updatePost.Content)
 	}
 	if updatePost.URL != nil {
 		updates["url"] = *updatePost.URL
@@ -106,20 +132,17 @@ func (r *PostRepositoryImpl) UpdatePostLink(id uint64, updatePost *request.Updat
 	if updatePost.Tags != nil {
 		updates["tags"] = *updatePost.Tags
 	}
-	if len(updates) > 0 {
-		now := time.Now()
-		updates["updated_at"] = now
-	}
-	return r.db.Model(&model.Post{}).Where("id = ?", id).Updates(updates).Error
+	return r.updatePost(id, updates, revision)
 }
 
-func (r *PostRepositoryImpl) UpdatePostMedia(id uint64, updatePost *request.UpdatePostMediaRequest) error {
+func (r *PostRepositoryImpl) UpdatePostMedia(id uint64, updatePost *request.UpdatePostMediaRequest, revision *model.PostRevision) error {
 	updates := make(map[string]interface{})
 	if updatePost.Title != nil {
 		updates["title"] = *updatePost.Title
 	}
 	if updatePost.Content != nil {
 		updates["content"] = *updatePost.Content
+		updates["content_text"] = util.HTMLToText(*updatePost.Content)
 	}
 	if updatePost.MediaURLs != nil {
 		updates["media_urls"] = *updatePost.MediaURLs
@@ -127,20 +150,17 @@ func (r *PostRepositoryImpl) UpdatePostMedia(id uint64, updatePost *request.Upda
 	if updatePost.Tags != nil {
 		updates["tags"] = *updatePost.Tags
 	}
-	if len(updates) > 0 {
-		now := time.Now()
-		updates["updated_at"] = now
-	}
-	return r.db.Model(&model.Post{}).Where("id = ?", id).Updates(updates).Error
+	return r.updatePost(id, updates, revision)
 }
 
-func (r *PostRepositoryImpl) UpdatePostPoll(id uint64, updatePost *request.UpdatePostPollRequest) error {
+func (r *PostRepositoryImpl) UpdatePostPoll(id uint64, updatePost *request.UpdatePostPollRequest, revision *model.PostRevision) error {
 	updates := make(map[string]interface{})
 	if updatePost.Title != nil {
 		updates["title"] = *updatePost.Title
 	}
 	if updatePost.Content != nil {
 		updates["content"] = *updatePost.Content
+		updates["content_text"] = util.HTMLToText(*updatePost.Content)
 	}
 	if updatePost.PollData != nil {
 		updates["poll_data"] = *updatePost.PollData
@@ -148,11 +168,64 @@ func (r *PostRepositoryImpl) UpdatePostPoll(id uint64, updatePost *request.Updat
 	if updatePost.Tags != nil {
 		updates["tags"] = *updatePost.Tags
 	}
-	if len(updates) > 0 {
-		now := time.Now()
-		updates["updated_at"] = now
+	return r.updatePost(id, updates, revision)
+}
+
+// editablePostColumns are compared with an edit, poll_data as jsonb since json has
+// no equality operator
+var editablePostColumns = []struct {
+	column    string
+	condition string
+}{
+	{"title", "title IS DISTINCT FROM ?"},
+	{"content", "content IS DISTINCT FROM ?"},
+	{"url", "url IS DISTINCT FROM ?"},
+	{"media_urls", "media_urls IS DISTINCT FROM ?"},
+	{"poll_data", "poll_data::jsonb IS DISTINCT FROM ?::jsonb"},
+	{"tags", "tags IS DISTINCT FROM ?"},
+}
+
+// updatePost applies an edit and saves the previous version, both or neither. An edit
+// that leaves every field as it is changes nothing, edited_at included.
+func (r *PostRepositoryImpl) updatePost(id uint64, updates map[string]interface{}, revision *model.PostRevision) error {
+	var conditions []string
+	var args []interface{}
+	for _, editable := range editablePostColumns {
+		if value, ok := updates[editable.column]; ok {
+			conditions = append(conditions, editable.condition)
+			args = append(args, value)
+		}
 	}
-	return r.db.Model(&model.Post{}).Where("id = ?", id).Updates(updates).Error
+	if len(conditions) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	updates["updated_at"] = now
+	updates["edited_at"] = now
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Post{}).
+			Where("id = ?", id).
+			Where("("+strings.Join(conditions, " OR ")+")", args...).
+			Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		if err := tx.Create(revision).Error; err != nil {
+			return err
+		}
+		return markMediaAttached(tx, updatedPostMediaURLs(updates))
+	})
+}
+
+// updatedPostMediaURLs returns the URLs of uploads set by the updates of a post
+func updatedPostMediaURLs(updates map[string]interface{}) []string {
+	content, _ := updates["content"].(string)
+	mediaURLs, _ := updates["media_urls"].(pq.StringArray)
+	return contentMediaURLs(content, mediaURLs...)
 }
 
 func (r *PostRepositoryImpl) DeletePost(id uint64) error {
@@ -166,13 +239,6 @@ func (r *PostRepositoryImpl) UpdatePostStatus(id uint64, status string) error {
 	return r.db.Model(&model.Post{}).Where("id = ?", id).Updates(updates).Error
 }
 
-func (r *PostRepositoryImpl) UpdatePollData(postID uint64, pollData *json.RawMessage) error {
-	updates := map[string]interface{}{
-		"poll_data": pollData,
-	}
-	return r.db.Model(&model.Post{}).Where("id = ?", postID).Updates(updates).Error
-}
-
 func (r *PostRepositoryImpl) GetAllPosts(sortBy string, page, limit int, tags []string, userID *uint64) ([]*model.Post, int64, error) {
 	var posts []*model.Post
 	var total int64
@@ -201,8 +267,9 @@ func (r *PostRepositoryImpl) GetAllPosts(sortBy string, page, limit int, tags []
 
 	// Select explicit columns to avoid ambiguity with posts.vote field
 	selectFields := `posts.id, posts.community_id, posts.author_id, posts.title, posts.type, 
-		posts.content, posts.url, posts.media_urls, posts.poll_data, posts.tags, 
-		posts.status, posts.created_at, posts.updated_at, posts.deleted_at,
+		posts.content, posts.url, posts.media_urls, posts.poll_data, posts.tags, posts.flair, 
+		posts.status, posts.crosspost_parent_id, posts.pin_order, posts.is_announcement, posts.locked_at, posts.lock_reason, posts.created_at, posts.published_at, posts.updated_at, posts.edited_at, posts.deleted_at,
+		(SELECT COUNT(DISTINCT user_id) FROM poll_votes WHERE post_id = posts.id) as poll_voter_count,
 		COALESCE((SELECT SUM(CASE WHEN vote = true THEN 1 WHEN vote = false THEN -1 ELSE 0 END) FROM post_votes WHERE post_id = posts.id), 0) as vote,
 		COUNT(DISTINCT comments.id) as comment_count`
 
@@ -232,18 +299,21 @@ func (r *PostRepositoryImpl) GetAllPosts(sortBy string, page, limit int, tags []
 
 	query = query.Group("posts.id").
 		Preload("Community").
-		Preload("Author")
+		Preload("Author").
+		Preload("CrosspostParent.Community").
+		Preload("CrosspostParent.Author").
+		Scopes(preloadPostExtras(userID))
 
 	// Sort method - use aggregate column aliases directly to avoid ambiguity
 	switch sortBy {
 	case constant.SORT_HOT:
-		query = query.Order("comment_count DESC, vote DESC, posts.created_at DESC")
+		query = query.Order("comment_count DESC, vote DESC, COALESCE(posts.published_at, posts.created_at) DESC")
 	case constant.SORT_TOP:
-		query = query.Order("vote DESC, posts.created_at DESC")
+		query = query.Order("vote DESC, COALESCE(posts.published_at, posts.created_at) DESC")
 	case constant.SORT_NEW:
 		fallthrough
 	default:
-		query = query.Order("posts.created_at DESC")
+		query = query.Order("COALESCE(posts.published_at, posts.created_at) DESC")
 	}
 
 	offset := (page - 1) * limit
@@ -273,8 +343,9 @@ func (r *PostRepositoryImpl) GetPostsByCommunityID(communityID uint64, sortBy st
 
 	// Select explicit columns to avoid ambiguity
 	selectFields := `posts.id, posts.community_id, posts.author_id, posts.title, posts.type, 
-		posts.content, posts.url, posts.media_urls, posts.poll_data, posts.tags, 
-		posts.status, posts.created_at, posts.updated_at, posts.deleted_at,
+		posts.content, posts.url, posts.media_urls, posts.poll_data, posts.tags, posts.flair, 
+		posts.status, posts.crosspost_parent_id, posts.pin_order, posts.is_announcement, posts.locked_at, posts.lock_reason, posts.created_at, posts.published_at, posts.updated_at, posts.edited_at, posts.deleted_at,
+		(SELECT COUNT(DISTINCT user_id) FROM poll_votes WHERE post_id = posts.id) as poll_voter_count,
 		COALESCE((SELECT SUM(CASE WHEN vote = true THEN 1 WHEN vote = false THEN -1 ELSE 0 END) FROM post_votes WHERE post_id = posts.id), 0) as vote,
 		COUNT(DISTINCT comments.id) as comment_count`
 
@@ -297,18 +368,24 @@ func (r *PostRepositoryImpl) GetPostsByCommunityID(communityID uint64, sortBy st
 
 	query = query.Group("posts.id").
 		Preload("Community").
-		Preload("Author")
+		Preload("Author").
+		Preload("CrosspostParent.Community").
+		Preload("CrosspostParent.Author").
+		Scopes(preloadPostExtras(userID))
+
+	// Pinned posts always come first, in their pin order
+	query = query.Order("posts.pin_order ASC NULLS LAST")
 
 	// Sort method - use aggregate column aliases directly
 	switch sortBy {
 	case constant.SORT_HOT:
-		query = query.Order("comment_count DESC, vote DESC, posts.created_at DESC")
+		query = query.Order("comment_count DESC, vote DESC, COALESCE(posts.published_at, posts.created_at) DESC")
 	case constant.SORT_TOP:
-		query = query.Order("vote DESC, posts.created_at DESC")
+		query = query.Order("vote DESC, COALESCE(posts.published_at, posts.created_at) DESC")
 	case constant.SORT_NEW:
 		fallthrough
 	default:
-		query = query.Order("posts.created_at DESC")
+		query = query.Order("COALESCE(posts.published_at, posts.created_at) DESC")
 	}
 
 	offset := (page - 1) * limit
@@ -342,7 +419,7 @@ func (r *PostRepositoryImpl) SearchPostsByTitle(title, sortBy string, page, limi
 	}
 
 	for _, p := range patterns {
-		countQuery = countQuery.Where("unaccent(lower(posts.title)) LIKE unaccent(lower(?))", p)
+		countQuery = countQuery.Where("(unaccent(lower(posts.title)) LIKE unaccent(lower(?)) OR unaccent(lower(posts.content_text)) LIKE unaccent(lower(?)))", p, p)
 	}
 	if len(tags) > 0 {
 		countQuery = countQuery.Where("posts.tags && ?", pq.StringArray(tags))
@@ -353,8 +430,9 @@ func (r *PostRepositoryImpl) SearchPostsByTitle(title, sortBy string, page, limi
 
 	// Select explicit columns to avoid ambiguity
 	selectFields := `posts.id, posts.community_id, posts.author_id, posts.title, posts.type, 
-		posts.content, posts.url, posts.media_urls, posts.poll_data, posts.tags, 
-		posts.status, posts.created_at, posts.updated_at, posts.deleted_at,
+		posts.content, posts.url, posts.media_urls, posts.poll_data, posts.tags, posts.flair, 
+		posts.status, posts.crosspost_parent_id, posts.pin_order, posts.is_announcement, posts.locked_at, posts.lock_reason, posts.created_at, posts.published_at, posts.updated_at, posts.edited_at, posts.deleted_at,
+		(SELECT COUNT(DISTINCT user_id) FROM poll_votes WHERE post_id = posts.id) as poll_voter_count,
 		COALESCE((SELECT SUM(CASE WHEN vote = true THEN 1 WHEN vote = false THEN -1 ELSE 0 END) FROM post_votes WHERE post_id = posts.id), 0) as vote,
 		COUNT(DISTINCT comments.id) as comment_count`
 
@@ -378,7 +456,7 @@ func (r *PostRepositoryImpl) SearchPostsByTitle(title, sortBy string, page, limi
 	}
 
 	for _, p := range patterns {
-		query = query.Where("unaccent(lower(posts.title)) LIKE unaccent(lower(?))", p)
+		query = query.Where("(unaccent(lower(posts.title)) LIKE unaccent(lower(?)) OR unaccent(lower(posts.content_text)) LIKE unaccent(lower(?)))", p, p)
 	}
 
 	// Filter by tags
@@ -388,18 +466,21 @@ func (r *PostRepositoryImpl) SearchPostsByTitle(title, sortBy string, page, limi
 
 	query = query.Group("posts.id").
 		Preload("Community").
-		Preload("Author")
+		Preload("Author").
+		Preload("CrosspostParent.Community").
+		Preload("CrosspostParent.Author").
+		Scopes(preloadPostExtras(userID))
 
 	// Sort method - use aggregate column aliases directly
 	switch sortBy {
 	case constant.SORT_HOT:
-		query = query.Order("comment_count DESC, vote DESC, posts.created_at DESC")
+		query = query.Order("comment_count DESC, vote DESC, COALESCE(posts.published_at, posts.created_at) DESC")
 	case constant.SORT_TOP:
-		query = query.Order("vote DESC, posts.created_at DESC")
+		query = query.Order("vote DESC, COALESCE(posts.published_at, posts.created_at) DESC")
 	case constant.SORT_NEW:
 		fallthrough
 	default:
-		query = query.Order("posts.created_at DESC")
+		query = query.Order("COALESCE(posts.published_at, posts.created_at) DESC")
 	}
 
 	if err := query.Offset(offset).Limit(limit).Find(&posts).Error; err != nil {
@@ -415,15 +496,16 @@ func (r *PostRepositoryImpl) GetPostsByUserID(userID uint64, sortBy string, page
 
 	// Count total posts by user
 	if err := r.db.Model(&model.Post{}).
-		Where("author_id = ? AND deleted_at IS NULL", userID).
+		Where("author_id = ? AND deleted_at IS NULL AND status NOT IN ?", userID, constant.UNPUBLISHED_POST_STATUSES).
 		Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	query := r.db.Table("posts").
 		Select(`posts.id, posts.community_id, posts.author_id, posts.title, posts.type, 
-			posts.content, posts.url, posts.media_urls, posts.poll_data, posts.tags, 
-			posts.status, posts.created_at, posts.updated_at, posts.deleted_at,
+			posts.content, posts.url, posts.media_urls, posts.poll_data, posts.tags, posts.flair, 
+			posts.status, posts.crosspost_parent_id, posts.pin_order, posts.is_announcement, posts.locked_at, posts.lock_reason, posts.created_at, posts.published_at, posts.updated_at, posts.edited_at, posts.deleted_at,
+			(SELECT COUNT(DISTINCT user_id) FROM poll_votes WHERE post_id = posts.id) as poll_voter_count,
 			COALESCE(SUM(CASE WHEN post_votes.vote = true THEN 1 WHEN post_votes.vote = false THEN -1 ELSE 0 END), 0) as vote,
 			COUNT(DISTINCT comments.id) as comment_count`).
 		Joins("LEFT JOIN post_votes ON posts.id = post_votes.post_id").
@@ -431,17 +513,20 @@ func (r *PostRepositoryImpl) GetPostsByUserID(userID uint64, sortBy string, page
 		Where("posts.author_id = ? AND posts.status = ? AND posts.deleted_at IS NULL", userID, constant.POST_STATUS_APPROVED).
 		Group("posts.id").
 		Preload("Community").
-		Preload("Author")
+		Preload("Author").
+		Preload("CrosspostParent.Community").
+		Preload("CrosspostParent.Author").
+		Scopes(preloadPostExtras(nil))
 
 	switch sortBy {
 	case constant.SORT_TOP:
-		query = query.Order("vote DESC, posts.created_at DESC")
+		query = query.Order("vote DESC, COALESCE(posts.published_at, posts.created_at) DESC")
 	case constant.SORT_HOT:
-		query = query.Order(`comment_count DESC, "vote" DESC, posts.created_at DESC`)
+		query = query.Order(`comment_count DESC, "vote" DESC, COALESCE(posts.published_at, posts.created_at) DESC`)
 	case constant.SORT_NEW:
 		fallthrough
 	default:
-		query = query.Order("posts.created_at DESC")
+		query = query.Order("COALESCE(posts.published_at, posts.created_at) DESC")
 	}
 
 	offset := (page - 1) * limit
@@ -456,17 +541,19 @@ func (r *PostRepositoryImpl) GetCommunityPostsForModerator(communityID uint64, s
 	var posts []*model.Post
 	var total int64
 
-	countQuery := r.db.Model(&model.Post{}).Where("community_id = ? AND deleted_at IS NULL", communityID)
+	countQuery := r.db.Model(&model.Post{}).
+		Where("community_id = ? AND deleted_at IS NULL AND status NOT IN ?", communityID, constant.UNPUBLISHED_POST_STATUSES)
 
 	query := r.db.Table("posts").
 		Select(`posts.id, posts.community_id, posts.author_id, posts.title, posts.type, 
-			posts.content, posts.url, posts.media_urls, posts.poll_data, posts.tags, 
-			posts.status, posts.created_at, posts.updated_at, posts.deleted_at,
+			posts.content, posts.url, posts.media_urls, posts.poll_data, posts.tags, posts.flair, 
+			posts.status, posts.crosspost_parent_id, posts.pin_order, posts.is_announcement, posts.locked_at, posts.lock_reason, posts.created_at, posts.published_at, posts.updated_at, posts.edited_at, posts.deleted_at,
+			(SELECT COUNT(DISTINCT user_id) FROM poll_votes WHERE post_id = posts.id) as poll_voter_count,
 			COALESCE(SUM(CASE WHEN post_votes.vote = true THEN 1 WHEN post_votes.vote = false THEN -1 ELSE 0 END), 0) as vote,
 			COUNT(DISTINCT comments.id) as comment_count`).
 		Joins("LEFT JOIN post_votes ON posts.id = post_votes.post_id").
 		Joins("LEFT JOIN comments ON posts.id = comments.post_id AND comments.deleted_at IS NULL").
-		Where("posts.community_id = ? AND posts.deleted_at IS NULL", communityID)
+		Where("posts.community_id = ? AND posts.deleted_at IS NULL AND posts.status NOT IN ?", communityID, constant.UNPUBLISHED_POST_STATUSES)
 
 	if status != "" {
 		countQuery = countQuery.Where("status = ?", status)
@@ -486,7 +573,10 @@ func (r *PostRepositoryImpl) GetCommunityPostsForModerator(communityID uint64, s
 		return nil, 0, err
 	}
 
-	query = query.Group("posts.id").Preload("Author").Order("posts.created_at DESC")
+	query = query.Group("posts.id").
+		Preload("Author").
+		Scopes(preloadPostExtras(nil)).
+		Order("COALESCE(posts.published_at, posts.created_at) DESC")
 
 	offset := (page - 1) * limit
 	if err := query.Offset(offset).Limit(limit).Find(&posts).Error; err != nil {
@@ -501,9 +591,238 @@ func (r *PostRepositoryImpl) GetPostsLastWeekCount(communityID uint64) (int64, e
 	sevenDaysAgo := time.Now().AddDate(0, 0, -7)
 
 	err := r.db.Model(&model.Post{}).
-		Where("community_id = ? AND status = ? AND created_at >= ?",
+		Where("community_id = ? AND status = ? AND COALESCE(published_at, created_at) >= ?",
 			communityID, constant.POST_STATUS_APPROVED, sevenDaysAgo).
 		Count(&count).Error
 
 	return count, err
 }
+
+func (r *PostRepositoryImpl) GetDraftPostsByUserID(userID uint64, page, limit int) ([]*model.Post, int64, error) {
+	var posts []*model.Post
+	var total int64
+
+	query := r.db.Model(&model.Post{}).
+		Where("author_id = ? AND status IN ? AND deleted_at IS NULL", userID, constant.UNPUBLISHED_POST_STATUSES)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Preload("Community").
+		Order("COALESCE(updated_at, created_at) DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&posts).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return posts, total, nil
+}
+
+func (r *PostRepositoryImpl) UpdateDraftPost(id uint64, draft *request.SaveDraftRequest) error {
+	updates := make(map[string]interface{})
+	if draft.Title != nil {
+		updates["title"] = *draft.Title
+	}
+	if draft.Content != nil {
+		updates["content"] = *draft.Content
+		updates["content_text"] = util.HTMLToText(*draft.Content)
+	}
+	if draft.URL != nil {
+		updates["url"] = *draft.URL
+	}
+	if draft.MediaURLs != nil {
+		updates["media_urls"] = *draft.MediaURLs
+	}
+	if draft.PollData != nil {
+		updates["poll_data"] = *draft.PollData
+	}
+	if draft.Tags != nil {
+		updates["tags"] = *draft.Tags
+	}
+	if draft.PublishAt != nil {
+		updates["publish_at"] = *draft.PublishAt
+	}
+	if draft.ClearPublishAt {
+		// Nothing would publish a scheduled post without a publishAt
+		updates["publish_at"] = nil
+		updates["status"] = constant.POST_STATUS_DRAFT
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+	updates["updated_at"] = time.Now()
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Post{}).
+			Where("id = ? AND status IN ?", id, constant.UNPUBLISHED_POST_STATUSES).
+			Updates(updates)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return markMediaAttached(tx, updatedPostMediaURLs(updates))
+	})
+}
+
+// PublishPost moves a post out of one of fromStatuses. It reports false when the post
+// was already moved by someone else (e.g. another scheduler instance).
+func (r *PostRepositoryImpl) PublishPost(id uint64, fromStatuses []string, status string, publishedAt time.Time) (bool, error) {
+	updates := map[string]interface{}{
+		"status":       status,
+		"published_at": publishedAt,
+	}
+	result := r.db.Model(&model.Post{}).
+		Where("id = ? AND status IN ? AND deleted_at IS NULL", id, fromStatuses).
+		Updates(updates)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *PostRepositoryImpl) GetDueScheduledPosts(now time.Time, limit int) ([]*model.Post, error) {
+	var posts []*model.Post
+	err := r.db.Where("status = ? AND publish_at <= ? AND deleted_at IS NULL", constant.POST_STATUS_SCHEDULED, now).
+		Order("publish_at ASC").
+		Limit(limit).
+		Find(&posts).Error
+	if err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+func (r *PostRepositoryImpl) IsCrosspostedToCommunity(parentID, communityID uint64) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.Post{}).
+		Where("crosspost_parent_id = ? AND community_id = ? AND deleted_at IS NULL", parentID, communityID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *PostRepositoryImpl) GetCrosspostsByParentID(parentID uint64, userID *uint64) ([]*model.Post, error) {
+	var posts []*model.Post
+
+	query := r.db.Table("posts").
+		Select("posts.*").
+		Joins("INNER JOIN communities ON posts.community_id = communities.id AND communities.deleted_at IS NULL").
+		Where("posts.crosspost_parent_id = ? AND posts.status = ? AND posts.deleted_at IS NULL", parentID, constant.POST_STATUS_APPROVED)
+
+	if userID == nil {
+		// only show posts from public communities
+		query = query.Where("communities.is_private = ?", false)
+	} else {
+		// show posts from public communities OR private communities user has joined
+		query = query.Joins("LEFT JOIN subscriptions ON posts.community_id = subscriptions.community_id AND subscriptions.user_id = ? AND subscriptions.status = ?", *userID, constant.SUBSCRIPTION_STATUS_APPROVED).
+			Where("communities.is_private = ? OR (communities.is_private = ? AND subscriptions.user_id IS NOT NULL)", false, true)
+	}
+
+	err := query.Order("COALESCE(posts.published_at, posts.created_at) DESC").
+		Preload("Community").
+		Preload("Author").
+		Preload("LinkPreview").
+		Find(&posts).Error
+	if err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// PinPost pins a post or changes the position of a pinned one. The pins of a community
+// are serialized by an advisory lock, so concurrent pins cannot pass the limit. A new pin
+// without a position goes to the bottom, a re-pin without one keeps its position.
+func (r *PostRepositoryImpl) PinPost(id uint64, pinOrder *int, isAnnouncement bool) (bool, error) {
+	pinned := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var post model.Post
+		if err := tx.Select("id", "community_id").Where("id = ?", id).First(&post).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtextextended(?, 0))", fmt.Sprintf("post_pins:%d", post.CommunityID)).Error; err != nil {
+			return err
+		}
+		// Read again under the lock, a concurrent pin may have pinned it
+		if err := tx.Select("id", "pin_order").Where("id = ?", id).First(&post).Error; err != nil {
+			return err
+		}
+
+		if post.PinOrder == nil {
+			var pinnedCount int64
+			if err := tx.Model(&model.Post{}).
+				Where("community_id = ? AND status = ? AND pin_order IS NOT NULL", post.CommunityID, constant.POST_STATUS_APPROVED).
+				Count(&pinnedCount).Error; err != nil {
+				return err
+			}
+			if pinnedCount >= constant.MAX_PINNED_POSTS_PER_COMMUNITY {
+				return nil
+			}
+			if pinOrder == nil {
+				nextOrder := int(pinnedCount) + 1
+				pinOrder = &nextOrder
+			}
+		} else if pinOrder == nil {
+			pinOrder = post.PinOrder
+		}
+
+		pinned = true
+		// UpdateColumns keeps updated_at untouched, pinning is not an edit of the post
+		return tx.Model(&model.Post{}).Where("id = ?", id).UpdateColumns(map[string]interface{}{
+			"pin_order":       *pinOrder,
+			"is_announcement": isAnnouncement,
+		}).Error
+	})
+	return pinned, err
+}
+
+func (r *PostRepositoryImpl) UpdatePostPin(id uint64, pinOrder *int, isAnnouncement bool) error {
+	updates := map[string]interface{}{
+		"pin_order":       pinOrder,
+		"is_announcement": isAnnouncement,
+	}
+	// UpdateColumns keeps updated_at untouched, pinning is not an edit of the post
+	return r.db.Model(&model.Post{}).Where("id = ?", id).UpdateColumns(updates).Error
+}
+
+func (r *PostRepositoryImpl) UpdatePostLock(id uint64, lockedAt *time.Time, lockReason *string) error {
+	updates := map[string]interface{}{
+		"locked_at":   lockedAt,
+		"lock_reason": lockReason,
+	}
+	return r.db.Model(&model.Post{}).Where("id = ?", id).UpdateColumns(updates).Error
+}
+
+func (r *PostRepositoryImpl) UpdatePostFlair(id uint64, flair *string) error {
+	return r.db.Model(&model.Post{}).Where("id = ?", id).UpdateColumn("flair", flair).Error
+}
+
+func (r *PostRepositoryImpl) GetRecentPostsByCommunityID(communityID uint64, limit int) ([]*model.Post, error) {
+	var posts []*model.Post
+	err := r.db.Where("community_id = ? AND status NOT IN ?", communityID, constant.UNPUBLISHED_POST_STATUSES).
+		Preload("Author").
+		Order("COALESCE(published_at, created_at) DESC").
+		Limit(limit).
+		Find(&posts).Error
+	if err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// preloadPostExtras loads the link preview, the reactions and mentions, the vote counts of poll
+// options and, for a signed-in user, only that user's own poll votes
+func preloadPostExtras(userID *uint64) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db = db.Preload("LinkPreview").Preload("Poll").Preload("PollOptions", func(tx *gorm.DB) *gorm.DB {
+			return tx.Where("status = ?", constant.POLL_OPTION_STATUS_APPROVED).Order("option_id ASC")
+		}).Scopes(preloadReactionCounts(userID), preloadMentions)
+		if userID != nil {
+			db = db.Preload("UserPollVotes", func(tx *gorm.DB) *gorm.DB {
+				return tx.Where("user_id = ?", *userID).Order("rank ASC, option_id ASC")
+			})
+		}
+		return db
+	}
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/domain/repository"
+
+	"gorm.io/gorm"
+)
+
+type PostRevisionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewPostRevisionRepository(db *gorm.DB) repository.PostRevisionRepository {
+	return &PostRevisionRepositoryImpl{db: db}
+}
+
+// Oldest first
+func (r *PostRevisionRepositoryImpl) GetPostRevisionsByPostID(postID uint64) ([]*model.PostRevision, error) {
+	var revisions []*model.PostRevision
+	err := r.db.Where("post_id = ?", postID).
+		Order("created_at ASC, id ASC").
+		Preload("Editor").
+		Find(&revisions).Error
+	if err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/domain/repository"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReactionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewReactionRepository(db *gorm.DB) repository.ReactionRepository {
+	return &ReactionRepositoryImpl{db: db}
+}
+
+func (r *ReactionRepositoryImpl) ToggleReaction(reaction *model.Reaction) (bool, error) {
+	added := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("target_type = ? AND target_id = ? AND user_id = ? AND emoji = ?",
+			reaction.TargetType, reaction.TargetID, reaction.UserID, reaction.Emoji).
+			Delete(&model.Reaction{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			return nil
+		}
+
+		// A concurrent toggle may have inserted the same reaction first, then nothing is added
+		result = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(reaction)
+		if result.Error != nil {
+			return result.Error
+		}
+		added = result.RowsAffected > 0
+		return nil
+	})
+	return added, err
+}
+
+func (r *ReactionRepositoryImpl) GetReactionCounts(targetType string, targetID uint64, userID *uint64) ([]*model.ReactionCount, error) {
+	var counts []*model.ReactionCount
+	err := selectReactionCounts(r.db.Model(&model.ReactionCount{}), userID).
+		Where("target_type = ? AND target_id = ?", targetType, targetID).
+		Find(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// preloadReactionCounts loads the reaction counts per emoji of posts, comments or
+// messages and whether the signed-in user used each emoji
+func preloadReactionCounts(userID *uint64) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Preload("ReactionCounts", func(tx *gorm.DB) *gorm.DB {
+			return selectReactionCounts(tx, userID)
+		})
+	}
+}
+
+// Emojis are listed in the order they were first used on the target
+func selectReactionCounts(db *gorm.DB, userID *uint64) *gorm.DB {
+	if userID != nil {
+		db = db.Select("target_type, target_id, emoji, COUNT(*) AS count, bool_or(user_id = ?) AS reacted", *userID)
+	} else {
+		db = db.Select("target_type, target_id, emoji, COUNT(*) AS count, false AS reacted")
+	}
+	return db.Group("target_type, target_id, emoji").Order("MIN(created_at) ASC, emoji ASC")
+}
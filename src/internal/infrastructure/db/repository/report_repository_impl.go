@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"errors"
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/domain/repository"
+	"social-platform-backend/package/constant"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ReportRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewReportRepository(db *gorm.DB) repository.ReportRepository {
+	return &ReportRepositoryImpl{db: db}
+}
+
+func (r *ReportRepositoryImpl) GetReporterStats(reporterID uint64, since time.Time) (int64, int64, error) {
+	var stats struct {
+		Resolved  int64 `gorm:"column:resolved"`
+		Dismissed int64 `gorm:"column:dismissed"`
+	}
+	err := r.db.Raw(`
+		SELECT COUNT(*) AS resolved, COUNT(*) FILTER (WHERE status = @dismissed) AS dismissed
+		FROM (
+			SELECT status FROM post_reports
+			WHERE reporter_id = @reporterID AND resolved_at >= @since
+			UNION ALL
+			SELECT status FROM comment_reports
+			WHERE reporter_id = @reporterID AND resolved_at >= @since
+		) AS reports`, map[string]interface{}{
+		"reporterID": reporterID,
+		"since":      since,
+		"dismissed":  constant.REPORT_STATUS_DISMISSED,
+	}).Scan(&stats).Error
+	if err != nil {
+		return 0, 0, err
+	}
+	return stats.Resolved, stats.Dismissed, nil
+}
+
+func (r *ReportRepositoryImpl) CreateEscalation(escalation *model.ReportEscalation) error {
+	return r.db.Create(escalation).Error
+}
+
+// GetOpenEscalation returns nil if the content has no open escalation
+func (r *ReportRepositoryImpl) GetOpenEscalation(targetType string, targetID uint64) (*model.ReportEscalation, error) {
+	var escalation model.ReportEscalation
+	err := r.db.Where("target_type = ? AND target_id = ? AND status = ?", targetType, targetID, constant.REPORT_STATUS_OPEN).
+		First(&escalation).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &escalation, nil
+}
+
+func (r *ReportRepositoryImpl) GetEscalationByID(id uint64) (*model.ReportEscalation, error) {
+	var escalation model.ReportEscalation
+	if err := r.db.Where("id = ?", id).First(&escalation).Error; err != nil {
+		return nil, err
+	}
+	return &escalation, nil
+}
+
+// GetEscalations lists escalations oldest first so the longest waiting are handled first
+func (r *ReportRepositoryImpl) GetEscalations(status string, page, limit int) ([]*model.ReportEscalation, int64, error) {
+	var escalations []*model.ReportEscalation
+	var total int64
+
+	filter := func(db *gorm.DB) *gorm.DB {
+		if status != "" {
+			return db.Where("status = ?", status)
+		}
+		return db
+	}
+
+	if err := r.db.Model(&model.ReportEscalation{}).Scopes(filter).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := r.db.Scopes(filter).
+		Order("created_at ASC, id ASC").
+		Offset(offset).
+		Limit(limit).
+		Preload("Community").
+		Preload("Escalator").
+		Find(&escalations).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return escalations, total, nil
+}
+
+func (r *ReportRepositoryImpl) ResolveEscalation(escalation *model.ReportEscalation) (bool, error) {
+	resolved := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// A moderator may have closed it from the queue in the meantime
+		result := tx.Model(&model.ReportEscalation{}).
+			Where("id = ? AND status = ?", escalation.ID, constant.REPORT_STATUS_OPEN).
+			Updates(map[string]interface{}{
+				"status":            escalation.Status,
+				"resolved_at":       escalation.ResolvedAt,
+				"resolved_by":       escalation.ResolvedBy,
+				"resolution":        escalation.Resolution,
+				"resolution_reason": escalation.ResolutionReason,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		resolved = true
+
+		reportUpdates := map[string]interface{}{
+			"status":            escalation.Status,
+			"resolved_at":       escalation.ResolvedAt,
+			"resolved_by":       escalation.ResolvedBy,
+			"resolution":        escalation.Resolution,
+			"resolution_reason": escalation.ResolutionReason,
+		}
+		if escalation.TargetType == constant.MODERATION_TARGET_POST {
+			return tx.Model(&model.PostReport{}).
+				Where("post_id = ? AND resolved_at IS NULL", escalation.TargetID).
+				Updates(reportUpdates).Error
+		}
+		return tx.Model(&model.CommentReport{}).
+			Where("comment_id = ? AND resolved_at IS NULL", escalation.TargetID).
+			Updates(reportUpdates).Error
+	})
+	return resolved, err
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/domain/repository"
+	"social-platform-backend/package/constant"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SavedPostCollectionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewSavedPostCollectionRepository(db *gorm.DB) repository.SavedPostCollectionRepository {
+	return &SavedPostCollectionRepositoryImpl{db: db}
+}
+
+const savedPostCollectionSelect = `saved_post_collections.*,
+	(SELECT COUNT(*) FROM saved_post_collection_items WHERE collection_id = saved_post_collections.id) as item_count`
+
+func (r *SavedPostCollectionRepositoryImpl) CreateCollection(collection *model.SavedPostCollection) error {
+	return r.db.Create(collection).Error
+}
+
+func (r *SavedPostCollectionRepositoryImpl) GetCollectionByID(id uint64) (*model.SavedPostCollection, error) {
+	var collection model.SavedPostCollection
+	err := r.db.Table("saved_post_collections").
+		Select(savedPostCollectionSelect).
+		Where("id = ?", id).
+		First(&collection).Error
+	if err != nil {
+		return nil, err
+	}
+	return &collection, nil
+}
+
+func (r *SavedPostCollectionRepositoryImpl) GetCollectionByShareToken(shareToken string) (*model.SavedPostCollection, error) {
+	var collection model.SavedPostCollection
+	err := r.db.Table("saved_post_collections").
+		Select(savedPostCollectionSelect).
+		Where("share_token = ? AND is_public = ?", shareToken, true).
+		Preload("User").
+		First(&collection).Error
+	if err != nil {
+		return nil, err
+	}
+	return &collection, nil
+}
+
+func (r *SavedPostCollectionRepositoryImpl) GetUserCollections(userID uint64) ([]*model.SavedPostCollection, error) {
+	var collections []*model.SavedPostCollection
+	err := r.db.Table("saved_post_collections").
+		Select(savedPostCollectionSelect).
+		Where("user_id = ?", userID).
+		Order("position ASC, id ASC").
+		Find(&collections).Error
+	if err != nil {
+		return nil, err
+	}
+	return collections, nil
+}
+
+func (r *SavedPostCollectionRepositoryImpl) CountUserCollections(userID uint64) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.SavedPostCollection{}).
+		Where("user_id = ?", userID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *SavedPostCollectionRepositoryImpl) UpdateCollection(id uint64, name string, isPublic bool, shareToken *string) error {
+	updates := map[string]interface{}{
+		"name":        name,
+		"is_public":   isPublic,
+		"share_token": shareToken,
+	}
+	return r.db.Model(&model.SavedPostCollection{}).Where("id = ?", id).Updates(updates).Error
+}
+
+func (r *SavedPostCollectionRepositoryImpl) ReorderCollections(userID uint64, collectionIDs []uint64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for position, collectionID := range collectionIDs {
+			if err := tx.Model(&model.SavedPostCollection{}).
+				Where("id = ? AND user_id = ?", collectionID, userID).
+				Update("position", position).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *SavedPostCollectionRepositoryImpl) DeleteCollection(id uint64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Saved posts stay saved, only their membership in the collection is removed
+		if err := tx.Where("collection_id = ?", id).Delete(&model.SavedPostCollectionItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.SavedPostCollection{}, id).Error
+	})
+}
+
+func (r *SavedPostCollectionRepositoryImpl) AddCollectionItem(item *model.SavedPostCollectionItem) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(item).Error
+}
+
+func (r *SavedPostCollectionRepositoryImpl) RemoveCollectionItem(collectionID, postID uint64) error {
+	return r.db.Where("collection_id = ? AND post_id = ?", collectionID, postID).
+		Delete(&model.SavedPostCollectionItem{}).Error
+}
+
+// GetCollectionPosts lists the posts of a shared collection. Anyone with the link may
+// read it, so only approved posts of public communities are listed.
+func (r *SavedPostCollectionRepositoryImpl) GetCollectionPosts(collectionID uint64, page, limit int) ([]*model.UserSavedPost, int64, error) {
+	var savedPosts []*model.UserSavedPost
+	var total int64
+
+	query := r.db.Model(&model.UserSavedPost{}).
+		Joins("INNER JOIN saved_post_collection_items ON saved_post_collection_items.user_id = user_saved_posts.user_id AND saved_post_collection_items.post_id = user_saved_posts.post_id").
+		Joins("INNER JOIN posts ON posts.id = user_saved_posts.post_id AND posts.deleted_at IS NULL").
+		Joins("INNER JOIN communities ON communities.id = posts.community_id AND communities.deleted_at IS NULL").
+		Where("saved_post_collection_items.collection_id = ? AND posts.status = ? AND communities.is_private = ?",
+			collectionID, constant.POST_STATUS_APPROVED, false)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Select("user_saved_posts.*").
+		Order("saved_post_collection_items.created_at DESC").
+		Preload("Community").
+		Offset(offset).
+		Limit(limit).
+		Find(&savedPosts).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return savedPosts, total, nil
+}
@@ -190,3 +190,25 @@ func (r *SubscriptionRepositoryImpl) UpdateSubscriptionStatus(userID, communityI
 		Where("user_id = ? AND community_id = ?", userID, communityID).
 		Update("status", status).Error
 }
+
+func (r *SubscriptionRepositoryImpl) GetApprovedSubscriberIDs(communityID uint64) ([]uint64, error) {
+	var userIDs []uint64
+	err := r.db.Model(&model.Subscription{}).
+		Where("community_id = ? AND status = ?", communityID, constant.SUBSCRIPTION_STATUS_APPROVED).
+		Pluck("user_id", &userIDs).Error
+	if err != nil {
+		return nil, err
+	}
+	return userIDs, nil
+}
+
+func (r *SubscriptionRepositoryImpl) FilterApprovedSubscriberIDs(communityID uint64, userIDs []uint64) ([]uint64, error) {
+	var subscriberIDs []uint64
+	if len(userIDs) == 0 {
+		return subscriberIDs, nil
+	}
+	err := r.db.Model(&model.Subscription{}).
+		Where("community_id = ? AND status = ? AND user_id IN ?", communityID, constant.SUBSCRIPTION_STATUS_APPROVED, userIDs).
+		Pluck("user_id", &subscriberIDs).Error
+	return subscriberIDs, err
+}
@@ -4,6 +4,8 @@ import (
 	"social-platform-backend/internal/domain/model"
 	"social-platform-backend/internal/domain/repository"
 	"social-platform-backend/internal/interface/dto/request"
+	"social-platform-backend/package/constant"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -95,7 +97,12 @@ func (r *UserRepositoryImpl) UpdateUserProfile(id uint64, updateUser *request.Up
 	if updateUser.CoverImage != nil {
 		updates["cover_image"] = *updateUser.CoverImage
 	}
-	return r.db.Model(&model.User{}).Where("id = ?", id).Updates(updates).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.User{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+			return err
+		}
+		return markMediaAttached(tx, profileImageURLs(updateUser.Avatar, updateUser.CoverImage))
+	})
 }
 
 func (r *UserRepositoryImpl) UpdateAuthProvider(userID uint64, provider string) error {
@@ -125,7 +132,7 @@ func (r *UserRepositoryImpl) GetLatestUserBadge(userID uint64) (*model.UserBadge
 func (r *UserRepositoryImpl) GetUserPostCount(userID uint64) (uint64, error) {
 	var count int64
 	err := r.db.Model(&model.Post{}).
-		Where("author_id = ? AND deleted_at IS NULL", userID).
+		Where("author_id = ? AND deleted_at IS NULL AND status NOT IN ?", userID, constant.UNPUBLISHED_POST_STATUSES).
 		Count(&count).Error
 	return uint64(count), err
 }
@@ -151,7 +158,7 @@ func (r *UserRepositoryImpl) GetUserBadgeHistory(userID uint64) ([]*model.UserBa
 	return userBadges, nil
 }
 
-func (r *UserRepositoryImpl) SearchUsers(searchTerm string, page, limit int) ([]*model.User, int64, error) {
+func (r *UserRepositoryImpl) SearchUsers(searchTerm string, communityID *uint64, page, limit int) ([]*model.User, int64, error) {
 	var users []*model.User
 	var total int64
 
@@ -165,6 +172,13 @@ func (r *UserRepositoryImpl) SearchUsers(searchTerm string, page, limit int) ([]
 		countQuery = countQuery.Where("LOWER(username) LIKE LOWER(?)", searchPattern)
 	}
 
+	if communityID != nil {
+		memberIDs := r.db.Model(&model.Subscription{}).Select("user_id").
+			Where("community_id = ? AND status = ?", *communityID, constant.SUBSCRIPTION_STATUS_APPROVED)
+		query = query.Where("id IN (?)", memberIDs)
+		countQuery = countQuery.Where("id IN (?)", memberIDs)
+	}
+
 	// Count total
 	if err := countQuery.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -178,3 +192,33 @@ func (r *UserRepositoryImpl) SearchUsers(searchTerm string, page, limit int) ([]
 
 	return users, total, nil
 }
+
+func (r *UserRepositoryImpl) GetUsersByUsernames(usernames []string) ([]*model.User, error) {
+	var users []*model.User
+	if len(usernames) == 0 {
+		return users, nil
+	}
+
+	lowered := make([]string, len(usernames))
+	for i, username := range usernames {
+		lowered[i] = strings.ToLower(username)
+	}
+
+	err := r.db.Where("LOWER(username) IN ? AND is_active = ?", lowered, true).Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *UserRepositoryImpl) GetUsersByIDs(ids []uint64) ([]*model.User, error) {
+	var users []*model.User
+	if len(ids) == 0 {
+		return users, nil
+	}
+	err := r.db.Where("id IN ?", ids).Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/package/constant"
 	"time"
 
 	"gorm.io/gorm"
@@ -19,6 +20,27 @@ func (r *UserRestrictionRepositoryImpl) CreateRestriction(restriction *model.Use
 	return r.db.Create(restriction).Error
 }
 
+func (r *UserRestrictionRepositoryImpl) GetRestrictionByID(id uint64) (*model.UserRestriction, error) {
+	var restriction model.UserRestriction
+	if err := r.db.Where("id = ?", id).First(&restriction).Error; err != nil {
+		return nil, err
+	}
+	return &restriction, nil
+}
+
+func (r *UserRestrictionRepositoryImpl) FilterBannedUserIDs(communityID uint64, userIDs []uint64) ([]uint64, error) {
+	var bannedIDs []uint64
+	if len(userIDs) == 0 {
+		return bannedIDs, nil
+	}
+	err := r.db.Model(&model.UserRestriction{}).
+		Distinct("user_id").
+		Where("community_id = ? AND user_id IN ? AND restriction_type IN ? AND (expires_at IS NULL OR expires_at > ?)",
+			communityID, userIDs, []string{constant.RESTRICTION_TEMPORARY_BAN, constant.RESTRICTION_PERMANENT_BAN}, time.Now()).
+		Pluck("user_id", &bannedIDs).Error
+	return bannedIDs, err
+}
+
 func (r *UserRestrictionRepositoryImpl) GetActiveRestrictionByUserAndCommunity(userID, communityID uint64) (*model.UserRestriction, error) {
 	var restriction model.UserRestriction
 	now := time.Now()
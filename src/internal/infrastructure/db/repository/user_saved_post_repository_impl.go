@@ -5,6 +5,7 @@ import (
 	"social-platform-backend/internal/domain/repository"
 	"social-platform-backend/internal/interface/dto/request"
 	"social-platform-backend/package/util"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -17,7 +18,7 @@ func NewUserSavedPostRepository(db *gorm.DB) repository.UserSavedPostRepository
 	return &UserSavedPostRepositoryImpl{db: db}
 }
 
-func (r *UserSavedPostRepositoryImpl) GetUserSavedPosts(userID uint64, searchTitle string, isFollowed *bool, page, limit int) ([]*model.UserSavedPost, int64, error) {
+func (r *UserSavedPostRepositoryImpl) GetUserSavedPosts(userID uint64, searchTitle string, isFollowed *bool, collectionID *uint64, page, limit int) ([]*model.UserSavedPost, int64, error) {
 	var savedPosts []*model.UserSavedPost
 	var total int64
 
@@ -37,6 +38,12 @@ func (r *UserSavedPostRepositoryImpl) GetUserSavedPosts(userID uint64, searchTit
 		query = query.Where("is_followed = ?", *isFollowed)
 	}
 
+	if collectionID != nil {
+		collectionPostIDs := r.db.Model(&model.SavedPostCollectionItem{}).Select("post_id").Where("collection_id = ? AND user_id = ?", *collectionID, userID)
+		countQuery = countQuery.Where("post_id IN (?)", collectionPostIDs)
+		query = query.Where("post_id IN (?)", collectionPostIDs)
+	}
+
 	if err := countQuery.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
@@ -44,7 +51,7 @@ func (r *UserSavedPostRepositoryImpl) GetUserSavedPosts(userID uint64, searchTit
 	query = query.Order("created_at DESC")
 
 	offset := (page - 1) * limit
-	if err := query.Offset(offset).Limit(limit).Preload("Community").Find(&savedPosts).Error; err != nil {
+	if err := query.Offset(offset).Limit(limit).Preload("Community").Preload("CollectionItems").Find(&savedPosts).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -72,6 +79,7 @@ func (r *UserSavedPostRepositoryImpl) CreateUserSavedPost(userID uint64, savedPo
 		AuthorAvatar:  author.Avatar,
 		CommunityID:   post.CommunityID,
 		IsFollowed:    savedPost.IsFollowed,
+		SnapshotAt:    time.Now(),
 	}
 
 	return r.db.Create(userSavedPost).Error
@@ -84,7 +92,13 @@ func (r *UserSavedPostRepositoryImpl) UpdateFollowedStatus(userID, postID uint64
 }
 
 func (r *UserSavedPostRepositoryImpl) DeleteUserSavedPost(userID, postID uint64) error {
-	return r.db.Where("user_id = ? AND post_id = ?", userID, postID).Delete(&model.UserSavedPost{}).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Remove the post from the user's collections as well
+		if err := tx.Where("user_id = ? AND post_id = ?", userID, postID).Delete(&model.SavedPostCollectionItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ? AND post_id = ?", userID, postID).Delete(&model.UserSavedPost{}).Error
+	})
 }
 
 func (r *UserSavedPostRepositoryImpl) CheckUserSavedPostExists(userID, postID uint64) (bool, error) {
@@ -102,3 +116,66 @@ func (r *UserSavedPostRepositoryImpl) GetFollowersByPostID(postID uint64) ([]uin
 		Pluck("user_id", &userIDs).Error
 	return userIDs, err
 }
+
+func (r *UserSavedPostRepositoryImpl) UpdateNote(userID, postID uint64, note *string) error {
+	return r.db.Model(&model.UserSavedPost{}).
+		Where("user_id = ? AND post_id = ?", userID, postID).
+		Update("note", note).Error
+}
+
+// RefreshSnapshots copies the current title and author of saved posts into their
+// snapshots, marking posts edited after the last snapshot, and flags saved posts
+// whose post was deleted. Rows are updated batchSize at a time, saved posts that
+// never got a snapshot count as saved at the time they were saved
+func (r *UserSavedPostRepositoryImpl) RefreshSnapshots(batchSize int) (int64, int64, error) {
+	var refreshed, flagged int64
+	for {
+		result := r.db.Exec(`
+			UPDATE user_saved_posts s
+			SET post_title = p.title,
+				author_name = u.username,
+				author_avatar = u.avatar,
+				post_edited_at = CASE WHEN p.edited_at > COALESCE(s.snapshot_at, s.created_at) THEN p.edited_at ELSE s.post_edited_at END,
+				snapshot_at = NOW()
+			FROM posts p
+			INNER JOIN users u ON u.id = p.author_id
+			WHERE p.id = s.post_id
+				AND (s.user_id, s.post_id) IN (
+					SELECT stale.user_id, stale.post_id
+					FROM user_saved_posts stale
+					INNER JOIN posts sp ON sp.id = stale.post_id AND sp.deleted_at IS NULL
+					INNER JOIN users su ON su.id = sp.author_id
+					WHERE stale.snapshot_at IS NULL
+						OR sp.title <> stale.post_title OR su.username <> stale.author_name
+						OR su.avatar IS DISTINCT FROM stale.author_avatar OR sp.edited_at > stale.snapshot_at
+					LIMIT ?)`, batchSize)
+		if result.Error != nil {
+			return refreshed, flagged, result.Error
+		}
+		refreshed += result.RowsAffected
+		if result.RowsAffected < int64(batchSize) {
+			break
+		}
+	}
+
+	for {
+		result := r.db.Exec(`
+			UPDATE user_saved_posts s
+			SET post_deleted_at = COALESCE((SELECT p.deleted_at FROM posts p WHERE p.id = s.post_id), NOW())
+			WHERE (s.user_id, s.post_id) IN (
+				SELECT gone.user_id, gone.post_id
+				FROM user_saved_posts gone
+				WHERE gone.post_deleted_at IS NULL
+					AND NOT EXISTS (SELECT 1 FROM posts p WHERE p.id = gone.post_id AND p.deleted_at IS NULL)
+				LIMIT ?)`, batchSize)
+		if result.Error != nil {
+			return refreshed, flagged, result.Error
+		}
+		flagged += result.RowsAffected
+		if result.RowsAffected < int64(batchSize) {
+			break
+		}
+	}
+
+	return refreshed, flagged, nil
+}
@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"social-platform-backend/config"
+)
+
+const (
+	DefaultLocalDir = "./uploads"
+	// LocalRoutePrefix is the path the router serves local uploads from
+	LocalRoutePrefix = "/media"
+)
+
+type LocalStorage struct {
+	dir       string
+	publicURL string
+}
+
+func NewLocalStorage(conf config.Storage, serverURL string) *LocalStorage {
+	publicURL := conf.PublicURL
+	if strings.TrimSpace(publicURL) == "" {
+		publicURL = strings.TrimRight(serverURL, "/") + LocalRoutePrefix
+	}
+
+	return &LocalStorage{
+		dir:       LocalDir(conf),
+		publicURL: strings.TrimRight(publicURL, "/"),
+	}
+}
+
+// LocalDir returns the configured upload directory or the default one
+func LocalDir(conf config.Storage) string {
+	if strings.TrimSpace(conf.LocalDir) == "" {
+		return DefaultLocalDir
+	}
+	return conf.LocalDir
+}
+
+func (s *LocalStorage) Put(ctx context.Context, key, contentType string, data []byte) (string, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("create storage directory: %w", err)
+	}
+
+	// write to a temp file first so readers never observe a partially written object
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return "", fmt.Errorf("create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("write object: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("close object: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return "", fmt.Errorf("chmod object: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", fmt.Errorf("store object: %w", err)
+	}
+
+	return s.publicURL + "/" + key, nil
+}
+
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("delete object: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalStorage) path(key string) (string, error) {
+	cleaned := filepath.Clean("/" + key)
+	if cleaned == "/" || cleaned != "/"+key {
+		return "", fmt.Errorf("invalid storage key")
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,179 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"social-platform-backend/config"
+)
+
+const s3DefaultTimeoutSeconds = 60
+
+// S3Storage talks to any S3-compatible object store (AWS, MinIO, R2, ...) using
+// SigV4-signed requests, so no vendor SDK is required
+type S3Storage struct {
+	conf       config.S3Storage
+	publicURL  string
+	httpClient *http.Client
+}
+
+func NewS3Storage(conf config.Storage) *S3Storage {
+	timeout := time.Duration(conf.S3.TimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = s3DefaultTimeoutSeconds * time.Second
+	}
+
+	return &S3Storage{
+		conf:       conf.S3,
+		publicURL:  strings.TrimRight(conf.PublicURL, "/"),
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *S3Storage) Put(ctx context.Context, key, contentType string, data []byte) (string, error) {
+	req, err := s.newRequest(ctx, http.MethodPut, key, data)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", contentType)
+	s.sign(req, data)
+
+	if err := s.do(req); err != nil {
+		return "", fmt.Errorf("put object: %w", err)
+	}
+
+	if s.publicURL != "" {
+		return s.publicURL + "/" + key, nil
+	}
+	return req.URL.String(), nil
+}
+
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	s.sign(req, nil)
+
+	if err := s.do(req); err != nil {
+		return fmt.Errorf("delete object: %w", err)
+	}
+	return nil
+}
+
+func (s *S3Storage) newRequest(ctx context.Context, method, key string, data []byte) (*http.Request, error) {
+	if strings.TrimSpace(s.conf.Endpoint) == "" || strings.TrimSpace(s.conf.Bucket) == "" {
+		return nil, fmt.Errorf("s3 storage is not configured")
+	}
+
+	endpoint, err := url.Parse(strings.TrimRight(s.conf.Endpoint, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid s3 endpoint: %w", err)
+	}
+
+	if s.conf.UsePathStyle {
+		endpoint.Path = "/" + s.conf.Bucket + "/" + key
+	} else {
+		endpoint.Host = s.conf.Bucket + "." + endpoint.Host
+		endpoint.Path = "/" + key
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint.String(), bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("create s3 request: %w", err)
+	}
+	req.ContentLength = int64(len(data))
+	return req, nil
+}
+
+func (s *S3Storage) do(req *http.Request) error {
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// S3 answers 204 for deletes, including deletes of keys that do not exist
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("s3 responded %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// sign adds an AWS Signature Version 4 Authorization header to the request
+func (s *S3Storage) sign(req *http.Request, payload []byte) {
+	now := time.Now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	shortDate := now.Format("20060102")
+	region := s.conf.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	payloadHash := sha256Hex(payload)
+	req.Header.Set("Host", req.URL.Host)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headerNames := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		headerNames = append(headerNames, strings.ToLower(name))
+	}
+	sort.Strings(headerNames)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range headerNames {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(req.Header.Get(name)) + "\n")
+	}
+	signedHeaders := strings.Join(headerNames, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := shortDate + "/" + region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.conf.SecretKey), shortDate)
+	signingKey = hmacSHA256(signingKey, region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.conf.AccessKey, scope, signedHeaders, signature,
+	))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
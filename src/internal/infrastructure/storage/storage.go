@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"context"
+	"strings"
+
+	"social-platform-backend/config"
+)
+
+// Storage persists uploaded media objects under a storage key and exposes them by public URL
+type Storage interface {
+	Put(ctx context.Context, key, contentType string, data []byte) (string, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// NewStorage builds the backend selected by the storage driver config, defaulting to the local filesystem
+func NewStorage(conf *config.Config) Storage {
+	if IsLocal(conf.Storage) {
+		return NewLocalStorage(conf.Storage, conf.Server.Url)
+	}
+	return NewS3Storage(conf.Storage)
+}
+
+// IsLocal reports whether uploads are kept on the local filesystem and served by the API
+func IsLocal(conf config.Storage) bool {
+	return strings.ToLower(strings.TrimSpace(conf.Driver)) != "s3"
+}
@@ -0,0 +1,19 @@
+package request
+
+import "social-platform-backend/package/template/payload"
+
+type AutoModRuleRequest struct {
+	Name       string                    `json:"name" binding:"required,max=100"`
+	Triggers   []string                  `json:"triggers" binding:"required,min=1,dive,oneof=post_created comment_created post_reported comment_reported"`
+	Conditions payload.AutoModConditions `json:"conditions"`
+	Actions    []payload.AutoModAction   `json:"actions" binding:"required,min=1"`
+	// Rules are enabled unless this is false
+	Enabled *bool `json:"enabled"`
+}
+
+// DryRunAutoModRequest tests Rule against the community's recent posts and comments
+// without acting on them. The community's enabled rules are tested when Rule is empty.
+type DryRunAutoModRequest struct {
+	Rule  *AutoModRuleRequest `json:"rule"`
+	Limit int                 `json:"limit" binding:"omitempty,min=1,max=200"`
+}
@@ -3,16 +3,19 @@ package request
 type CreateCommentRequest struct {
 	PostID          uint64  `json:"postId" binding:"required"`
 	Content         string  `json:"content" binding:"required"`
+	ContentFormat   string  `json:"contentFormat,omitempty" binding:"omitempty,oneof=html markdown"`
 	ParentCommentID *uint64 `json:"parentCommentId,omitempty"`
 	MediaURL        *string `json:"mediaUrl,omitempty"`
 }
 
 type UpdateCommentRequest struct {
-	Content  string  `json:"content" binding:"required"`
-	MediaURL *string `json:"mediaUrl,omitempty"`
+	Content       string  `json:"content" binding:"required"`
+	ContentFormat string  `json:"contentFormat,omitempty" binding:"omitempty,oneof=html markdown"`
+	MediaURL      *string `json:"mediaUrl,omitempty"`
 }
 
 type ReportCommentRequest struct {
-	Reasons []string `json:"reasons" binding:"required,min=1"`
+	Reasons []string `json:"reasons"`
+	RuleIDs []uint64 `json:"ruleIds" binding:"omitempty,max=5"`
 	Note    *string  `json:"note,omitempty"`
 }
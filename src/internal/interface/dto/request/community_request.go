@@ -28,7 +28,8 @@ type VerifyCommunityNameRequest struct {
 }
 
 type UpdateMemberRoleRequest struct {
-	Role string `json:"role" binding:"required,oneof=admin user"`
+	Role   string  `json:"role" binding:"required,oneof=admin moderator user"`
+	RoleID *uint64 `json:"roleId"` // custom role of a moderator
 }
 
 type UpdateRequiresPostApprovalRequest struct {
@@ -39,6 +40,40 @@ type UpdateRequiresMemberApprovalRequest struct {
 	RequiresMemberApproval bool `json:"requiresMemberApproval"`
 }
 
+// An empty list restores the default emoji set
+type UpdateReactionEmojisRequest struct {
+	Emojis []string `json:"emojis"`
+}
+
+// What members see of the mod log, moderators always see all of it
+type UpdateModLogSettingsRequest struct {
+	MemberAccess   bool `json:"memberAccess"`
+	ShowModerators bool `json:"showModerators"`
+	ShowReasons    bool `json:"showReasons"`
+}
+
 type UpdateSubscriptionStatusRequest struct {
-	Status string `json:"status" binding:"required,oneof=approved rejected"`
+	Status string  `json:"status" binding:"required,oneof=approved rejected"`
+	Reason *string `json:"reason" binding:"omitempty,max=500"`
+}
+
+// Query parameters of removing a member
+type RemoveMemberRequest struct {
+	Reason *string `form:"reason" binding:"omitempty,max=500"`
+}
+
+// Query parameters of a moderator removing a post or comment. RuleID is the community
+// rule the content broke, its title is the reason when none is given.
+type RemoveContentRequest struct {
+	RuleID *uint64 `form:"ruleId"`
+	Reason *string `form:"reason" binding:"omitempty,max=500"`
+}
+
+type PinPostRequest struct {
+	PinOrder       *int `json:"pinOrder" binding:"omitempty,min=1"`
+	IsAnnouncement bool `json:"isAnnouncement"`
+}
+
+type LockContentRequest struct {
+	Reason *string `json:"reason" binding:"omitempty,max=500"`
 }
@@ -0,0 +1,6 @@
+package request
+
+type CommunityRoleRequest struct {
+	Name        string   `json:"name" binding:"required,min=1,max=50"`
+	Permissions []string `json:"permissions" binding:"required,min=1,dive,oneof=manage_posts manage_comments manage_members ban_users edit_settings manage_rules view_reports manage_roles"`
+}
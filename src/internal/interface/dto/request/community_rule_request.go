@@ -0,0 +1,11 @@
+package request
+
+type CommunityRuleRequest struct {
+	Title       string  `json:"title" binding:"required,min=1,max=100"`
+	Description *string `json:"description" binding:"omitempty,max=1000"`
+	AppliesTo   string  `json:"appliesTo" binding:"required,oneof=posts comments all"`
+}
+
+type ReorderCommunityRulesRequest struct {
+	RuleIDs []uint64 `json:"ruleIds" binding:"required"`
+}
@@ -0,0 +1,24 @@
+package request
+
+import "time"
+
+// ModerationQueueActionRequest resolves a queue item. RuleID is the community rule cited
+// for a removal. RestrictionType and ExpiresAt are only used to ban the author.
+// NotifyReporters anonymously tells the reporters when action is taken against the content.
+type ModerationQueueActionRequest struct {
+	Action          string     `json:"action" binding:"required,oneof=approve remove ignore_reports ban_author lock"`
+	Reason          *string    `json:"reason" binding:"omitempty,max=500"`
+	RuleID          *uint64    `json:"ruleId"`
+	RestrictionType string     `json:"restrictionType" binding:"omitempty,oneof=warning temporary_ban permanent_ban"`
+	ExpiresAt       *time.Time `json:"expiresAt"`
+	NotifyReporters bool       `json:"notifyReporters"`
+}
+
+type EscalateReportsRequest struct {
+	Note *string `json:"note" binding:"omitempty,max=1000"`
+}
+
+type ResolveReportEscalationRequest struct {
+	Status string  `json:"status" binding:"required,oneof=actioned dismissed"`
+	Reason *string `json:"reason" binding:"omitempty,max=500"`
+}
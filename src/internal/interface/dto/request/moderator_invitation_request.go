@@ -0,0 +1,12 @@
+package request
+
+// InviteModeratorRequest offers a member the admin role, or a custom role with RoleID
+type InviteModeratorRequest struct {
+	UserID uint64  `json:"userId" binding:"required"`
+	Role   string  `json:"role" binding:"required,oneof=admin moderator"`
+	RoleID *uint64 `json:"roleId"`
+}
+
+type TransferOwnershipRequest struct {
+	UserID uint64 `json:"userId" binding:"required"`
+}
@@ -2,65 +2,100 @@ package request
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/lib/pq"
 )
 
 type CreatePostRequest struct {
-	CommunityID uint64           `json:"communityId" binding:"required"`
-	Title       string           `json:"title" binding:"required"`
-	Type        string           `json:"type" binding:"required,oneof=text link media poll"`
-	Content     string           `json:"content" binding:"required"`
-	URL         *string          `json:"url,omitempty"`
-	MediaURLs   *pq.StringArray  `json:"mediaUrls,omitempty"`
-	PollData    *json.RawMessage `json:"pollData,omitempty"`
-	Tags        *pq.StringArray  `json:"tags,omitempty"`
+	CommunityID   uint64           `json:"communityId" binding:"required"`
+	Title         string           `json:"title" binding:"required"`
+	Type          string           `json:"type" binding:"required,oneof=text link media poll"`
+	Content       string           `json:"content" binding:"required"`
+	ContentFormat string           `json:"contentFormat,omitempty" binding:"omitempty,oneof=html markdown"`
+	URL           *string          `json:"url,omitempty"`
+	MediaURLs     *pq.StringArray  `json:"mediaUrls,omitempty"`
+	PollData      *json.RawMessage `json:"pollData,omitempty"`
+	Tags          *pq.StringArray  `json:"tags,omitempty"`
+	IsDraft       bool             `json:"isDraft"`
+	PublishAt     *time.Time       `json:"publishAt,omitempty"`
+}
+
+type SaveDraftRequest struct {
+	Title          *string          `json:"title,omitempty"`
+	Content        *string          `json:"content,omitempty"`
+	ContentFormat  string           `json:"contentFormat,omitempty" binding:"omitempty,oneof=html markdown"`
+	URL            *string          `json:"url,omitempty"`
+	MediaURLs      *pq.StringArray  `json:"mediaUrls,omitempty"`
+	PollData       *json.RawMessage `json:"pollData,omitempty"`
+	Tags           *pq.StringArray  `json:"tags,omitempty"`
+	PublishAt      *time.Time       `json:"publishAt,omitempty"`
+	ClearPublishAt bool             `json:"clearPublishAt,omitempty"`
 }
 
 type UpdatePostTextRequest struct {
-	Title   *string         `json:"title,omitempty"`
-	Content *string         `json:"content,omitempty"`
-	Tags    *pq.StringArray `json:"tags,omitempty"`
+	Title         *string         `json:"title,omitempty"`
+	Content       *string         `json:"content,omitempty"`
+	ContentFormat string          `json:"contentFormat,omitempty" binding:"omitempty,oneof=html markdown"`
+	Tags          *pq.StringArray `json:"tags,omitempty"`
 }
 
 type UpdatePostLinkRequest struct {
-	Title   *string         `json:"title,omitempty"`
-	Content *string         `json:"content,omitempty"`
-	URL     *string         `json:"url,omitempty"`
-	Tags    *pq.StringArray `json:"tags,omitempty"`
+	Title         *string         `json:"title,omitempty"`
+	Content       *string         `json:"content,omitempty"`
+	ContentFormat string          `json:"contentFormat,omitempty" binding:"omitempty,oneof=html markdown"`
+	URL           *string         `json:"url,omitempty"`
+	Tags          *pq.StringArray `json:"tags,omitempty"`
 }
 
 type UpdatePostMediaRequest struct {
-	Title     *string         `json:"title,omitempty"`
-	Content   *string         `json:"content,omitempty"`
-	MediaURLs *pq.StringArray `json:"mediaUrls,omitempty"`
-	Tags      *pq.StringArray `json:"tags,omitempty"`
+	Title         *string         `json:"title,omitempty"`
+	Content       *string         `json:"content,omitempty"`
+	ContentFormat string          `json:"contentFormat,omitempty" binding:"omitempty,oneof=html markdown"`
+	MediaURLs     *pq.StringArray `json:"mediaUrls,omitempty"`
+	Tags          *pq.StringArray `json:"tags,omitempty"`
 }
 
 type UpdatePostPollRequest struct {
-	Title    *string          `json:"title,omitempty"`
-	Content  *string          `json:"content,omitempty"`
-	PollData *json.RawMessage `json:"pollData,omitempty"`
-	Tags     *pq.StringArray  `json:"tags,omitempty"`
+	Title         *string          `json:"title,omitempty"`
+	Content       *string          `json:"content,omitempty"`
+	ContentFormat string           `json:"contentFormat,omitempty" binding:"omitempty,oneof=html markdown"`
+	PollData      *json.RawMessage `json:"pollData,omitempty"`
+	Tags          *pq.StringArray  `json:"tags,omitempty"`
 }
 
 type VotePostRequest struct {
 	Vote bool `json:"vote"`
 }
 
+// VotePollRequest takes OptionID, or RankedOptionIDs (most preferred first) for ranked
+// choice polls
 type VotePollRequest struct {
-	OptionID int `json:"optionId" binding:"required"`
+	OptionID        int   `json:"optionId"`
+	RankedOptionIDs []int `json:"rankedOptionIds,omitempty"`
 }
 
+// UnvotePollRequest ignores OptionID for ranked choice polls, the whole ballot is removed
 type UnvotePollRequest struct {
-	OptionID int `json:"optionId" binding:"required"`
+	OptionID int `json:"optionId"`
+}
+
+type SuggestPollOptionRequest struct {
+	Text string `json:"text" binding:"required,max=200"`
 }
 
 type UpdatePostStatusRequest struct {
-	Status string `json:"status" binding:"required,oneof=pending approved rejected"`
+	Status string  `json:"status" binding:"required,oneof=pending approved rejected"`
+	Reason *string `json:"reason" binding:"omitempty,max=500"`
 }
 
 type ReportPostRequest struct {
-	Reasons []string `json:"reasons" binding:"required,min=1"`
+	Reasons []string `json:"reasons"`
+	RuleIDs []uint64 `json:"ruleIds" binding:"omitempty,max=5"`
 	Note    *string  `json:"note,omitempty"`
 }
+
+type CrosspostRequest struct {
+	CommunityID uint64  `json:"communityId" binding:"required"`
+	Title       *string `json:"title,omitempty"`
+}
@@ -0,0 +1,5 @@
+package request
+
+type ToggleReactionRequest struct {
+	Emoji string `json:"emoji" binding:"required"`
+}
@@ -8,3 +8,25 @@ type UserSavedPostRequest struct {
 type UpdateUserSavedPostRequest struct {
 	IsFollowed bool `json:"isFollowed"`
 }
+
+type UpdateSavedPostNoteRequest struct {
+	Note string `json:"note" binding:"max=2000"`
+}
+
+type CreateSavedPostCollectionRequest struct {
+	Name     string `json:"name" binding:"required,max=100"`
+	IsPublic bool   `json:"isPublic"`
+}
+
+type UpdateSavedPostCollectionRequest struct {
+	Name     *string `json:"name" binding:"omitempty,max=100"`
+	IsPublic *bool   `json:"isPublic"`
+}
+
+type ReorderSavedPostCollectionsRequest struct {
+	CollectionIDs []uint64 `json:"collectionIds" binding:"required"`
+}
+
+type AddPostToCollectionRequest struct {
+	PostID uint64 `json:"postId" binding:"required"`
+}
@@ -0,0 +1,61 @@
+package response
+
+import (
+	"encoding/json"
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/package/template/payload"
+	"time"
+)
+
+type AutoModRuleResponse struct {
+	ID          uint64                    `json:"id"`
+	CommunityID uint64                    `json:"communityId"`
+	Name        string                    `json:"name"`
+	Triggers    []string                  `json:"triggers"`
+	Conditions  payload.AutoModConditions `json:"conditions"`
+	Actions     []payload.AutoModAction   `json:"actions"`
+	Enabled     bool                      `json:"enabled"`
+	CreatedBy   uint64                    `json:"createdBy"`
+	CreatedAt   time.Time                 `json:"createdAt"`
+	UpdatedAt   *time.Time                `json:"updatedAt,omitempty"`
+}
+
+func NewAutoModRuleResponse(rule *model.AutoModRule) *AutoModRuleResponse {
+	resp := &AutoModRuleResponse{
+		ID:          rule.ID,
+		CommunityID: rule.CommunityID,
+		Name:        rule.Name,
+		Triggers:    rule.Triggers,
+		Actions:     []payload.AutoModAction{},
+		Enabled:     rule.Enabled,
+		CreatedBy:   rule.CreatedBy,
+		CreatedAt:   rule.CreatedAt,
+		UpdatedAt:   rule.UpdatedAt,
+	}
+	if rule.Conditions != nil {
+		_ = json.Unmarshal(*rule.Conditions, &resp.Conditions)
+	}
+	if rule.Actions != nil {
+		_ = json.Unmarshal(*rule.Actions, &resp.Actions)
+	}
+	return resp
+}
+
+// AutoModDryRunResponse lists what the tested rules would have done to recent content
+type AutoModDryRunResponse struct {
+	PostsChecked    int                   `json:"postsChecked"`
+	CommentsChecked int                   `json:"commentsChecked"`
+	Matches         []*AutoModDryRunMatch `json:"matches"`
+}
+
+// AutoModDryRunMatch is one rule matching one post or comment. RuleID is empty for a
+// rule that is not saved yet.
+type AutoModDryRunMatch struct {
+	TargetType string                      `json:"targetType"`
+	TargetID   uint64                      `json:"targetId"`
+	Content    *ModerationQueueContentInfo `json:"content"`
+	Trigger    string                      `json:"trigger"`
+	RuleID     uint64                      `json:"ruleId,omitempty"`
+	RuleName   string                      `json:"ruleName"`
+	Actions    []string                    `json:"actions"`
+}
@@ -2,6 +2,7 @@ package response
 
 import (
 	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/package/constant"
 	"time"
 )
 
@@ -16,7 +17,36 @@ type CommentResponse struct {
 	IsVoted         *bool              `json:"isVoted,omitempty"`
 	CreatedAt       time.Time          `json:"createdAt"`
 	UpdatedAt       *time.Time         `json:"updatedAt,omitempty"`
+	IsEdited        bool               `json:"isEdited"`
+	EditedAt        *time.Time         `json:"editedAt,omitempty"`
+	IsLocked        bool               `json:"isLocked"`
+	LockReason      *string            `json:"lockReason,omitempty"`
+	IsDeleted       bool               `json:"isDeleted"`
+	DeletedBy       *string            `json:"deletedBy,omitempty"`
 	Replies         []*CommentResponse `json:"replies,omitempty"`
+
+	// Set when only part of the replies were loaded
+	MoreRepliesCount  int64  `json:"moreRepliesCount,omitempty"`
+	ContinuationToken string `json:"continuationToken,omitempty"`
+
+	Reactions []*ReactionCountResponse `json:"reactions"`
+	Mentions  []*MentionResponse       `json:"mentions,omitempty"`
+}
+
+type CommentRepliesResponse struct {
+	CommentID         uint64             `json:"commentId"`
+	Replies           []*CommentResponse `json:"replies"`
+	MoreRepliesCount  int64              `json:"moreRepliesCount"`
+	ContinuationToken string             `json:"continuationToken,omitempty"`
+}
+
+// CommentPermalinkResponse opens a thread at a single comment: the post it belongs to,
+// the ancestors leading to it (topmost first) and its direct replies
+type CommentPermalinkResponse struct {
+	Post             *PostSummaryInfo   `json:"post"`
+	Ancestors        []*CommentResponse `json:"ancestors"`
+	HasMoreAncestors bool               `json:"hasMoreAncestors"`
+	Comment          *CommentResponse   `json:"comment"`
 }
 
 func NewCommentResponse(comment *model.Comment) *CommentResponse {
@@ -29,6 +59,26 @@ func NewCommentResponse(comment *model.Comment) *CommentResponse {
 		Vote:            comment.Vote,
 		CreatedAt:       comment.CreatedAt,
 		UpdatedAt:       comment.UpdatedAt,
+		IsEdited:        comment.EditedAt != nil,
+		EditedAt:        comment.EditedAt,
+		IsLocked:        comment.LockedAt != nil,
+		LockReason:      comment.LockReason,
+		Reactions:       newReactionCountResponses(comment.ReactionCounts),
+		Mentions:        newMentionResponses(comment.Mentions),
+	}
+
+	// Tombstones only keep their place in the thread
+	if comment.DeletedAt != nil {
+		response.IsDeleted = true
+		response.DeletedBy = comment.DeletedBy
+		response.Content = constant.COMMENT_DELETED_PLACEHOLDER
+		if comment.DeletedBy != nil && *comment.DeletedBy == constant.COMMENT_DELETED_BY_MODERATOR {
+			response.Content = constant.COMMENT_REMOVED_PLACEHOLDER
+		}
+		response.MediaURL = nil
+		response.Reactions = []*ReactionCountResponse{}
+		response.Mentions = nil
+		return response
 	}
 
 	if comment.UserVote != nil {
@@ -21,6 +21,7 @@ type CommunityDetailResponse struct {
 	PostsLastWeek            int64          `json:"postsLastWeek"`
 	IsRequiresMemberApproval bool           `json:"isRequiresMemberApproval"`
 	IsRequiresPostApproval   bool           `json:"isRequiresPostApproval"`
+	IsModLogPublic           bool           `json:"isModLogPublic"`
 	IsFollow                 *bool          `json:"isFollow,omitempty"`
 	IsRequestJoin            *bool          `json:"isRequestJoin,omitempty"`
 
@@ -41,6 +42,7 @@ func NewCommunityDetailResponse(community *model.Community) *CommunityDetailResp
 		CreatedAt:                community.CreatedAt,
 		IsRequiresMemberApproval: community.RequiresMemberApproval,
 		IsRequiresPostApproval:   community.RequiresPostApproval,
+		IsModLogPublic:           community.ModLogMemberAccess,
 	}
 }
 
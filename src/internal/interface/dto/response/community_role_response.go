@@ -0,0 +1,48 @@
+package response
+
+import (
+	"social-platform-backend/internal/domain/model"
+	"time"
+)
+
+type CommunityRoleResponse struct {
+	ID          uint64     `json:"id"`
+	CommunityID uint64     `json:"communityId"`
+	Name        string     `json:"name"`
+	Permissions []string   `json:"permissions"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   *time.Time `json:"updatedAt,omitempty"`
+}
+
+func NewCommunityRoleResponse(role *model.CommunityRole) *CommunityRoleResponse {
+	permissions := []string(role.Permissions)
+	if permissions == nil {
+		permissions = []string{}
+	}
+	return &CommunityRoleResponse{
+		ID:          role.ID,
+		CommunityID: role.CommunityID,
+		Name:        role.Name,
+		Permissions: permissions,
+		CreatedAt:   role.CreatedAt,
+		UpdatedAt:   role.UpdatedAt,
+	}
+}
+
+func NewCommunityRoleResponses(roles []*model.CommunityRole) []*CommunityRoleResponse {
+	responses := make([]*CommunityRoleResponse, len(roles))
+	for i, role := range roles {
+		responses[i] = NewCommunityRoleResponse(role)
+	}
+	return responses
+}
+
+// CommunityPermissionsResponse is what the caller may do in a community, so clients can
+// hide the actions they would be refused. RoleID and RoleName are set for custom roles.
+type CommunityPermissionsResponse struct {
+	CommunityID uint64   `json:"communityId"`
+	Role        string   `json:"role"`
+	RoleID      *uint64  `json:"roleId,omitempty"`
+	RoleName    *string  `json:"roleName,omitempty"`
+	Permissions []string `json:"permissions"`
+}
@@ -0,0 +1,54 @@
+package response
+
+import (
+	"social-platform-backend/internal/domain/model"
+	"time"
+)
+
+type CommunityRuleResponse struct {
+	ID          uint64     `json:"id"`
+	CommunityID uint64     `json:"communityId"`
+	Position    int        `json:"position"`
+	Title       string     `json:"title"`
+	Description *string    `json:"description,omitempty"`
+	AppliesTo   string     `json:"appliesTo"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   *time.Time `json:"updatedAt,omitempty"`
+}
+
+func NewCommunityRuleResponse(rule *model.CommunityRule) *CommunityRuleResponse {
+	return &CommunityRuleResponse{
+		ID:          rule.ID,
+		CommunityID: rule.CommunityID,
+		Position:    rule.Position,
+		Title:       rule.Title,
+		Description: rule.Description,
+		AppliesTo:   rule.AppliesTo,
+		CreatedAt:   rule.CreatedAt,
+		UpdatedAt:   rule.UpdatedAt,
+	}
+}
+
+func NewCommunityRuleResponses(rules []*model.CommunityRule) []*CommunityRuleResponse {
+	responses := make([]*CommunityRuleResponse, len(rules))
+	for i, rule := range rules {
+		responses[i] = NewCommunityRuleResponse(rule)
+	}
+	return responses
+}
+
+// CommunityRuleStatsResponse counts, for each rule, the reports citing it and the
+// removals made for it since From
+type CommunityRuleStatsResponse struct {
+	CommunityID uint64                   `json:"communityId"`
+	From        time.Time                `json:"from"`
+	Rules       []*CommunityRuleStatInfo `json:"rules"`
+}
+
+type CommunityRuleStatInfo struct {
+	RuleID       uint64 `json:"ruleId"`
+	Position     int    `json:"position"`
+	Title        string `json:"title"`
+	ReportCount  int64  `json:"reportCount"`
+	RemovalCount int64  `json:"removalCount"`
+}
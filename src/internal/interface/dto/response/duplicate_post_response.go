@@ -0,0 +1,73 @@
+package response
+
+import (
+	"social-platform-backend/internal/domain/model"
+	"time"
+)
+
+// DuplicatePostResponse shows a post next to the earlier post it was found to copy
+type DuplicatePostResponse struct {
+	ID          uint64             `json:"id"`
+	MatchType   string             `json:"matchType"`
+	Similarity  float64            `json:"similarity"`
+	SameAuthor  bool               `json:"sameAuthor"`
+	Action      string             `json:"action"`
+	CreatedAt   time.Time          `json:"createdAt"`
+	Post        *DuplicatePostInfo `json:"post"`
+	MatchedPost *DuplicatePostInfo `json:"matchedPost"`
+}
+
+// DuplicatePostInfo only has the ID and IsDeleted once the post is deleted
+type DuplicatePostInfo struct {
+	ID        uint64         `json:"id"`
+	Title     string         `json:"title,omitempty"`
+	Status    string         `json:"status,omitempty"`
+	Community *CommunityInfo `json:"community,omitempty"`
+	Author    *AuthorInfo    `json:"author,omitempty"`
+	CreatedAt *time.Time     `json:"createdAt,omitempty"`
+	IsDeleted bool           `json:"isDeleted"`
+}
+
+func NewDuplicatePostResponse(match *model.PostDuplicateMatch) *DuplicatePostResponse {
+	return &DuplicatePostResponse{
+		ID:          match.ID,
+		MatchType:   match.MatchType,
+		Similarity:  match.Similarity,
+		SameAuthor:  match.SameAuthor,
+		Action:      match.Action,
+		CreatedAt:   match.CreatedAt,
+		Post:        newDuplicatePostInfo(match.PostID, match.Post),
+		MatchedPost: newDuplicatePostInfo(match.MatchedPostID, match.MatchedPost),
+	}
+}
+
+func newDuplicatePostInfo(postID uint64, post *model.Post) *DuplicatePostInfo {
+	if post == nil {
+		return &DuplicatePostInfo{ID: postID, IsDeleted: true}
+	}
+
+	info := &DuplicatePostInfo{
+		ID:        post.ID,
+		Title:     post.Title,
+		Status:    post.Status,
+		CreatedAt: &post.CreatedAt,
+	}
+	if post.Community != nil {
+		info.Community = &CommunityInfo{
+			ID:               post.Community.ID,
+			Name:             post.Community.Name,
+			Avatar:           post.Community.CommunityAvatar,
+			ShortDescription: post.Community.ShortDescription,
+		}
+	}
+	if post.Author != nil {
+		info.Author = &AuthorInfo{
+			ID:        post.Author.ID,
+			Username:  post.Author.Username,
+			Avatar:    post.Author.Avatar,
+			Karma:     post.Author.Karma,
+			CreatedAt: post.Author.CreatedAt,
+		}
+	}
+	return info
+}
@@ -0,0 +1,44 @@
+package response
+
+import "social-platform-backend/internal/domain/model"
+
+type MediaVariantResponse struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+type MediaResponse struct {
+	ID       uint64 `json:"id"`
+	URL      string `json:"url"`
+	MimeType string `json:"mimeType"`
+	Size     int64  `json:"size"`
+	Width    *int   `json:"width,omitempty"`
+	Height   *int   `json:"height,omitempty"`
+	// Resized copies keyed by variant name (thumbnail, medium), only for images
+	Variants map[string]MediaVariantResponse `json:"variants,omitempty"`
+}
+
+func NewMediaResponse(media *model.Media) *MediaResponse {
+	resp := &MediaResponse{
+		ID:       media.ID,
+		URL:      media.URL,
+		MimeType: media.MimeType,
+		Size:     media.Size,
+		Width:    media.Width,
+		Height:   media.Height,
+	}
+
+	if len(media.Variants) > 0 {
+		resp.Variants = make(map[string]MediaVariantResponse, len(media.Variants))
+		for _, variant := range media.Variants {
+			resp.Variants[variant.Name] = MediaVariantResponse{
+				URL:    variant.URL,
+				Width:  variant.Width,
+				Height: variant.Height,
+			}
+		}
+	}
+
+	return resp
+}
@@ -0,0 +1,37 @@
+package response
+
+import "social-platform-backend/internal/domain/model"
+
+// MentionResponse lets clients link an @username in the content to the user
+type MentionResponse struct {
+	UserID   uint64 `json:"userId"`
+	Username string `json:"username"`
+}
+
+func newMentionResponses(mentions []*model.Mention) []*MentionResponse {
+	responses := make([]*MentionResponse, 0, len(mentions))
+	for _, mention := range mentions {
+		if mention.User == nil {
+			continue
+		}
+		responses = append(responses, &MentionResponse{
+			UserID:   mention.UserID,
+			Username: mention.User.Username,
+		})
+	}
+	return responses
+}
+
+type MentionSuggestionResponse struct {
+	ID       uint64  `json:"id"`
+	Username string  `json:"username"`
+	Avatar   *string `json:"avatar,omitempty"`
+}
+
+func NewMentionSuggestionResponse(user *model.User) *MentionSuggestionResponse {
+	return &MentionSuggestionResponse{
+		ID:       user.ID,
+		Username: user.Username,
+		Avatar:   user.Avatar,
+	}
+}
@@ -87,6 +87,9 @@ type MessageResponse struct {
 	MetaData       *MetaDataResponse `json:"metadata,omitempty"`
 	CreatedAt      time.Time         `json:"createdAt"`
 	IsDeleted      bool              `json:"isDeleted"`
+
+	Reactions []*ReactionCountResponse `json:"reactions"`
+	Mentions  []*MentionResponse       `json:"mentions,omitempty"`
 }
 
 func NewMessageResponse(message *model.Message) *MessageResponse {
@@ -97,6 +100,8 @@ func NewMessageResponse(message *model.Message) *MessageResponse {
 		ReadAt:         message.ReadAt,
 		CreatedAt:      message.CreatedAt,
 		IsDeleted:      message.DeletedAt.Valid,
+		Reactions:      newReactionCountResponses(message.ReactionCounts),
+		Mentions:       newMentionResponses(message.Mentions),
 	}
 
 	if message.MetaData != nil {
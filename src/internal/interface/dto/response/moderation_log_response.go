@@ -0,0 +1,69 @@
+package response
+
+import (
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/package/constant"
+	"time"
+)
+
+type ModerationLogResponse struct {
+	ID         uint64      `json:"id"`
+	Actor      *AuthorInfo `json:"actor,omitempty"`
+	Action     string      `json:"action"`
+	TargetType string      `json:"targetType"`
+	TargetID   uint64      `json:"targetId"`
+	Reason     *string     `json:"reason,omitempty"`
+	RuleID     *uint64     `json:"ruleId,omitempty"`
+	Details    *string     `json:"details,omitempty"`
+	CreatedAt  time.Time   `json:"createdAt"`
+}
+
+func NewModerationLogResponse(log *model.ModerationLog) *ModerationLogResponse {
+	return &ModerationLogResponse{
+		ID:         log.ID,
+		Actor:      newEditorInfo(log.Actor),
+		Action:     log.Action,
+		TargetType: log.TargetType,
+		TargetID:   log.TargetID,
+		Reason:     log.Reason,
+		RuleID:     log.RuleID,
+		Details:    log.Details,
+		CreatedAt:  log.CreatedAt,
+	}
+}
+
+// MemberModerationLogResponse is a mod log entry as members see it. Users acted on are
+// never named, moderators and reasons only when the community allows it.
+type MemberModerationLogResponse struct {
+	ID         uint64      `json:"id"`
+	Actor      *AuthorInfo `json:"actor,omitempty"`
+	Action     string      `json:"action"`
+	TargetType string      `json:"targetType"`
+	TargetID   *uint64     `json:"targetId,omitempty"`
+	Reason     *string     `json:"reason,omitempty"`
+	RuleID     *uint64     `json:"ruleId,omitempty"`
+	Details    *string     `json:"details,omitempty"`
+	CreatedAt  time.Time   `json:"createdAt"`
+}
+
+func NewMemberModerationLogResponse(log *model.ModerationLog, showModerators, showReasons bool) *MemberModerationLogResponse {
+	resp := &MemberModerationLogResponse{
+		ID:         log.ID,
+		Action:     log.Action,
+		TargetType: log.TargetType,
+		Details:    log.Details,
+		CreatedAt:  log.CreatedAt,
+	}
+	if log.TargetType != constant.MODERATION_TARGET_USER {
+		targetID := log.TargetID
+		resp.TargetID = &targetID
+	}
+	if showModerators {
+		resp.Actor = newEditorInfo(log.Actor)
+	}
+	if showReasons {
+		resp.Reason = log.Reason
+		resp.RuleID = log.RuleID
+	}
+	return resp
+}
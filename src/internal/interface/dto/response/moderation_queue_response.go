@@ -0,0 +1,104 @@
+package response
+
+import (
+	"social-platform-backend/internal/domain/model"
+	"time"
+)
+
+type ModerationQueueItemResponse struct {
+	TargetType    string                      `json:"targetType"`
+	TargetID      uint64                      `json:"targetId"`
+	QueuedAt      time.Time                   `json:"queuedAt"`
+	IsPending     bool                        `json:"isPending"`
+	ReportCount   int64                       `json:"reportCount"`
+	FlagCount     int64                       `json:"flagCount"`
+	Content       *ModerationQueueContentInfo `json:"content,omitempty"`
+	ReportReasons []ReportReasonCount         `json:"reportReasons"`
+	ReportRules   []ReportRuleCount           `json:"reportRules"`
+	Reporters     []ReporterInfo              `json:"reporters"`
+	Flags         []ContentFlagInfo           `json:"flags"`
+}
+
+// ModerationQueueContentInfo describes the queued post, or the queued comment with the
+// post it belongs to
+type ModerationQueueContentInfo struct {
+	PostID    uint64      `json:"postId"`
+	PostTitle string      `json:"postTitle"`
+	CommentID *uint64     `json:"commentId,omitempty"`
+	Content   string      `json:"content"`
+	Status    string      `json:"status,omitempty"`
+	IsLocked  bool        `json:"isLocked"`
+	Author    *AuthorInfo `json:"author,omitempty"`
+	CreatedAt time.Time   `json:"createdAt"`
+}
+
+type ReportReasonCount struct {
+	Reason string `json:"reason"`
+	Count  int    `json:"count"`
+}
+
+// ReportRuleCount is a community rule cited by reporters. Title is empty once the rule is deleted.
+type ReportRuleCount struct {
+	RuleID uint64 `json:"ruleId"`
+	Title  string `json:"title,omitempty"`
+	Count  int    `json:"count"`
+}
+
+type ContentFlagInfo struct {
+	Source    string    `json:"source"`
+	Reason    string    `json:"reason"`
+	Category  *string   `json:"category,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func NewModerationQueueItemResponse(item *model.ModerationQueueItem) *ModerationQueueItemResponse {
+	return &ModerationQueueItemResponse{
+		TargetType:    item.TargetType,
+		TargetID:      item.TargetID,
+		QueuedAt:      item.QueuedAt,
+		IsPending:     item.IsPending,
+		ReportCount:   item.ReportCount,
+		FlagCount:     item.FlagCount,
+		ReportReasons: []ReportReasonCount{},
+		ReportRules:   []ReportRuleCount{},
+		Reporters:     []ReporterInfo{},
+		Flags:         []ContentFlagInfo{},
+	}
+}
+
+func NewModerationQueuePostContent(post *model.Post) *ModerationQueueContentInfo {
+	return &ModerationQueueContentInfo{
+		PostID:    post.ID,
+		PostTitle: post.Title,
+		Content:   post.Content,
+		Status:    post.Status,
+		IsLocked:  post.LockedAt != nil,
+		Author:    newEditorInfo(post.Author),
+		CreatedAt: post.CreatedAt,
+	}
+}
+
+func NewModerationQueueCommentContent(comment *model.Comment) *ModerationQueueContentInfo {
+	commentID := comment.ID
+	info := &ModerationQueueContentInfo{
+		PostID:    comment.PostID,
+		CommentID: &commentID,
+		Content:   comment.Content,
+		IsLocked:  comment.LockedAt != nil,
+		Author:    newEditorInfo(comment.Author),
+		CreatedAt: comment.CreatedAt,
+	}
+	if comment.Post != nil {
+		info.PostTitle = comment.Post.Title
+	}
+	return info
+}
+
+func NewContentFlagInfo(flag *model.ContentFlag) ContentFlagInfo {
+	return ContentFlagInfo{
+		Source:    flag.Source,
+		Reason:    flag.Reason,
+		Category:  flag.Category,
+		CreatedAt: flag.CreatedAt,
+	}
+}
@@ -0,0 +1,53 @@
+package response
+
+import (
+	"social-platform-backend/internal/domain/model"
+	"time"
+)
+
+type ModeratorInvitationResponse struct {
+	ID            uint64      `json:"id"`
+	CommunityID   uint64      `json:"communityId"`
+	CommunityName string      `json:"communityName,omitempty"`
+	Inviter       *AuthorInfo `json:"inviter,omitempty"`
+	Invitee       *AuthorInfo `json:"invitee,omitempty"`
+	Type          string      `json:"type"`
+	Role          string      `json:"role"`
+	RoleID        *uint64     `json:"roleId,omitempty"`
+	RoleName      *string     `json:"roleName,omitempty"`
+	Status        string      `json:"status"`
+	ExpiresAt     time.Time   `json:"expiresAt"`
+	CreatedAt     time.Time   `json:"createdAt"`
+	RespondedAt   *time.Time  `json:"respondedAt,omitempty"`
+}
+
+func NewModeratorInvitationResponse(invitation *model.ModeratorInvitation) *ModeratorInvitationResponse {
+	resp := &ModeratorInvitationResponse{
+		ID:          invitation.ID,
+		CommunityID: invitation.CommunityID,
+		Inviter:     newEditorInfo(invitation.Inviter),
+		Invitee:     newEditorInfo(invitation.Invitee),
+		Type:        invitation.Type,
+		Role:        invitation.Role,
+		RoleID:      invitation.RoleID,
+		Status:      invitation.Status,
+		ExpiresAt:   invitation.ExpiresAt,
+		CreatedAt:   invitation.CreatedAt,
+		RespondedAt: invitation.RespondedAt,
+	}
+	if invitation.Community != nil {
+		resp.CommunityName = invitation.Community.Name
+	}
+	if invitation.CustomRole != nil {
+		resp.RoleName = &invitation.CustomRole.Name
+	}
+	return resp
+}
+
+func NewModeratorInvitationResponses(invitations []*model.ModeratorInvitation) []*ModeratorInvitationResponse {
+	responses := make([]*ModeratorInvitationResponse, len(invitations))
+	for i, invitation := range invitations {
+		responses[i] = NewModeratorInvitationResponse(invitation)
+	}
+	return responses
+}
@@ -0,0 +1,68 @@
+package response
+
+import (
+	"social-platform-backend/internal/domain/model"
+	"time"
+)
+
+type PostAnalyticsResponse struct {
+	PostID       uint64 `json:"postId"`
+	Views        int64  `json:"views"`
+	Vote         int64  `json:"vote"`
+	CommentCount int64  `json:"commentCount"`
+	SaveCount    int64  `json:"saveCount"`
+	// Bucket size of the timelines, hour or day
+	Interval        string                    `json:"interval"`
+	From            time.Time                 `json:"from"`
+	ViewTimeline    []*ActivityBucketResponse `json:"viewTimeline"`
+	VoteTimeline    []*VoteBucketResponse     `json:"voteTimeline"`
+	CommentTimeline []*ActivityBucketResponse `json:"commentTimeline"`
+	Referrals       []*ReferralResponse       `json:"referrals"`
+}
+
+type ActivityBucketResponse struct {
+	Time  time.Time `json:"time"`
+	Count int64     `json:"count"`
+}
+
+type VoteBucketResponse struct {
+	Time      time.Time `json:"time"`
+	Upvotes   int64     `json:"upvotes"`
+	Downvotes int64     `json:"downvotes"`
+}
+
+// ReferralResponse has no community for views that did not come from a community feed
+type ReferralResponse struct {
+	CommunityID   *uint64 `json:"communityId,omitempty"`
+	CommunityName *string `json:"communityName,omitempty"`
+	Views         int64   `json:"views"`
+}
+
+func NewActivityBucketResponses(buckets []*model.PostActivityBucket) []*ActivityBucketResponse {
+	responses := make([]*ActivityBucketResponse, len(buckets))
+	for i, bucket := range buckets {
+		responses[i] = &ActivityBucketResponse{Time: bucket.BucketStart, Count: bucket.Count}
+	}
+	return responses
+}
+
+func NewVoteBucketResponses(buckets []*model.PostVoteBucket) []*VoteBucketResponse {
+	responses := make([]*VoteBucketResponse, len(buckets))
+	for i, bucket := range buckets {
+		responses[i] = &VoteBucketResponse{Time: bucket.BucketStart, Upvotes: bucket.Upvotes, Downvotes: bucket.Downvotes}
+	}
+	return responses
+}
+
+func NewReferralResponses(referrals []*model.PostReferral) []*ReferralResponse {
+	responses := make([]*ReferralResponse, len(referrals))
+	for i, referral := range referrals {
+		responses[i] = &ReferralResponse{Views: referral.Views}
+		if referral.CommunityID != 0 {
+			communityID := referral.CommunityID
+			responses[i].CommunityID = &communityID
+			responses[i].CommunityName = referral.CommunityName
+		}
+	}
+	return responses
+}
@@ -7,6 +7,7 @@ type ReporterInfo struct {
 	Username string   `json:"username"`
 	Avatar   *string  `json:"avatar,omitempty"`
 	Reasons  []string `json:"reasons"`
+	RuleIDs  []int64  `json:"ruleIds"`
 	Note     *string  `json:"note,omitempty"`
 }
 
@@ -36,12 +37,16 @@ func NewPostReportResponse(postID uint64, postTitle string, author *model.User)
 	}
 }
 
-func NewReporterInfo(reporter *model.User, reasons []string, note *string) ReporterInfo {
+func NewReporterInfo(reporter *model.User, reasons []string, ruleIDs []int64, note *string) ReporterInfo {
+	if ruleIDs == nil {
+		ruleIDs = []int64{}
+	}
 	return ReporterInfo{
 		ID:       reporter.ID,
 		Username: reporter.Username,
 		Avatar:   reporter.Avatar,
 		Reasons:  reasons,
+		RuleIDs:  ruleIDs,
 		Note:     note,
 	}
 }
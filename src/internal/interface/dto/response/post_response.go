@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"log"
 	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/package/constant"
 	"social-platform-backend/package/template/payload"
+	"social-platform-backend/package/util"
 	"time"
 
 	"github.com/lib/pq"
@@ -27,86 +29,300 @@ type AuthorInfo struct {
 }
 
 type PollOptionResponse struct {
-	ID     int      `json:"id"`
-	Text   string   `json:"text"`
-	Votes  int      `json:"votes"`
-	Voters []uint64 `json:"voters"`
+	ID    int    `json:"id"`
+	Text  string `json:"text"`
+	Votes int64  `json:"votes"`
 }
 
 type PollDataResponse struct {
-	Question       string               `json:"question"`
-	Options        []PollOptionResponse `json:"options"`
-	MultipleChoice bool                 `json:"multipleChoice"`
-	ExpiresAt      *time.Time           `json:"expiresAt,omitempty"`
-	TotalVotes     int                  `json:"totalVotes"`
+	Question              string               `json:"question"`
+	Options               []PollOptionResponse `json:"options"`
+	MultipleChoice        bool                 `json:"multipleChoice"`
+	RankedChoice          bool                 `json:"rankedChoice"`
+	HideResultsUntilClose bool                 `json:"hideResultsUntilClose"`
+	AllowWriteIns         bool                 `json:"allowWriteIns"`
+	ExpiresAt             *time.Time           `json:"expiresAt,omitempty"`
+	IsClosed              bool                 `json:"isClosed"`
+	ClosedAt              *time.Time           `json:"closedAt,omitempty"`
+	// Option votes are zero while the results are hidden
+	ResultsHidden bool  `json:"resultsHidden"`
+	TotalVotes    int64 `json:"totalVotes"`
+	// Options the requesting user voted for, in rank order for ranked choice polls.
+	// Empty for anonymous requests.
+	SelectedOptionIDs []int `json:"selectedOptionIds"`
+	// Instant-runoff rounds of a ranked choice poll, only set on the post detail
+	Runoff *util.RunoffResult `json:"runoff,omitempty"`
 }
 
-func convertPollDataToResponse(pollDataRaw *json.RawMessage) *PollDataResponse {
-	if pollDataRaw == nil {
+// convertPollDataToResponse takes the poll definition from poll_data and the results
+// from the preloaded poll_options and the requesting user's poll_votes. Voter IDs are
+// never exposed.
+func convertPollDataToResponse(post *model.Post) *PollDataResponse {
+	if post.PollData == nil {
 		return nil
 	}
 
 	var pollData payload.PollData
-	if err := json.Unmarshal(*pollDataRaw, &pollData); err != nil {
-		log.Printf("[Err] Error unmarshalling poll data in convertPollDataToResponse: %v, raw data: %s", err, string(*pollDataRaw))
+	if err := json.Unmarshal(*post.PollData, &pollData); err != nil {
+		log.Printf("[Err] Error unmarshalling poll data in convertPollDataToResponse: %v, raw data: %s", err, string(*post.PollData))
 		return nil
 	}
 
+	isClosed := pollData.ExpiresAt != nil && !time.Now().Before(*pollData.ExpiresAt)
+	var closedAt *time.Time
+	if post.Poll != nil && post.Poll.ClosedAt != nil {
+		isClosed = true
+		closedAt = post.Poll.ClosedAt
+	} else if isClosed {
+		closedAt = pollData.ExpiresAt
+	}
+	resultsHidden := pollData.HideResultsUntilClose && !isClosed
+
+	voteCounts := make(map[int]int64, len(post.PollOptions))
+	for _, option := range post.PollOptions {
+		voteCounts[option.OptionID] = option.VoteCount
+	}
+
 	options := make([]PollOptionResponse, len(pollData.Options))
 	for i, opt := range pollData.Options {
+		votes := voteCounts[opt.ID]
+		// Polls that were never voted on since votes moved to poll_votes
+		if len(post.PollOptions) == 0 {
+			votes = int64(len(opt.Voters))
+		}
+		if resultsHidden {
+			votes = 0
+		}
 		options[i] = PollOptionResponse{
-			ID:     opt.ID,
-			Text:   opt.Text,
-			Votes:  opt.Votes,
-			Voters: opt.Voters,
+			ID:    opt.ID,
+			Text:  opt.Text,
+			Votes: votes,
 		}
 	}
 
+	totalVotes := post.PollVoterCount
+	if len(post.PollOptions) == 0 {
+		totalVotes = int64(pollData.TotalVotes)
+	}
+
+	selectedOptionIDs := make([]int, len(post.UserPollVotes))
+	for i, vote := range post.UserPollVotes {
+		selectedOptionIDs[i] = vote.OptionID
+	}
+
 	return &PollDataResponse{
-		Question:       pollData.Question,
-		Options:        options,
-		MultipleChoice: pollData.MultipleChoice,
-		ExpiresAt:      pollData.ExpiresAt,
-		TotalVotes:     pollData.TotalVotes,
+		Question:              pollData.Question,
+		Options:               options,
+		MultipleChoice:        pollData.MultipleChoice,
+		RankedChoice:          pollData.RankedChoice,
+		HideResultsUntilClose: pollData.HideResultsUntilClose,
+		AllowWriteIns:         pollData.AllowWriteIns,
+		ExpiresAt:             pollData.ExpiresAt,
+		IsClosed:              isClosed,
+		ClosedAt:              closedAt,
+		ResultsHidden:         resultsHidden,
+		TotalVotes:            totalVotes,
+		SelectedOptionIDs:     selectedOptionIDs,
 	}
 }
 
-type PostListResponse struct {
-	ID           uint64            `json:"id"`
-	CommunityID  uint64            `json:"communityId"`
-	Community    *CommunityInfo    `json:"community,omitempty"`
-	AuthorID     uint64            `json:"authorId"`
-	Author       *AuthorInfo       `json:"author,omitempty"`
-	Title        string            `json:"title"`
-	Type         string            `json:"type"`
-	Content      string            `json:"content"`
-	URL          *string           `json:"url,omitempty"`
-	MediaURLs    *pq.StringArray   `json:"mediaUrls,omitempty"`
-	PollData     *PollDataResponse `json:"pollData,omitempty"`
-	Tags         *pq.StringArray   `json:"tags,omitempty"`
-	Vote         int64             `json:"vote"`
-	IsVoted      *bool             `json:"isVoted,omitempty"`
-	CommentCount int64             `json:"commentCount"`
-	CreatedAt    time.Time         `json:"createdAt"`
-	UpdatedAt    *time.Time        `json:"updatedAt,omitempty"`
+// PollWriteInResponse is a suggested option waiting for the poll author
+type PollWriteInResponse struct {
+	ID          int         `json:"id"`
+	Text        string      `json:"text"`
+	SuggestedBy *AuthorInfo `json:"suggestedBy,omitempty"`
+	CreatedAt   time.Time   `json:"createdAt"`
 }
 
-func NewPostListResponse(post *model.Post) *PostListResponse {
-	response := &PostListResponse{
+func NewPollWriteInResponse(option *model.PollOption) *PollWriteInResponse {
+	return &PollWriteInResponse{
+		ID:          option.OptionID,
+		Text:        option.Text,
+		SuggestedBy: newEditorInfo(option.Suggester),
+		CreatedAt:   option.CreatedAt,
+	}
+}
+
+// redactPollData strips the voter lists polls kept in poll_data before votes moved
+// to poll_votes
+func redactPollData(rawPollData *json.RawMessage) *json.RawMessage {
+	if rawPollData == nil {
+		return nil
+	}
+
+	var pollData payload.PollData
+	if err := json.Unmarshal(*rawPollData, &pollData); err != nil {
+		return nil
+	}
+	for i := range pollData.Options {
+		pollData.Options[i].Voters = nil
+	}
+
+	redacted, err := json.Marshal(pollData)
+	if err != nil {
+		return nil
+	}
+	rawMessage := json.RawMessage(redacted)
+	return &rawMessage
+}
+
+// CrosspostParentInfo describes the original post a crosspost was made from
+type CrosspostParentInfo struct {
+	ID        uint64         `json:"id"`
+	Title     string         `json:"title"`
+	Community *CommunityInfo `json:"community,omitempty"`
+	Author    *AuthorInfo    `json:"author,omitempty"`
+	CreatedAt time.Time      `json:"createdAt"`
+}
+
+// PostSummaryInfo describes the post around a comment opened from a permalink
+type PostSummaryInfo struct {
+	ID           uint64         `json:"id"`
+	Title        string         `json:"title"`
+	Community    *CommunityInfo `json:"community,omitempty"`
+	Author       *AuthorInfo    `json:"author,omitempty"`
+	CommentCount int64          `json:"commentCount"`
+	IsLocked     bool           `json:"isLocked"`
+	CreatedAt    time.Time      `json:"createdAt"`
+}
+
+func NewPostSummaryInfo(post *model.Post) *PostSummaryInfo {
+	info := &PostSummaryInfo{
 		ID:           post.ID,
-		CommunityID:  post.CommunityID,
-		AuthorID:     post.AuthorID,
 		Title:        post.Title,
-		Type:         post.Type,
-		Content:      post.Content,
-		URL:          post.URL,
-		MediaURLs:    post.MediaURLs,
-		PollData:     convertPollDataToResponse(post.PollData),
-		Tags:         post.Tags,
-		Vote:         post.Vote,
 		CommentCount: post.CommentCount,
+		IsLocked:     post.LockedAt != nil,
 		CreatedAt:    post.CreatedAt,
-		UpdatedAt:    post.UpdatedAt,
+	}
+	if post.Community != nil {
+		info.Community = &CommunityInfo{
+			ID:               post.Community.ID,
+			Name:             post.Community.Name,
+			Avatar:           post.Community.CommunityAvatar,
+			ShortDescription: post.Community.ShortDescription,
+		}
+	}
+	if post.Author != nil {
+		info.Author = &AuthorInfo{
+			ID:        post.Author.ID,
+			Username:  post.Author.Username,
+			Avatar:    post.Author.Avatar,
+			Karma:     post.Author.Karma,
+			CreatedAt: post.Author.CreatedAt,
+		}
+	}
+	return info
+}
+
+func newCrosspostParentInfo(parent *model.Post) *CrosspostParentInfo {
+	info := &CrosspostParentInfo{
+		ID:        parent.ID,
+		Title:     parent.Title,
+		CreatedAt: parent.CreatedAt,
+	}
+	if parent.Community != nil {
+		info.Community = &CommunityInfo{
+			ID:               parent.Community.ID,
+			Name:             parent.Community.Name,
+			Avatar:           parent.Community.CommunityAvatar,
+			ShortDescription: parent.Community.ShortDescription,
+		}
+	}
+	if parent.Author != nil {
+		info.Author = &AuthorInfo{
+			ID:        parent.Author.ID,
+			Username:  parent.Author.Username,
+			Avatar:    parent.Author.Avatar,
+			Karma:     parent.Author.Karma,
+			CreatedAt: parent.Author.CreatedAt,
+		}
+	}
+	return info
+}
+
+type LinkPreviewResponse struct {
+	Title       *string `json:"title,omitempty"`
+	Description *string `json:"description,omitempty"`
+	ImageURL    *string `json:"imageUrl,omitempty"`
+	SiteName    *string `json:"siteName,omitempty"`
+}
+
+// newLinkPreviewResponse returns nil until the page was fetched successfully
+func newLinkPreviewResponse(preview *model.LinkPreview) *LinkPreviewResponse {
+	if preview == nil || preview.Status != constant.LINK_PREVIEW_STATUS_OK {
+		return nil
+	}
+	return &LinkPreviewResponse{
+		Title:       preview.Title,
+		Description: preview.Description,
+		ImageURL:    preview.ImageURL,
+		SiteName:    preview.SiteName,
+	}
+}
+
+type PostListResponse struct {
+	ID             uint64               `json:"id"`
+	CommunityID    uint64               `json:"communityId"`
+	Community      *CommunityInfo       `json:"community,omitempty"`
+	AuthorID       uint64               `json:"authorId"`
+	Author         *AuthorInfo          `json:"author,omitempty"`
+	Title          string               `json:"title"`
+	Type           string               `json:"type"`
+	Content        string               `json:"content"`
+	URL            *string              `json:"url,omitempty"`
+	LinkPreview    *LinkPreviewResponse `json:"linkPreview,omitempty"`
+	MediaURLs      *pq.StringArray      `json:"mediaUrls,omitempty"`
+	PollData       *PollDataResponse    `json:"pollData,omitempty"`
+	Tags           *pq.StringArray      `json:"tags,omitempty"`
+	Flair          *string              `json:"flair,omitempty"`
+	Vote           int64                `json:"vote"`
+	IsVoted        *bool                `json:"isVoted,omitempty"`
+	CommentCount   int64                `json:"commentCount"`
+	CreatedAt      time.Time            `json:"createdAt"`
+	PublishedAt    *time.Time           `json:"publishedAt,omitempty"`
+	UpdatedAt      *time.Time           `json:"updatedAt,omitempty"`
+	IsEdited       bool                 `json:"isEdited"`
+	EditedAt       *time.Time           `json:"editedAt,omitempty"`
+	IsPinned       bool                 `json:"isPinned"`
+	PinOrder       *int                 `json:"pinOrder,omitempty"`
+	IsAnnouncement bool                 `json:"isAnnouncement"`
+	IsLocked       bool                 `json:"isLocked"`
+	LockReason     *string              `json:"lockReason,omitempty"`
+
+	CrosspostParent *CrosspostParentInfo `json:"crosspostParent,omitempty"`
+
+	Reactions []*ReactionCountResponse `json:"reactions"`
+	Mentions  []*MentionResponse       `json:"mentions,omitempty"`
+}
+
+func NewPostListResponse(post *model.Post) *PostListResponse {
+	response := &PostListResponse{
+		ID:             post.ID,
+		CommunityID:    post.CommunityID,
+		AuthorID:       post.AuthorID,
+		Title:          post.Title,
+		Type:           post.Type,
+		Content:        post.Content,
+		URL:            post.URL,
+		LinkPreview:    newLinkPreviewResponse(post.LinkPreview),
+		MediaURLs:      post.MediaURLs,
+		PollData:       convertPollDataToResponse(post),
+		Tags:           post.Tags,
+		Flair:          post.Flair,
+		Vote:           post.Vote,
+		CommentCount:   post.CommentCount,
+		CreatedAt:      post.CreatedAt,
+		PublishedAt:    post.PublishedAt,
+		UpdatedAt:      post.UpdatedAt,
+		IsEdited:       post.EditedAt != nil,
+		EditedAt:       post.EditedAt,
+		IsPinned:       post.PinOrder != nil,
+		PinOrder:       post.PinOrder,
+		IsAnnouncement: post.IsAnnouncement,
+		IsLocked:       post.LockedAt != nil,
+		LockReason:     post.LockReason,
+		Reactions:      newReactionCountResponses(post.ReactionCounts),
+		Mentions:       newMentionResponses(post.Mentions),
 	}
 
 	if post.UserVote != nil {
@@ -133,41 +349,74 @@ func NewPostListResponse(post *model.Post) *PostListResponse {
 		}
 	}
 
+	if post.CrosspostParent != nil {
+		response.CrosspostParent = newCrosspostParentInfo(post.CrosspostParent)
+	}
+
 	return response
 }
 
 type PostDetailResponse struct {
-	ID           uint64            `json:"id"`
-	Community    *CommunityInfo    `json:"community,omitempty"`
-	Author       *AuthorInfo       `json:"author,omitempty"`
-	Title        string            `json:"title"`
-	Type         string            `json:"type"`
-	Content      string            `json:"content"`
-	URL          *string           `json:"url,omitempty"`
-	MediaURLs    *pq.StringArray   `json:"mediaUrls,omitempty"`
-	PollData     *PollDataResponse `json:"pollData,omitempty"`
-	Tags         *pq.StringArray   `json:"tags,omitempty"`
-	Vote         int64             `json:"vote"`
-	IsVoted      *bool             `json:"isVoted,omitempty"`
-	CommentCount int64             `json:"commentCount"`
-	CreatedAt    time.Time         `json:"createdAt"`
-	UpdatedAt    *time.Time        `json:"updatedAt,omitempty"`
+	ID             uint64               `json:"id"`
+	Community      *CommunityInfo       `json:"community,omitempty"`
+	Author         *AuthorInfo          `json:"author,omitempty"`
+	Title          string               `json:"title"`
+	Type           string               `json:"type"`
+	Content        string               `json:"content"`
+	URL            *string              `json:"url,omitempty"`
+	LinkPreview    *LinkPreviewResponse `json:"linkPreview,omitempty"`
+	MediaURLs      *pq.StringArray      `json:"mediaUrls,omitempty"`
+	PollData       *PollDataResponse    `json:"pollData,omitempty"`
+	Tags           *pq.StringArray      `json:"tags,omitempty"`
+	Flair          *string              `json:"flair,omitempty"`
+	Vote           int64                `json:"vote"`
+	IsVoted        *bool                `json:"isVoted,omitempty"`
+	CommentCount   int64                `json:"commentCount"`
+	CreatedAt      time.Time            `json:"createdAt"`
+	PublishedAt    *time.Time           `json:"publishedAt,omitempty"`
+	UpdatedAt      *time.Time           `json:"updatedAt,omitempty"`
+	IsEdited       bool                 `json:"isEdited"`
+	EditedAt       *time.Time           `json:"editedAt,omitempty"`
+	IsPinned       bool                 `json:"isPinned"`
+	PinOrder       *int                 `json:"pinOrder,omitempty"`
+	IsAnnouncement bool                 `json:"isAnnouncement"`
+	IsLocked       bool                 `json:"isLocked"`
+	LockReason     *string              `json:"lockReason,omitempty"`
+
+	CrosspostParent *CrosspostParentInfo `json:"crosspostParent,omitempty"`
+	CrosspostCount  int64                `json:"crosspostCount"`
+
+	Reactions []*ReactionCountResponse `json:"reactions"`
+	Mentions  []*MentionResponse       `json:"mentions,omitempty"`
 }
 
 func NewPostDetailResponse(post *model.Post) *PostDetailResponse {
 	response := &PostDetailResponse{
-		ID:           post.ID,
-		Title:        post.Title,
-		Type:         post.Type,
-		Content:      post.Content,
-		URL:          post.URL,
-		MediaURLs:    post.MediaURLs,
-		PollData:     convertPollDataToResponse(post.PollData),
-		Tags:         post.Tags,
-		Vote:         post.Vote,
-		CommentCount: post.CommentCount,
-		CreatedAt:    post.CreatedAt,
-		UpdatedAt:    post.UpdatedAt,
+		ID:             post.ID,
+		Title:          post.Title,
+		Type:           post.Type,
+		Content:        post.Content,
+		URL:            post.URL,
+		LinkPreview:    newLinkPreviewResponse(post.LinkPreview),
+		MediaURLs:      post.MediaURLs,
+		PollData:       convertPollDataToResponse(post),
+		Tags:           post.Tags,
+		Flair:          post.Flair,
+		Vote:           post.Vote,
+		CommentCount:   post.CommentCount,
+		CreatedAt:      post.CreatedAt,
+		PublishedAt:    post.PublishedAt,
+		UpdatedAt:      post.UpdatedAt,
+		IsEdited:       post.EditedAt != nil,
+		EditedAt:       post.EditedAt,
+		IsPinned:       post.PinOrder != nil,
+		PinOrder:       post.PinOrder,
+		IsAnnouncement: post.IsAnnouncement,
+		IsLocked:       post.LockedAt != nil,
+		LockReason:     post.LockReason,
+		CrosspostCount: post.CrosspostCount,
+		Reactions:      newReactionCountResponses(post.ReactionCounts),
+		Mentions:       newMentionResponses(post.Mentions),
 	}
 
 	if post.UserVote != nil {
@@ -193,45 +442,66 @@ func NewPostDetailResponse(post *model.Post) *PostDetailResponse {
 			CreatedAt: post.Author.CreatedAt,
 		}
 	}
+	if post.CrosspostParent != nil {
+		response.CrosspostParent = newCrosspostParentInfo(post.CrosspostParent)
+	}
 	return response
 }
 
 type CommunityPostListResponse struct {
-	ID           uint64            `json:"id"`
-	CommunityID  uint64            `json:"communityId"`
-	AuthorID     uint64            `json:"authorId"`
-	Author       *AuthorInfo       `json:"author,omitempty"`
-	Title        string            `json:"title"`
-	Type         string            `json:"type"`
-	Content      string            `json:"content"`
-	URL          *string           `json:"url,omitempty"`
-	MediaURLs    *pq.StringArray   `json:"mediaUrls,omitempty"`
-	PollData     *PollDataResponse `json:"pollData,omitempty"`
-	Tags         *pq.StringArray   `json:"tags,omitempty"`
-	Status       string            `json:"status"`
-	Vote         int64             `json:"vote"`
-	CommentCount int64             `json:"commentCount"`
-	CreatedAt    time.Time         `json:"createdAt"`
-	UpdatedAt    *time.Time        `json:"updatedAt,omitempty"`
+	ID             uint64            `json:"id"`
+	CommunityID    uint64            `json:"communityId"`
+	AuthorID       uint64            `json:"authorId"`
+	Author         *AuthorInfo       `json:"author,omitempty"`
+	Title          string            `json:"title"`
+	Type           string            `json:"type"`
+	Content        string            `json:"content"`
+	URL            *string           `json:"url,omitempty"`
+	MediaURLs      *pq.StringArray   `json:"mediaUrls,omitempty"`
+	PollData       *PollDataResponse `json:"pollData,omitempty"`
+	Tags           *pq.StringArray   `json:"tags,omitempty"`
+	Flair          *string           `json:"flair,omitempty"`
+	Status         string            `json:"status"`
+	Vote           int64             `json:"vote"`
+	CommentCount   int64             `json:"commentCount"`
+	CreatedAt      time.Time         `json:"createdAt"`
+	PublishedAt    *time.Time        `json:"publishedAt,omitempty"`
+	UpdatedAt      *time.Time        `json:"updatedAt,omitempty"`
+	IsEdited       bool              `json:"isEdited"`
+	EditedAt       *time.Time        `json:"editedAt,omitempty"`
+	IsPinned       bool              `json:"isPinned"`
+	PinOrder       *int              `json:"pinOrder,omitempty"`
+	IsAnnouncement bool              `json:"isAnnouncement"`
+	IsLocked       bool              `json:"isLocked"`
+	LockReason     *string           `json:"lockReason,omitempty"`
 }
 
 func NewCommunityPostListResponse(post *model.Post) *CommunityPostListResponse {
 	response := &CommunityPostListResponse{
-		ID:           post.ID,
-		CommunityID:  post.CommunityID,
-		AuthorID:     post.AuthorID,
-		Title:        post.Title,
-		Type:         post.Type,
-		Content:      post.Content,
-		URL:          post.URL,
-		MediaURLs:    post.MediaURLs,
-		PollData:     convertPollDataToResponse(post.PollData),
-		Tags:         post.Tags,
-		Status:       post.Status,
-		Vote:         post.Vote,
-		CommentCount: post.CommentCount,
-		CreatedAt:    post.CreatedAt,
-		UpdatedAt:    post.UpdatedAt,
+		ID:             post.ID,
+		CommunityID:    post.CommunityID,
+		AuthorID:       post.AuthorID,
+		Title:          post.Title,
+		Type:           post.Type,
+		Content:        post.Content,
+		URL:            post.URL,
+		MediaURLs:      post.MediaURLs,
+		PollData:       convertPollDataToResponse(post),
+		Tags:           post.Tags,
+		Flair:          post.Flair,
+		Status:         post.Status,
+		Vote:           post.Vote,
+		CommentCount:   post.CommentCount,
+		CreatedAt:      post.CreatedAt,
+		PublishedAt:    post.PublishedAt,
+		UpdatedAt:      post.UpdatedAt,
+		IsEdited:       post.EditedAt != nil,
+		EditedAt:       post.EditedAt,
+		IsPinned:       post.PinOrder != nil,
+		PinOrder:       post.PinOrder,
+		IsAnnouncement: post.IsAnnouncement,
+		IsLocked:       post.LockedAt != nil,
+		LockReason:     post.LockReason,
 	}
 	if post.Author != nil {
 		response.Author = &AuthorInfo{
@@ -246,6 +516,50 @@ func NewCommunityPostListResponse(post *model.Post) *CommunityPostListResponse {
 	return response
 }
 
+type DraftPostResponse struct {
+	ID          uint64           `json:"id"`
+	CommunityID uint64           `json:"communityId"`
+	Community   *CommunityInfo   `json:"community,omitempty"`
+	Title       string           `json:"title"`
+	Type        string           `json:"type"`
+	Content     string           `json:"content"`
+	URL         *string          `json:"url,omitempty"`
+	MediaURLs   *pq.StringArray  `json:"mediaUrls,omitempty"`
+	PollData    *json.RawMessage `json:"pollData,omitempty"`
+	Tags        *pq.StringArray  `json:"tags,omitempty"`
+	Status      string           `json:"status"`
+	PublishAt   *time.Time       `json:"publishAt,omitempty"`
+	CreatedAt   time.Time        `json:"createdAt"`
+	UpdatedAt   *time.Time       `json:"updatedAt,omitempty"`
+}
+
+func NewDraftPostResponse(post *model.Post) *DraftPostResponse {
+	response := &DraftPostResponse{
+		ID:          post.ID,
+		CommunityID: post.CommunityID,
+		Title:       post.Title,
+		Type:        post.Type,
+		Content:     post.Content,
+		URL:         post.URL,
+		MediaURLs:   post.MediaURLs,
+		PollData:    post.PollData,
+		Tags:        post.Tags,
+		Status:      post.Status,
+		PublishAt:   post.PublishAt,
+		CreatedAt:   post.CreatedAt,
+		UpdatedAt:   post.UpdatedAt,
+	}
+	if post.Community != nil {
+		response.Community = &CommunityInfo{
+			ID:               post.Community.ID,
+			Name:             post.Community.Name,
+			Avatar:           post.Community.CommunityAvatar,
+			ShortDescription: post.Community.ShortDescription,
+		}
+	}
+	return response
+}
+
 type SavedPostResponse struct {
 	PostID     uint64         `json:"postId"`
 	Title      string         `json:"title"`
@@ -253,6 +567,12 @@ type SavedPostResponse struct {
 	Author     AuthorInfo     `json:"author"`
 	IsFollowed bool           `json:"isFollowed"`
 	CreatedAt  time.Time      `json:"createdAt"`
+
+	Note          *string   `json:"note,omitempty"`
+	IsPostEdited  bool      `json:"isPostEdited"`
+	IsPostDeleted bool      `json:"isPostDeleted"`
+	CollectionIDs []uint64  `json:"collectionIds,omitempty"`
+	SavedAt       time.Time `json:"savedAt"`
 }
 
 func NewSavedPostResponse(savedPost *model.UserSavedPost) *SavedPostResponse {
@@ -264,8 +584,12 @@ func NewSavedPostResponse(savedPost *model.UserSavedPost) *SavedPostResponse {
 			Username: savedPost.AuthorName,
 			Avatar:   savedPost.AuthorAvatar,
 		},
-		IsFollowed: savedPost.IsFollowed,
-		CreatedAt:  savedPost.PostCreatedAt,
+		IsFollowed:    savedPost.IsFollowed,
+		CreatedAt:     savedPost.PostCreatedAt,
+		Note:          savedPost.Note,
+		IsPostEdited:  savedPost.PostEditedAt != nil,
+		IsPostDeleted: savedPost.PostDeletedAt != nil,
+		SavedAt:       savedPost.CreatedAt,
 	}
 
 	if savedPost.Community != nil {
@@ -276,5 +600,9 @@ func NewSavedPostResponse(savedPost *model.UserSavedPost) *SavedPostResponse {
 		}
 	}
 
+	for _, item := range savedPost.CollectionItems {
+		response.CollectionIDs = append(response.CollectionIDs, item.CollectionID)
+	}
+
 	return response
 }
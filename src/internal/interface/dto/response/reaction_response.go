@@ -0,0 +1,74 @@
+package response
+
+import "social-platform-backend/internal/domain/model"
+
+type ReactionCountResponse struct {
+	Emoji   string `json:"emoji"`
+	Count   int64  `json:"count"`
+	Reacted bool   `json:"reacted"`
+}
+
+// newReactionCountResponses always returns a list so clients get [] rather than null
+func newReactionCountResponses(counts []*model.ReactionCount) []*ReactionCountResponse {
+	responses := make([]*ReactionCountResponse, len(counts))
+	for i, count := range counts {
+		responses[i] = &ReactionCountResponse{
+			Emoji:   count.Emoji,
+			Count:   count.Count,
+			Reacted: count.Reacted,
+		}
+	}
+	return responses
+}
+
+type ToggleReactionResponse struct {
+	Emoji     string                   `json:"emoji"`
+	Added     bool                     `json:"added"`
+	Reactions []*ReactionCountResponse `json:"reactions"`
+}
+
+func NewToggleReactionResponse(emoji string, added bool, counts []*model.ReactionCount) *ToggleReactionResponse {
+	return &ToggleReactionResponse{
+		Emoji:     emoji,
+		Added:     added,
+		Reactions: newReactionCountResponses(counts),
+	}
+}
+
+type ReactionEmojisResponse struct {
+	Emojis   []string `json:"emojis"`
+	IsCustom bool     `json:"isCustom"`
+}
+
+type ReactionTotalResponse struct {
+	Emoji string `json:"emoji"`
+	Count int64  `json:"count"`
+}
+
+// ReactionUpdatedEvent is sent over SSE when someone adds or removes a reaction. The
+// totals are the same for every receiver, UserID tells who toggled the emoji.
+type ReactionUpdatedEvent struct {
+	TargetType     string                   `json:"targetType"`
+	TargetID       uint64                   `json:"targetId"`
+	PostID         *uint64                  `json:"postId,omitempty"`
+	ConversationID *uint64                  `json:"conversationId,omitempty"`
+	UserID         uint64                   `json:"userId"`
+	Emoji          string                   `json:"emoji"`
+	Added          bool                     `json:"added"`
+	Reactions      []*ReactionTotalResponse `json:"reactions"`
+}
+
+func NewReactionUpdatedEvent(reaction *model.Reaction, added bool, counts []*model.ReactionCount) ReactionUpdatedEvent {
+	totals := make([]*ReactionTotalResponse, len(counts))
+	for i, count := range counts {
+		totals[i] = &ReactionTotalResponse{Emoji: count.Emoji, Count: count.Count}
+	}
+	return ReactionUpdatedEvent{
+		TargetType: reaction.TargetType,
+		TargetID:   reaction.TargetID,
+		UserID:     reaction.UserID,
+		Emoji:      reaction.Emoji,
+		Added:      added,
+		Reactions:  totals,
+	}
+}
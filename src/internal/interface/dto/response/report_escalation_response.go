@@ -0,0 +1,46 @@
+package response
+
+import (
+	"social-platform-backend/internal/domain/model"
+	"time"
+)
+
+type ReportEscalationResponse struct {
+	ID               uint64         `json:"id"`
+	Community        *CommunityInfo `json:"community,omitempty"`
+	TargetType       string         `json:"targetType"`
+	TargetID         uint64         `json:"targetId"`
+	EscalatedBy      *AuthorInfo    `json:"escalatedBy,omitempty"`
+	Note             *string        `json:"note,omitempty"`
+	Status           string         `json:"status"`
+	ResolvedBy       *uint64        `json:"resolvedBy,omitempty"`
+	Resolution       *string        `json:"resolution,omitempty"`
+	ResolutionReason *string        `json:"resolutionReason,omitempty"`
+	CreatedAt        time.Time      `json:"createdAt"`
+	ResolvedAt       *time.Time     `json:"resolvedAt,omitempty"`
+}
+
+func NewReportEscalationResponse(escalation *model.ReportEscalation) *ReportEscalationResponse {
+	resp := &ReportEscalationResponse{
+		ID:               escalation.ID,
+		TargetType:       escalation.TargetType,
+		TargetID:         escalation.TargetID,
+		EscalatedBy:      newEditorInfo(escalation.Escalator),
+		Note:             escalation.Note,
+		Status:           escalation.Status,
+		ResolvedBy:       escalation.ResolvedBy,
+		Resolution:       escalation.Resolution,
+		ResolutionReason: escalation.ResolutionReason,
+		CreatedAt:        escalation.CreatedAt,
+		ResolvedAt:       escalation.ResolvedAt,
+	}
+	if escalation.Community != nil {
+		resp.Community = &CommunityInfo{
+			ID:               escalation.Community.ID,
+			Name:             escalation.Community.Name,
+			Avatar:           escalation.Community.CommunityAvatar,
+			ShortDescription: escalation.Community.ShortDescription,
+		}
+	}
+	return resp
+}
@@ -0,0 +1,96 @@
+package response
+
+import (
+	"encoding/json"
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/package/util"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// PostRevisionResponse is a previous version of a post. The diffs describe the edit
+// that replaced it, i.e. this version compared to the next one.
+type PostRevisionResponse struct {
+	ID          uint64           `json:"id"`
+	Editor      *AuthorInfo      `json:"editor,omitempty"`
+	Title       string           `json:"title"`
+	Content     string           `json:"content"`
+	URL         *string          `json:"url,omitempty"`
+	MediaURLs   *pq.StringArray  `json:"mediaUrls,omitempty"`
+	PollData    *json.RawMessage `json:"pollData,omitempty"`
+	Tags        *pq.StringArray  `json:"tags,omitempty"`
+	EditedAt    time.Time        `json:"editedAt"`
+	TitleDiff   []util.DiffLine  `json:"titleDiff"`
+	ContentDiff []util.DiffLine  `json:"contentDiff"`
+}
+
+// NewPostRevisionResponses expects revisions ordered oldest first
+func NewPostRevisionResponses(revisions []*model.PostRevision, current *model.Post) []*PostRevisionResponse {
+	responses := make([]*PostRevisionResponse, len(revisions))
+	for i, revision := range revisions {
+		nextTitle, nextContent := current.Title, current.Content
+		if i+1 < len(revisions) {
+			nextTitle, nextContent = revisions[i+1].Title, revisions[i+1].Content
+		}
+
+		responses[i] = &PostRevisionResponse{
+			ID:          revision.ID,
+			Editor:      newEditorInfo(revision.Editor),
+			Title:       revision.Title,
+			Content:     revision.Content,
+			URL:         revision.URL,
+			MediaURLs:   revision.MediaURLs,
+			PollData:    redactPollData(revision.PollData),
+			Tags:        revision.Tags,
+			EditedAt:    revision.CreatedAt,
+			TitleDiff:   util.DiffLines(revision.Title, nextTitle),
+			ContentDiff: util.DiffLines(revision.Content, nextContent),
+		}
+	}
+	return responses
+}
+
+// CommentRevisionResponse is a previous version of a comment, diffed against the next one
+type CommentRevisionResponse struct {
+	ID          uint64          `json:"id"`
+	Editor      *AuthorInfo     `json:"editor,omitempty"`
+	Content     string          `json:"content"`
+	MediaURL    *string         `json:"mediaUrl,omitempty"`
+	EditedAt    time.Time       `json:"editedAt"`
+	ContentDiff []util.DiffLine `json:"contentDiff"`
+}
+
+// NewCommentRevisionResponses expects revisions ordered oldest first
+func NewCommentRevisionResponses(revisions []*model.CommentRevision, current *model.Comment) []*CommentRevisionResponse {
+	responses := make([]*CommentRevisionResponse, len(revisions))
+	for i, revision := range revisions {
+		nextContent := current.Content
+		if i+1 < len(revisions) {
+			nextContent = revisions[i+1].Content
+		}
+
+		responses[i] = &CommentRevisionResponse{
+			ID:          revision.ID,
+			Editor:      newEditorInfo(revision.Editor),
+			Content:     revision.Content,
+			MediaURL:    revision.MediaURL,
+			EditedAt:    revision.CreatedAt,
+			ContentDiff: util.DiffLines(revision.Content, nextContent),
+		}
+	}
+	return responses
+}
+
+func newEditorInfo(editor *model.User) *AuthorInfo {
+	if editor == nil {
+		return nil
+	}
+	return &AuthorInfo{
+		ID:        editor.ID,
+		Username:  editor.Username,
+		Avatar:    editor.Avatar,
+		Karma:     editor.Karma,
+		CreatedAt: editor.CreatedAt,
+	}
+}
@@ -0,0 +1,71 @@
+package response
+
+import (
+	"social-platform-backend/internal/domain/model"
+	"time"
+)
+
+type SavedPostCollectionResponse struct {
+	ID         uint64     `json:"id"`
+	Name       string     `json:"name"`
+	Position   int        `json:"position"`
+	IsPublic   bool       `json:"isPublic"`
+	ShareToken *string    `json:"shareToken,omitempty"`
+	ItemCount  int64      `json:"itemCount"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  *time.Time `json:"updatedAt,omitempty"`
+}
+
+func NewSavedPostCollectionResponse(collection *model.SavedPostCollection) *SavedPostCollectionResponse {
+	return &SavedPostCollectionResponse{
+		ID:         collection.ID,
+		Name:       collection.Name,
+		Position:   collection.Position,
+		IsPublic:   collection.IsPublic,
+		ShareToken: collection.ShareToken,
+		ItemCount:  collection.ItemCount,
+		CreatedAt:  collection.CreatedAt,
+		UpdatedAt:  collection.UpdatedAt,
+	}
+}
+
+type SharedSavedPostCollectionResponse struct {
+	ID        uint64               `json:"id"`
+	Name      string               `json:"name"`
+	Owner     *AuthorInfo          `json:"owner,omitempty"`
+	ItemCount int64                `json:"itemCount"`
+	Posts     []*SavedPostResponse `json:"posts"`
+}
+
+// NewSharedSavedPostCollectionResponse leaves out the private parts of the owner's
+// saved posts, i.e. notes and the other collections a post is in. The item count is
+// the number of posts visible to visitors, not the collection's own count.
+func NewSharedSavedPostCollectionResponse(collection *model.SavedPostCollection, savedPosts []*model.UserSavedPost, visibleCount int64) *SharedSavedPostCollectionResponse {
+	response := &SharedSavedPostCollectionResponse{
+		ID:        collection.ID,
+		Name:      collection.Name,
+		ItemCount: visibleCount,
+		Posts:     make([]*SavedPostResponse, len(savedPosts)),
+	}
+
+	if collection.User != nil {
+		response.Owner = &AuthorInfo{
+			ID:        collection.User.ID,
+			Username:  collection.User.Username,
+			Avatar:    collection.User.Avatar,
+			Bio:       collection.User.Bio,
+			Karma:     collection.User.Karma,
+			CreatedAt: collection.User.CreatedAt,
+		}
+	}
+
+	for i, savedPost := range savedPosts {
+		post := NewSavedPostResponse(savedPost)
+		post.Note = nil
+		post.CollectionIDs = nil
+		post.IsFollowed = false
+		response.Posts[i] = post
+	}
+
+	return response
+}
@@ -0,0 +1,240 @@
+package handler
+
+import (
+	"net/http"
+	"social-platform-backend/internal/interface/dto/request"
+	"social-platform-backend/internal/interface/dto/response"
+	"social-platform-backend/internal/service"
+	"social-platform-backend/package/logger"
+	"social-platform-backend/package/util"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AutoModHandler struct {
+	autoModService *service.AutoModService
+}
+
+func NewAutoModHandler(autoModService *service.AutoModService) *AutoModHandler {
+	return &AutoModHandler{
+		autoModService: autoModService,
+	}
+}
+
+func (h *AutoModHandler) GetRules(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, communityID, ok := parseAutoModRequest(c, "GetRules")
+	if !ok {
+		return
+	}
+
+	rules, err := h.autoModService.GetRules(ctx, userID, communityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting automod rules in AutoModHandler.GetRules: %v", err)
+		writeAutoModError(c, err, "Failed to get automod rules")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Automod rules retrieved successfully",
+		Data:    rules,
+	})
+}
+
+func (h *AutoModHandler) CreateRule(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, communityID, ok := parseAutoModRequest(c, "CreateRule")
+	if !ok {
+		return
+	}
+
+	var req request.AutoModRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error binding JSON in AutoModHandler.CreateRule: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid request payload: " + err.Error(),
+		})
+		return
+	}
+
+	rule, err := h.autoModService.CreateRule(ctx, userID, communityID, &req)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error creating automod rule in AutoModHandler.CreateRule: %v", err)
+		writeAutoModError(c, err, "Failed to create automod rule")
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Automod rule created successfully")
+	c.JSON(http.StatusCreated, response.APIResponse{
+		Success: true,
+		Message: "Automod rule created successfully",
+		Data:    rule,
+	})
+}
+
+func (h *AutoModHandler) UpdateRule(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, communityID, ok := parseAutoModRequest(c, "UpdateRule")
+	if !ok {
+		return
+	}
+	ruleID, ok := parseAutoModRuleID(c, "UpdateRule")
+	if !ok {
+		return
+	}
+
+	var req request.AutoModRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error binding JSON in AutoModHandler.UpdateRule: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid request payload: " + err.Error(),
+		})
+		return
+	}
+
+	rule, err := h.autoModService.UpdateRule(ctx, userID, communityID, ruleID, &req)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error updating automod rule in AutoModHandler.UpdateRule: %v", err)
+		writeAutoModError(c, err, "Failed to update automod rule")
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Automod rule updated successfully")
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Automod rule updated successfully",
+		Data:    rule,
+	})
+}
+
+func (h *AutoModHandler) DeleteRule(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, communityID, ok := parseAutoModRequest(c, "DeleteRule")
+	if !ok {
+		return
+	}
+	ruleID, ok := parseAutoModRuleID(c, "DeleteRule")
+	if !ok {
+		return
+	}
+
+	if err := h.autoModService.DeleteRule(ctx, userID, communityID, ruleID); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error deleting automod rule in AutoModHandler.DeleteRule: %v", err)
+		writeAutoModError(c, err, "Failed to delete automod rule")
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Automod rule deleted successfully")
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Automod rule deleted successfully",
+	})
+}
+
+func (h *AutoModHandler) DryRun(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, communityID, ok := parseAutoModRequest(c, "DryRun")
+	if !ok {
+		return
+	}
+
+	var req request.DryRunAutoModRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error binding JSON in AutoModHandler.DryRun: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid request payload: " + err.Error(),
+		})
+		return
+	}
+
+	result, err := h.autoModService.DryRun(ctx, userID, communityID, &req)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error running automod rules in AutoModHandler.DryRun: %v", err)
+		writeAutoModError(c, err, "Failed to run automod rules")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Automod dry run completed successfully",
+		Data:    result,
+	})
+}
+
+func parseAutoModRequest(c *gin.Context, method string) (uint64, uint64, bool) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in AutoModHandler.%s", err.Error(), method)
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return 0, 0, false
+	}
+
+	communityID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid community ID in AutoModHandler.%s: %v", method, err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid community ID",
+		})
+		return 0, 0, false
+	}
+	return userID, communityID, true
+}
+
+func parseAutoModRuleID(c *gin.Context, method string) (uint64, bool) {
+	ruleID, err := strconv.ParseUint(c.Param("ruleId"), 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(c.Request.Context(), "[Err] Invalid rule ID in AutoModHandler.%s: %v", method, err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid rule ID",
+		})
+		return 0, false
+	}
+	return ruleID, true
+}
+
+func writeAutoModError(c *gin.Context, err error, fallbackMessage string) {
+	switch {
+	case err.Error() == "community not found":
+		c.JSON(http.StatusNotFound, response.APIResponse{
+			Success: false,
+			Message: "Community not found",
+		})
+	case err.Error() == "rule not found":
+		c.JSON(http.StatusNotFound, response.APIResponse{
+			Success: false,
+			Message: "Automod rule not found",
+		})
+	case err.Error() == "permission denied":
+		c.JSON(http.StatusForbidden, response.APIResponse{
+			Success: false,
+			Message: "You don't have permission to moderate this community",
+		})
+	case err.Error() == "automod rule limit reached":
+		c.JSON(http.StatusConflict, response.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+	case strings.HasPrefix(err.Error(), "invalid rule"):
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, response.APIResponse{
+			Success: false,
+			Message: fallbackMessage,
+		})
+	}
+}
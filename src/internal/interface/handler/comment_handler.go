@@ -48,6 +48,20 @@ func (h *CommentHandler) CreateComment(c *gin.Context) {
 
 	if err := h.commentService.CreateComment(ctx, userID, &req); err != nil {
 		logger.ErrorfWithCtx(ctx, "[Err] Error creating comment in CommentHandler.CreateComment: %v", err)
+		if err.Error() == "post is locked" || err.Error() == "comment thread is locked" {
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+			return
+		}
+		if err.Error() == "content is empty" {
+			c.JSON(http.StatusBadRequest, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, response.APIResponse{
 			Success: false,
 			Message: err.Error(),
@@ -81,6 +95,8 @@ func (h *CommentHandler) GetCommentsOnPost(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", strconv.Itoa(constant.DEFAULT_PAGE)))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(constant.DEFAULT_LIMIT)))
 	sortBy := c.DefaultQuery("sortBy", constant.COMMENT_SORT_NEWEST)
+	depth, _ := strconv.Atoi(c.DefaultQuery("depth", strconv.Itoa(constant.COMMENT_TREE_DEFAULT_DEPTH)))
+	replyLimit, _ := strconv.Atoi(c.DefaultQuery("replyLimit", strconv.Itoa(constant.COMMENT_TREE_DEFAULT_REPLIES)))
 
 	if page < 1 {
 		page = 1
@@ -89,7 +105,7 @@ func (h *CommentHandler) GetCommentsOnPost(c *gin.Context) {
 		limit = 12
 	}
 
-	comments, pagination, err := h.commentService.GetCommentsByPostID(ctx, postID, sortBy, page, limit, userID)
+	comments, pagination, err := h.commentService.GetCommentsByPostID(ctx, postID, sortBy, page, limit, depth, replyLimit, userID)
 	if err != nil {
 		logger.ErrorfWithCtx(ctx, "[Err] Error getting comments in CommentHandler.GetCommentsByPostID: %v", err)
 		c.JSON(http.StatusInternalServerError, response.APIResponse{
@@ -148,6 +164,8 @@ func (h *CommentHandler) UpdateComment(c *gin.Context) {
 			statusCode = http.StatusForbidden
 		} else if err.Error() == "comment not found" {
 			statusCode = http.StatusNotFound
+		} else if err.Error() == "content is empty" {
+			statusCode = http.StatusBadRequest
 		}
 		c.JSON(statusCode, response.APIResponse{
 			Success: false,
@@ -246,6 +264,8 @@ func (h *CommentHandler) VoteComment(c *gin.Context) {
 		statusCode := http.StatusInternalServerError
 		if err.Error() == "comment not found" {
 			statusCode = http.StatusNotFound
+		} else if err.Error() == "post is locked" || err.Error() == "comment thread is locked" {
+			statusCode = http.StatusForbidden
 		}
 		c.JSON(statusCode, response.APIResponse{
 			Success: false,
@@ -398,6 +418,10 @@ func (h *CommentHandler) ReportComment(c *gin.Context) {
 			statusCode = http.StatusNotFound
 		} else if err.Error() == "you have already reported this comment" {
 			statusCode = http.StatusConflict
+		} else if err.Error() == "reporting temporarily restricted" {
+			statusCode = http.StatusTooManyRequests
+		} else if err.Error() == "report reason is required" || err.Error() == "invalid report rules" {
+			statusCode = http.StatusBadRequest
 		}
 		c.JSON(statusCode, response.APIResponse{
 			Success: false,
@@ -412,3 +436,144 @@ func (h *CommentHandler) ReportComment(c *gin.Context) {
 		Message: "Comment reported successfully",
 	})
 }
+
+func (h *CommentHandler) GetCommentRevisions(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := util.GetOptionalUserIDFromContext(c)
+
+	idParam := c.Param("id")
+	commentID, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid comment ID in CommentHandler.GetCommentRevisions: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid comment ID",
+		})
+		return
+	}
+
+	revisions, err := h.commentService.GetCommentRevisions(ctx, commentID, userID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting comment revisions in CommentHandler.GetCommentRevisions: %v", err)
+
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: "Comment not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, response.APIResponse{
+			Success: false,
+			Message: "Failed to get comment revisions",
+		})
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Comment revisions retrieved successfully")
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Comment revisions retrieved successfully",
+		Data:    revisions,
+	})
+}
+
+func (h *CommentHandler) GetCommentReplies(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := util.GetOptionalUserIDFromContext(c)
+
+	idParam := c.Param("id")
+	commentID, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid comment ID in CommentHandler.GetCommentReplies: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid comment ID",
+		})
+		return
+	}
+
+	depth, _ := strconv.Atoi(c.DefaultQuery("depth", strconv.Itoa(constant.COMMENT_TREE_DEFAULT_DEPTH)))
+	replyLimit, _ := strconv.Atoi(c.DefaultQuery("replyLimit", strconv.Itoa(constant.COMMENT_TREE_DEFAULT_REPLIES)))
+	continuation := c.Query("continuation")
+
+	replies, err := h.commentService.GetCommentReplies(ctx, commentID, continuation, depth, replyLimit, userID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting comment replies in CommentHandler.GetCommentReplies: %v", err)
+
+		switch err.Error() {
+		case "invalid continuation token":
+			c.JSON(http.StatusBadRequest, response.APIResponse{
+				Success: false,
+				Message: "Invalid continuation token",
+			})
+		case "comment not found":
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: "Comment not found",
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, response.APIResponse{
+				Success: false,
+				Message: "Failed to get comment replies",
+			})
+		}
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Comment replies retrieved successfully for comment %d", commentID)
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Comment replies retrieved successfully",
+		Data:    replies,
+	})
+}
+
+func (h *CommentHandler) GetCommentPermalink(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := util.GetOptionalUserIDFromContext(c)
+
+	idParam := c.Param("id")
+	commentID, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid comment ID in CommentHandler.GetCommentPermalink: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid comment ID",
+		})
+		return
+	}
+
+	contextDepth, err := strconv.Atoi(c.DefaultQuery("context", strconv.Itoa(constant.COMMENT_PERMALINK_DEFAULT_CONTEXT)))
+	if err != nil {
+		contextDepth = constant.COMMENT_PERMALINK_DEFAULT_CONTEXT
+	}
+	replyLimit, _ := strconv.Atoi(c.DefaultQuery("replyLimit", strconv.Itoa(constant.COMMENT_TREE_DEFAULT_REPLIES)))
+
+	permalink, err := h.commentService.GetCommentPermalink(ctx, commentID, contextDepth, replyLimit, userID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting comment permalink in CommentHandler.GetCommentPermalink: %v", err)
+
+		if err.Error() == "comment not found" {
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: "Comment not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, response.APIResponse{
+			Success: false,
+			Message: "Failed to get comment",
+		})
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Comment permalink retrieved successfully for comment %d", commentID)
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Comment retrieved successfully",
+		Data:    permalink,
+	})
+}
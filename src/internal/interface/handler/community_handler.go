@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
 	"social-platform-backend/internal/interface/dto/request"
 	"social-platform-backend/internal/interface/dto/response"
@@ -308,6 +309,14 @@ func (h *CommunityHandler) UnjoinCommunity(c *gin.Context) {
 			return
 		}
 
+		if err.Error() == "super admin must transfer ownership before leaving" {
+			c.JSON(http.StatusConflict, response.APIResponse{
+				Success: false,
+				Message: "Transfer ownership of the community before leaving it",
+			})
+			return
+		}
+
 		if strings.Contains(err.Error(), "not found") {
 			c.JSON(http.StatusNotFound, response.APIResponse{
 				Success: false,
@@ -576,7 +585,7 @@ func (h *CommunityHandler) UpdateMemberRole(c *gin.Context) {
 		return
 	}
 
-	if err := h.communityService.UpdateMemberRole(ctx, userID, communityID, targetUserID, req.Role); err != nil {
+	if err := h.communityService.UpdateMemberRole(ctx, userID, communityID, targetUserID, req.Role, req.RoleID); err != nil {
 		logger.ErrorfWithCtx(ctx, "[Err] Error updating member role in CommunityHandler.UpdateMemberRole: %v", err)
 
 		if strings.Contains(err.Error(), "permission denied") {
@@ -587,6 +596,38 @@ func (h *CommunityHandler) UpdateMemberRole(c *gin.Context) {
 			return
 		}
 
+		if err.Error() == "role exceeds your permissions" || err.Error() == "cannot change the role of the super admin" {
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+			return
+		}
+
+		if err.Error() == "user must accept a moderator invitation" {
+			c.JSON(http.StatusConflict, response.APIResponse{
+				Success: false,
+				Message: "Invite the member to become a moderator instead",
+			})
+			return
+		}
+
+		if err.Error() == "role id is required" {
+			c.JSON(http.StatusBadRequest, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+			return
+		}
+
+		if err.Error() == "role not found" {
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: "Community role not found",
+			})
+			return
+		}
+
 		if strings.Contains(err.Error(), "not found") {
 			c.JSON(http.StatusNotFound, response.APIResponse{
 				Success: false,
@@ -651,7 +692,17 @@ func (h *CommunityHandler) RemoveMember(c *gin.Context) {
 		return
 	}
 
-	if err := h.communityService.RemoveMember(ctx, userID, communityID, memberID); err != nil {
+	var req request.RemoveMemberRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error binding query in CommunityHandler.RemoveMember: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid request parameters: " + err.Error(),
+		})
+		return
+	}
+
+	if err := h.communityService.RemoveMember(ctx, userID, communityID, memberID, req.Reason); err != nil {
 		logger.ErrorfWithCtx(ctx, "[Err] Error removing member in CommunityHandler.RemoveMember: %v", err)
 
 		if strings.Contains(err.Error(), "permission denied") {
@@ -662,6 +713,14 @@ func (h *CommunityHandler) RemoveMember(c *gin.Context) {
 			return
 		}
 
+		if err.Error() == "cannot remove the super admin" || err.Error() == "role exceeds your permissions" {
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+			return
+		}
+
 		if strings.Contains(err.Error(), "community not found") {
 			c.JSON(http.StatusNotFound, response.APIResponse{
 				Success: false,
@@ -878,7 +937,7 @@ func (h *CommunityHandler) UpdatePostStatusByModerator(c *gin.Context) {
 		return
 	}
 
-	if err := h.communityService.UpdatePostStatusByModerator(ctx, userID, communityID, postID, req.Status); err != nil {
+	if err := h.communityService.UpdatePostStatusByModerator(ctx, userID, communityID, postID, req.Status, req.Reason); err != nil {
 		logger.ErrorfWithCtx(ctx, "[Err] Error updating post status in CommunityHandler.UpdatePostStatusByModerator: %v", err)
 
 		if strings.Contains(err.Error(), "permission denied") {
@@ -953,9 +1012,28 @@ func (h *CommunityHandler) DeletePostByModerator(c *gin.Context) {
 		return
 	}
 
-	if err := h.communityService.DeletePostByModerator(ctx, userID, communityID, postID); err != nil {
+	// The community rule the post broke can be cited with ?ruleId=
+	var req request.RemoveContentRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error binding query in CommunityHandler.DeletePostByModerator: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid request parameters: " + err.Error(),
+		})
+		return
+	}
+
+	if err := h.communityService.DeletePostByModerator(ctx, userID, communityID, postID, req.RuleID, req.Reason); err != nil {
 		logger.ErrorfWithCtx(ctx, "[Err] Error deleting post in CommunityHandler.DeletePostByModerator: %v", err)
 
+		if err.Error() == "invalid rule" {
+			c.JSON(http.StatusBadRequest, response.APIResponse{
+				Success: false,
+				Message: "The rule is not a rule of this community for posts",
+			})
+			return
+		}
+
 		if strings.Contains(err.Error(), "permission denied") {
 			c.JSON(http.StatusForbidden, response.APIResponse{
 				Success: false,
@@ -986,11 +1064,11 @@ func (h *CommunityHandler) DeletePostByModerator(c *gin.Context) {
 	})
 }
 
-func (h *CommunityHandler) DeleteCommentByModerator(c *gin.Context) {
+func (h *CommunityHandler) PinPost(c *gin.Context) {
 	ctx := c.Request.Context()
 	userID, err := util.GetUserIDFromContext(c)
 	if err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] %s in CommunityHandler.DeleteCommentByModerator", err.Error())
+		logger.ErrorfWithCtx(ctx, "[Err] %s in CommunityHandler.PinPost", err.Error())
 		c.JSON(http.StatusUnauthorized, response.APIResponse{
 			Success: false,
 			Message: "Unauthorized",
@@ -1001,7 +1079,7 @@ func (h *CommunityHandler) DeleteCommentByModerator(c *gin.Context) {
 	communityIDParam := c.Param("id")
 	communityID, err := strconv.ParseUint(communityIDParam, 10, 64)
 	if err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] Invalid community ID in CommunityHandler.DeleteCommentByModerator: %v", err)
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid community ID in CommunityHandler.PinPost: %v", err)
 		c.JSON(http.StatusBadRequest, response.APIResponse{
 			Success: false,
 			Message: "Invalid community ID",
@@ -1009,24 +1087,34 @@ func (h *CommunityHandler) DeleteCommentByModerator(c *gin.Context) {
 		return
 	}
 
-	commentIDParam := c.Param("commentId")
-	commentID, err := strconv.ParseUint(commentIDParam, 10, 64)
+	postIDParam := c.Param("postId")
+	postID, err := strconv.ParseUint(postIDParam, 10, 64)
 	if err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] Invalid comment ID in CommunityHandler.DeleteCommentByModerator: %v", err)
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid post ID in CommunityHandler.PinPost: %v", err)
 		c.JSON(http.StatusBadRequest, response.APIResponse{
 			Success: false,
-			Message: "Invalid comment ID",
+			Message: "Invalid post ID",
 		})
 		return
 	}
 
-	if err := h.communityService.DeleteCommentByModerator(ctx, userID, communityID, commentID); err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] Error deleting comment in CommunityHandler.DeleteCommentByModerator: %v", err)
+	var req request.PinPostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error binding JSON in CommunityHandler.PinPost: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid request payload: " + err.Error(),
+		})
+		return
+	}
+
+	if err := h.communityService.PinPost(ctx, userID, communityID, postID, &req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error pinning post in CommunityHandler.PinPost: %v", err)
 
 		if strings.Contains(err.Error(), "permission denied") {
 			c.JSON(http.StatusForbidden, response.APIResponse{
 				Success: false,
-				Message: "You don't have permission to delete comments",
+				Message: "You don't have permission to pin posts",
 			})
 			return
 		}
@@ -1034,30 +1122,38 @@ func (h *CommunityHandler) DeleteCommentByModerator(c *gin.Context) {
 		if strings.Contains(err.Error(), "not found") {
 			c.JSON(http.StatusNotFound, response.APIResponse{
 				Success: false,
-				Message: "Comment not found",
+				Message: err.Error(),
+			})
+			return
+		}
+
+		if err.Error() == "only approved posts can be pinned" || err.Error() == "pinned posts limit reached" {
+			c.JSON(http.StatusBadRequest, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
 			})
 			return
 		}
 
 		c.JSON(http.StatusInternalServerError, response.APIResponse{
 			Success: false,
-			Message: "Failed to delete comment",
+			Message: "Failed to pin post",
 		})
 		return
 	}
 
-	logger.InfofWithCtx(ctx, "[Info] Comment deleted successfully")
+	logger.InfofWithCtx(ctx, "[Info] Post pinned successfully")
 	c.JSON(http.StatusOK, response.APIResponse{
 		Success: true,
-		Message: "Comment deleted successfully",
+		Message: "Post pinned successfully",
 	})
 }
 
-func (h *CommunityHandler) GetCommunityPostReports(c *gin.Context) {
+func (h *CommunityHandler) UnpinPost(c *gin.Context) {
 	ctx := c.Request.Context()
 	userID, err := util.GetUserIDFromContext(c)
 	if err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] %s in CommunityHandler.GetCommunityPostReports", err.Error())
+		logger.ErrorfWithCtx(ctx, "[Err] %s in CommunityHandler.UnpinPost", err.Error())
 		c.JSON(http.StatusUnauthorized, response.APIResponse{
 			Success: false,
 			Message: "Unauthorized",
@@ -1068,7 +1164,7 @@ func (h *CommunityHandler) GetCommunityPostReports(c *gin.Context) {
 	communityIDParam := c.Param("id")
 	communityID, err := strconv.ParseUint(communityIDParam, 10, 64)
 	if err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] Invalid community ID in CommunityHandler.GetCommunityPostReports: %v", err)
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid community ID in CommunityHandler.UnpinPost: %v", err)
 		c.JSON(http.StatusBadRequest, response.APIResponse{
 			Success: false,
 			Message: "Invalid community ID",
@@ -1076,24 +1172,24 @@ func (h *CommunityHandler) GetCommunityPostReports(c *gin.Context) {
 		return
 	}
 
-	page, _ := strconv.Atoi(c.DefaultQuery("page", strconv.Itoa(constant.DEFAULT_PAGE)))
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(constant.DEFAULT_LIMIT)))
-
-	if page < 1 {
-		page = constant.DEFAULT_PAGE
-	}
-	if limit < 1 || limit > 100 {
-		limit = constant.DEFAULT_LIMIT
+	postIDParam := c.Param("postId")
+	postID, err := strconv.ParseUint(postIDParam, 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid post ID in CommunityHandler.UnpinPost: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid post ID",
+		})
+		return
 	}
 
-	reports, pagination, err := h.communityService.GetCommunityPostReports(ctx, userID, communityID, page, limit)
-	if err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] Error getting community post reports in CommunityHandler.GetCommunityPostReports: %v", err)
+	if err := h.communityService.UnpinPost(ctx, userID, communityID, postID); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error unpinning post in CommunityHandler.UnpinPost: %v", err)
 
 		if strings.Contains(err.Error(), "permission denied") {
 			c.JSON(http.StatusForbidden, response.APIResponse{
 				Success: false,
-				Message: "You don't have permission to view post reports",
+				Message: "You don't have permission to unpin posts",
 			})
 			return
 		}
@@ -1101,32 +1197,38 @@ func (h *CommunityHandler) GetCommunityPostReports(c *gin.Context) {
 		if strings.Contains(err.Error(), "not found") {
 			c.JSON(http.StatusNotFound, response.APIResponse{
 				Success: false,
-				Message: "Community not found",
+				Message: err.Error(),
+			})
+			return
+		}
+
+		if err.Error() == "post is not pinned" {
+			c.JSON(http.StatusBadRequest, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
 			})
 			return
 		}
 
 		c.JSON(http.StatusInternalServerError, response.APIResponse{
 			Success: false,
-			Message: "Failed to get post reports",
+			Message: "Failed to unpin post",
 		})
 		return
 	}
 
-	logger.InfofWithCtx(ctx, "[Info] Post reports retrieved successfully")
+	logger.InfofWithCtx(ctx, "[Info] Post unpinned successfully")
 	c.JSON(http.StatusOK, response.APIResponse{
-		Success:    true,
-		Message:    "Post reports retrieved successfully",
-		Data:       reports,
-		Pagination: pagination,
+		Success: true,
+		Message: "Post unpinned successfully",
 	})
 }
 
-func (h *CommunityHandler) DeletePostReport(c *gin.Context) {
+func (h *CommunityHandler) LockPost(c *gin.Context) {
 	ctx := c.Request.Context()
 	userID, err := util.GetUserIDFromContext(c)
 	if err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] %s in CommunityHandler.DeletePostReport", err.Error())
+		logger.ErrorfWithCtx(ctx, "[Err] %s in CommunityHandler.LockPost", err.Error())
 		c.JSON(http.StatusUnauthorized, response.APIResponse{
 			Success: false,
 			Message: "Unauthorized",
@@ -1137,7 +1239,7 @@ func (h *CommunityHandler) DeletePostReport(c *gin.Context) {
 	communityIDParam := c.Param("id")
 	communityID, err := strconv.ParseUint(communityIDParam, 10, 64)
 	if err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] Invalid community ID in CommunityHandler.DeletePostReport: %v", err)
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid community ID in CommunityHandler.LockPost: %v", err)
 		c.JSON(http.StatusBadRequest, response.APIResponse{
 			Success: false,
 			Message: "Invalid community ID",
@@ -1145,47 +1247,73 @@ func (h *CommunityHandler) DeletePostReport(c *gin.Context) {
 		return
 	}
 
-	reportIDParam := c.Param("reportId")
-	reportID, err := strconv.ParseUint(reportIDParam, 10, 64)
+	postIDParam := c.Param("postId")
+	postID, err := strconv.ParseUint(postIDParam, 10, 64)
 	if err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] Invalid report ID in CommunityHandler.DeletePostReport: %v", err)
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid post ID in CommunityHandler.LockPost: %v", err)
 		c.JSON(http.StatusBadRequest, response.APIResponse{
 			Success: false,
-			Message: "Invalid report ID",
+			Message: "Invalid post ID",
+		})
+		return
+	}
+
+	var req request.LockContentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error binding JSON in CommunityHandler.LockPost: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid request payload: " + err.Error(),
 		})
 		return
 	}
 
-	if err := h.communityService.DeletePostReport(ctx, userID, communityID, reportID); err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] Error deleting post report in CommunityHandler.DeletePostReport: %v", err)
+	if err := h.communityService.LockPost(ctx, userID, communityID, postID, &req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error locking post in CommunityHandler.LockPost: %v", err)
 
 		if strings.Contains(err.Error(), "permission denied") {
 			c.JSON(http.StatusForbidden, response.APIResponse{
 				Success: false,
-				Message: "You don't have permission to delete post reports",
+				Message: "You don't have permission to lock posts",
+			})
+			return
+		}
+
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+			return
+		}
+
+		if err.Error() == "post is already locked" {
+			c.JSON(http.StatusBadRequest, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
 			})
 			return
 		}
 
 		c.JSON(http.StatusInternalServerError, response.APIResponse{
 			Success: false,
-			Message: "Failed to delete post report",
+			Message: "Failed to lock post",
 		})
 		return
 	}
 
-	logger.InfofWithCtx(ctx, "[Info] Post report deleted successfully")
+	logger.InfofWithCtx(ctx, "[Info] Post locked successfully")
 	c.JSON(http.StatusOK, response.APIResponse{
 		Success: true,
-		Message: "Post report deleted successfully",
+		Message: "Post locked successfully",
 	})
 }
 
-func (h *CommunityHandler) GetCommunityCommentReports(c *gin.Context) {
+func (h *CommunityHandler) UnlockPost(c *gin.Context) {
 	ctx := c.Request.Context()
 	userID, err := util.GetUserIDFromContext(c)
 	if err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] %s in CommunityHandler.GetCommunityCommentReports", err.Error())
+		logger.ErrorfWithCtx(ctx, "[Err] %s in CommunityHandler.UnlockPost", err.Error())
 		c.JSON(http.StatusUnauthorized, response.APIResponse{
 			Success: false,
 			Message: "Unauthorized",
@@ -1196,7 +1324,7 @@ func (h *CommunityHandler) GetCommunityCommentReports(c *gin.Context) {
 	communityIDParam := c.Param("id")
 	communityID, err := strconv.ParseUint(communityIDParam, 10, 64)
 	if err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] Invalid community ID in CommunityHandler.GetCommunityCommentReports: %v", err)
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid community ID in CommunityHandler.UnlockPost: %v", err)
 		c.JSON(http.StatusBadRequest, response.APIResponse{
 			Success: false,
 			Message: "Invalid community ID",
@@ -1204,24 +1332,24 @@ func (h *CommunityHandler) GetCommunityCommentReports(c *gin.Context) {
 		return
 	}
 
-	page, _ := strconv.Atoi(c.DefaultQuery("page", strconv.Itoa(constant.DEFAULT_PAGE)))
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(constant.DEFAULT_LIMIT)))
-
-	if page < 1 {
-		page = constant.DEFAULT_PAGE
-	}
-	if limit < 1 || limit > 100 {
-		limit = constant.DEFAULT_LIMIT
+	postIDParam := c.Param("postId")
+	postID, err := strconv.ParseUint(postIDParam, 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid post ID in CommunityHandler.UnlockPost: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid post ID",
+		})
+		return
 	}
 
-	reports, pagination, err := h.communityService.GetCommunityCommentReports(ctx, userID, communityID, page, limit)
-	if err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] Error getting community comment reports in CommunityHandler.GetCommunityCommentReports: %v", err)
+	if err := h.communityService.UnlockPost(ctx, userID, communityID, postID); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error unlocking post in CommunityHandler.UnlockPost: %v", err)
 
 		if strings.Contains(err.Error(), "permission denied") {
 			c.JSON(http.StatusForbidden, response.APIResponse{
 				Success: false,
-				Message: "You don't have permission to view comment reports",
+				Message: "You don't have permission to unlock posts",
 			})
 			return
 		}
@@ -1229,32 +1357,38 @@ func (h *CommunityHandler) GetCommunityCommentReports(c *gin.Context) {
 		if strings.Contains(err.Error(), "not found") {
 			c.JSON(http.StatusNotFound, response.APIResponse{
 				Success: false,
-				Message: "Community not found",
+				Message: err.Error(),
+			})
+			return
+		}
+
+		if err.Error() == "post is not locked" {
+			c.JSON(http.StatusBadRequest, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
 			})
 			return
 		}
 
 		c.JSON(http.StatusInternalServerError, response.APIResponse{
 			Success: false,
-			Message: "Failed to get comment reports",
+			Message: "Failed to unlock post",
 		})
 		return
 	}
 
-	logger.InfofWithCtx(ctx, "[Info] Comment reports retrieved successfully")
+	logger.InfofWithCtx(ctx, "[Info] Post unlocked successfully")
 	c.JSON(http.StatusOK, response.APIResponse{
-		Success:    true,
-		Message:    "Comment reports retrieved successfully",
-		Data:       reports,
-		Pagination: pagination,
+		Success: true,
+		Message: "Post unlocked successfully",
 	})
 }
 
-func (h *CommunityHandler) DeleteCommentReport(c *gin.Context) {
+func (h *CommunityHandler) ClosePoll(c *gin.Context) {
 	ctx := c.Request.Context()
 	userID, err := util.GetUserIDFromContext(c)
 	if err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] %s in CommunityHandler.DeleteCommentReport", err.Error())
+		logger.ErrorfWithCtx(ctx, "[Err] %s in CommunityHandler.ClosePoll", err.Error())
 		c.JSON(http.StatusUnauthorized, response.APIResponse{
 			Success: false,
 			Message: "Unauthorized",
@@ -1265,7 +1399,7 @@ func (h *CommunityHandler) DeleteCommentReport(c *gin.Context) {
 	communityIDParam := c.Param("id")
 	communityID, err := strconv.ParseUint(communityIDParam, 10, 64)
 	if err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] Invalid community ID in CommunityHandler.DeleteCommentReport: %v", err)
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid community ID in CommunityHandler.ClosePoll: %v", err)
 		c.JSON(http.StatusBadRequest, response.APIResponse{
 			Success: false,
 			Message: "Invalid community ID",
@@ -1273,47 +1407,63 @@ func (h *CommunityHandler) DeleteCommentReport(c *gin.Context) {
 		return
 	}
 
-	reportIDParam := c.Param("reportId")
-	reportID, err := strconv.ParseUint(reportIDParam, 10, 64)
+	postIDParam := c.Param("postId")
+	postID, err := strconv.ParseUint(postIDParam, 10, 64)
 	if err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] Invalid report ID in CommunityHandler.DeleteCommentReport: %v", err)
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid post ID in CommunityHandler.ClosePoll: %v", err)
 		c.JSON(http.StatusBadRequest, response.APIResponse{
 			Success: false,
-			Message: "Invalid report ID",
+			Message: "Invalid post ID",
 		})
 		return
 	}
 
-	if err := h.communityService.DeleteCommentReport(ctx, userID, communityID, reportID); err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] Error deleting comment report in CommunityHandler.DeleteCommentReport: %v", err)
+	if err := h.communityService.ClosePoll(ctx, userID, communityID, postID); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error closing poll in CommunityHandler.ClosePoll: %v", err)
 
 		if strings.Contains(err.Error(), "permission denied") {
 			c.JSON(http.StatusForbidden, response.APIResponse{
 				Success: false,
-				Message: "You don't have permission to delete comment reports",
+				Message: "You don't have permission to close polls",
+			})
+			return
+		}
+
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+			return
+		}
+
+		if err.Error() == "post is not a poll" || err.Error() == "poll is already closed" {
+			c.JSON(http.StatusBadRequest, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
 			})
 			return
 		}
 
 		c.JSON(http.StatusInternalServerError, response.APIResponse{
 			Success: false,
-			Message: "Failed to delete comment report",
+			Message: "Failed to close poll",
 		})
 		return
 	}
 
-	logger.InfofWithCtx(ctx, "[Info] Comment report deleted successfully")
+	logger.InfofWithCtx(ctx, "[Info] Poll closed successfully")
 	c.JSON(http.StatusOK, response.APIResponse{
 		Success: true,
-		Message: "Comment report deleted successfully",
+		Message: "Poll closed successfully",
 	})
 }
 
-func (h *CommunityHandler) BanUser(c *gin.Context) {
+func (h *CommunityHandler) LockComment(c *gin.Context) {
 	ctx := c.Request.Context()
-	moderatorID, err := util.GetUserIDFromContext(c)
+	userID, err := util.GetUserIDFromContext(c)
 	if err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] %s in CommunityHandler.BanUser", err.Error())
+		logger.ErrorfWithCtx(ctx, "[Err] %s in CommunityHandler.LockComment", err.Error())
 		c.JSON(http.StatusUnauthorized, response.APIResponse{
 			Success: false,
 			Message: "Unauthorized",
@@ -1324,7 +1474,7 @@ func (h *CommunityHandler) BanUser(c *gin.Context) {
 	communityIDParam := c.Param("id")
 	communityID, err := strconv.ParseUint(communityIDParam, 10, 64)
 	if err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] Invalid community ID in CommunityHandler.BanUser: %v", err)
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid community ID in CommunityHandler.LockComment: %v", err)
 		c.JSON(http.StatusBadRequest, response.APIResponse{
 			Success: false,
 			Message: "Invalid community ID",
@@ -1332,50 +1482,863 @@ func (h *CommunityHandler) BanUser(c *gin.Context) {
 		return
 	}
 
-	var req request.BanUserRequest
+	commentIDParam := c.Param("commentId")
+	commentID, err := strconv.ParseUint(commentIDParam, 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid comment ID in CommunityHandler.LockComment: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid comment ID",
+		})
+		return
+	}
+
+	var req request.LockContentRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] Invalid request body in CommunityHandler.BanUser: %v", err)
+		logger.ErrorfWithCtx(ctx, "[Err] Error binding JSON in CommunityHandler.LockComment: %v", err)
 		c.JSON(http.StatusBadRequest, response.APIResponse{
 			Success: false,
-			Message: "Invalid request body",
+			Message: "Invalid request payload: " + err.Error(),
 		})
 		return
 	}
 
-	if err := h.communityService.BanUser(ctx, moderatorID, communityID, &req); err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] Error banning user in CommunityHandler.BanUser: %v", err)
+	if err := h.communityService.LockComment(ctx, userID, communityID, commentID, &req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error locking comment in CommunityHandler.LockComment: %v", err)
 
 		if strings.Contains(err.Error(), "permission denied") {
 			c.JSON(http.StatusForbidden, response.APIResponse{
 				Success: false,
-				Message: "You don't have permission to ban users",
+				Message: "You don't have permission to lock comments",
 			})
 			return
 		}
 
-		if strings.Contains(err.Error(), "community not found") {
+		if strings.Contains(err.Error(), "not found") {
 			c.JSON(http.StatusNotFound, response.APIResponse{
 				Success: false,
-				Message: "Community not found",
+				Message: err.Error(),
 			})
 			return
 		}
 
-		c.JSON(http.StatusBadRequest, response.APIResponse{
+		if err.Error() == "comment is already locked" {
+			c.JSON(http.StatusBadRequest, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, response.APIResponse{
 			Success: false,
-			Message: err.Error(),
+			Message: "Failed to lock comment",
 		})
 		return
 	}
 
-	logger.InfofWithCtx(ctx, "[Info] User banned successfully")
+	logger.InfofWithCtx(ctx, "[Info] Comment locked successfully")
 	c.JSON(http.StatusOK, response.APIResponse{
 		Success: true,
-		Message: "User banned successfully",
+		Message: "Comment locked successfully",
 	})
 }
 
-func (h *CommunityHandler) GetUserRestrictionHistory(c *gin.Context) {
+func (h *CommunityHandler) UnlockComment(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in CommunityHandler.UnlockComment", err.Error())
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	communityIDParam := c.Param("id")
+	communityID, err := strconv.ParseUint(communityIDParam, 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid community ID in CommunityHandler.UnlockComment: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid community ID",
+		})
+		return
+	}
+
+	commentIDParam := c.Param("commentId")
+	commentID, err := strconv.ParseUint(commentIDParam, 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid comment ID in CommunityHandler.UnlockComment: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid comment ID",
+		})
+		return
+	}
+
+	if err := h.communityService.UnlockComment(ctx, userID, communityID, commentID); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error unlocking comment in CommunityHandler.UnlockComment: %v", err)
+
+		if strings.Contains(err.Error(), "permission denied") {
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: "You don't have permission to unlock comments",
+			})
+			return
+		}
+
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+			return
+		}
+
+		if err.Error() == "comment is not locked" {
+			c.JSON(http.StatusBadRequest, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, response.APIResponse{
+			Success: false,
+			Message: "Failed to unlock comment",
+		})
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Comment unlocked successfully")
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Comment unlocked successfully",
+	})
+}
+
+func (h *CommunityHandler) GetModerationLogs(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in CommunityHandler.GetModerationLogs", err.Error())
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	communityIDParam := c.Param("id")
+	communityID, err := strconv.ParseUint(communityIDParam, 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid community ID in CommunityHandler.GetModerationLogs: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid community ID",
+		})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	logs, pagination, err := h.communityService.GetModerationLogs(ctx, userID, communityID, page, limit)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting moderation logs in CommunityHandler.GetModerationLogs: %v", err)
+
+		if strings.Contains(err.Error(), "permission denied") {
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: "You don't have permission to view moderation logs",
+			})
+			return
+		}
+
+		if strings.Contains(err.Error(), "community not found") {
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: "Community not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, response.APIResponse{
+			Success: false,
+			Message: "Failed to get moderation logs",
+		})
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Moderation logs retrieved successfully")
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success:    true,
+		Message:    "Moderation logs retrieved successfully",
+		Data:       logs,
+		Pagination: pagination,
+	})
+}
+
+func (h *CommunityHandler) GetMemberModerationLogs(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in CommunityHandler.GetMemberModerationLogs", err.Error())
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	communityIDParam := c.Param("id")
+	communityID, err := strconv.ParseUint(communityIDParam, 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid community ID in CommunityHandler.GetMemberModerationLogs: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid community ID",
+		})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	logs, pagination, err := h.communityService.GetMemberModerationLogs(ctx, userID, communityID, page, limit)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting moderation logs in CommunityHandler.GetMemberModerationLogs: %v", err)
+
+		switch err.Error() {
+		case "community not found":
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: "Community not found",
+			})
+		case "moderation log is not public":
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: "This community's moderation log is not public",
+			})
+		case "permission denied":
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: "Only members can view the moderation log",
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, response.APIResponse{
+				Success: false,
+				Message: "Failed to get moderation logs",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success:    true,
+		Message:    "Moderation logs retrieved successfully",
+		Data:       logs,
+		Pagination: pagination,
+	})
+}
+
+func (h *CommunityHandler) UpdateModLogSettings(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in CommunityHandler.UpdateModLogSettings", err.Error())
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid community ID in CommunityHandler.UpdateModLogSettings: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid community ID",
+		})
+		return
+	}
+
+	var req request.UpdateModLogSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error binding JSON in CommunityHandler.UpdateModLogSettings: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid request payload: " + err.Error(),
+		})
+		return
+	}
+
+	if err := h.communityService.UpdateModLogSettings(ctx, userID, id, &req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error updating mod log settings in CommunityHandler.UpdateModLogSettings: %v", err)
+
+		switch err.Error() {
+		case "community not found":
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: "Community not found",
+			})
+		case "permission denied":
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: "Permission denied",
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, response.APIResponse{
+				Success: false,
+				Message: "Failed to update mod log settings",
+			})
+		}
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Mod log settings updated successfully for community %d", id)
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Mod log settings updated successfully",
+	})
+}
+
+func (h *CommunityHandler) DeleteCommentByModerator(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in CommunityHandler.DeleteCommentByModerator", err.Error())
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	communityIDParam := c.Param("id")
+	communityID, err := strconv.ParseUint(communityIDParam, 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid community ID in CommunityHandler.DeleteCommentByModerator: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid community ID",
+		})
+		return
+	}
+
+	commentIDParam := c.Param("commentId")
+	commentID, err := strconv.ParseUint(commentIDParam, 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid comment ID in CommunityHandler.DeleteCommentByModerator: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid comment ID",
+		})
+		return
+	}
+
+	// The community rule the comment broke can be cited with ?ruleId=
+	var req request.RemoveContentRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error binding query in CommunityHandler.DeleteCommentByModerator: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid request parameters: " + err.Error(),
+		})
+		return
+	}
+
+	if err := h.communityService.DeleteCommentByModerator(ctx, userID, communityID, commentID, req.RuleID, req.Reason); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error deleting comment in CommunityHandler.DeleteCommentByModerator: %v", err)
+
+		if err.Error() == "invalid rule" {
+			c.JSON(http.StatusBadRequest, response.APIResponse{
+				Success: false,
+				Message: "The rule is not a rule of this community for comments",
+			})
+			return
+		}
+
+		if strings.Contains(err.Error(), "permission denied") {
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: "You don't have permission to delete comments",
+			})
+			return
+		}
+
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: "Comment not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, response.APIResponse{
+			Success: false,
+			Message: "Failed to delete comment",
+		})
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Comment deleted successfully")
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Comment deleted successfully",
+	})
+}
+
+func (h *CommunityHandler) GetCommunityPostReports(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in CommunityHandler.GetCommunityPostReports", err.Error())
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	communityIDParam := c.Param("id")
+	communityID, err := strconv.ParseUint(communityIDParam, 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid community ID in CommunityHandler.GetCommunityPostReports: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid community ID",
+		})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", strconv.Itoa(constant.DEFAULT_PAGE)))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(constant.DEFAULT_LIMIT)))
+
+	if page < 1 {
+		page = constant.DEFAULT_PAGE
+	}
+	if limit < 1 || limit > 100 {
+		limit = constant.DEFAULT_LIMIT
+	}
+
+	reports, pagination, err := h.communityService.GetCommunityPostReports(ctx, userID, communityID, page, limit)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting community post reports in CommunityHandler.GetCommunityPostReports: %v", err)
+
+		if strings.Contains(err.Error(), "permission denied") {
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: "You don't have permission to view post reports",
+			})
+			return
+		}
+
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: "Community not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, response.APIResponse{
+			Success: false,
+			Message: "Failed to get post reports",
+		})
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Post reports retrieved successfully")
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success:    true,
+		Message:    "Post reports retrieved successfully",
+		Data:       reports,
+		Pagination: pagination,
+	})
+}
+
+func (h *CommunityHandler) GetCommunityDuplicatePosts(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in CommunityHandler.GetCommunityDuplicatePosts", err.Error())
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	communityIDParam := c.Param("id")
+	communityID, err := strconv.ParseUint(communityIDParam, 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid community ID in CommunityHandler.GetCommunityDuplicatePosts: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid community ID",
+		})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", strconv.Itoa(constant.DEFAULT_PAGE)))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(constant.DEFAULT_LIMIT)))
+
+	if page < 1 {
+		page = constant.DEFAULT_PAGE
+	}
+	if limit < 1 || limit > 100 {
+		limit = constant.DEFAULT_LIMIT
+	}
+
+	duplicates, pagination, err := h.communityService.GetCommunityDuplicatePosts(ctx, userID, communityID, page, limit)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting duplicate posts in CommunityHandler.GetCommunityDuplicatePosts: %v", err)
+
+		if strings.Contains(err.Error(), "permission denied") {
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: "You don't have permission to view duplicate posts",
+			})
+			return
+		}
+
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: "Community not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, response.APIResponse{
+			Success: false,
+			Message: "Failed to get duplicate posts",
+		})
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Duplicate posts retrieved successfully")
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success:    true,
+		Message:    "Duplicate posts retrieved successfully",
+		Data:       duplicates,
+		Pagination: pagination,
+	})
+}
+
+func (h *CommunityHandler) DismissPostReport(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in CommunityHandler.DismissPostReport", err.Error())
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	communityIDParam := c.Param("id")
+	communityID, err := strconv.ParseUint(communityIDParam, 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid community ID in CommunityHandler.DismissPostReport: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid community ID",
+		})
+		return
+	}
+
+	reportIDParam := c.Param("reportId")
+	reportID, err := strconv.ParseUint(reportIDParam, 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid report ID in CommunityHandler.DismissPostReport: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid report ID",
+		})
+		return
+	}
+
+	if err := h.communityService.DismissPostReport(ctx, userID, communityID, reportID); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error dismissing post report in CommunityHandler.DismissPostReport: %v", err)
+
+		if strings.Contains(err.Error(), "permission denied") {
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: "You don't have permission to dismiss post reports",
+			})
+			return
+		}
+
+		if strings.Contains(err.Error(), "report not found") {
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: "Report not found",
+			})
+			return
+		}
+
+		if strings.Contains(err.Error(), "report already resolved") {
+			c.JSON(http.StatusConflict, response.APIResponse{
+				Success: false,
+				Message: "Report is already resolved",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, response.APIResponse{
+			Success: false,
+			Message: "Failed to dismiss post report",
+		})
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Post report dismissed successfully")
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Post report dismissed successfully",
+	})
+}
+
+func (h *CommunityHandler) GetCommunityCommentReports(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in CommunityHandler.GetCommunityCommentReports", err.Error())
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	communityIDParam := c.Param("id")
+	communityID, err := strconv.ParseUint(communityIDParam, 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid community ID in CommunityHandler.GetCommunityCommentReports: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid community ID",
+		})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", strconv.Itoa(constant.DEFAULT_PAGE)))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(constant.DEFAULT_LIMIT)))
+
+	if page < 1 {
+		page = constant.DEFAULT_PAGE
+	}
+	if limit < 1 || limit > 100 {
+		limit = constant.DEFAULT_LIMIT
+	}
+
+	reports, pagination, err := h.communityService.GetCommunityCommentReports(ctx, userID, communityID, page, limit)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting community comment reports in CommunityHandler.GetCommunityCommentReports: %v", err)
+
+		if strings.Contains(err.Error(), "permission denied") {
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: "You don't have permission to view comment reports",
+			})
+			return
+		}
+
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: "Community not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, response.APIResponse{
+			Success: false,
+			Message: "Failed to get comment reports",
+		})
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Comment reports retrieved successfully")
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success:    true,
+		Message:    "Comment reports retrieved successfully",
+		Data:       reports,
+		Pagination: pagination,
+	})
+}
+
+func (h *CommunityHandler) DismissCommentReport(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in CommunityHandler.DismissCommentReport", err.Error())
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	communityIDParam := c.Param("id")
+	communityID, err := strconv.ParseUint(communityIDParam, 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid community ID in CommunityHandler.DismissCommentReport: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid community ID",
+		})
+		return
+	}
+
+	reportIDParam := c.Param("reportId")
+	reportID, err := strconv.ParseUint(reportIDParam, 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid report ID in CommunityHandler.DismissCommentReport: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid report ID",
+		})
+		return
+	}
+
+	if err := h.communityService.DismissCommentReport(ctx, userID, communityID, reportID); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error dismissing comment report in CommunityHandler.DismissCommentReport: %v", err)
+
+		if strings.Contains(err.Error(), "permission denied") {
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: "You don't have permission to dismiss comment reports",
+			})
+			return
+		}
+
+		if strings.Contains(err.Error(), "report not found") {
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: "Report not found",
+			})
+			return
+		}
+
+		if strings.Contains(err.Error(), "report already resolved") {
+			c.JSON(http.StatusConflict, response.APIResponse{
+				Success: false,
+				Message: "Report is already resolved",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, response.APIResponse{
+			Success: false,
+			Message: "Failed to dismiss comment report",
+		})
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Comment report dismissed successfully")
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Comment report dismissed successfully",
+	})
+}
+
+func (h *CommunityHandler) BanUser(c *gin.Context) {
+	ctx := c.Request.Context()
+	moderatorID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in CommunityHandler.BanUser", err.Error())
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	communityIDParam := c.Param("id")
+	communityID, err := strconv.ParseUint(communityIDParam, 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid community ID in CommunityHandler.BanUser: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid community ID",
+		})
+		return
+	}
+
+	var req request.BanUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid request body in CommunityHandler.BanUser: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+		return
+	}
+
+	if err := h.communityService.BanUser(ctx, moderatorID, communityID, &req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error banning user in CommunityHandler.BanUser: %v", err)
+
+		if strings.Contains(err.Error(), "permission denied") {
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: "You don't have permission to ban users",
+			})
+			return
+		}
+
+		if err.Error() == "cannot ban the super admin" || err.Error() == "role exceeds your permissions" {
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+			return
+		}
+
+		if strings.Contains(err.Error(), "community not found") {
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: "Community not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] User banned successfully")
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "User banned successfully",
+	})
+}
+
+func (h *CommunityHandler) GetUserRestrictionHistory(c *gin.Context) {
 	ctx := c.Request.Context()
 	moderatorID, err := util.GetUserIDFromContext(c)
 	if err != nil {
@@ -1508,6 +2471,14 @@ func (h *CommunityHandler) RemoveUserRestriction(c *gin.Context) {
 			return
 		}
 
+		if strings.Contains(err.Error(), "restriction not found") {
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: "Restriction not found",
+			})
+			return
+		}
+
 		c.JSON(http.StatusInternalServerError, response.APIResponse{
 			Success: false,
 			Message: "Failed to remove restriction",
@@ -1614,6 +2585,79 @@ func (h *CommunityHandler) UpdateRequiresPostApproval(c *gin.Context) {
 	})
 }
 
+func (h *CommunityHandler) UpdateReactionEmojis(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in CommunityHandler.UpdateReactionEmojis", err.Error())
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid community ID in CommunityHandler.UpdateReactionEmojis: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid community ID",
+		})
+		return
+	}
+
+	var req request.UpdateReactionEmojisRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error binding JSON in CommunityHandler.UpdateReactionEmojis: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid request payload: " + err.Error(),
+		})
+		return
+	}
+
+	if err := h.communityService.UpdateReactionEmojis(ctx, userID, id, &req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error updating reaction emojis in CommunityHandler.UpdateReactionEmojis: %v", err)
+
+		switch err.Error() {
+		case "community not found":
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: "Community not found",
+			})
+		case "permission denied":
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: "Permission denied",
+			})
+		case "too many emojis":
+			c.JSON(http.StatusBadRequest, response.APIResponse{
+				Success: false,
+				Message: fmt.Sprintf("A community can have at most %d reaction emojis", constant.MAX_COMMUNITY_REACTION_EMOJIS),
+			})
+		case "invalid emoji":
+			c.JSON(http.StatusBadRequest, response.APIResponse{
+				Success: false,
+				Message: "Each reaction must be a single emoji",
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, response.APIResponse{
+				Success: false,
+				Message: "Failed to update reaction emojis",
+			})
+		}
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Reaction emojis updated successfully for community %d", id)
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Reaction emojis updated successfully",
+	})
+}
+
 func (h *CommunityHandler) UpdateRequiresMemberApproval(c *gin.Context) {
 	ctx := c.Request.Context()
 	userID, err := util.GetUserIDFromContext(c)
@@ -1724,7 +2768,7 @@ func (h *CommunityHandler) UpdateSubscriptionStatus(c *gin.Context) {
 		return
 	}
 
-	if err := h.communityService.UpdateSubscriptionStatus(ctx, moderatorUserID, communityID, targetUserID, req.Status); err != nil {
+	if err := h.communityService.UpdateSubscriptionStatus(ctx, moderatorUserID, communityID, targetUserID, req.Status, req.Reason); err != nil {
 		logger.ErrorfWithCtx(ctx, "[Err] Error updating subscription status in CommunityHandler.UpdateSubscriptionStatus: %v", err)
 
 		if err.Error() == "community not found" {
@@ -1772,3 +2816,141 @@ func (h *CommunityHandler) UpdateSubscriptionStatus(c *gin.Context) {
 		Message: "Subscription status updated successfully",
 	})
 }
+
+func (h *CommunityHandler) GetPostRevisionsForModerator(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in CommunityHandler.GetPostRevisionsForModerator", err.Error())
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	communityIDParam := c.Param("id")
+	communityID, err := strconv.ParseUint(communityIDParam, 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid community ID in CommunityHandler.GetPostRevisionsForModerator: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid community ID",
+		})
+		return
+	}
+
+	postIDParam := c.Param("postId")
+	postID, err := strconv.ParseUint(postIDParam, 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid post ID in CommunityHandler.GetPostRevisionsForModerator: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid post ID",
+		})
+		return
+	}
+
+	revisions, err := h.communityService.GetPostRevisionsForModerator(ctx, userID, communityID, postID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting post revisions in CommunityHandler.GetPostRevisionsForModerator: %v", err)
+
+		if strings.Contains(err.Error(), "permission denied") {
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: "You don't have permission to view post history",
+			})
+			return
+		}
+
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: "Post not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, response.APIResponse{
+			Success: false,
+			Message: "Failed to get post revisions",
+		})
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Post revisions retrieved successfully")
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Post revisions retrieved successfully",
+		Data:    revisions,
+	})
+}
+
+func (h *CommunityHandler) GetCommentRevisionsForModerator(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in CommunityHandler.GetCommentRevisionsForModerator", err.Error())
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	communityIDParam := c.Param("id")
+	communityID, err := strconv.ParseUint(communityIDParam, 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid community ID in CommunityHandler.GetCommentRevisionsForModerator: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid community ID",
+		})
+		return
+	}
+
+	commentIDParam := c.Param("commentId")
+	commentID, err := strconv.ParseUint(commentIDParam, 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid comment ID in CommunityHandler.GetCommentRevisionsForModerator: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid comment ID",
+		})
+		return
+	}
+
+	revisions, err := h.communityService.GetCommentRevisionsForModerator(ctx, userID, communityID, commentID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting comment revisions in CommunityHandler.GetCommentRevisionsForModerator: %v", err)
+
+		if strings.Contains(err.Error(), "permission denied") {
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: "You don't have permission to view comment history",
+			})
+			return
+		}
+
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: "Comment not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, response.APIResponse{
+			Success: false,
+			Message: "Failed to get comment revisions",
+		})
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Comment revisions retrieved successfully")
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Comment revisions retrieved successfully",
+		Data:    revisions,
+	})
+}
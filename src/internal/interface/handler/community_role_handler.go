@@ -0,0 +1,234 @@
+package handler
+
+import (
+	"net/http"
+	"social-platform-backend/internal/interface/dto/request"
+	"social-platform-backend/internal/interface/dto/response"
+	"social-platform-backend/internal/service"
+	"social-platform-backend/package/logger"
+	"social-platform-backend/package/util"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CommunityRoleHandler struct {
+	communityRoleService *service.CommunityRoleService
+}
+
+func NewCommunityRoleHandler(communityRoleService *service.CommunityRoleService) *CommunityRoleHandler {
+	return &CommunityRoleHandler{
+		communityRoleService: communityRoleService,
+	}
+}
+
+func (h *CommunityRoleHandler) GetPermissions(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, communityID, ok := parseCommunityRoleRequest(c, "GetPermissions")
+	if !ok {
+		return
+	}
+
+	permissions, err := h.communityRoleService.GetPermissions(ctx, userID, communityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting community permissions in CommunityRoleHandler.GetPermissions: %v", err)
+		writeCommunityRoleError(c, err, "Failed to get community permissions")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Community permissions retrieved successfully",
+		Data:    permissions,
+	})
+}
+
+func (h *CommunityRoleHandler) GetRoles(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, communityID, ok := parseCommunityRoleRequest(c, "GetRoles")
+	if !ok {
+		return
+	}
+
+	roles, err := h.communityRoleService.GetRoles(ctx, userID, communityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting community roles in CommunityRoleHandler.GetRoles: %v", err)
+		writeCommunityRoleError(c, err, "Failed to get community roles")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Community roles retrieved successfully",
+		Data:    roles,
+	})
+}
+
+func (h *CommunityRoleHandler) CreateRole(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, communityID, ok := parseCommunityRoleRequest(c, "CreateRole")
+	if !ok {
+		return
+	}
+
+	var req request.CommunityRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error binding JSON in CommunityRoleHandler.CreateRole: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid request payload: " + err.Error(),
+		})
+		return
+	}
+
+	role, err := h.communityRoleService.CreateRole(ctx, userID, communityID, &req)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error creating community role in CommunityRoleHandler.CreateRole: %v", err)
+		writeCommunityRoleError(c, err, "Failed to create community role")
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Community role created successfully")
+	c.JSON(http.StatusCreated, response.APIResponse{
+		Success: true,
+		Message: "Community role created successfully",
+		Data:    role,
+	})
+}
+
+func (h *CommunityRoleHandler) UpdateRole(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, communityID, ok := parseCommunityRoleRequest(c, "UpdateRole")
+	if !ok {
+		return
+	}
+	roleID, ok := parseCommunityRoleID(c, "UpdateRole")
+	if !ok {
+		return
+	}
+
+	var req request.CommunityRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error binding JSON in CommunityRoleHandler.UpdateRole: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid request payload: " + err.Error(),
+		})
+		return
+	}
+
+	role, err := h.communityRoleService.UpdateRole(ctx, userID, communityID, roleID, &req)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error updating community role in CommunityRoleHandler.UpdateRole: %v", err)
+		writeCommunityRoleError(c, err, "Failed to update community role")
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Community role updated successfully")
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Community role updated successfully",
+		Data:    role,
+	})
+}
+
+func (h *CommunityRoleHandler) DeleteRole(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, communityID, ok := parseCommunityRoleRequest(c, "DeleteRole")
+	if !ok {
+		return
+	}
+	roleID, ok := parseCommunityRoleID(c, "DeleteRole")
+	if !ok {
+		return
+	}
+
+	if err := h.communityRoleService.DeleteRole(ctx, userID, communityID, roleID); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error deleting community role in CommunityRoleHandler.DeleteRole: %v", err)
+		writeCommunityRoleError(c, err, "Failed to delete community role")
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Community role deleted successfully")
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Community role deleted successfully",
+	})
+}
+
+func parseCommunityRoleRequest(c *gin.Context, method string) (uint64, uint64, bool) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in CommunityRoleHandler.%s", err.Error(), method)
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return 0, 0, false
+	}
+
+	communityID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid community ID in CommunityRoleHandler.%s: %v", method, err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid community ID",
+		})
+		return 0, 0, false
+	}
+	return userID, communityID, true
+}
+
+func parseCommunityRoleID(c *gin.Context, method string) (uint64, bool) {
+	roleID, err := strconv.ParseUint(c.Param("roleId"), 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(c.Request.Context(), "[Err] Invalid role ID in CommunityRoleHandler.%s: %v", method, err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid role ID",
+		})
+		return 0, false
+	}
+	return roleID, true
+}
+
+func writeCommunityRoleError(c *gin.Context, err error, fallbackMessage string) {
+	switch err.Error() {
+	case "community not found":
+		c.JSON(http.StatusNotFound, response.APIResponse{
+			Success: false,
+			Message: "Community not found",
+		})
+	case "role not found":
+		c.JSON(http.StatusNotFound, response.APIResponse{
+			Success: false,
+			Message: "Community role not found",
+		})
+	case "permission denied":
+		c.JSON(http.StatusForbidden, response.APIResponse{
+			Success: false,
+			Message: "You don't have permission to moderate this community",
+		})
+	case "role exceeds your permissions":
+		c.JSON(http.StatusForbidden, response.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+	case "community role limit reached", "role name already exists", "role is assigned to moderators":
+		c.JSON(http.StatusConflict, response.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+	case "role name is required", "role permissions are required", "invalid permission":
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, response.APIResponse{
+			Success: false,
+			Message: fallbackMessage,
+		})
+	}
+}
@@ -0,0 +1,277 @@
+package handler
+
+import (
+	"net/http"
+	"social-platform-backend/internal/interface/dto/request"
+	"social-platform-backend/internal/interface/dto/response"
+	"social-platform-backend/internal/service"
+	"social-platform-backend/package/constant"
+	"social-platform-backend/package/logger"
+	"social-platform-backend/package/util"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CommunityRuleHandler struct {
+	communityRuleService *service.CommunityRuleService
+}
+
+func NewCommunityRuleHandler(communityRuleService *service.CommunityRuleService) *CommunityRuleHandler {
+	return &CommunityRuleHandler{
+		communityRuleService: communityRuleService,
+	}
+}
+
+func (h *CommunityRuleHandler) GetRules(c *gin.Context) {
+	ctx := c.Request.Context()
+	communityID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid community ID in CommunityRuleHandler.GetRules: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid community ID",
+		})
+		return
+	}
+
+	rules, err := h.communityRuleService.GetRules(ctx, communityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting community rules in CommunityRuleHandler.GetRules: %v", err)
+		writeCommunityRuleError(c, err, "Failed to get community rules")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Community rules retrieved successfully",
+		Data:    rules,
+	})
+}
+
+func (h *CommunityRuleHandler) CreateRule(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, communityID, ok := parseCommunityRuleRequest(c, "CreateRule")
+	if !ok {
+		return
+	}
+
+	var req request.CommunityRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error binding JSON in CommunityRuleHandler.CreateRule: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid request payload: " + err.Error(),
+		})
+		return
+	}
+
+	rule, err := h.communityRuleService.CreateRule(ctx, userID, communityID, &req)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error creating community rule in CommunityRuleHandler.CreateRule: %v", err)
+		writeCommunityRuleError(c, err, "Failed to create community rule")
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Community rule created successfully")
+	c.JSON(http.StatusCreated, response.APIResponse{
+		Success: true,
+		Message: "Community rule created successfully",
+		Data:    rule,
+	})
+}
+
+func (h *CommunityRuleHandler) UpdateRule(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, communityID, ok := parseCommunityRuleRequest(c, "UpdateRule")
+	if !ok {
+		return
+	}
+	ruleID, ok := parseCommunityRuleID(c, "UpdateRule")
+	if !ok {
+		return
+	}
+
+	var req request.CommunityRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error binding JSON in CommunityRuleHandler.UpdateRule: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid request payload: " + err.Error(),
+		})
+		return
+	}
+
+	rule, err := h.communityRuleService.UpdateRule(ctx, userID, communityID, ruleID, &req)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error updating community rule in CommunityRuleHandler.UpdateRule: %v", err)
+		writeCommunityRuleError(c, err, "Failed to update community rule")
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Community rule updated successfully")
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Community rule updated successfully",
+		Data:    rule,
+	})
+}
+
+func (h *CommunityRuleHandler) DeleteRule(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, communityID, ok := parseCommunityRuleRequest(c, "DeleteRule")
+	if !ok {
+		return
+	}
+	ruleID, ok := parseCommunityRuleID(c, "DeleteRule")
+	if !ok {
+		return
+	}
+
+	if err := h.communityRuleService.DeleteRule(ctx, userID, communityID, ruleID); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error deleting community rule in CommunityRuleHandler.DeleteRule: %v", err)
+		writeCommunityRuleError(c, err, "Failed to delete community rule")
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Community rule deleted successfully")
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Community rule deleted successfully",
+	})
+}
+
+func (h *CommunityRuleHandler) ReorderRules(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, communityID, ok := parseCommunityRuleRequest(c, "ReorderRules")
+	if !ok {
+		return
+	}
+
+	var req request.ReorderCommunityRulesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error binding JSON in CommunityRuleHandler.ReorderRules: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid request payload: " + err.Error(),
+		})
+		return
+	}
+
+	rules, err := h.communityRuleService.ReorderRules(ctx, userID, communityID, &req)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error reordering community rules in CommunityRuleHandler.ReorderRules: %v", err)
+		writeCommunityRuleError(c, err, "Failed to reorder community rules")
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Community rules reordered successfully")
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Community rules reordered successfully",
+		Data:    rules,
+	})
+}
+
+func (h *CommunityRuleHandler) GetRuleStats(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, communityID, ok := parseCommunityRuleRequest(c, "GetRuleStats")
+	if !ok {
+		return
+	}
+
+	days, err := strconv.Atoi(c.DefaultQuery("days", strconv.Itoa(constant.COMMUNITY_RULE_DEFAULT_STATS_DAYS)))
+	if err != nil || days < 1 || days > constant.COMMUNITY_RULE_MAX_STATS_DAYS {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid days in CommunityRuleHandler.GetRuleStats: %s", c.Query("days"))
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid days, must be between 1 and " + strconv.Itoa(constant.COMMUNITY_RULE_MAX_STATS_DAYS),
+		})
+		return
+	}
+
+	stats, err := h.communityRuleService.GetRuleStats(ctx, userID, communityID, days)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting community rule stats in CommunityRuleHandler.GetRuleStats: %v", err)
+		writeCommunityRuleError(c, err, "Failed to get community rule stats")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Community rule stats retrieved successfully",
+		Data:    stats,
+	})
+}
+
+func parseCommunityRuleRequest(c *gin.Context, method string) (uint64, uint64, bool) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in CommunityRuleHandler.%s", err.Error(), method)
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return 0, 0, false
+	}
+
+	communityID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid community ID in CommunityRuleHandler.%s: %v", method, err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid community ID",
+		})
+		return 0, 0, false
+	}
+	return userID, communityID, true
+}
+
+func parseCommunityRuleID(c *gin.Context, method string) (uint64, bool) {
+	ruleID, err := strconv.ParseUint(c.Param("ruleId"), 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(c.Request.Context(), "[Err] Invalid rule ID in CommunityRuleHandler.%s: %v", method, err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid rule ID",
+		})
+		return 0, false
+	}
+	return ruleID, true
+}
+
+func writeCommunityRuleError(c *gin.Context, err error, fallbackMessage string) {
+	switch err.Error() {
+	case "community not found":
+		c.JSON(http.StatusNotFound, response.APIResponse{
+			Success: false,
+			Message: "Community not found",
+		})
+	case "rule not found":
+		c.JSON(http.StatusNotFound, response.APIResponse{
+			Success: false,
+			Message: "Community rule not found",
+		})
+	case "permission denied":
+		c.JSON(http.StatusForbidden, response.APIResponse{
+			Success: false,
+			Message: "You don't have permission to moderate this community",
+		})
+	case "community rule limit reached":
+		c.JSON(http.StatusConflict, response.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+	case "rule title is required", "invalid rule order":
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, response.APIResponse{
+			Success: false,
+			Message: fallbackMessage,
+		})
+	}
+}
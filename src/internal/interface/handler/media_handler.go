@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"social-platform-backend/internal/interface/dto/response"
+	"social-platform-backend/internal/service"
+	"social-platform-backend/package/constant"
+	"social-platform-backend/package/logger"
+	"social-platform-backend/package/util"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MediaHandler struct {
+	mediaService *service.MediaService
+}
+
+func NewMediaHandler(mediaService *service.MediaService) *MediaHandler {
+	return &MediaHandler{
+		mediaService: mediaService,
+	}
+}
+
+func (h *MediaHandler) UploadMedia(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in MediaHandler.UploadMedia", err.Error())
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, constant.MEDIA_MAX_REQUEST_BYTES)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error reading form file in MediaHandler.UploadMedia: %v", err)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, response.APIResponse{
+				Success: false,
+				Message: "File is too large",
+			})
+			return
+		}
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "A file is required",
+		})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error opening form file in MediaHandler.UploadMedia: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid file",
+		})
+		return
+	}
+	defer file.Close()
+
+	useCase := c.PostForm("useCase")
+	result, err := h.mediaService.UploadMedia(ctx, userID, useCase, file)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error uploading media in MediaHandler.UploadMedia: %v", err)
+		statusCode := http.StatusInternalServerError
+		switch {
+		case strings.HasPrefix(err.Error(), "file is too large"):
+			statusCode = http.StatusRequestEntityTooLarge
+		case err.Error() == "invalid media use case" ||
+			err.Error() == "file is empty" ||
+			err.Error() == "invalid image file" ||
+			strings.HasPrefix(err.Error(), "unsupported file type"):
+			statusCode = http.StatusBadRequest
+		}
+		c.JSON(statusCode, response.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Media uploaded successfully")
+	c.JSON(http.StatusCreated, response.APIResponse{
+		Success: true,
+		Message: "Media uploaded successfully",
+		Data:    result,
+	})
+}
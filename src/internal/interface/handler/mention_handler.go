@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"net/http"
+	"social-platform-backend/internal/interface/dto/response"
+	"social-platform-backend/internal/service"
+	"social-platform-backend/package/logger"
+	"social-platform-backend/package/util"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MentionHandler struct {
+	mentionService *service.MentionService
+}
+
+func NewMentionHandler(mentionService *service.MentionService) *MentionHandler {
+	return &MentionHandler{
+		mentionService: mentionService,
+	}
+}
+
+func (h *MentionHandler) GetMentionSuggestions(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in MentionHandler.GetMentionSuggestions", err.Error())
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	communityID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid community ID in MentionHandler.GetMentionSuggestions: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid community ID",
+		})
+		return
+	}
+
+	suggestions, err := h.mentionService.GetMentionSuggestions(ctx, userID, communityID, c.Query("q"))
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting mention suggestions in MentionHandler.GetMentionSuggestions: %v", err)
+		switch err.Error() {
+		case "community not found":
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: "Community not found",
+			})
+		case "permission denied":
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: "You must be a member of this community",
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, response.APIResponse{
+				Success: false,
+				Message: "Failed to get mention suggestions",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Mention suggestions retrieved successfully",
+		Data:    suggestions,
+	})
+}
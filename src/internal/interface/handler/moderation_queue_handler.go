@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"net/http"
+	"social-platform-backend/internal/interface/dto/request"
+	"social-platform-backend/internal/interface/dto/response"
+	"social-platform-backend/internal/service"
+	"social-platform-backend/package/constant"
+	"social-platform-backend/package/logger"
+	"social-platform-backend/package/util"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ModerationQueueHandler struct {
+	moderationQueueService *service.ModerationQueueService
+}
+
+func NewModerationQueueHandler(moderationQueueService *service.ModerationQueueService) *ModerationQueueHandler {
+	return &ModerationQueueHandler{
+		moderationQueueService: moderationQueueService,
+	}
+}
+
+func (h *ModerationQueueHandler) GetModerationQueue(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in ModerationQueueHandler.GetModerationQueue", err.Error())
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	communityID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid community ID in ModerationQueueHandler.GetModerationQueue: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid community ID",
+		})
+		return
+	}
+
+	reason := c.Query("reason")
+	if reason != "" && reason != constant.MODERATION_QUEUE_PENDING && reason != constant.MODERATION_QUEUE_FLAGGED && reason != constant.MODERATION_QUEUE_REPORTED {
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid reason, must be pending, flagged or reported",
+		})
+		return
+	}
+
+	targetType := c.Query("type")
+	if targetType != "" && targetType != constant.MODERATION_TARGET_POST && targetType != constant.MODERATION_TARGET_COMMENT {
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid type, must be post or comment",
+		})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", strconv.Itoa(constant.DEFAULT_PAGE)))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(constant.DEFAULT_LIMIT)))
+
+	if page < 1 {
+		page = constant.DEFAULT_PAGE
+	}
+	if limit < 1 || limit > 100 {
+		limit = constant.DEFAULT_LIMIT
+	}
+
+	items, pagination, err := h.moderationQueueService.GetModerationQueue(ctx, userID, communityID, reason, targetType, page, limit)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting moderation queue in ModerationQueueHandler.GetModerationQueue: %v", err)
+		switch err.Error() {
+		case "community not found":
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: "Community not found",
+			})
+		case "permission denied":
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: "You don't have permission to view the moderation queue",
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, response.APIResponse{
+				Success: false,
+				Message: "Failed to get moderation queue",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success:    true,
+		Message:    "Moderation queue retrieved successfully",
+		Data:       items,
+		Pagination: pagination,
+	})
+}
+
+func (h *ModerationQueueHandler) ApplyQueueAction(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in ModerationQueueHandler.ApplyQueueAction", err.Error())
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	communityID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid community ID in ModerationQueueHandler.ApplyQueueAction: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid community ID",
+		})
+		return
+	}
+
+	targetID, err := strconv.ParseUint(c.Param("targetId"), 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid target ID in ModerationQueueHandler.ApplyQueueAction: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid target ID",
+		})
+		return
+	}
+
+	var req request.ModerationQueueActionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error binding JSON in ModerationQueueHandler.ApplyQueueAction: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid request payload: " + err.Error(),
+		})
+		return
+	}
+
+	if err := h.moderationQueueService.ApplyQueueAction(ctx, userID, communityID, c.Param("targetType"), targetID, &req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error applying queue action in ModerationQueueHandler.ApplyQueueAction: %v", err)
+		switch err.Error() {
+		case "community not found", "post not found", "comment not found":
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: "Content not found",
+			})
+		case "permission denied":
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: "You don't have permission to moderate this community",
+			})
+		case "cannot ban the super admin", "role exceeds your permissions":
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+		case "failed to apply queue action":
+			c.JSON(http.StatusInternalServerError, response.APIResponse{
+				Success: false,
+				Message: "Failed to apply queue action",
+			})
+		default:
+			c.JSON(http.StatusBadRequest, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Queue action applied successfully",
+	})
+}
@@ -0,0 +1,305 @@
+package handler
+
+import (
+	"net/http"
+	"social-platform-backend/internal/interface/dto/request"
+	"social-platform-backend/internal/interface/dto/response"
+	"social-platform-backend/internal/service"
+	"social-platform-backend/package/logger"
+	"social-platform-backend/package/util"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ModeratorInvitationHandler struct {
+	moderatorInvitationService *service.ModeratorInvitationService
+}
+
+func NewModeratorInvitationHandler(moderatorInvitationService *service.ModeratorInvitationService) *ModeratorInvitationHandler {
+	return &ModeratorInvitationHandler{
+		moderatorInvitationService: moderatorInvitationService,
+	}
+}
+
+func (h *ModeratorInvitationHandler) InviteModerator(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, communityID, ok := parseModeratorInvitationRequest(c, "InviteModerator")
+	if !ok {
+		return
+	}
+
+	var req request.InviteModeratorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error binding JSON in ModeratorInvitationHandler.InviteModerator: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid request payload: " + err.Error(),
+		})
+		return
+	}
+
+	invitation, err := h.moderatorInvitationService.InviteModerator(ctx, userID, communityID, &req)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error inviting moderator in ModeratorInvitationHandler.InviteModerator: %v", err)
+		writeModeratorInvitationError(c, err, "Failed to invite moderator")
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Moderator invitation sent successfully")
+	c.JSON(http.StatusCreated, response.APIResponse{
+		Success: true,
+		Message: "Moderator invitation sent successfully",
+		Data:    invitation,
+	})
+}
+
+func (h *ModeratorInvitationHandler) TransferOwnership(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, communityID, ok := parseModeratorInvitationRequest(c, "TransferOwnership")
+	if !ok {
+		return
+	}
+
+	var req request.TransferOwnershipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error binding JSON in ModeratorInvitationHandler.TransferOwnership: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid request payload: " + err.Error(),
+		})
+		return
+	}
+
+	invitation, err := h.moderatorInvitationService.TransferOwnership(ctx, userID, communityID, &req)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error offering ownership in ModeratorInvitationHandler.TransferOwnership: %v", err)
+		writeModeratorInvitationError(c, err, "Failed to transfer ownership")
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Ownership transfer offered successfully")
+	c.JSON(http.StatusCreated, response.APIResponse{
+		Success: true,
+		Message: "Ownership transfer offered successfully",
+		Data:    invitation,
+	})
+}
+
+func (h *ModeratorInvitationHandler) GetCommunityInvitations(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, communityID, ok := parseModeratorInvitationRequest(c, "GetCommunityInvitations")
+	if !ok {
+		return
+	}
+
+	invitations, err := h.moderatorInvitationService.GetCommunityInvitations(ctx, userID, communityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting invitations in ModeratorInvitationHandler.GetCommunityInvitations: %v", err)
+		writeModeratorInvitationError(c, err, "Failed to get invitations")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Invitations retrieved successfully",
+		Data:    invitations,
+	})
+}
+
+func (h *ModeratorInvitationHandler) CancelInvitation(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, communityID, ok := parseModeratorInvitationRequest(c, "CancelInvitation")
+	if !ok {
+		return
+	}
+	invitationID, ok := parseInvitationID(c, "CancelInvitation")
+	if !ok {
+		return
+	}
+
+	if err := h.moderatorInvitationService.CancelInvitation(ctx, userID, communityID, invitationID); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error cancelling invitation in ModeratorInvitationHandler.CancelInvitation: %v", err)
+		writeModeratorInvitationError(c, err, "Failed to cancel invitation")
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Invitation cancelled successfully")
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Invitation cancelled successfully",
+	})
+}
+
+func (h *ModeratorInvitationHandler) GetMyInvitations(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in ModeratorInvitationHandler.GetMyInvitations", err.Error())
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	invitations, err := h.moderatorInvitationService.GetMyInvitations(ctx, userID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting invitations in ModeratorInvitationHandler.GetMyInvitations: %v", err)
+		writeModeratorInvitationError(c, err, "Failed to get invitations")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Invitations retrieved successfully",
+		Data:    invitations,
+	})
+}
+
+func (h *ModeratorInvitationHandler) AcceptInvitation(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, invitationID, ok := parseInvitationReply(c, "AcceptInvitation")
+	if !ok {
+		return
+	}
+
+	if err := h.moderatorInvitationService.AcceptInvitation(ctx, userID, invitationID); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error accepting invitation in ModeratorInvitationHandler.AcceptInvitation: %v", err)
+		writeModeratorInvitationError(c, err, "Failed to accept invitation")
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Invitation accepted successfully")
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Invitation accepted successfully",
+	})
+}
+
+func (h *ModeratorInvitationHandler) DeclineInvitation(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, invitationID, ok := parseInvitationReply(c, "DeclineInvitation")
+	if !ok {
+		return
+	}
+
+	if err := h.moderatorInvitationService.DeclineInvitation(ctx, userID, invitationID); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error declining invitation in ModeratorInvitationHandler.DeclineInvitation: %v", err)
+		writeModeratorInvitationError(c, err, "Failed to decline invitation")
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Invitation declined successfully")
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Invitation declined successfully",
+	})
+}
+
+func parseModeratorInvitationRequest(c *gin.Context, method string) (uint64, uint64, bool) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in ModeratorInvitationHandler.%s", err.Error(), method)
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return 0, 0, false
+	}
+
+	communityID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid community ID in ModeratorInvitationHandler.%s: %v", method, err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid community ID",
+		})
+		return 0, 0, false
+	}
+	return userID, communityID, true
+}
+
+func parseInvitationReply(c *gin.Context, method string) (uint64, uint64, bool) {
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(c.Request.Context(), "[Err] %s in ModeratorInvitationHandler.%s", err.Error(), method)
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return 0, 0, false
+	}
+
+	invitationID, ok := parseInvitationID(c, method)
+	if !ok {
+		return 0, 0, false
+	}
+	return userID, invitationID, true
+}
+
+func parseInvitationID(c *gin.Context, method string) (uint64, bool) {
+	invitationID, err := strconv.ParseUint(c.Param("invitationId"), 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(c.Request.Context(), "[Err] Invalid invitation ID in ModeratorInvitationHandler.%s: %v", method, err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid invitation ID",
+		})
+		return 0, false
+	}
+	return invitationID, true
+}
+
+func writeModeratorInvitationError(c *gin.Context, err error, fallbackMessage string) {
+	switch err.Error() {
+	case "community not found":
+		c.JSON(http.StatusNotFound, response.APIResponse{
+			Success: false,
+			Message: "Community not found",
+		})
+	case "invitation not found":
+		c.JSON(http.StatusNotFound, response.APIResponse{
+			Success: false,
+			Message: "Invitation not found",
+		})
+	case "role not found":
+		c.JSON(http.StatusNotFound, response.APIResponse{
+			Success: false,
+			Message: "Community role not found",
+		})
+	case "permission denied":
+		c.JSON(http.StatusForbidden, response.APIResponse{
+			Success: false,
+			Message: "You don't have permission to manage moderators of this community",
+		})
+	case "role exceeds your permissions":
+		c.JSON(http.StatusForbidden, response.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+	case "user is already a moderator", "invitation already pending", "invitation is no longer pending",
+		"invitation can no longer be accepted":
+		c.JSON(http.StatusConflict, response.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+	case "invitation expired":
+		c.JSON(http.StatusGone, response.APIResponse{
+			Success: false,
+			Message: "Invitation expired",
+		})
+	case "cannot invite yourself", "cannot transfer ownership to yourself", "user is not a member of this community",
+		"role id is required", "invalid role":
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, response.APIResponse{
+			Success: false,
+			Message: fallbackMessage,
+		})
+	}
+}
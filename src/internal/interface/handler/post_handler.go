@@ -15,12 +15,14 @@ import (
 )
 
 type PostHandler struct {
-	postService *service.PostService
+	postService          *service.PostService
+	postAnalyticsService *service.PostAnalyticsService
 }
 
-func NewPostHandler(postService *service.PostService) *PostHandler {
+func NewPostHandler(postService *service.PostService, postAnalyticsService *service.PostAnalyticsService) *PostHandler {
 	return &PostHandler{
-		postService: postService,
+		postService:          postService,
+		postAnalyticsService: postAnalyticsService,
 	}
 }
 
@@ -48,6 +50,20 @@ func (h *PostHandler) CreatePost(c *gin.Context) {
 
 	if err := h.postService.CreatePost(ctx, userID, &req); err != nil {
 		logger.ErrorfWithCtx(ctx, "[Err] Error creating post in PostHandler.CreatePost: %v", err)
+		if err.Error() == "content is empty" {
+			c.JSON(http.StatusBadRequest, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+			return
+		}
+		if err.Error() == "duplicate content" {
+			c.JSON(http.StatusConflict, response.APIResponse{
+				Success: false,
+				Message: "This post duplicates a recent post",
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, response.APIResponse{
 			Success: false,
 			Message: err.Error(),
@@ -160,6 +176,15 @@ func (h *PostHandler) UpdatePost(c *gin.Context) {
 			return
 		}
 
+		if strings.HasPrefix(err.Error(), "poll") || err.Error() == "invalid poll data format" ||
+			err.Error() == "invalid poll data" || err.Error() == "content is empty" {
+			c.JSON(http.StatusBadRequest, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+			return
+		}
+
 		c.JSON(http.StatusInternalServerError, response.APIResponse{
 			Success: false,
 			Message: "Failed to update post",
@@ -311,6 +336,15 @@ func (h *PostHandler) GetPostDetail(c *gin.Context) {
 		return
 	}
 
+	// ref is the community feed the post was opened from, used for referral analytics
+	referralCommunityID, _ := strconv.ParseUint(c.Query("ref"), 10, 64)
+	referralCommunityID = h.postAnalyticsService.ReferralCommunityID(ctx, referralCommunityID, userID)
+	var authorID uint64
+	if post.Author != nil {
+		authorID = post.Author.ID
+	}
+	h.postAnalyticsService.RecordView(postID, authorID, userID, c.ClientIP(), c.Request.UserAgent(), referralCommunityID)
+
 	logger.InfofWithCtx(ctx, "[Info] Post detail retrieved successfully")
 	c.JSON(http.StatusOK, response.APIResponse{
 		Success: true,
@@ -484,6 +518,14 @@ func (h *PostHandler) VotePost(c *gin.Context) {
 			return
 		}
 
+		if err.Error() == "post is locked" {
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+			return
+		}
+
 		c.JSON(http.StatusInternalServerError, response.APIResponse{
 			Success: false,
 			Message: "Failed to vote post",
@@ -590,8 +632,18 @@ func (h *PostHandler) VotePoll(c *gin.Context) {
 			return
 		}
 
+		if err.Error() == "post is locked" {
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+			return
+		}
+
 		if err.Error() == "post is not a poll" || err.Error() == "option not found" ||
-			err.Error() == "poll has expired" || err.Error() == "already voted for this option" {
+			err.Error() == "poll has expired" || err.Error() == "poll is closed" ||
+			err.Error() == "already voted for this option" || err.Error() == "ranked option ids are required" ||
+			err.Error() == "each option can only be ranked once" {
 			c.JSON(http.StatusBadRequest, response.APIResponse{
 				Success: false,
 				Message: err.Error(),
@@ -658,7 +710,8 @@ func (h *PostHandler) UnvotePoll(c *gin.Context) {
 		}
 
 		if err.Error() == "post is not a poll" || err.Error() == "option not found" ||
-			err.Error() == "you have not voted for this option" {
+			err.Error() == "poll is closed" || err.Error() == "you have not voted for this option" ||
+			err.Error() == "you have not voted in this poll" {
 			c.JSON(http.StatusBadRequest, response.APIResponse{
 				Success: false,
 				Message: err.Error(),
@@ -767,6 +820,14 @@ func (h *PostHandler) ReportPost(c *gin.Context) {
 	if err := h.postService.ReportPost(ctx, userID, postID, &req); err != nil {
 		logger.ErrorfWithCtx(ctx, "[Err] Error reporting post in PostHandler.ReportPost: %v", err)
 
+		if err.Error() == "report reason is required" || err.Error() == "invalid report rules" {
+			c.JSON(http.StatusBadRequest, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+			return
+		}
+
 		if strings.Contains(err.Error(), "not found") {
 			c.JSON(http.StatusNotFound, response.APIResponse{
 				Success: false,
@@ -783,6 +844,14 @@ func (h *PostHandler) ReportPost(c *gin.Context) {
 			return
 		}
 
+		if strings.Contains(err.Error(), "reporting temporarily restricted") {
+			c.JSON(http.StatusTooManyRequests, response.APIResponse{
+				Success: false,
+				Message: "Reporting is temporarily restricted because most of your recent reports were dismissed",
+			})
+			return
+		}
+
 		c.JSON(http.StatusInternalServerError, response.APIResponse{
 			Success: false,
 			Message: "Failed to report post",
@@ -822,3 +891,730 @@ func (h *PostHandler) GetAllTags(c *gin.Context) {
 		Data:    tags,
 	})
 }
+
+func (h *PostHandler) GetUserDrafts(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in PostHandler.GetUserDrafts", err.Error())
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", strconv.Itoa(constant.DEFAULT_PAGE)))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(constant.DEFAULT_LIMIT)))
+
+	if page < 1 {
+		page = constant.DEFAULT_PAGE
+	}
+	if limit < 1 || limit > 100 {
+		limit = constant.DEFAULT_LIMIT
+	}
+
+	drafts, pagination, err := h.postService.GetUserDrafts(ctx, userID, page, limit)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting drafts in PostHandler.GetUserDrafts: %v", err)
+		c.JSON(http.StatusInternalServerError, response.APIResponse{
+			Success: false,
+			Message: "Failed to retrieve drafts",
+		})
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] User drafts retrieved successfully")
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success:    true,
+		Message:    "Drafts retrieved successfully",
+		Data:       drafts,
+		Pagination: pagination,
+	})
+}
+
+func (h *PostHandler) SaveDraft(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in PostHandler.SaveDraft", err.Error())
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	idParam := c.Param("id")
+	postID, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid post ID in PostHandler.SaveDraft: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid post ID",
+		})
+		return
+	}
+
+	var req request.SaveDraftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error binding JSON in PostHandler.SaveDraft: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid request payload: " + err.Error(),
+		})
+		return
+	}
+
+	if err := h.postService.SaveDraft(ctx, userID, postID, &req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error saving draft in PostHandler.SaveDraft: %v", err)
+
+		switch err.Error() {
+		case "post not found":
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: "Post not found",
+			})
+		case "permission denied":
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: "You don't have permission to edit this draft",
+			})
+		case "post is not a draft":
+			c.JSON(http.StatusBadRequest, response.APIResponse{
+				Success: false,
+				Message: "Post is already published",
+			})
+		case "failed to save draft":
+			c.JSON(http.StatusInternalServerError, response.APIResponse{
+				Success: false,
+				Message: "Failed to save draft",
+			})
+		default:
+			// content validation errors
+			c.JSON(http.StatusBadRequest, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+		}
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Draft saved successfully")
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Draft saved successfully",
+	})
+}
+
+func (h *PostHandler) PublishDraft(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in PostHandler.PublishDraft", err.Error())
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	idParam := c.Param("id")
+	postID, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid post ID in PostHandler.PublishDraft: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid post ID",
+		})
+		return
+	}
+
+	if err := h.postService.PublishDraft(ctx, userID, postID); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error publishing draft in PostHandler.PublishDraft: %v", err)
+
+		switch err.Error() {
+		case "post not found", "community not found":
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+		case "permission denied", "you are banned from this community":
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+		case "failed to publish post":
+			c.JSON(http.StatusInternalServerError, response.APIResponse{
+				Success: false,
+				Message: "Failed to publish post",
+			})
+		case "duplicate content":
+			c.JSON(http.StatusConflict, response.APIResponse{
+				Success: false,
+				Message: "This post duplicates a recent post",
+			})
+		default:
+			// post is not a draft or content validation errors
+			c.JSON(http.StatusBadRequest, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+		}
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Draft published successfully")
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Post published successfully",
+	})
+}
+
+func (h *PostHandler) GetPostRevisions(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := util.GetOptionalUserIDFromContext(c)
+
+	idParam := c.Param("id")
+	postID, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid post ID in PostHandler.GetPostRevisions: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid post ID",
+		})
+		return
+	}
+
+	revisions, err := h.postService.GetPostRevisions(ctx, postID, userID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting post revisions in PostHandler.GetPostRevisions: %v", err)
+
+		if err.Error() == "post not found" {
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: "Post not found",
+			})
+			return
+		}
+
+		if strings.Contains(err.Error(), "permission") {
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, response.APIResponse{
+			Success: false,
+			Message: "Failed to get post revisions",
+		})
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Post revisions retrieved successfully")
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Post revisions retrieved successfully",
+		Data:    revisions,
+	})
+}
+
+func (h *PostHandler) CrosspostPost(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in PostHandler.CrosspostPost", err.Error())
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	idParam := c.Param("id")
+	postID, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid post ID in PostHandler.CrosspostPost: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid post ID",
+		})
+		return
+	}
+
+	var req request.CrosspostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error binding JSON in PostHandler.CrosspostPost: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid request payload: " + err.Error(),
+		})
+		return
+	}
+
+	if err := h.postService.CrosspostPost(ctx, userID, postID, &req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error crossposting in PostHandler.CrosspostPost: %v", err)
+
+		switch {
+		case err.Error() == "post not found" || err.Error() == "community not found":
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+		case strings.HasPrefix(err.Error(), "you "):
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+		case strings.Contains(err.Error(), "already"):
+			c.JSON(http.StatusConflict, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+		case err.Error() == "failed to crosspost":
+			c.JSON(http.StatusInternalServerError, response.APIResponse{
+				Success: false,
+				Message: "Failed to crosspost",
+			})
+		default:
+			c.JSON(http.StatusBadRequest, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+		}
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Post crossposted successfully")
+	c.JSON(http.StatusCreated, response.APIResponse{
+		Success: true,
+		Message: "Post crossposted successfully",
+	})
+}
+
+func (h *PostHandler) GetCrossposts(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := util.GetOptionalUserIDFromContext(c)
+
+	idParam := c.Param("id")
+	postID, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid post ID in PostHandler.GetCrossposts: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid post ID",
+		})
+		return
+	}
+
+	crossposts, err := h.postService.GetCrossposts(ctx, postID, userID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting crossposts in PostHandler.GetCrossposts: %v", err)
+
+		if err.Error() == "post not found" {
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: "Post not found",
+			})
+			return
+		}
+
+		if strings.Contains(err.Error(), "permission") {
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, response.APIResponse{
+			Success: false,
+			Message: "Failed to get crossposts",
+		})
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Crossposts retrieved successfully")
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Crossposts retrieved successfully",
+		Data:    crossposts,
+	})
+}
+
+func (h *PostHandler) ClosePoll(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in PostHandler.ClosePoll", err.Error())
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	idParam := c.Param("id")
+	postID, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid post ID in PostHandler.ClosePoll: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid post ID",
+		})
+		return
+	}
+
+	if err := h.postService.ClosePoll(ctx, userID, postID); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error closing poll in PostHandler.ClosePoll: %v", err)
+
+		if err.Error() == "post not found" {
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: "Post not found",
+			})
+			return
+		}
+
+		if err.Error() == "permission denied" {
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: "Only the poll author can close this poll",
+			})
+			return
+		}
+
+		if err.Error() == "post is not a poll" || err.Error() == "poll is already closed" {
+			c.JSON(http.StatusBadRequest, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, response.APIResponse{
+			Success: false,
+			Message: "Failed to close poll",
+		})
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Poll closed successfully")
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Poll closed successfully",
+	})
+}
+
+func (h *PostHandler) SuggestPollOption(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in PostHandler.SuggestPollOption", err.Error())
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	idParam := c.Param("id")
+	postID, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid post ID in PostHandler.SuggestPollOption: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid post ID",
+		})
+		return
+	}
+
+	var req request.SuggestPollOptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid request in PostHandler.SuggestPollOption: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid request",
+		})
+		return
+	}
+
+	if err := h.postService.SuggestPollOption(ctx, userID, postID, &req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error suggesting poll option in PostHandler.SuggestPollOption: %v", err)
+
+		if err.Error() == "post not found" {
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: "Post not found",
+			})
+			return
+		}
+
+		if err.Error() == "post is locked" || strings.Contains(err.Error(), "banned") {
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+			return
+		}
+
+		if err.Error() == "post is not a poll" || strings.HasPrefix(err.Error(), "poll") ||
+			err.Error() == "too many pending write-in options" {
+			c.JSON(http.StatusBadRequest, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, response.APIResponse{
+			Success: false,
+			Message: "Failed to suggest poll option",
+		})
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Poll option suggested successfully")
+	c.JSON(http.StatusCreated, response.APIResponse{
+		Success: true,
+		Message: "Poll option suggested, waiting for the author's approval",
+	})
+}
+
+func (h *PostHandler) GetPendingPollOptions(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in PostHandler.GetPendingPollOptions", err.Error())
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	idParam := c.Param("id")
+	postID, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid post ID in PostHandler.GetPendingPollOptions: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid post ID",
+		})
+		return
+	}
+
+	options, err := h.postService.GetPendingPollOptions(ctx, userID, postID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting pending poll options in PostHandler.GetPendingPollOptions: %v", err)
+
+		if err.Error() == "post not found" {
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: "Post not found",
+			})
+			return
+		}
+
+		if err.Error() == "permission denied" {
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: "Only the poll author can review write-in options",
+			})
+			return
+		}
+
+		if err.Error() == "post is not a poll" {
+			c.JSON(http.StatusBadRequest, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, response.APIResponse{
+			Success: false,
+			Message: "Failed to get pending poll options",
+		})
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Pending poll options retrieved successfully")
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Pending poll options retrieved successfully",
+		Data:    options,
+	})
+}
+
+func (h *PostHandler) ApprovePollOption(c *gin.Context) {
+	h.reviewPollOption(c, "ApprovePollOption", true)
+}
+
+func (h *PostHandler) RejectPollOption(c *gin.Context) {
+	h.reviewPollOption(c, "RejectPollOption", false)
+}
+
+// reviewPollOption handles the author approving or rejecting a write-in option
+func (h *PostHandler) reviewPollOption(c *gin.Context, method string, approve bool) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in PostHandler.%s", err.Error(), method)
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	idParam := c.Param("id")
+	postID, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid post ID in PostHandler.%s: %v", method, err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid post ID",
+		})
+		return
+	}
+
+	optionIDParam := c.Param("optionId")
+	optionID, err := strconv.Atoi(optionIDParam)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid option ID in PostHandler.%s: %v", method, err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid option ID",
+		})
+		return
+	}
+
+	action, message := "reject", "Poll option rejected successfully"
+	if approve {
+		err = h.postService.ApprovePollOption(ctx, userID, postID, optionID)
+		action, message = "approve", "Poll option approved successfully"
+	} else {
+		err = h.postService.RejectPollOption(ctx, userID, postID, optionID)
+	}
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error reviewing poll option in PostHandler.%s: %v", method, err)
+
+		if err.Error() == "post not found" || err.Error() == "write-in option not found" {
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+			return
+		}
+
+		if err.Error() == "permission denied" {
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: "Only the poll author can review write-in options",
+			})
+			return
+		}
+
+		if err.Error() == "post is not a poll" || err.Error() == "poll is closed" ||
+			err.Error() == "poll has reached the maximum number of options" {
+			c.JSON(http.StatusBadRequest, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, response.APIResponse{
+			Success: false,
+			Message: "Failed to " + action + " poll option",
+		})
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] %s", message)
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: message,
+	})
+}
+
+func (h *PostHandler) GetPostAnalytics(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in PostHandler.GetPostAnalytics", err.Error())
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	idParam := c.Param("id")
+	postID, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid post ID in PostHandler.GetPostAnalytics: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid post ID",
+		})
+		return
+	}
+
+	interval := c.DefaultQuery("interval", constant.POST_ANALYTICS_INTERVAL_DAY)
+	if interval != constant.POST_ANALYTICS_INTERVAL_HOUR && interval != constant.POST_ANALYTICS_INTERVAL_DAY {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid interval in PostHandler.GetPostAnalytics: %s", interval)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid interval, must be hour or day",
+		})
+		return
+	}
+
+	days, err := strconv.Atoi(c.DefaultQuery("days", strconv.Itoa(constant.POST_ANALYTICS_DEFAULT_DAYS)))
+	if err != nil || days < 1 || days > constant.POST_ANALYTICS_MAX_DAYS {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid days in PostHandler.GetPostAnalytics: %s", c.Query("days"))
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid days, must be between 1 and " + strconv.Itoa(constant.POST_ANALYTICS_MAX_DAYS),
+		})
+		return
+	}
+
+	analytics, err := h.postAnalyticsService.GetPostAnalytics(ctx, userID, postID, interval, days)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting post analytics in PostHandler.GetPostAnalytics: %v", err)
+
+		if err.Error() == "post not found" {
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: "Post not found",
+			})
+			return
+		}
+
+		if err.Error() == "permission denied" {
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: "Only the author and community moderators can view post analytics",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, response.APIResponse{
+			Success: false,
+			Message: "Failed to get post analytics",
+		})
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Post analytics retrieved successfully")
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Post analytics retrieved successfully",
+		Data:    analytics,
+	})
+}
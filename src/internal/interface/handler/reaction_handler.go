@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"net/http"
+	"social-platform-backend/internal/interface/dto/request"
+	"social-platform-backend/internal/interface/dto/response"
+	"social-platform-backend/internal/service"
+	"social-platform-backend/package/constant"
+	"social-platform-backend/package/logger"
+	"social-platform-backend/package/util"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReactionHandler struct {
+	reactionService *service.ReactionService
+}
+
+func NewReactionHandler(reactionService *service.ReactionService) *ReactionHandler {
+	return &ReactionHandler{
+		reactionService: reactionService,
+	}
+}
+
+func (h *ReactionHandler) GetReactionEmojis(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var communityID *uint64
+	if communityIDParam := c.Query("communityId"); communityIDParam != "" {
+		id, err := strconv.ParseUint(communityIDParam, 10, 64)
+		if err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Invalid community ID in ReactionHandler.GetReactionEmojis: %v", err)
+			c.JSON(http.StatusBadRequest, response.APIResponse{
+				Success: false,
+				Message: "Invalid community ID",
+			})
+			return
+		}
+		communityID = &id
+	}
+
+	emojis, err := h.reactionService.GetReactionEmojis(ctx, communityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting reaction emojis in ReactionHandler.GetReactionEmojis: %v", err)
+		if err.Error() == "community not found" {
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: "Community not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.APIResponse{
+			Success: false,
+			Message: "Failed to get reaction emojis",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Reaction emojis retrieved successfully",
+		Data:    emojis,
+	})
+}
+
+func (h *ReactionHandler) TogglePostReaction(c *gin.Context) {
+	h.toggleReaction(c, constant.REACTION_TARGET_POST, "id", "TogglePostReaction")
+}
+
+func (h *ReactionHandler) ToggleCommentReaction(c *gin.Context) {
+	h.toggleReaction(c, constant.REACTION_TARGET_COMMENT, "id", "ToggleCommentReaction")
+}
+
+func (h *ReactionHandler) ToggleMessageReaction(c *gin.Context) {
+	h.toggleReaction(c, constant.REACTION_TARGET_MESSAGE, "messageId", "ToggleMessageReaction")
+}
+
+func (h *ReactionHandler) toggleReaction(c *gin.Context, targetType, idParamName, method string) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in ReactionHandler.%s", err.Error(), method)
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	targetID, err := strconv.ParseUint(c.Param(idParamName), 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid %s ID in ReactionHandler.%s: %v", targetType, method, err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid " + targetType + " ID",
+		})
+		return
+	}
+
+	var req request.ToggleReactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error binding JSON in ReactionHandler.%s: %v", method, err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid request payload: " + err.Error(),
+		})
+		return
+	}
+
+	result, err := h.reactionService.ToggleReaction(ctx, userID, targetType, targetID, req.Emoji)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error toggling reaction in ReactionHandler.%s: %v", method, err)
+
+		switch err.Error() {
+		case "post not found", "comment not found", "message not found", "community not found":
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+		case "post is locked", "comment thread is locked", "you are not a member of this community":
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+		case "unauthorized":
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: "You are not part of this conversation",
+			})
+		case "invalid emoji":
+			c.JSON(http.StatusBadRequest, response.APIResponse{
+				Success: false,
+				Message: "This emoji cannot be used here",
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, response.APIResponse{
+				Success: false,
+				Message: "Failed to toggle reaction",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Reaction updated successfully",
+		Data:    result,
+	})
+}
@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"net/http"
+	"social-platform-backend/internal/interface/dto/request"
+	"social-platform-backend/internal/interface/dto/response"
+	"social-platform-backend/internal/service"
+	"social-platform-backend/package/constant"
+	"social-platform-backend/package/logger"
+	"social-platform-backend/package/util"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReportHandler struct {
+	reportService *service.ReportService
+}
+
+func NewReportHandler(reportService *service.ReportService) *ReportHandler {
+	return &ReportHandler{
+		reportService: reportService,
+	}
+}
+
+func (h *ReportHandler) EscalateReports(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in ReportHandler.EscalateReports", err.Error())
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	communityID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid community ID in ReportHandler.EscalateReports: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid community ID",
+		})
+		return
+	}
+
+	targetID, err := strconv.ParseUint(c.Param("targetId"), 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid target ID in ReportHandler.EscalateReports: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid target ID",
+		})
+		return
+	}
+
+	var req request.EscalateReportsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error binding JSON in ReportHandler.EscalateReports: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid request payload: " + err.Error(),
+		})
+		return
+	}
+
+	if err := h.reportService.EscalateReports(ctx, userID, communityID, c.Param("targetType"), targetID, &req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error escalating reports in ReportHandler.EscalateReports: %v", err)
+		switch err.Error() {
+		case "community not found", "post not found", "comment not found":
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: "Content not found",
+			})
+		case "permission denied":
+			c.JSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: "You don't have permission to moderate this community",
+			})
+		case "reports already escalated":
+			c.JSON(http.StatusConflict, response.APIResponse{
+				Success: false,
+				Message: "Reports are already escalated",
+			})
+		case "failed to escalate reports":
+			c.JSON(http.StatusInternalServerError, response.APIResponse{
+				Success: false,
+				Message: "Failed to escalate reports",
+			})
+		default:
+			c.JSON(http.StatusBadRequest, response.APIResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+		}
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Reports escalated successfully")
+	c.JSON(http.StatusCreated, response.APIResponse{
+		Success: true,
+		Message: "Reports escalated successfully",
+	})
+}
+
+func (h *ReportHandler) GetReportEscalations(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	status := c.Query("status")
+	if status != "" && status != constant.REPORT_STATUS_OPEN && status != constant.REPORT_STATUS_ACTIONED && status != constant.REPORT_STATUS_DISMISSED {
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid status, must be open, actioned or dismissed",
+		})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", strconv.Itoa(constant.DEFAULT_PAGE)))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(constant.DEFAULT_LIMIT)))
+
+	if page < 1 {
+		page = constant.DEFAULT_PAGE
+	}
+	if limit < 1 || limit > 100 {
+		limit = constant.DEFAULT_LIMIT
+	}
+
+	escalations, pagination, err := h.reportService.GetReportEscalations(ctx, status, page, limit)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting report escalations in ReportHandler.GetReportEscalations: %v", err)
+		c.JSON(http.StatusInternalServerError, response.APIResponse{
+			Success: false,
+			Message: "Failed to get report escalations",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success:    true,
+		Message:    "Report escalations retrieved successfully",
+		Data:       escalations,
+		Pagination: pagination,
+	})
+}
+
+func (h *ReportHandler) ResolveReportEscalation(c *gin.Context) {
+	ctx := c.Request.Context()
+	adminID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in ReportHandler.ResolveReportEscalation", err.Error())
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	escalationID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid escalation ID in ReportHandler.ResolveReportEscalation: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid escalation ID",
+		})
+		return
+	}
+
+	var req request.ResolveReportEscalationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error binding JSON in ReportHandler.ResolveReportEscalation: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid request payload: " + err.Error(),
+		})
+		return
+	}
+
+	if err := h.reportService.ResolveReportEscalation(ctx, adminID, escalationID, &req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error resolving escalation in ReportHandler.ResolveReportEscalation: %v", err)
+		switch err.Error() {
+		case "escalation not found":
+			c.JSON(http.StatusNotFound, response.APIResponse{
+				Success: false,
+				Message: "Escalation not found",
+			})
+		case "escalation already resolved":
+			c.JSON(http.StatusConflict, response.APIResponse{
+				Success: false,
+				Message: "Escalation is already resolved",
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, response.APIResponse{
+				Success: false,
+				Message: "Failed to resolve escalation",
+			})
+		}
+		return
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Report escalation resolved successfully")
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Report escalation resolved successfully",
+	})
+}
@@ -0,0 +1,386 @@
+package handler
+
+import (
+	"net/http"
+	"social-platform-backend/internal/interface/dto/request"
+	"social-platform-backend/internal/interface/dto/response"
+	"social-platform-backend/internal/service"
+	"social-platform-backend/package/constant"
+	"social-platform-backend/package/logger"
+	"social-platform-backend/package/util"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SavedPostCollectionHandler struct {
+	collectionService *service.SavedPostCollectionService
+}
+
+func NewSavedPostCollectionHandler(collectionService *service.SavedPostCollectionService) *SavedPostCollectionHandler {
+	return &SavedPostCollectionHandler{
+		collectionService: collectionService,
+	}
+}
+
+func (h *SavedPostCollectionHandler) GetUserCollections(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in SavedPostCollectionHandler.GetUserCollections", err.Error())
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	collections, err := h.collectionService.GetUserCollections(ctx, userID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting collections in SavedPostCollectionHandler.GetUserCollections: %v", err)
+		c.JSON(http.StatusInternalServerError, response.APIResponse{
+			Success: false,
+			Message: "Failed to get collections",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Collections retrieved successfully",
+		Data:    collections,
+	})
+}
+
+func (h *SavedPostCollectionHandler) CreateCollection(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in SavedPostCollectionHandler.CreateCollection", err.Error())
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	var req request.CreateSavedPostCollectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error binding JSON in SavedPostCollectionHandler.CreateCollection: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid request payload: " + err.Error(),
+		})
+		return
+	}
+
+	collection, err := h.collectionService.CreateCollection(ctx, userID, &req)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error creating collection in SavedPostCollectionHandler.CreateCollection: %v", err)
+		writeCollectionError(c, err, "Failed to create collection")
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.APIResponse{
+		Success: true,
+		Message: "Collection created successfully",
+		Data:    collection,
+	})
+}
+
+func (h *SavedPostCollectionHandler) UpdateCollection(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in SavedPostCollectionHandler.UpdateCollection", err.Error())
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	collectionID, ok := parseCollectionID(c, "UpdateCollection")
+	if !ok {
+		return
+	}
+
+	var req request.UpdateSavedPostCollectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error binding JSON in SavedPostCollectionHandler.UpdateCollection: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid request payload: " + err.Error(),
+		})
+		return
+	}
+
+	collection, err := h.collectionService.UpdateCollection(ctx, userID, collectionID, &req)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error updating collection in SavedPostCollectionHandler.UpdateCollection: %v", err)
+		writeCollectionError(c, err, "Failed to update collection")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Collection updated successfully",
+		Data:    collection,
+	})
+}
+
+func (h *SavedPostCollectionHandler) ReorderCollections(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in SavedPostCollectionHandler.ReorderCollections", err.Error())
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	var req request.ReorderSavedPostCollectionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error binding JSON in SavedPostCollectionHandler.ReorderCollections: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid request payload: " + err.Error(),
+		})
+		return
+	}
+
+	if err := h.collectionService.ReorderCollections(ctx, userID, &req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error reordering collections in SavedPostCollectionHandler.ReorderCollections: %v", err)
+		writeCollectionError(c, err, "Failed to reorder collections")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Collections reordered successfully",
+	})
+}
+
+func (h *SavedPostCollectionHandler) DeleteCollection(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in SavedPostCollectionHandler.DeleteCollection", err.Error())
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	collectionID, ok := parseCollectionID(c, "DeleteCollection")
+	if !ok {
+		return
+	}
+
+	if err := h.collectionService.DeleteCollection(ctx, userID, collectionID); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error deleting collection in SavedPostCollectionHandler.DeleteCollection: %v", err)
+		writeCollectionError(c, err, "Failed to delete collection")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Collection deleted successfully",
+	})
+}
+
+func (h *SavedPostCollectionHandler) AddPostToCollection(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in SavedPostCollectionHandler.AddPostToCollection", err.Error())
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	collectionID, ok := parseCollectionID(c, "AddPostToCollection")
+	if !ok {
+		return
+	}
+
+	var req request.AddPostToCollectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error binding JSON in SavedPostCollectionHandler.AddPostToCollection: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid request payload: " + err.Error(),
+		})
+		return
+	}
+
+	if err := h.collectionService.AddPostToCollection(ctx, userID, collectionID, &req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error adding post to collection in SavedPostCollectionHandler.AddPostToCollection: %v", err)
+		writeCollectionError(c, err, "Failed to add post to collection")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Post added to collection successfully",
+	})
+}
+
+func (h *SavedPostCollectionHandler) RemovePostFromCollection(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in SavedPostCollectionHandler.RemovePostFromCollection", err.Error())
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	collectionID, ok := parseCollectionID(c, "RemovePostFromCollection")
+	if !ok {
+		return
+	}
+
+	postID, err := strconv.ParseUint(c.Param("postId"), 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid post ID in SavedPostCollectionHandler.RemovePostFromCollection: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid post ID",
+		})
+		return
+	}
+
+	if err := h.collectionService.RemovePostFromCollection(ctx, userID, collectionID, postID); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error removing post from collection in SavedPostCollectionHandler.RemovePostFromCollection: %v", err)
+		writeCollectionError(c, err, "Failed to remove post from collection")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Post removed from collection successfully",
+	})
+}
+
+func (h *SavedPostCollectionHandler) UpdateSavedPostNote(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := util.GetUserIDFromContext(c)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] %s in SavedPostCollectionHandler.UpdateSavedPostNote", err.Error())
+		c.JSON(http.StatusUnauthorized, response.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	postID, err := strconv.ParseUint(c.Param("postId"), 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid post ID in SavedPostCollectionHandler.UpdateSavedPostNote: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid post ID",
+		})
+		return
+	}
+
+	var req request.UpdateSavedPostNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error binding JSON in SavedPostCollectionHandler.UpdateSavedPostNote: %v", err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid request payload: " + err.Error(),
+		})
+		return
+	}
+
+	if err := h.collectionService.UpdateSavedPostNote(ctx, userID, postID, &req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error updating note in SavedPostCollectionHandler.UpdateSavedPostNote: %v", err)
+		writeCollectionError(c, err, "Failed to update note")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success: true,
+		Message: "Note updated successfully",
+	})
+}
+
+func (h *SavedPostCollectionHandler) GetSharedCollection(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", strconv.Itoa(constant.DEFAULT_PAGE)))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(constant.DEFAULT_LIMIT)))
+
+	if page < 1 {
+		page = constant.DEFAULT_PAGE
+	}
+	if limit < 1 || limit > 100 {
+		limit = constant.DEFAULT_LIMIT
+	}
+
+	collection, pagination, err := h.collectionService.GetSharedCollection(ctx, c.Param("token"), page, limit)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting shared collection in SavedPostCollectionHandler.GetSharedCollection: %v", err)
+		writeCollectionError(c, err, "Failed to get collection")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.APIResponse{
+		Success:    true,
+		Message:    "Collection retrieved successfully",
+		Data:       collection,
+		Pagination: pagination,
+	})
+}
+
+func parseCollectionID(c *gin.Context, method string) (uint64, bool) {
+	collectionID, err := strconv.ParseUint(c.Param("collectionId"), 10, 64)
+	if err != nil {
+		logger.ErrorfWithCtx(c.Request.Context(), "[Err] Invalid collection ID in SavedPostCollectionHandler.%s: %v", method, err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: "Invalid collection ID",
+		})
+		return 0, false
+	}
+	return collectionID, true
+}
+
+func writeCollectionError(c *gin.Context, err error, fallbackMessage string) {
+	switch err.Error() {
+	case "collection not found", "post not found", "saved post not found":
+		c.JSON(http.StatusNotFound, response.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+	case "permission denied":
+		c.JSON(http.StatusForbidden, response.APIResponse{
+			Success: false,
+			Message: "You can only manage your own collections",
+		})
+	case "collection name is required", "invalid collection order":
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+	case "collection limit reached":
+		c.JSON(http.StatusConflict, response.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, response.APIResponse{
+			Success: false,
+			Message: fallbackMessage,
+		})
+	}
+}
@@ -150,6 +150,13 @@ func (h *SSEHandler) StreamConversationMessages(c *gin.Context) {
 					}
 				}
 			}
+			if event.Event == "reaction_updated" {
+				if reactionEvent, ok := event.Data.(response.ReactionUpdatedEvent); ok {
+					if reactionEvent.ConversationID == nil || *reactionEvent.ConversationID != conversationID {
+						continue // Skip reactions outside this conversation
+					}
+				}
+			}
 
 			eventData, err := json.Marshal(event.Data)
 			if err != nil {
@@ -251,6 +251,19 @@ func (h *UserHandler) GetUserSavedPosts(c *gin.Context) {
 		followed := followedParam == "true"
 		isFollowed = &followed
 	}
+	var collectionID *uint64
+	if collectionParam := c.Query("collectionId"); collectionParam != "" {
+		id, err := strconv.ParseUint(collectionParam, 10, 64)
+		if err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Invalid collection ID in UserHandler.GetUserSavedPosts: %v", err)
+			c.JSON(http.StatusBadRequest, response.APIResponse{
+				Success: false,
+				Message: "Invalid collection ID",
+			})
+			return
+		}
+		collectionID = &id
+	}
 
 	page, _ := strconv.Atoi(c.DefaultQuery("page", strconv.Itoa(constant.DEFAULT_PAGE)))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(constant.DEFAULT_LIMIT)))
@@ -262,7 +275,7 @@ func (h *UserHandler) GetUserSavedPosts(c *gin.Context) {
 		limit = constant.DEFAULT_LIMIT
 	}
 
-	savedPosts, pagination, err := h.userService.GetUserSavedPosts(ctx, userID, searchTitle, isFollowed, page, limit)
+	savedPosts, pagination, err := h.userService.GetUserSavedPosts(ctx, userID, searchTitle, isFollowed, collectionID, page, limit)
 	if err != nil {
 		logger.ErrorfWithCtx(ctx, "[Err] Error getting user saved posts in UserHandler.GetUserSavedPosts: %v", err)
 		c.JSON(http.StatusInternalServerError, response.APIResponse{
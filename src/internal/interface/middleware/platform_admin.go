@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+	"social-platform-backend/config"
+	"social-platform-backend/internal/interface/dto/response"
+	"social-platform-backend/package/logger"
+	"social-platform-backend/package/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PlatformAdminMiddleware lets only the users listed in auth.platformAdminIDs through.
+// It runs after AuthMiddleware.
+func PlatformAdminMiddleware(conf *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := util.GetUserIDFromContext(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response.APIResponse{
+				Success: false,
+				Message: "Unauthorized",
+			})
+			return
+		}
+		if !slices.Contains(conf.Auth.PlatformAdminIDs, userID) {
+			logger.ErrorfWithCtx(c.Request.Context(), "[Err] User is not a platform admin in PlatformAdminMiddleware: userID=%d", userID)
+			c.AbortWithStatusJSON(http.StatusForbidden, response.APIResponse{
+				Success: false,
+				Message: "Platform admin access required",
+			})
+			return
+		}
+		c.Next()
+	}
+}
@@ -2,6 +2,7 @@ package router
 
 import (
 	"social-platform-backend/config"
+	"social-platform-backend/internal/infrastructure/storage"
 	"social-platform-backend/internal/interface/handler"
 	"social-platform-backend/internal/interface/middleware"
 	"social-platform-backend/internal/wire"
@@ -22,6 +23,16 @@ func SetupRoutes(appHandler *wire.AppHandler, conf *config.Config) *gin.Engine {
 		setupProtectedRoutes(api, appHandler, conf)
 	}
 
+	// Uploads stored on the local filesystem are served by the API itself
+	if storage.IsLocal(conf.Storage) {
+		media := router.Group(storage.LocalRoutePrefix)
+		media.Use(func(c *gin.Context) {
+			c.Header("X-Content-Type-Options", "nosniff")
+			c.Next()
+		})
+		media.Static("", storage.LocalDir(conf.Storage))
+	}
+
 	if strings.TrimSpace(conf.Log.DashboardToken) != "" {
 		dash := router.Group("")
 		dash.Use(middleware.LogDashboardAuth(conf))
@@ -34,6 +45,13 @@ func SetupRoutes(appHandler *wire.AppHandler, conf *config.Config) *gin.Engine {
 		apiAdmin.GET("/metrics", handler.GetAdminMetrics)
 	}
 
+	platformAdmin := router.Group("/api/v1/admin")
+	platformAdmin.Use(middleware.AuthMiddleware(conf), middleware.PlatformAdminMiddleware(conf))
+	{
+		platformAdmin.GET("/report-escalations", appHandler.ReportHandler.GetReportEscalations)
+		platformAdmin.PATCH("/report-escalations/:id", appHandler.ReportHandler.ResolveReportEscalation)
+	}
+
 	return router
 }
 
@@ -66,6 +84,7 @@ func setupPublicRoutes(rg *gin.RouterGroup, appHandler *wire.AppHandler, conf *c
 		communities.GET("/filter", appHandler.CommunityHandler.FilterCommunities)
 		communities.GET("/:id", appHandler.CommunityHandler.GetCommunityByID)
 		communities.GET("/:id/posts", appHandler.PostHandler.GetPostsByCommunity)
+		communities.GET("/:id/rules", appHandler.CommunityRuleHandler.GetRules)
 		communities.POST("/verify-name", appHandler.CommunityHandler.VerifyCommunityName)
 		communities.GET("/topics", appHandler.CommunityHandler.GetAllTopics)
 	}
@@ -77,6 +96,25 @@ func setupPublicRoutes(rg *gin.RouterGroup, appHandler *wire.AppHandler, conf *c
 		posts.GET("/:id", appHandler.PostHandler.GetPostDetail)
 		posts.GET("/:id/comments", appHandler.CommentHandler.GetCommentsOnPost)
 		posts.GET("/tags", appHandler.PostHandler.GetAllTags)
+		posts.GET("/:id/revisions", appHandler.PostHandler.GetPostRevisions)
+		posts.GET("/:id/crossposts", appHandler.PostHandler.GetCrossposts)
+	}
+
+	comments := rg.Group("/comments")
+	{
+		comments.GET("/:id/revisions", appHandler.CommentHandler.GetCommentRevisions)
+		comments.GET("/:id/replies", appHandler.CommentHandler.GetCommentReplies)
+		comments.GET("/:id/permalink", appHandler.CommentHandler.GetCommentPermalink)
+	}
+
+	reactions := rg.Group("/reactions")
+	{
+		reactions.GET("/emojis", appHandler.ReactionHandler.GetReactionEmojis)
+	}
+
+	collections := rg.Group("/collections")
+	{
+		collections.GET("/shared/:token", appHandler.SavedPostCollectionHandler.GetSharedCollection)
 	}
 
 	users := rg.Group("/users")
@@ -108,6 +146,18 @@ func setupProtectedRoutes(rg *gin.RouterGroup, appHandler *wire.AppHandler, conf
 			users.POST("/saved-posts", appHandler.UserHandler.CreateUserSavedPost)
 			users.PATCH("/saved-posts/:postId", appHandler.UserHandler.UpdateUserSavedPostFollowStatus)
 			users.DELETE("/saved-posts/:postId", appHandler.UserHandler.DeleteUserSavedPost)
+			users.PATCH("/saved-posts/:postId/note", appHandler.SavedPostCollectionHandler.UpdateSavedPostNote)
+			users.GET("/collections", appHandler.SavedPostCollectionHandler.GetUserCollections)
+			users.POST("/collections", appHandler.SavedPostCollectionHandler.CreateCollection)
+			users.PUT("/collections/order", appHandler.SavedPostCollectionHandler.ReorderCollections)
+			users.PATCH("/collections/:collectionId", appHandler.SavedPostCollectionHandler.UpdateCollection)
+			users.DELETE("/collections/:collectionId", appHandler.SavedPostCollectionHandler.DeleteCollection)
+			users.POST("/collections/:collectionId/posts", appHandler.SavedPostCollectionHandler.AddPostToCollection)
+			users.DELETE("/collections/:collectionId/posts/:postId", appHandler.SavedPostCollectionHandler.RemovePostFromCollection)
+			users.GET("/me/drafts", appHandler.PostHandler.GetUserDrafts)
+			users.GET("/moderator-invitations", appHandler.ModeratorInvitationHandler.GetMyInvitations)
+			users.POST("/moderator-invitations/:invitationId/accept", appHandler.ModeratorInvitationHandler.AcceptInvitation)
+			users.POST("/moderator-invitations/:invitationId/decline", appHandler.ModeratorInvitationHandler.DeclineInvitation)
 		}
 
 		communities := protected.Group("/communities")
@@ -121,16 +171,53 @@ func setupProtectedRoutes(rg *gin.RouterGroup, appHandler *wire.AppHandler, conf
 			communities.PUT("/:id/moderators/:userId", appHandler.CommunityHandler.UpdateMemberRole)
 			communities.DELETE("/:id/members/:memberId", appHandler.CommunityHandler.RemoveMember)
 			communities.GET("/:id/role", appHandler.CommunityHandler.GetUserRoleInCommunity)
+			communities.GET("/:id/permissions", appHandler.CommunityRoleHandler.GetPermissions)
 			communities.PATCH("/:id/requires-post-approval", appHandler.CommunityHandler.UpdateRequiresPostApproval)
 			communities.PATCH("/:id/requires-member-approval", appHandler.CommunityHandler.UpdateRequiresMemberApproval)
+			communities.PATCH("/:id/reaction-emojis", appHandler.CommunityHandler.UpdateReactionEmojis)
+			communities.PATCH("/:id/mod-log-settings", appHandler.CommunityHandler.UpdateModLogSettings)
+			communities.GET("/:id/mod-log", appHandler.CommunityHandler.GetMemberModerationLogs)
+			communities.GET("/:id/mention-suggestions", appHandler.MentionHandler.GetMentionSuggestions)
 			communities.GET("/:id/manage/posts", appHandler.CommunityHandler.GetCommunityPostsForModerator)
 			communities.PATCH("/:id/manage/posts/:postId/status", appHandler.CommunityHandler.UpdatePostStatusByModerator)
 			communities.DELETE("/:id/manage/posts/:postId", appHandler.CommunityHandler.DeletePostByModerator)
+			communities.PUT("/:id/manage/posts/:postId/pin", appHandler.CommunityHandler.PinPost)
+			communities.DELETE("/:id/manage/posts/:postId/pin", appHandler.CommunityHandler.UnpinPost)
+			communities.PUT("/:id/manage/posts/:postId/lock", appHandler.CommunityHandler.LockPost)
+			communities.DELETE("/:id/manage/posts/:postId/lock", appHandler.CommunityHandler.UnlockPost)
+			communities.PUT("/:id/manage/posts/:postId/poll/close", appHandler.CommunityHandler.ClosePoll)
 			communities.DELETE("/:id/manage/comments/:commentId", appHandler.CommunityHandler.DeleteCommentByModerator)
+			communities.PUT("/:id/manage/comments/:commentId/lock", appHandler.CommunityHandler.LockComment)
+			communities.DELETE("/:id/manage/comments/:commentId/lock", appHandler.CommunityHandler.UnlockComment)
+			communities.GET("/:id/manage/logs", appHandler.CommunityHandler.GetModerationLogs)
+			communities.GET("/:id/manage/queue", appHandler.ModerationQueueHandler.GetModerationQueue)
+			communities.POST("/:id/manage/queue/:targetType/:targetId", appHandler.ModerationQueueHandler.ApplyQueueAction)
+			communities.POST("/:id/manage/queue/:targetType/:targetId/escalate", appHandler.ReportHandler.EscalateReports)
+			communities.GET("/:id/manage/automod/rules", appHandler.AutoModHandler.GetRules)
+			communities.POST("/:id/manage/automod/rules", appHandler.AutoModHandler.CreateRule)
+			communities.PUT("/:id/manage/automod/rules/:ruleId", appHandler.AutoModHandler.UpdateRule)
+			communities.DELETE("/:id/manage/automod/rules/:ruleId", appHandler.AutoModHandler.DeleteRule)
+			communities.POST("/:id/manage/automod/dry-run", appHandler.AutoModHandler.DryRun)
+			communities.POST("/:id/manage/rules", appHandler.CommunityRuleHandler.CreateRule)
+			communities.PUT("/:id/manage/rules/order", appHandler.CommunityRuleHandler.ReorderRules)
+			communities.PUT("/:id/manage/rules/:ruleId", appHandler.CommunityRuleHandler.UpdateRule)
+			communities.DELETE("/:id/manage/rules/:ruleId", appHandler.CommunityRuleHandler.DeleteRule)
+			communities.GET("/:id/manage/analytics/rules", appHandler.CommunityRuleHandler.GetRuleStats)
+			communities.GET("/:id/manage/roles", appHandler.CommunityRoleHandler.GetRoles)
+			communities.POST("/:id/manage/roles", appHandler.CommunityRoleHandler.CreateRole)
+			communities.PUT("/:id/manage/roles/:roleId", appHandler.CommunityRoleHandler.UpdateRole)
+			communities.DELETE("/:id/manage/roles/:roleId", appHandler.CommunityRoleHandler.DeleteRole)
+			communities.GET("/:id/manage/invitations", appHandler.ModeratorInvitationHandler.GetCommunityInvitations)
+			communities.POST("/:id/manage/invitations", appHandler.ModeratorInvitationHandler.InviteModerator)
+			communities.DELETE("/:id/manage/invitations/:invitationId", appHandler.ModeratorInvitationHandler.CancelInvitation)
+			communities.POST("/:id/manage/ownership-transfer", appHandler.ModeratorInvitationHandler.TransferOwnership)
+			communities.GET("/:id/manage/posts/:postId/revisions", appHandler.CommunityHandler.GetPostRevisionsForModerator)
+			communities.GET("/:id/manage/comments/:commentId/revisions", appHandler.CommunityHandler.GetCommentRevisionsForModerator)
 			communities.GET("/:id/manage/reports", appHandler.CommunityHandler.GetCommunityPostReports)
-			communities.DELETE("/:id/manage/reports/:reportId", appHandler.CommunityHandler.DeletePostReport)
+			communities.DELETE("/:id/manage/reports/:reportId", appHandler.CommunityHandler.DismissPostReport)
+			communities.GET("/:id/manage/duplicates", appHandler.CommunityHandler.GetCommunityDuplicatePosts)
 			communities.GET("/:id/manage/comment-reports", appHandler.CommunityHandler.GetCommunityCommentReports)
-			communities.DELETE("/:id/manage/comment-reports/:reportId", appHandler.CommunityHandler.DeleteCommentReport)
+			communities.DELETE("/:id/manage/comment-reports/:reportId", appHandler.CommunityHandler.DismissCommentReport)
 			communities.POST("/:id/manage/ban-user", appHandler.CommunityHandler.BanUser)
 			communities.GET("/:id/manage/restrictions/user/:userId", appHandler.CommunityHandler.GetUserRestrictionHistory)
 			communities.DELETE("/:id/manage/restrictions/:restrictionId", appHandler.CommunityHandler.RemoveUserRestriction)
@@ -146,7 +233,17 @@ func setupProtectedRoutes(rg *gin.RouterGroup, appHandler *wire.AppHandler, conf
 			posts.DELETE("/:id/vote", appHandler.PostHandler.UnvotePost)
 			posts.POST("/:id/poll/vote", appHandler.PostHandler.VotePoll)
 			posts.DELETE("/:id/poll/vote", appHandler.PostHandler.UnvotePoll)
+			posts.POST("/:id/poll/close", appHandler.PostHandler.ClosePoll)
+			posts.POST("/:id/poll/options", appHandler.PostHandler.SuggestPollOption)
+			posts.GET("/:id/poll/options/pending", appHandler.PostHandler.GetPendingPollOptions)
+			posts.PUT("/:id/poll/options/:optionId/approve", appHandler.PostHandler.ApprovePollOption)
+			posts.DELETE("/:id/poll/options/:optionId", appHandler.PostHandler.RejectPollOption)
 			posts.POST("/:id/report", appHandler.PostHandler.ReportPost)
+			posts.PUT("/:id/draft", appHandler.PostHandler.SaveDraft)
+			posts.POST("/:id/publish", appHandler.PostHandler.PublishDraft)
+			posts.POST("/:id/crosspost", middleware.CheckUserRestrictionForPostMiddleware(appHandler.UserRestrictionRepo), appHandler.PostHandler.CrosspostPost)
+			posts.GET("/:id/analytics", appHandler.PostHandler.GetPostAnalytics)
+			posts.POST("/:id/reactions", appHandler.ReactionHandler.TogglePostReaction)
 		}
 
 		comments := protected.Group("/comments")
@@ -157,6 +254,7 @@ func setupProtectedRoutes(rg *gin.RouterGroup, appHandler *wire.AppHandler, conf
 			comments.POST("/:id/vote", appHandler.CommentHandler.VoteComment)
 			comments.DELETE("/:id/vote", appHandler.CommentHandler.UnvoteComment)
 			comments.POST("/:id/report", appHandler.CommentHandler.ReportComment)
+			comments.POST("/:id/reactions", appHandler.ReactionHandler.ToggleCommentReaction)
 		}
 
 		messages := protected.Group("/messages")
@@ -167,6 +265,7 @@ func setupProtectedRoutes(rg *gin.RouterGroup, appHandler *wire.AppHandler, conf
 			messages.PATCH("/conversations/:conversationId/read", appHandler.MessageHandler.MarkConversationAsRead)
 			messages.PATCH("/:messageId/read", appHandler.MessageHandler.MarkAsRead)
 			messages.DELETE("/:messageId", appHandler.MessageHandler.DeleteMessage)
+			messages.POST("/:messageId/reactions", appHandler.ReactionHandler.ToggleMessageReaction)
 		}
 
 		notifications := protected.Group("/notifications")
@@ -188,5 +287,10 @@ func setupProtectedRoutes(rg *gin.RouterGroup, appHandler *wire.AppHandler, conf
 		{
 			chatbot.POST("/stream", appHandler.ChatbotHandler.StreamChat)
 		}
+
+		media := protected.Group("/media")
+		{
+			media.POST("", appHandler.MediaHandler.UploadMedia)
+		}
 	}
 }
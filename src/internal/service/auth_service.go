@@ -114,6 +114,8 @@ func (s *AuthService) Register(ctx context.Context, req *request.RegisterRequest
 			constant.NOTIFICATION_ACTION_GET_COMMENT_REPLY,
 			constant.NOTIFICATION_ACTION_POST_STATUS_UPDATED,
 			constant.NOTIFICATION_ACTION_SUBSCRIPTION_STATUS_UPDATED,
+			constant.NOTIFICATION_ACTION_GET_REACTION,
+			constant.NOTIFICATION_ACTION_MENTIONED,
 		}
 
 		now := time.Now()
@@ -640,6 +642,8 @@ func (s *AuthService) GoogleLogin(ctx context.Context, req *request.GoogleLoginR
 			constant.NOTIFICATION_ACTION_GET_COMMENT_REPLY,
 			constant.NOTIFICATION_ACTION_POST_STATUS_UPDATED,
 			constant.NOTIFICATION_ACTION_SUBSCRIPTION_STATUS_UPDATED,
+			constant.NOTIFICATION_ACTION_GET_REACTION,
+			constant.NOTIFICATION_ACTION_MENTIONED,
 		}
 
 		now := time.Now()
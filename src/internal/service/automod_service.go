@@ -0,0 +1,915 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"social-platform-backend/config"
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/domain/repository"
+	"social-platform-backend/internal/interface/dto/request"
+	"social-platform-backend/internal/interface/dto/response"
+	"social-platform-backend/package/constant"
+	"social-platform-backend/package/logger"
+	"social-platform-backend/package/template/payload"
+	"social-platform-backend/package/util"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AutoModService runs the rules communities define for new posts, new comments and
+// reports. Content written by the community's moderators is not checked. The compiled
+// rules of a community are cached until one of its rules is changed.
+type AutoModService struct {
+	autoModRuleRepo        repository.AutoModRuleRepository
+	communityRepo          repository.CommunityRepository
+	communityModeratorRepo repository.CommunityModeratorRepository
+	postRepo               repository.PostRepository
+	commentRepo            repository.CommentRepository
+	userRepo               repository.UserRepository
+	postReportRepo         repository.PostReportRepository
+	commentReportRepo      repository.CommentReportRepository
+	contentFlagRepo        repository.ContentFlagRepository
+	moderationLogRepo      repository.ModerationLogRepository
+	notificationService    *NotificationService
+	contentSanitizer       *util.HTMLSanitizer
+	// botUserID posts the comments of the comment action, 0 while the action is disabled
+	botUserID uint64
+
+	rulesMu sync.RWMutex
+	rules   map[uint64]map[string][]*autoModRule
+	// rulesVersion changes on every invalidation, so rules loaded before it are not cached
+	rulesVersion uint64
+}
+
+func NewAutoModService(
+	autoModRuleRepo repository.AutoModRuleRepository,
+	communityRepo repository.CommunityRepository,
+	communityModeratorRepo repository.CommunityModeratorRepository,
+	postRepo repository.PostRepository,
+	commentRepo repository.CommentRepository,
+	userRepo repository.UserRepository,
+	postReportRepo repository.PostReportRepository,
+	commentReportRepo repository.CommentReportRepository,
+	contentFlagRepo repository.ContentFlagRepository,
+	moderationLogRepo repository.ModerationLogRepository,
+	notificationService *NotificationService,
+	contentSanitizer *util.HTMLSanitizer,
+	conf *config.Config,
+) *AutoModService {
+	botUserID := conf.AutoMod.BotUserID
+	if botUserID != 0 {
+		if _, err := userRepo.GetUserByID(botUserID); err != nil {
+			logger.Errorf("[Err] AutoModerator account %d not found, comment action is disabled: %v", botUserID, err)
+			botUserID = 0
+		}
+	}
+
+	return &AutoModService{
+		autoModRuleRepo:        autoModRuleRepo,
+		communityRepo:          communityRepo,
+		communityModeratorRepo: communityModeratorRepo,
+		postRepo:               postRepo,
+		commentRepo:            commentRepo,
+		userRepo:               userRepo,
+		postReportRepo:         postReportRepo,
+		commentReportRepo:      commentReportRepo,
+		contentFlagRepo:        contentFlagRepo,
+		moderationLogRepo:      moderationLogRepo,
+		notificationService:    notificationService,
+		contentSanitizer:       contentSanitizer,
+		botUserID:              botUserID,
+		rules:                  make(map[uint64]map[string][]*autoModRule),
+	}
+}
+
+// autoModRule is a rule with its definition parsed and its patterns compiled
+type autoModRule struct {
+	ID           uint64
+	Name         string
+	Triggers     []string
+	Conditions   payload.AutoModConditions
+	Actions      []payload.AutoModAction
+	titlePattern *regexp.Regexp
+	bodyPattern  *regexp.Regexp
+}
+
+// autoModTarget is the post, or the comment with its post, that rules are matched against
+type autoModTarget struct {
+	Post        *model.Post
+	Comment     *model.Comment
+	Author      *model.User
+	ReportCount int
+}
+
+func (s *AutoModService) GetRules(ctx context.Context, userID, communityID uint64) ([]*response.AutoModRuleResponse, error) {
+	if _, err := s.checkModerator(ctx, userID, communityID, "GetRules"); err != nil {
+		return nil, err
+	}
+
+	rules, err := s.autoModRuleRepo.GetRulesByCommunityID(communityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting automod rules in AutoModService.GetRules: %v", err)
+		return nil, fmt.Errorf("failed to get automod rules")
+	}
+
+	ruleResponses := make([]*response.AutoModRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		ruleResponses = append(ruleResponses, response.NewAutoModRuleResponse(rule))
+	}
+	return ruleResponses, nil
+}
+
+func (s *AutoModService) CreateRule(ctx context.Context, userID, communityID uint64, req *request.AutoModRuleRequest) (*response.AutoModRuleResponse, error) {
+	if _, err := s.checkModerator(ctx, userID, communityID, "CreateRule"); err != nil {
+		return nil, err
+	}
+
+	count, err := s.autoModRuleRepo.CountRules(communityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error counting automod rules in AutoModService.CreateRule: %v", err)
+		return nil, fmt.Errorf("failed to create automod rule")
+	}
+	if count >= constant.AUTOMOD_MAX_RULES_PER_COMMUNITY {
+		return nil, fmt.Errorf("automod rule limit reached")
+	}
+
+	rule := &model.AutoModRule{
+		CommunityID: communityID,
+		CreatedBy:   userID,
+		CreatedAt:   time.Now(),
+	}
+	if err := setAutoModRuleDefinition(rule, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.autoModRuleRepo.CreateRule(rule); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error creating automod rule in AutoModService.CreateRule: %v", err)
+		return nil, fmt.Errorf("failed to create automod rule")
+	}
+	s.invalidateRules(communityID)
+
+	s.recordRuleChange(ctx, communityID, userID, constant.MODERATION_ACTION_CREATE_AUTOMOD_RULE, rule)
+	return response.NewAutoModRuleResponse(rule), nil
+}
+
+func (s *AutoModService) UpdateRule(ctx context.Context, userID, communityID, ruleID uint64, req *request.AutoModRuleRequest) (*response.AutoModRuleResponse, error) {
+	if _, err := s.checkModerator(ctx, userID, communityID, "UpdateRule"); err != nil {
+		return nil, err
+	}
+
+	rule, err := s.getCommunityRule(ctx, communityID, ruleID, "UpdateRule")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := setAutoModRuleDefinition(rule, req); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	rule.UpdatedAt = &now
+
+	if err := s.autoModRuleRepo.UpdateRule(rule); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error updating automod rule in AutoModService.UpdateRule: %v", err)
+		return nil, fmt.Errorf("failed to update automod rule")
+	}
+	s.invalidateRules(communityID)
+
+	s.recordRuleChange(ctx, communityID, userID, constant.MODERATION_ACTION_UPDATE_AUTOMOD_RULE, rule)
+	return response.NewAutoModRuleResponse(rule), nil
+}
+
+func (s *AutoModService) DeleteRule(ctx context.Context, userID, communityID, ruleID uint64) error {
+	if _, err := s.checkModerator(ctx, userID, communityID, "DeleteRule"); err != nil {
+		return err
+	}
+
+	rule, err := s.getCommunityRule(ctx, communityID, ruleID, "DeleteRule")
+	if err != nil {
+		return err
+	}
+
+	if err := s.autoModRuleRepo.DeleteRule(rule.ID); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error deleting automod rule in AutoModService.DeleteRule: %v", err)
+		return fmt.Errorf("failed to delete automod rule")
+	}
+	s.invalidateRules(communityID)
+
+	s.recordRuleChange(ctx, communityID, userID, constant.MODERATION_ACTION_DELETE_AUTOMOD_RULE, rule)
+	return nil
+}
+
+// DryRun matches rules against the community's recent posts and comments and reports
+// what they would have done, without doing it. Reported triggers are matched against
+// the current open reports.
+func (s *AutoModService) DryRun(ctx context.Context, userID, communityID uint64, req *request.DryRunAutoModRequest) (*response.AutoModDryRunResponse, error) {
+	if _, err := s.checkModerator(ctx, userID, communityID, "DryRun"); err != nil {
+		return nil, err
+	}
+
+	var rules []*autoModRule
+	if req.Rule != nil {
+		rule := &model.AutoModRule{CommunityID: communityID}
+		if err := setAutoModRuleDefinition(rule, req.Rule); err != nil {
+			return nil, err
+		}
+		compiled, err := compileAutoModRule(rule)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule: %v", err)
+		}
+		rules = append(rules, compiled)
+	} else {
+		savedRules, err := s.autoModRuleRepo.GetRulesByCommunityID(communityID)
+		if err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Error getting automod rules in AutoModService.DryRun: %v", err)
+			return nil, fmt.Errorf("failed to run automod rules")
+		}
+		for _, savedRule := range savedRules {
+			if !savedRule.Enabled {
+				continue
+			}
+			if compiled := s.compileRule(ctx, savedRule); compiled != nil {
+				rules = append(rules, compiled)
+			}
+		}
+	}
+
+	limit := req.Limit
+	if limit < 1 || limit > constant.AUTOMOD_MAX_DRY_RUN_LIMIT {
+		limit = constant.AUTOMOD_DEFAULT_DRY_RUN_LIMIT
+	}
+
+	posts, err := s.postRepo.GetRecentPostsByCommunityID(communityID, limit)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting recent posts in AutoModService.DryRun: %v", err)
+		return nil, fmt.Errorf("failed to run automod rules")
+	}
+	comments, err := s.commentRepo.GetRecentCommentsByCommunityID(communityID, limit)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting recent comments in AutoModService.DryRun: %v", err)
+		return nil, fmt.Errorf("failed to run automod rules")
+	}
+
+	postReportCounts, commentReportCounts := s.countDryRunReports(ctx, posts, comments)
+	moderatorIDs := s.getModeratorIDs(ctx, communityID)
+
+	result := &response.AutoModDryRunResponse{
+		PostsChecked:    len(posts),
+		CommentsChecked: len(comments),
+		Matches:         []*response.AutoModDryRunMatch{},
+	}
+	for _, post := range posts {
+		if moderatorIDs[post.AuthorID] {
+			continue
+		}
+		target := &autoModTarget{Post: post, Author: post.Author, ReportCount: postReportCounts[post.ID]}
+		for _, rule := range rules {
+			if trigger := rule.dryRunTrigger(target); trigger != "" {
+				result.Matches = append(result.Matches, newAutoModDryRunMatch(rule, trigger, constant.MODERATION_TARGET_POST, post.ID,
+					response.NewModerationQueuePostContent(post)))
+			}
+		}
+	}
+	for _, comment := range comments {
+		if moderatorIDs[comment.AuthorID] || comment.Post == nil {
+			continue
+		}
+		target := &autoModTarget{Post: comment.Post, Comment: comment, Author: comment.Author, ReportCount: commentReportCounts[comment.ID]}
+		for _, rule := range rules {
+			if trigger := rule.dryRunTrigger(target); trigger != "" {
+				result.Matches = append(result.Matches, newAutoModDryRunMatch(rule, trigger, constant.MODERATION_TARGET_COMMENT, comment.ID,
+					response.NewModerationQueueCommentContent(comment)))
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// Evaluate runs the community's enabled rules for trigger against a post, or against a
+// comment when one is given, and applies the actions of every rule that matches. It
+// reports whether the content was removed. Rules never fail the action that triggered
+// them, errors are only logged.
+func (s *AutoModService) Evaluate(ctx context.Context, trigger string, post *model.Post, comment *model.Comment) bool {
+	authorID := post.AuthorID
+	if comment != nil {
+		authorID = comment.AuthorID
+	}
+
+	rules, err := s.enabledRules(ctx, post.CommunityID, trigger)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting automod rules in AutoModService.Evaluate: %v", err)
+		return false
+	}
+	if len(rules) == 0 {
+		return false
+	}
+
+	if isCommunityModerator(s.communityModeratorRepo, post.CommunityID, authorID) {
+		return false
+	}
+
+	target := &autoModTarget{Post: post, Comment: comment}
+	if author, err := s.userRepo.GetUserByID(authorID); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting author in AutoModService.Evaluate: %v", err)
+	} else {
+		target.Author = author
+	}
+
+	isReportTrigger := trigger == constant.AUTOMOD_TRIGGER_POST_REPORTED || trigger == constant.AUTOMOD_TRIGGER_COMMENT_REPORTED
+	if isReportTrigger {
+		target.ReportCount = s.countOpenReports(ctx, post, comment)
+	}
+
+	for _, rule := range rules {
+		if isReportTrigger && target.ReportCount < rule.reportThreshold() {
+			continue
+		}
+		if !rule.matches(target) {
+			continue
+		}
+		// Each report re-runs the rules, a reported rule only fires once per target
+		if isReportTrigger && !s.markRuleFired(ctx, rule, target) {
+			continue
+		}
+
+		logger.InfofWithCtx(ctx, "[Info] AutoModerator rule %d matched %s in community %d", rule.ID, trigger, post.CommunityID)
+		if s.applyActions(ctx, rule, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyActions carries out the actions of a matching rule and reports whether the
+// content was removed
+func (s *AutoModService) applyActions(ctx context.Context, rule *autoModRule, target *autoModTarget) bool {
+	reason := "AutoModerator: " + rule.Name
+	removed := false
+	flagged := false
+
+	for _, action := range rule.Actions {
+		switch action.Type {
+		case constant.AUTOMOD_ACTION_HOLD:
+			// Comments cannot wait for approval, the flag puts them in the queue
+			if target.Comment == nil && target.Post.Status == constant.POST_STATUS_APPROVED {
+				if err := s.postRepo.UpdatePostStatus(target.Post.ID, constant.POST_STATUS_PENDING); err != nil {
+					logger.ErrorfWithCtx(ctx, "[Err] Error holding post in AutoModService.applyActions: %v", err)
+				} else {
+					target.Post.Status = constant.POST_STATUS_PENDING
+				}
+			}
+			if !flagged {
+				s.flagContent(ctx, target, reason)
+				flagged = true
+			}
+		case constant.AUTOMOD_ACTION_REMOVE:
+			if target.Comment != nil {
+				if err := s.commentRepo.DeleteComment(target.Comment.ID, constant.COMMENT_DELETED_BY_MODERATOR); err != nil {
+					logger.ErrorfWithCtx(ctx, "[Err] Error removing comment in AutoModService.applyActions: %v", err)
+					continue
+				}
+			} else {
+				if err := s.postRepo.UpdatePostStatus(target.Post.ID, constant.POST_STATUS_REJECTED); err != nil {
+					logger.ErrorfWithCtx(ctx, "[Err] Error removing post in AutoModService.applyActions: %v", err)
+					continue
+				}
+				target.Post.Status = constant.POST_STATUS_REJECTED
+				// Moderators can still approve a false positive from the moderation queue
+				if !flagged {
+					s.flagContent(ctx, target, reason)
+					flagged = true
+				}
+			}
+			removed = true
+		case constant.AUTOMOD_ACTION_SET_FLAIR:
+			if target.Comment != nil {
+				continue
+			}
+			flair := action.Flair
+			if err := s.postRepo.UpdatePostFlair(target.Post.ID, &flair); err != nil {
+				logger.ErrorfWithCtx(ctx, "[Err] Error setting post flair in AutoModService.applyActions: %v", err)
+				continue
+			}
+			target.Post.Flair = &flair
+		case constant.AUTOMOD_ACTION_COMMENT:
+			s.postComment(ctx, target, action.Message)
+		case constant.AUTOMOD_ACTION_NOTIFY_MODERATORS:
+			s.notifyModerators(ctx, rule, target, action.Message)
+		}
+	}
+
+	return removed
+}
+
+// markRuleFired records that a reported rule fired on the target and reports whether
+// it had not fired on it before. When that cannot be recorded the rule does not fire.
+func (s *AutoModService) markRuleFired(ctx context.Context, rule *autoModRule, target *autoModTarget) bool {
+	firing := &model.AutoModRuleFiring{
+		RuleID:     rule.ID,
+		TargetType: constant.MODERATION_TARGET_POST,
+		TargetID:   target.Post.ID,
+		FiredAt:    time.Now(),
+	}
+	if target.Comment != nil {
+		firing.TargetType = constant.MODERATION_TARGET_COMMENT
+		firing.TargetID = target.Comment.ID
+	}
+
+	firstFiring, err := s.autoModRuleRepo.MarkRuleFired(firing)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error recording automod rule firing in AutoModService.markRuleFired: %v", err)
+		return false
+	}
+	return firstFiring
+}
+
+func (s *AutoModService) flagContent(ctx context.Context, target *autoModTarget, reason string) {
+	if s.contentFlagRepo == nil {
+		return
+	}
+
+	flag := &model.ContentFlag{
+		CommunityID: target.Post.CommunityID,
+		TargetType:  constant.MODERATION_TARGET_POST,
+		TargetID:    target.Post.ID,
+		Source:      constant.CONTENT_FLAG_SOURCE_AUTOMOD,
+		Reason:      reason,
+		CreatedAt:   time.Now(),
+	}
+	if target.Comment != nil {
+		flag.TargetType = constant.MODERATION_TARGET_COMMENT
+		flag.TargetID = target.Comment.ID
+	}
+	if err := s.contentFlagRepo.CreateContentFlag(flag); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error flagging content in AutoModService.flagContent: %v", err)
+	}
+}
+
+// postComment replies to the content as the configured AutoModerator account
+func (s *AutoModService) postComment(ctx context.Context, target *autoModTarget, message string) {
+	if s.botUserID == 0 {
+		logger.InfofWithCtx(ctx, "[Info] AutoModerator account is not configured, skipping comment on post %d", target.Post.ID)
+		return
+	}
+
+	content := message
+	if s.contentSanitizer != nil {
+		content = s.contentSanitizer.SanitizeContent(message, constant.CONTENT_FORMAT_MARKDOWN)
+	}
+	comment := &model.Comment{
+		PostID:      target.Post.ID,
+		AuthorID:    s.botUserID,
+		Content:     content,
+		ContentText: util.HTMLToText(content),
+	}
+	if target.Comment != nil {
+		parentID := target.Comment.ID
+		comment.ParentCommentID = &parentID
+	}
+	if err := s.commentRepo.CreateComment(comment); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error posting comment in AutoModService.postComment: %v", err)
+	}
+}
+
+func (s *AutoModService) notifyModerators(ctx context.Context, rule *autoModRule, target *autoModTarget, message string) {
+	if s.notificationService == nil {
+		return
+	}
+
+	community, err := s.communityRepo.GetCommunityByID(target.Post.CommunityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Community not found in AutoModService.notifyModerators: %v", err)
+		return
+	}
+	moderators, err := s.communityModeratorRepo.GetCommunityModerators(community.ID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting moderators in AutoModService.notifyModerators: %v", err)
+		return
+	}
+
+	notifPayload := payload.AutoModTriggeredNotificationPayload{
+		CommunityID:   community.ID,
+		CommunityName: community.Name,
+		RuleID:        rule.ID,
+		RuleName:      rule.Name,
+		PostID:        target.Post.ID,
+		Message:       message,
+	}
+	if target.Comment != nil {
+		commentID := target.Comment.ID
+		notifPayload.CommentID = &commentID
+	}
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.ErrorfWithCtx(ctx, "[Panic] Recovered in AutoModService.notifyModerators: %v", r)
+			}
+		}()
+
+		for _, moderator := range moderators {
+			if err := s.notificationService.CreateNotification(ctx, moderator.UserID, constant.NOTIFICATION_ACTION_AUTOMOD_TRIGGERED, notifPayload); err != nil {
+				logger.ErrorfWithCtx(ctx, "[Err] Error notifying moderator %d in AutoModService.notifyModerators: %v", moderator.UserID, err)
+			}
+		}
+	}()
+}
+
+func (s *AutoModService) countOpenReports(ctx context.Context, post *model.Post, comment *model.Comment) int {
+	if comment != nil {
+		reports, err := s.commentReportRepo.GetOpenReportsByCommentIDs([]uint64{comment.ID})
+		if err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Error counting comment reports in AutoModService.countOpenReports: %v", err)
+			return 0
+		}
+		return len(reports)
+	}
+
+	reports, err := s.postReportRepo.GetOpenReportsByPostIDs([]uint64{post.ID})
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error counting post reports in AutoModService.countOpenReports: %v", err)
+		return 0
+	}
+	return len(reports)
+}
+
+func (s *AutoModService) countDryRunReports(ctx context.Context, posts []*model.Post, comments []*model.Comment) (map[uint64]int, map[uint64]int) {
+	postReportCounts := make(map[uint64]int)
+	commentReportCounts := make(map[uint64]int)
+
+	postIDs := make([]uint64, 0, len(posts))
+	for _, post := range posts {
+		postIDs = append(postIDs, post.ID)
+	}
+	if postReports, err := s.postReportRepo.GetOpenReportsByPostIDs(postIDs); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting post reports in AutoModService.DryRun: %v", err)
+	} else {
+		for _, report := range postReports {
+			postReportCounts[report.PostID]++
+		}
+	}
+
+	commentIDs := make([]uint64, 0, len(comments))
+	for _, comment := range comments {
+		commentIDs = append(commentIDs, comment.ID)
+	}
+	if commentReports, err := s.commentReportRepo.GetOpenReportsByCommentIDs(commentIDs); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting comment reports in AutoModService.DryRun: %v", err)
+	} else {
+		for _, report := range commentReports {
+			commentReportCounts[report.CommentID]++
+		}
+	}
+
+	return postReportCounts, commentReportCounts
+}
+
+func (s *AutoModService) getModeratorIDs(ctx context.Context, communityID uint64) map[uint64]bool {
+	moderatorIDs := make(map[uint64]bool)
+	moderators, err := s.communityModeratorRepo.GetCommunityModerators(communityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting moderators in AutoModService.getModeratorIDs: %v", err)
+		return moderatorIDs
+	}
+	for _, moderator := range moderators {
+		if isModeratorRole(moderator.Role) {
+			moderatorIDs[moderator.UserID] = true
+		}
+	}
+	return moderatorIDs
+}
+
+func (s *AutoModService) recordRuleChange(ctx context.Context, communityID, actorID uint64, action string, rule *model.AutoModRule) {
+	if s.moderationLogRepo == nil {
+		return
+	}
+
+	details := rule.Name
+	moderationLog := &model.ModerationLog{
+		CommunityID: communityID,
+		ActorID:     actorID,
+		Action:      action,
+		TargetType:  constant.MODERATION_TARGET_AUTOMOD_RULE,
+		TargetID:    rule.ID,
+		Details:     &details,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.moderationLogRepo.CreateModerationLog(moderationLog); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error recording moderation log in AutoModService.recordRuleChange: %v", err)
+	}
+}
+
+func (s *AutoModService) getCommunityRule(ctx context.Context, communityID, ruleID uint64, method string) (*model.AutoModRule, error) {
+	rule, err := s.autoModRuleRepo.GetRuleByID(ruleID)
+	if err != nil || rule.CommunityID != communityID {
+		logger.ErrorfWithCtx(ctx, "[Err] Rule not found in AutoModService.%s: ruleID=%d, communityID=%d, err=%v", method, ruleID, communityID, err)
+		return nil, fmt.Errorf("rule not found")
+	}
+	return rule, nil
+}
+
+func (s *AutoModService) checkModerator(ctx context.Context, userID, communityID uint64, method string) (*model.Community, error) {
+	// Check if community exists
+	community, err := s.communityRepo.GetCommunityByID(communityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Community not found in AutoModService.%s: %v", method, err)
+		return nil, fmt.Errorf("community not found")
+	}
+
+	if err := checkCommunityPermission(ctx, s.communityModeratorRepo, communityID, userID, constant.COMMUNITY_PERMISSION_MANAGE_RULES, "AutoModService."+method); err != nil {
+		return nil, err
+	}
+
+	return community, nil
+}
+
+// enabledRules returns the compiled enabled rules of a community that run on trigger
+func (s *AutoModService) enabledRules(ctx context.Context, communityID uint64, trigger string) ([]*autoModRule, error) {
+	s.rulesMu.RLock()
+	rules, ok := s.rules[communityID][trigger]
+	version := s.rulesVersion
+	s.rulesMu.RUnlock()
+	if ok {
+		return rules, nil
+	}
+
+	savedRules, err := s.autoModRuleRepo.GetEnabledRules(communityID, trigger)
+	if err != nil {
+		return nil, err
+	}
+	rules = make([]*autoModRule, 0, len(savedRules))
+	for _, savedRule := range savedRules {
+		if rule := s.compileRule(ctx, savedRule); rule != nil {
+			rules = append(rules, rule)
+		}
+	}
+
+	s.rulesMu.Lock()
+	defer s.rulesMu.Unlock()
+	if s.rulesVersion == version {
+		if s.rules[communityID] == nil {
+			s.rules[communityID] = make(map[string][]*autoModRule)
+		}
+		s.rules[communityID][trigger] = rules
+	}
+	return rules, nil
+}
+
+func (s *AutoModService) invalidateRules(communityID uint64) {
+	s.rulesMu.Lock()
+	defer s.rulesMu.Unlock()
+	delete(s.rules, communityID)
+	s.rulesVersion++
+}
+
+// compileRule parses a saved rule, a rule that no longer parses is skipped
+func (s *AutoModService) compileRule(ctx context.Context, savedRule *model.AutoModRule) *autoModRule {
+	rule, err := compileAutoModRule(savedRule)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid automod rule %d in AutoModService.compileRule: %v", savedRule.ID, err)
+		return nil
+	}
+	return rule
+}
+
+// setAutoModRuleDefinition validates the request and stores it on the rule
+func setAutoModRuleDefinition(rule *model.AutoModRule, req *request.AutoModRuleRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return fmt.Errorf("invalid rule: name is required")
+	}
+	if len(req.Actions) > constant.AUTOMOD_MAX_ACTIONS_PER_RULE {
+		return fmt.Errorf("invalid rule: at most %d actions are allowed", constant.AUTOMOD_MAX_ACTIONS_PER_RULE)
+	}
+
+	for _, action := range req.Actions {
+		switch action.Type {
+		case constant.AUTOMOD_ACTION_HOLD, constant.AUTOMOD_ACTION_REMOVE, constant.AUTOMOD_ACTION_NOTIFY_MODERATORS:
+		case constant.AUTOMOD_ACTION_SET_FLAIR:
+			if strings.TrimSpace(action.Flair) == "" || len(action.Flair) > constant.AUTOMOD_MAX_FLAIR_LENGTH {
+				return fmt.Errorf("invalid rule: flair must be 1 to %d characters", constant.AUTOMOD_MAX_FLAIR_LENGTH)
+			}
+		case constant.AUTOMOD_ACTION_COMMENT:
+			if strings.TrimSpace(action.Message) == "" {
+				return fmt.Errorf("invalid rule: comment message is required")
+			}
+		default:
+			return fmt.Errorf("invalid rule: unknown action %q", action.Type)
+		}
+		if len(action.Message) > constant.AUTOMOD_MAX_COMMENT_LENGTH {
+			return fmt.Errorf("invalid rule: message must be at most %d characters", constant.AUTOMOD_MAX_COMMENT_LENGTH)
+		}
+	}
+
+	conditions := req.Conditions
+	for _, pattern := range []string{conditions.TitleRegex, conditions.ContentRegex} {
+		if len(pattern) > constant.AUTOMOD_MAX_REGEX_LENGTH {
+			return fmt.Errorf("invalid rule: patterns must be at most %d characters", constant.AUTOMOD_MAX_REGEX_LENGTH)
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid rule: %v", err)
+		}
+	}
+	for _, postType := range conditions.PostTypes {
+		switch postType {
+		case constant.PostTypeText, constant.PostTypeLink, constant.PostTypeMedia, constant.PostTypePoll:
+		default:
+			return fmt.Errorf("invalid rule: unknown post type %q", postType)
+		}
+	}
+	if conditions.MinReports < 0 {
+		return fmt.Errorf("invalid rule: minReports cannot be negative")
+	}
+
+	rawConditions, err := json.Marshal(conditions)
+	if err != nil {
+		return fmt.Errorf("invalid rule: %v", err)
+	}
+	rawActions, err := json.Marshal(req.Actions)
+	if err != nil {
+		return fmt.Errorf("invalid rule: %v", err)
+	}
+	conditionsMessage := json.RawMessage(rawConditions)
+	actionsMessage := json.RawMessage(rawActions)
+
+	rule.Name = name
+	rule.Triggers = req.Triggers
+	rule.Conditions = &conditionsMessage
+	rule.Actions = &actionsMessage
+	rule.Enabled = req.Enabled == nil || *req.Enabled
+	return nil
+}
+
+func compileAutoModRule(savedRule *model.AutoModRule) (*autoModRule, error) {
+	rule := &autoModRule{
+		ID:       savedRule.ID,
+		Name:     savedRule.Name,
+		Triggers: savedRule.Triggers,
+	}
+	if savedRule.Conditions != nil {
+		if err := json.Unmarshal(*savedRule.Conditions, &rule.Conditions); err != nil {
+			return nil, err
+		}
+	}
+	if savedRule.Actions != nil {
+		if err := json.Unmarshal(*savedRule.Actions, &rule.Actions); err != nil {
+			return nil, err
+		}
+	}
+
+	var err error
+	if rule.Conditions.TitleRegex != "" {
+		if rule.titlePattern, err = regexp.Compile(rule.Conditions.TitleRegex); err != nil {
+			return nil, err
+		}
+	}
+	if rule.Conditions.ContentRegex != "" {
+		if rule.bodyPattern, err = regexp.Compile(rule.Conditions.ContentRegex); err != nil {
+			return nil, err
+		}
+	}
+	return rule, nil
+}
+
+// reportThreshold is the number of open reports at which a reported rule fires
+func (r *autoModRule) reportThreshold() int {
+	if r.Conditions.MinReports > 1 {
+		return r.Conditions.MinReports
+	}
+	return 1
+}
+
+func (r *autoModRule) hasTrigger(trigger string) bool {
+	for _, t := range r.Triggers {
+		if t == trigger {
+			return true
+		}
+	}
+	return false
+}
+
+// dryRunTrigger returns the trigger the rule would have matched the content on, or an
+// empty string. A reported trigger matches content that has reached the threshold.
+func (r *autoModRule) dryRunTrigger(target *autoModTarget) string {
+	createdTrigger, reportedTrigger := constant.AUTOMOD_TRIGGER_POST_CREATED, constant.AUTOMOD_TRIGGER_POST_REPORTED
+	if target.Comment != nil {
+		createdTrigger, reportedTrigger = constant.AUTOMOD_TRIGGER_COMMENT_CREATED, constant.AUTOMOD_TRIGGER_COMMENT_REPORTED
+	}
+
+	if r.hasTrigger(createdTrigger) {
+		created := *target
+		created.ReportCount = 0
+		if r.matches(&created) {
+			return createdTrigger
+		}
+	}
+	if r.hasTrigger(reportedTrigger) && target.ReportCount >= r.reportThreshold() && r.matches(target) {
+		return reportedTrigger
+	}
+	return ""
+}
+
+// matches reports whether every condition of the rule holds for the target. Author
+// conditions never hold when the author could not be loaded.
+func (r *autoModRule) matches(target *autoModTarget) bool {
+	conditions := r.Conditions
+	isComment := target.Comment != nil
+
+	if conditions.AuthorKarmaBelow != nil {
+		if target.Author == nil || target.Author.Karma >= *conditions.AuthorKarmaBelow {
+			return false
+		}
+	}
+	if conditions.AccountAgeDaysBelow != nil {
+		if target.Author == nil || time.Since(target.Author.CreatedAt) >= time.Duration(*conditions.AccountAgeDaysBelow)*24*time.Hour {
+			return false
+		}
+	}
+
+	if r.titlePattern != nil && (isComment || !r.titlePattern.MatchString(target.Post.Title)) {
+		return false
+	}
+	if r.bodyPattern != nil && !r.bodyPattern.MatchString(target.bodyText()) {
+		return false
+	}
+
+	if len(conditions.Domains) > 0 && !matchesAnyDomain(target.linkDomains(), conditions.Domains) {
+		return false
+	}
+
+	if len(conditions.Tags) > 0 {
+		if isComment || target.Post.Tags == nil || !containsAnyFold(*target.Post.Tags, conditions.Tags) {
+			return false
+		}
+	}
+	if len(conditions.PostTypes) > 0 && (isComment || !containsAnyFold([]string{target.Post.Type}, conditions.PostTypes)) {
+		return false
+	}
+
+	return target.ReportCount >= conditions.MinReports
+}
+
+func (t *autoModTarget) bodyText() string {
+	if t.Comment != nil {
+		return t.Comment.ContentText
+	}
+	if t.Post.ContentText != "" {
+		return t.Post.ContentText
+	}
+	return util.HTMLToText(t.Post.Content)
+}
+
+// linkDomains returns the domains of the post's link and of the links in the content
+func (t *autoModTarget) linkDomains() []string {
+	if t.Comment != nil {
+		return util.ExtractLinkDomains(t.Comment.Content)
+	}
+	domains := util.ExtractLinkDomains(t.Post.Content)
+	if t.Post.URL != nil {
+		if domain := util.LinkDomain(*t.Post.URL); domain != "" {
+			domains = append(domains, domain)
+		}
+	}
+	return domains
+}
+
+func matchesAnyDomain(hosts, domains []string) bool {
+	for _, host := range hosts {
+		for _, domain := range domains {
+			if util.MatchesDomain(host, domain) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func containsAnyFold(values, candidates []string) bool {
+	for _, value := range values {
+		for _, candidate := range candidates {
+			if strings.EqualFold(value, candidate) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func newAutoModDryRunMatch(rule *autoModRule, trigger, targetType string, targetID uint64, content *response.ModerationQueueContentInfo) *response.AutoModDryRunMatch {
+	actions := make([]string, 0, len(rule.Actions))
+	for _, action := range rule.Actions {
+		actions = append(actions, action.Type)
+	}
+	return &response.AutoModDryRunMatch{
+		TargetType: targetType,
+		TargetID:   targetID,
+		Content:    content,
+		Trigger:    trigger,
+		RuleID:     rule.ID,
+		RuleName:   rule.Name,
+		Actions:    actions,
+	}
+}
@@ -0,0 +1,493 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"social-platform-backend/config"
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/interface/dto/request"
+	"social-platform-backend/package/constant"
+	"social-platform-backend/package/template/payload"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestAutoModRule(id uint64, triggers []string, conditions payload.AutoModConditions, actions ...payload.AutoModAction) *model.AutoModRule {
+	rawConditions, _ := json.Marshal(conditions)
+	rawActions, _ := json.Marshal(actions)
+	conditionsMessage := json.RawMessage(rawConditions)
+	actionsMessage := json.RawMessage(rawActions)
+	return &model.AutoModRule{
+		ID:          id,
+		CommunityID: 3,
+		Name:        "Spam filter",
+		Triggers:    triggers,
+		Conditions:  &conditionsMessage,
+		Actions:     &actionsMessage,
+		Enabled:     true,
+	}
+}
+
+func TestAutoModService_Evaluate_HoldsPostFromNewAccount(t *testing.T) {
+	mockAutoModRuleRepo := new(MockAutoModRuleRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockPostRepo := new(MockPostRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockContentFlagRepo := new(MockContentFlagRepository)
+	autoModService := NewAutoModService(
+		mockAutoModRuleRepo,
+		nil,
+		mockCommunityModeratorRepo,
+		mockPostRepo,
+		nil,
+		mockUserRepo,
+		nil, nil,
+		mockContentFlagRepo,
+		nil, nil,
+		newTestContentSanitizer(),
+		&config.Config{},
+	)
+
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), mock.Anything).Return("", assert.AnError)
+
+	maxKarma := uint64(10)
+	url := "https://www.spam.example.com/offer"
+	post := &model.Post{ID: 10, CommunityID: 3, AuthorID: 5, Title: "Great offer", Type: constant.PostTypeLink, URL: &url, Status: constant.POST_STATUS_APPROVED}
+
+	mockAutoModRuleRepo.On("GetEnabledRules", uint64(3), constant.AUTOMOD_TRIGGER_POST_CREATED).Return([]*model.AutoModRule{
+		newTestAutoModRule(1, []string{constant.AUTOMOD_TRIGGER_POST_CREATED},
+			payload.AutoModConditions{AuthorKarmaBelow: &maxKarma, Domains: []string{"example.com"}},
+			payload.AutoModAction{Type: constant.AUTOMOD_ACTION_HOLD},
+			payload.AutoModAction{Type: constant.AUTOMOD_ACTION_SET_FLAIR, Flair: "Needs review"}),
+	}, nil)
+	mockUserRepo.On("GetUserByID", uint64(5)).Return(&model.User{ID: 5, Karma: 2, CreatedAt: time.Now()}, nil)
+	mockPostRepo.On("UpdatePostStatus", uint64(10), constant.POST_STATUS_PENDING).Return(nil)
+	mockPostRepo.On("UpdatePostFlair", uint64(10), mock.Anything).Return(nil)
+	mockContentFlagRepo.On("CreateContentFlag", mock.MatchedBy(func(flag *model.ContentFlag) bool {
+		return flag.TargetType == constant.MODERATION_TARGET_POST && flag.TargetID == 10 && flag.Source == constant.CONTENT_FLAG_SOURCE_AUTOMOD
+	})).Return(nil)
+
+	removed := autoModService.Evaluate(context.Background(), constant.AUTOMOD_TRIGGER_POST_CREATED, post, nil)
+
+	assert.False(t, removed)
+	assert.Equal(t, constant.POST_STATUS_PENDING, post.Status)
+	assert.Equal(t, "Needs review", *post.Flair)
+	mockPostRepo.AssertExpectations(t)
+	mockContentFlagRepo.AssertExpectations(t)
+}
+
+func TestAutoModService_Evaluate_NoMatch(t *testing.T) {
+	mockAutoModRuleRepo := new(MockAutoModRuleRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockPostRepo := new(MockPostRepository)
+	mockUserRepo := new(MockUserRepository)
+	autoModService := NewAutoModService(
+		mockAutoModRuleRepo,
+		nil,
+		mockCommunityModeratorRepo,
+		mockPostRepo,
+		nil,
+		mockUserRepo,
+		nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		&config.Config{},
+	)
+
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), mock.Anything).Return("", assert.AnError)
+
+	post := &model.Post{ID: 10, CommunityID: 3, AuthorID: 5, Title: "Weekly discussion", Type: constant.PostTypeText, Status: constant.POST_STATUS_APPROVED,
+		Tags: &pq.StringArray{"meta"}}
+
+	mockAutoModRuleRepo.On("GetEnabledRules", uint64(3), constant.AUTOMOD_TRIGGER_POST_CREATED).Return([]*model.AutoModRule{
+		newTestAutoModRule(1, []string{constant.AUTOMOD_TRIGGER_POST_CREATED},
+			payload.AutoModConditions{TitleRegex: "(?i)giveaway", Tags: []string{"Meta"}},
+			payload.AutoModAction{Type: constant.AUTOMOD_ACTION_REMOVE}),
+	}, nil)
+	mockUserRepo.On("GetUserByID", uint64(5)).Return(&model.User{ID: 5}, nil)
+
+	removed := autoModService.Evaluate(context.Background(), constant.AUTOMOD_TRIGGER_POST_CREATED, post, nil)
+
+	assert.False(t, removed)
+	assert.Equal(t, constant.POST_STATUS_APPROVED, post.Status)
+	mockPostRepo.AssertNotCalled(t, "UpdatePostStatus", mock.Anything, mock.Anything)
+}
+
+func TestAutoModService_Evaluate_RemovesComment(t *testing.T) {
+	mockAutoModRuleRepo := new(MockAutoModRuleRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockCommentRepo := new(MockCommentRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockContentFlagRepo := new(MockContentFlagRepository)
+	autoModService := NewAutoModService(
+		mockAutoModRuleRepo,
+		nil,
+		mockCommunityModeratorRepo,
+		nil,
+		mockCommentRepo,
+		mockUserRepo,
+		nil, nil,
+		mockContentFlagRepo,
+		nil, nil,
+		newTestContentSanitizer(),
+		&config.Config{},
+	)
+
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), mock.Anything).Return("", assert.AnError)
+
+	post := &model.Post{ID: 10, CommunityID: 3, AuthorID: 1}
+	comment := &model.Comment{ID: 20, PostID: 10, AuthorID: 5, ContentText: "Buy CHEAP pills now"}
+
+	mockAutoModRuleRepo.On("GetEnabledRules", uint64(3), constant.AUTOMOD_TRIGGER_COMMENT_CREATED).Return([]*model.AutoModRule{
+		newTestAutoModRule(1, []string{constant.AUTOMOD_TRIGGER_COMMENT_CREATED},
+			payload.AutoModConditions{ContentRegex: "(?i)cheap pills"},
+			payload.AutoModAction{Type: constant.AUTOMOD_ACTION_REMOVE}),
+		newTestAutoModRule(2, []string{constant.AUTOMOD_TRIGGER_COMMENT_CREATED},
+			payload.AutoModConditions{},
+			payload.AutoModAction{Type: constant.AUTOMOD_ACTION_HOLD}),
+	}, nil)
+	mockUserRepo.On("GetUserByID", uint64(5)).Return(&model.User{ID: 5}, nil)
+	mockCommentRepo.On("DeleteComment", uint64(20), constant.COMMENT_DELETED_BY_MODERATOR).Return(nil)
+
+	removed := autoModService.Evaluate(context.Background(), constant.AUTOMOD_TRIGGER_COMMENT_CREATED, post, comment)
+
+	assert.True(t, removed)
+	mockCommentRepo.AssertExpectations(t)
+	// Rules after a removal are not run
+	mockContentFlagRepo.AssertNotCalled(t, "CreateContentFlag", mock.Anything)
+}
+
+func TestAutoModService_Evaluate_ReportThreshold(t *testing.T) {
+	mockAutoModRuleRepo := new(MockAutoModRuleRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockPostRepo := new(MockPostRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockPostReportRepo := new(MockPostReportRepository)
+	mockContentFlagRepo := new(MockContentFlagRepository)
+	autoModService := NewAutoModService(
+		mockAutoModRuleRepo,
+		nil,
+		mockCommunityModeratorRepo,
+		mockPostRepo,
+		nil,
+		mockUserRepo,
+		mockPostReportRepo,
+		nil,
+		mockContentFlagRepo,
+		nil, nil,
+		newTestContentSanitizer(),
+		&config.Config{},
+	)
+
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), mock.Anything).Return("", assert.AnError)
+
+	post := &model.Post{ID: 10, CommunityID: 3, AuthorID: 5, Status: constant.POST_STATUS_APPROVED}
+
+	mockAutoModRuleRepo.On("GetEnabledRules", uint64(3), constant.AUTOMOD_TRIGGER_POST_REPORTED).Return([]*model.AutoModRule{
+		newTestAutoModRule(1, []string{constant.AUTOMOD_TRIGGER_POST_REPORTED},
+			payload.AutoModConditions{MinReports: 3},
+			payload.AutoModAction{Type: constant.AUTOMOD_ACTION_HOLD}),
+	}, nil)
+	mockUserRepo.On("GetUserByID", uint64(5)).Return(&model.User{ID: 5}, nil)
+	mockPostReportRepo.On("GetOpenReportsByPostIDs", []uint64{10}).Return([]*model.PostReport{{ID: 1}, {ID: 2}}, nil).Once()
+
+	autoModService.Evaluate(context.Background(), constant.AUTOMOD_TRIGGER_POST_REPORTED, post, nil)
+	mockPostRepo.AssertNotCalled(t, "UpdatePostStatus", mock.Anything, mock.Anything)
+
+	// two reports arrive together, the count skips past the threshold
+	mockPostReportRepo.On("GetOpenReportsByPostIDs", []uint64{10}).Return([]*model.PostReport{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}}, nil).Once()
+	mockAutoModRuleRepo.On("MarkRuleFired", mock.MatchedBy(func(firing *model.AutoModRuleFiring) bool {
+		return firing.RuleID == 1 && firing.TargetType == constant.MODERATION_TARGET_POST && firing.TargetID == 10
+	})).Return(true, nil).Once()
+	mockPostRepo.On("UpdatePostStatus", uint64(10), constant.POST_STATUS_PENDING).Return(nil)
+	mockContentFlagRepo.On("CreateContentFlag", mock.Anything).Return(nil)
+
+	autoModService.Evaluate(context.Background(), constant.AUTOMOD_TRIGGER_POST_REPORTED, post, nil)
+
+	assert.Equal(t, constant.POST_STATUS_PENDING, post.Status)
+	mockPostRepo.AssertExpectations(t)
+
+	// the rule already fired on the post, later reports do not fire it again
+	mockPostReportRepo.On("GetOpenReportsByPostIDs", []uint64{10}).Return([]*model.PostReport{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}, {ID: 5}}, nil).Once()
+	mockAutoModRuleRepo.On("MarkRuleFired", mock.Anything).Return(false, nil).Once()
+
+	autoModService.Evaluate(context.Background(), constant.AUTOMOD_TRIGGER_POST_REPORTED, post, nil)
+
+	mockContentFlagRepo.AssertNumberOfCalls(t, "CreateContentFlag", 1)
+	mockAutoModRuleRepo.AssertNumberOfCalls(t, "GetEnabledRules", 1)
+}
+
+func TestAutoModService_DeleteRule_InvalidatesCachedRules(t *testing.T) {
+	mockAutoModRuleRepo := new(MockAutoModRuleRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockUserRepo := new(MockUserRepository)
+	autoModService := NewAutoModService(
+		mockAutoModRuleRepo,
+		mockCommunityRepo,
+		mockCommunityModeratorRepo,
+		nil, nil,
+		mockUserRepo,
+		nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		&config.Config{},
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(1)).Return(constant.ROLE_ADMIN, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(5)).Return("", assert.AnError)
+	mockUserRepo.On("GetUserByID", uint64(5)).Return(&model.User{ID: 5, CreatedAt: time.Now()}, nil)
+
+	post := &model.Post{ID: 10, CommunityID: 3, AuthorID: 5, Title: "Hello", Status: constant.POST_STATUS_APPROVED}
+	rule := newTestAutoModRule(1, []string{constant.AUTOMOD_TRIGGER_POST_CREATED},
+		payload.AutoModConditions{TitleRegex: "^spam"},
+		payload.AutoModAction{Type: constant.AUTOMOD_ACTION_REMOVE})
+	mockAutoModRuleRepo.On("GetEnabledRules", uint64(3), constant.AUTOMOD_TRIGGER_POST_CREATED).Return([]*model.AutoModRule{rule}, nil).Once()
+
+	autoModService.Evaluate(context.Background(), constant.AUTOMOD_TRIGGER_POST_CREATED, post, nil)
+	autoModService.Evaluate(context.Background(), constant.AUTOMOD_TRIGGER_POST_CREATED, post, nil)
+	mockAutoModRuleRepo.AssertNumberOfCalls(t, "GetEnabledRules", 1)
+
+	mockAutoModRuleRepo.On("GetRuleByID", uint64(1)).Return(rule, nil)
+	mockAutoModRuleRepo.On("DeleteRule", uint64(1)).Return(nil)
+	mockAutoModRuleRepo.On("GetEnabledRules", uint64(3), constant.AUTOMOD_TRIGGER_POST_CREATED).Return([]*model.AutoModRule{}, nil).Once()
+
+	err := autoModService.DeleteRule(context.Background(), 1, 3, 1)
+	assert.NoError(t, err)
+
+	autoModService.Evaluate(context.Background(), constant.AUTOMOD_TRIGGER_POST_CREATED, post, nil)
+	mockAutoModRuleRepo.AssertNumberOfCalls(t, "GetEnabledRules", 2)
+}
+
+func TestAutoModService_Evaluate_SkipsModerators(t *testing.T) {
+	mockAutoModRuleRepo := new(MockAutoModRuleRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockPostRepo := new(MockPostRepository)
+	autoModService := NewAutoModService(
+		mockAutoModRuleRepo,
+		nil,
+		mockCommunityModeratorRepo,
+		mockPostRepo,
+		nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		&config.Config{},
+	)
+
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(1)).Return(constant.ROLE_ADMIN, nil)
+
+	post := &model.Post{ID: 10, CommunityID: 3, AuthorID: 1, Status: constant.POST_STATUS_APPROVED}
+
+	mockAutoModRuleRepo.On("GetEnabledRules", uint64(3), constant.AUTOMOD_TRIGGER_POST_CREATED).Return([]*model.AutoModRule{
+		newTestAutoModRule(1, []string{constant.AUTOMOD_TRIGGER_POST_CREATED},
+			payload.AutoModConditions{},
+			payload.AutoModAction{Type: constant.AUTOMOD_ACTION_REMOVE}),
+	}, nil)
+
+	removed := autoModService.Evaluate(context.Background(), constant.AUTOMOD_TRIGGER_POST_CREATED, post, nil)
+
+	assert.False(t, removed)
+	mockPostRepo.AssertNotCalled(t, "UpdatePostStatus", mock.Anything, mock.Anything)
+}
+
+func TestAutoModService_CreateRule_InvalidRegex(t *testing.T) {
+	mockAutoModRuleRepo := new(MockAutoModRuleRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	autoModService := NewAutoModService(
+		mockAutoModRuleRepo,
+		mockCommunityRepo,
+		mockCommunityModeratorRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		&config.Config{},
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, Name: "golang"}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(1)).Return(constant.ROLE_ADMIN, nil)
+
+	mockAutoModRuleRepo.On("CountRules", uint64(3)).Return(int64(0), nil)
+
+	_, err := autoModService.CreateRule(context.Background(), 1, 3, &request.AutoModRuleRequest{
+		Name:       "Spam",
+		Triggers:   []string{constant.AUTOMOD_TRIGGER_POST_CREATED},
+		Conditions: payload.AutoModConditions{TitleRegex: "(unclosed"},
+		Actions:    []payload.AutoModAction{{Type: constant.AUTOMOD_ACTION_REMOVE}},
+	})
+
+	assert.ErrorContains(t, err, "invalid rule")
+	mockAutoModRuleRepo.AssertNotCalled(t, "CreateRule", mock.Anything)
+}
+
+func TestAutoModService_CreateRule_Success(t *testing.T) {
+	mockAutoModRuleRepo := new(MockAutoModRuleRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockModerationLogRepo := new(MockModerationLogRepository)
+	autoModService := NewAutoModService(
+		mockAutoModRuleRepo,
+		mockCommunityRepo,
+		mockCommunityModeratorRepo,
+		nil, nil, nil, nil, nil, nil,
+		mockModerationLogRepo,
+		nil,
+		newTestContentSanitizer(),
+		&config.Config{},
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, Name: "golang"}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(1)).Return(constant.ROLE_ADMIN, nil)
+
+	mockAutoModRuleRepo.On("CountRules", uint64(3)).Return(int64(2), nil)
+	mockAutoModRuleRepo.On("CreateRule", mock.MatchedBy(func(rule *model.AutoModRule) bool {
+		return rule.CommunityID == 3 && rule.Name == "Spam" && rule.Enabled && rule.CreatedBy == 1
+	})).Return(nil)
+	mockModerationLogRepo.On("CreateModerationLog", mock.MatchedBy(func(log *model.ModerationLog) bool {
+		return log.Action == constant.MODERATION_ACTION_CREATE_AUTOMOD_RULE && log.TargetType == constant.MODERATION_TARGET_AUTOMOD_RULE
+	})).Return(nil)
+
+	rule, err := autoModService.CreateRule(context.Background(), 1, 3, &request.AutoModRuleRequest{
+		Name:       " Spam ",
+		Triggers:   []string{constant.AUTOMOD_TRIGGER_POST_CREATED},
+		Conditions: payload.AutoModConditions{Domains: []string{"spam.example"}},
+		Actions:    []payload.AutoModAction{{Type: constant.AUTOMOD_ACTION_REMOVE}},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"spam.example"}, rule.Conditions.Domains)
+	mockAutoModRuleRepo.AssertExpectations(t)
+	mockModerationLogRepo.AssertExpectations(t)
+}
+
+func TestAutoModService_DryRun(t *testing.T) {
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockPostRepo := new(MockPostRepository)
+	mockCommentRepo := new(MockCommentRepository)
+	mockPostReportRepo := new(MockPostReportRepository)
+	mockCommentReportRepo := new(MockCommentReportRepository)
+	autoModService := NewAutoModService(
+		nil,
+		mockCommunityRepo,
+		mockCommunityModeratorRepo,
+		mockPostRepo,
+		mockCommentRepo,
+		nil,
+		mockPostReportRepo,
+		mockCommentReportRepo,
+		nil, nil, nil,
+		newTestContentSanitizer(),
+		&config.Config{},
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, Name: "golang"}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(1)).Return(constant.ROLE_ADMIN, nil)
+
+	posts := []*model.Post{
+		{ID: 10, CommunityID: 3, AuthorID: 5, Title: "Free crypto giveaway", Author: &model.User{ID: 5}},
+		{ID: 11, CommunityID: 3, AuthorID: 6, Title: "Help with generics", Author: &model.User{ID: 6}},
+	}
+	comments := []*model.Comment{
+		{ID: 20, PostID: 11, AuthorID: 5, ContentText: "join my giveaway", Post: posts[1], Author: &model.User{ID: 5}},
+	}
+
+	mockPostRepo.On("GetRecentPostsByCommunityID", uint64(3), constant.AUTOMOD_DEFAULT_DRY_RUN_LIMIT).Return(posts, nil)
+	mockCommentRepo.On("GetRecentCommentsByCommunityID", uint64(3), constant.AUTOMOD_DEFAULT_DRY_RUN_LIMIT).Return(comments, nil)
+	mockPostReportRepo.On("GetOpenReportsByPostIDs", []uint64{10, 11}).Return([]*model.PostReport{}, nil)
+	mockCommentReportRepo.On("GetOpenReportsByCommentIDs", []uint64{20}).Return([]*model.CommentReport{}, nil)
+	mockCommunityModeratorRepo.On("GetCommunityModerators", uint64(3)).Return([]*model.CommunityModerator{}, nil)
+
+	result, err := autoModService.DryRun(context.Background(), 1, 3, &request.DryRunAutoModRequest{
+		Rule: &request.AutoModRuleRequest{
+			Name:       "Giveaways",
+			Triggers:   []string{constant.AUTOMOD_TRIGGER_POST_CREATED},
+			Conditions: payload.AutoModConditions{TitleRegex: "(?i)giveaway"},
+			Actions:    []payload.AutoModAction{{Type: constant.AUTOMOD_ACTION_HOLD}},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.PostsChecked)
+	assert.Equal(t, 1, result.CommentsChecked)
+	if assert.Len(t, result.Matches, 1) {
+		assert.Equal(t, uint64(10), result.Matches[0].TargetID)
+		assert.Equal(t, constant.AUTOMOD_TRIGGER_POST_CREATED, result.Matches[0].Trigger)
+		assert.Equal(t, []string{constant.AUTOMOD_ACTION_HOLD}, result.Matches[0].Actions)
+	}
+	mockPostRepo.AssertNotCalled(t, "UpdatePostStatus", mock.Anything, mock.Anything)
+}
+
+func TestAutoModService_Evaluate_CommentsAsBotUser(t *testing.T) {
+	mockAutoModRuleRepo := new(MockAutoModRuleRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockCommentRepo := new(MockCommentRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockUserRepo.On("GetUserByID", uint64(99)).Return(&model.User{ID: 99}, nil)
+	autoModService := NewAutoModService(
+		mockAutoModRuleRepo,
+		nil,
+		mockCommunityModeratorRepo,
+		nil,
+		mockCommentRepo,
+		mockUserRepo,
+		nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		&config.Config{AutoMod: config.AutoMod{BotUserID: 99}},
+	)
+
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(5)).Return("", assert.AnError)
+	mockUserRepo.On("GetUserByID", uint64(5)).Return(&model.User{ID: 5, CreatedAt: time.Now()}, nil)
+
+	post := &model.Post{ID: 10, CommunityID: 3, AuthorID: 5, Status: constant.POST_STATUS_APPROVED}
+
+	mockAutoModRuleRepo.On("GetEnabledRules", uint64(3), constant.AUTOMOD_TRIGGER_POST_CREATED).Return([]*model.AutoModRule{
+		newTestAutoModRule(1, []string{constant.AUTOMOD_TRIGGER_POST_CREATED},
+			payload.AutoModConditions{},
+			payload.AutoModAction{Type: constant.AUTOMOD_ACTION_COMMENT, Message: "Please read the rules"}),
+	}, nil)
+	mockCommentRepo.On("CreateComment", mock.MatchedBy(func(comment *model.Comment) bool {
+		return comment.PostID == 10 && comment.AuthorID == 99 && comment.ParentCommentID == nil
+	})).Return(nil)
+
+	autoModService.Evaluate(context.Background(), constant.AUTOMOD_TRIGGER_POST_CREATED, post, nil)
+
+	mockCommentRepo.AssertExpectations(t)
+}
+
+func TestAutoModService_Evaluate_SkipsCommentWhenBotUserMissing(t *testing.T) {
+	mockAutoModRuleRepo := new(MockAutoModRuleRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockCommentRepo := new(MockCommentRepository)
+	mockUserRepo := new(MockUserRepository)
+	// the configured account does not exist when the service is created
+	mockUserRepo.On("GetUserByID", uint64(99)).Return(nil, assert.AnError).Once()
+	autoModService := NewAutoModService(
+		mockAutoModRuleRepo,
+		nil,
+		mockCommunityModeratorRepo,
+		nil,
+		mockCommentRepo,
+		mockUserRepo,
+		nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		&config.Config{AutoMod: config.AutoMod{BotUserID: 99}},
+	)
+
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(5)).Return("", assert.AnError)
+	mockUserRepo.On("GetUserByID", uint64(5)).Return(&model.User{ID: 5, CreatedAt: time.Now()}, nil)
+
+	post := &model.Post{ID: 10, CommunityID: 3, AuthorID: 5, Status: constant.POST_STATUS_APPROVED}
+
+	mockAutoModRuleRepo.On("GetEnabledRules", uint64(3), constant.AUTOMOD_TRIGGER_POST_CREATED).Return([]*model.AutoModRule{
+		newTestAutoModRule(1, []string{constant.AUTOMOD_TRIGGER_POST_CREATED},
+			payload.AutoModConditions{},
+			payload.AutoModAction{Type: constant.AUTOMOD_ACTION_COMMENT, Message: "Please read the rules"}),
+	}, nil)
+
+	autoModService.Evaluate(context.Background(), constant.AUTOMOD_TRIGGER_POST_CREATED, post, nil)
+
+	mockCommentRepo.AssertNotCalled(t, "CreateComment", mock.Anything)
+	mockUserRepo.AssertExpectations(t)
+}
@@ -10,20 +10,30 @@ import (
 	"social-platform-backend/package/constant"
 	"social-platform-backend/package/logger"
 	"social-platform-backend/package/template/payload"
+	"social-platform-backend/package/util"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 type CommentService struct {
-	commentRepo         repository.CommentRepository
-	postRepo            repository.PostRepository
-	commentVoteRepo     repository.CommentVoteRepository
-	commentReportRepo   repository.CommentReportRepository
-	botTaskRepo         repository.BotTaskRepository
-	userRepo            repository.UserRepository
-	userSavedPostRepo   repository.UserSavedPostRepository
-	notificationService *NotificationService
-	botTaskService      *BotTaskService
-	aiServiceClient     *AIServiceClient
+	commentRepo            repository.CommentRepository
+	postRepo               repository.PostRepository
+	commentVoteRepo        repository.CommentVoteRepository
+	commentReportRepo      repository.CommentReportRepository
+	botTaskRepo            repository.BotTaskRepository
+	userRepo               repository.UserRepository
+	userSavedPostRepo      repository.UserSavedPostRepository
+	notificationService    *NotificationService
+	botTaskService         *BotTaskService
+	aiServiceClient        *AIServiceClient
+	commentRevisionRepo    repository.CommentRevisionRepository
+	contentSanitizer       *util.HTMLSanitizer
+	mentionService         *MentionService
+	reportRepo             repository.ReportRepository
+	autoModService         *AutoModService
+	communityRuleRepo      repository.CommunityRuleRepository
+	communityModeratorRepo repository.CommunityModeratorRepository
 }
 
 func NewCommentService(
@@ -37,18 +47,32 @@ func NewCommentService(
 	notificationService *NotificationService,
 	botTaskService *BotTaskService,
 	aiServiceClient *AIServiceClient,
+	commentRevisionRepo repository.CommentRevisionRepository,
+	contentSanitizer *util.HTMLSanitizer,
+	mentionService *MentionService,
+	reportRepo repository.ReportRepository,
+	autoModService *AutoModService,
+	communityRuleRepo repository.CommunityRuleRepository,
+	communityModeratorRepo repository.CommunityModeratorRepository,
 ) *CommentService {
 	return &CommentService{
-		commentRepo:         commentRepo,
-		postRepo:            postRepo,
-		commentVoteRepo:     commentVoteRepo,
-		commentReportRepo:   commentReportRepo,
-		botTaskRepo:         botTaskRepo,
-		userRepo:            userRepo,
-		userSavedPostRepo:   userSavedPostRepo,
-		notificationService: notificationService,
-		botTaskService:      botTaskService,
-		aiServiceClient:     aiServiceClient,
+		commentRepo:            commentRepo,
+		postRepo:               postRepo,
+		commentVoteRepo:        commentVoteRepo,
+		commentReportRepo:      commentReportRepo,
+		botTaskRepo:            botTaskRepo,
+		userRepo:               userRepo,
+		userSavedPostRepo:      userSavedPostRepo,
+		notificationService:    notificationService,
+		botTaskService:         botTaskService,
+		aiServiceClient:        aiServiceClient,
+		commentRevisionRepo:    commentRevisionRepo,
+		contentSanitizer:       contentSanitizer,
+		mentionService:         mentionService,
+		reportRepo:             reportRepo,
+		autoModService:         autoModService,
+		communityRuleRepo:      communityRuleRepo,
+		communityModeratorRepo: communityModeratorRepo,
 	}
 }
 
@@ -60,11 +84,21 @@ func (s *CommentService) CreateComment(ctx context.Context, userID uint64, req *
 		return fmt.Errorf("post not found")
 	}
 
+	if isUnpublishedPost(post) {
+		logger.ErrorfWithCtx(ctx, "[Err] Post is not published in CommentService.CreateComment: postID=%d", req.PostID)
+		return fmt.Errorf("post not found")
+	}
+
+	if post.LockedAt != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Post is locked in CommentService.CreateComment: postID=%d", req.PostID)
+		return fmt.Errorf("post is locked")
+	}
+
 	// If it is a reply, check if parent comment exists and belongs to the same post
 	var parentComment *model.Comment
 	if req.ParentCommentID != nil {
 		parentComment, err = s.commentRepo.GetCommentByID(*req.ParentCommentID)
-		if err != nil {
+		if err != nil || parentComment.DeletedAt != nil {
 			logger.ErrorfWithCtx(ctx, "[Err] Parent comment not found in CommentService.CreateComment: %v", err)
 			return fmt.Errorf("parent comment not found")
 		}
@@ -72,13 +106,23 @@ func (s *CommentService) CreateComment(ctx context.Context, userID uint64, req *
 			logger.ErrorfWithCtx(ctx, "[Err] Parent comment does not belong to the same post in CommentService.CreateComment")
 			return fmt.Errorf("parent comment does not belong to this post")
 		}
+		if err := s.checkCommentThreadLock(ctx, parentComment.ID); err != nil {
+			return err
+		}
+	}
+
+	content := s.contentSanitizer.SanitizeContent(req.Content, req.ContentFormat)
+	if content == "" {
+		logger.ErrorfWithCtx(ctx, "[Err] Content is empty after sanitization in CommentService.CreateComment")
+		return fmt.Errorf("content is empty")
 	}
 
 	comment := &model.Comment{
 		PostID:          req.PostID,
 		AuthorID:        userID,
 		ParentCommentID: req.ParentCommentID,
-		Content:         req.Content,
+		Content:         content,
+		ContentText:     util.HTMLToText(content),
 		MediaURL:        req.MediaURL,
 	}
 
@@ -87,6 +131,10 @@ func (s *CommentService) CreateComment(ctx context.Context, userID uint64, req *
 		return fmt.Errorf("failed to create comment")
 	}
 
+	if s.runAutoMod(ctx, constant.AUTOMOD_TRIGGER_COMMENT_CREATED, post, comment) {
+		return nil
+	}
+
 	go func(userID uint64, post *model.Post, parentComment *model.Comment, comment *model.Comment) {
 		defer func() {
 			if r := recover(); r != nil {
@@ -123,7 +171,7 @@ func (s *CommentService) CreateComment(ctx context.Context, userID uint64, req *
 				imageURLs = append(imageURLs, *comment.MediaURL)
 			}
 
-			if moderationResult, err := s.aiServiceClient.CheckContent(ctx, comment.Content, imageURLs); err != nil {
+			if moderationResult, err := s.aiServiceClient.CheckContent(ctx, comment.ContentText, imageURLs); err != nil {
 				logger.ErrorfWithCtx(ctx, "[Err] Error checking content via AI service in CommentService.CreateComment: %v", err)
 			} else if moderationResult != nil && moderationResult.IsViolation {
 				violation = true
@@ -135,7 +183,7 @@ func (s *CommentService) CreateComment(ctx context.Context, userID uint64, req *
 		if violation {
 			logger.InfofWithCtx(ctx, "[Info] Content violation detected for comment %d: %s", comment.ID, violationReason)
 
-			if err := s.commentRepo.DeleteComment(comment.ID, comment.ParentCommentID); err != nil {
+			if err := s.commentRepo.DeleteComment(comment.ID, constant.COMMENT_DELETED_BY_MODERATOR); err != nil {
 				logger.ErrorfWithCtx(ctx, "[Err] Error deleting comment for violation: %v", err)
 			}
 
@@ -160,6 +208,10 @@ func (s *CommentService) CreateComment(ctx context.Context, userID uint64, req *
 			}
 		}
 
+		if s.mentionService != nil {
+			s.mentionService.SyncCommentMentions(ctx, comment, post)
+		}
+
 		// Send notifications
 		commenter, err := s.userRepo.GetUserByID(userID)
 		if err != nil {
@@ -232,7 +284,10 @@ func (s *CommentService) CreateComment(ctx context.Context, userID uint64, req *
 	return nil
 }
 
-func (s *CommentService) GetCommentsByPostID(ctx context.Context, postID uint64, sortBy string, page, limit int, userID *uint64) ([]*response.CommentResponse, *response.Pagination, error) {
+// GetCommentsByPostID returns a page of top-level comments with their reply trees, loaded
+// depth levels deep with at most replyLimit replies per comment. Truncated comments carry
+// a continuation token to load the rest through GetCommentReplies.
+func (s *CommentService) GetCommentsByPostID(ctx context.Context, postID uint64, sortBy string, page, limit, depth, replyLimit int, userID *uint64) ([]*response.CommentResponse, *response.Pagination, error) {
 	// Validate pagination
 	if page <= 0 {
 		page = constant.DEFAULT_PAGE
@@ -240,6 +295,7 @@ func (s *CommentService) GetCommentsByPostID(ctx context.Context, postID uint64,
 	if limit <= 0 || limit > 100 {
 		limit = constant.DEFAULT_LIMIT
 	}
+	depth, replyLimit = normalizeCommentTreeLimits(depth, replyLimit)
 
 	if sortBy != constant.COMMENT_SORT_NEWEST && sortBy != constant.COMMENT_SORT_OLDEST && sortBy != constant.COMMENT_SORT_POPULAR {
 		sortBy = constant.COMMENT_SORT_NEWEST
@@ -254,23 +310,20 @@ func (s *CommentService) GetCommentsByPostID(ctx context.Context, postID uint64,
 		return nil, nil, fmt.Errorf("failed to get comments")
 	}
 
-	// Build response with nested replies
-	var commentResponses []*response.CommentResponse
-	for _, comment := range comments {
-		commentResp := response.NewCommentResponse(comment)
-
-		// Get all replies for this comment
-		replies, err := s.loadRepliesRecursively(ctx, comment.ID, userID)
-		if err != nil {
-			logger.ErrorfWithCtx(ctx, "[Err] Error loading replies in CommentService.GetCommentsByPostID: %v", err)
-			// Continue without replies rather than failing entirely
-			replies = []*response.CommentResponse{}
-		}
-		commentResp.Replies = replies
-
-		commentResponses = append(commentResponses, commentResp)
+	// Replies of the whole page are loaded at once
+	rootIDs := make([]uint64, len(comments))
+	for i, comment := range comments {
+		rootIDs[i] = comment.ID
+	}
+	replies, err := s.commentRepo.GetCommentSubtrees(postID, rootIDs, 0, depth, replyLimit, userID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error loading replies in CommentService.GetCommentsByPostID: %v", err)
+		// Continue without replies rather than failing entirely, they can still be expanded
+		replies = nil
 	}
 
+	commentResponses := buildCommentTrees(comments, replies)
+
 	pagination := &response.Pagination{
 		Total: total,
 		Page:  page,
@@ -284,33 +337,171 @@ func (s *CommentService) GetCommentsByPostID(ctx context.Context, postID uint64,
 	return commentResponses, pagination, nil
 }
 
-func (s *CommentService) loadRepliesRecursively(ctx context.Context, parentID uint64, userID *uint64) ([]*response.CommentResponse, error) {
-	replies, err := s.commentRepo.GetRepliesByParentID(parentID, userID)
+// GetCommentReplies expands a truncated comment: its replies starting at the continuation
+// token (or the first one without a token), each with its own reply tree
+func (s *CommentService) GetCommentReplies(ctx context.Context, commentID uint64, continuation string, depth, replyLimit int, userID *uint64) (*response.CommentRepliesResponse, error) {
+	depth, replyLimit = normalizeCommentTreeLimits(depth, replyLimit)
+
+	offset := 0
+	if continuation != "" {
+		parentID, continuationOffset, err := util.DecodeReplyContinuation(continuation)
+		if err != nil || parentID != commentID {
+			logger.ErrorfWithCtx(ctx, "[Err] Invalid continuation token in CommentService.GetCommentReplies: commentID=%d", commentID)
+			return nil, fmt.Errorf("invalid continuation token")
+		}
+		offset = continuationOffset
+	}
+
+	comment, err := s.commentRepo.GetCommentByID(commentID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Comment not found in CommentService.GetCommentReplies: %v", err)
+		return nil, fmt.Errorf("comment not found")
+	}
+
+	// Same visibility as the post the comment belongs to
+	post, err := s.postRepo.GetPostDetailByID(comment.PostID, userID)
+	if err != nil || isUnpublishedPost(post) {
+		logger.ErrorfWithCtx(ctx, "[Err] Post is not visible in CommentService.GetCommentReplies: commentID=%d", commentID)
+		return nil, fmt.Errorf("comment not found")
+	}
+
+	// The depth is counted from the expanded comment, so its replies are the first level
+	subtrees, err := s.commentRepo.GetCommentSubtrees(comment.PostID, []uint64{commentID}, offset, depth, replyLimit, userID)
 	if err != nil {
-		return nil, err
+		logger.ErrorfWithCtx(ctx, "[Err] Error loading replies in CommentService.GetCommentReplies: %v", err)
+		return nil, fmt.Errorf("failed to get replies")
 	}
 
-	var replyResponses []*response.CommentResponse
+	total, err := s.commentRepo.CountReplies(commentID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error counting replies in CommentService.GetCommentReplies: %v", err)
+		return nil, fmt.Errorf("failed to get replies")
+	}
+
+	var directReplies, nestedReplies []*model.Comment
+	for _, reply := range subtrees {
+		if reply.ParentCommentID != nil && *reply.ParentCommentID == commentID {
+			directReplies = append(directReplies, reply)
+		} else {
+			nestedReplies = append(nestedReplies, reply)
+		}
+	}
+
+	result := &response.CommentRepliesResponse{
+		CommentID: commentID,
+		Replies:   buildCommentTrees(directReplies, nestedReplies),
+	}
+	loaded := offset + len(directReplies)
+	if more := total - int64(loaded); more > 0 {
+		result.MoreRepliesCount = more
+		result.ContinuationToken = util.EncodeReplyContinuation(commentID, loaded)
+	}
+
+	return result, nil
+}
+
+// GetCommentPermalink opens the thread at a single comment, with up to contextDepth
+// ancestors above it and its direct replies below it
+func (s *CommentService) GetCommentPermalink(ctx context.Context, commentID uint64, contextDepth, replyLimit int, userID *uint64) (*response.CommentPermalinkResponse, error) {
+	if contextDepth < 0 {
+		contextDepth = constant.COMMENT_PERMALINK_DEFAULT_CONTEXT
+	}
+	if contextDepth > constant.COMMENT_PERMALINK_MAX_CONTEXT {
+		contextDepth = constant.COMMENT_PERMALINK_MAX_CONTEXT
+	}
+	_, replyLimit = normalizeCommentTreeLimits(1, replyLimit)
+
+	path, err := s.commentRepo.GetCommentPath(commentID, contextDepth, userID)
+	if err != nil || len(path) == 0 {
+		logger.ErrorfWithCtx(ctx, "[Err] Comment not found in CommentService.GetCommentPermalink: commentID=%d, err=%v", commentID, err)
+		return nil, fmt.Errorf("comment not found")
+	}
+	comment := path[len(path)-1]
+
+	// Same visibility as the post the comment belongs to
+	post, err := s.postRepo.GetPostDetailByID(comment.PostID, userID)
+	if err != nil || isUnpublishedPost(post) {
+		logger.ErrorfWithCtx(ctx, "[Err] Post is not visible in CommentService.GetCommentPermalink: commentID=%d", commentID)
+		return nil, fmt.Errorf("comment not found")
+	}
+
+	replies, err := s.commentRepo.GetCommentSubtrees(comment.PostID, []uint64{comment.ID}, 0, 1, replyLimit, userID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error loading replies in CommentService.GetCommentPermalink: %v", err)
+		// The comment is still shown, its replies can be expanded
+		replies = nil
+	}
+
+	ancestors := make([]*response.CommentResponse, 0, len(path)-1)
+	for _, ancestor := range path[:len(path)-1] {
+		ancestors = append(ancestors, response.NewCommentResponse(ancestor))
+	}
+
+	return &response.CommentPermalinkResponse{
+		Post:             response.NewPostSummaryInfo(post),
+		Ancestors:        ancestors,
+		HasMoreAncestors: path[0].ParentCommentID != nil,
+		Comment:          buildCommentTrees([]*model.Comment{comment}, replies)[0],
+	}, nil
+}
+
+// buildCommentTrees nests the replies under the given top-level comments. A comment whose
+// replies were not all loaded gets the number of missing replies and a continuation token.
+func buildCommentTrees(roots, replies []*model.Comment) []*response.CommentResponse {
+	nodes := make(map[uint64]*response.CommentResponse, len(roots)+len(replies))
+	replyCounts := make(map[uint64]int64, len(roots)+len(replies))
+
+	rootResponses := make([]*response.CommentResponse, 0, len(roots))
+	for _, root := range roots {
+		rootResp := response.NewCommentResponse(root)
+		nodes[root.ID] = rootResp
+		replyCounts[root.ID] = root.ReplyCount
+		rootResponses = append(rootResponses, rootResp)
+	}
 	for _, reply := range replies {
-		replyResp := response.NewCommentResponse(reply)
+		nodes[reply.ID] = response.NewCommentResponse(reply)
+		replyCounts[reply.ID] = reply.ReplyCount
+	}
 
-		// Load child replies
-		nestedReplies, err := s.loadRepliesRecursively(ctx, reply.ID, userID)
-		if err != nil {
-			logger.ErrorfWithCtx(ctx, "[Err] Error loading nested replies: %v", err)
-			nestedReplies = []*response.CommentResponse{}
+	// Replies come ordered by creation time, so siblings keep their order
+	for _, reply := range replies {
+		if reply.ParentCommentID == nil {
+			continue
 		}
-		replyResp.Replies = nestedReplies
+		if parent, ok := nodes[*reply.ParentCommentID]; ok {
+			parent.Replies = append(parent.Replies, nodes[reply.ID])
+		}
+	}
 
-		replyResponses = append(replyResponses, replyResp)
+	for id, node := range nodes {
+		if more := replyCounts[id] - int64(len(node.Replies)); more > 0 {
+			node.MoreRepliesCount = more
+			node.ContinuationToken = util.EncodeReplyContinuation(id, len(node.Replies))
+		}
 	}
 
-	return replyResponses, nil
+	return rootResponses
+}
+
+func normalizeCommentTreeLimits(depth, replyLimit int) (int, int) {
+	if depth <= 0 {
+		depth = constant.COMMENT_TREE_DEFAULT_DEPTH
+	}
+	if depth > constant.COMMENT_TREE_MAX_DEPTH {
+		depth = constant.COMMENT_TREE_MAX_DEPTH
+	}
+	if replyLimit <= 0 {
+		replyLimit = constant.COMMENT_TREE_DEFAULT_REPLIES
+	}
+	if replyLimit > constant.COMMENT_TREE_MAX_REPLIES {
+		replyLimit = constant.COMMENT_TREE_MAX_REPLIES
+	}
+	return depth, replyLimit
 }
 
 func (s *CommentService) UpdateComment(ctx context.Context, userID, commentID uint64, req *request.UpdateCommentRequest) error {
 	comment, err := s.commentRepo.GetCommentByID(commentID)
-	if err != nil {
+	if err != nil || comment.DeletedAt != nil {
 		logger.ErrorfWithCtx(ctx, "[Err] Comment not found in CommentService.UpdateComment: %v", err)
 		return fmt.Errorf("comment not found")
 	}
@@ -321,17 +512,67 @@ func (s *CommentService) UpdateComment(ctx context.Context, userID, commentID ui
 		return fmt.Errorf("permission denied")
 	}
 
-	if err := s.commentRepo.UpdateComment(commentID, req.Content, req.MediaURL); err != nil {
+	content := s.contentSanitizer.SanitizeContent(req.Content, req.ContentFormat)
+	if content == "" {
+		logger.ErrorfWithCtx(ctx, "[Err] Content is empty after sanitization in CommentService.UpdateComment")
+		return fmt.Errorf("content is empty")
+	}
+
+	// Keep the previous version so moderators can see what was reported
+	revision := &model.CommentRevision{
+		CommentID: comment.ID,
+		EditorID:  userID,
+		Content:   comment.Content,
+		MediaURL:  comment.MediaURL,
+		CreatedAt: time.Now(),
+	}
+	if err := s.commentRepo.UpdateComment(commentID, content, req.MediaURL, revision); err != nil {
 		logger.ErrorfWithCtx(ctx, "[Err] Error updating comment in CommentService.UpdateComment: %v", err)
 		return fmt.Errorf("failed to update comment")
 	}
 
+	if s.mentionService != nil {
+		if post, err := s.postRepo.GetPostByID(comment.PostID); err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Post not found in CommentService.UpdateComment: %v", err)
+		} else {
+			comment.ContentText = util.HTMLToText(content)
+			s.mentionService.SyncCommentMentions(ctx, comment, post)
+		}
+	}
+
 	return nil
 }
 
-func (s *CommentService) DeleteComment(ctx context.Context, userID, commentID uint64) error {
+func (s *CommentService) GetCommentRevisions(ctx context.Context, commentID uint64, userID *uint64) ([]*response.CommentRevisionResponse, error) {
 	comment, err := s.commentRepo.GetCommentByID(commentID)
+	if err != nil || comment.DeletedAt != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Comment not found in CommentService.GetCommentRevisions: %v", err)
+		return nil, fmt.Errorf("comment not found")
+	}
+
+	// Same visibility as the post the comment belongs to
+	post, err := s.postRepo.GetPostDetailByID(comment.PostID, userID)
+	if err != nil || isUnpublishedPost(post) {
+		logger.ErrorfWithCtx(ctx, "[Err] Post is not visible in CommentService.GetCommentRevisions: commentID=%d", commentID)
+		return nil, fmt.Errorf("comment not found")
+	}
+	if !canViewHiddenRevisions(s.communityModeratorRepo, post, comment.AuthorID, userID) {
+		logger.ErrorfWithCtx(ctx, "[Err] Post is not approved in CommentService.GetCommentRevisions: commentID=%d, status=%s", commentID, post.Status)
+		return nil, fmt.Errorf("comment not found")
+	}
+
+	revisions, err := s.commentRevisionRepo.GetCommentRevisionsByCommentID(commentID)
 	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting comment revisions in CommentService.GetCommentRevisions: %v", err)
+		return nil, fmt.Errorf("failed to get revisions")
+	}
+
+	return response.NewCommentRevisionResponses(revisions, comment), nil
+}
+
+func (s *CommentService) DeleteComment(ctx context.Context, userID, commentID uint64) error {
+	comment, err := s.commentRepo.GetCommentByID(commentID)
+	if err != nil || comment.DeletedAt != nil {
 		logger.ErrorfWithCtx(ctx, "[Err] Comment not found in CommentService.DeleteComment: %v", err)
 		return fmt.Errorf("comment not found")
 	}
@@ -342,8 +583,8 @@ func (s *CommentService) DeleteComment(ctx context.Context, userID, commentID ui
 		return fmt.Errorf("permission denied")
 	}
 
-	// Delete comment with transaction (updates replies' parent_comment_id, then deletes the comment)
-	if err := s.commentRepo.DeleteComment(commentID, comment.ParentCommentID); err != nil {
+	// Comments with replies are left as a tombstone so the thread keeps its shape
+	if err := s.commentRepo.DeleteComment(commentID, constant.COMMENT_DELETED_BY_AUTHOR); err != nil {
 		logger.ErrorfWithCtx(ctx, "[Err] Error deleting comment in CommentService.DeleteComment: %v", err)
 		return fmt.Errorf("failed to delete comment")
 	}
@@ -354,11 +595,26 @@ func (s *CommentService) DeleteComment(ctx context.Context, userID, commentID ui
 func (s *CommentService) VoteComment(ctx context.Context, userID, commentID uint64, vote bool) error {
 	// Check if comment exists
 	comment, err := s.commentRepo.GetCommentByID(commentID)
-	if err != nil {
+	if err != nil || comment.DeletedAt != nil {
 		logger.ErrorfWithCtx(ctx, "[Err] Comment not found in CommentService.VoteComment: %v", err)
 		return fmt.Errorf("comment not found")
 	}
 
+	post, err := s.postRepo.GetPostByID(comment.PostID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Post not found in CommentService.VoteComment: %v", err)
+		return fmt.Errorf("post not found")
+	}
+
+	if post.LockedAt != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Post is locked in CommentService.VoteComment: postID=%d", post.ID)
+		return fmt.Errorf("post is locked")
+	}
+
+	if err := s.checkCommentThreadLock(ctx, commentID); err != nil {
+		return err
+	}
+
 	commentVote := &model.CommentVote{
 		UserID:    userID,
 		CommentID: commentID,
@@ -463,9 +719,13 @@ func (s *CommentService) GetCommentsByUserID(ctx context.Context, userID uint64,
 }
 
 func (s *CommentService) ReportComment(ctx context.Context, userID, commentID uint64, req *request.ReportCommentRequest) error {
+	if len(req.Reasons) == 0 && len(req.RuleIDs) == 0 {
+		return fmt.Errorf("report reason is required")
+	}
+
 	// Check if comment exists
-	_, err := s.commentRepo.GetCommentByID(commentID)
-	if err != nil {
+	comment, err := s.commentRepo.GetCommentByID(commentID)
+	if err != nil || comment.DeletedAt != nil {
 		logger.ErrorfWithCtx(ctx, "[Err] Comment not found in CommentService.ReportComment: %v", err)
 		return fmt.Errorf("comment not found")
 	}
@@ -480,13 +740,32 @@ func (s *CommentService) ReportComment(ctx context.Context, userID, commentID ui
 		return fmt.Errorf("you have already reported this comment")
 	}
 
+	if err := checkReporterStanding(ctx, s.reportRepo, userID); err != nil {
+		return err
+	}
+
+	// Rules are checked against the community of the comment's post
+	var post *model.Post
+	var ruleIDs pq.Int64Array
+	if len(req.RuleIDs) > 0 {
+		if post, err = s.postRepo.GetPostByID(comment.PostID); err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Post not found in CommentService.ReportComment: %v", err)
+			return fmt.Errorf("comment not found")
+		}
+		if ruleIDs, err = getReportRuleIDs(ctx, s.communityRuleRepo, post.CommunityID, constant.MODERATION_TARGET_COMMENT, req.RuleIDs); err != nil {
+			return err
+		}
+	}
+
 	// Create report
 	report := &model.CommentReport{
 		CommentID:  commentID,
 		ReporterID: userID,
 		Reasons:    req.Reasons,
+		RuleIDs:    ruleIDs,
 		Note:       req.Note,
 		CreatedAt:  time.Now(),
+		Status:     constant.REPORT_STATUS_OPEN,
 	}
 
 	if err := s.commentReportRepo.CreateCommentReport(report); err != nil {
@@ -494,5 +773,53 @@ func (s *CommentService) ReportComment(ctx context.Context, userID, commentID ui
 		return fmt.Errorf("failed to report comment")
 	}
 
+	s.runAutoMod(ctx, constant.AUTOMOD_TRIGGER_COMMENT_REPORTED, post, comment)
+
+	return nil
+}
+
+// runAutoMod applies the community's AutoModerator rules to the comment and reports
+// whether they removed it. The post is loaded when not given.
+func (s *CommentService) runAutoMod(ctx context.Context, trigger string, post *model.Post, comment *model.Comment) bool {
+	if s.autoModService == nil {
+		return false
+	}
+	if post == nil {
+		var err error
+		if post, err = s.postRepo.GetPostByID(comment.PostID); err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Post not found in CommentService.runAutoMod: %v", err)
+			return false
+		}
+	}
+	return s.autoModService.Evaluate(ctx, trigger, post, comment)
+}
+
+// PurgeCommentTombstones removes tombstones that have been without replies for the
+// retention window
+func (s *CommentService) PurgeCommentTombstones(ctx context.Context) error {
+	cutoff := time.Now().Add(-constant.COMMENT_TOMBSTONE_RETENTION_HOURS * time.Hour)
+	purged, err := s.commentRepo.PurgeCommentTombstones(cutoff)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error purging tombstones in CommentService.PurgeCommentTombstones: %v", err)
+		return fmt.Errorf("failed to purge comment tombstones")
+	}
+
+	if purged > 0 {
+		logger.InfofWithCtx(ctx, "[Info] Purged %d comment tombstones", purged)
+	}
+	return nil
+}
+
+// checkCommentThreadLock fails if the comment or any of its ancestors is locked
+func (s *CommentService) checkCommentThreadLock(ctx context.Context, commentID uint64) error {
+	lockedComment, err := s.commentRepo.GetLockedAncestor(commentID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error checking comment thread lock: %v", err)
+		return fmt.Errorf("failed to check comment thread")
+	}
+	if lockedComment != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Comment thread is locked: commentID=%d, lockedCommentID=%d", commentID, lockedComment.ID)
+		return fmt.Errorf("comment thread is locked")
+	}
 	return nil
 }
@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"social-platform-backend/internal/domain/model"
 	"social-platform-backend/internal/interface/dto/request"
+	"social-platform-backend/package/constant"
+	"social-platform-backend/package/util"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -19,7 +22,13 @@ func TestCommentService_CreateComment_Success(t *testing.T) {
 	commentService := NewCommentService(
 		mockCommentRepo,
 		mockPostRepo,
-		nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	userID := uint64(123)
@@ -49,7 +58,13 @@ func TestCommentService_CreateComment_PostNotFound(t *testing.T) {
 	commentService := NewCommentService(
 		nil,
 		mockPostRepo,
-		nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	req := &request.CreateCommentRequest{
@@ -73,7 +88,13 @@ func TestCommentService_CreateComment_WithParent(t *testing.T) {
 	commentService := NewCommentService(
 		mockCommentRepo,
 		mockPostRepo,
-		nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	userID := uint64(123)
@@ -97,6 +118,7 @@ func TestCommentService_CreateComment_WithParent(t *testing.T) {
 
 	mockPostRepo.On("GetPostByID", req.PostID).Return(post, nil)
 	mockCommentRepo.On("GetCommentByID", parentCommentID).Return(parentComment, nil)
+	mockCommentRepo.On("GetLockedAncestor", parentCommentID).Return(nil, nil)
 	mockCommentRepo.On("CreateComment", mock.AnythingOfType("*model.Comment")).Return(nil)
 
 	err := commentService.CreateComment(context.Background(), userID, req)
@@ -106,6 +128,82 @@ func TestCommentService_CreateComment_WithParent(t *testing.T) {
 	mockCommentRepo.AssertExpectations(t)
 }
 
+func TestCommentService_CreateComment_PostLocked(t *testing.T) {
+	mockCommentRepo := new(MockCommentRepository)
+	mockPostRepo := new(MockPostRepository)
+
+	commentService := NewCommentService(
+		mockCommentRepo,
+		mockPostRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	lockedAt := time.Now()
+	req := &request.CreateCommentRequest{
+		PostID:  456,
+		Content: "Test comment",
+	}
+
+	post := &model.Post{
+		ID:       456,
+		AuthorID: 789,
+		LockedAt: &lockedAt,
+	}
+
+	mockPostRepo.On("GetPostByID", req.PostID).Return(post, nil)
+
+	err := commentService.CreateComment(context.Background(), 123, req)
+
+	assert.Error(t, err)
+	assert.Equal(t, "post is locked", err.Error())
+	mockCommentRepo.AssertNotCalled(t, "CreateComment", mock.Anything)
+}
+
+func TestCommentService_CreateComment_ThreadLocked(t *testing.T) {
+	mockCommentRepo := new(MockCommentRepository)
+	mockPostRepo := new(MockPostRepository)
+
+	commentService := NewCommentService(
+		mockCommentRepo,
+		mockPostRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	lockedAt := time.Now()
+	parentCommentID := uint64(111)
+	req := &request.CreateCommentRequest{
+		PostID:          456,
+		ParentCommentID: &parentCommentID,
+		Content:         "Reply comment",
+	}
+
+	post := &model.Post{ID: 456, AuthorID: 789}
+	parentComment := &model.Comment{ID: parentCommentID, PostID: 456, AuthorID: 999}
+	lockedAncestor := &model.Comment{ID: 100, PostID: 456, LockedAt: &lockedAt}
+
+	mockPostRepo.On("GetPostByID", req.PostID).Return(post, nil)
+	mockCommentRepo.On("GetCommentByID", parentCommentID).Return(parentComment, nil)
+	mockCommentRepo.On("GetLockedAncestor", parentCommentID).Return(lockedAncestor, nil)
+
+	err := commentService.CreateComment(context.Background(), 123, req)
+
+	assert.Error(t, err)
+	assert.Equal(t, "comment thread is locked", err.Error())
+	mockCommentRepo.AssertNotCalled(t, "CreateComment", mock.Anything)
+}
+
 func TestCommentService_CreateComment_ParentNotFound(t *testing.T) {
 	mockCommentRepo := new(MockCommentRepository)
 	mockPostRepo := new(MockPostRepository)
@@ -113,7 +211,13 @@ func TestCommentService_CreateComment_ParentNotFound(t *testing.T) {
 	commentService := NewCommentService(
 		mockCommentRepo,
 		mockPostRepo,
-		nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	parentCommentID := uint64(999)
@@ -143,7 +247,13 @@ func TestCommentService_CreateComment_ParentDifferentPost(t *testing.T) {
 	commentService := NewCommentService(
 		mockCommentRepo,
 		mockPostRepo,
-		nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	parentCommentID := uint64(111)
@@ -172,10 +282,18 @@ func TestCommentService_CreateComment_ParentDifferentPost(t *testing.T) {
 
 func TestCommentService_UpdateComment_Success(t *testing.T) {
 	mockCommentRepo := new(MockCommentRepository)
+	mockRevisionRepo := new(MockCommentRevisionRepository)
 
 	commentService := NewCommentService(
 		mockCommentRepo,
 		nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		mockRevisionRepo,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	userID := uint64(123)
@@ -190,7 +308,9 @@ func TestCommentService_UpdateComment_Success(t *testing.T) {
 	}
 
 	mockCommentRepo.On("GetCommentByID", commentID).Return(comment, nil)
-	mockCommentRepo.On("UpdateComment", commentID, req.Content, req.MediaURL).Return(nil)
+	mockCommentRepo.On("UpdateComment", commentID, req.Content, req.MediaURL, mock.MatchedBy(func(revision *model.CommentRevision) bool {
+		return revision.CommentID == commentID && revision.EditorID == userID
+	})).Return(nil)
 
 	err := commentService.UpdateComment(context.Background(), userID, commentID, req)
 
@@ -203,7 +323,13 @@ func TestCommentService_UpdateComment_NotAuthor(t *testing.T) {
 
 	commentService := NewCommentService(
 		mockCommentRepo,
-		nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	userID := uint64(123)
@@ -231,7 +357,13 @@ func TestCommentService_DeleteComment_Success(t *testing.T) {
 
 	commentService := NewCommentService(
 		mockCommentRepo,
-		nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	userID := uint64(123)
@@ -244,7 +376,7 @@ func TestCommentService_DeleteComment_Success(t *testing.T) {
 	}
 
 	mockCommentRepo.On("GetCommentByID", commentID).Return(comment, nil)
-	mockCommentRepo.On("DeleteComment", commentID, comment.ParentCommentID).Return(nil)
+	mockCommentRepo.On("DeleteComment", commentID, constant.COMMENT_DELETED_BY_AUTHOR).Return(nil)
 
 	err := commentService.DeleteComment(context.Background(), userID, commentID)
 
@@ -257,7 +389,13 @@ func TestCommentService_DeleteComment_NotFound(t *testing.T) {
 
 	commentService := NewCommentService(
 		mockCommentRepo,
-		nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	commentID := uint64(999)
@@ -279,7 +417,13 @@ func TestCommentService_ReportComment_Success(t *testing.T) {
 		nil,
 		nil,
 		mockCommentReportRepo,
-		nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	userID := uint64(123)
@@ -323,7 +467,13 @@ func TestCommentService_ReportComment_CommentNotFound(t *testing.T) {
 		nil,
 		nil,
 		mockCommentReportRepo,
-		nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	userID := uint64(123)
@@ -350,7 +500,13 @@ func TestCommentService_ReportComment_AlreadyReported(t *testing.T) {
 		nil,
 		nil,
 		mockCommentReportRepo,
-		nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	userID := uint64(123)
@@ -374,3 +530,374 @@ func TestCommentService_ReportComment_AlreadyReported(t *testing.T) {
 	mockCommentRepo.AssertExpectations(t)
 	mockCommentReportRepo.AssertExpectations(t)
 }
+
+func TestCommentService_ReportComment_ReporterRestricted(t *testing.T) {
+	mockCommentRepo := new(MockCommentRepository)
+	mockCommentReportRepo := new(MockCommentReportRepository)
+	mockReportRepo := new(MockReportRepository)
+
+	commentService := NewCommentService(
+		mockCommentRepo,
+		nil,
+		nil,
+		mockCommentReportRepo,
+		nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		mockReportRepo,
+		nil,
+		nil,
+		nil,
+	)
+
+	userID := uint64(123)
+	commentID := uint64(456)
+	req := &request.ReportCommentRequest{
+		Reasons: []string{"spam"},
+	}
+
+	mockCommentRepo.On("GetCommentByID", commentID).Return(&model.Comment{ID: commentID, AuthorID: 999}, nil)
+	mockCommentReportRepo.On("IsUserReportedComment", userID, commentID).Return(false, nil)
+	mockReportRepo.On("GetReporterStats", userID, mock.Anything).Return(int64(12), int64(11), nil)
+
+	err := commentService.ReportComment(context.Background(), userID, commentID, req)
+
+	assert.EqualError(t, err, "reporting temporarily restricted")
+	mockCommentReportRepo.AssertNotCalled(t, "CreateCommentReport", mock.Anything)
+}
+
+func TestCommentService_CreateComment_SanitizesContent(t *testing.T) {
+	mockCommentRepo := new(MockCommentRepository)
+	mockPostRepo := new(MockPostRepository)
+
+	commentService := NewCommentService(
+		mockCommentRepo,
+		mockPostRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	req := &request.CreateCommentRequest{
+		PostID:  456,
+		Content: `<p onclick="x()">Nice <a href="https://example.com">post</a><script>alert(1)</script></p>`,
+	}
+
+	mockPostRepo.On("GetPostByID", req.PostID).Return(&model.Post{ID: 456, AuthorID: 789}, nil)
+	mockCommentRepo.On("CreateComment", mock.MatchedBy(func(comment *model.Comment) bool {
+		return comment.Content == `<p>Nice <a href="https://example.com" rel="nofollow ugc noopener">post</a></p>` &&
+			comment.ContentText == "Nice post"
+	})).Return(nil)
+
+	err := commentService.CreateComment(context.Background(), 123, req)
+
+	assert.NoError(t, err)
+	mockCommentRepo.AssertExpectations(t)
+}
+
+func TestCommentService_UpdateComment_EmptyAfterSanitization(t *testing.T) {
+	mockCommentRepo := new(MockCommentRepository)
+
+	commentService := NewCommentService(
+		mockCommentRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	mockCommentRepo.On("GetCommentByID", uint64(10)).Return(&model.Comment{ID: 10, AuthorID: 123}, nil)
+
+	err := commentService.UpdateComment(context.Background(), 123, 10, &request.UpdateCommentRequest{
+		Content: "<script>alert(1)</script>",
+	})
+
+	assert.EqualError(t, err, "content is empty")
+	mockCommentRepo.AssertNotCalled(t, "UpdateComment", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCommentService_GetCommentsByPostID_BuildsTruncatedTree(t *testing.T) {
+	mockCommentRepo := new(MockCommentRepository)
+
+	commentService := NewCommentService(
+		mockCommentRepo,
+		nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	parent := func(id uint64) *uint64 { return &id }
+	roots := []*model.Comment{{ID: 1, PostID: 9, ReplyCount: 3}, {ID: 2, PostID: 9}}
+	// depth 2 and 2 replies per comment: comment 1 misses one reply, comment 11 is cut at the depth limit
+	replies := []*model.Comment{
+		{ID: 10, PostID: 9, ParentCommentID: parent(1), ReplyCount: 1},
+		{ID: 11, PostID: 9, ParentCommentID: parent(1)},
+		{ID: 20, PostID: 9, ParentCommentID: parent(10), ReplyCount: 4},
+	}
+
+	mockCommentRepo.On("GetCommentsByPostID", uint64(9), constant.COMMENT_SORT_NEWEST, 12, 0, (*uint64)(nil)).Return(roots, int64(2), nil)
+	mockCommentRepo.On("GetCommentSubtrees", uint64(9), []uint64{1, 2}, 0, 2, 2, (*uint64)(nil)).Return(replies, nil)
+
+	result, _, err := commentService.GetCommentsByPostID(context.Background(), 9, constant.COMMENT_SORT_NEWEST, 1, 12, 2, 2, nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Len(t, result[0].Replies, 2)
+	assert.Equal(t, int64(1), result[0].MoreRepliesCount)
+	assert.Equal(t, util.EncodeReplyContinuation(1, 2), result[0].ContinuationToken)
+	assert.Equal(t, uint64(20), result[0].Replies[0].Replies[0].ID)
+	assert.Equal(t, int64(4), result[0].Replies[0].Replies[0].MoreRepliesCount)
+	assert.Zero(t, result[0].Replies[1].MoreRepliesCount)
+	assert.Empty(t, result[1].Replies)
+	assert.Empty(t, result[1].ContinuationToken)
+}
+
+func TestCommentService_GetCommentReplies_Continuation(t *testing.T) {
+	mockCommentRepo := new(MockCommentRepository)
+	mockPostRepo := new(MockPostRepository)
+
+	commentService := NewCommentService(
+		mockCommentRepo,
+		mockPostRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	parent := func(id uint64) *uint64 { return &id }
+	mockCommentRepo.On("GetCommentByID", uint64(1)).Return(&model.Comment{ID: 1, PostID: 9}, nil)
+	mockPostRepo.On("GetPostDetailByID", uint64(9), (*uint64)(nil)).Return(&model.Post{ID: 9, Status: constant.POST_STATUS_APPROVED}, nil)
+	mockCommentRepo.On("GetCommentSubtrees", uint64(9), []uint64{1}, 2, constant.COMMENT_TREE_DEFAULT_DEPTH, 2, (*uint64)(nil)).Return([]*model.Comment{
+		{ID: 12, PostID: 9, ParentCommentID: parent(1), ReplyCount: 1},
+		{ID: 13, PostID: 9, ParentCommentID: parent(1)},
+		{ID: 30, PostID: 9, ParentCommentID: parent(12)},
+	}, nil)
+	mockCommentRepo.On("CountReplies", uint64(1)).Return(int64(5), nil)
+
+	result, err := commentService.GetCommentReplies(context.Background(), 1, util.EncodeReplyContinuation(1, 2), 0, 2, nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Replies, 2)
+	assert.Len(t, result.Replies[0].Replies, 1)
+	assert.Equal(t, int64(1), result.MoreRepliesCount)
+	assert.Equal(t, util.EncodeReplyContinuation(1, 4), result.ContinuationToken)
+}
+
+func TestCommentService_GetCommentReplies_TokenForOtherComment(t *testing.T) {
+	mockCommentRepo := new(MockCommentRepository)
+
+	commentService := NewCommentService(
+		mockCommentRepo,
+		nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	result, err := commentService.GetCommentReplies(context.Background(), 1, util.EncodeReplyContinuation(2, 2), 0, 0, nil)
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "invalid continuation token")
+	mockCommentRepo.AssertNotCalled(t, "GetCommentByID", mock.Anything)
+}
+
+func TestCommentService_GetCommentPermalink_Success(t *testing.T) {
+	mockCommentRepo := new(MockCommentRepository)
+	mockPostRepo := new(MockPostRepository)
+
+	commentService := NewCommentService(
+		mockCommentRepo,
+		mockPostRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	parent := func(id uint64) *uint64 { return &id }
+	// the context stops at comment 2, which still has a parent
+	mockCommentRepo.On("GetCommentPath", uint64(4), 2, (*uint64)(nil)).Return([]*model.Comment{
+		{ID: 2, PostID: 9, ParentCommentID: parent(1)},
+		{ID: 3, PostID: 9, ParentCommentID: parent(2)},
+		{ID: 4, PostID: 9, ParentCommentID: parent(3), ReplyCount: 3},
+	}, nil)
+	mockPostRepo.On("GetPostDetailByID", uint64(9), (*uint64)(nil)).Return(&model.Post{ID: 9, Title: "Thread", Status: constant.POST_STATUS_APPROVED, CommentCount: 40}, nil)
+	mockCommentRepo.On("GetCommentSubtrees", uint64(9), []uint64{4}, 0, 1, 2, (*uint64)(nil)).Return([]*model.Comment{
+		{ID: 5, PostID: 9, ParentCommentID: parent(4), ReplyCount: 1},
+		{ID: 6, PostID: 9, ParentCommentID: parent(4)},
+	}, nil)
+
+	result, err := commentService.GetCommentPermalink(context.Background(), 4, 2, 2, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Thread", result.Post.Title)
+	assert.Equal(t, int64(40), result.Post.CommentCount)
+	assert.Len(t, result.Ancestors, 2)
+	assert.Equal(t, uint64(2), result.Ancestors[0].ID)
+	assert.True(t, result.HasMoreAncestors)
+	assert.Equal(t, uint64(4), result.Comment.ID)
+	assert.Len(t, result.Comment.Replies, 2)
+	assert.Equal(t, int64(1), result.Comment.MoreRepliesCount)
+	assert.Equal(t, int64(1), result.Comment.Replies[0].MoreRepliesCount)
+}
+
+func TestCommentService_GetCommentPermalink_PostNotVisible(t *testing.T) {
+	mockCommentRepo := new(MockCommentRepository)
+	mockPostRepo := new(MockPostRepository)
+
+	commentService := NewCommentService(
+		mockCommentRepo,
+		mockPostRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	userID := uint64(7)
+	mockCommentRepo.On("GetCommentPath", uint64(4), constant.COMMENT_PERMALINK_DEFAULT_CONTEXT, &userID).Return([]*model.Comment{{ID: 4, PostID: 9}}, nil)
+	mockPostRepo.On("GetPostDetailByID", uint64(9), &userID).Return(nil, errors.New("record not found"))
+
+	result, err := commentService.GetCommentPermalink(context.Background(), 4, -1, 0, &userID)
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "comment not found")
+	mockCommentRepo.AssertNotCalled(t, "GetCommentSubtrees", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCommentService_DeleteComment_AlreadyDeleted(t *testing.T) {
+	mockCommentRepo := new(MockCommentRepository)
+
+	commentService := NewCommentService(
+		mockCommentRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	deletedAt := time.Now()
+	mockCommentRepo.On("GetCommentByID", uint64(456)).Return(&model.Comment{ID: 456, AuthorID: 123, DeletedAt: &deletedAt}, nil)
+
+	err := commentService.DeleteComment(context.Background(), 123, 456)
+
+	assert.EqualError(t, err, "comment not found")
+	mockCommentRepo.AssertNotCalled(t, "DeleteComment", mock.Anything, mock.Anything)
+}
+
+func TestCommentService_CreateComment_ReplyToTombstone(t *testing.T) {
+	mockCommentRepo := new(MockCommentRepository)
+	mockPostRepo := new(MockPostRepository)
+
+	commentService := NewCommentService(
+		mockCommentRepo,
+		mockPostRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	parentCommentID := uint64(10)
+	deletedAt := time.Now()
+	mockPostRepo.On("GetPostByID", uint64(456)).Return(&model.Post{ID: 456}, nil)
+	mockCommentRepo.On("GetCommentByID", parentCommentID).Return(&model.Comment{ID: parentCommentID, PostID: 456, DeletedAt: &deletedAt}, nil)
+
+	err := commentService.CreateComment(context.Background(), 123, &request.CreateCommentRequest{PostID: 456, ParentCommentID: &parentCommentID, Content: "Reply"})
+
+	assert.EqualError(t, err, "parent comment not found")
+	mockCommentRepo.AssertNotCalled(t, "CreateComment", mock.Anything)
+}
+
+func TestCommentService_GetCommentsByPostID_HidesTombstones(t *testing.T) {
+	mockCommentRepo := new(MockCommentRepository)
+
+	commentService := NewCommentService(
+		mockCommentRepo,
+		nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	parent := func(id uint64) *uint64 { return &id }
+	deletedAt := time.Now()
+	deletedByAuthor := constant.COMMENT_DELETED_BY_AUTHOR
+	removedByModerator := constant.COMMENT_DELETED_BY_MODERATOR
+	mockCommentRepo.On("GetCommentsByPostID", uint64(9), constant.COMMENT_SORT_NEWEST, 12, 0, (*uint64)(nil)).Return([]*model.Comment{
+		{ID: 1, PostID: 9, Author: &model.User{ID: 5, Username: "gone"}, DeletedAt: &deletedAt, DeletedBy: &deletedByAuthor, ReplyCount: 1},
+	}, int64(1), nil)
+	mockCommentRepo.On("GetCommentSubtrees", uint64(9), []uint64{1}, 0, constant.COMMENT_TREE_DEFAULT_DEPTH, constant.COMMENT_TREE_DEFAULT_REPLIES, (*uint64)(nil)).Return([]*model.Comment{
+		{ID: 2, PostID: 9, ParentCommentID: parent(1), DeletedAt: &deletedAt, DeletedBy: &removedByModerator, ReplyCount: 1},
+		{ID: 3, PostID: 9, ParentCommentID: parent(2), Content: "still here"},
+	}, nil)
+
+	result, _, err := commentService.GetCommentsByPostID(context.Background(), 9, constant.COMMENT_SORT_NEWEST, 1, 12, 0, 0, nil)
+
+	assert.NoError(t, err)
+	assert.True(t, result[0].IsDeleted)
+	assert.Equal(t, constant.COMMENT_DELETED_PLACEHOLDER, result[0].Content)
+	assert.Nil(t, result[0].Author)
+	assert.Equal(t, constant.COMMENT_REMOVED_PLACEHOLDER, result[0].Replies[0].Content)
+	assert.Equal(t, "still here", result[0].Replies[0].Replies[0].Content)
+	assert.False(t, result[0].Replies[0].Replies[0].IsDeleted)
+}
+
+func TestCommentService_PurgeCommentTombstones_UsesRetentionWindow(t *testing.T) {
+	mockCommentRepo := new(MockCommentRepository)
+
+	commentService := NewCommentService(
+		mockCommentRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	mockCommentRepo.On("PurgeCommentTombstones", mock.MatchedBy(func(before time.Time) bool {
+		age := time.Since(before)
+		return age >= constant.COMMENT_TOMBSTONE_RETENTION_HOURS*time.Hour && age < constant.COMMENT_TOMBSTONE_RETENTION_HOURS*time.Hour+time.Minute
+	})).Return(int64(2), nil)
+
+	assert.NoError(t, commentService.PurgeCommentTombstones(context.Background()))
+	mockCommentRepo.AssertExpectations(t)
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/domain/repository"
+	"social-platform-backend/package/constant"
+	"social-platform-backend/package/logger"
+)
+
+// communityAccess is what a user may do in a community. Role is ROLE_USER for members
+// who do not moderate it, CustomRole is set for moderators with ROLE_MODERATOR.
+type communityAccess struct {
+	Role        string
+	CustomRole  *model.CommunityRole
+	Permissions []string
+}
+
+func (a *communityAccess) isModerator() bool {
+	return isModeratorRole(a.Role)
+}
+
+func (a *communityAccess) can(permission string) bool {
+	return slices.Contains(a.Permissions, permission)
+}
+
+// isModeratorRole tells whether a community_moderators role moderates the community
+func isModeratorRole(role string) bool {
+	return role == constant.ROLE_SUPER_ADMIN || role == constant.ROLE_ADMIN || role == constant.ROLE_MODERATOR
+}
+
+// getCommunityAccess resolves the role and permissions of a user in a community. Built-in
+// roles need no more than the role lookup, custom roles are loaded with their permissions.
+func getCommunityAccess(communityModeratorRepo repository.CommunityModeratorRepository, communityID, userID uint64) (*communityAccess, error) {
+	role, err := communityModeratorRepo.GetModeratorRole(communityID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch role {
+	case constant.ROLE_SUPER_ADMIN, constant.ROLE_ADMIN:
+		return &communityAccess{Role: role, Permissions: constant.COMMUNITY_ROLE_PERMISSIONS[role]}, nil
+	case constant.ROLE_MODERATOR:
+		customRole, err := communityModeratorRepo.GetModeratorCustomRole(communityID, userID)
+		if err != nil {
+			return nil, err
+		}
+		return &communityAccess{Role: role, CustomRole: customRole, Permissions: customRole.Permissions}, nil
+	}
+	return &communityAccess{Role: constant.ROLE_USER}, nil
+}
+
+// checkCommunityPermission is the authorization check of the community operations.
+// caller names the service method for the log.
+func checkCommunityPermission(ctx context.Context, communityModeratorRepo repository.CommunityModeratorRepository, communityID, userID uint64, permission, caller string) error {
+	access, err := getCommunityAccess(communityModeratorRepo, communityID, userID)
+	if err != nil || !access.can(permission) {
+		logger.ErrorfWithCtx(ctx, "[Err] User does not have permission in %s: userID=%d, communityID=%d, permission=%s, err=%v", caller, userID, communityID, permission, err)
+		return fmt.Errorf("permission denied")
+	}
+	return nil
+}
+
+// checkCommunityOwner lets only the super admin of the community through
+func checkCommunityOwner(ctx context.Context, communityModeratorRepo repository.CommunityModeratorRepository, communityID, userID uint64, caller string) error {
+	role, err := communityModeratorRepo.GetModeratorRole(communityID, userID)
+	if err != nil || role != constant.ROLE_SUPER_ADMIN {
+		logger.ErrorfWithCtx(ctx, "[Err] User does not have permission in %s: userID=%d, communityID=%d", caller, userID, communityID)
+		return fmt.Errorf("permission denied")
+	}
+	return nil
+}
+
+func isCommunityModerator(communityModeratorRepo repository.CommunityModeratorRepository, communityID, userID uint64) bool {
+	role, err := communityModeratorRepo.GetModeratorRole(communityID, userID)
+	return err == nil && isModeratorRole(role)
+}
+
+// hasAllPermissions tells whether access covers every one of permissions, so that
+// nobody hands out or takes away more than they can do themselves
+func hasAllPermissions(access *communityAccess, permissions []string) bool {
+	for _, permission := range permissions {
+		if !access.can(permission) {
+			return false
+		}
+	}
+	return true
+}
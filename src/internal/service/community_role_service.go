@@ -0,0 +1,265 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/domain/repository"
+	"social-platform-backend/internal/interface/dto/request"
+	"social-platform-backend/internal/interface/dto/response"
+	"social-platform-backend/package/constant"
+	"social-platform-backend/package/logger"
+	"strings"
+	"time"
+)
+
+// CommunityRoleService manages the custom moderator roles of a community and tells
+// users what they may do in it
+type CommunityRoleService struct {
+	communityRoleRepo      repository.CommunityRoleRepository
+	communityRepo          repository.CommunityRepository
+	communityModeratorRepo repository.CommunityModeratorRepository
+	moderationLogRepo      repository.ModerationLogRepository
+}
+
+func NewCommunityRoleService(
+	communityRoleRepo repository.CommunityRoleRepository,
+	communityRepo repository.CommunityRepository,
+	communityModeratorRepo repository.CommunityModeratorRepository,
+	moderationLogRepo repository.ModerationLogRepository,
+) *CommunityRoleService {
+	return &CommunityRoleService{
+		communityRoleRepo:      communityRoleRepo,
+		communityRepo:          communityRepo,
+		communityModeratorRepo: communityModeratorRepo,
+		moderationLogRepo:      moderationLogRepo,
+	}
+}
+
+// GetPermissions returns the caller's role and effective permissions in a community.
+// Users who do not moderate it get ROLE_USER and no permissions.
+func (s *CommunityRoleService) GetPermissions(ctx context.Context, userID, communityID uint64) (*response.CommunityPermissionsResponse, error) {
+	if _, err := s.communityRepo.GetCommunityByID(communityID); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Community not found in CommunityRoleService.GetPermissions: %v", err)
+		return nil, fmt.Errorf("community not found")
+	}
+
+	access, err := getCommunityAccess(s.communityModeratorRepo, communityID, userID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting community access in CommunityRoleService.GetPermissions: %v", err)
+		return nil, fmt.Errorf("failed to get permissions")
+	}
+
+	resp := &response.CommunityPermissionsResponse{
+		CommunityID: communityID,
+		Role:        access.Role,
+		Permissions: append([]string{}, access.Permissions...),
+	}
+	if access.CustomRole != nil {
+		resp.RoleID = &access.CustomRole.ID
+		resp.RoleName = &access.CustomRole.Name
+	}
+	return resp, nil
+}
+
+// GetRoles lists the custom roles of a community to the moderators who can assign them
+func (s *CommunityRoleService) GetRoles(ctx context.Context, userID, communityID uint64) ([]*response.CommunityRoleResponse, error) {
+	if _, err := s.communityRepo.GetCommunityByID(communityID); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Community not found in CommunityRoleService.GetRoles: %v", err)
+		return nil, fmt.Errorf("community not found")
+	}
+	if err := checkCommunityPermission(ctx, s.communityModeratorRepo, communityID, userID, constant.COMMUNITY_PERMISSION_MANAGE_ROLES, "CommunityRoleService.GetRoles"); err != nil {
+		return nil, err
+	}
+
+	roles, err := s.communityRoleRepo.GetRolesByCommunityID(communityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting community roles in CommunityRoleService.GetRoles: %v", err)
+		return nil, fmt.Errorf("failed to get community roles")
+	}
+	return response.NewCommunityRoleResponses(roles), nil
+}
+
+func (s *CommunityRoleService) CreateRole(ctx context.Context, userID, communityID uint64, req *request.CommunityRoleRequest) (*response.CommunityRoleResponse, error) {
+	access, err := s.checkRoleManager(ctx, userID, communityID, "CreateRole")
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.communityRoleRepo.CountRoles(communityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error counting community roles in CommunityRoleService.CreateRole: %v", err)
+		return nil, fmt.Errorf("failed to create community role")
+	}
+	if count >= constant.COMMUNITY_ROLE_MAX_PER_COMMUNITY {
+		return nil, fmt.Errorf("community role limit reached")
+	}
+
+	role := &model.CommunityRole{
+		CommunityID: communityID,
+		CreatedBy:   userID,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.setRoleDefinition(ctx, role, access, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.communityRoleRepo.CreateRole(role); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error creating community role in CommunityRoleService.CreateRole: %v", err)
+		return nil, fmt.Errorf("failed to create community role")
+	}
+
+	s.recordRoleChange(ctx, communityID, userID, constant.MODERATION_ACTION_CREATE_COMMUNITY_ROLE, role)
+	return response.NewCommunityRoleResponse(role), nil
+}
+
+// UpdateRole renames a role or changes its permissions, which applies at once to the
+// moderators holding it
+func (s *CommunityRoleService) UpdateRole(ctx context.Context, userID, communityID, roleID uint64, req *request.CommunityRoleRequest) (*response.CommunityRoleResponse, error) {
+	access, err := s.checkRoleManager(ctx, userID, communityID, "UpdateRole")
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := s.getCommunityRole(ctx, communityID, roleID, "UpdateRole")
+	if err != nil {
+		return nil, err
+	}
+	if !hasAllPermissions(access, role.Permissions) {
+		return nil, fmt.Errorf("role exceeds your permissions")
+	}
+
+	if err := s.setRoleDefinition(ctx, role, access, req); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	role.UpdatedAt = &now
+
+	if err := s.communityRoleRepo.UpdateRole(role); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error updating community role in CommunityRoleService.UpdateRole: %v", err)
+		return nil, fmt.Errorf("failed to update community role")
+	}
+
+	s.recordRoleChange(ctx, communityID, userID, constant.MODERATION_ACTION_UPDATE_COMMUNITY_ROLE, role)
+	return response.NewCommunityRoleResponse(role), nil
+}
+
+// DeleteRole refuses to delete a role moderators still hold, they have to be given
+// another role first
+func (s *CommunityRoleService) DeleteRole(ctx context.Context, userID, communityID, roleID uint64) error {
+	access, err := s.checkRoleManager(ctx, userID, communityID, "DeleteRole")
+	if err != nil {
+		return err
+	}
+
+	role, err := s.getCommunityRole(ctx, communityID, roleID, "DeleteRole")
+	if err != nil {
+		return err
+	}
+	if !hasAllPermissions(access, role.Permissions) {
+		return fmt.Errorf("role exceeds your permissions")
+	}
+
+	members, err := s.communityRoleRepo.CountRoleMembers(role.ID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error counting role members in CommunityRoleService.DeleteRole: %v", err)
+		return fmt.Errorf("failed to delete community role")
+	}
+	if members > 0 {
+		return fmt.Errorf("role is assigned to moderators")
+	}
+
+	if err := s.communityRoleRepo.DeleteRole(role.ID); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error deleting community role in CommunityRoleService.DeleteRole: %v", err)
+		return fmt.Errorf("failed to delete community role")
+	}
+
+	s.recordRoleChange(ctx, communityID, userID, constant.MODERATION_ACTION_DELETE_COMMUNITY_ROLE, role)
+	return nil
+}
+
+// setRoleDefinition validates the request and stores it on the role. A role can only
+// grant permissions its author holds.
+func (s *CommunityRoleService) setRoleDefinition(ctx context.Context, role *model.CommunityRole, access *communityAccess, req *request.CommunityRoleRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return fmt.Errorf("role name is required")
+	}
+
+	permissions := make([]string, 0, len(req.Permissions))
+	for _, permission := range req.Permissions {
+		if !slices.Contains(constant.COMMUNITY_PERMISSIONS, permission) {
+			return fmt.Errorf("invalid permission")
+		}
+		if !slices.Contains(permissions, permission) {
+			permissions = append(permissions, permission)
+		}
+	}
+	if len(permissions) == 0 {
+		return fmt.Errorf("role permissions are required")
+	}
+	if !hasAllPermissions(access, permissions) {
+		return fmt.Errorf("role exceeds your permissions")
+	}
+
+	roles, err := s.communityRoleRepo.GetRolesByCommunityID(role.CommunityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting community roles in CommunityRoleService.setRoleDefinition: %v", err)
+		return fmt.Errorf("failed to check role name")
+	}
+	for _, other := range roles {
+		if other.ID != role.ID && strings.EqualFold(other.Name, name) {
+			return fmt.Errorf("role name already exists")
+		}
+	}
+
+	role.Name = name
+	role.Permissions = permissions
+	return nil
+}
+
+func (s *CommunityRoleService) recordRoleChange(ctx context.Context, communityID, actorID uint64, action string, role *model.CommunityRole) {
+	if s.moderationLogRepo == nil {
+		return
+	}
+
+	details := role.Name + ": " + strings.Join(role.Permissions, ", ")
+	moderationLog := &model.ModerationLog{
+		CommunityID: communityID,
+		ActorID:     actorID,
+		Action:      action,
+		TargetType:  constant.MODERATION_TARGET_COMMUNITY_ROLE,
+		TargetID:    role.ID,
+		Details:     &details,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.moderationLogRepo.CreateModerationLog(moderationLog); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error recording moderation log in CommunityRoleService.recordRoleChange: %v", err)
+	}
+}
+
+func (s *CommunityRoleService) getCommunityRole(ctx context.Context, communityID, roleID uint64, method string) (*model.CommunityRole, error) {
+	role, err := s.communityRoleRepo.GetRoleByID(roleID)
+	if err != nil || role.CommunityID != communityID {
+		logger.ErrorfWithCtx(ctx, "[Err] Role not found in CommunityRoleService.%s: roleID=%d, communityID=%d, err=%v", method, roleID, communityID, err)
+		return nil, fmt.Errorf("role not found")
+	}
+	return role, nil
+}
+
+// checkRoleManager returns the caller's access when they may manage the community's roles
+func (s *CommunityRoleService) checkRoleManager(ctx context.Context, userID, communityID uint64, method string) (*communityAccess, error) {
+	// Check if community exists
+	if _, err := s.communityRepo.GetCommunityByID(communityID); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Community not found in CommunityRoleService.%s: %v", method, err)
+		return nil, fmt.Errorf("community not found")
+	}
+
+	access, err := getCommunityAccess(s.communityModeratorRepo, communityID, userID)
+	if err != nil || !access.can(constant.COMMUNITY_PERMISSION_MANAGE_ROLES) {
+		logger.ErrorfWithCtx(ctx, "[Err] User does not have permission in CommunityRoleService.%s: userID=%d, communityID=%d", method, userID, communityID)
+		return nil, fmt.Errorf("permission denied")
+	}
+	return access, nil
+}
@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/interface/dto/request"
+	"social-platform-backend/package/constant"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCommunityRoleService_GetPermissions_CustomRole(t *testing.T) {
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	communityRoleService := NewCommunityRoleService(
+		nil,
+		mockCommunityRepo,
+		mockCommunityModeratorRepo,
+		nil,
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, Name: "golang"}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(4)).Return(constant.ROLE_MODERATOR, nil)
+	mockCommunityModeratorRepo.On("GetModeratorCustomRole", uint64(3), uint64(4)).Return(&model.CommunityRole{
+		ID:          7,
+		CommunityID: 3,
+		Name:        "Role manager",
+		Permissions: pq.StringArray{constant.COMMUNITY_PERMISSION_MANAGE_ROLES, constant.COMMUNITY_PERMISSION_MANAGE_POSTS},
+	}, nil)
+
+	result, err := communityRoleService.GetPermissions(context.Background(), 4, 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, constant.ROLE_MODERATOR, result.Role)
+	assert.Equal(t, uint64(7), *result.RoleID)
+	assert.Equal(t, "Role manager", *result.RoleName)
+	assert.Equal(t, []string{constant.COMMUNITY_PERMISSION_MANAGE_ROLES, constant.COMMUNITY_PERMISSION_MANAGE_POSTS}, result.Permissions)
+}
+
+func TestCommunityRoleService_GetRoles_CustomRoleWithoutPermission(t *testing.T) {
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockCommunityRoleRepo := new(MockCommunityRoleRepository)
+	communityRoleService := NewCommunityRoleService(
+		mockCommunityRoleRepo,
+		mockCommunityRepo,
+		mockCommunityModeratorRepo,
+		nil,
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, Name: "golang"}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(4)).Return(constant.ROLE_MODERATOR, nil)
+	mockCommunityModeratorRepo.On("GetModeratorCustomRole", uint64(3), uint64(4)).Return(&model.CommunityRole{
+		ID:          7,
+		CommunityID: 3,
+		Name:        "Greeter",
+		Permissions: pq.StringArray{},
+	}, nil)
+
+	result, err := communityRoleService.GetRoles(context.Background(), 4, 3)
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "permission denied")
+	mockCommunityRoleRepo.AssertNotCalled(t, "GetRolesByCommunityID", mock.Anything)
+}
+
+func TestCommunityRoleService_GetPermissions_Member(t *testing.T) {
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	communityRoleService := NewCommunityRoleService(
+		nil,
+		mockCommunityRepo,
+		mockCommunityModeratorRepo,
+		nil,
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, Name: "golang"}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), mock.Anything).Return("", nil)
+
+	result, err := communityRoleService.GetPermissions(context.Background(), 9, 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, constant.ROLE_USER, result.Role)
+	assert.Nil(t, result.RoleID)
+	assert.NotNil(t, result.Permissions)
+	assert.Empty(t, result.Permissions)
+}
+
+func TestCommunityRoleService_CreateRole_Success(t *testing.T) {
+	mockCommunityRoleRepo := new(MockCommunityRoleRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockModerationLogRepo := new(MockModerationLogRepository)
+	communityRoleService := NewCommunityRoleService(
+		mockCommunityRoleRepo,
+		mockCommunityRepo,
+		mockCommunityModeratorRepo,
+		mockModerationLogRepo,
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, Name: "golang"}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(1)).Return(constant.ROLE_SUPER_ADMIN, nil)
+
+	mockCommunityRoleRepo.On("CountRoles", uint64(3)).Return(int64(1), nil)
+	mockCommunityRoleRepo.On("GetRolesByCommunityID", uint64(3)).Return([]*model.CommunityRole{{ID: 7, CommunityID: 3, Name: "Role manager"}}, nil)
+	mockCommunityRoleRepo.On("CreateRole", mock.MatchedBy(func(role *model.CommunityRole) bool {
+		return role.CommunityID == 3 && role.Name == "Janitor" && role.CreatedBy == 1 &&
+			len(role.Permissions) == 2 && role.Permissions[0] == constant.COMMUNITY_PERMISSION_MANAGE_POSTS
+	})).Run(func(args mock.Arguments) {
+		args.Get(0).(*model.CommunityRole).ID = 8
+	}).Return(nil)
+	mockModerationLogRepo.On("CreateModerationLog", mock.MatchedBy(func(log *model.ModerationLog) bool {
+		return log.Action == constant.MODERATION_ACTION_CREATE_COMMUNITY_ROLE && log.TargetType == constant.MODERATION_TARGET_COMMUNITY_ROLE && log.TargetID == 8
+	})).Return(nil)
+
+	result, err := communityRoleService.CreateRole(context.Background(), 1, 3, &request.CommunityRoleRequest{
+		Name: " Janitor ",
+		Permissions: []string{
+			constant.COMMUNITY_PERMISSION_MANAGE_POSTS,
+			constant.COMMUNITY_PERMISSION_MANAGE_COMMENTS,
+			constant.COMMUNITY_PERMISSION_MANAGE_POSTS,
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(8), result.ID)
+	assert.Equal(t, "Janitor", result.Name)
+	mockModerationLogRepo.AssertExpectations(t)
+}
+
+func TestCommunityRoleService_CreateRole_AdminCannotManageRoles(t *testing.T) {
+	mockCommunityRoleRepo := new(MockCommunityRoleRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	communityRoleService := NewCommunityRoleService(
+		mockCommunityRoleRepo,
+		mockCommunityRepo,
+		mockCommunityModeratorRepo,
+		nil,
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, Name: "golang"}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(2)).Return(constant.ROLE_ADMIN, nil)
+
+	_, err := communityRoleService.CreateRole(context.Background(), 2, 3, &request.CommunityRoleRequest{
+		Name:        "Janitor",
+		Permissions: []string{constant.COMMUNITY_PERMISSION_MANAGE_POSTS},
+	})
+
+	assert.EqualError(t, err, "permission denied")
+	mockCommunityRoleRepo.AssertNotCalled(t, "CreateRole", mock.Anything)
+}
+
+func TestCommunityRoleService_CreateRole_CannotGrantMoreThanOwnPermissions(t *testing.T) {
+	mockCommunityRoleRepo := new(MockCommunityRoleRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	communityRoleService := NewCommunityRoleService(
+		mockCommunityRoleRepo,
+		mockCommunityRepo,
+		mockCommunityModeratorRepo,
+		nil,
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, Name: "golang"}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(4)).Return(constant.ROLE_MODERATOR, nil)
+	mockCommunityModeratorRepo.On("GetModeratorCustomRole", uint64(3), uint64(4)).Return(&model.CommunityRole{
+		ID:          7,
+		CommunityID: 3,
+		Name:        "Role manager",
+		Permissions: pq.StringArray{constant.COMMUNITY_PERMISSION_MANAGE_ROLES, constant.COMMUNITY_PERMISSION_MANAGE_POSTS},
+	}, nil)
+
+	mockCommunityRoleRepo.On("CountRoles", uint64(3)).Return(int64(1), nil)
+
+	_, err := communityRoleService.CreateRole(context.Background(), 4, 3, &request.CommunityRoleRequest{
+		Name:        "Banner",
+		Permissions: []string{constant.COMMUNITY_PERMISSION_MANAGE_POSTS, constant.COMMUNITY_PERMISSION_BAN_USERS},
+	})
+
+	assert.EqualError(t, err, "role exceeds your permissions")
+	mockCommunityRoleRepo.AssertNotCalled(t, "CreateRole", mock.Anything)
+}
+
+func TestCommunityRoleService_CreateRole_DuplicateName(t *testing.T) {
+	mockCommunityRoleRepo := new(MockCommunityRoleRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	communityRoleService := NewCommunityRoleService(
+		mockCommunityRoleRepo,
+		mockCommunityRepo,
+		mockCommunityModeratorRepo,
+		nil,
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, Name: "golang"}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(1)).Return(constant.ROLE_SUPER_ADMIN, nil)
+
+	mockCommunityRoleRepo.On("CountRoles", uint64(3)).Return(int64(1), nil)
+	mockCommunityRoleRepo.On("GetRolesByCommunityID", uint64(3)).Return([]*model.CommunityRole{{ID: 7, CommunityID: 3, Name: "Role manager"}}, nil)
+
+	_, err := communityRoleService.CreateRole(context.Background(), 1, 3, &request.CommunityRoleRequest{
+		Name:        "role MANAGER",
+		Permissions: []string{constant.COMMUNITY_PERMISSION_MANAGE_POSTS},
+	})
+
+	assert.EqualError(t, err, "role name already exists")
+}
+
+func TestCommunityRoleService_DeleteRole_AssignedRole(t *testing.T) {
+	mockCommunityRoleRepo := new(MockCommunityRoleRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	communityRoleService := NewCommunityRoleService(
+		mockCommunityRoleRepo,
+		mockCommunityRepo,
+		mockCommunityModeratorRepo,
+		nil,
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, Name: "golang"}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(1)).Return(constant.ROLE_SUPER_ADMIN, nil)
+
+	mockCommunityRoleRepo.On("GetRoleByID", uint64(7)).Return(&model.CommunityRole{
+		ID:          7,
+		CommunityID: 3,
+		Name:        "Role manager",
+		Permissions: pq.StringArray{constant.COMMUNITY_PERMISSION_MANAGE_ROLES},
+	}, nil)
+	mockCommunityRoleRepo.On("CountRoleMembers", uint64(7)).Return(int64(1), nil)
+
+	err := communityRoleService.DeleteRole(context.Background(), 1, 3, 7)
+
+	assert.EqualError(t, err, "role is assigned to moderators")
+	mockCommunityRoleRepo.AssertNotCalled(t, "DeleteRole", mock.Anything)
+}
@@ -0,0 +1,345 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/domain/repository"
+	"social-platform-backend/internal/interface/dto/request"
+	"social-platform-backend/internal/interface/dto/response"
+	"social-platform-backend/package/constant"
+	"social-platform-backend/package/logger"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// CommunityRuleService manages the ordered rules of a community. Members can read
+// them, reports and moderator removals cite them.
+type CommunityRuleService struct {
+	communityRuleRepo      repository.CommunityRuleRepository
+	communityRepo          repository.CommunityRepository
+	communityModeratorRepo repository.CommunityModeratorRepository
+	moderationLogRepo      repository.ModerationLogRepository
+}
+
+func NewCommunityRuleService(
+	communityRuleRepo repository.CommunityRuleRepository,
+	communityRepo repository.CommunityRepository,
+	communityModeratorRepo repository.CommunityModeratorRepository,
+	moderationLogRepo repository.ModerationLogRepository,
+) *CommunityRuleService {
+	return &CommunityRuleService{
+		communityRuleRepo:      communityRuleRepo,
+		communityRepo:          communityRepo,
+		communityModeratorRepo: communityModeratorRepo,
+		moderationLogRepo:      moderationLogRepo,
+	}
+}
+
+func (s *CommunityRuleService) GetRules(ctx context.Context, communityID uint64) ([]*response.CommunityRuleResponse, error) {
+	if _, err := s.communityRepo.GetCommunityByID(communityID); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Community not found in CommunityRuleService.GetRules: %v", err)
+		return nil, fmt.Errorf("community not found")
+	}
+
+	rules, err := s.communityRuleRepo.GetRulesByCommunityID(communityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting community rules in CommunityRuleService.GetRules: %v", err)
+		return nil, fmt.Errorf("failed to get community rules")
+	}
+	return response.NewCommunityRuleResponses(rules), nil
+}
+
+func (s *CommunityRuleService) CreateRule(ctx context.Context, userID, communityID uint64, req *request.CommunityRuleRequest) (*response.CommunityRuleResponse, error) {
+	if err := s.checkModerator(ctx, userID, communityID, "CreateRule"); err != nil {
+		return nil, err
+	}
+
+	count, err := s.communityRuleRepo.CountRules(communityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error counting community rules in CommunityRuleService.CreateRule: %v", err)
+		return nil, fmt.Errorf("failed to create community rule")
+	}
+	if count >= constant.COMMUNITY_RULE_MAX_PER_COMMUNITY {
+		return nil, fmt.Errorf("community rule limit reached")
+	}
+
+	rule := &model.CommunityRule{
+		CommunityID: communityID,
+		CreatedBy:   userID,
+		CreatedAt:   time.Now(),
+	}
+	if err := setCommunityRuleDefinition(rule, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.communityRuleRepo.CreateRule(rule); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error creating community rule in CommunityRuleService.CreateRule: %v", err)
+		return nil, fmt.Errorf("failed to create community rule")
+	}
+
+	s.recordRuleChange(ctx, communityID, userID, constant.MODERATION_ACTION_CREATE_COMMUNITY_RULE, constant.MODERATION_TARGET_COMMUNITY_RULE, rule.ID, rule.Title)
+	return response.NewCommunityRuleResponse(rule), nil
+}
+
+func (s *CommunityRuleService) UpdateRule(ctx context.Context, userID, communityID, ruleID uint64, req *request.CommunityRuleRequest) (*response.CommunityRuleResponse, error) {
+	if err := s.checkModerator(ctx, userID, communityID, "UpdateRule"); err != nil {
+		return nil, err
+	}
+
+	rule, err := s.getCommunityRule(ctx, communityID, ruleID, "UpdateRule")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := setCommunityRuleDefinition(rule, req); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	rule.UpdatedAt = &now
+
+	if err := s.communityRuleRepo.UpdateRule(rule); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error updating community rule in CommunityRuleService.UpdateRule: %v", err)
+		return nil, fmt.Errorf("failed to update community rule")
+	}
+
+	s.recordRuleChange(ctx, communityID, userID, constant.MODERATION_ACTION_UPDATE_COMMUNITY_RULE, constant.MODERATION_TARGET_COMMUNITY_RULE, rule.ID, rule.Title)
+	return response.NewCommunityRuleResponse(rule), nil
+}
+
+// DeleteRule keeps the rule's ID on the reports and removals that cited it
+func (s *CommunityRuleService) DeleteRule(ctx context.Context, userID, communityID, ruleID uint64) error {
+	if err := s.checkModerator(ctx, userID, communityID, "DeleteRule"); err != nil {
+		return err
+	}
+
+	rule, err := s.getCommunityRule(ctx, communityID, ruleID, "DeleteRule")
+	if err != nil {
+		return err
+	}
+
+	if err := s.communityRuleRepo.DeleteRule(rule.ID); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error deleting community rule in CommunityRuleService.DeleteRule: %v", err)
+		return fmt.Errorf("failed to delete community rule")
+	}
+
+	s.recordRuleChange(ctx, communityID, userID, constant.MODERATION_ACTION_DELETE_COMMUNITY_RULE, constant.MODERATION_TARGET_COMMUNITY_RULE, rule.ID, rule.Title)
+	return nil
+}
+
+func (s *CommunityRuleService) ReorderRules(ctx context.Context, userID, communityID uint64, req *request.ReorderCommunityRulesRequest) ([]*response.CommunityRuleResponse, error) {
+	if err := s.checkModerator(ctx, userID, communityID, "ReorderRules"); err != nil {
+		return nil, err
+	}
+
+	rules, err := s.communityRuleRepo.GetRulesByCommunityID(communityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting community rules in CommunityRuleService.ReorderRules: %v", err)
+		return nil, fmt.Errorf("failed to reorder community rules")
+	}
+
+	// The new order has to list every rule of the community exactly once
+	if len(req.RuleIDs) != len(rules) {
+		return nil, fmt.Errorf("invalid rule order")
+	}
+	ruleMap := make(map[uint64]*model.CommunityRule, len(rules))
+	for _, rule := range rules {
+		ruleMap[rule.ID] = rule
+	}
+	ordered := make([]*model.CommunityRule, 0, len(rules))
+	for position, ruleID := range req.RuleIDs {
+		rule, ok := ruleMap[ruleID]
+		if !ok {
+			return nil, fmt.Errorf("invalid rule order")
+		}
+		delete(ruleMap, ruleID)
+		rule.Position = position
+		ordered = append(ordered, rule)
+	}
+
+	if err := s.communityRuleRepo.ReorderRules(communityID, req.RuleIDs); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error reordering community rules in CommunityRuleService.ReorderRules: %v", err)
+		return nil, fmt.Errorf("failed to reorder community rules")
+	}
+
+	titles := make([]string, len(ordered))
+	for i, rule := range ordered {
+		titles[i] = rule.Title
+	}
+	s.recordRuleChange(ctx, communityID, userID, constant.MODERATION_ACTION_REORDER_COMMUNITY_RULES, constant.MODERATION_TARGET_COMMUNITY, communityID, strings.Join(titles, ", "))
+	return response.NewCommunityRuleResponses(ordered), nil
+}
+
+// GetRuleStats counts the reports citing each rule and the removals made for it over the last days
+func (s *CommunityRuleService) GetRuleStats(ctx context.Context, userID, communityID uint64, days int) (*response.CommunityRuleStatsResponse, error) {
+	if err := s.checkModerator(ctx, userID, communityID, "GetRuleStats"); err != nil {
+		return nil, err
+	}
+
+	if days < 1 {
+		days = constant.COMMUNITY_RULE_DEFAULT_STATS_DAYS
+	}
+	if days > constant.COMMUNITY_RULE_MAX_STATS_DAYS {
+		days = constant.COMMUNITY_RULE_MAX_STATS_DAYS
+	}
+	from := time.Now().AddDate(0, 0, -days)
+
+	rules, err := s.communityRuleRepo.GetRulesByCommunityID(communityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting community rules in CommunityRuleService.GetRuleStats: %v", err)
+		return nil, fmt.Errorf("failed to get community rule stats")
+	}
+	stats, err := s.communityRuleRepo.GetRuleStats(communityID, from)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting community rule stats in CommunityRuleService.GetRuleStats: %v", err)
+		return nil, fmt.Errorf("failed to get community rule stats")
+	}
+
+	statMap := make(map[uint64]*model.CommunityRuleStat, len(stats))
+	for _, stat := range stats {
+		statMap[stat.RuleID] = stat
+	}
+
+	resp := &response.CommunityRuleStatsResponse{
+		CommunityID: communityID,
+		From:        from,
+		Rules:       make([]*response.CommunityRuleStatInfo, len(rules)),
+	}
+	for i, rule := range rules {
+		info := &response.CommunityRuleStatInfo{
+			RuleID:   rule.ID,
+			Position: rule.Position,
+			Title:    rule.Title,
+		}
+		if stat := statMap[rule.ID]; stat != nil {
+			info.ReportCount = stat.ReportCount
+			info.RemovalCount = stat.RemovalCount
+		}
+		resp.Rules[i] = info
+	}
+	return resp, nil
+}
+
+func (s *CommunityRuleService) recordRuleChange(ctx context.Context, communityID, actorID uint64, action, targetType string, targetID uint64, details string) {
+	if s.moderationLogRepo == nil {
+		return
+	}
+
+	moderationLog := &model.ModerationLog{
+		CommunityID: communityID,
+		ActorID:     actorID,
+		Action:      action,
+		TargetType:  targetType,
+		TargetID:    targetID,
+		Details:     &details,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.moderationLogRepo.CreateModerationLog(moderationLog); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error recording moderation log in CommunityRuleService.recordRuleChange: %v", err)
+	}
+}
+
+func (s *CommunityRuleService) getCommunityRule(ctx context.Context, communityID, ruleID uint64, method string) (*model.CommunityRule, error) {
+	rule, err := s.communityRuleRepo.GetRuleByID(ruleID)
+	if err != nil || rule.CommunityID != communityID {
+		logger.ErrorfWithCtx(ctx, "[Err] Rule not found in CommunityRuleService.%s: ruleID=%d, communityID=%d, err=%v", method, ruleID, communityID, err)
+		return nil, fmt.Errorf("rule not found")
+	}
+	return rule, nil
+}
+
+func (s *CommunityRuleService) checkModerator(ctx context.Context, userID, communityID uint64, method string) error {
+	// Check if community exists
+	if _, err := s.communityRepo.GetCommunityByID(communityID); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Community not found in CommunityRuleService.%s: %v", method, err)
+		return fmt.Errorf("community not found")
+	}
+
+	return checkCommunityPermission(ctx, s.communityModeratorRepo, communityID, userID, constant.COMMUNITY_PERMISSION_MANAGE_RULES, "CommunityRuleService."+method)
+}
+
+// setCommunityRuleDefinition validates the request and stores it on the rule
+func setCommunityRuleDefinition(rule *model.CommunityRule, req *request.CommunityRuleRequest) error {
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		return fmt.Errorf("rule title is required")
+	}
+
+	rule.Title = title
+	rule.Description = nil
+	if req.Description != nil {
+		if description := strings.TrimSpace(*req.Description); description != "" {
+			rule.Description = &description
+		}
+	}
+	rule.AppliesTo = req.AppliesTo
+	return nil
+}
+
+// ruleAppliesTo tells whether a rule can be cited for a post or a comment
+func ruleAppliesTo(rule *model.CommunityRule, targetType string) bool {
+	switch rule.AppliesTo {
+	case constant.COMMUNITY_RULE_APPLIES_TO_ALL:
+		return true
+	case constant.COMMUNITY_RULE_APPLIES_TO_POSTS:
+		return targetType == constant.MODERATION_TARGET_POST
+	case constant.COMMUNITY_RULE_APPLIES_TO_COMMENTS:
+		return targetType == constant.MODERATION_TARGET_COMMENT
+	}
+	return false
+}
+
+// getReportRuleIDs checks that the rules cited by a report belong to the community and
+// apply to the reported content. Duplicates are dropped.
+func getReportRuleIDs(ctx context.Context, communityRuleRepo repository.CommunityRuleRepository, communityID uint64, targetType string, ruleIDs []uint64) (pq.Int64Array, error) {
+	if len(ruleIDs) == 0 {
+		return nil, nil
+	}
+	if communityRuleRepo == nil {
+		return nil, fmt.Errorf("invalid report rules")
+	}
+
+	rules, err := communityRuleRepo.GetRulesByIDs(ruleIDs)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting community rules in getReportRuleIDs: %v", err)
+		return nil, fmt.Errorf("failed to check report rules")
+	}
+	ruleMap := make(map[uint64]*model.CommunityRule, len(rules))
+	for _, rule := range rules {
+		ruleMap[rule.ID] = rule
+	}
+
+	reportRuleIDs := make(pq.Int64Array, 0, len(ruleIDs))
+	seen := make(map[uint64]bool, len(ruleIDs))
+	for _, ruleID := range ruleIDs {
+		rule := ruleMap[ruleID]
+		if rule == nil || rule.CommunityID != communityID || !ruleAppliesTo(rule, targetType) {
+			return nil, fmt.Errorf("invalid report rules")
+		}
+		if !seen[ruleID] {
+			seen[ruleID] = true
+			reportRuleIDs = append(reportRuleIDs, int64(ruleID))
+		}
+	}
+	return reportRuleIDs, nil
+}
+
+// getRemovalRule loads the community rule a moderator cites for removing a post or a
+// comment. No rule is cited when ruleID is nil.
+func getRemovalRule(ctx context.Context, communityRuleRepo repository.CommunityRuleRepository, communityID uint64, targetType string, ruleID *uint64) (*model.CommunityRule, error) {
+	if ruleID == nil {
+		return nil, nil
+	}
+	if communityRuleRepo == nil {
+		return nil, fmt.Errorf("invalid rule")
+	}
+
+	rule, err := communityRuleRepo.GetRuleByID(*ruleID)
+	if err != nil || rule.CommunityID != communityID || !ruleAppliesTo(rule, targetType) {
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid removal rule in getRemovalRule: ruleID=%d, communityID=%d, err=%v", *ruleID, communityID, err)
+		return nil, fmt.Errorf("invalid rule")
+	}
+	return rule, nil
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/interface/dto/request"
+	"social-platform-backend/package/constant"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCommunityRuleService_CreateRule_Success(t *testing.T) {
+	mockCommunityRuleRepo := new(MockCommunityRuleRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockModerationLogRepo := new(MockModerationLogRepository)
+	communityRuleService := NewCommunityRuleService(
+		mockCommunityRuleRepo,
+		mockCommunityRepo,
+		mockCommunityModeratorRepo,
+		mockModerationLogRepo,
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, Name: "golang"}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(1)).Return(constant.ROLE_ADMIN, nil)
+
+	description := "  No ads or referral links  "
+
+	mockCommunityRuleRepo.On("CountRules", uint64(3)).Return(int64(2), nil)
+	mockCommunityRuleRepo.On("CreateRule", mock.MatchedBy(func(rule *model.CommunityRule) bool {
+		return rule.CommunityID == 3 && rule.Title == "No spam" && *rule.Description == "No ads or referral links" &&
+			rule.AppliesTo == constant.COMMUNITY_RULE_APPLIES_TO_ALL && rule.CreatedBy == 1
+	})).Run(func(args mock.Arguments) {
+		rule := args.Get(0).(*model.CommunityRule)
+		rule.ID = 9
+		rule.Position = 2
+	}).Return(nil)
+	mockModerationLogRepo.On("CreateModerationLog", mock.MatchedBy(func(log *model.ModerationLog) bool {
+		return log.Action == constant.MODERATION_ACTION_CREATE_COMMUNITY_RULE && log.TargetType == constant.MODERATION_TARGET_COMMUNITY_RULE && log.TargetID == 9
+	})).Return(nil)
+
+	result, err := communityRuleService.CreateRule(context.Background(), 1, 3, &request.CommunityRuleRequest{
+		Title:       " No spam ",
+		Description: &description,
+		AppliesTo:   constant.COMMUNITY_RULE_APPLIES_TO_ALL,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(9), result.ID)
+	assert.Equal(t, 2, result.Position)
+	mockModerationLogRepo.AssertExpectations(t)
+}
+
+func TestCommunityRuleService_CreateRule_LimitReached(t *testing.T) {
+	mockCommunityRuleRepo := new(MockCommunityRuleRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	communityRuleService := NewCommunityRuleService(
+		mockCommunityRuleRepo,
+		mockCommunityRepo,
+		mockCommunityModeratorRepo,
+		nil,
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, Name: "golang"}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(1)).Return(constant.ROLE_ADMIN, nil)
+
+	mockCommunityRuleRepo.On("CountRules", uint64(3)).Return(int64(constant.COMMUNITY_RULE_MAX_PER_COMMUNITY), nil)
+
+	_, err := communityRuleService.CreateRule(context.Background(), 1, 3, &request.CommunityRuleRequest{
+		Title:     "No spam",
+		AppliesTo: constant.COMMUNITY_RULE_APPLIES_TO_POSTS,
+	})
+
+	assert.EqualError(t, err, "community rule limit reached")
+	mockCommunityRuleRepo.AssertNotCalled(t, "CreateRule", mock.Anything)
+}
+
+func TestCommunityRuleService_ReorderRules_MustListEveryRule(t *testing.T) {
+	mockCommunityRuleRepo := new(MockCommunityRuleRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	communityRuleService := NewCommunityRuleService(
+		mockCommunityRuleRepo,
+		mockCommunityRepo,
+		mockCommunityModeratorRepo,
+		nil,
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, Name: "golang"}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(1)).Return(constant.ROLE_ADMIN, nil)
+
+	mockCommunityRuleRepo.On("GetRulesByCommunityID", uint64(3)).Return([]*model.CommunityRule{
+		{ID: 1, CommunityID: 3, Position: 0},
+		{ID: 2, CommunityID: 3, Position: 1},
+	}, nil)
+
+	_, err := communityRuleService.ReorderRules(context.Background(), 1, 3, &request.ReorderCommunityRulesRequest{RuleIDs: []uint64{2, 2}})
+
+	assert.EqualError(t, err, "invalid rule order")
+	mockCommunityRuleRepo.AssertNotCalled(t, "ReorderRules", mock.Anything, mock.Anything)
+}
+
+func TestCommunityRuleService_DeleteRule_OtherCommunity(t *testing.T) {
+	mockCommunityRuleRepo := new(MockCommunityRuleRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	communityRuleService := NewCommunityRuleService(
+		mockCommunityRuleRepo,
+		mockCommunityRepo,
+		mockCommunityModeratorRepo,
+		nil,
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, Name: "golang"}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(1)).Return(constant.ROLE_ADMIN, nil)
+
+	mockCommunityRuleRepo.On("GetRuleByID", uint64(5)).Return(&model.CommunityRule{ID: 5, CommunityID: 4}, nil)
+
+	err := communityRuleService.DeleteRule(context.Background(), 1, 3, 5)
+
+	assert.EqualError(t, err, "rule not found")
+	mockCommunityRuleRepo.AssertNotCalled(t, "DeleteRule", mock.Anything)
+}
+
+func TestCommunityRuleService_GetRuleStats_NotModerator(t *testing.T) {
+	mockCommunityRuleRepo := new(MockCommunityRuleRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	communityRuleService := NewCommunityRuleService(
+		mockCommunityRuleRepo,
+		mockCommunityRepo,
+		mockCommunityModeratorRepo,
+		nil,
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, Name: "golang"}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), mock.Anything).Return("", assert.AnError)
+
+	_, err := communityRuleService.GetRuleStats(context.Background(), 2, 3, 30)
+
+	assert.EqualError(t, err, "permission denied")
+	mockCommunityRuleRepo.AssertNotCalled(t, "GetRuleStats", mock.Anything, mock.Anything)
+}
+
+func TestCommunityRuleService_GetRuleStats_IncludesUncitedRules(t *testing.T) {
+	mockCommunityRuleRepo := new(MockCommunityRuleRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	communityRuleService := NewCommunityRuleService(
+		mockCommunityRuleRepo,
+		mockCommunityRepo,
+		mockCommunityModeratorRepo,
+		nil,
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, Name: "golang"}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(1)).Return(constant.ROLE_ADMIN, nil)
+
+	mockCommunityRuleRepo.On("GetRulesByCommunityID", uint64(3)).Return([]*model.CommunityRule{
+		{ID: 1, CommunityID: 3, Position: 0, Title: "Be civil"},
+		{ID: 2, CommunityID: 3, Position: 1, Title: "No spam"},
+	}, nil)
+	mockCommunityRuleRepo.On("GetRuleStats", uint64(3), mock.Anything).Return([]*model.CommunityRuleStat{
+		{RuleID: 2, ReportCount: 7, RemovalCount: 3},
+	}, nil)
+
+	result, err := communityRuleService.GetRuleStats(context.Background(), 1, 3, 7)
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Rules, 2)
+	assert.Equal(t, int64(0), result.Rules[0].ReportCount)
+	assert.Equal(t, "No spam", result.Rules[1].Title)
+	assert.Equal(t, int64(7), result.Rules[1].ReportCount)
+	assert.Equal(t, int64(3), result.Rules[1].RemovalCount)
+}
+
+func TestGetReportRuleIDs(t *testing.T) {
+	ruleRepo := new(MockCommunityRuleRepository)
+	ruleRepo.On("GetRulesByIDs", []uint64{1, 1, 2}).Return([]*model.CommunityRule{
+		{ID: 1, CommunityID: 3, AppliesTo: constant.COMMUNITY_RULE_APPLIES_TO_ALL},
+		{ID: 2, CommunityID: 3, AppliesTo: constant.COMMUNITY_RULE_APPLIES_TO_POSTS},
+	}, nil)
+	ruleRepo.On("GetRulesByIDs", []uint64{2}).Return([]*model.CommunityRule{
+		{ID: 2, CommunityID: 3, AppliesTo: constant.COMMUNITY_RULE_APPLIES_TO_POSTS},
+	}, nil)
+	ruleRepo.On("GetRulesByIDs", []uint64{8}).Return([]*model.CommunityRule{
+		{ID: 8, CommunityID: 4, AppliesTo: constant.COMMUNITY_RULE_APPLIES_TO_ALL},
+	}, nil)
+
+	ruleIDs, err := getReportRuleIDs(context.Background(), ruleRepo, 3, constant.MODERATION_TARGET_POST, []uint64{1, 1, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, pq.Int64Array{1, 2}, ruleIDs)
+
+	_, err = getReportRuleIDs(context.Background(), ruleRepo, 3, constant.MODERATION_TARGET_COMMENT, []uint64{2})
+	assert.EqualError(t, err, "invalid report rules")
+
+	_, err = getReportRuleIDs(context.Background(), ruleRepo, 3, constant.MODERATION_TARGET_POST, []uint64{8})
+	assert.EqualError(t, err, "invalid report rules")
+}
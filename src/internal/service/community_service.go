@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"fmt"
+	"slices"
 	"social-platform-backend/internal/domain/model"
 	"social-platform-backend/internal/domain/repository"
 	"social-platform-backend/internal/interface/dto/request"
@@ -10,6 +11,7 @@ import (
 	"social-platform-backend/package/constant"
 	"social-platform-backend/package/logger"
 	"social-platform-backend/package/template/payload"
+	"social-platform-backend/package/util"
 	"strings"
 	"time"
 )
@@ -26,6 +28,14 @@ type CommunityService struct {
 	userRestrictionRepo    repository.UserRestrictionRepository
 	notificationService    *NotificationService
 	botTaskService         *BotTaskService
+	postRevisionRepo       repository.PostRevisionRepository
+	commentRevisionRepo    repository.CommentRevisionRepository
+	moderationLogRepo      repository.ModerationLogRepository
+	postService            *PostService
+	postFingerprintRepo    repository.PostFingerprintRepository
+	communityRuleRepo      repository.CommunityRuleRepository
+	communityRoleRepo      repository.CommunityRoleRepository
+	invitationRepo         repository.ModeratorInvitationRepository
 }
 
 func NewCommunityService(
@@ -40,6 +50,14 @@ func NewCommunityService(
 	userRestrictionRepo repository.UserRestrictionRepository,
 	notificationService *NotificationService,
 	botTaskService *BotTaskService,
+	postRevisionRepo repository.PostRevisionRepository,
+	commentRevisionRepo repository.CommentRevisionRepository,
+	moderationLogRepo repository.ModerationLogRepository,
+	postService *PostService,
+	postFingerprintRepo repository.PostFingerprintRepository,
+	communityRuleRepo repository.CommunityRuleRepository,
+	communityRoleRepo repository.CommunityRoleRepository,
+	invitationRepo repository.ModeratorInvitationRepository,
 ) *CommunityService {
 	return &CommunityService{
 		communityRepo:          communityRepo,
@@ -53,6 +71,14 @@ func NewCommunityService(
 		userRestrictionRepo:    userRestrictionRepo,
 		notificationService:    notificationService,
 		botTaskService:         botTaskService,
+		postRevisionRepo:       postRevisionRepo,
+		commentRevisionRepo:    commentRevisionRepo,
+		moderationLogRepo:      moderationLogRepo,
+		postService:            postService,
+		postFingerprintRepo:    postFingerprintRepo,
+		communityRuleRepo:      communityRuleRepo,
+		communityRoleRepo:      communityRoleRepo,
+		invitationRepo:         invitationRepo,
 	}
 }
 
@@ -140,11 +166,8 @@ func (s *CommunityService) UpdateCommunity(ctx context.Context, userID, id uint6
 		return fmt.Errorf("community not found")
 	}
 
-	// Check if user has permission (must be SUPER_ADMIN)
-	role, err := s.communityModeratorRepo.GetModeratorRole(id, userID)
-	if err != nil || role != constant.ROLE_SUPER_ADMIN {
-		logger.ErrorfWithCtx(ctx, "[Err] User does not have permission in CommunityService.UpdateCommunity: userID=%d, communityID=%d", userID, id)
-		return fmt.Errorf("permission denied")
+	if err := checkCommunityPermission(ctx, s.communityModeratorRepo, id, userID, constant.COMMUNITY_PERMISSION_EDIT_SETTINGS, "CommunityService.UpdateCommunity"); err != nil {
+		return err
 	}
 
 	if err := s.communityRepo.UpdateCommunity(id, req); err != nil {
@@ -152,9 +175,40 @@ func (s *CommunityService) UpdateCommunity(ctx context.Context, userID, id uint6
 		return fmt.Errorf("failed to update community")
 	}
 
+	s.recordSettingsChange(ctx, id, userID, "updated "+strings.Join(updatedCommunityFields(req), ", "))
 	return nil
 }
 
+// updatedCommunityFields names the fields an update request sets, for the mod log
+func updatedCommunityFields(req *request.UpdateCommunityRequest) []string {
+	fields := make([]string, 0)
+	if req.Name != nil {
+		fields = append(fields, "name")
+	}
+	if req.ShortDescription != nil {
+		fields = append(fields, "short description")
+	}
+	if req.Description != nil {
+		fields = append(fields, "description")
+	}
+	if req.Topic != nil {
+		fields = append(fields, "topics")
+	}
+	if req.CommunityAvatar != nil {
+		fields = append(fields, "avatar")
+	}
+	if req.CoverImage != nil {
+		fields = append(fields, "cover image")
+	}
+	if req.IsPrivate != nil {
+		fields = append(fields, fmt.Sprintf("private: %t", *req.IsPrivate))
+	}
+	if len(fields) == 0 {
+		fields = append(fields, "nothing")
+	}
+	return fields
+}
+
 func (s *CommunityService) DeleteCommunity(ctx context.Context, userID, id uint64) error {
 	// Check if community exists
 	_, err := s.communityRepo.GetCommunityByID(id)
@@ -163,11 +217,8 @@ func (s *CommunityService) DeleteCommunity(ctx context.Context, userID, id uint6
 		return fmt.Errorf("community not found")
 	}
 
-	// Check if user has permission (must be SUPER_ADMIN)
-	role, err := s.communityModeratorRepo.GetModeratorRole(id, userID)
-	if err != nil || role != constant.ROLE_SUPER_ADMIN {
-		logger.ErrorfWithCtx(ctx, "[Err] User does not have permission in CommunityService.DeleteCommunity: userID=%d, communityID=%d", userID, id)
-		return fmt.Errorf("permission denied")
+	if err := checkCommunityOwner(ctx, s.communityModeratorRepo, id, userID, "CommunityService.DeleteCommunity"); err != nil {
+		return err
 	}
 
 	if err := s.communityRepo.DeleteCommunity(id); err != nil {
@@ -246,13 +297,18 @@ func (s *CommunityService) UnjoinCommunity(ctx context.Context, userID, communit
 		return fmt.Errorf("not subscribed to this community")
 	}
 
-	// Check if user is a moderator
+	// Check if user is a moderator. The super admin has to hand the community over first
+	// so that it never loses its owner.
 	role, err := s.communityModeratorRepo.GetModeratorRole(communityID, userID)
+	if err == nil && role == constant.ROLE_SUPER_ADMIN {
+		return fmt.Errorf("super admin must transfer ownership before leaving")
+	}
 	if err == nil && role != "" {
 		if err := s.communityModeratorRepo.DeleteModerator(communityID, userID); err != nil {
 			logger.ErrorfWithCtx(ctx, "[Err] Error deleting moderator in CommunityService.UnjoinCommunity: %v", err)
 			return fmt.Errorf("failed to remove moderator role")
 		}
+		s.cancelInviterInvitations(ctx, communityID, userID)
 	}
 
 	// Delete subscription
@@ -395,11 +451,8 @@ func (s *CommunityService) GetCommunityMembers(ctx context.Context, userID, comm
 		return nil, nil, fmt.Errorf("community not found")
 	}
 
-	// Check if user has permission (SUPER_ADMIN or ADMIN)
-	role, err := s.communityModeratorRepo.GetModeratorRole(communityID, userID)
-	if err != nil || (role != constant.ROLE_SUPER_ADMIN && role != constant.ROLE_ADMIN) {
-		logger.ErrorfWithCtx(ctx, "[Err] User does not have permission in CommunityService.GetCommunityMembers: userID=%d, communityID=%d", userID, communityID)
-		return nil, nil, fmt.Errorf("permission denied")
+	if err := checkCommunityPermission(ctx, s.communityModeratorRepo, communityID, userID, constant.COMMUNITY_PERMISSION_MANAGE_MEMBERS, "CommunityService.GetCommunityMembers"); err != nil {
+		return nil, nil, err
 	}
 
 	// Validate sortBy
@@ -447,7 +500,7 @@ func (s *CommunityService) GetCommunityMembers(ctx context.Context, userID, comm
 	return memberResponses, pagination, nil
 }
 
-func (s *CommunityService) RemoveMember(ctx context.Context, userID, communityID, memberID uint64) error {
+func (s *CommunityService) RemoveMember(ctx context.Context, userID, communityID, memberID uint64, reason *string) error {
 	// Check if community exists
 	_, err := s.communityRepo.GetCommunityByID(communityID)
 	if err != nil {
@@ -455,9 +508,8 @@ func (s *CommunityService) RemoveMember(ctx context.Context, userID, communityID
 		return fmt.Errorf("community not found")
 	}
 
-	// Check if user has permission (must be SUPER_ADMIN or ADMIN)
-	role, err := s.communityModeratorRepo.GetModeratorRole(communityID, userID)
-	if err != nil || (role != constant.ROLE_SUPER_ADMIN && role != constant.ROLE_ADMIN) {
+	callerAccess, err := getCommunityAccess(s.communityModeratorRepo, communityID, userID)
+	if err != nil || !callerAccess.can(constant.COMMUNITY_PERMISSION_MANAGE_MEMBERS) {
 		logger.ErrorfWithCtx(ctx, "[Err] User does not have permission in CommunityService.RemoveMember: userID=%d, communityID=%d", userID, communityID)
 		return fmt.Errorf("permission denied")
 	}
@@ -472,16 +524,42 @@ func (s *CommunityService) RemoveMember(ctx context.Context, userID, communityID
 		return fmt.Errorf("member not found in this community")
 	}
 
+	memberAccess, err := getCommunityAccess(s.communityModeratorRepo, communityID, memberID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting member role in CommunityService.RemoveMember: %v", err)
+		return fmt.Errorf("failed to remove member")
+	}
+	if memberAccess.Role == constant.ROLE_SUPER_ADMIN {
+		return fmt.Errorf("cannot remove the super admin")
+	}
+	if !hasAllPermissions(callerAccess, memberAccess.Permissions) {
+		return fmt.Errorf("role exceeds your permissions")
+	}
+
+	// A removed moderator loses the role along with the membership
+	if memberAccess.isModerator() {
+		if err := s.communityModeratorRepo.DeleteModerator(communityID, memberID); err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Error removing moderator in CommunityService.RemoveMember: %v", err)
+			return fmt.Errorf("failed to remove member")
+		}
+		s.cancelInviterInvitations(ctx, communityID, memberID)
+	}
+
 	// Remove member
 	if err := s.subscriptionRepo.DeleteSubscription(memberID, communityID); err != nil {
 		logger.ErrorfWithCtx(ctx, "[Err] Error removing member in CommunityService.RemoveMember: %v", err)
 		return fmt.Errorf("failed to remove member")
 	}
 
+	s.recordModerationLog(ctx, communityID, userID, constant.MODERATION_ACTION_REMOVE_MEMBER, constant.MODERATION_TARGET_USER, memberID, reason)
 	return nil
 }
 
-func (s *CommunityService) UpdateMemberRole(ctx context.Context, adminUserID, communityID, targetUserID uint64, role string) error {
+// UpdateMemberRole changes the role of a moderator to user, admin or one of the
+// community's custom roles. Members become moderators only by accepting an invitation.
+// Moderators can only hand out and take away roles whose permissions they hold
+// themselves, and the super admin's role never changes here.
+func (s *CommunityService) UpdateMemberRole(ctx context.Context, adminUserID, communityID, targetUserID uint64, role string, roleID *uint64) error {
 	// Check if community exists
 	_, err := s.communityRepo.GetCommunityByID(communityID)
 	if err != nil {
@@ -489,9 +567,8 @@ func (s *CommunityService) UpdateMemberRole(ctx context.Context, adminUserID, co
 		return fmt.Errorf("community not found")
 	}
 
-	// Check if admin has permission (must be SUPER_ADMIN)
-	adminRole, err := s.communityModeratorRepo.GetModeratorRole(communityID, adminUserID)
-	if err != nil || adminRole != constant.ROLE_SUPER_ADMIN {
+	adminAccess, err := getCommunityAccess(s.communityModeratorRepo, communityID, adminUserID)
+	if err != nil || !adminAccess.can(constant.COMMUNITY_PERMISSION_MANAGE_ROLES) {
 		logger.ErrorfWithCtx(ctx, "[Err] User does not have permission in CommunityService.UpdateMemberRole: userID=%d, communityID=%d", adminUserID, communityID)
 		return fmt.Errorf("permission denied")
 	}
@@ -506,31 +583,77 @@ func (s *CommunityService) UpdateMemberRole(ctx context.Context, adminUserID, co
 		return fmt.Errorf("user is not a member of this community")
 	}
 
-	// If role is "user", remove from moderators
-	if role == constant.ROLE_USER {
+	targetAccess, err := getCommunityAccess(s.communityModeratorRepo, communityID, targetUserID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting member role in CommunityService.UpdateMemberRole: %v", err)
+		return fmt.Errorf("failed to update moderator role")
+	}
+	if targetAccess.Role == constant.ROLE_SUPER_ADMIN {
+		return fmt.Errorf("cannot change the role of the super admin")
+	}
+	if !targetAccess.isModerator() {
+		if role == constant.ROLE_USER {
+			return nil
+		}
+		return fmt.Errorf("user must accept a moderator invitation")
+	}
+	if !hasAllPermissions(adminAccess, targetAccess.Permissions) {
+		return fmt.Errorf("role exceeds your permissions")
+	}
+
+	moderator := &model.CommunityModerator{
+		CommunityID: communityID,
+		UserID:      targetUserID,
+		Role:        role,
+		JoinedAt:    time.Now(),
+	}
+	var details *string
+	switch role {
+	case constant.ROLE_USER:
+		// If role is "user", remove from moderators
 		if err := s.communityModeratorRepo.DeleteModerator(communityID, targetUserID); err != nil {
 			logger.ErrorfWithCtx(ctx, "[Err] Error removing moderator in CommunityService.UpdateMemberRole: %v", err)
 			return fmt.Errorf("failed to remove moderator role")
 		}
+		s.cancelInviterInvitations(ctx, communityID, targetUserID)
+		s.recordModerationLog(ctx, communityID, adminUserID, constant.MODERATION_ACTION_REMOVE_MODERATOR, constant.MODERATION_TARGET_USER, targetUserID, nil)
 		return nil
-	}
-
-	// If role is "admin", upsert moderator
-	if role == constant.ROLE_ADMIN {
-		moderator := &model.CommunityModerator{
-			CommunityID: communityID,
-			UserID:      targetUserID,
-			Role:        constant.ROLE_ADMIN,
-			JoinedAt:    time.Now(),
+	case constant.ROLE_ADMIN:
+		if !hasAllPermissions(adminAccess, constant.COMMUNITY_ROLE_PERMISSIONS[constant.ROLE_ADMIN]) {
+			return fmt.Errorf("role exceeds your permissions")
 		}
-		if err := s.communityModeratorRepo.UpsertModerator(moderator); err != nil {
-			logger.ErrorfWithCtx(ctx, "[Err] Error upserting moderator in CommunityService.UpdateMemberRole: %v", err)
-			return fmt.Errorf("failed to update moderator role")
+	case constant.ROLE_MODERATOR:
+		if roleID == nil {
+			return fmt.Errorf("role id is required")
 		}
-		return nil
-	}
-
-	return fmt.Errorf("invalid role")
+		customRole, err := s.communityRoleRepo.GetRoleByID(*roleID)
+		if err != nil || customRole.CommunityID != communityID {
+			logger.ErrorfWithCtx(ctx, "[Err] Role not found in CommunityService.UpdateMemberRole: roleID=%d, communityID=%d, err=%v", *roleID, communityID, err)
+			return fmt.Errorf("role not found")
+		}
+		if !hasAllPermissions(adminAccess, customRole.Permissions) {
+			return fmt.Errorf("role exceeds your permissions")
+		}
+		moderator.RoleID = &customRole.ID
+		details = &customRole.Name
+	default:
+		return fmt.Errorf("invalid role")
+	}
+
+	if err := s.communityModeratorRepo.UpsertModerator(moderator); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error upserting moderator in CommunityService.UpdateMemberRole: %v", err)
+		return fmt.Errorf("failed to update moderator role")
+	}
+	s.createModerationLog(ctx, &model.ModerationLog{
+		CommunityID: communityID,
+		ActorID:     adminUserID,
+		Action:      constant.MODERATION_ACTION_ADD_MODERATOR,
+		TargetType:  constant.MODERATION_TARGET_USER,
+		TargetID:    targetUserID,
+		Details:     details,
+		CreatedAt:   time.Now(),
+	})
+	return nil
 }
 
 func (s *CommunityService) GetUserRoleInCommunity(ctx context.Context, userID, communityID uint64) (string, error) {
@@ -560,11 +683,8 @@ func (s *CommunityService) GetCommunityPostsForModerator(ctx context.Context, us
 		return nil, nil, fmt.Errorf("community not found")
 	}
 
-	// Check if user has permission (SUPER_ADMIN or ADMIN)
-	role, err := s.communityModeratorRepo.GetModeratorRole(communityID, userID)
-	if err != nil || (role != constant.ROLE_SUPER_ADMIN && role != constant.ROLE_ADMIN) {
-		logger.ErrorfWithCtx(ctx, "[Err] User does not have permission in CommunityService.GetCommunityPostsForModerator: userID=%d, communityID=%d", userID, communityID)
-		return nil, nil, fmt.Errorf("permission denied")
+	if err := checkCommunityPermission(ctx, s.communityModeratorRepo, communityID, userID, constant.COMMUNITY_PERMISSION_MANAGE_POSTS, "CommunityService.GetCommunityPostsForModerator"); err != nil {
+		return nil, nil, err
 	}
 
 	posts, total, err := s.postRepo.GetCommunityPostsForModerator(communityID, status, searchTitle, page, limit)
@@ -598,7 +718,7 @@ func (s *CommunityService) GetCommunityPostsForModerator(ctx context.Context, us
 	return postResponses, pagination, nil
 }
 
-func (s *CommunityService) UpdatePostStatusByModerator(ctx context.Context, userID, communityID, postID uint64, status string) error {
+func (s *CommunityService) UpdatePostStatusByModerator(ctx context.Context, userID, communityID, postID uint64, status string, reason *string) error {
 	// Check if community exists
 	_, err := s.communityRepo.GetCommunityByID(communityID)
 	if err != nil {
@@ -606,11 +726,8 @@ func (s *CommunityService) UpdatePostStatusByModerator(ctx context.Context, user
 		return fmt.Errorf("community not found")
 	}
 
-	// Check if user has permission (SUPER_ADMIN or ADMIN)
-	role, err := s.communityModeratorRepo.GetModeratorRole(communityID, userID)
-	if err != nil || (role != constant.ROLE_SUPER_ADMIN && role != constant.ROLE_ADMIN) {
-		logger.ErrorfWithCtx(ctx, "[Err] User does not have permission in CommunityService.UpdatePostStatusByModerator: userID=%d, communityID=%d", userID, communityID)
-		return fmt.Errorf("permission denied")
+	if err := checkCommunityPermission(ctx, s.communityModeratorRepo, communityID, userID, constant.COMMUNITY_PERMISSION_MANAGE_POSTS, "CommunityService.UpdatePostStatusByModerator"); err != nil {
+		return err
 	}
 
 	// Get post
@@ -631,6 +748,25 @@ func (s *CommunityService) UpdatePostStatusByModerator(ctx context.Context, user
 		return fmt.Errorf("failed to update post status")
 	}
 
+	switch status {
+	case constant.POST_STATUS_APPROVED:
+		s.recordModerationLog(ctx, communityID, userID, constant.MODERATION_ACTION_APPROVE_POST, constant.MODERATION_TARGET_POST, postID, reason)
+	case constant.POST_STATUS_REJECTED:
+		s.recordModerationLog(ctx, communityID, userID, constant.MODERATION_ACTION_REJECT_POST, constant.MODERATION_TARGET_POST, postID, reason)
+	default:
+		details := "status: " + status
+		s.createModerationLog(ctx, &model.ModerationLog{
+			CommunityID: communityID,
+			ActorID:     userID,
+			Action:      constant.MODERATION_ACTION_UPDATE_POST_STATUS,
+			TargetType:  constant.MODERATION_TARGET_POST,
+			TargetID:    postID,
+			Reason:      reason,
+			Details:     &details,
+			CreatedAt:   time.Now(),
+		})
+	}
+
 	// Send notification to post author
 	if status == constant.POST_STATUS_APPROVED || status == constant.POST_STATUS_REJECTED {
 		go func(authorID uint64, postID uint64, postTitle string, status string) {
@@ -653,13 +789,18 @@ func (s *CommunityService) UpdatePostStatusByModerator(ctx context.Context, user
 			}
 
 			s.notificationService.CreateNotification(ctx, authorID, constant.NOTIFICATION_ACTION_POST_STATUS_UPDATED, notifPayload)
+
+			if status == constant.POST_STATUS_APPROVED && s.postService != nil {
+				s.postService.NotifyMentions(ctx, post)
+			}
 		}(post.AuthorID, postID, post.Title, status)
 	}
 
 	return nil
 }
 
-func (s *CommunityService) DeletePostByModerator(ctx context.Context, userID, communityID, postID uint64) error {
+// DeletePostByModerator removes a post, ruleID is the community rule it broke when given
+func (s *CommunityService) DeletePostByModerator(ctx context.Context, userID, communityID, postID uint64, ruleID *uint64, reason *string) error {
 	// Check if community exists
 	_, err := s.communityRepo.GetCommunityByID(communityID)
 	if err != nil {
@@ -667,11 +808,8 @@ func (s *CommunityService) DeletePostByModerator(ctx context.Context, userID, co
 		return fmt.Errorf("community not found")
 	}
 
-	// Check if user has permission (SUPER_ADMIN or ADMIN)
-	role, err := s.communityModeratorRepo.GetModeratorRole(communityID, userID)
-	if err != nil || (role != constant.ROLE_SUPER_ADMIN && role != constant.ROLE_ADMIN) {
-		logger.ErrorfWithCtx(ctx, "[Err] User does not have permission in CommunityService.DeletePostByModerator: userID=%d, communityID=%d", userID, communityID)
-		return fmt.Errorf("permission denied")
+	if err := checkCommunityPermission(ctx, s.communityModeratorRepo, communityID, userID, constant.COMMUNITY_PERMISSION_MANAGE_POSTS, "CommunityService.DeletePostByModerator"); err != nil {
+		return err
 	}
 
 	// Get post
@@ -687,12 +825,19 @@ func (s *CommunityService) DeletePostByModerator(ctx context.Context, userID, co
 		return fmt.Errorf("post not found in this community")
 	}
 
+	rule, err := getRemovalRule(ctx, s.communityRuleRepo, communityID, constant.MODERATION_TARGET_POST, ruleID)
+	if err != nil {
+		return err
+	}
+
 	// Delete post
 	if err := s.postRepo.DeletePost(postID); err != nil {
 		logger.ErrorfWithCtx(ctx, "[Err] Error deleting post in CommunityService.DeletePostByModerator: %v", err)
 		return fmt.Errorf("failed to delete post")
 	}
 
+	s.recordRemovalLog(ctx, communityID, userID, constant.MODERATION_ACTION_REMOVE_POST, constant.MODERATION_TARGET_POST, postID, rule, reason)
+
 	// Send notification to post author
 	go func(authorID uint64, postID uint64) {
 		defer func() {
@@ -701,8 +846,12 @@ func (s *CommunityService) DeletePostByModerator(ctx context.Context, userID, co
 			}
 		}()
 
-		notifPayload := map[string]interface{}{
-			"postId": postID,
+		notifPayload := payload.PostDeletedNotificationPayload{
+			PostID: postID,
+		}
+		if rule != nil {
+			notifPayload.RuleID = &rule.ID
+			notifPayload.RuleTitle = rule.Title
 		}
 
 		s.notificationService.CreateNotification(ctx, authorID, constant.NOTIFICATION_ACTION_POST_DELETED, notifPayload)
@@ -711,23 +860,513 @@ func (s *CommunityService) DeletePostByModerator(ctx context.Context, userID, co
 	return nil
 }
 
-func (s *CommunityService) DeleteCommentByModerator(ctx context.Context, userID, communityID, commentID uint64) error {
+func (s *CommunityService) PinPost(ctx context.Context, userID, communityID, postID uint64, req *request.PinPostRequest) error {
+	// Check if community exists
+	community, err := s.communityRepo.GetCommunityByID(communityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Community not found in CommunityService.PinPost: %v", err)
+		return fmt.Errorf("community not found")
+	}
+
+	if err := checkCommunityPermission(ctx, s.communityModeratorRepo, communityID, userID, constant.COMMUNITY_PERMISSION_MANAGE_POSTS, "CommunityService.PinPost"); err != nil {
+		return err
+	}
+
+	// Get post
+	post, err := s.postRepo.GetPostByID(postID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Post not found in CommunityService.PinPost: %v", err)
+		return fmt.Errorf("post not found")
+	}
+
+	// Verify post belongs to this community
+	if post.CommunityID != communityID {
+		logger.ErrorfWithCtx(ctx, "[Err] Post does not belong to community in CommunityService.PinPost: postID=%d, communityID=%d", postID, communityID)
+		return fmt.Errorf("post not found in this community")
+	}
+
+	// Only posts visible in the feed can be pinned
+	if post.Status != constant.POST_STATUS_APPROVED {
+		logger.ErrorfWithCtx(ctx, "[Err] Post is not approved in CommunityService.PinPost: postID=%d, status=%s", postID, post.Status)
+		return fmt.Errorf("only approved posts can be pinned")
+	}
+
+	// The limit is checked in the same transaction as the pin
+	pinned, err := s.postRepo.PinPost(postID, req.PinOrder, req.IsAnnouncement)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error pinning post in CommunityService.PinPost: %v", err)
+		return fmt.Errorf("failed to pin post")
+	}
+	if !pinned {
+		return fmt.Errorf("pinned posts limit reached")
+	}
+
+	// Notify all approved subscribers when the post becomes an announcement
+	if req.IsAnnouncement && !post.IsAnnouncement {
+		go func(communityID uint64, communityName string, postID uint64, postTitle string) {
+			defer func() {
+				if r := recover(); r != nil {
+					logger.ErrorfWithCtx(ctx, "[Panic] Recovered in CommunityService.PinPost notification: %v", r)
+				}
+			}()
+
+			subscriberIDs, err := s.subscriptionRepo.GetApprovedSubscriberIDs(communityID)
+			if err != nil {
+				logger.ErrorfWithCtx(ctx, "[Err] Error getting subscribers in CommunityService.PinPost: %v", err)
+				return
+			}
+
+			notifPayload := payload.CommunityAnnouncementPayload{
+				CommunityID:   communityID,
+				CommunityName: communityName,
+				PostID:        postID,
+				PostTitle:     postTitle,
+			}
+
+			recipientIDs := make([]uint64, 0, len(subscriberIDs))
+			for _, subscriberID := range subscriberIDs {
+				if subscriberID != userID {
+					recipientIDs = append(recipientIDs, subscriberID)
+				}
+			}
+			if err := s.notificationService.CreateNotifications(ctx, recipientIDs, constant.NOTIFICATION_ACTION_COMMUNITY_ANNOUNCEMENT, notifPayload); err != nil {
+				logger.ErrorfWithCtx(ctx, "[Err] Error notifying subscribers in CommunityService.PinPost: %v", err)
+			}
+		}(communityID, community.Name, postID, post.Title)
+	}
+
+	return nil
+}
+
+func (s *CommunityService) UnpinPost(ctx context.Context, userID, communityID, postID uint64) error {
 	// Check if community exists
 	_, err := s.communityRepo.GetCommunityByID(communityID)
 	if err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] Community not found in CommunityService.DeleteCommentByModerator: %v", err)
+		logger.ErrorfWithCtx(ctx, "[Err] Community not found in CommunityService.UnpinPost: %v", err)
 		return fmt.Errorf("community not found")
 	}
 
-	// Check if user has permission (SUPER_ADMIN or ADMIN)
-	role, err := s.communityModeratorRepo.GetModeratorRole(communityID, userID)
-	if err != nil || (role != constant.ROLE_SUPER_ADMIN && role != constant.ROLE_ADMIN) {
-		logger.ErrorfWithCtx(ctx, "[Err] User does not have permission in CommunityService.DeleteCommentByModerator: userID=%d, communityID=%d", userID, communityID)
-		return fmt.Errorf("permission denied")
+	if err := checkCommunityPermission(ctx, s.communityModeratorRepo, communityID, userID, constant.COMMUNITY_PERMISSION_MANAGE_POSTS, "CommunityService.UnpinPost"); err != nil {
+		return err
+	}
+
+	// Get post
+	post, err := s.postRepo.GetPostByID(postID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Post not found in CommunityService.UnpinPost: %v", err)
+		return fmt.Errorf("post not found")
+	}
+
+	// Verify post belongs to this community
+	if post.CommunityID != communityID {
+		logger.ErrorfWithCtx(ctx, "[Err] Post does not belong to community in CommunityService.UnpinPost: postID=%d, communityID=%d", postID, communityID)
+		return fmt.Errorf("post not found in this community")
+	}
+
+	if post.PinOrder == nil {
+		return fmt.Errorf("post is not pinned")
+	}
+
+	if err := s.postRepo.UpdatePostPin(postID, nil, false); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error unpinning post in CommunityService.UnpinPost: %v", err)
+		return fmt.Errorf("failed to unpin post")
+	}
+
+	return nil
+}
+
+func (s *CommunityService) LockPost(ctx context.Context, userID, communityID, postID uint64, req *request.LockContentRequest) error {
+	post, err := s.getPostForModerator(ctx, userID, communityID, postID, "LockPost")
+	if err != nil {
+		return err
+	}
+
+	if post.LockedAt != nil {
+		return fmt.Errorf("post is already locked")
+	}
+
+	now := time.Now()
+	if err := s.postRepo.UpdatePostLock(postID, &now, req.Reason); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error locking post in CommunityService.LockPost: %v", err)
+		return fmt.Errorf("failed to lock post")
+	}
+
+	s.recordModerationLog(ctx, communityID, userID, constant.MODERATION_ACTION_LOCK_POST, constant.MODERATION_TARGET_POST, postID, req.Reason)
+	return nil
+}
+
+func (s *CommunityService) UnlockPost(ctx context.Context, userID, communityID, postID uint64) error {
+	post, err := s.getPostForModerator(ctx, userID, communityID, postID, "UnlockPost")
+	if err != nil {
+		return err
+	}
+
+	if post.LockedAt == nil {
+		return fmt.Errorf("post is not locked")
+	}
+
+	if err := s.postRepo.UpdatePostLock(postID, nil, nil); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error unlocking post in CommunityService.UnlockPost: %v", err)
+		return fmt.Errorf("failed to unlock post")
+	}
+
+	s.recordModerationLog(ctx, communityID, userID, constant.MODERATION_ACTION_UNLOCK_POST, constant.MODERATION_TARGET_POST, postID, nil)
+	return nil
+}
+
+func (s *CommunityService) ClosePoll(ctx context.Context, userID, communityID, postID uint64) error {
+	post, err := s.getPostForModerator(ctx, userID, communityID, postID, "ClosePoll")
+	if err != nil {
+		return err
+	}
+
+	if isUnpublishedPost(post) {
+		return fmt.Errorf("post not found")
+	}
+
+	if err := s.postService.closePoll(ctx, userID, post); err != nil {
+		return err
+	}
+
+	s.recordModerationLog(ctx, communityID, userID, constant.MODERATION_ACTION_CLOSE_POLL, constant.MODERATION_TARGET_POST, postID, nil)
+	return nil
+}
+
+// Locking a comment locks its whole subtree: no replies or votes anywhere below it
+func (s *CommunityService) LockComment(ctx context.Context, userID, communityID, commentID uint64, req *request.LockContentRequest) error {
+	comment, err := s.getCommentForModerator(ctx, userID, communityID, commentID, "LockComment")
+	if err != nil {
+		return err
+	}
+
+	if comment.LockedAt != nil {
+		return fmt.Errorf("comment is already locked")
+	}
+
+	now := time.Now()
+	if err := s.commentRepo.UpdateCommentLock(commentID, &now, req.Reason); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error locking comment in CommunityService.LockComment: %v", err)
+		return fmt.Errorf("failed to lock comment")
+	}
+
+	s.recordModerationLog(ctx, communityID, userID, constant.MODERATION_ACTION_LOCK_COMMENT, constant.MODERATION_TARGET_COMMENT, commentID, req.Reason)
+	return nil
+}
+
+func (s *CommunityService) UnlockComment(ctx context.Context, userID, communityID, commentID uint64) error {
+	comment, err := s.getCommentForModerator(ctx, userID, communityID, commentID, "UnlockComment")
+	if err != nil {
+		return err
+	}
+
+	if comment.LockedAt == nil {
+		return fmt.Errorf("comment is not locked")
+	}
+
+	if err := s.commentRepo.UpdateCommentLock(commentID, nil, nil); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error unlocking comment in CommunityService.UnlockComment: %v", err)
+		return fmt.Errorf("failed to unlock comment")
+	}
+
+	s.recordModerationLog(ctx, communityID, userID, constant.MODERATION_ACTION_UNLOCK_COMMENT, constant.MODERATION_TARGET_COMMENT, commentID, nil)
+	return nil
+}
+
+func (s *CommunityService) GetModerationLogs(ctx context.Context, userID, communityID uint64, page, limit int) ([]*response.ModerationLogResponse, *response.Pagination, error) {
+	// Check if community exists
+	_, err := s.communityRepo.GetCommunityByID(communityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Community not found in CommunityService.GetModerationLogs: %v", err)
+		return nil, nil, fmt.Errorf("community not found")
+	}
+
+	if err := checkCommunityPermission(ctx, s.communityModeratorRepo, communityID, userID, constant.COMMUNITY_PERMISSION_VIEW_REPORTS, "CommunityService.GetModerationLogs"); err != nil {
+		return nil, nil, err
+	}
+
+	logs, total, err := s.moderationLogRepo.GetModerationLogsByCommunityID(communityID, page, limit)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting moderation logs in CommunityService.GetModerationLogs: %v", err)
+		return nil, nil, fmt.Errorf("failed to get moderation logs")
+	}
+
+	logResponses := make([]*response.ModerationLogResponse, len(logs))
+	for i, log := range logs {
+		logResponses[i] = response.NewModerationLogResponse(log)
+	}
+
+	pagination := &response.Pagination{
+		Total: total,
+		Page:  page,
+		Limit: limit,
+	}
+	totalPages := (total + int64(limit) - 1) / int64(limit)
+	if int64(page) < totalPages {
+		pagination.NextURL = fmt.Sprintf("/api/v1/communities/%d/manage/logs?page=%d&limit=%d", communityID, page+1, limit)
+	}
+
+	return logResponses, pagination, nil
+}
+
+// GetMemberModerationLogs is the mod log as the community lets its members see it.
+// Moderators get the same view so they can check what members see.
+func (s *CommunityService) GetMemberModerationLogs(ctx context.Context, userID, communityID uint64, page, limit int) ([]*response.MemberModerationLogResponse, *response.Pagination, error) {
+	// Check if community exists
+	community, err := s.communityRepo.GetCommunityByID(communityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Community not found in CommunityService.GetMemberModerationLogs: %v", err)
+		return nil, nil, fmt.Errorf("community not found")
+	}
+
+	if !isCommunityModerator(s.communityModeratorRepo, communityID, userID) {
+		if !community.ModLogMemberAccess {
+			return nil, nil, fmt.Errorf("moderation log is not public")
+		}
+
+		memberIDs, err := s.subscriptionRepo.FilterApprovedSubscriberIDs(communityID, []uint64{userID})
+		if err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Error checking membership in CommunityService.GetMemberModerationLogs: %v", err)
+			return nil, nil, fmt.Errorf("failed to get moderation logs")
+		}
+		if len(memberIDs) == 0 {
+			logger.ErrorfWithCtx(ctx, "[Err] User is not a member in CommunityService.GetMemberModerationLogs: userID=%d, communityID=%d", userID, communityID)
+			return nil, nil, fmt.Errorf("permission denied")
+		}
+	}
+
+	logs, total, err := s.moderationLogRepo.GetMemberModerationLogs(communityID, constant.MOD_LOG_MEMBER_HIDDEN_ACTIONS, page, limit)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting moderation logs in CommunityService.GetMemberModerationLogs: %v", err)
+		return nil, nil, fmt.Errorf("failed to get moderation logs")
+	}
+
+	logResponses := make([]*response.MemberModerationLogResponse, len(logs))
+	for i, log := range logs {
+		logResponses[i] = response.NewMemberModerationLogResponse(log, community.ModLogShowModerators, community.ModLogShowReasons)
+	}
+
+	pagination := &response.Pagination{
+		Total: total,
+		Page:  page,
+		Limit: limit,
+	}
+	if int64(page*limit) < total {
+		pagination.NextURL = fmt.Sprintf("/api/v1/communities/%d/mod-log?page=%d&limit=%d", communityID, page+1, limit)
+	}
+
+	return logResponses, pagination, nil
+}
+
+func (s *CommunityService) UpdateModLogSettings(ctx context.Context, userID, communityID uint64, req *request.UpdateModLogSettingsRequest) error {
+	// Check if community exists
+	_, err := s.communityRepo.GetCommunityByID(communityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Community not found in CommunityService.UpdateModLogSettings: %v", err)
+		return fmt.Errorf("community not found")
+	}
+
+	if err := checkCommunityPermission(ctx, s.communityModeratorRepo, communityID, userID, constant.COMMUNITY_PERMISSION_EDIT_SETTINGS, "CommunityService.UpdateModLogSettings"); err != nil {
+		return err
+	}
+
+	if err := s.communityRepo.UpdateModLogSettings(communityID, req.MemberAccess, req.ShowModerators, req.ShowReasons); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error updating mod log settings in CommunityService.UpdateModLogSettings: %v", err)
+		return fmt.Errorf("failed to update mod log settings")
+	}
+
+	s.recordSettingsChange(ctx, communityID, userID, fmt.Sprintf("mod log member access: %t, show moderators: %t, show reasons: %t",
+		req.MemberAccess, req.ShowModerators, req.ShowReasons))
+	return nil
+}
+
+// getPostForModerator checks the community, the caller's moderator role and that the
+// post belongs to the community
+func (s *CommunityService) getPostForModerator(ctx context.Context, userID, communityID, postID uint64, method string) (*model.Post, error) {
+	// Check if community exists
+	_, err := s.communityRepo.GetCommunityByID(communityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Community not found in CommunityService.%s: %v", method, err)
+		return nil, fmt.Errorf("community not found")
+	}
+
+	if err := checkCommunityPermission(ctx, s.communityModeratorRepo, communityID, userID, constant.COMMUNITY_PERMISSION_MANAGE_POSTS, "CommunityService."+method); err != nil {
+		return nil, err
+	}
+
+	post, err := s.postRepo.GetPostByID(postID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Post not found in CommunityService.%s: %v", method, err)
+		return nil, fmt.Errorf("post not found")
+	}
+
+	if post.CommunityID != communityID {
+		logger.ErrorfWithCtx(ctx, "[Err] Post does not belong to community in CommunityService.%s: postID=%d, communityID=%d", method, postID, communityID)
+		return nil, fmt.Errorf("post not found in this community")
+	}
+
+	return post, nil
+}
+
+// getCommentForModerator is getPostForModerator for comments, using the comment's post
+// to resolve its community
+func (s *CommunityService) getCommentForModerator(ctx context.Context, userID, communityID, commentID uint64, method string) (*model.Comment, error) {
+	// Check if community exists
+	_, err := s.communityRepo.GetCommunityByID(communityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Community not found in CommunityService.%s: %v", method, err)
+		return nil, fmt.Errorf("community not found")
+	}
+
+	if err := checkCommunityPermission(ctx, s.communityModeratorRepo, communityID, userID, constant.COMMUNITY_PERMISSION_MANAGE_COMMENTS, "CommunityService."+method); err != nil {
+		return nil, err
 	}
 
 	comment, err := s.commentRepo.GetCommentByID(commentID)
 	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Comment not found in CommunityService.%s: %v", method, err)
+		return nil, fmt.Errorf("comment not found")
+	}
+
+	post, err := s.postRepo.GetPostByID(comment.PostID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Post not found in CommunityService.%s: %v", method, err)
+		return nil, fmt.Errorf("post not found")
+	}
+
+	if post.CommunityID != communityID {
+		logger.ErrorfWithCtx(ctx, "[Err] Comment does not belong to community in CommunityService.%s: commentID=%d, communityID=%d", method, commentID, communityID)
+		return nil, fmt.Errorf("comment not found in this community")
+	}
+
+	return comment, nil
+}
+
+func (s *CommunityService) recordModerationLog(ctx context.Context, communityID, actorID uint64, action, targetType string, targetID uint64, reason *string) {
+	s.createModerationLog(ctx, &model.ModerationLog{
+		CommunityID: communityID,
+		ActorID:     actorID,
+		Action:      action,
+		TargetType:  targetType,
+		TargetID:    targetID,
+		Reason:      reason,
+		CreatedAt:   time.Now(),
+	})
+}
+
+// recordRemovalLog records a removal with the community rule it cited, if any. Without
+// a reason of its own the removal gives the title of the rule.
+func (s *CommunityService) recordRemovalLog(ctx context.Context, communityID, actorID uint64, action, targetType string, targetID uint64, rule *model.CommunityRule, reason *string) {
+	moderationLog := &model.ModerationLog{
+		CommunityID: communityID,
+		ActorID:     actorID,
+		Action:      action,
+		TargetType:  targetType,
+		TargetID:    targetID,
+		Reason:      reason,
+		CreatedAt:   time.Now(),
+	}
+	if rule != nil {
+		moderationLog.RuleID = &rule.ID
+		if reason == nil || *reason == "" {
+			moderationLog.Reason = &rule.Title
+		}
+	}
+	s.createModerationLog(ctx, moderationLog)
+}
+
+func (s *CommunityService) recordSettingsChange(ctx context.Context, communityID, actorID uint64, details string) {
+	s.createModerationLog(ctx, &model.ModerationLog{
+		CommunityID: communityID,
+		ActorID:     actorID,
+		Action:      constant.MODERATION_ACTION_UPDATE_SETTINGS,
+		TargetType:  constant.MODERATION_TARGET_COMMUNITY,
+		TargetID:    communityID,
+		Details:     &details,
+		CreatedAt:   time.Now(),
+	})
+}
+
+// cancelInviterInvitations withdraws the pending invitations of a moderator who lost the
+// role. Failing here is only logged, accepting an invitation checks the inviter again.
+func (s *CommunityService) cancelInviterInvitations(ctx context.Context, communityID, inviterID uint64) {
+	if _, err := s.invitationRepo.CancelInviterInvitations(communityID, inviterID, time.Now()); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error cancelling invitations of user %d in community %d: %v", inviterID, communityID, err)
+	}
+}
+
+// createModerationLog never fails the moderator action, a missing log entry is only logged
+func (s *CommunityService) createModerationLog(ctx context.Context, moderationLog *model.ModerationLog) {
+	if err := s.moderationLogRepo.CreateModerationLog(moderationLog); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error recording moderation log for %s on %s %d: %v", moderationLog.Action, moderationLog.TargetType, moderationLog.TargetID, err)
+	}
+}
+
+func (s *CommunityService) GetPostRevisionsForModerator(ctx context.Context, userID, communityID, postID uint64) ([]*response.PostRevisionResponse, error) {
+	if err := checkCommunityPermission(ctx, s.communityModeratorRepo, communityID, userID, constant.COMMUNITY_PERMISSION_MANAGE_POSTS, "CommunityService.GetPostRevisionsForModerator"); err != nil {
+		return nil, err
+	}
+
+	post, err := s.postRepo.GetPostByID(postID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Post not found in CommunityService.GetPostRevisionsForModerator: %v", err)
+		return nil, fmt.Errorf("post not found")
+	}
+
+	if post.CommunityID != communityID {
+		logger.ErrorfWithCtx(ctx, "[Err] Post does not belong to community in CommunityService.GetPostRevisionsForModerator: postID=%d, communityID=%d", postID, communityID)
+		return nil, fmt.Errorf("post not found in this community")
+	}
+
+	revisions, err := s.postRevisionRepo.GetPostRevisionsByPostID(postID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting post revisions in CommunityService.GetPostRevisionsForModerator: %v", err)
+		return nil, fmt.Errorf("failed to get revisions")
+	}
+
+	return response.NewPostRevisionResponses(revisions, post), nil
+}
+
+func (s *CommunityService) GetCommentRevisionsForModerator(ctx context.Context, userID, communityID, commentID uint64) ([]*response.CommentRevisionResponse, error) {
+	if err := checkCommunityPermission(ctx, s.communityModeratorRepo, communityID, userID, constant.COMMUNITY_PERMISSION_MANAGE_COMMENTS, "CommunityService.GetCommentRevisionsForModerator"); err != nil {
+		return nil, err
+	}
+
+	comment, err := s.commentRepo.GetCommentByID(commentID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Comment not found in CommunityService.GetCommentRevisionsForModerator: %v", err)
+		return nil, fmt.Errorf("comment not found")
+	}
+
+	post, err := s.postRepo.GetPostByID(comment.PostID)
+	if err != nil || post.CommunityID != communityID {
+		logger.ErrorfWithCtx(ctx, "[Err] Comment does not belong to community in CommunityService.GetCommentRevisionsForModerator: commentID=%d, communityID=%d", commentID, communityID)
+		return nil, fmt.Errorf("comment not found in this community")
+	}
+
+	revisions, err := s.commentRevisionRepo.GetCommentRevisionsByCommentID(commentID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting comment revisions in CommunityService.GetCommentRevisionsForModerator: %v", err)
+		return nil, fmt.Errorf("failed to get revisions")
+	}
+
+	return response.NewCommentRevisionResponses(revisions, comment), nil
+}
+
+// DeleteCommentByModerator removes a comment, ruleID is the community rule it broke when given
+func (s *CommunityService) DeleteCommentByModerator(ctx context.Context, userID, communityID, commentID uint64, ruleID *uint64, reason *string) error {
+	// Check if community exists
+	_, err := s.communityRepo.GetCommunityByID(communityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Community not found in CommunityService.DeleteCommentByModerator: %v", err)
+		return fmt.Errorf("community not found")
+	}
+
+	if err := checkCommunityPermission(ctx, s.communityModeratorRepo, communityID, userID, constant.COMMUNITY_PERMISSION_MANAGE_COMMENTS, "CommunityService.DeleteCommentByModerator"); err != nil {
+		return err
+	}
+
+	comment, err := s.commentRepo.GetCommentByID(commentID)
+	if err != nil || comment.DeletedAt != nil {
 		logger.ErrorfWithCtx(ctx, "[Err] Comment not found in CommunityService.DeleteCommentByModerator: %v", err)
 		return fmt.Errorf("comment not found")
 	}
@@ -744,11 +1383,18 @@ func (s *CommunityService) DeleteCommentByModerator(ctx context.Context, userID,
 		return fmt.Errorf("comment not found in this community")
 	}
 
-	if err := s.commentRepo.DeleteComment(commentID, comment.ParentCommentID); err != nil {
+	rule, err := getRemovalRule(ctx, s.communityRuleRepo, communityID, constant.MODERATION_TARGET_COMMENT, ruleID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.commentRepo.DeleteComment(commentID, constant.COMMENT_DELETED_BY_MODERATOR); err != nil {
 		logger.ErrorfWithCtx(ctx, "[Err] Error deleting comment in CommunityService.DeleteCommentByModerator: %v", err)
 		return fmt.Errorf("failed to delete comment")
 	}
 
+	s.recordRemovalLog(ctx, communityID, userID, constant.MODERATION_ACTION_REMOVE_COMMENT, constant.MODERATION_TARGET_COMMENT, commentID, rule, reason)
+
 	// Send notification to comment author
 	go func(authorID uint64, commentID uint64, postID uint64) {
 		defer func() {
@@ -761,6 +1407,10 @@ func (s *CommunityService) DeleteCommentByModerator(ctx context.Context, userID,
 			CommentID: commentID,
 			PostID:    postID,
 		}
+		if rule != nil {
+			notifPayload.RuleID = &rule.ID
+			notifPayload.RuleTitle = rule.Title
+		}
 
 		s.notificationService.CreateNotification(ctx, authorID, constant.NOTIFICATION_ACTION_COMMENT_DELETED, notifPayload)
 	}(comment.AuthorID, commentID, comment.PostID)
@@ -776,11 +1426,8 @@ func (s *CommunityService) GetCommunityPostReports(ctx context.Context, userID,
 		return nil, nil, fmt.Errorf("community not found")
 	}
 
-	// Check if user has permission (SUPER_ADMIN or ADMIN)
-	role, err := s.communityModeratorRepo.GetModeratorRole(communityID, userID)
-	if err != nil || (role != constant.ROLE_SUPER_ADMIN && role != constant.ROLE_ADMIN) {
-		logger.ErrorfWithCtx(ctx, "[Err] User does not have permission in CommunityService.GetCommunityPostReports: userID=%d, communityID=%d", userID, communityID)
-		return nil, nil, fmt.Errorf("permission denied")
+	if err := checkCommunityPermission(ctx, s.communityModeratorRepo, communityID, userID, constant.COMMUNITY_PERMISSION_VIEW_REPORTS, "CommunityService.GetCommunityPostReports"); err != nil {
+		return nil, nil, err
 	}
 
 	reports, total, err := s.postReportRepo.GetPostReportsByCommunityID(communityID, page, limit)
@@ -808,7 +1455,7 @@ func (s *CommunityService) GetCommunityPostReports(ctx context.Context, userID,
 			reportMap[report.PostID].ID = report.ID
 		}
 
-		reporterInfo := response.NewReporterInfo(report.Reporter, report.Reasons, report.Note)
+		reporterInfo := response.NewReporterInfo(report.Reporter, report.Reasons, report.RuleIDs, report.Note)
 		reportMap[report.PostID].Reporters = append(reportMap[report.PostID].Reporters, reporterInfo)
 		reportMap[report.PostID].TotalReports++
 	}
@@ -832,27 +1479,71 @@ func (s *CommunityService) GetCommunityPostReports(ctx context.Context, userID,
 	return reportResponses, pagination, nil
 }
 
-func (s *CommunityService) DeletePostReport(ctx context.Context, userID, communityID, reportID uint64) error {
+// GetCommunityDuplicatePosts lists posts of the community that repeat recent posts,
+// each next to the post it matched
+func (s *CommunityService) GetCommunityDuplicatePosts(ctx context.Context, userID, communityID uint64, page, limit int) ([]*response.DuplicatePostResponse, *response.Pagination, error) {
+	// Check if community exists
+	_, err := s.communityRepo.GetCommunityByID(communityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Community not found in CommunityService.GetCommunityDuplicatePosts: %v", err)
+		return nil, nil, fmt.Errorf("community not found")
+	}
+
+	if err := checkCommunityPermission(ctx, s.communityModeratorRepo, communityID, userID, constant.COMMUNITY_PERMISSION_MANAGE_POSTS, "CommunityService.GetCommunityDuplicatePosts"); err != nil {
+		return nil, nil, err
+	}
+
+	matches, total, err := s.postFingerprintRepo.GetDuplicateMatchesByCommunityID(communityID, page, limit)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting duplicate posts in CommunityService.GetCommunityDuplicatePosts: %v", err)
+		return nil, nil, fmt.Errorf("failed to get duplicate posts")
+	}
+
+	duplicateResponses := make([]*response.DuplicatePostResponse, len(matches))
+	for i, match := range matches {
+		duplicateResponses[i] = response.NewDuplicatePostResponse(match)
+	}
+
+	pagination := &response.Pagination{
+		Total: total,
+		Page:  page,
+		Limit: limit,
+	}
+	if int64(page*limit) < total {
+		pagination.NextURL = fmt.Sprintf("/api/v1/communities/%d/manage/duplicates?page=%d&limit=%d", communityID, page+1, limit)
+	}
+
+	return duplicateResponses, pagination, nil
+}
+
+func (s *CommunityService) DismissPostReport(ctx context.Context, userID, communityID, reportID uint64) error {
 	// Check if community exists
 	_, err := s.communityRepo.GetCommunityByID(communityID)
 	if err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] Community not found in CommunityService.DeletePostReport: %v", err)
+		logger.ErrorfWithCtx(ctx, "[Err] Community not found in CommunityService.DismissPostReport: %v", err)
 		return fmt.Errorf("community not found")
 	}
 
-	// Check if user has permission (SUPER_ADMIN or ADMIN)
-	role, err := s.communityModeratorRepo.GetModeratorRole(communityID, userID)
-	if err != nil || (role != constant.ROLE_SUPER_ADMIN && role != constant.ROLE_ADMIN) {
-		logger.ErrorfWithCtx(ctx, "[Err] User does not have permission in CommunityService.DeletePostReport: userID=%d, communityID=%d", userID, communityID)
-		return fmt.Errorf("permission denied")
+	if err := checkCommunityPermission(ctx, s.communityModeratorRepo, communityID, userID, constant.COMMUNITY_PERMISSION_VIEW_REPORTS, "CommunityService.DismissPostReport"); err != nil {
+		return err
 	}
 
-	// Delete report
-	if err := s.postReportRepo.DeletePostReport(reportID); err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] Error deleting post report in CommunityService.DeletePostReport: %v", err)
-		return fmt.Errorf("failed to delete post report")
+	report, err := s.postReportRepo.GetPostReportByID(reportID)
+	if err != nil || report.Post == nil || report.Post.CommunityID != communityID {
+		logger.ErrorfWithCtx(ctx, "[Err] Post report not found in CommunityService.DismissPostReport: reportID=%d, communityID=%d", reportID, communityID)
+		return fmt.Errorf("report not found")
+	}
+	if report.ResolvedAt != nil {
+		return fmt.Errorf("report already resolved")
 	}
 
+	// Dismiss every open report of the post, they are kept for the reporters' history
+	if err := s.postReportRepo.DismissPostReports(report.PostID, userID); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error dismissing post reports in CommunityService.DismissPostReport: %v", err)
+		return fmt.Errorf("failed to dismiss post report")
+	}
+
+	s.recordModerationLog(ctx, communityID, userID, constant.MODERATION_ACTION_DISMISS_REPORTS, constant.MODERATION_TARGET_POST, report.PostID, nil)
 	return nil
 }
 
@@ -864,11 +1555,8 @@ func (s *CommunityService) GetCommunityCommentReports(ctx context.Context, userI
 		return nil, nil, fmt.Errorf("community not found")
 	}
 
-	// Check if user has permission (SUPER_ADMIN or ADMIN)
-	role, err := s.communityModeratorRepo.GetModeratorRole(communityID, userID)
-	if err != nil || (role != constant.ROLE_SUPER_ADMIN && role != constant.ROLE_ADMIN) {
-		logger.ErrorfWithCtx(ctx, "[Err] User does not have permission in CommunityService.GetCommunityCommentReports: userID=%d, communityID=%d", userID, communityID)
-		return nil, nil, fmt.Errorf("permission denied")
+	if err := checkCommunityPermission(ctx, s.communityModeratorRepo, communityID, userID, constant.COMMUNITY_PERMISSION_VIEW_REPORTS, "CommunityService.GetCommunityCommentReports"); err != nil {
+		return nil, nil, err
 	}
 
 	reports, total, err := s.commentReportRepo.GetCommentReportsByCommunityID(communityID, page, limit)
@@ -899,7 +1587,7 @@ func (s *CommunityService) GetCommunityCommentReports(ctx context.Context, userI
 			reportMap[report.CommentID].ID = report.ID
 		}
 
-		reporterInfo := response.NewReporterInfo(report.Reporter, report.Reasons, report.Note)
+		reporterInfo := response.NewReporterInfo(report.Reporter, report.Reasons, report.RuleIDs, report.Note)
 		reportMap[report.CommentID].Reporters = append(reportMap[report.CommentID].Reporters, reporterInfo)
 		reportMap[report.CommentID].TotalReports++
 	}
@@ -923,27 +1611,34 @@ func (s *CommunityService) GetCommunityCommentReports(ctx context.Context, userI
 	return reportResponses, pagination, nil
 }
 
-func (s *CommunityService) DeleteCommentReport(ctx context.Context, userID, communityID, reportID uint64) error {
+func (s *CommunityService) DismissCommentReport(ctx context.Context, userID, communityID, reportID uint64) error {
 	// Check if community exists
 	_, err := s.communityRepo.GetCommunityByID(communityID)
 	if err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] Community not found in CommunityService.DeleteCommentReport: %v", err)
+		logger.ErrorfWithCtx(ctx, "[Err] Community not found in CommunityService.DismissCommentReport: %v", err)
 		return fmt.Errorf("community not found")
 	}
 
-	// Check if user has permission (SUPER_ADMIN or ADMIN)
-	role, err := s.communityModeratorRepo.GetModeratorRole(communityID, userID)
-	if err != nil || (role != constant.ROLE_SUPER_ADMIN && role != constant.ROLE_ADMIN) {
-		logger.ErrorfWithCtx(ctx, "[Err] User does not have permission in CommunityService.DeleteCommentReport: userID=%d, communityID=%d", userID, communityID)
-		return fmt.Errorf("permission denied")
+	if err := checkCommunityPermission(ctx, s.communityModeratorRepo, communityID, userID, constant.COMMUNITY_PERMISSION_VIEW_REPORTS, "CommunityService.DismissCommentReport"); err != nil {
+		return err
+	}
+
+	report, err := s.commentReportRepo.GetCommentReportByID(reportID)
+	if err != nil || report.Comment == nil || report.Comment.Post == nil || report.Comment.Post.CommunityID != communityID {
+		logger.ErrorfWithCtx(ctx, "[Err] Comment report not found in CommunityService.DismissCommentReport: reportID=%d, communityID=%d", reportID, communityID)
+		return fmt.Errorf("report not found")
+	}
+	if report.ResolvedAt != nil {
+		return fmt.Errorf("report already resolved")
 	}
 
-	// Delete report
-	if err := s.commentReportRepo.DeleteCommentReport(reportID); err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] Error deleting comment report in CommunityService.DeleteCommentReport: %v", err)
-		return fmt.Errorf("failed to delete comment report")
+	// Dismiss every open report of the comment, they are kept for the reporters' history
+	if err := s.commentReportRepo.DismissCommentReports(report.CommentID, userID); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error dismissing comment reports in CommunityService.DismissCommentReport: %v", err)
+		return fmt.Errorf("failed to dismiss comment report")
 	}
 
+	s.recordModerationLog(ctx, communityID, userID, constant.MODERATION_ACTION_DISMISS_REPORTS, constant.MODERATION_TARGET_COMMENT, report.CommentID, nil)
 	return nil
 }
 
@@ -973,11 +1668,8 @@ func (s *CommunityService) UpdateRequiresPostApproval(ctx context.Context, userI
 		return fmt.Errorf("community not found")
 	}
 
-	// Check if user has permission (must be SUPER_ADMIN)
-	role, err := s.communityModeratorRepo.GetModeratorRole(communityID, userID)
-	if err != nil || role != constant.ROLE_SUPER_ADMIN {
-		logger.ErrorfWithCtx(ctx, "[Err] User does not have permission in CommunityService.UpdateRequiresPostApproval: userID=%d, communityID=%d", userID, communityID)
-		return fmt.Errorf("permission denied")
+	if err := checkCommunityPermission(ctx, s.communityModeratorRepo, communityID, userID, constant.COMMUNITY_PERMISSION_EDIT_SETTINGS, "CommunityService.UpdateRequiresPostApproval"); err != nil {
+		return err
 	}
 
 	// Update requires post approval
@@ -986,6 +1678,7 @@ func (s *CommunityService) UpdateRequiresPostApproval(ctx context.Context, userI
 		return fmt.Errorf("failed to update requires post approval")
 	}
 
+	s.recordSettingsChange(ctx, communityID, userID, fmt.Sprintf("requires post approval: %t", req.RequiresPostApproval))
 	return nil
 }
 
@@ -997,11 +1690,8 @@ func (s *CommunityService) UpdateRequiresMemberApproval(ctx context.Context, use
 		return fmt.Errorf("community not found")
 	}
 
-	// Check if user has permission (must be SUPER_ADMIN)
-	role, err := s.communityModeratorRepo.GetModeratorRole(communityID, userID)
-	if err != nil || role != constant.ROLE_SUPER_ADMIN {
-		logger.ErrorfWithCtx(ctx, "[Err] User does not have permission in CommunityService.UpdateRequiresMemberApproval: userID=%d, communityID=%d", userID, communityID)
-		return fmt.Errorf("permission denied")
+	if err := checkCommunityPermission(ctx, s.communityModeratorRepo, communityID, userID, constant.COMMUNITY_PERMISSION_EDIT_SETTINGS, "CommunityService.UpdateRequiresMemberApproval"); err != nil {
+		return err
 	}
 
 	// Update requires member approval
@@ -1010,10 +1700,53 @@ func (s *CommunityService) UpdateRequiresMemberApproval(ctx context.Context, use
 		return fmt.Errorf("failed to update requires member approval")
 	}
 
+	s.recordSettingsChange(ctx, communityID, userID, fmt.Sprintf("requires member approval: %t", req.RequiresMemberApproval))
 	return nil
 }
 
-func (s *CommunityService) UpdateSubscriptionStatus(ctx context.Context, moderatorUserID, communityID, targetUserID uint64, status string) error {
+// UpdateReactionEmojis replaces the reaction emojis of the community. An empty list
+// restores the platform-wide set.
+func (s *CommunityService) UpdateReactionEmojis(ctx context.Context, userID, communityID uint64, req *request.UpdateReactionEmojisRequest) error {
+	// Check if community exists
+	_, err := s.communityRepo.GetCommunityByID(communityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Community not found in CommunityService.UpdateReactionEmojis: %v", err)
+		return fmt.Errorf("community not found")
+	}
+
+	if err := checkCommunityPermission(ctx, s.communityModeratorRepo, communityID, userID, constant.COMMUNITY_PERMISSION_EDIT_SETTINGS, "CommunityService.UpdateReactionEmojis"); err != nil {
+		return err
+	}
+
+	if len(req.Emojis) > constant.MAX_COMMUNITY_REACTION_EMOJIS {
+		return fmt.Errorf("too many emojis")
+	}
+
+	emojis := make([]string, 0, len(req.Emojis))
+	for _, emoji := range req.Emojis {
+		if !util.IsReactionEmoji(emoji, constant.MAX_REACTION_EMOJI_BYTES) {
+			logger.ErrorfWithCtx(ctx, "[Err] Invalid emoji in CommunityService.UpdateReactionEmojis: %q", emoji)
+			return fmt.Errorf("invalid emoji")
+		}
+		if !slices.Contains(emojis, emoji) {
+			emojis = append(emojis, emoji)
+		}
+	}
+
+	if err := s.communityRepo.UpdateReactionEmojis(communityID, emojis); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error updating reaction emojis in CommunityService.UpdateReactionEmojis: %v", err)
+		return fmt.Errorf("failed to update reaction emojis")
+	}
+
+	details := "reaction emojis: default"
+	if len(emojis) > 0 {
+		details = "reaction emojis: " + strings.Join(emojis, " ")
+	}
+	s.recordSettingsChange(ctx, communityID, userID, details)
+	return nil
+}
+
+func (s *CommunityService) UpdateSubscriptionStatus(ctx context.Context, moderatorUserID, communityID, targetUserID uint64, status string, reason *string) error {
 	// Check if community exists
 	community, err := s.communityRepo.GetCommunityByID(communityID)
 	if err != nil {
@@ -1021,11 +1754,8 @@ func (s *CommunityService) UpdateSubscriptionStatus(ctx context.Context, moderat
 		return fmt.Errorf("community not found")
 	}
 
-	// Check if moderator has permission (SUPER_ADMIN or ADMIN)
-	role, err := s.communityModeratorRepo.GetModeratorRole(communityID, moderatorUserID)
-	if err != nil || (role != constant.ROLE_SUPER_ADMIN && role != constant.ROLE_ADMIN) {
-		logger.ErrorfWithCtx(ctx, "[Err] User does not have permission in CommunityService.UpdateSubscriptionStatus: userID=%d, communityID=%d", moderatorUserID, communityID)
-		return fmt.Errorf("permission denied")
+	if err := checkCommunityPermission(ctx, s.communityModeratorRepo, communityID, moderatorUserID, constant.COMMUNITY_PERMISSION_MANAGE_MEMBERS, "CommunityService.UpdateSubscriptionStatus"); err != nil {
+		return err
 	}
 
 	// Check if subscription exists
@@ -1087,8 +1817,12 @@ func (s *CommunityService) UpdateSubscriptionStatus(ctx context.Context, moderat
 		return fmt.Errorf("invalid status")
 	}
 
+	action := constant.MODERATION_ACTION_APPROVE_MEMBER
+	if status == constant.SUBSCRIPTION_STATUS_REJECTED {
+		action = constant.MODERATION_ACTION_REJECT_MEMBER
+	}
+	s.recordModerationLog(ctx, communityID, moderatorUserID, action, constant.MODERATION_TARGET_USER, targetUserID, reason)
 	return nil
-
 }
 
 func (s *CommunityService) BanUser(ctx context.Context, moderatorID, communityID uint64, req *request.BanUserRequest) error {
@@ -1099,13 +1833,27 @@ func (s *CommunityService) BanUser(ctx context.Context, moderatorID, communityID
 		return fmt.Errorf("community not found")
 	}
 
-	// Check if moderator has permission (SUPER_ADMIN or ADMIN)
-	role, err := s.communityModeratorRepo.GetModeratorRole(communityID, moderatorID)
-	if err != nil || (role != constant.ROLE_SUPER_ADMIN && role != constant.ROLE_ADMIN) {
-		logger.ErrorfWithCtx(ctx, "[Err] User does not have permission in CommunityService.BanUser: moderatorID=%d, communityID=%d", moderatorID, communityID)
+	callerAccess, err := getCommunityAccess(s.communityModeratorRepo, communityID, moderatorID)
+	if err != nil || !callerAccess.can(constant.COMMUNITY_PERMISSION_BAN_USERS) {
+		logger.ErrorfWithCtx(ctx, "[Err] User does not have permission in CommunityService.BanUser: userID=%d, communityID=%d", moderatorID, communityID)
 		return fmt.Errorf("permission denied")
 	}
 
+	if req.UserID == moderatorID {
+		return fmt.Errorf("cannot ban yourself")
+	}
+	targetAccess, err := getCommunityAccess(s.communityModeratorRepo, communityID, req.UserID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting user role in CommunityService.BanUser: %v", err)
+		return fmt.Errorf("failed to ban user")
+	}
+	if targetAccess.Role == constant.ROLE_SUPER_ADMIN {
+		return fmt.Errorf("cannot ban the super admin")
+	}
+	if !hasAllPermissions(callerAccess, targetAccess.Permissions) {
+		return fmt.Errorf("role exceeds your permissions")
+	}
+
 	// Validate restriction type and expiry date
 	if req.RestrictionType == constant.RESTRICTION_TEMPORARY_BAN {
 		if req.ExpiresAt == nil {
@@ -1138,6 +1886,25 @@ func (s *CommunityService) BanUser(ctx context.Context, moderatorID, communityID
 		return fmt.Errorf("failed to ban user")
 	}
 
+	action := constant.MODERATION_ACTION_BAN_USER
+	if req.RestrictionType == constant.RESTRICTION_WARNING {
+		action = constant.MODERATION_ACTION_WARN_USER
+	}
+	details := req.RestrictionType
+	if req.ExpiresAt != nil {
+		details += " until " + req.ExpiresAt.Format(time.RFC3339)
+	}
+	s.createModerationLog(ctx, &model.ModerationLog{
+		CommunityID: communityID,
+		ActorID:     moderatorID,
+		Action:      action,
+		TargetType:  constant.MODERATION_TARGET_USER,
+		TargetID:    req.UserID,
+		Reason:      &req.Reason,
+		Details:     &details,
+		CreatedAt:   restriction.CreatedAt,
+	})
+
 	// Get community name for notification
 	community, err := s.communityRepo.GetCommunityByID(communityID)
 	if err != nil {
@@ -1175,11 +1942,8 @@ func (s *CommunityService) GetUserRestrictionHistory(ctx context.Context, modera
 		return nil, nil, fmt.Errorf("community not found")
 	}
 
-	// Check if moderator has permission (SUPER_ADMIN or ADMIN)
-	role, err := s.communityModeratorRepo.GetModeratorRole(communityID, moderatorID)
-	if err != nil || (role != constant.ROLE_SUPER_ADMIN && role != constant.ROLE_ADMIN) {
-		logger.ErrorfWithCtx(ctx, "[Err] User does not have permission in CommunityService.GetUserRestrictionHistory: moderatorID=%d, communityID=%d", moderatorID, communityID)
-		return nil, nil, fmt.Errorf("permission denied")
+	if err := checkCommunityPermission(ctx, s.communityModeratorRepo, communityID, moderatorID, constant.COMMUNITY_PERMISSION_BAN_USERS, "CommunityService.GetUserRestrictionHistory"); err != nil {
+		return nil, nil, err
 	}
 
 	// Get restriction history
@@ -1218,11 +1982,14 @@ func (s *CommunityService) RemoveUserRestriction(ctx context.Context, moderatorI
 		return fmt.Errorf("community not found")
 	}
 
-	// Check if moderator has permission (SUPER_ADMIN or ADMIN)
-	role, err := s.communityModeratorRepo.GetModeratorRole(communityID, moderatorID)
-	if err != nil || (role != constant.ROLE_SUPER_ADMIN && role != constant.ROLE_ADMIN) {
-		logger.ErrorfWithCtx(ctx, "[Err] User does not have permission in CommunityService.RemoveUserRestriction: moderatorID=%d, communityID=%d", moderatorID, communityID)
-		return fmt.Errorf("permission denied")
+	if err := checkCommunityPermission(ctx, s.communityModeratorRepo, communityID, moderatorID, constant.COMMUNITY_PERMISSION_BAN_USERS, "CommunityService.RemoveUserRestriction"); err != nil {
+		return err
+	}
+
+	restriction, err := s.userRestrictionRepo.GetRestrictionByID(restrictionID)
+	if err != nil || restriction.CommunityID != communityID {
+		logger.ErrorfWithCtx(ctx, "[Err] Restriction not found in CommunityService.RemoveUserRestriction: restrictionID=%d, communityID=%d", restrictionID, communityID)
+		return fmt.Errorf("restriction not found")
 	}
 
 	// Delete restriction
@@ -1231,5 +1998,15 @@ func (s *CommunityService) RemoveUserRestriction(ctx context.Context, moderatorI
 		return fmt.Errorf("failed to remove restriction")
 	}
 
+	details := restriction.RestrictionType
+	s.createModerationLog(ctx, &model.ModerationLog{
+		CommunityID: communityID,
+		ActorID:     moderatorID,
+		Action:      constant.MODERATION_ACTION_REMOVE_RESTRICTION,
+		TargetType:  constant.MODERATION_TARGET_USER,
+		TargetID:    restriction.UserID,
+		Details:     &details,
+		CreatedAt:   time.Now(),
+	})
 	return nil
 }
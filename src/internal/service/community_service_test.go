@@ -7,6 +7,7 @@ import (
 
 	"social-platform-backend/internal/domain/model"
 	"social-platform-backend/internal/interface/dto/request"
+	"social-platform-backend/package/constant"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -20,7 +21,10 @@ func TestCommunityService_CreateCommunity_Success(t *testing.T) {
 		mockCommunityRepo,
 		nil,
 		mockCommunityModeratorRepo,
-		nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	userID := uint64(123)
@@ -45,7 +49,10 @@ func TestCommunityService_CreateCommunity_RepositoryError(t *testing.T) {
 
 	communityService := NewCommunityService(
 		mockCommunityRepo,
-		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	desc := "Test"
@@ -73,7 +80,10 @@ func TestCommunityService_GetCommunityByID_Success(t *testing.T) {
 		nil, // subscriptionRepo
 		mockCommunityModeratorRepo,
 		mockPostRepo,
-		nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	communityID := uint64(456)
@@ -103,7 +113,10 @@ func TestCommunityService_GetCommunityByID_NotFound(t *testing.T) {
 
 	communityService := NewCommunityService(
 		mockCommunityRepo,
-		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	communityID := uint64(999)
@@ -128,7 +141,10 @@ func TestCommunityService_GetCommunityByID_WithModerators(t *testing.T) {
 		nil, // subscriptionRepo
 		mockCommunityModeratorRepo,
 		mockPostRepo,
-		nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	communityID := uint64(456)
@@ -169,12 +185,18 @@ func TestCommunityService_GetCommunityByID_WithModerators(t *testing.T) {
 func TestCommunityService_UpdateCommunity_Success(t *testing.T) {
 	mockCommunityRepo := new(MockCommunityRepository)
 	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockModerationLogRepo := new(MockModerationLogRepository)
 
 	communityService := NewCommunityService(
 		mockCommunityRepo,
 		nil,
 		mockCommunityModeratorRepo,
-		nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		mockModerationLogRepo,
+		nil, nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	userID := uint64(123)
@@ -190,10 +212,15 @@ func TestCommunityService_UpdateCommunity_Success(t *testing.T) {
 	mockCommunityModeratorRepo.On("GetModeratorRole", communityID, userID).Return("super_admin", nil)
 	mockCommunityRepo.On("UpdateCommunity", communityID, req).Return(nil)
 
+	mockModerationLogRepo.On("CreateModerationLog", mock.MatchedBy(func(log *model.ModerationLog) bool {
+		return log.Action == constant.MODERATION_ACTION_UPDATE_SETTINGS && *log.Details == "updated description"
+	})).Return(nil)
+
 	err := communityService.UpdateCommunity(context.Background(), userID, communityID, req)
 
 	assert.NoError(t, err)
 	mockCommunityRepo.AssertExpectations(t)
+	mockModerationLogRepo.AssertExpectations(t)
 	mockCommunityModeratorRepo.AssertExpectations(t)
 }
 
@@ -205,7 +232,10 @@ func TestCommunityService_UpdateCommunity_NotSuperAdmin(t *testing.T) {
 		mockCommunityRepo,
 		nil,
 		mockCommunityModeratorRepo,
-		nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	userID := uint64(123)
@@ -219,6 +249,9 @@ func TestCommunityService_UpdateCommunity_NotSuperAdmin(t *testing.T) {
 
 	mockCommunityRepo.On("GetCommunityByID", communityID).Return(community, nil)
 	mockCommunityModeratorRepo.On("GetModeratorRole", communityID, userID).Return("moderator", nil)
+	mockCommunityModeratorRepo.On("GetModeratorCustomRole", communityID, userID).Return(&model.CommunityRole{
+		Permissions: []string{constant.COMMUNITY_PERMISSION_MANAGE_POSTS},
+	}, nil)
 
 	err := communityService.UpdateCommunity(context.Background(), userID, communityID, req)
 
@@ -236,7 +269,10 @@ func TestCommunityService_UpdateCommunity_NotModerator(t *testing.T) {
 		mockCommunityRepo,
 		nil,
 		mockCommunityModeratorRepo,
-		nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	userID := uint64(123)
@@ -267,7 +303,10 @@ func TestCommunityService_DeleteCommunity_Success(t *testing.T) {
 		mockCommunityRepo,
 		nil,
 		mockCommunityModeratorRepo,
-		nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	userID := uint64(123)
@@ -294,7 +333,10 @@ func TestCommunityService_DeleteCommunity_NotSuperAdmin(t *testing.T) {
 		mockCommunityRepo,
 		nil,
 		mockCommunityModeratorRepo,
-		nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	userID := uint64(123)
@@ -312,3 +354,763 @@ func TestCommunityService_DeleteCommunity_NotSuperAdmin(t *testing.T) {
 	mockCommunityRepo.AssertExpectations(t)
 	mockCommunityModeratorRepo.AssertExpectations(t)
 }
+
+func TestCommunityService_PinPost_DefaultOrder(t *testing.T) {
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockPostRepo := new(MockPostRepository)
+
+	communityService := NewCommunityService(
+		mockCommunityRepo,
+		nil,
+		mockCommunityModeratorRepo,
+		mockPostRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	userID := uint64(123)
+	communityID := uint64(456)
+	postID := uint64(789)
+	req := &request.PinPostRequest{}
+
+	community := &model.Community{ID: communityID}
+	post := &model.Post{ID: postID, CommunityID: communityID, Status: "approved"}
+
+	mockCommunityRepo.On("GetCommunityByID", communityID).Return(community, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", communityID, userID).Return("admin", nil)
+	mockPostRepo.On("GetPostByID", postID).Return(post, nil)
+	mockPostRepo.On("PinPost", postID, (*int)(nil), false).Return(true, nil)
+
+	err := communityService.PinPost(context.Background(), userID, communityID, postID, req)
+
+	assert.NoError(t, err)
+	mockCommunityRepo.AssertExpectations(t)
+	mockCommunityModeratorRepo.AssertExpectations(t)
+	mockPostRepo.AssertExpectations(t)
+}
+
+func TestCommunityService_PinPost_LimitReached(t *testing.T) {
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockPostRepo := new(MockPostRepository)
+
+	communityService := NewCommunityService(
+		mockCommunityRepo,
+		nil,
+		mockCommunityModeratorRepo,
+		mockPostRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	userID := uint64(123)
+	communityID := uint64(456)
+	postID := uint64(789)
+	req := &request.PinPostRequest{}
+
+	community := &model.Community{ID: communityID}
+	post := &model.Post{ID: postID, CommunityID: communityID, Status: "approved"}
+
+	mockCommunityRepo.On("GetCommunityByID", communityID).Return(community, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", communityID, userID).Return("super_admin", nil)
+	mockPostRepo.On("GetPostByID", postID).Return(post, nil)
+	// the community reached MAX_PINNED_POSTS_PER_COMMUNITY
+	mockPostRepo.On("PinPost", postID, (*int)(nil), false).Return(false, nil)
+
+	err := communityService.PinPost(context.Background(), userID, communityID, postID, req)
+
+	assert.Error(t, err)
+	assert.Equal(t, "pinned posts limit reached", err.Error())
+}
+
+func TestCommunityService_PinPost_NotApproved(t *testing.T) {
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockPostRepo := new(MockPostRepository)
+
+	communityService := NewCommunityService(
+		mockCommunityRepo,
+		nil,
+		mockCommunityModeratorRepo,
+		mockPostRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	userID := uint64(123)
+	communityID := uint64(456)
+	postID := uint64(789)
+
+	community := &model.Community{ID: communityID}
+	post := &model.Post{ID: postID, CommunityID: communityID, Status: "pending"}
+
+	mockCommunityRepo.On("GetCommunityByID", communityID).Return(community, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", communityID, userID).Return("admin", nil)
+	mockPostRepo.On("GetPostByID", postID).Return(post, nil)
+
+	err := communityService.PinPost(context.Background(), userID, communityID, postID, &request.PinPostRequest{})
+
+	assert.Error(t, err)
+	assert.Equal(t, "only approved posts can be pinned", err.Error())
+}
+
+func TestCommunityService_LockPost_RecordsModerationLog(t *testing.T) {
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockPostRepo := new(MockPostRepository)
+	mockModerationLogRepo := new(MockModerationLogRepository)
+
+	communityService := NewCommunityService(
+		mockCommunityRepo,
+		nil,
+		mockCommunityModeratorRepo,
+		mockPostRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		mockModerationLogRepo,
+		nil, nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	userID := uint64(123)
+	communityID := uint64(456)
+	postID := uint64(789)
+	reason := "Thread got out of hand"
+
+	community := &model.Community{ID: communityID}
+	post := &model.Post{ID: postID, CommunityID: communityID, Status: "approved"}
+
+	mockCommunityRepo.On("GetCommunityByID", communityID).Return(community, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", communityID, userID).Return("admin", nil)
+	mockPostRepo.On("GetPostByID", postID).Return(post, nil)
+	mockPostRepo.On("UpdatePostLock", postID, mock.AnythingOfType("*time.Time"), &reason).Return(nil)
+	mockModerationLogRepo.On("CreateModerationLog", mock.MatchedBy(func(log *model.ModerationLog) bool {
+		return log.Action == constant.MODERATION_ACTION_LOCK_POST && log.TargetID == postID && log.ActorID == userID
+	})).Return(nil)
+
+	err := communityService.LockPost(context.Background(), userID, communityID, postID, &request.LockContentRequest{Reason: &reason})
+
+	assert.NoError(t, err)
+	mockPostRepo.AssertExpectations(t)
+	mockModerationLogRepo.AssertExpectations(t)
+}
+
+func TestCommunityService_UnlockPost_NotLocked(t *testing.T) {
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockPostRepo := new(MockPostRepository)
+
+	communityService := NewCommunityService(
+		mockCommunityRepo,
+		nil,
+		mockCommunityModeratorRepo,
+		mockPostRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	userID := uint64(123)
+	communityID := uint64(456)
+	postID := uint64(789)
+
+	community := &model.Community{ID: communityID}
+	post := &model.Post{ID: postID, CommunityID: communityID, Status: "approved"}
+
+	mockCommunityRepo.On("GetCommunityByID", communityID).Return(community, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", communityID, userID).Return("super_admin", nil)
+	mockPostRepo.On("GetPostByID", postID).Return(post, nil)
+
+	err := communityService.UnlockPost(context.Background(), userID, communityID, postID)
+
+	assert.Error(t, err)
+	assert.Equal(t, "post is not locked", err.Error())
+	mockPostRepo.AssertNotCalled(t, "UpdatePostLock", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCommunityService_UpdateReactionEmojis_Deduplicates(t *testing.T) {
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockModerationLogRepo := new(MockModerationLogRepository)
+
+	communityService := NewCommunityService(
+		mockCommunityRepo,
+		nil,
+		mockCommunityModeratorRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		mockModerationLogRepo,
+		nil, nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	userID := uint64(123)
+	communityID := uint64(456)
+
+	mockCommunityRepo.On("GetCommunityByID", communityID).Return(&model.Community{ID: communityID}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", communityID, userID).Return(constant.ROLE_SUPER_ADMIN, nil)
+	mockCommunityRepo.On("UpdateReactionEmojis", communityID, []string{"🦀", "🚀"}).Return(nil)
+
+	mockModerationLogRepo.On("CreateModerationLog", mock.MatchedBy(func(log *model.ModerationLog) bool {
+		return log.Action == constant.MODERATION_ACTION_UPDATE_SETTINGS && log.TargetType == constant.MODERATION_TARGET_COMMUNITY
+	})).Return(nil)
+
+	err := communityService.UpdateReactionEmojis(context.Background(), userID, communityID, &request.UpdateReactionEmojisRequest{
+		Emojis: []string{"🦀", "🚀", "🦀"},
+	})
+
+	assert.NoError(t, err)
+	mockCommunityRepo.AssertExpectations(t)
+	mockModerationLogRepo.AssertExpectations(t)
+}
+
+func TestCommunityService_UpdateReactionEmojis_InvalidEmoji(t *testing.T) {
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+
+	communityService := NewCommunityService(
+		mockCommunityRepo,
+		nil,
+		mockCommunityModeratorRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	userID := uint64(123)
+	communityID := uint64(456)
+
+	mockCommunityRepo.On("GetCommunityByID", communityID).Return(&model.Community{ID: communityID}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", communityID, userID).Return(constant.ROLE_SUPER_ADMIN, nil)
+
+	err := communityService.UpdateReactionEmojis(context.Background(), userID, communityID, &request.UpdateReactionEmojisRequest{
+		Emojis: []string{"🦀", "crab"},
+	})
+
+	assert.EqualError(t, err, "invalid emoji")
+	mockCommunityRepo.AssertNotCalled(t, "UpdateReactionEmojis", mock.Anything, mock.Anything)
+}
+
+func TestCommunityService_GetMemberModerationLogs_Redacted(t *testing.T) {
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockSubscriptionRepo := new(MockSubscriptionRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockModerationLogRepo := new(MockModerationLogRepository)
+
+	communityService := NewCommunityService(
+		mockCommunityRepo,
+		mockSubscriptionRepo,
+		mockCommunityModeratorRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		mockModerationLogRepo,
+		nil, nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	userID := uint64(123)
+	communityID := uint64(456)
+	reason := "spam"
+
+	mockCommunityRepo.On("GetCommunityByID", communityID).Return(&model.Community{ID: communityID, ModLogMemberAccess: true, ModLogShowReasons: true}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", communityID, userID).Return("", errors.New("not found"))
+	mockSubscriptionRepo.On("FilterApprovedSubscriberIDs", communityID, []uint64{userID}).Return([]uint64{userID}, nil)
+	mockModerationLogRepo.On("GetMemberModerationLogs", communityID, constant.MOD_LOG_MEMBER_HIDDEN_ACTIONS, 1, 10).Return([]*model.ModerationLog{
+		{ID: 1, ActorID: 9, Actor: &model.User{ID: 9, Username: "mod"}, Action: constant.MODERATION_ACTION_REMOVE_POST, TargetType: constant.MODERATION_TARGET_POST, TargetID: 77, Reason: &reason},
+		{ID: 2, ActorID: 9, Actor: &model.User{ID: 9, Username: "mod"}, Action: constant.MODERATION_ACTION_BAN_USER, TargetType: constant.MODERATION_TARGET_USER, TargetID: 55},
+	}, int64(2), nil)
+
+	logs, _, err := communityService.GetMemberModerationLogs(context.Background(), userID, communityID, 1, 10)
+
+	assert.NoError(t, err)
+	assert.Len(t, logs, 2)
+	assert.Nil(t, logs[0].Actor)
+	assert.Equal(t, uint64(77), *logs[0].TargetID)
+	assert.Equal(t, reason, *logs[0].Reason)
+	assert.Nil(t, logs[1].TargetID)
+}
+
+func TestCommunityService_GetMemberModerationLogs_NotPublic(t *testing.T) {
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockModerationLogRepo := new(MockModerationLogRepository)
+
+	communityService := NewCommunityService(
+		mockCommunityRepo,
+		nil,
+		mockCommunityModeratorRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		mockModerationLogRepo,
+		nil, nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	userID := uint64(123)
+	communityID := uint64(456)
+
+	mockCommunityRepo.On("GetCommunityByID", communityID).Return(&model.Community{ID: communityID}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", communityID, userID).Return("", errors.New("not found"))
+
+	logs, _, err := communityService.GetMemberModerationLogs(context.Background(), userID, communityID, 1, 10)
+
+	assert.Nil(t, logs)
+	assert.EqualError(t, err, "moderation log is not public")
+	mockModerationLogRepo.AssertNotCalled(t, "GetMemberModerationLogs", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCommunityService_RemoveUserRestriction_RecordsModerationLog(t *testing.T) {
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockUserRestrictionRepo := new(MockUserRestrictionRepository)
+	mockModerationLogRepo := new(MockModerationLogRepository)
+
+	communityService := NewCommunityService(
+		mockCommunityRepo,
+		nil,
+		mockCommunityModeratorRepo,
+		nil, nil, nil, nil, nil,
+		mockUserRestrictionRepo,
+		nil, nil, nil, nil,
+		mockModerationLogRepo,
+		nil, nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	moderatorID := uint64(123)
+	communityID := uint64(456)
+	restrictionID := uint64(7)
+
+	mockCommunityRepo.On("GetCommunityByID", communityID).Return(&model.Community{ID: communityID}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", communityID, moderatorID).Return(constant.ROLE_ADMIN, nil)
+	mockUserRestrictionRepo.On("GetRestrictionByID", restrictionID).Return(&model.UserRestriction{
+		ID: restrictionID, UserID: 55, CommunityID: communityID, RestrictionType: constant.RESTRICTION_PERMANENT_BAN,
+	}, nil)
+	mockUserRestrictionRepo.On("DeleteRestriction", restrictionID).Return(nil)
+	mockModerationLogRepo.On("CreateModerationLog", mock.MatchedBy(func(log *model.ModerationLog) bool {
+		return log.Action == constant.MODERATION_ACTION_REMOVE_RESTRICTION && log.TargetType == constant.MODERATION_TARGET_USER &&
+			log.TargetID == 55 && log.ActorID == moderatorID
+	})).Return(nil)
+
+	err := communityService.RemoveUserRestriction(context.Background(), moderatorID, communityID, restrictionID)
+
+	assert.NoError(t, err)
+	mockUserRestrictionRepo.AssertExpectations(t)
+	mockModerationLogRepo.AssertExpectations(t)
+}
+
+func TestCommunityService_RemoveUserRestriction_OtherCommunity(t *testing.T) {
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockUserRestrictionRepo := new(MockUserRestrictionRepository)
+
+	communityService := NewCommunityService(
+		mockCommunityRepo,
+		nil,
+		mockCommunityModeratorRepo,
+		nil, nil, nil, nil, nil,
+		mockUserRestrictionRepo,
+		nil, nil, nil, nil, nil, nil, nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	moderatorID := uint64(123)
+	communityID := uint64(456)
+	restrictionID := uint64(7)
+
+	mockCommunityRepo.On("GetCommunityByID", communityID).Return(&model.Community{ID: communityID}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", communityID, moderatorID).Return(constant.ROLE_ADMIN, nil)
+	mockUserRestrictionRepo.On("GetRestrictionByID", restrictionID).Return(&model.UserRestriction{ID: restrictionID, UserID: 55, CommunityID: 999}, nil)
+
+	err := communityService.RemoveUserRestriction(context.Background(), moderatorID, communityID, restrictionID)
+
+	assert.EqualError(t, err, "restriction not found")
+	mockUserRestrictionRepo.AssertNotCalled(t, "DeleteRestriction", mock.Anything)
+}
+
+func TestCommunityService_UpdateMemberRole_AssignsCustomRole(t *testing.T) {
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockSubscriptionRepo := new(MockSubscriptionRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockModerationLogRepo := new(MockModerationLogRepository)
+	mockCommunityRoleRepo := new(MockCommunityRoleRepository)
+
+	communityService := NewCommunityService(
+		mockCommunityRepo,
+		mockSubscriptionRepo,
+		mockCommunityModeratorRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		mockModerationLogRepo,
+		nil, nil,
+		nil,
+		mockCommunityRoleRepo,
+		nil,
+	)
+
+	adminID := uint64(1)
+	userID := uint64(5)
+	communityID := uint64(456)
+	roleID := uint64(7)
+
+	mockCommunityRepo.On("GetCommunityByID", communityID).Return(&model.Community{ID: communityID}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", communityID, adminID).Return(constant.ROLE_SUPER_ADMIN, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", communityID, userID).Return(constant.ROLE_ADMIN, nil)
+	mockSubscriptionRepo.On("IsUserSubscribed", userID, communityID).Return(true, nil)
+	mockCommunityRoleRepo.On("GetRoleByID", roleID).Return(&model.CommunityRole{
+		ID:          roleID,
+		CommunityID: communityID,
+		Name:        "Janitor",
+		Permissions: []string{constant.COMMUNITY_PERMISSION_MANAGE_POSTS},
+	}, nil)
+	mockCommunityModeratorRepo.On("UpsertModerator", mock.MatchedBy(func(moderator *model.CommunityModerator) bool {
+		return moderator.UserID == userID && moderator.Role == constant.ROLE_MODERATOR && *moderator.RoleID == roleID
+	})).Return(nil)
+	mockModerationLogRepo.On("CreateModerationLog", mock.MatchedBy(func(log *model.ModerationLog) bool {
+		return log.Action == constant.MODERATION_ACTION_ADD_MODERATOR && log.TargetID == userID && *log.Details == "Janitor"
+	})).Return(nil)
+
+	err := communityService.UpdateMemberRole(context.Background(), adminID, communityID, userID, constant.ROLE_MODERATOR, &roleID)
+
+	assert.NoError(t, err)
+	mockCommunityModeratorRepo.AssertExpectations(t)
+	mockModerationLogRepo.AssertExpectations(t)
+}
+
+func TestCommunityService_UpdateMemberRole_MemberNeedsInvitation(t *testing.T) {
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockSubscriptionRepo := new(MockSubscriptionRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+
+	communityService := NewCommunityService(
+		mockCommunityRepo,
+		mockSubscriptionRepo,
+		mockCommunityModeratorRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil,
+		nil, nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	adminID := uint64(1)
+	userID := uint64(5)
+	communityID := uint64(456)
+
+	mockCommunityRepo.On("GetCommunityByID", communityID).Return(&model.Community{ID: communityID}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", communityID, adminID).Return(constant.ROLE_SUPER_ADMIN, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", communityID, userID).Return("", nil)
+	mockSubscriptionRepo.On("IsUserSubscribed", userID, communityID).Return(true, nil)
+
+	err := communityService.UpdateMemberRole(context.Background(), adminID, communityID, userID, constant.ROLE_ADMIN, nil)
+
+	assert.EqualError(t, err, "user must accept a moderator invitation")
+	mockCommunityModeratorRepo.AssertNotCalled(t, "UpsertModerator", mock.Anything)
+}
+
+func TestCommunityService_UnjoinCommunity_SuperAdminMustTransferOwnership(t *testing.T) {
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockSubscriptionRepo := new(MockSubscriptionRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+
+	communityService := NewCommunityService(
+		mockCommunityRepo,
+		mockSubscriptionRepo,
+		mockCommunityModeratorRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil,
+		nil, nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	ownerID := uint64(1)
+	communityID := uint64(456)
+
+	mockCommunityRepo.On("GetCommunityByID", communityID).Return(&model.Community{ID: communityID}, nil)
+	mockSubscriptionRepo.On("IsUserSubscribed", ownerID, communityID).Return(true, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", communityID, ownerID).Return(constant.ROLE_SUPER_ADMIN, nil)
+
+	err := communityService.UnjoinCommunity(context.Background(), ownerID, communityID)
+
+	assert.EqualError(t, err, "super admin must transfer ownership before leaving")
+	mockCommunityModeratorRepo.AssertNotCalled(t, "DeleteModerator", mock.Anything, mock.Anything)
+	mockSubscriptionRepo.AssertNotCalled(t, "DeleteSubscription", mock.Anything, mock.Anything)
+}
+
+func TestCommunityService_UpdateMemberRole_CannotChangeSuperAdmin(t *testing.T) {
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockSubscriptionRepo := new(MockSubscriptionRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+
+	communityService := NewCommunityService(
+		mockCommunityRepo,
+		mockSubscriptionRepo,
+		mockCommunityModeratorRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil,
+		nil, nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	moderatorID := uint64(4)
+	ownerID := uint64(1)
+	communityID := uint64(456)
+
+	mockCommunityRepo.On("GetCommunityByID", communityID).Return(&model.Community{ID: communityID}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", communityID, moderatorID).Return(constant.ROLE_MODERATOR, nil)
+	mockCommunityModeratorRepo.On("GetModeratorCustomRole", communityID, moderatorID).Return(&model.CommunityRole{
+		Permissions: []string{constant.COMMUNITY_PERMISSION_MANAGE_ROLES},
+	}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", communityID, ownerID).Return(constant.ROLE_SUPER_ADMIN, nil)
+	mockSubscriptionRepo.On("IsUserSubscribed", ownerID, communityID).Return(true, nil)
+
+	err := communityService.UpdateMemberRole(context.Background(), moderatorID, communityID, ownerID, constant.ROLE_USER, nil)
+
+	assert.EqualError(t, err, "cannot change the role of the super admin")
+	mockCommunityModeratorRepo.AssertNotCalled(t, "DeleteModerator", communityID, ownerID)
+}
+
+func TestCommunityService_RemoveMember_RemovesModeratorRole(t *testing.T) {
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockSubscriptionRepo := new(MockSubscriptionRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockModerationLogRepo := new(MockModerationLogRepository)
+	mockModeratorInvitationRepo := new(MockModeratorInvitationRepository)
+
+	communityService := NewCommunityService(
+		mockCommunityRepo,
+		mockSubscriptionRepo,
+		mockCommunityModeratorRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		mockModerationLogRepo,
+		nil, nil,
+		nil,
+		nil,
+		mockModeratorInvitationRepo,
+	)
+
+	ownerID := uint64(1)
+	adminID := uint64(2)
+	communityID := uint64(456)
+
+	mockCommunityRepo.On("GetCommunityByID", communityID).Return(&model.Community{ID: communityID}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", communityID, ownerID).Return(constant.ROLE_SUPER_ADMIN, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", communityID, adminID).Return(constant.ROLE_ADMIN, nil)
+	mockSubscriptionRepo.On("IsUserSubscribed", adminID, communityID).Return(true, nil)
+	mockCommunityModeratorRepo.On("DeleteModerator", communityID, adminID).Return(nil)
+	mockModeratorInvitationRepo.On("CancelInviterInvitations", communityID, adminID, mock.Anything).Return(int64(1), nil)
+	mockSubscriptionRepo.On("DeleteSubscription", adminID, communityID).Return(nil)
+	mockModerationLogRepo.On("CreateModerationLog", mock.MatchedBy(func(log *model.ModerationLog) bool {
+		return log.Action == constant.MODERATION_ACTION_REMOVE_MEMBER && log.TargetID == adminID &&
+			log.Reason != nil && *log.Reason == "Inactive"
+	})).Return(nil)
+
+	err := communityService.RemoveMember(context.Background(), ownerID, communityID, adminID, stringPtr("Inactive"))
+
+	assert.NoError(t, err)
+	mockCommunityModeratorRepo.AssertExpectations(t)
+	mockSubscriptionRepo.AssertExpectations(t)
+	mockModeratorInvitationRepo.AssertExpectations(t)
+}
+
+func TestCommunityService_DeletePostByModerator_RuleTitleAsReason(t *testing.T) {
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockPostRepo := new(MockPostRepository)
+	mockModerationLogRepo := new(MockModerationLogRepository)
+	mockCommunityRuleRepo := new(MockCommunityRuleRepository)
+
+	communityService := NewCommunityService(
+		mockCommunityRepo,
+		nil,
+		mockCommunityModeratorRepo,
+		mockPostRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		mockModerationLogRepo,
+		nil, nil,
+		mockCommunityRuleRepo,
+		nil,
+		nil,
+	)
+
+	ruleID := uint64(9)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(1)).Return(constant.ROLE_ADMIN, nil)
+	mockPostRepo.On("GetPostByID", uint64(10)).Return(&model.Post{ID: 10, CommunityID: 3, AuthorID: 5}, nil)
+	mockCommunityRuleRepo.On("GetRuleByID", ruleID).Return(&model.CommunityRule{
+		ID:          ruleID,
+		CommunityID: 3,
+		Title:       "No spam",
+		AppliesTo:   constant.COMMUNITY_RULE_APPLIES_TO_ALL,
+	}, nil)
+	mockPostRepo.On("DeletePost", uint64(10)).Return(nil)
+	mockModerationLogRepo.On("CreateModerationLog", mock.MatchedBy(func(log *model.ModerationLog) bool {
+		return log.Action == constant.MODERATION_ACTION_REMOVE_POST && *log.RuleID == ruleID &&
+			log.Reason != nil && *log.Reason == "No spam"
+	})).Return(nil)
+
+	err := communityService.DeletePostByModerator(context.Background(), 1, 3, 10, &ruleID, nil)
+
+	assert.NoError(t, err)
+	mockModerationLogRepo.AssertExpectations(t)
+}
+
+func TestCommunityService_RemoveMember_RoleExceedsPermissions(t *testing.T) {
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockSubscriptionRepo := new(MockSubscriptionRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+
+	communityService := NewCommunityService(
+		mockCommunityRepo,
+		mockSubscriptionRepo,
+		mockCommunityModeratorRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil,
+		nil, nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	moderatorID := uint64(4)
+	adminID := uint64(2)
+	communityID := uint64(456)
+
+	mockCommunityRepo.On("GetCommunityByID", communityID).Return(&model.Community{ID: communityID}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", communityID, moderatorID).Return(constant.ROLE_MODERATOR, nil)
+	mockCommunityModeratorRepo.On("GetModeratorCustomRole", communityID, moderatorID).Return(&model.CommunityRole{
+		Permissions: []string{constant.COMMUNITY_PERMISSION_MANAGE_MEMBERS},
+	}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", communityID, adminID).Return(constant.ROLE_ADMIN, nil)
+	mockSubscriptionRepo.On("IsUserSubscribed", adminID, communityID).Return(true, nil)
+
+	err := communityService.RemoveMember(context.Background(), moderatorID, communityID, adminID, nil)
+
+	assert.EqualError(t, err, "role exceeds your permissions")
+	mockCommunityModeratorRepo.AssertNotCalled(t, "DeleteModerator", mock.Anything, mock.Anything)
+	mockSubscriptionRepo.AssertNotCalled(t, "DeleteSubscription", mock.Anything, mock.Anything)
+}
+
+func TestCommunityService_BanUser_CustomRoleCannotBanSuperAdmin(t *testing.T) {
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+
+	communityService := NewCommunityService(
+		mockCommunityRepo,
+		nil,
+		mockCommunityModeratorRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil,
+		nil, nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	moderatorID := uint64(4)
+	ownerID := uint64(1)
+	communityID := uint64(456)
+
+	mockCommunityRepo.On("GetCommunityByID", communityID).Return(&model.Community{ID: communityID}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", communityID, moderatorID).Return(constant.ROLE_MODERATOR, nil)
+	mockCommunityModeratorRepo.On("GetModeratorCustomRole", communityID, moderatorID).Return(&model.CommunityRole{
+		Permissions: []string{constant.COMMUNITY_PERMISSION_BAN_USERS},
+	}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", communityID, ownerID).Return(constant.ROLE_SUPER_ADMIN, nil)
+
+	err := communityService.BanUser(context.Background(), moderatorID, communityID, &request.BanUserRequest{
+		UserID:          ownerID,
+		RestrictionType: constant.RESTRICTION_PERMANENT_BAN,
+		Reason:          "takeover",
+	})
+
+	assert.EqualError(t, err, "cannot ban the super admin")
+}
+
+func TestCommunityService_BanUser_CustomRoleCannotBanAdmin(t *testing.T) {
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+
+	communityService := NewCommunityService(
+		mockCommunityRepo,
+		nil,
+		mockCommunityModeratorRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil,
+		nil, nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	moderatorID := uint64(4)
+	adminID := uint64(2)
+	communityID := uint64(456)
+
+	mockCommunityRepo.On("GetCommunityByID", communityID).Return(&model.Community{ID: communityID}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", communityID, moderatorID).Return(constant.ROLE_MODERATOR, nil)
+	mockCommunityModeratorRepo.On("GetModeratorCustomRole", communityID, moderatorID).Return(&model.CommunityRole{
+		Permissions: []string{constant.COMMUNITY_PERMISSION_BAN_USERS},
+	}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", communityID, adminID).Return(constant.ROLE_ADMIN, nil)
+
+	err := communityService.BanUser(context.Background(), moderatorID, communityID, &request.BanUserRequest{
+		UserID:          adminID,
+		RestrictionType: constant.RESTRICTION_TEMPORARY_BAN,
+		Reason:          "disagreement",
+	})
+
+	assert.EqualError(t, err, "role exceeds your permissions")
+}
+
+func TestCommunityService_BanUser_CannotBanSelf(t *testing.T) {
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+
+	communityService := NewCommunityService(
+		mockCommunityRepo,
+		nil,
+		mockCommunityModeratorRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil,
+		nil, nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	communityID := uint64(456)
+
+	mockCommunityRepo.On("GetCommunityByID", communityID).Return(&model.Community{ID: communityID}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", communityID, uint64(2)).Return(constant.ROLE_ADMIN, nil)
+
+	err := communityService.BanUser(context.Background(), 2, communityID, &request.BanUserRequest{
+		UserID:          2,
+		RestrictionType: constant.RESTRICTION_WARNING,
+		Reason:          "test",
+	})
+
+	assert.EqualError(t, err, "cannot ban yourself")
+}
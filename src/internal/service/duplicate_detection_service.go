@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"social-platform-backend/config"
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/domain/repository"
+	"social-platform-backend/package/constant"
+	"social-platform-backend/package/logger"
+	"social-platform-backend/package/util"
+	"sort"
+	"time"
+)
+
+// Stricter actions win when a post matches several earlier posts
+var duplicateActionPriority = map[string]int{
+	constant.DUPLICATE_ACTION_NONE:   0,
+	constant.DUPLICATE_ACTION_FLAG:   1,
+	constant.DUPLICATE_ACTION_HOLD:   2,
+	constant.DUPLICATE_ACTION_REJECT: 3,
+}
+
+// DuplicateCheck is the outcome of comparing a post with earlier posts
+type DuplicateCheck struct {
+	Fingerprint *model.PostFingerprint
+	Matches     []*model.PostDuplicateMatch
+	Action      string
+}
+
+// DuplicateDetectionService fingerprints published posts and finds reposts of the
+// same text or link, by the same author or across the platform
+type DuplicateDetectionService struct {
+	postFingerprintRepo repository.PostFingerprintRepository
+	contentFlagRepo     repository.ContentFlagRepository
+	settings            config.DuplicateDetection
+	now                 func() time.Time
+}
+
+func NewDuplicateDetectionService(postFingerprintRepo repository.PostFingerprintRepository, conf *config.Config, contentFlagRepo repository.ContentFlagRepository) *DuplicateDetectionService {
+	settings := conf.DuplicateDetection
+	if settings.MinSimilarity <= 0 || settings.MinSimilarity > 1 {
+		settings.MinSimilarity = constant.DEFAULT_DUPLICATE_MIN_SIMILARITY
+	}
+	if settings.MinWords <= 0 {
+		settings.MinWords = constant.DEFAULT_DUPLICATE_MIN_WORDS
+	}
+	if settings.AuthorWindowHours <= 0 {
+		settings.AuthorWindowHours = constant.DEFAULT_DUPLICATE_AUTHOR_WINDOW_HOURS
+	}
+	if settings.GlobalWindowHours <= 0 {
+		settings.GlobalWindowHours = constant.DEFAULT_DUPLICATE_GLOBAL_WINDOW_HOURS
+	}
+	if _, ok := duplicateActionPriority[settings.AuthorAction]; !ok {
+		settings.AuthorAction = constant.DEFAULT_DUPLICATE_AUTHOR_ACTION
+	}
+	if _, ok := duplicateActionPriority[settings.GlobalAction]; !ok {
+		settings.GlobalAction = constant.DEFAULT_DUPLICATE_GLOBAL_ACTION
+	}
+
+	return &DuplicateDetectionService{
+		postFingerprintRepo: postFingerprintRepo,
+		contentFlagRepo:     contentFlagRepo,
+		settings:            settings,
+		now:                 time.Now,
+	}
+}
+
+// CheckPost compares a post about to be published with recent posts. It returns nil
+// when detection is disabled. A failed lookup lets the post through unchecked.
+func (s *DuplicateDetectionService) CheckPost(ctx context.Context, post *model.Post) *DuplicateCheck {
+	if !s.settings.Enabled {
+		return nil
+	}
+
+	fingerprint := s.fingerprintPost(post)
+	check := &DuplicateCheck{Fingerprint: fingerprint, Action: constant.DUPLICATE_ACTION_NONE}
+
+	now := s.now()
+	candidates, err := s.postFingerprintRepo.FindCandidateFingerprints(
+		fingerprint,
+		now.Add(-time.Duration(s.settings.AuthorWindowHours)*time.Hour),
+		now.Add(-time.Duration(s.settings.GlobalWindowHours)*time.Hour),
+		constant.DUPLICATE_MAX_CANDIDATES,
+	)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error finding duplicate candidates in DuplicateDetectionService.CheckPost: %v", err)
+		return check
+	}
+
+	for _, candidate := range candidates {
+		match := &model.PostDuplicateMatch{
+			MatchedPostID: candidate.PostID,
+			CommunityID:   post.CommunityID,
+			SameAuthor:    candidate.AuthorID == post.AuthorID,
+		}
+
+		if fingerprint.CanonicalURL != nil && candidate.CanonicalURL != nil && *fingerprint.CanonicalURL == *candidate.CanonicalURL {
+			match.MatchType = constant.DUPLICATE_MATCH_URL
+			match.Similarity = 1
+		} else {
+			similarity := util.MinHashSimilarity(fingerprint.MinHash, candidate.MinHash)
+			if similarity < s.settings.MinSimilarity {
+				continue
+			}
+			match.MatchType = constant.DUPLICATE_MATCH_TEXT
+			match.Similarity = similarity
+		}
+
+		match.Action = s.settings.GlobalAction
+		if match.SameAuthor {
+			match.Action = s.settings.AuthorAction
+		}
+		if match.Action == constant.DUPLICATE_ACTION_NONE {
+			continue
+		}
+
+		check.Matches = append(check.Matches, match)
+		if duplicateActionPriority[match.Action] > duplicateActionPriority[check.Action] {
+			check.Action = match.Action
+		}
+	}
+
+	sort.SliceStable(check.Matches, func(i, j int) bool {
+		return check.Matches[i].Similarity > check.Matches[j].Similarity
+	})
+	if len(check.Matches) > constant.DUPLICATE_MAX_RECORDED_MATCHES {
+		check.Matches = check.Matches[:constant.DUPLICATE_MAX_RECORDED_MATCHES]
+	}
+
+	if len(check.Matches) > 0 {
+		logger.InfofWithCtx(ctx, "[Info] Post by user %d matches %d recent posts, action %s", post.AuthorID, len(check.Matches), check.Action)
+	}
+	return check
+}
+
+// SaveFingerprint stores the fingerprint of a created post and the matches found for it.
+// Posts that were flagged or held are added to the moderation queue.
+func (s *DuplicateDetectionService) SaveFingerprint(ctx context.Context, post *model.Post, check *DuplicateCheck) {
+	if check == nil {
+		return
+	}
+
+	check.Fingerprint.PostID = post.ID
+	check.Fingerprint.CreatedAt = s.now()
+	for _, match := range check.Matches {
+		match.PostID = post.ID
+		match.CreatedAt = check.Fingerprint.CreatedAt
+	}
+
+	if err := s.postFingerprintRepo.CreatePostFingerprint(check.Fingerprint, check.Matches); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error saving post fingerprint in DuplicateDetectionService.SaveFingerprint: %v", err)
+	}
+
+	// Flagged and held posts also wait in the moderation queue
+	if s.contentFlagRepo == nil || (check.Action != constant.DUPLICATE_ACTION_FLAG && check.Action != constant.DUPLICATE_ACTION_HOLD) {
+		return
+	}
+	flag := &model.ContentFlag{
+		CommunityID: post.CommunityID,
+		TargetType:  constant.MODERATION_TARGET_POST,
+		TargetID:    post.ID,
+		Source:      constant.CONTENT_FLAG_SOURCE_DUPLICATE,
+		Reason:      fmt.Sprintf("matches %d recent posts", len(check.Matches)),
+		CreatedAt:   check.Fingerprint.CreatedAt,
+	}
+	if err := s.contentFlagRepo.CreateContentFlag(flag); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error flagging duplicate post in DuplicateDetectionService.SaveFingerprint: %v", err)
+	}
+}
+
+func (s *DuplicateDetectionService) fingerprintPost(post *model.Post) *model.PostFingerprint {
+	fingerprint := &model.PostFingerprint{
+		PostID:      post.ID,
+		AuthorID:    post.AuthorID,
+		CommunityID: post.CommunityID,
+	}
+
+	words := util.FingerprintWords(post.Title + " " + post.ContentText)
+	if len(words) >= s.settings.MinWords {
+		fingerprint.MinHash = util.MinHashSignature(words)
+		fingerprint.Bands = util.MinHashBands(fingerprint.MinHash)
+	}
+
+	if post.Type == constant.PostTypeLink && post.URL != nil {
+		if canonicalURL := util.CanonicalizeURL(*post.URL); canonicalURL != "" {
+			fingerprint.CanonicalURL = &canonicalURL
+		}
+	}
+
+	return fingerprint
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"social-platform-backend/config"
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/interface/dto/request"
+	"social-platform-backend/package/constant"
+	"social-platform-backend/package/util"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const duplicateSpamText = "Buy cheap followers today at our amazing store, the best prices on the whole internet guaranteed for everyone"
+
+func newTestDuplicateDetectionService(fingerprintRepo *MockPostFingerprintRepository, authorAction, globalAction string) *DuplicateDetectionService {
+	return NewDuplicateDetectionService(fingerprintRepo, &config.Config{
+		DuplicateDetection: config.DuplicateDetection{
+			Enabled:      true,
+			AuthorAction: authorAction,
+			GlobalAction: globalAction,
+		},
+	}, nil)
+}
+
+func fingerprintOf(postID, authorID uint64, text string) *model.PostFingerprint {
+	signature := util.MinHashSignature(util.FingerprintWords(text))
+	return &model.PostFingerprint{
+		PostID:   postID,
+		AuthorID: authorID,
+		MinHash:  signature,
+		Bands:    util.MinHashBands(signature),
+	}
+}
+
+func TestDuplicateDetectionService_CheckPost_Disabled(t *testing.T) {
+	mockFingerprintRepo := new(MockPostFingerprintRepository)
+	duplicateService := NewDuplicateDetectionService(mockFingerprintRepo, &config.Config{}, nil)
+
+	check := duplicateService.CheckPost(context.Background(), &model.Post{AuthorID: 1, Title: duplicateSpamText})
+
+	assert.Nil(t, check)
+	mockFingerprintRepo.AssertNotCalled(t, "FindCandidateFingerprints", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDuplicateDetectionService_CheckPost_SameAuthorAndOthers(t *testing.T) {
+	mockFingerprintRepo := new(MockPostFingerprintRepository)
+	duplicateService := newTestDuplicateDetectionService(mockFingerprintRepo, constant.DUPLICATE_ACTION_HOLD, constant.DUPLICATE_ACTION_FLAG)
+
+	candidates := []*model.PostFingerprint{
+		fingerprintOf(10, 2, "Great deal "+duplicateSpamText+" act now"),
+		fingerprintOf(11, 1, "Great deal! "+duplicateSpamText),
+		fingerprintOf(12, 3, "Does anyone know a good tutorial for learning goroutines and channels in depth"),
+	}
+	mockFingerprintRepo.On("FindCandidateFingerprints", mock.MatchedBy(func(fingerprint *model.PostFingerprint) bool {
+		return len(fingerprint.MinHash) == util.MinHashSize && fingerprint.CanonicalURL == nil
+	}), mock.Anything, mock.Anything, constant.DUPLICATE_MAX_CANDIDATES).Return(candidates, nil)
+
+	post := &model.Post{AuthorID: 1, CommunityID: 5, Type: constant.PostTypeText, Title: "Great deal", ContentText: duplicateSpamText}
+	check := duplicateService.CheckPost(context.Background(), post)
+
+	assert.Equal(t, constant.DUPLICATE_ACTION_HOLD, check.Action)
+	assert.Len(t, check.Matches, 2)
+	// most similar first
+	assert.Equal(t, uint64(11), check.Matches[0].MatchedPostID)
+	assert.True(t, check.Matches[0].SameAuthor)
+	assert.Equal(t, constant.DUPLICATE_ACTION_HOLD, check.Matches[0].Action)
+	assert.Equal(t, uint64(10), check.Matches[1].MatchedPostID)
+	assert.Equal(t, constant.DUPLICATE_ACTION_FLAG, check.Matches[1].Action)
+	assert.Equal(t, uint64(5), check.Matches[1].CommunityID)
+}
+
+func TestDuplicateDetectionService_CheckPost_LinkMatch(t *testing.T) {
+	mockFingerprintRepo := new(MockPostFingerprintRepository)
+	duplicateService := newTestDuplicateDetectionService(mockFingerprintRepo, constant.DUPLICATE_ACTION_REJECT, constant.DUPLICATE_ACTION_NONE)
+
+	canonicalURL := "https://example.com/deal"
+	mockFingerprintRepo.On("FindCandidateFingerprints", mock.MatchedBy(func(fingerprint *model.PostFingerprint) bool {
+		// the title is too short to compare by text
+		return fingerprint.MinHash == nil && *fingerprint.CanonicalURL == canonicalURL
+	}), mock.Anything, mock.Anything, mock.Anything).Return([]*model.PostFingerprint{
+		{PostID: 20, AuthorID: 1, CanonicalURL: &canonicalURL},
+		{PostID: 21, AuthorID: 2, CanonicalURL: &canonicalURL},
+	}, nil)
+
+	url := "http://www.example.com/deal/?utm_source=spam"
+	post := &model.Post{AuthorID: 1, Type: constant.PostTypeLink, Title: "Look", URL: &url}
+	check := duplicateService.CheckPost(context.Background(), post)
+
+	assert.Equal(t, constant.DUPLICATE_ACTION_REJECT, check.Action)
+	// matches from other authors are ignored with action none
+	assert.Len(t, check.Matches, 1)
+	assert.Equal(t, constant.DUPLICATE_MATCH_URL, check.Matches[0].MatchType)
+}
+
+func TestDuplicateDetectionService_CheckPost_LookupFailureLetsPostThrough(t *testing.T) {
+	mockFingerprintRepo := new(MockPostFingerprintRepository)
+	duplicateService := newTestDuplicateDetectionService(mockFingerprintRepo, constant.DUPLICATE_ACTION_REJECT, constant.DUPLICATE_ACTION_REJECT)
+
+	mockFingerprintRepo.On("FindCandidateFingerprints", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db down"))
+
+	check := duplicateService.CheckPost(context.Background(), &model.Post{AuthorID: 1, Title: duplicateSpamText})
+
+	assert.Equal(t, constant.DUPLICATE_ACTION_NONE, check.Action)
+	assert.NotNil(t, check.Fingerprint)
+}
+
+func TestPostService_CreatePost_DuplicateRejected(t *testing.T) {
+	mockPostRepo := new(MockPostRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockFingerprintRepo := new(MockPostFingerprintRepository)
+
+	postService := NewPostService(
+		mockPostRepo,
+		mockCommunityRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		newTestDuplicateDetectionService(mockFingerprintRepo, constant.DUPLICATE_ACTION_REJECT, constant.DUPLICATE_ACTION_FLAG),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(1)).Return(&model.Community{ID: 1}, nil)
+	mockFingerprintRepo.On("FindCandidateFingerprints", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([]*model.PostFingerprint{fingerprintOf(30, 123, duplicateSpamText)}, nil)
+
+	err := postService.CreatePost(context.Background(), 123, &request.CreatePostRequest{
+		CommunityID: 1,
+		Title:       "Deal",
+		Type:        constant.PostTypeText,
+		Content:     duplicateSpamText,
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, "duplicate content", err.Error())
+	mockPostRepo.AssertNotCalled(t, "CreatePost", mock.Anything)
+}
+
+func TestPostService_CreatePost_DuplicateHeld(t *testing.T) {
+	mockPostRepo := new(MockPostRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockFingerprintRepo := new(MockPostFingerprintRepository)
+
+	postService := NewPostService(
+		mockPostRepo,
+		mockCommunityRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		newTestDuplicateDetectionService(mockFingerprintRepo, constant.DUPLICATE_ACTION_HOLD, constant.DUPLICATE_ACTION_FLAG),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(1)).Return(&model.Community{ID: 1}, nil)
+	mockFingerprintRepo.On("FindCandidateFingerprints", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([]*model.PostFingerprint{fingerprintOf(30, 123, duplicateSpamText)}, nil)
+	mockPostRepo.On("CreatePost", mock.MatchedBy(func(post *model.Post) bool {
+		return post.Status == constant.POST_STATUS_PENDING
+	})).Run(func(args mock.Arguments) {
+		args.Get(0).(*model.Post).ID = 31
+	}).Return(nil)
+	mockFingerprintRepo.On("CreatePostFingerprint", mock.MatchedBy(func(fingerprint *model.PostFingerprint) bool {
+		return fingerprint.PostID == 31
+	}), mock.MatchedBy(func(matches []*model.PostDuplicateMatch) bool {
+		return len(matches) == 1 && matches[0].PostID == 31 && matches[0].MatchedPostID == 30
+	})).Return(nil)
+
+	err := postService.CreatePost(context.Background(), 123, &request.CreatePostRequest{
+		CommunityID: 1,
+		Title:       "Deal",
+		Type:        constant.PostTypeText,
+		Content:     duplicateSpamText,
+	})
+
+	assert.NoError(t, err)
+	mockPostRepo.AssertExpectations(t)
+	mockFingerprintRepo.AssertExpectations(t)
+}
@@ -0,0 +1,324 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/domain/repository"
+	"social-platform-backend/package/constant"
+	"social-platform-backend/package/logger"
+	"strings"
+	"syscall"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+// LinkPreviewFetcher downloads the HTML of a page. Fetch returns the final URL after
+// redirects, used to resolve relative image URLs.
+type LinkPreviewFetcher interface {
+	Fetch(ctx context.Context, pageURL string) (body []byte, finalURL string, err error)
+}
+
+type httpLinkPreviewFetcher struct {
+	httpClient *http.Client
+}
+
+// NewLinkPreviewFetcher returns a fetcher that only connects to public addresses
+func NewLinkPreviewFetcher() LinkPreviewFetcher {
+	dialer := &net.Dialer{
+		Timeout: constant.LINK_PREVIEW_FETCH_TIMEOUT_SECONDS * time.Second,
+		// Checked on the resolved address of every connection, redirects included,
+		// so DNS rebinding cannot reach internal services
+		Control: func(network, address string, _ syscall.RawConn) error {
+			return checkPublicAddress(address)
+		},
+	}
+
+	return NewLinkPreviewFetcherWithClient(&http.Client{
+		Timeout: constant.LINK_PREVIEW_FETCH_TIMEOUT_SECONDS * time.Second,
+		Transport: &http.Transport{
+			// A proxy would make the address check useless
+			Proxy:                 nil,
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   constant.LINK_PREVIEW_FETCH_TIMEOUT_SECONDS * time.Second,
+			ResponseHeaderTimeout: constant.LINK_PREVIEW_FETCH_TIMEOUT_SECONDS * time.Second,
+			MaxIdleConns:          10,
+			IdleConnTimeout:       30 * time.Second,
+		},
+	})
+}
+
+// NewLinkPreviewFetcherWithClient uses the given client as is, e.g. an httptest
+// server client in tests. Redirects are still limited and must stay on http(s).
+func NewLinkPreviewFetcherWithClient(httpClient *http.Client) LinkPreviewFetcher {
+	httpClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) > constant.LINK_PREVIEW_MAX_REDIRECTS {
+			return fmt.Errorf("too many redirects")
+		}
+		if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+			return fmt.Errorf("redirect to unsupported scheme %q", req.URL.Scheme)
+		}
+		return nil
+	}
+	return &httpLinkPreviewFetcher{httpClient: httpClient}
+}
+
+// checkPublicAddress rejects loopback, private, link-local and other non public
+// addresses as well as ports other than 80 and 443
+func checkPublicAddress(address string) error {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if port != "80" && port != "443" {
+		return fmt.Errorf("port %s is not allowed", port)
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	addr = addr.Unmap()
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return fmt.Errorf("address %s is not public", addr)
+	}
+	for _, prefix := range reservedPrefixes {
+		if prefix.Contains(addr) {
+			return fmt.Errorf("address %s is not public", addr)
+		}
+	}
+	return nil
+}
+
+var reservedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"), // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"), // benchmarking
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"), // NAT64, may embed a private IPv4
+}
+
+func (f *httpLinkPreviewFetcher) Fetch(ctx context.Context, pageURL string) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+	req.Header.Set("User-Agent", "SocialPlatformBot/1.0 (link preview)")
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("fetch page: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || (mediaType != "text/html" && mediaType != "application/xhtml+xml") {
+		return nil, "", fmt.Errorf("unsupported content type %q", resp.Header.Get("Content-Type"))
+	}
+
+	// Pages larger than the limit are cut off, the metadata is in the head anyway
+	body, err := io.ReadAll(io.LimitReader(resp.Body, constant.LINK_PREVIEW_MAX_BODY_BYTES))
+	if err != nil {
+		return nil, "", fmt.Errorf("read page: %w", err)
+	}
+
+	return body, resp.Request.URL.String(), nil
+}
+
+type LinkPreviewService struct {
+	linkPreviewRepo repository.LinkPreviewRepository
+	fetcher         LinkPreviewFetcher
+}
+
+func NewLinkPreviewService(linkPreviewRepo repository.LinkPreviewRepository, fetcher LinkPreviewFetcher) *LinkPreviewService {
+	return &LinkPreviewService{
+		linkPreviewRepo: linkPreviewRepo,
+		fetcher:         fetcher,
+	}
+}
+
+// RefreshLinkPreviewAsync fetches the preview in the background unless a fresh one is cached
+func (s *LinkPreviewService) RefreshLinkPreviewAsync(ctx context.Context, pageURL string) {
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.ErrorfWithCtx(ctx, "[Panic] Recovered in LinkPreviewService.RefreshLinkPreviewAsync: %v", r)
+			}
+		}()
+
+		// The request context is gone once the handler returns
+		if _, err := s.GetOrFetchLinkPreview(context.Background(), pageURL); err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Error refreshing link preview in LinkPreviewService.RefreshLinkPreviewAsync: %v", err)
+		}
+	}()
+}
+
+// GetOrFetchLinkPreview returns the cached preview or fetches the page when the cache
+// entry is missing or stale. A page that cannot be previewed is cached as failed.
+func (s *LinkPreviewService) GetOrFetchLinkPreview(ctx context.Context, pageURL string) (*model.LinkPreview, error) {
+	cached, err := s.linkPreviewRepo.GetLinkPreviewByURL(pageURL)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting link preview in LinkPreviewService.GetOrFetchLinkPreview: %v", err)
+		return nil, fmt.Errorf("failed to get link preview")
+	}
+	if cached != nil && !isLinkPreviewStale(cached) {
+		return cached, nil
+	}
+
+	preview := &model.LinkPreview{
+		URL:       pageURL,
+		Status:    constant.LINK_PREVIEW_STATUS_FAILED,
+		FetchedAt: time.Now(),
+	}
+
+	parsedURL, err := url.Parse(pageURL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		logger.ErrorfWithCtx(ctx, "[Err] Unsupported link preview URL in LinkPreviewService.GetOrFetchLinkPreview: %s", pageURL)
+	} else if body, finalURL, err := s.fetcher.Fetch(ctx, pageURL); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error fetching link preview in LinkPreviewService.GetOrFetchLinkPreview: url=%s, err=%v", pageURL, err)
+	} else {
+		extractLinkPreview(body, finalURL, preview)
+	}
+
+	if err := s.linkPreviewRepo.UpsertLinkPreview(preview); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error saving link preview in LinkPreviewService.GetOrFetchLinkPreview: %v", err)
+		return nil, fmt.Errorf("failed to save link preview")
+	}
+
+	return preview, nil
+}
+
+func isLinkPreviewStale(preview *model.LinkPreview) bool {
+	ttl := constant.LINK_PREVIEW_CACHE_TTL_HOURS * time.Hour
+	if preview.Status == constant.LINK_PREVIEW_STATUS_FAILED {
+		ttl = constant.LINK_PREVIEW_FAILED_RETRY_MINUTES * time.Minute
+	}
+	return time.Since(preview.FetchedAt) > ttl
+}
+
+// extractLinkPreview reads the OpenGraph and Twitter card tags of the document head,
+// falling back to <title> and the description meta tag. The preview is marked ok if a
+// title was found.
+func extractLinkPreview(body []byte, pageURL string, preview *model.LinkPreview) {
+	meta := make(map[string]string)
+	var documentTitle string
+
+	tokenizer := html.NewTokenizer(strings.NewReader(string(body)))
+	inTitle := false
+parse:
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			break parse
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			switch token.Data {
+			case "body":
+				break parse
+			case "title":
+				inTitle = documentTitle == ""
+			case "meta":
+				var key, content string
+				for _, attr := range token.Attr {
+					switch strings.ToLower(attr.Key) {
+					case "property", "name":
+						if key == "" {
+							key = strings.ToLower(strings.TrimSpace(attr.Val))
+						}
+					case "content":
+						content = strings.TrimSpace(attr.Val)
+					}
+				}
+				// The first occurrence of a tag wins
+				if _, exists := meta[key]; key != "" && content != "" && !exists {
+					meta[key] = content
+				}
+			}
+		case html.TextToken:
+			if inTitle {
+				documentTitle = strings.TrimSpace(string(tokenizer.Text()))
+			}
+		case html.EndTagToken:
+			token := tokenizer.Token()
+			if token.Data == "title" {
+				inTitle = false
+			}
+			if token.Data == "head" {
+				break parse
+			}
+		}
+	}
+
+	firstOf := func(values ...string) string {
+		for _, value := range values {
+			if value != "" {
+				return value
+			}
+		}
+		return ""
+	}
+
+	title := firstOf(meta["og:title"], meta["twitter:title"], documentTitle)
+	description := firstOf(meta["og:description"], meta["twitter:description"], meta["description"])
+	image := firstOf(meta["og:image"], meta["og:image:url"], meta["twitter:image"], meta["twitter:image:src"])
+	siteName := firstOf(meta["og:site_name"], meta["application-name"])
+
+	if title == "" {
+		return
+	}
+
+	preview.Status = constant.LINK_PREVIEW_STATUS_OK
+	preview.Title = truncatePreviewText(title, constant.LINK_PREVIEW_MAX_TITLE_LENGTH)
+	preview.Description = truncatePreviewText(description, constant.LINK_PREVIEW_MAX_DESCRIPTION_LENGTH)
+	preview.SiteName = truncatePreviewText(siteName, constant.LINK_PREVIEW_MAX_TITLE_LENGTH)
+	preview.ImageURL = resolvePreviewImageURL(pageURL, image)
+}
+
+func truncatePreviewText(text string, maxLength int) *string {
+	text = strings.Join(strings.Fields(text), " ")
+	if text == "" {
+		return nil
+	}
+	if utf8.RuneCountInString(text) > maxLength {
+		text = string([]rune(text)[:maxLength])
+	}
+	return &text
+}
+
+// resolvePreviewImageURL makes relative image URLs absolute and drops anything that is
+// not http(s), e.g. data: or javascript: URLs
+func resolvePreviewImageURL(pageURL, imageURL string) *string {
+	if imageURL == "" {
+		return nil
+	}
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return nil
+	}
+	ref, err := url.Parse(imageURL)
+	if err != nil {
+		return nil
+	}
+	resolved := base.ResolveReference(ref)
+	if resolved.Scheme != "http" && resolved.Scheme != "https" {
+		return nil
+	}
+	result := resolved.String()
+	return &result
+}
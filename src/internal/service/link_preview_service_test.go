@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/package/constant"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testPreviewPage = `<!DOCTYPE html>
+<html>
+<head>
+	<title>Fallback title</title>
+	<meta property="og:title" content="  OpenGraph   title ">
+	<meta name="twitter:description" content="Twitter description">
+	<meta property="og:image" content="/images/cover.png">
+	<meta property="og:site_name" content="Example">
+</head>
+<body><meta property="og:title" content="Ignored"></body>
+</html>`
+
+func TestLinkPreviewService_GetOrFetchLinkPreview_ExtractsOpenGraph(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(testPreviewPage))
+	}))
+	defer server.Close()
+
+	mockLinkPreviewRepo := new(MockLinkPreviewRepository)
+	linkPreviewService := NewLinkPreviewService(mockLinkPreviewRepo, NewLinkPreviewFetcherWithClient(server.Client()))
+
+	pageURL := server.URL + "/article"
+	mockLinkPreviewRepo.On("GetLinkPreviewByURL", pageURL).Return(nil, nil)
+	mockLinkPreviewRepo.On("UpsertLinkPreview", mock.AnythingOfType("*model.LinkPreview")).Return(nil)
+
+	preview, err := linkPreviewService.GetOrFetchLinkPreview(context.Background(), pageURL)
+
+	assert.NoError(t, err)
+	assert.Equal(t, constant.LINK_PREVIEW_STATUS_OK, preview.Status)
+	assert.Equal(t, "OpenGraph title", *preview.Title)
+	assert.Equal(t, "Twitter description", *preview.Description)
+	assert.Equal(t, server.URL+"/images/cover.png", *preview.ImageURL)
+	assert.Equal(t, "Example", *preview.SiteName)
+	mockLinkPreviewRepo.AssertExpectations(t)
+}
+
+func TestLinkPreviewService_GetOrFetchLinkPreview_UsesFreshCache(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	mockLinkPreviewRepo := new(MockLinkPreviewRepository)
+	linkPreviewService := NewLinkPreviewService(mockLinkPreviewRepo, NewLinkPreviewFetcherWithClient(server.Client()))
+
+	cached := &model.LinkPreview{URL: server.URL, Status: constant.LINK_PREVIEW_STATUS_OK, FetchedAt: time.Now().Add(-time.Hour)}
+	mockLinkPreviewRepo.On("GetLinkPreviewByURL", server.URL).Return(cached, nil)
+
+	preview, err := linkPreviewService.GetOrFetchLinkPreview(context.Background(), server.URL)
+
+	assert.NoError(t, err)
+	assert.Same(t, cached, preview)
+	assert.Equal(t, 0, requests)
+	mockLinkPreviewRepo.AssertNotCalled(t, "UpsertLinkPreview", mock.Anything)
+}
+
+func TestLinkPreviewService_GetOrFetchLinkPreview_CachesNonHTMLAsFailed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write([]byte(testPreviewPage))
+	}))
+	defer server.Close()
+
+	mockLinkPreviewRepo := new(MockLinkPreviewRepository)
+	linkPreviewService := NewLinkPreviewService(mockLinkPreviewRepo, NewLinkPreviewFetcherWithClient(server.Client()))
+
+	mockLinkPreviewRepo.On("GetLinkPreviewByURL", server.URL).Return(nil, nil)
+	mockLinkPreviewRepo.On("UpsertLinkPreview", mock.AnythingOfType("*model.LinkPreview")).Return(nil)
+
+	preview, err := linkPreviewService.GetOrFetchLinkPreview(context.Background(), server.URL)
+
+	assert.NoError(t, err)
+	assert.Equal(t, constant.LINK_PREVIEW_STATUS_FAILED, preview.Status)
+	assert.Nil(t, preview.Title)
+}
+
+func TestLinkPreviewFetcher_BlocksPrivateAddresses(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	_, _, err := NewLinkPreviewFetcher().Fetch(context.Background(), server.URL)
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, requests)
+}
+
+func TestLinkPreviewFetcher_LimitsBodySize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(strings.Repeat("a", constant.LINK_PREVIEW_MAX_BODY_BYTES*2)))
+	}))
+	defer server.Close()
+
+	body, _, err := NewLinkPreviewFetcherWithClient(server.Client()).Fetch(context.Background(), server.URL)
+
+	assert.NoError(t, err)
+	assert.Len(t, body, constant.LINK_PREVIEW_MAX_BODY_BYTES)
+}
+
+func TestCheckPublicAddress(t *testing.T) {
+	assert.NoError(t, checkPublicAddress("93.184.216.34:443"))
+	assert.Error(t, checkPublicAddress("127.0.0.1:80"))
+	assert.Error(t, checkPublicAddress("10.0.0.5:80"))
+	assert.Error(t, checkPublicAddress("169.254.169.254:80"))
+	assert.Error(t, checkPublicAddress("[::ffff:192.168.1.1]:443"))
+	assert.Error(t, checkPublicAddress("[::1]:443"))
+	assert.Error(t, checkPublicAddress("93.184.216.34:22"))
+}
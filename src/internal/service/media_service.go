@@ -0,0 +1,292 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"slices"
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/domain/repository"
+	"social-platform-backend/internal/infrastructure/storage"
+	"social-platform-backend/internal/interface/dto/response"
+	"social-platform-backend/package/constant"
+	"social-platform-backend/package/logger"
+	"social-platform-backend/package/util"
+	"strings"
+	"time"
+)
+
+type mediaVariantSpec struct {
+	name    string
+	maxSize int
+}
+
+var mediaVariantSpecs = []mediaVariantSpec{
+	{name: constant.MEDIA_VARIANT_THUMBNAIL, maxSize: constant.MEDIA_VARIANT_THUMBNAIL_SIZE},
+	{name: constant.MEDIA_VARIANT_MEDIUM, maxSize: constant.MEDIA_VARIANT_MEDIUM_SIZE},
+}
+
+type MediaService struct {
+	mediaRepo repository.MediaRepository
+	storage   storage.Storage
+}
+
+func NewMediaService(mediaRepo repository.MediaRepository, storage storage.Storage) *MediaService {
+	return &MediaService{
+		mediaRepo: mediaRepo,
+		storage:   storage,
+	}
+}
+
+// UploadMedia validates an upload against the limits of its use-case, strips image
+// metadata, generates resized variants and stores everything. Re-uploading a file
+// returns the existing media instead of storing it again.
+func (s *MediaService) UploadMedia(ctx context.Context, userID uint64, useCase string, file io.Reader) (*response.MediaResponse, error) {
+	allowedTypes, ok := constant.MEDIA_ALLOWED_MIME_TYPES[useCase]
+	if !ok {
+		return nil, fmt.Errorf("invalid media use case")
+	}
+	maxSize := constant.MEDIA_MAX_SIZE_BYTES[useCase]
+
+	data, err := io.ReadAll(io.LimitReader(file, maxSize+1))
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error reading upload in MediaService.UploadMedia: %v", err)
+		return nil, fmt.Errorf("failed to read file")
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("file is empty")
+	}
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("file is too large, the limit for %s uploads is %d MB", useCase, maxSize>>20)
+	}
+
+	// Never trust the client supplied content type or file name
+	mimeType, _, _ := strings.Cut(http.DetectContentType(data), ";")
+	if !slices.Contains(allowedTypes, mimeType) {
+		return nil, fmt.Errorf("unsupported file type %s for %s uploads", mimeType, useCase)
+	}
+
+	sum := sha256.Sum256(data)
+	contentHash := hex.EncodeToString(sum[:])
+
+	existing, err := s.mediaRepo.GetMediaByUploaderAndHash(userID, contentHash)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting media by hash in MediaService.UploadMedia: %v", err)
+		return nil, fmt.Errorf("failed to upload file")
+	}
+	if existing != nil {
+		touched, err := s.mediaRepo.TouchMedia(existing.ID)
+		if err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Error touching media in MediaService.UploadMedia: %v", err)
+			return nil, fmt.Errorf("failed to upload file")
+		}
+		// otherwise orphan cleanup just deleted it, upload it again
+		if touched {
+			return response.NewMediaResponse(existing), nil
+		}
+	}
+
+	media := &model.Media{
+		UploaderID:  userID,
+		UseCase:     useCase,
+		MimeType:    mimeType,
+		ContentHash: contentHash,
+	}
+
+	// Reuse the stored objects when someone else uploaded the same file
+	shared, err := s.mediaRepo.CreateSharedMedia(media)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error creating shared media in MediaService.UploadMedia: %v", err)
+		return nil, fmt.Errorf("failed to upload file")
+	}
+	if shared {
+		return response.NewMediaResponse(media), nil
+	}
+
+	if err := s.storeMedia(ctx, media, data); err != nil {
+		return nil, err
+	}
+
+	if err := s.mediaRepo.CreateMedia(media); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error creating media in MediaService.UploadMedia: %v", err)
+		return nil, fmt.Errorf("failed to upload file")
+	}
+
+	return response.NewMediaResponse(media), nil
+}
+
+// storeMedia writes the file and, for images, its variants to storage. Objects are
+// keyed by content hash.
+func (s *MediaService) storeMedia(ctx context.Context, media *model.Media, data []byte) error {
+	var img image.Image
+	if slices.Contains(constant.MEDIA_IMAGE_MIME_TYPES, media.MimeType) {
+		processed, decoded, err := processImage(data, media.MimeType)
+		if err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Error processing image in MediaService.storeMedia: %v", err)
+			return fmt.Errorf("invalid image file")
+		}
+		data = processed
+		img = decoded
+
+		width, height := img.Bounds().Dx(), img.Bounds().Dy()
+		media.Width = &width
+		media.Height = &height
+	}
+
+	keyPrefix := "media/" + media.ContentHash[:2] + "/" + media.ContentHash
+	var storedKeys []string
+	cleanup := func() {
+		for _, key := range storedKeys {
+			if err := s.storage.Delete(ctx, key); err != nil {
+				logger.ErrorfWithCtx(ctx, "[Err] Error deleting %s in MediaService.storeMedia: %v", key, err)
+			}
+		}
+	}
+
+	media.StorageKey = keyPrefix + constant.MEDIA_FILE_EXTENSIONS[media.MimeType]
+	media.Size = int64(len(data))
+	url, err := s.storage.Put(ctx, media.StorageKey, media.MimeType, data)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error storing media in MediaService.storeMedia: %v", err)
+		return fmt.Errorf("failed to upload file")
+	}
+	media.URL = url
+	storedKeys = append(storedKeys, media.StorageKey)
+
+	if img == nil {
+		return nil
+	}
+
+	for _, spec := range mediaVariantSpecs {
+		resized := util.ResizeToFit(img, spec.maxSize)
+		if resized == nil {
+			continue
+		}
+
+		variantData, variantType, err := encodeVariant(resized, media.MimeType)
+		if err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Error encoding %s variant in MediaService.storeMedia: %v", spec.name, err)
+			cleanup()
+			return fmt.Errorf("failed to upload file")
+		}
+
+		variant := model.MediaVariant{
+			Name:       spec.name,
+			StorageKey: keyPrefix + "_" + spec.name + constant.MEDIA_FILE_EXTENSIONS[variantType],
+			Width:      resized.Bounds().Dx(),
+			Height:     resized.Bounds().Dy(),
+			Size:       int64(len(variantData)),
+		}
+		variant.URL, err = s.storage.Put(ctx, variant.StorageKey, variantType, variantData)
+		if err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Error storing %s variant in MediaService.storeMedia: %v", spec.name, err)
+			cleanup()
+			return fmt.Errorf("failed to upload file")
+		}
+		storedKeys = append(storedKeys, variant.StorageKey)
+		media.Variants = append(media.Variants, variant)
+	}
+
+	return nil
+}
+
+// processImage decodes an image and re-encodes JPEG and PNG files, which drops EXIF
+// and other metadata such as GPS position. JPEGs are rotated upright first since their
+// orientation tag is lost. GIFs are kept as is to preserve animation, the format has
+// no EXIF block.
+func processImage(data []byte, mimeType string) ([]byte, image.Image, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, err
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > constant.MEDIA_MAX_IMAGE_PIXELS {
+		return nil, nil, fmt.Errorf("image dimensions %dx%d not allowed", config.Width, config.Height)
+	}
+
+	var buf bytes.Buffer
+	switch mimeType {
+	case "image/jpeg":
+		img, err := jpeg.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, nil, err
+		}
+		img = util.ApplyOrientation(img, util.JPEGOrientation(data))
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: constant.MEDIA_JPEG_QUALITY}); err != nil {
+			return nil, nil, err
+		}
+		return buf.Bytes(), img, nil
+	case "image/png":
+		img, err := png.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, nil, err
+		}
+		return buf.Bytes(), img, nil
+	case "image/gif":
+		// first frame only, used for the variants
+		img, err := gif.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, nil, err
+		}
+		return data, img, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported image type %s", mimeType)
+	}
+}
+
+// encodeVariant encodes JPEG sources as JPEG and everything else as PNG to keep transparency
+func encodeVariant(img image.Image, sourceType string) ([]byte, string, error) {
+	var buf bytes.Buffer
+	if sourceType == "image/jpeg" {
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: constant.MEDIA_JPEG_QUALITY}); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), "image/jpeg", nil
+	}
+
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), "image/png", nil
+}
+
+// CleanupOrphanMedia deletes uploads that were never attached to a post, comment,
+// message or profile. Stored objects are removed once no media row shares them.
+func (s *MediaService) CleanupOrphanMedia(ctx context.Context) error {
+	cutoff := time.Now().Add(-constant.MEDIA_ORPHAN_GRACE_HOURS * time.Hour)
+	orphans, err := s.mediaRepo.GetOrphanMedia(cutoff, constant.SCHEDULER_CLEANUP_ORPHAN_MEDIA_BATCH_SIZE)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting orphan media in MediaService.CleanupOrphanMedia: %v", err)
+		return fmt.Errorf("failed to get orphan media")
+	}
+
+	for _, media := range orphans {
+		err := s.mediaRepo.DeleteOrphanMedia(media, cutoff, func() error {
+			keys := []string{media.StorageKey}
+			for _, variant := range media.Variants {
+				keys = append(keys, variant.StorageKey)
+			}
+			for _, key := range keys {
+				if err := s.storage.Delete(ctx, key); err != nil {
+					return fmt.Errorf("delete %s: %w", key, err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Error deleting media %d in MediaService.CleanupOrphanMedia: %v", media.ID, err)
+		}
+	}
+
+	return nil
+}
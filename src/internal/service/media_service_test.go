@@ -0,0 +1,229 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/package/constant"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func encodeTestPNG(t *testing.T, width, height int) []byte {
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))))
+	return buf.Bytes()
+}
+
+// encodeTestJPEGWithExif returns a JPEG carrying an EXIF block with orientation 6
+// (rotate 90 degrees clockwise)
+func encodeTestJPEGWithExif(t *testing.T, width, height int) []byte {
+	var buf bytes.Buffer
+	assert.NoError(t, jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height)), nil))
+	encoded := buf.Bytes()
+
+	exif := []byte("Exif\x00\x00" +
+		"MM\x00\x2a\x00\x00\x00\x08" +
+		"\x00\x01" +
+		"\x01\x12\x00\x03\x00\x00\x00\x01\x00\x06\x00\x00")
+	segment := append([]byte{0xFF, 0xE1, 0x00, byte(len(exif) + 2)}, exif...)
+
+	data := append([]byte{}, encoded[:2]...)
+	data = append(data, segment...)
+	return append(data, encoded[2:]...)
+}
+
+func testContentHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestMediaService_UploadMedia_GeneratesVariants(t *testing.T) {
+	mockMediaRepo := new(MockMediaRepository)
+	mockStorage := new(MockStorage)
+	mediaService := NewMediaService(mockMediaRepo, mockStorage)
+
+	data := encodeTestPNG(t, 2000, 1000)
+	hash := testContentHash(data)
+
+	mockMediaRepo.On("GetMediaByUploaderAndHash", uint64(1), hash).Return(nil, nil)
+	mockMediaRepo.On("CreateSharedMedia", mock.AnythingOfType("*model.Media")).Return(false, nil)
+	mockStorage.On("Put", mock.Anything, mock.AnythingOfType("string"), "image/png", mock.Anything).
+		Return("https://cdn.example.com/object", nil)
+	mockMediaRepo.On("CreateMedia", mock.AnythingOfType("*model.Media")).Return(nil)
+
+	result, err := mediaService.UploadMedia(context.Background(), 1, constant.MEDIA_USE_CASE_POST, bytes.NewReader(data))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "image/png", result.MimeType)
+	assert.Equal(t, 2000, *result.Width)
+	assert.Equal(t, 1000, *result.Height)
+	assert.Equal(t, 320, result.Variants[constant.MEDIA_VARIANT_THUMBNAIL].Width)
+	assert.Equal(t, 160, result.Variants[constant.MEDIA_VARIANT_THUMBNAIL].Height)
+	assert.Equal(t, 1280, result.Variants[constant.MEDIA_VARIANT_MEDIUM].Width)
+	mockStorage.AssertCalled(t, "Put", mock.Anything, "media/"+hash[:2]+"/"+hash+".png", "image/png", mock.Anything)
+	mockStorage.AssertCalled(t, "Put", mock.Anything, "media/"+hash[:2]+"/"+hash+"_thumbnail.png", "image/png", mock.Anything)
+	mockStorage.AssertNumberOfCalls(t, "Put", 3)
+}
+
+func TestMediaService_UploadMedia_StripsExifAndRotates(t *testing.T) {
+	mockMediaRepo := new(MockMediaRepository)
+	mockStorage := new(MockStorage)
+	mediaService := NewMediaService(mockMediaRepo, mockStorage)
+
+	data := encodeTestJPEGWithExif(t, 200, 100)
+	hash := testContentHash(data)
+
+	var stored []byte
+	mockMediaRepo.On("GetMediaByUploaderAndHash", uint64(1), hash).Return(nil, nil)
+	mockMediaRepo.On("CreateSharedMedia", mock.AnythingOfType("*model.Media")).Return(false, nil)
+	mockStorage.On("Put", mock.Anything, "media/"+hash[:2]+"/"+hash+".jpg", "image/jpeg", mock.Anything).
+		Run(func(args mock.Arguments) { stored = args.Get(3).([]byte) }).
+		Return("https://cdn.example.com/object.jpg", nil)
+	mockMediaRepo.On("CreateMedia", mock.AnythingOfType("*model.Media")).Return(nil)
+
+	result, err := mediaService.UploadMedia(context.Background(), 1, constant.MEDIA_USE_CASE_AVATAR, bytes.NewReader(data))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 100, *result.Width)
+	assert.Equal(t, 200, *result.Height)
+	assert.Empty(t, result.Variants)
+	assert.NotContains(t, string(stored), "Exif")
+}
+
+func TestMediaService_UploadMedia_RejectsUnsupportedType(t *testing.T) {
+	mockMediaRepo := new(MockMediaRepository)
+	mediaService := NewMediaService(mockMediaRepo, new(MockStorage))
+
+	_, err := mediaService.UploadMedia(context.Background(), 1, constant.MEDIA_USE_CASE_AVATAR, strings.NewReader("<html><script>alert(1)</script></html>"))
+
+	assert.EqualError(t, err, "unsupported file type text/html for avatar uploads")
+	mockMediaRepo.AssertNotCalled(t, "CreateMedia", mock.Anything)
+}
+
+func TestMediaService_UploadMedia_RejectsTooLarge(t *testing.T) {
+	mediaService := NewMediaService(new(MockMediaRepository), new(MockStorage))
+	data := bytes.Repeat([]byte{0}, int(constant.MEDIA_MAX_SIZE_BYTES[constant.MEDIA_USE_CASE_AVATAR])+1)
+
+	_, err := mediaService.UploadMedia(context.Background(), 1, constant.MEDIA_USE_CASE_AVATAR, bytes.NewReader(data))
+
+	assert.EqualError(t, err, "file is too large, the limit for avatar uploads is 5 MB")
+}
+
+func TestMediaService_UploadMedia_InvalidUseCase(t *testing.T) {
+	mediaService := NewMediaService(new(MockMediaRepository), new(MockStorage))
+
+	_, err := mediaService.UploadMedia(context.Background(), 1, "banner", strings.NewReader("data"))
+
+	assert.EqualError(t, err, "invalid media use case")
+}
+
+func TestMediaService_UploadMedia_DedupesOwnUpload(t *testing.T) {
+	mockMediaRepo := new(MockMediaRepository)
+	mockStorage := new(MockStorage)
+	mediaService := NewMediaService(mockMediaRepo, mockStorage)
+
+	data := encodeTestPNG(t, 10, 10)
+	hash := testContentHash(data)
+	existing := &model.Media{ID: 7, URL: "https://cdn.example.com/existing.png", MimeType: "image/png"}
+
+	mockMediaRepo.On("GetMediaByUploaderAndHash", uint64(1), hash).Return(existing, nil)
+	mockMediaRepo.On("TouchMedia", uint64(7)).Return(true, nil)
+
+	result, err := mediaService.UploadMedia(context.Background(), 1, constant.MEDIA_USE_CASE_COMMENT, bytes.NewReader(data))
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), result.ID)
+	mockStorage.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockMediaRepo.AssertNotCalled(t, "CreateMedia", mock.Anything)
+}
+
+func TestMediaService_UploadMedia_ReusesObjectsOfOtherUploader(t *testing.T) {
+	mockMediaRepo := new(MockMediaRepository)
+	mockStorage := new(MockStorage)
+	mediaService := NewMediaService(mockMediaRepo, mockStorage)
+
+	data := encodeTestPNG(t, 10, 10)
+	hash := testContentHash(data)
+	shared := &model.Media{
+		ID:         3,
+		UploaderID: 2,
+		StorageKey: "media/ab/shared.png",
+		URL:        "https://cdn.example.com/shared.png",
+		Variants:   []model.MediaVariant{{ID: 9, MediaID: 3, Name: constant.MEDIA_VARIANT_THUMBNAIL, URL: "https://cdn.example.com/thumb.png"}},
+	}
+
+	mockMediaRepo.On("GetMediaByUploaderAndHash", uint64(1), hash).Return(nil, nil)
+	mockMediaRepo.On("CreateSharedMedia", mock.MatchedBy(func(media *model.Media) bool {
+		return media.UploaderID == 1 && media.ContentHash == hash
+	})).Run(func(args mock.Arguments) {
+		media := args.Get(0).(*model.Media)
+		media.StorageKey = shared.StorageKey
+		media.URL = shared.URL
+	}).Return(true, nil)
+
+	result, err := mediaService.UploadMedia(context.Background(), 1, constant.MEDIA_USE_CASE_POST, bytes.NewReader(data))
+
+	assert.NoError(t, err)
+	assert.Equal(t, shared.URL, result.URL)
+	mockStorage.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockMediaRepo.AssertExpectations(t)
+}
+
+func TestMediaService_CleanupOrphanMedia(t *testing.T) {
+	mockMediaRepo := new(MockMediaRepository)
+	mockStorage := new(MockStorage)
+	mediaService := NewMediaService(mockMediaRepo, mockStorage)
+
+	unshared := &model.Media{
+		ID:          1,
+		ContentHash: "aaa",
+		StorageKey:  "media/aa/aaa.png",
+		Variants:    []model.MediaVariant{{StorageKey: "media/aa/aaa_thumbnail.png"}},
+	}
+	shared := &model.Media{ID: 2, ContentHash: "bbb", StorageKey: "media/bb/bbb.png"}
+
+	mockMediaRepo.On("GetOrphanMedia", mock.Anything, constant.SCHEDULER_CLEANUP_ORPHAN_MEDIA_BATCH_SIZE).
+		Return([]*model.Media{unshared, shared}, nil)
+	mockMediaRepo.On("DeleteOrphanMedia", unshared, mock.Anything).Return(true, nil)
+	mockMediaRepo.On("DeleteOrphanMedia", shared, mock.Anything).Return(false, nil)
+	mockStorage.On("Delete", mock.Anything, mock.AnythingOfType("string")).Return(nil)
+
+	err := mediaService.CleanupOrphanMedia(context.Background())
+
+	assert.NoError(t, err)
+	mockStorage.AssertCalled(t, "Delete", mock.Anything, "media/aa/aaa.png")
+	mockStorage.AssertCalled(t, "Delete", mock.Anything, "media/aa/aaa_thumbnail.png")
+	mockStorage.AssertNotCalled(t, "Delete", mock.Anything, "media/bb/bbb.png")
+}
+
+func TestMediaService_UploadMedia_ReuploadsMediaDeletedByCleanup(t *testing.T) {
+	mockMediaRepo := new(MockMediaRepository)
+	mockStorage := new(MockStorage)
+	mediaService := NewMediaService(mockMediaRepo, mockStorage)
+
+	data := encodeTestPNG(t, 10, 10)
+	hash := testContentHash(data)
+
+	mockMediaRepo.On("GetMediaByUploaderAndHash", uint64(1), hash).Return(&model.Media{ID: 7}, nil)
+	mockMediaRepo.On("TouchMedia", uint64(7)).Return(false, nil)
+	mockMediaRepo.On("CreateSharedMedia", mock.AnythingOfType("*model.Media")).Return(false, nil)
+	mockStorage.On("Put", mock.Anything, mock.AnythingOfType("string"), "image/png", mock.Anything).
+		Return("https://cdn.example.com/object.png", nil)
+	mockMediaRepo.On("CreateMedia", mock.AnythingOfType("*model.Media")).Return(nil)
+
+	result, err := mediaService.UploadMedia(context.Background(), 1, constant.MEDIA_USE_CASE_COMMENT, bytes.NewReader(data))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://cdn.example.com/object.png", result.URL)
+	mockMediaRepo.AssertCalled(t, "CreateMedia", mock.Anything)
+}
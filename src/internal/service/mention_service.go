@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/domain/repository"
+	"social-platform-backend/internal/interface/dto/response"
+	"social-platform-backend/package/constant"
+	"social-platform-backend/package/logger"
+	"social-platform-backend/package/template/payload"
+	"social-platform-backend/package/util"
+)
+
+type MentionService struct {
+	mentionRepo         repository.MentionRepository
+	userRepo            repository.UserRepository
+	communityRepo       repository.CommunityRepository
+	subscriptionRepo    repository.SubscriptionRepository
+	userRestrictionRepo repository.UserRestrictionRepository
+	notificationService *NotificationService
+}
+
+func NewMentionService(
+	mentionRepo repository.MentionRepository,
+	userRepo repository.UserRepository,
+	communityRepo repository.CommunityRepository,
+	subscriptionRepo repository.SubscriptionRepository,
+	userRestrictionRepo repository.UserRestrictionRepository,
+	notificationService *NotificationService,
+) *MentionService {
+	return &MentionService{
+		mentionRepo:         mentionRepo,
+		userRepo:            userRepo,
+		communityRepo:       communityRepo,
+		subscriptionRepo:    subscriptionRepo,
+		userRestrictionRepo: userRestrictionRepo,
+		notificationService: notificationService,
+	}
+}
+
+// SyncPostMentions stores the users mentioned in the title and content of a post.
+// They are notified only when notify is set, posts waiting for approval notify
+// through NotifyPostMentions once approved.
+func (s *MentionService) SyncPostMentions(ctx context.Context, post *model.Post, notify bool) {
+	community, err := s.communityRepo.GetCommunityByID(post.CommunityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Community not found in MentionService.SyncPostMentions: %v", err)
+		return
+	}
+
+	userIDs := s.resolveMentions(ctx, post.Title+" "+post.ContentText, post.AuthorID, community, nil)
+	if err := s.mentionRepo.ReplaceMentions(constant.MENTION_TARGET_POST, post.ID, post.AuthorID, userIDs); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error saving mentions in MentionService.SyncPostMentions: %v", err)
+		return
+	}
+
+	if notify {
+		s.NotifyPostMentions(ctx, post)
+	}
+}
+
+func (s *MentionService) NotifyPostMentions(ctx context.Context, post *model.Post) {
+	s.notifyMentions(ctx, constant.MENTION_TARGET_POST, post.ID, post.AuthorID, payload.MentionNotificationPayload{
+		PostID:    post.ID,
+		PostTitle: post.Title,
+	})
+}
+
+func (s *MentionService) SyncCommentMentions(ctx context.Context, comment *model.Comment, post *model.Post) {
+	community, err := s.communityRepo.GetCommunityByID(post.CommunityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Community not found in MentionService.SyncCommentMentions: %v", err)
+		return
+	}
+
+	userIDs := s.resolveMentions(ctx, comment.ContentText, comment.AuthorID, community, nil)
+	if err := s.mentionRepo.ReplaceMentions(constant.MENTION_TARGET_COMMENT, comment.ID, comment.AuthorID, userIDs); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error saving mentions in MentionService.SyncCommentMentions: %v", err)
+		return
+	}
+
+	commentID := comment.ID
+	s.notifyMentions(ctx, constant.MENTION_TARGET_COMMENT, comment.ID, comment.AuthorID, payload.MentionNotificationPayload{
+		PostID:    post.ID,
+		PostTitle: post.Title,
+		CommentID: &commentID,
+	})
+}
+
+// SyncMessageMentions only resolves the participants of the conversation. There is no
+// mention notification, the recipient is already notified about the message itself.
+func (s *MentionService) SyncMessageMentions(ctx context.Context, message *model.Message, conversation *model.Conversation) {
+	participants := map[uint64]bool{conversation.User1ID: true, conversation.User2ID: true}
+	userIDs := s.resolveMentions(ctx, message.Content, message.SenderID, nil, participants)
+	if err := s.mentionRepo.ReplaceMentions(constant.MENTION_TARGET_MESSAGE, message.ID, message.SenderID, userIDs); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error saving mentions in MentionService.SyncMessageMentions: %v", err)
+	}
+}
+
+// resolveMentions maps the mentioned usernames to users who may be mentioned: not the
+// author, only members in private communities, nobody banned from the community and,
+// when participants is given, only those users. There are no user-to-user blocks in
+// the platform yet, a community ban is the only block applied to mentions.
+func (s *MentionService) resolveMentions(ctx context.Context, text string, authorID uint64, community *model.Community, participants map[uint64]bool) []uint64 {
+	usernames := util.ExtractMentions(text, constant.MAX_MENTIONS_PER_CONTENT)
+	if len(usernames) == 0 {
+		return nil
+	}
+
+	users, err := s.userRepo.GetUsersByUsernames(usernames)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting mentioned users in MentionService.resolveMentions: %v", err)
+		return nil
+	}
+
+	var userIDs []uint64
+	for _, user := range users {
+		if user.ID == authorID || (participants != nil && !participants[user.ID]) {
+			continue
+		}
+		userIDs = append(userIDs, user.ID)
+	}
+	if community == nil || len(userIDs) == 0 {
+		return userIDs
+	}
+
+	if community.IsPrivate {
+		userIDs, err = s.subscriptionRepo.FilterApprovedSubscriberIDs(community.ID, userIDs)
+		if err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Error filtering community members in MentionService.resolveMentions: %v", err)
+			return nil
+		}
+	}
+
+	bannedIDs, err := s.userRestrictionRepo.FilterBannedUserIDs(community.ID, userIDs)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error filtering banned users in MentionService.resolveMentions: %v", err)
+		return nil
+	}
+	banned := make(map[uint64]bool, len(bannedIDs))
+	for _, bannedID := range bannedIDs {
+		banned[bannedID] = true
+	}
+
+	allowed := make([]uint64, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if !banned[userID] {
+			allowed = append(allowed, userID)
+		}
+	}
+	return allowed
+}
+
+// notifyMentions notifies mentioned users who were not notified about this content yet,
+// so editing a post or comment only pings the newly mentioned users
+func (s *MentionService) notifyMentions(ctx context.Context, targetType string, targetID, mentionerID uint64, notifPayload payload.MentionNotificationPayload) {
+	if s.notificationService == nil {
+		return
+	}
+
+	mentions, err := s.mentionRepo.GetUnnotifiedMentions(targetType, targetID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting mentions in MentionService.notifyMentions: %v", err)
+		return
+	}
+	if len(mentions) == 0 {
+		return
+	}
+
+	mentioner, err := s.userRepo.GetUserByID(mentionerID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting mentioner in MentionService.notifyMentions: %v", err)
+		return
+	}
+	notifPayload.UserName = mentioner.Username
+
+	// Marked first so a concurrent edit does not notify the same users twice
+	ids := make([]uint64, len(mentions))
+	for i, mention := range mentions {
+		ids[i] = mention.ID
+	}
+	if err := s.mentionRepo.MarkMentionsNotified(ids); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error marking mentions notified in MentionService.notifyMentions: %v", err)
+		return
+	}
+
+	for _, mention := range mentions {
+		if err := s.notificationService.CreateNotification(ctx, mention.UserID, constant.NOTIFICATION_ACTION_MENTIONED, notifPayload); err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Error sending mention notification to user %d in MentionService.notifyMentions: %v", mention.UserID, err)
+		}
+	}
+}
+
+// GetMentionSuggestions autocompletes @username with members of the community
+func (s *MentionService) GetMentionSuggestions(ctx context.Context, userID, communityID uint64, query string) ([]*response.MentionSuggestionResponse, error) {
+	community, err := s.communityRepo.GetCommunityByID(communityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Community not found in MentionService.GetMentionSuggestions: %v", err)
+		return nil, fmt.Errorf("community not found")
+	}
+
+	// Members of a private community are only listed to other members
+	if community.IsPrivate {
+		memberIDs, err := s.subscriptionRepo.FilterApprovedSubscriberIDs(communityID, []uint64{userID})
+		if err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Error checking membership in MentionService.GetMentionSuggestions: %v", err)
+			return nil, fmt.Errorf("failed to get mention suggestions")
+		}
+		if len(memberIDs) == 0 {
+			return nil, fmt.Errorf("permission denied")
+		}
+	}
+
+	users, _, err := s.userRepo.SearchUsers(query, &communityID, 1, constant.MAX_MENTION_SUGGESTIONS+1)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error searching users in MentionService.GetMentionSuggestions: %v", err)
+		return nil, fmt.Errorf("failed to get mention suggestions")
+	}
+
+	suggestions := make([]*response.MentionSuggestionResponse, 0, len(users))
+	for _, user := range users {
+		if user.ID == userID || len(suggestions) == constant.MAX_MENTION_SUGGESTIONS {
+			continue
+		}
+		suggestions = append(suggestions, response.NewMentionSuggestionResponse(user))
+	}
+	return suggestions, nil
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/package/constant"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMentionService_SyncPostMentions_FiltersAndNotifies(t *testing.T) {
+	mockNotificationRepo := new(MockNotificationRepository)
+	mockNotificationSettingRepo := new(MockNotificationSettingRepository)
+	mockNotificationUserRepo := new(MockUserRepository)
+	notificationService := NewNotificationService(mockNotificationRepo, mockNotificationSettingRepo, nil, mockNotificationUserRepo, NewSSEService(), nil)
+	mockMentionRepo := new(MockMentionRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockSubscriptionRepo := new(MockSubscriptionRepository)
+	mockUserRestrictionRepo := new(MockUserRestrictionRepository)
+	mentionService := NewMentionService(
+		mockMentionRepo,
+		mockUserRepo,
+		mockCommunityRepo,
+		mockSubscriptionRepo,
+		mockUserRestrictionRepo,
+		notificationService,
+	)
+
+	post := &model.Post{ID: 10, CommunityID: 3, AuthorID: 1, Title: "Hi @alice", ContentText: "cc @Bob @carol @dave and @author, mail me@example.com"}
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, IsPrivate: true}, nil)
+	mockUserRepo.On("GetUsersByUsernames", []string{"alice", "Bob", "carol", "dave", "author"}).Return([]*model.User{
+		{ID: 1, Username: "author"}, {ID: 2, Username: "alice"}, {ID: 3, Username: "bob"}, {ID: 4, Username: "carol"}, {ID: 5, Username: "dave"},
+	}, nil)
+	// carol is not a member of the private community
+	mockSubscriptionRepo.On("FilterApprovedSubscriberIDs", uint64(3), []uint64{2, 3, 4, 5}).Return([]uint64{2, 3, 5}, nil)
+	// dave is banned, bob only has a warning
+	mockUserRestrictionRepo.On("FilterBannedUserIDs", uint64(3), []uint64{2, 3, 5}).Return([]uint64{5}, nil)
+	mockMentionRepo.On("ReplaceMentions", constant.MENTION_TARGET_POST, uint64(10), uint64(1), []uint64{2, 3}).Return(nil)
+	// bob was already mentioned before the edit, only alice is new
+	mockMentionRepo.On("GetUnnotifiedMentions", constant.MENTION_TARGET_POST, uint64(10)).Return([]*model.Mention{{ID: 100, UserID: 2}}, nil)
+	mockUserRepo.On("GetUserByID", uint64(1)).Return(&model.User{ID: 1, Username: "author"}, nil)
+	mockMentionRepo.On("MarkMentionsNotified", []uint64{100}).Return(nil)
+	mockNotificationUserRepo.On("GetUserByID", uint64(2)).Return(&model.User{ID: 2, Email: "alice@example.com"}, nil)
+	mockNotificationSettingRepo.On("GetUserNotificationSetting", uint64(2), constant.NOTIFICATION_ACTION_MENTIONED).Return(nil, errors.New("not found"))
+	mockNotificationRepo.On("CreateNotification", mock.MatchedBy(func(notification *model.Notification) bool {
+		return notification.UserID == 2 && notification.Body == `author mentioned you in the post "Hi @alice"`
+	})).Return(nil).Once()
+	mockNotificationRepo.On("GetUnreadCount", uint64(2)).Return(int64(1), nil).Maybe()
+
+	mentionService.SyncPostMentions(context.Background(), post, true)
+
+	mockMentionRepo.AssertExpectations(t)
+	mockNotificationRepo.AssertExpectations(t)
+}
+
+func TestMentionService_SyncPostMentions_PendingPostDoesNotNotify(t *testing.T) {
+	mockMentionRepo := new(MockMentionRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockSubscriptionRepo := new(MockSubscriptionRepository)
+	mockUserRestrictionRepo := new(MockUserRestrictionRepository)
+	mentionService := NewMentionService(
+		mockMentionRepo,
+		mockUserRepo,
+		mockCommunityRepo,
+		mockSubscriptionRepo,
+		mockUserRestrictionRepo,
+		NewNotificationService(nil, nil, nil, nil, nil, nil),
+	)
+
+	post := &model.Post{ID: 10, CommunityID: 3, AuthorID: 1, Title: "Hi @alice"}
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3}, nil)
+	mockUserRepo.On("GetUsersByUsernames", []string{"alice"}).Return([]*model.User{{ID: 2, Username: "alice"}}, nil)
+	mockUserRestrictionRepo.On("FilterBannedUserIDs", uint64(3), []uint64{2}).Return([]uint64{}, nil)
+	mockMentionRepo.On("ReplaceMentions", constant.MENTION_TARGET_POST, uint64(10), uint64(1), []uint64{2}).Return(nil)
+
+	mentionService.SyncPostMentions(context.Background(), post, false)
+
+	mockMentionRepo.AssertExpectations(t)
+	mockMentionRepo.AssertNotCalled(t, "GetUnnotifiedMentions", mock.Anything, mock.Anything)
+	mockSubscriptionRepo.AssertNotCalled(t, "FilterApprovedSubscriberIDs", mock.Anything, mock.Anything)
+}
+
+func TestMentionService_SyncMessageMentions_OnlyParticipants(t *testing.T) {
+	mockMentionRepo := new(MockMentionRepository)
+	mockUserRepo := new(MockUserRepository)
+	mentionService := NewMentionService(
+		mockMentionRepo,
+		mockUserRepo,
+		nil, nil, nil, nil,
+	)
+
+	message := &model.Message{ID: 30, SenderID: 1, Content: "@bob have you asked @carol?"}
+	conversation := &model.Conversation{ID: 4, User1ID: 1, User2ID: 2}
+	mockUserRepo.On("GetUsersByUsernames", []string{"bob", "carol"}).Return([]*model.User{{ID: 2, Username: "bob"}, {ID: 3, Username: "carol"}}, nil)
+	mockMentionRepo.On("ReplaceMentions", constant.MENTION_TARGET_MESSAGE, uint64(30), uint64(1), []uint64{2}).Return(nil)
+
+	mentionService.SyncMessageMentions(context.Background(), message, conversation)
+
+	mockMentionRepo.AssertExpectations(t)
+	mockMentionRepo.AssertNotCalled(t, "GetUnnotifiedMentions", mock.Anything, mock.Anything)
+}
+
+func TestMentionService_GetMentionSuggestions_PrivateCommunityNonMember(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockSubscriptionRepo := new(MockSubscriptionRepository)
+	mentionService := NewMentionService(
+		nil,
+		mockUserRepo,
+		mockCommunityRepo,
+		mockSubscriptionRepo,
+		nil, nil,
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, IsPrivate: true}, nil)
+	mockSubscriptionRepo.On("FilterApprovedSubscriberIDs", uint64(3), []uint64{1}).Return([]uint64{}, nil)
+
+	result, err := mentionService.GetMentionSuggestions(context.Background(), 1, 3, "al")
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "permission denied")
+	mockUserRepo.AssertNotCalled(t, "SearchUsers", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMentionService_GetMentionSuggestions_ExcludesSelf(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	mentionService := NewMentionService(
+		nil,
+		mockUserRepo,
+		mockCommunityRepo,
+		nil, nil, nil,
+	)
+	communityID := uint64(3)
+
+	mockCommunityRepo.On("GetCommunityByID", communityID).Return(&model.Community{ID: communityID}, nil)
+	mockUserRepo.On("SearchUsers", "al", &communityID, 1, constant.MAX_MENTION_SUGGESTIONS+1).Return([]*model.User{
+		{ID: 1, Username: "alex"}, {ID: 2, Username: "alice"},
+	}, int64(2), nil)
+
+	result, err := mentionService.GetMentionSuggestions(context.Background(), 1, communityID, "al")
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, "alice", result[0].Username)
+}
@@ -17,6 +17,7 @@ type MessageService struct {
 	messageAttachmentRepo repository.MessageAttachmentRepository
 	userRepo              repository.UserRepository
 	sseService            *SSEService
+	mentionService        *MentionService
 }
 
 func NewMessageService(
@@ -25,6 +26,7 @@ func NewMessageService(
 	messageAttachmentRepo repository.MessageAttachmentRepository,
 	userRepo repository.UserRepository,
 	sseService *SSEService,
+	mentionService *MentionService,
 ) *MessageService {
 	return &MessageService{
 		conversationRepo:      conversationRepo,
@@ -32,6 +34,7 @@ func NewMessageService(
 		messageAttachmentRepo: messageAttachmentRepo,
 		userRepo:              userRepo,
 		sseService:            sseService,
+		mentionService:        mentionService,
 	}
 }
 
@@ -92,6 +95,10 @@ func (s *MessageService) SendMessage(ctx context.Context, senderID uint64, req *
 		logger.WarnfWithCtx(ctx, "[Warn] Failed to update last message: %v", err)
 	}
 
+	if s.mentionService != nil {
+		s.mentionService.SyncMessageMentions(ctx, message, conversation)
+	}
+
 	fullMessage, err := s.messageRepo.GetMessageByID(message.ID)
 	if err != nil {
 		logger.WarnfWithCtx(ctx, "[Warn] Failed to get full message: %v", err)
@@ -200,7 +207,7 @@ func (s *MessageService) GetMessages(ctx context.Context, userID, conversationID
 		return nil, nil, fmt.Errorf("unauthorized")
 	}
 
-	messages, total, err := s.messageRepo.GetConversationMessages(conversationID, page, limit)
+	messages, total, err := s.messageRepo.GetConversationMessages(conversationID, userID, page, limit)
 	if err != nil {
 		logger.ErrorfWithCtx(ctx, "[Err] Error getting messages: %v", err)
 		return nil, nil, fmt.Errorf("failed to get messages")
@@ -28,6 +28,7 @@ func TestMessageService_SendMessage_Success(t *testing.T) {
 		nil,
 		mockUserRepo,
 		sseService,
+		nil,
 	)
 
 	senderID := uint64(123)
@@ -83,6 +84,7 @@ func TestMessageService_SendMessage_RecipientNotFound(t *testing.T) {
 		nil,
 		mockUserRepo,
 		nil,
+		nil,
 	)
 
 	req := &request.SendMessageRequest{
@@ -110,6 +112,7 @@ func TestMessageService_SendMessage_ConversationError(t *testing.T) {
 		nil,
 		mockUserRepo,
 		nil,
+		nil,
 	)
 
 	senderID := uint64(123)
@@ -143,6 +146,7 @@ func TestMessageService_MarkMessageAsRead_Success(t *testing.T) {
 		nil,
 		nil,
 		sseService,
+		nil,
 	)
 
 	userID := uint64(123)
@@ -186,6 +190,7 @@ func TestMessageService_MarkMessageAsRead_MessageNotFound(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
 	)
 
 	messageID := uint64(999)
@@ -209,6 +214,7 @@ func TestMessageService_MarkMessageAsRead_Unauthorized(t *testing.T) {
 		nil,
 		nil,
 		sseService,
+		nil,
 	)
 
 	userID := uint64(123)
@@ -242,6 +248,7 @@ func TestMessageService_GetConversations_Success(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
 	)
 
 	userID := uint64(123)
@@ -287,6 +294,7 @@ func TestMessageService_GetConversations_EmptyResult(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
 	)
 
 	userID := uint64(123)
@@ -314,6 +322,7 @@ func TestMessageService_GetMessages_Success(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
 	)
 
 	userID := uint64(123)
@@ -339,7 +348,7 @@ func TestMessageService_GetMessages_Success(t *testing.T) {
 	}
 
 	mockConversationRepo.On("CheckUserInConversation", conversationID, userID).Return(true, nil)
-	mockMessageRepo.On("GetConversationMessages", conversationID, page, limit).Return(messages, int64(2), nil)
+	mockMessageRepo.On("GetConversationMessages", conversationID, userID, page, limit).Return(messages, int64(2), nil)
 
 	result, pagination, err := messageService.GetMessages(context.Background(), userID, conversationID, page, limit)
 
@@ -361,6 +370,7 @@ func TestMessageService_GetMessages_Unauthorized(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
 	)
 
 	userID := uint64(123)
@@ -388,6 +398,7 @@ func TestMessageService_GetMessages_ConversationCheckError(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
 	)
 
 	userID := uint64(123)
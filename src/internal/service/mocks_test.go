@@ -1,9 +1,12 @@
 package service
 
 import (
-	"encoding/json"
+	"context"
+	"social-platform-backend/config"
 	"social-platform-backend/internal/domain/model"
 	"social-platform-backend/internal/interface/dto/request"
+	"social-platform-backend/package/util"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -97,14 +100,30 @@ func (m *MockUserRepository) GetUserBadgeHistory(userID uint64) ([]*model.UserBa
 	return args.Get(0).([]*model.UserBadge), args.Error(1)
 }
 
-func (m *MockUserRepository) SearchUsers(searchTerm string, page, limit int) ([]*model.User, int64, error) {
-	args := m.Called(searchTerm, page, limit)
+func (m *MockUserRepository) SearchUsers(searchTerm string, communityID *uint64, page, limit int) ([]*model.User, int64, error) {
+	args := m.Called(searchTerm, communityID, page, limit)
 	if args.Get(0) == nil {
 		return nil, args.Get(1).(int64), args.Error(2)
 	}
 	return args.Get(0).([]*model.User), args.Get(1).(int64), args.Error(2)
 }
 
+func (m *MockUserRepository) GetUsersByUsernames(usernames []string) ([]*model.User, error) {
+	args := m.Called(usernames)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetUsersByIDs(ids []uint64) ([]*model.User, error) {
+	args := m.Called(ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.User), args.Error(1)
+}
+
 type MockUserVerificationRepository struct {
 	mock.Mock
 }
@@ -189,6 +208,14 @@ func (m *MockNotificationSettingRepository) GetUserNotificationSettings(userID u
 	return args.Get(0).([]*model.NotificationSetting), args.Error(1)
 }
 
+func (m *MockNotificationSettingRepository) GetNotificationSettingsByUserIDs(userIDs []uint64, action string) ([]*model.NotificationSetting, error) {
+	args := m.Called(userIDs, action)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.NotificationSetting), args.Error(1)
+}
+
 func (m *MockNotificationSettingRepository) UpdateNotificationSetting(setting *model.NotificationSetting) error {
 	args := m.Called(setting)
 	return args.Error(0)
@@ -328,6 +355,16 @@ func (m *MockCommunityRepository) UpdateRequiresMemberApproval(id uint64, requir
 	return args.Error(0)
 }
 
+func (m *MockCommunityRepository) UpdateReactionEmojis(id uint64, emojis []string) error {
+	args := m.Called(id, emojis)
+	return args.Error(0)
+}
+
+func (m *MockCommunityRepository) UpdateModLogSettings(id uint64, memberAccess, showModerators, showReasons bool) error {
+	args := m.Called(id, memberAccess, showModerators, showReasons)
+	return args.Error(0)
+}
+
 type MockCommunityModeratorRepository struct {
 	mock.Mock
 }
@@ -347,6 +384,14 @@ func (m *MockCommunityModeratorRepository) GetModeratorRole(communityID, userID
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockCommunityModeratorRepository) GetModeratorCustomRole(communityID, userID uint64) (*model.CommunityRole, error) {
+	args := m.Called(communityID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.CommunityRole), args.Error(1)
+}
+
 func (m *MockCommunityModeratorRepository) GetModeratorCommunitiesByUserID(userID uint64) ([]*model.CommunityModerator, error) {
 	args := m.Called(userID)
 	if args.Get(0) == nil {
@@ -385,6 +430,123 @@ func (m *MockUserSavedPostRepository) GetUserSavedPostCount(userID uint64) (int6
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockUserSavedPostRepository) GetUserSavedPosts(userID uint64, searchTitle string, isFollowed *bool, collectionID *uint64, page, limit int) ([]*model.UserSavedPost, int64, error) {
+	args := m.Called(userID, searchTitle, isFollowed, collectionID, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*model.UserSavedPost), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockUserSavedPostRepository) CreateUserSavedPost(userID uint64, savedPost *request.UserSavedPostRequest) error {
+	args := m.Called(userID, savedPost)
+	return args.Error(0)
+}
+
+func (m *MockUserSavedPostRepository) UpdateFollowedStatus(userID, postID uint64, isFollowed bool) error {
+	args := m.Called(userID, postID, isFollowed)
+	return args.Error(0)
+}
+
+func (m *MockUserSavedPostRepository) DeleteUserSavedPost(userID, postID uint64) error {
+	args := m.Called(userID, postID)
+	return args.Error(0)
+}
+
+func (m *MockUserSavedPostRepository) CheckUserSavedPostExists(userID, postID uint64) (bool, error) {
+	args := m.Called(userID, postID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockUserSavedPostRepository) GetFollowersByPostID(postID uint64) ([]uint64, error) {
+	args := m.Called(postID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uint64), args.Error(1)
+}
+
+func (m *MockUserSavedPostRepository) UpdateNote(userID, postID uint64, note *string) error {
+	args := m.Called(userID, postID, note)
+	return args.Error(0)
+}
+
+func (m *MockUserSavedPostRepository) RefreshSnapshots(batchSize int) (int64, int64, error) {
+	args := m.Called(batchSize)
+	return args.Get(0).(int64), args.Get(1).(int64), args.Error(2)
+}
+
+type MockSavedPostCollectionRepository struct {
+	mock.Mock
+}
+
+func (m *MockSavedPostCollectionRepository) CreateCollection(collection *model.SavedPostCollection) error {
+	args := m.Called(collection)
+	return args.Error(0)
+}
+
+func (m *MockSavedPostCollectionRepository) GetCollectionByID(id uint64) (*model.SavedPostCollection, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SavedPostCollection), args.Error(1)
+}
+
+func (m *MockSavedPostCollectionRepository) GetCollectionByShareToken(shareToken string) (*model.SavedPostCollection, error) {
+	args := m.Called(shareToken)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SavedPostCollection), args.Error(1)
+}
+
+func (m *MockSavedPostCollectionRepository) GetUserCollections(userID uint64) ([]*model.SavedPostCollection, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.SavedPostCollection), args.Error(1)
+}
+
+func (m *MockSavedPostCollectionRepository) CountUserCollections(userID uint64) (int64, error) {
+	args := m.Called(userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockSavedPostCollectionRepository) UpdateCollection(id uint64, name string, isPublic bool, shareToken *string) error {
+	args := m.Called(id, name, isPublic, shareToken)
+	return args.Error(0)
+}
+
+func (m *MockSavedPostCollectionRepository) ReorderCollections(userID uint64, collectionIDs []uint64) error {
+	args := m.Called(userID, collectionIDs)
+	return args.Error(0)
+}
+
+func (m *MockSavedPostCollectionRepository) DeleteCollection(id uint64) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockSavedPostCollectionRepository) AddCollectionItem(item *model.SavedPostCollectionItem) error {
+	args := m.Called(item)
+	return args.Error(0)
+}
+
+func (m *MockSavedPostCollectionRepository) RemoveCollectionItem(collectionID, postID uint64) error {
+	args := m.Called(collectionID, postID)
+	return args.Error(0)
+}
+
+func (m *MockSavedPostCollectionRepository) GetCollectionPosts(collectionID uint64, page, limit int) ([]*model.UserSavedPost, int64, error) {
+	args := m.Called(collectionID, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*model.UserSavedPost), args.Get(1).(int64), args.Error(2)
+}
+
 type MockPostRepository struct {
 	mock.Mock
 }
@@ -402,6 +564,14 @@ func (m *MockPostRepository) GetPostByID(id uint64) (*model.Post, error) {
 	return args.Get(0).(*model.Post), args.Error(1)
 }
 
+func (m *MockPostRepository) GetPostsByIDs(ids []uint64) ([]*model.Post, error) {
+	args := m.Called(ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Post), args.Error(1)
+}
+
 func (m *MockPostRepository) GetPostDetailByID(id uint64, userID *uint64) (*model.Post, error) {
 	args := m.Called(id, userID)
 	if args.Get(0) == nil {
@@ -410,23 +580,23 @@ func (m *MockPostRepository) GetPostDetailByID(id uint64, userID *uint64) (*mode
 	return args.Get(0).(*model.Post), args.Error(1)
 }
 
-func (m *MockPostRepository) UpdatePostText(id uint64, updatePost *request.UpdatePostTextRequest) error {
-	args := m.Called(id, updatePost)
+func (m *MockPostRepository) UpdatePostText(id uint64, updatePost *request.UpdatePostTextRequest, revision *model.PostRevision) error {
+	args := m.Called(id, updatePost, revision)
 	return args.Error(0)
 }
 
-func (m *MockPostRepository) UpdatePostLink(id uint64, updatePost *request.UpdatePostLinkRequest) error {
-	args := m.Called(id, updatePost)
+func (m *MockPostRepository) UpdatePostLink(id uint64, updatePost *request.UpdatePostLinkRequest, revision *model.PostRevision) error {
+	args := m.Called(id, updatePost, revision)
 	return args.Error(0)
 }
 
-func (m *MockPostRepository) UpdatePostMedia(id uint64, updatePost *request.UpdatePostMediaRequest) error {
-	args := m.Called(id, updatePost)
+func (m *MockPostRepository) UpdatePostMedia(id uint64, updatePost *request.UpdatePostMediaRequest, revision *model.PostRevision) error {
+	args := m.Called(id, updatePost, revision)
 	return args.Error(0)
 }
 
-func (m *MockPostRepository) UpdatePostPoll(id uint64, updatePost *request.UpdatePostPollRequest) error {
-	args := m.Called(id, updatePost)
+func (m *MockPostRepository) UpdatePostPoll(id uint64, updatePost *request.UpdatePostPollRequest, revision *model.PostRevision) error {
+	args := m.Called(id, updatePost, revision)
 	return args.Error(0)
 }
 
@@ -435,11 +605,6 @@ func (m *MockPostRepository) UpdatePostStatus(id uint64, status string) error {
 	return args.Error(0)
 }
 
-func (m *MockPostRepository) UpdatePollData(postID uint64, pollData *json.RawMessage) error {
-	args := m.Called(postID, pollData)
-	return args.Error(0)
-}
-
 func (m *MockPostRepository) DeletePost(id uint64) error {
 	args := m.Called(id)
 	return args.Error(0)
@@ -490,6 +655,73 @@ func (m *MockPostRepository) GetPostsLastWeekCount(communityID uint64) (int64, e
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockPostRepository) GetDraftPostsByUserID(userID uint64, page, limit int) ([]*model.Post, int64, error) {
+	args := m.Called(userID, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*model.Post), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockPostRepository) UpdateDraftPost(id uint64, draft *request.SaveDraftRequest) error {
+	args := m.Called(id, draft)
+	return args.Error(0)
+}
+
+func (m *MockPostRepository) PublishPost(id uint64, fromStatuses []string, status string, publishedAt time.Time) (bool, error) {
+	args := m.Called(id, fromStatuses, status, publishedAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPostRepository) GetDueScheduledPosts(now time.Time, limit int) ([]*model.Post, error) {
+	args := m.Called(now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Post), args.Error(1)
+}
+
+func (m *MockPostRepository) IsCrosspostedToCommunity(parentID, communityID uint64) (bool, error) {
+	args := m.Called(parentID, communityID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPostRepository) GetCrosspostsByParentID(parentID uint64, userID *uint64) ([]*model.Post, error) {
+	args := m.Called(parentID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Post), args.Error(1)
+}
+
+func (m *MockPostRepository) PinPost(id uint64, pinOrder *int, isAnnouncement bool) (bool, error) {
+	args := m.Called(id, pinOrder, isAnnouncement)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPostRepository) UpdatePostPin(id uint64, pinOrder *int, isAnnouncement bool) error {
+	args := m.Called(id, pinOrder, isAnnouncement)
+	return args.Error(0)
+}
+
+func (m *MockPostRepository) UpdatePostLock(id uint64, lockedAt *time.Time, lockReason *string) error {
+	args := m.Called(id, lockedAt, lockReason)
+	return args.Error(0)
+}
+
+func (m *MockPostRepository) UpdatePostFlair(id uint64, flair *string) error {
+	args := m.Called(id, flair)
+	return args.Error(0)
+}
+
+func (m *MockPostRepository) GetRecentPostsByCommunityID(communityID uint64, limit int) ([]*model.Post, error) {
+	args := m.Called(communityID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Post), args.Error(1)
+}
+
 type MockCommentRepository struct {
 	mock.Mock
 }
@@ -507,6 +739,14 @@ func (m *MockCommentRepository) GetCommentByID(id uint64) (*model.Comment, error
 	return args.Get(0).(*model.Comment), args.Error(1)
 }
 
+func (m *MockCommentRepository) GetCommentsByIDs(ids []uint64) ([]*model.Comment, error) {
+	args := m.Called(ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Comment), args.Error(1)
+}
+
 func (m *MockCommentRepository) GetCommentsByPostID(postID uint64, sortBy string, limit, offset int, userID *uint64) ([]*model.Comment, int64, error) {
 	args := m.Called(postID, sortBy, limit, offset, userID)
 	if args.Get(0) == nil {
@@ -515,24 +755,40 @@ func (m *MockCommentRepository) GetCommentsByPostID(postID uint64, sortBy string
 	return args.Get(0).([]*model.Comment), args.Get(1).(int64), args.Error(2)
 }
 
-func (m *MockCommentRepository) UpdateComment(id uint64, content string, mediaURL *string) error {
-	args := m.Called(id, content, mediaURL)
+func (m *MockCommentRepository) UpdateComment(id uint64, content string, mediaURL *string, revision *model.CommentRevision) error {
+	args := m.Called(id, content, mediaURL, revision)
 	return args.Error(0)
 }
 
-func (m *MockCommentRepository) DeleteComment(commentID uint64, parentCommentID *uint64) error {
-	args := m.Called(commentID, parentCommentID)
+func (m *MockCommentRepository) DeleteComment(commentID uint64, deletedBy string) error {
+	args := m.Called(commentID, deletedBy)
 	return args.Error(0)
 }
 
-func (m *MockCommentRepository) GetRepliesByParentID(parentID uint64, userID *uint64) ([]*model.Comment, error) {
-	args := m.Called(parentID, userID)
+func (m *MockCommentRepository) PurgeCommentTombstones(before time.Time) (int64, error) {
+	args := m.Called(before)
+	return args.Get(0).(int64), args.Error(1)
+}
+func (m *MockCommentRepository) GetCommentSubtrees(postID uint64, parentIDs []uint64, offset, maxDepth, maxReplies int, userID *uint64) ([]*model.Comment, error) {
+	args := m.Called(postID, parentIDs, offset, maxDepth, maxReplies, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) GetCommentPath(commentID uint64, maxAncestors int, userID *uint64) ([]*model.Comment, error) {
+	args := m.Called(commentID, maxAncestors, userID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]*model.Comment), args.Error(1)
 }
 
+func (m *MockCommentRepository) CountReplies(parentID uint64) (int64, error) {
+	args := m.Called(parentID)
+	return args.Get(0).(int64), args.Error(1)
+}
 func (m *MockCommentRepository) GetCommentsByUserID(userID uint64, sortBy string, page, limit int, requestUserID *uint64) ([]*model.Comment, int64, error) {
 	args := m.Called(userID, sortBy, page, limit, requestUserID)
 	if args.Get(0) == nil {
@@ -541,6 +797,27 @@ func (m *MockCommentRepository) GetCommentsByUserID(userID uint64, sortBy string
 	return args.Get(0).([]*model.Comment), args.Get(1).(int64), args.Error(2)
 }
 
+func (m *MockCommentRepository) UpdateCommentLock(id uint64, lockedAt *time.Time, lockReason *string) error {
+	args := m.Called(id, lockedAt, lockReason)
+	return args.Error(0)
+}
+
+func (m *MockCommentRepository) GetLockedAncestor(commentID uint64) (*model.Comment, error) {
+	args := m.Called(commentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) GetRecentCommentsByCommunityID(communityID uint64, limit int) ([]*model.Comment, error) {
+	args := m.Called(communityID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Comment), args.Error(1)
+}
+
 type MockCommentReportRepository struct {
 	mock.Mock
 }
@@ -558,8 +835,16 @@ func (m *MockCommentReportRepository) GetCommentReportsByCommunityID(communityID
 	return args.Get(0).([]*model.CommentReport), args.Get(1).(int64), args.Error(2)
 }
 
-func (m *MockCommentReportRepository) DeleteCommentReport(id uint64) error {
+func (m *MockCommentReportRepository) GetCommentReportByID(id uint64) (*model.CommentReport, error) {
 	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.CommentReport), args.Error(1)
+}
+
+func (m *MockCommentReportRepository) DismissCommentReports(commentID, resolvedBy uint64) error {
+	args := m.Called(commentID, resolvedBy)
 	return args.Error(0)
 }
 
@@ -568,6 +853,14 @@ func (m *MockCommentReportRepository) IsUserReportedComment(userID, commentID ui
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockCommentReportRepository) GetOpenReportsByCommentIDs(commentIDs []uint64) ([]*model.CommentReport, error) {
+	args := m.Called(commentIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.CommentReport), args.Error(1)
+}
+
 type MockSubscriptionRepository struct {
 	mock.Mock
 }
@@ -608,6 +901,22 @@ func (m *MockSubscriptionRepository) UpdateSubscriptionStatus(userID, communityI
 	return args.Error(0)
 }
 
+func (m *MockSubscriptionRepository) GetApprovedSubscriberIDs(communityID uint64) ([]uint64, error) {
+	args := m.Called(communityID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uint64), args.Error(1)
+}
+
+func (m *MockSubscriptionRepository) FilterApprovedSubscriberIDs(communityID uint64, userIDs []uint64) ([]uint64, error) {
+	args := m.Called(communityID, userIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uint64), args.Error(1)
+}
+
 type MockTopicRepository struct {
 	mock.Mock
 }
@@ -629,6 +938,45 @@ func (m *MockPostReportRepository) CreateReport(report *model.PostReport) error
 	return args.Error(0)
 }
 
+func (m *MockPostReportRepository) CreatePostReport(report *model.PostReport) error {
+	args := m.Called(report)
+	return args.Error(0)
+}
+
+func (m *MockPostReportRepository) GetPostReportsByCommunityID(communityID uint64, page, limit int) ([]*model.PostReport, int64, error) {
+	args := m.Called(communityID, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*model.PostReport), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockPostReportRepository) GetPostReportByID(id uint64) (*model.PostReport, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.PostReport), args.Error(1)
+}
+
+func (m *MockPostReportRepository) DismissPostReports(postID, resolvedBy uint64) error {
+	args := m.Called(postID, resolvedBy)
+	return args.Error(0)
+}
+
+func (m *MockPostReportRepository) IsUserReportedPost(userID, postID uint64) (bool, error) {
+	args := m.Called(userID, postID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPostReportRepository) GetOpenReportsByPostIDs(postIDs []uint64) ([]*model.PostReport, error) {
+	args := m.Called(postIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.PostReport), args.Error(1)
+}
+
 type MockBotTaskService struct {
 	mock.Mock
 }
@@ -663,6 +1011,11 @@ func (m *MockNotificationRepository) CreateNotification(notification *model.Noti
 	return args.Error(0)
 }
 
+func (m *MockNotificationRepository) CreateNotifications(notifications []*model.Notification) error {
+	args := m.Called(notifications)
+	return args.Error(0)
+}
+
 func (m *MockNotificationRepository) GetNotificationByID(id uint64) (*model.Notification, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
@@ -717,8 +1070,8 @@ func (m *MockMessageRepository) GetMessageByID(id uint64) (*model.Message, error
 	return args.Get(0).(*model.Message), args.Error(1)
 }
 
-func (m *MockMessageRepository) GetConversationMessages(conversationID uint64, page, limit int) ([]*model.Message, int64, error) {
-	args := m.Called(conversationID, page, limit)
+func (m *MockMessageRepository) GetConversationMessages(conversationID, userID uint64, page, limit int) ([]*model.Message, int64, error) {
+	args := m.Called(conversationID, userID, page, limit)
 	if args.Get(0) == nil {
 		return nil, args.Get(1).(int64), args.Error(2)
 	}
@@ -810,3 +1163,717 @@ type MockSSEService struct {
 func (m *MockSSEService) BroadcastToUser(userID uint64, event interface{}) {
 	m.Called(userID, event)
 }
+
+type MockUserRestrictionRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRestrictionRepository) CreateRestriction(restriction *model.UserRestriction) error {
+	args := m.Called(restriction)
+	return args.Error(0)
+}
+
+func (m *MockUserRestrictionRepository) GetRestrictionByID(id uint64) (*model.UserRestriction, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.UserRestriction), args.Error(1)
+}
+
+func (m *MockUserRestrictionRepository) GetActiveRestrictionByUserAndCommunity(userID, communityID uint64) (*model.UserRestriction, error) {
+	args := m.Called(userID, communityID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.UserRestriction), args.Error(1)
+}
+
+func (m *MockUserRestrictionRepository) FilterBannedUserIDs(communityID uint64, userIDs []uint64) ([]uint64, error) {
+	args := m.Called(communityID, userIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uint64), args.Error(1)
+}
+
+func (m *MockUserRestrictionRepository) GetUserRestrictionHistory(userID uint64, page, limit int) ([]*model.UserRestriction, int64, error) {
+	args := m.Called(userID, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*model.UserRestriction), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockUserRestrictionRepository) DeleteRestriction(id uint64) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+type MockPostRevisionRepository struct {
+	mock.Mock
+}
+
+func (m *MockPostRevisionRepository) GetPostRevisionsByPostID(postID uint64) ([]*model.PostRevision, error) {
+	args := m.Called(postID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.PostRevision), args.Error(1)
+}
+
+type MockCommentRevisionRepository struct {
+	mock.Mock
+}
+
+func (m *MockCommentRevisionRepository) GetCommentRevisionsByCommentID(commentID uint64) ([]*model.CommentRevision, error) {
+	args := m.Called(commentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.CommentRevision), args.Error(1)
+}
+
+type MockModerationLogRepository struct {
+	mock.Mock
+}
+
+func (m *MockModerationLogRepository) CreateModerationLog(log *model.ModerationLog) error {
+	args := m.Called(log)
+	return args.Error(0)
+}
+
+func (m *MockModerationLogRepository) GetModerationLogsByCommunityID(communityID uint64, page, limit int) ([]*model.ModerationLog, int64, error) {
+	args := m.Called(communityID, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*model.ModerationLog), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockModerationLogRepository) GetMemberModerationLogs(communityID uint64, hiddenActions []string, page, limit int) ([]*model.ModerationLog, int64, error) {
+	args := m.Called(communityID, hiddenActions, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*model.ModerationLog), args.Get(1).(int64), args.Error(2)
+}
+
+type MockPollRepository struct {
+	mock.Mock
+}
+
+func (m *MockPollRepository) SyncPoll(poll *model.Poll, options []*model.PollOption) error {
+	args := m.Called(poll, options)
+	return args.Error(0)
+}
+
+func (m *MockPollRepository) ImportPoll(poll *model.Poll, options []*model.PollOption, votes []*model.PollVote) error {
+	args := m.Called(poll, options, votes)
+	return args.Error(0)
+}
+
+func (m *MockPollRepository) GetPollByPostID(postID uint64) (*model.Poll, error) {
+	args := m.Called(postID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Poll), args.Error(1)
+}
+
+func (m *MockPollRepository) GetPollOptionsByPostID(postID uint64) ([]*model.PollOption, error) {
+	args := m.Called(postID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.PollOption), args.Error(1)
+}
+
+func (m *MockPollRepository) AddPollVote(vote *model.PollVote, singleChoice bool) (bool, error) {
+	args := m.Called(vote, singleChoice)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPollRepository) RemovePollVote(postID uint64, optionID int, userID uint64) (bool, error) {
+	args := m.Called(postID, optionID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPollRepository) ReplaceRankedBallot(postID, userID uint64, ballot []*model.PollVote) error {
+	args := m.Called(postID, userID, ballot)
+	return args.Error(0)
+}
+
+func (m *MockPollRepository) RemovePollBallot(postID, userID uint64) (bool, error) {
+	args := m.Called(postID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPollRepository) GetPollVotes(postID uint64) ([]*model.PollVote, error) {
+	args := m.Called(postID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.PollVote), args.Error(1)
+}
+
+func (m *MockPollRepository) GetPollVoterIDs(postID uint64) ([]uint64, error) {
+	args := m.Called(postID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uint64), args.Error(1)
+}
+
+func (m *MockPollRepository) ClosePoll(postID, closedBy uint64, closedAt time.Time) (bool, error) {
+	args := m.Called(postID, closedBy, closedAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPollRepository) GetPollsToFinalize(now time.Time, limit int) ([]*model.Poll, error) {
+	args := m.Called(now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Poll), args.Error(1)
+}
+
+func (m *MockPollRepository) FinalizePoll(postID uint64, finalizedAt time.Time) (bool, error) {
+	args := m.Called(postID, finalizedAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPollRepository) CreateWriteInOption(option *model.PollOption) error {
+	args := m.Called(option)
+	return args.Error(0)
+}
+
+func (m *MockPollRepository) CountPendingWriteIns(postID, userID uint64) (int64, error) {
+	args := m.Called(postID, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockPollRepository) GetPendingWriteIns(postID uint64) ([]*model.PollOption, error) {
+	args := m.Called(postID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.PollOption), args.Error(1)
+}
+
+func (m *MockPollRepository) ApproveWriteInOption(postID uint64, optionID int) (bool, error) {
+	args := m.Called(postID, optionID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPollRepository) DeletePendingWriteIn(postID uint64, optionID int) (bool, error) {
+	args := m.Called(postID, optionID)
+	return args.Bool(0), args.Error(1)
+}
+
+type MockLinkPreviewRepository struct {
+	mock.Mock
+}
+
+func (m *MockLinkPreviewRepository) GetLinkPreviewByURL(url string) (*model.LinkPreview, error) {
+	args := m.Called(url)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.LinkPreview), args.Error(1)
+}
+
+func (m *MockLinkPreviewRepository) UpsertLinkPreview(preview *model.LinkPreview) error {
+	args := m.Called(preview)
+	return args.Error(0)
+}
+
+type MockMediaRepository struct {
+	mock.Mock
+}
+
+func (m *MockMediaRepository) CreateMedia(media *model.Media) error {
+	args := m.Called(media)
+	return args.Error(0)
+}
+
+func (m *MockMediaRepository) GetMediaByUploaderAndHash(uploaderID uint64, contentHash string) (*model.Media, error) {
+	args := m.Called(uploaderID, contentHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Media), args.Error(1)
+}
+
+func (m *MockMediaRepository) CreateSharedMedia(media *model.Media) (bool, error) {
+	args := m.Called(media)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMediaRepository) TouchMedia(id uint64) (bool, error) {
+	args := m.Called(id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMediaRepository) GetOrphanMedia(olderThan time.Time, limit int) ([]*model.Media, error) {
+	args := m.Called(olderThan, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Media), args.Error(1)
+}
+
+// DeleteOrphanMedia returns the error of deleteObjects when the mock says the media is
+// the last one with its content
+func (m *MockMediaRepository) DeleteOrphanMedia(media *model.Media, olderThan time.Time, deleteObjects func() error) error {
+	args := m.Called(media, olderThan)
+	if args.Bool(0) {
+		return deleteObjects()
+	}
+	return args.Error(1)
+}
+
+type MockStorage struct {
+	mock.Mock
+}
+
+func (m *MockStorage) Put(ctx context.Context, key, contentType string, data []byte) (string, error) {
+	args := m.Called(ctx, key, contentType, data)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockStorage) Delete(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+type MockPostAnalyticsRepository struct {
+	mock.Mock
+}
+
+func (m *MockPostAnalyticsRepository) IncrementPostViews(stats []*model.PostViewStat) error {
+	args := m.Called(stats)
+	return args.Error(0)
+}
+
+func (m *MockPostAnalyticsRepository) GetPostTotalViews(postID uint64) (int64, error) {
+	args := m.Called(postID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockPostAnalyticsRepository) GetPostViewTimeline(postID uint64, interval string, from time.Time) ([]*model.PostActivityBucket, error) {
+	args := m.Called(postID, interval, from)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.PostActivityBucket), args.Error(1)
+}
+
+func (m *MockPostAnalyticsRepository) GetPostVoteTimeline(postID uint64, interval string, from time.Time) ([]*model.PostVoteBucket, error) {
+	args := m.Called(postID, interval, from)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.PostVoteBucket), args.Error(1)
+}
+
+func (m *MockPostAnalyticsRepository) GetPostCommentTimeline(postID uint64, interval string, from time.Time) ([]*model.PostActivityBucket, error) {
+	args := m.Called(postID, interval, from)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.PostActivityBucket), args.Error(1)
+}
+
+func (m *MockPostAnalyticsRepository) GetPostReferrals(postID uint64) ([]*model.PostReferral, error) {
+	args := m.Called(postID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.PostReferral), args.Error(1)
+}
+
+func (m *MockPostAnalyticsRepository) CountPostSaves(postID uint64) (int64, error) {
+	args := m.Called(postID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockPostAnalyticsRepository) GetPostVoteScore(postID uint64) (int64, error) {
+	args := m.Called(postID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockPostAnalyticsRepository) CountPostComments(postID uint64) (int64, error) {
+	args := m.Called(postID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type MockPostFingerprintRepository struct {
+	mock.Mock
+}
+
+func (m *MockPostFingerprintRepository) CreatePostFingerprint(fingerprint *model.PostFingerprint, matches []*model.PostDuplicateMatch) error {
+	args := m.Called(fingerprint, matches)
+	return args.Error(0)
+}
+
+func (m *MockPostFingerprintRepository) FindCandidateFingerprints(fingerprint *model.PostFingerprint, authorSince, globalSince time.Time, limit int) ([]*model.PostFingerprint, error) {
+	args := m.Called(fingerprint, authorSince, globalSince, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.PostFingerprint), args.Error(1)
+}
+
+func (m *MockPostFingerprintRepository) GetDuplicateMatchesByCommunityID(communityID uint64, page, limit int) ([]*model.PostDuplicateMatch, int64, error) {
+	args := m.Called(communityID, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*model.PostDuplicateMatch), args.Get(1).(int64), args.Error(2)
+}
+
+type MockReactionRepository struct {
+	mock.Mock
+}
+
+func (m *MockReactionRepository) ToggleReaction(reaction *model.Reaction) (bool, error) {
+	args := m.Called(reaction)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockReactionRepository) GetReactionCounts(targetType string, targetID uint64, userID *uint64) ([]*model.ReactionCount, error) {
+	args := m.Called(targetType, targetID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.ReactionCount), args.Error(1)
+}
+
+func newTestContentSanitizer() *util.HTMLSanitizer {
+	return util.NewContentSanitizer(&config.Config{})
+}
+
+type MockMentionRepository struct {
+	mock.Mock
+}
+
+func (m *MockMentionRepository) ReplaceMentions(targetType string, targetID, mentionerID uint64, userIDs []uint64) error {
+	args := m.Called(targetType, targetID, mentionerID, userIDs)
+	return args.Error(0)
+}
+
+func (m *MockMentionRepository) GetUnnotifiedMentions(targetType string, targetID uint64) ([]*model.Mention, error) {
+	args := m.Called(targetType, targetID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Mention), args.Error(1)
+}
+
+func (m *MockMentionRepository) MarkMentionsNotified(ids []uint64) error {
+	args := m.Called(ids)
+	return args.Error(0)
+}
+
+type MockContentFlagRepository struct {
+	mock.Mock
+}
+
+func (m *MockContentFlagRepository) CreateContentFlag(flag *model.ContentFlag) error {
+	args := m.Called(flag)
+	return args.Error(0)
+}
+
+func (m *MockContentFlagRepository) GetOpenFlagsByTargets(targetType string, targetIDs []uint64) ([]*model.ContentFlag, error) {
+	args := m.Called(targetType, targetIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.ContentFlag), args.Error(1)
+}
+
+type MockModerationQueueRepository struct {
+	mock.Mock
+}
+
+func (m *MockModerationQueueRepository) GetQueueItems(communityID uint64, reason, targetType string, page, limit int) ([]*model.ModerationQueueItem, int64, error) {
+	args := m.Called(communityID, reason, targetType, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*model.ModerationQueueItem), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockModerationQueueRepository) ResolveQueueItem(resolution *model.ModerationQueueResolution) ([]uint64, error) {
+	args := m.Called(resolution)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uint64), args.Error(1)
+}
+
+type MockReportRepository struct {
+	mock.Mock
+}
+
+func (m *MockReportRepository) GetReporterStats(reporterID uint64, since time.Time) (int64, int64, error) {
+	args := m.Called(reporterID, since)
+	return args.Get(0).(int64), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockReportRepository) CreateEscalation(escalation *model.ReportEscalation) error {
+	args := m.Called(escalation)
+	return args.Error(0)
+}
+
+func (m *MockReportRepository) GetOpenEscalation(targetType string, targetID uint64) (*model.ReportEscalation, error) {
+	args := m.Called(targetType, targetID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ReportEscalation), args.Error(1)
+}
+
+func (m *MockReportRepository) GetEscalationByID(id uint64) (*model.ReportEscalation, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ReportEscalation), args.Error(1)
+}
+
+func (m *MockReportRepository) GetEscalations(status string, page, limit int) ([]*model.ReportEscalation, int64, error) {
+	args := m.Called(status, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*model.ReportEscalation), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockReportRepository) ResolveEscalation(escalation *model.ReportEscalation) (bool, error) {
+	args := m.Called(escalation)
+	return args.Bool(0), args.Error(1)
+}
+
+type MockAutoModRuleRepository struct {
+	mock.Mock
+}
+
+func (m *MockAutoModRuleRepository) CreateRule(rule *model.AutoModRule) error {
+	args := m.Called(rule)
+	return args.Error(0)
+}
+
+func (m *MockAutoModRuleRepository) GetRuleByID(id uint64) (*model.AutoModRule, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.AutoModRule), args.Error(1)
+}
+
+func (m *MockAutoModRuleRepository) GetRulesByCommunityID(communityID uint64) ([]*model.AutoModRule, error) {
+	args := m.Called(communityID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.AutoModRule), args.Error(1)
+}
+
+func (m *MockAutoModRuleRepository) GetEnabledRules(communityID uint64, trigger string) ([]*model.AutoModRule, error) {
+	args := m.Called(communityID, trigger)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.AutoModRule), args.Error(1)
+}
+
+func (m *MockAutoModRuleRepository) CountRules(communityID uint64) (int64, error) {
+	args := m.Called(communityID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockAutoModRuleRepository) UpdateRule(rule *model.AutoModRule) error {
+	args := m.Called(rule)
+	return args.Error(0)
+}
+
+func (m *MockAutoModRuleRepository) DeleteRule(id uint64) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockAutoModRuleRepository) MarkRuleFired(firing *model.AutoModRuleFiring) (bool, error) {
+	args := m.Called(firing)
+	return args.Bool(0), args.Error(1)
+}
+
+type MockCommunityRuleRepository struct {
+	mock.Mock
+}
+
+func (m *MockCommunityRuleRepository) CreateRule(rule *model.CommunityRule) error {
+	args := m.Called(rule)
+	return args.Error(0)
+}
+
+func (m *MockCommunityRuleRepository) GetRuleByID(id uint64) (*model.CommunityRule, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.CommunityRule), args.Error(1)
+}
+
+func (m *MockCommunityRuleRepository) GetRulesByCommunityID(communityID uint64) ([]*model.CommunityRule, error) {
+	args := m.Called(communityID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.CommunityRule), args.Error(1)
+}
+
+func (m *MockCommunityRuleRepository) GetRulesByIDs(ids []uint64) ([]*model.CommunityRule, error) {
+	args := m.Called(ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.CommunityRule), args.Error(1)
+}
+
+func (m *MockCommunityRuleRepository) CountRules(communityID uint64) (int64, error) {
+	args := m.Called(communityID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockCommunityRuleRepository) UpdateRule(rule *model.CommunityRule) error {
+	args := m.Called(rule)
+	return args.Error(0)
+}
+
+func (m *MockCommunityRuleRepository) DeleteRule(id uint64) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockCommunityRuleRepository) ReorderRules(communityID uint64, ruleIDs []uint64) error {
+	args := m.Called(communityID, ruleIDs)
+	return args.Error(0)
+}
+
+func (m *MockCommunityRuleRepository) GetRuleStats(communityID uint64, since time.Time) ([]*model.CommunityRuleStat, error) {
+	args := m.Called(communityID, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.CommunityRuleStat), args.Error(1)
+}
+
+// MockCommunityRoleRepository is a mock implementation of CommunityRoleRepository
+type MockCommunityRoleRepository struct {
+	mock.Mock
+}
+
+func (m *MockCommunityRoleRepository) CreateRole(role *model.CommunityRole) error {
+	args := m.Called(role)
+	return args.Error(0)
+}
+
+func (m *MockCommunityRoleRepository) GetRoleByID(id uint64) (*model.CommunityRole, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.CommunityRole), args.Error(1)
+}
+
+func (m *MockCommunityRoleRepository) GetRolesByCommunityID(communityID uint64) ([]*model.CommunityRole, error) {
+	args := m.Called(communityID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.CommunityRole), args.Error(1)
+}
+
+func (m *MockCommunityRoleRepository) CountRoles(communityID uint64) (int64, error) {
+	args := m.Called(communityID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockCommunityRoleRepository) UpdateRole(role *model.CommunityRole) error {
+	args := m.Called(role)
+	return args.Error(0)
+}
+
+func (m *MockCommunityRoleRepository) DeleteRole(id uint64) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockCommunityRoleRepository) CountRoleMembers(roleID uint64) (int64, error) {
+	args := m.Called(roleID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// MockModeratorInvitationRepository is a mock implementation of ModeratorInvitationRepository
+type MockModeratorInvitationRepository struct {
+	mock.Mock
+}
+
+func (m *MockModeratorInvitationRepository) CreateInvitation(invitation *model.ModeratorInvitation) error {
+	args := m.Called(invitation)
+	return args.Error(0)
+}
+
+func (m *MockModeratorInvitationRepository) GetInvitationByID(id uint64) (*model.ModeratorInvitation, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ModeratorInvitation), args.Error(1)
+}
+
+func (m *MockModeratorInvitationRepository) GetPendingInvitation(communityID, inviteeID uint64, invitationType string) (*model.ModeratorInvitation, error) {
+	args := m.Called(communityID, inviteeID, invitationType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ModeratorInvitation), args.Error(1)
+}
+
+func (m *MockModeratorInvitationRepository) GetPendingCommunityInvitations(communityID uint64, now time.Time) ([]*model.ModeratorInvitation, error) {
+	args := m.Called(communityID, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.ModeratorInvitation), args.Error(1)
+}
+
+func (m *MockModeratorInvitationRepository) GetPendingUserInvitations(inviteeID uint64, now time.Time) ([]*model.ModeratorInvitation, error) {
+	args := m.Called(inviteeID, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.ModeratorInvitation), args.Error(1)
+}
+
+func (m *MockModeratorInvitationRepository) CloseInvitation(id uint64, status string, at time.Time) (bool, error) {
+	args := m.Called(id, status, at)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockModeratorInvitationRepository) AcceptInvitation(invitation *model.ModeratorInvitation, moderationLog *model.ModerationLog, at time.Time) (bool, error) {
+	args := m.Called(invitation, moderationLog, at)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockModeratorInvitationRepository) CancelInviterInvitations(communityID, inviterID uint64, at time.Time) (int64, error) {
+	args := m.Called(communityID, inviterID, at)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockModeratorInvitationRepository) ExpireInvitations(now time.Time) (int64, error) {
+	args := m.Called(now)
+	return args.Get(0).(int64), args.Error(1)
+}
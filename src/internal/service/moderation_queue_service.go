@@ -0,0 +1,528 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/domain/repository"
+	"social-platform-backend/internal/interface/dto/request"
+	"social-platform-backend/internal/interface/dto/response"
+	"social-platform-backend/package/constant"
+	"social-platform-backend/package/logger"
+	"social-platform-backend/package/template/payload"
+	"time"
+)
+
+type ModerationQueueService struct {
+	communityRepo          repository.CommunityRepository
+	communityModeratorRepo repository.CommunityModeratorRepository
+	moderationQueueRepo    repository.ModerationQueueRepository
+	postRepo               repository.PostRepository
+	commentRepo            repository.CommentRepository
+	postReportRepo         repository.PostReportRepository
+	commentReportRepo      repository.CommentReportRepository
+	contentFlagRepo        repository.ContentFlagRepository
+	notificationService    *NotificationService
+	mentionService         *MentionService
+	reportService          *ReportService
+	communityRuleRepo      repository.CommunityRuleRepository
+}
+
+func NewModerationQueueService(
+	communityRepo repository.CommunityRepository,
+	communityModeratorRepo repository.CommunityModeratorRepository,
+	moderationQueueRepo repository.ModerationQueueRepository,
+	postRepo repository.PostRepository,
+	commentRepo repository.CommentRepository,
+	postReportRepo repository.PostReportRepository,
+	commentReportRepo repository.CommentReportRepository,
+	contentFlagRepo repository.ContentFlagRepository,
+	notificationService *NotificationService,
+	mentionService *MentionService,
+	reportService *ReportService,
+	communityRuleRepo repository.CommunityRuleRepository,
+) *ModerationQueueService {
+	return &ModerationQueueService{
+		communityRepo:          communityRepo,
+		communityModeratorRepo: communityModeratorRepo,
+		moderationQueueRepo:    moderationQueueRepo,
+		postRepo:               postRepo,
+		commentRepo:            commentRepo,
+		postReportRepo:         postReportRepo,
+		commentReportRepo:      commentReportRepo,
+		contentFlagRepo:        contentFlagRepo,
+		notificationService:    notificationService,
+		mentionService:         mentionService,
+		reportService:          reportService,
+		communityRuleRepo:      communityRuleRepo,
+	}
+}
+
+// GetModerationQueue lists the pending, flagged and reported posts and comments of a
+// community, oldest first. reason and targetType narrow the queue when not empty.
+func (s *ModerationQueueService) GetModerationQueue(ctx context.Context, userID, communityID uint64, reason, targetType string, page, limit int) ([]*response.ModerationQueueItemResponse, *response.Pagination, error) {
+	community, err := s.checkModerator(ctx, userID, communityID, constant.COMMUNITY_PERMISSION_VIEW_REPORTS, "GetModerationQueue")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items, total, err := s.moderationQueueRepo.GetQueueItems(community.ID, reason, targetType, page, limit)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting queue items in ModerationQueueService.GetModerationQueue: %v", err)
+		return nil, nil, fmt.Errorf("failed to get moderation queue")
+	}
+
+	var postIDs, commentIDs []uint64
+	for _, item := range items {
+		if item.TargetType == constant.MODERATION_TARGET_POST {
+			postIDs = append(postIDs, item.TargetID)
+		} else {
+			commentIDs = append(commentIDs, item.TargetID)
+		}
+	}
+
+	itemResponses, err := s.buildQueueItems(ctx, items, postIDs, commentIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pagination := &response.Pagination{
+		Total: total,
+		Page:  page,
+		Limit: limit,
+	}
+	if int64(page*limit) < total {
+		nextURL := fmt.Sprintf("/api/v1/communities/%d/manage/queue?page=%d&limit=%d", communityID, page+1, limit)
+		if reason != "" {
+			nextURL += "&reason=" + reason
+		}
+		if targetType != "" {
+			nextURL += "&type=" + targetType
+		}
+		pagination.NextURL = nextURL
+	}
+
+	return itemResponses, pagination, nil
+}
+
+// buildQueueItems loads the queued content with its open reports and flags in a few
+// batched queries
+func (s *ModerationQueueService) buildQueueItems(ctx context.Context, items []*model.ModerationQueueItem, postIDs, commentIDs []uint64) ([]*response.ModerationQueueItemResponse, error) {
+	posts, err := s.postRepo.GetPostsByIDs(postIDs)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting posts in ModerationQueueService.buildQueueItems: %v", err)
+		return nil, fmt.Errorf("failed to get moderation queue")
+	}
+	comments, err := s.commentRepo.GetCommentsByIDs(commentIDs)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting comments in ModerationQueueService.buildQueueItems: %v", err)
+		return nil, fmt.Errorf("failed to get moderation queue")
+	}
+	postReports, err := s.postReportRepo.GetOpenReportsByPostIDs(postIDs)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting post reports in ModerationQueueService.buildQueueItems: %v", err)
+		return nil, fmt.Errorf("failed to get moderation queue")
+	}
+	commentReports, err := s.commentReportRepo.GetOpenReportsByCommentIDs(commentIDs)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting comment reports in ModerationQueueService.buildQueueItems: %v", err)
+		return nil, fmt.Errorf("failed to get moderation queue")
+	}
+	postFlags, err := s.contentFlagRepo.GetOpenFlagsByTargets(constant.MODERATION_TARGET_POST, postIDs)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting post flags in ModerationQueueService.buildQueueItems: %v", err)
+		return nil, fmt.Errorf("failed to get moderation queue")
+	}
+	commentFlags, err := s.contentFlagRepo.GetOpenFlagsByTargets(constant.MODERATION_TARGET_COMMENT, commentIDs)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting comment flags in ModerationQueueService.buildQueueItems: %v", err)
+		return nil, fmt.Errorf("failed to get moderation queue")
+	}
+
+	itemResponses := make([]*response.ModerationQueueItemResponse, len(items))
+	itemMap := make(map[string]*response.ModerationQueueItemResponse, len(items))
+	for i, item := range items {
+		itemResponses[i] = response.NewModerationQueueItemResponse(item)
+		itemMap[queueItemKey(item.TargetType, item.TargetID)] = itemResponses[i]
+	}
+
+	for _, post := range posts {
+		if item := itemMap[queueItemKey(constant.MODERATION_TARGET_POST, post.ID)]; item != nil {
+			item.Content = response.NewModerationQueuePostContent(post)
+		}
+	}
+	for _, comment := range comments {
+		if item := itemMap[queueItemKey(constant.MODERATION_TARGET_COMMENT, comment.ID)]; item != nil {
+			item.Content = response.NewModerationQueueCommentContent(comment)
+		}
+	}
+
+	for _, report := range postReports {
+		if item := itemMap[queueItemKey(constant.MODERATION_TARGET_POST, report.PostID)]; item != nil {
+			addQueueReport(item, report.Reporter, report.Reasons, report.RuleIDs, report.Note)
+		}
+	}
+	for _, report := range commentReports {
+		if item := itemMap[queueItemKey(constant.MODERATION_TARGET_COMMENT, report.CommentID)]; item != nil {
+			addQueueReport(item, report.Reporter, report.Reasons, report.RuleIDs, report.Note)
+		}
+	}
+	s.setReportRuleTitles(ctx, itemResponses)
+
+	for _, flag := range append(postFlags, commentFlags...) {
+		if item := itemMap[queueItemKey(flag.TargetType, flag.TargetID)]; item != nil {
+			item.Flags = append(item.Flags, response.NewContentFlagInfo(flag))
+		}
+	}
+
+	return itemResponses, nil
+}
+
+// setReportRuleTitles names the rules cited by the reports, deleted rules keep no title
+func (s *ModerationQueueService) setReportRuleTitles(ctx context.Context, items []*response.ModerationQueueItemResponse) {
+	if s.communityRuleRepo == nil {
+		return
+	}
+
+	var ruleIDs []uint64
+	for _, item := range items {
+		for _, rule := range item.ReportRules {
+			ruleIDs = append(ruleIDs, rule.RuleID)
+		}
+	}
+	if len(ruleIDs) == 0 {
+		return
+	}
+
+	rules, err := s.communityRuleRepo.GetRulesByIDs(ruleIDs)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting community rules in ModerationQueueService.setReportRuleTitles: %v", err)
+		return
+	}
+	titles := make(map[uint64]string, len(rules))
+	for _, rule := range rules {
+		titles[rule.ID] = rule.Title
+	}
+	for _, item := range items {
+		for i := range item.ReportRules {
+			item.ReportRules[i].Title = titles[item.ReportRules[i].RuleID]
+		}
+	}
+}
+
+func queueItemKey(targetType string, targetID uint64) string {
+	return fmt.Sprintf("%s:%d", targetType, targetID)
+}
+
+// addQueueReport adds the reporter and counts each reason and cited rule, in the order
+// they were first seen
+func addQueueReport(item *response.ModerationQueueItemResponse, reporter *model.User, reasons []string, ruleIDs []int64, note *string) {
+	if reporter != nil {
+		item.Reporters = append(item.Reporters, response.NewReporterInfo(reporter, reasons, ruleIDs, note))
+	}
+	for _, ruleID := range ruleIDs {
+		found := false
+		for i := range item.ReportRules {
+			if item.ReportRules[i].RuleID == uint64(ruleID) {
+				item.ReportRules[i].Count++
+				found = true
+				break
+			}
+		}
+		if !found {
+			item.ReportRules = append(item.ReportRules, response.ReportRuleCount{RuleID: uint64(ruleID), Count: 1})
+		}
+	}
+	for _, reason := range reasons {
+		found := false
+		for i := range item.ReportReasons {
+			if item.ReportReasons[i].Reason == reason {
+				item.ReportReasons[i].Count++
+				found = true
+				break
+			}
+		}
+		if !found {
+			item.ReportReasons = append(item.ReportReasons, response.ReportReasonCount{Reason: reason, Count: 1})
+		}
+	}
+}
+
+// ApplyQueueAction acts on a queued post or comment. The content change, the resolution
+// of its reports and flags and the moderation log entry are saved together.
+func (s *ModerationQueueService) ApplyQueueAction(ctx context.Context, userID, communityID uint64, targetType string, targetID uint64, req *request.ModerationQueueActionRequest) error {
+	community, err := s.checkModerator(ctx, userID, communityID, queueActionPermission(targetType, req.Action), "ApplyQueueAction")
+	if err != nil {
+		return err
+	}
+
+	var post *model.Post
+	var comment *model.Comment
+	var authorID uint64
+	var isLocked bool
+	switch targetType {
+	case constant.MODERATION_TARGET_POST:
+		post, err = s.postRepo.GetPostByID(targetID)
+		if err != nil || post.CommunityID != communityID {
+			logger.ErrorfWithCtx(ctx, "[Err] Post not found in ModerationQueueService.ApplyQueueAction: postID=%d, communityID=%d, err=%v", targetID, communityID, err)
+			return fmt.Errorf("post not found")
+		}
+		authorID = post.AuthorID
+		isLocked = post.LockedAt != nil
+	case constant.MODERATION_TARGET_COMMENT:
+		comment, err = s.commentRepo.GetCommentByID(targetID)
+		if err != nil || comment.DeletedAt != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Comment not found in ModerationQueueService.ApplyQueueAction: %v", err)
+			return fmt.Errorf("comment not found")
+		}
+		post, err = s.postRepo.GetPostByID(comment.PostID)
+		if err != nil || post.CommunityID != communityID {
+			logger.ErrorfWithCtx(ctx, "[Err] Comment does not belong to community in ModerationQueueService.ApplyQueueAction: commentID=%d, communityID=%d", targetID, communityID)
+			return fmt.Errorf("comment not found")
+		}
+		authorID = comment.AuthorID
+		isLocked = comment.LockedAt != nil
+	default:
+		return fmt.Errorf("invalid target type")
+	}
+
+	// Only removals cite a community rule
+	var rule *model.CommunityRule
+	if req.RuleID != nil {
+		if req.Action != constant.QUEUE_ACTION_REMOVE {
+			return fmt.Errorf("rule can only be cited for removals")
+		}
+		if rule, err = getRemovalRule(ctx, s.communityRuleRepo, communityID, targetType, req.RuleID); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	resolution := &model.ModerationQueueResolution{
+		CommunityID: communityID,
+		TargetType:  targetType,
+		TargetID:    targetID,
+		Action:      req.Action,
+		ActorID:     userID,
+		Reason:      req.Reason,
+		ModerationLog: &model.ModerationLog{
+			CommunityID: communityID,
+			ActorID:     userID,
+			TargetType:  targetType,
+			TargetID:    targetID,
+			Reason:      req.Reason,
+			RuleID:      req.RuleID,
+			CreatedAt:   now,
+		},
+	}
+
+	isPost := targetType == constant.MODERATION_TARGET_POST
+	switch req.Action {
+	case constant.QUEUE_ACTION_APPROVE:
+		resolution.ModerationLog.Action = constant.MODERATION_ACTION_APPROVE_COMMENT
+		if isPost {
+			resolution.ModerationLog.Action = constant.MODERATION_ACTION_APPROVE_POST
+		}
+	case constant.QUEUE_ACTION_REMOVE:
+		resolution.ModerationLog.Action = constant.MODERATION_ACTION_REMOVE_COMMENT
+		if isPost {
+			resolution.ModerationLog.Action = constant.MODERATION_ACTION_REMOVE_POST
+		}
+	case constant.QUEUE_ACTION_IGNORE_REPORTS:
+		resolution.ModerationLog.Action = constant.MODERATION_ACTION_IGNORE_REPORTS
+	case constant.QUEUE_ACTION_LOCK:
+		if isLocked {
+			return fmt.Errorf("content is already locked")
+		}
+		resolution.ModerationLog.Action = constant.MODERATION_ACTION_LOCK_COMMENT
+		if isPost {
+			resolution.ModerationLog.Action = constant.MODERATION_ACTION_LOCK_POST
+		}
+	case constant.QUEUE_ACTION_BAN_AUTHOR:
+		restriction, err := newQueueRestriction(req, authorID, communityID, userID, now)
+		if err != nil {
+			return err
+		}
+		if err := s.checkBanAuthor(ctx, userID, communityID, authorID); err != nil {
+			return err
+		}
+		resolution.Restriction = restriction
+		resolution.ModerationLog.Action = constant.MODERATION_ACTION_BAN_USER
+		resolution.ModerationLog.TargetType = constant.MODERATION_TARGET_USER
+		resolution.ModerationLog.TargetID = authorID
+	default:
+		return fmt.Errorf("invalid action")
+	}
+
+	// Reports on approved or ignored content were not acted on
+	resolution.ReportStatus = constant.REPORT_STATUS_ACTIONED
+	if req.Action == constant.QUEUE_ACTION_APPROVE || req.Action == constant.QUEUE_ACTION_IGNORE_REPORTS {
+		resolution.ReportStatus = constant.REPORT_STATUS_DISMISSED
+	}
+
+	reporterIDs, err := s.moderationQueueRepo.ResolveQueueItem(resolution)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error resolving queue item in ModerationQueueService.ApplyQueueAction: %v", err)
+		return fmt.Errorf("failed to apply queue action")
+	}
+
+	s.notifyQueueAction(ctx, community, resolution, post, comment, authorID, rule)
+	if req.NotifyReporters && resolution.ReportStatus == constant.REPORT_STATUS_ACTIONED && s.reportService != nil && len(reporterIDs) > 0 {
+		var commentID *uint64
+		if comment != nil {
+			commentID = &comment.ID
+		}
+		s.reportService.NotifyReporters(ctx, community, reporterIDs, post.ID, commentID)
+	}
+	return nil
+}
+
+// checkBanAuthor keeps moderators from banning the super admin or a moderator whose
+// permissions they do not hold, the same as RemoveMember
+func (s *ModerationQueueService) checkBanAuthor(ctx context.Context, userID, communityID, authorID uint64) error {
+	callerAccess, err := getCommunityAccess(s.communityModeratorRepo, communityID, userID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting moderator role in ModerationQueueService.checkBanAuthor: %v", err)
+		return fmt.Errorf("failed to apply queue action")
+	}
+	authorAccess, err := getCommunityAccess(s.communityModeratorRepo, communityID, authorID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting author role in ModerationQueueService.checkBanAuthor: %v", err)
+		return fmt.Errorf("failed to apply queue action")
+	}
+	if authorAccess.Role == constant.ROLE_SUPER_ADMIN {
+		return fmt.Errorf("cannot ban the super admin")
+	}
+	if !hasAllPermissions(callerAccess, authorAccess.Permissions) {
+		return fmt.Errorf("role exceeds your permissions")
+	}
+	return nil
+}
+
+// newQueueRestriction validates the ban of a queued content's author the same way BanUser does
+func newQueueRestriction(req *request.ModerationQueueActionRequest, authorID, communityID, moderatorID uint64, now time.Time) (*model.UserRestriction, error) {
+	if authorID == moderatorID {
+		return nil, fmt.Errorf("cannot ban yourself")
+	}
+	if req.Reason == nil || *req.Reason == "" {
+		return nil, fmt.Errorf("reason is required to ban the author")
+	}
+
+	switch req.RestrictionType {
+	case constant.RESTRICTION_TEMPORARY_BAN:
+		if req.ExpiresAt == nil {
+			return nil, fmt.Errorf("temporary ban requires expiry date")
+		}
+		if req.ExpiresAt.Before(now) {
+			return nil, fmt.Errorf("expiry date must be in the future")
+		}
+	case constant.RESTRICTION_PERMANENT_BAN, constant.RESTRICTION_WARNING:
+		if req.ExpiresAt != nil {
+			return nil, fmt.Errorf("permanent ban and warning cannot have expiry date")
+		}
+	default:
+		return nil, fmt.Errorf("invalid restriction type")
+	}
+
+	return &model.UserRestriction{
+		UserID:          authorID,
+		CommunityID:     communityID,
+		RestrictionType: req.RestrictionType,
+		Reason:          *req.Reason,
+		IssuedBy:        moderatorID,
+		ExpiresAt:       req.ExpiresAt,
+		CreatedAt:       now,
+	}, nil
+}
+
+// notifyQueueAction tells the author what happened to their content once it is saved,
+// with the community rule cited for a removal
+func (s *ModerationQueueService) notifyQueueAction(ctx context.Context, community *model.Community, resolution *model.ModerationQueueResolution, post *model.Post, comment *model.Comment, authorID uint64, rule *model.CommunityRule) {
+	if s.notificationService == nil {
+		return
+	}
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.ErrorfWithCtx(ctx, "[Panic] Recovered in ModerationQueueService.notifyQueueAction: %v", r)
+			}
+		}()
+
+		var err error
+		switch {
+		case resolution.Action == constant.QUEUE_ACTION_APPROVE && comment == nil &&
+			(post.Status == constant.POST_STATUS_PENDING || post.Status == constant.POST_STATUS_REJECTED):
+			err = s.notificationService.CreateNotification(ctx, authorID, constant.NOTIFICATION_ACTION_POST_STATUS_UPDATED, payload.PostStatusNotificationPayload{
+				PostID: post.ID,
+				Status: "approved",
+			})
+			if s.mentionService != nil {
+				s.mentionService.NotifyPostMentions(ctx, post)
+			}
+		case resolution.Action == constant.QUEUE_ACTION_REMOVE && comment == nil:
+			notifPayload := payload.PostDeletedNotificationPayload{
+				PostID: post.ID,
+			}
+			if rule != nil {
+				notifPayload.RuleID = &rule.ID
+				notifPayload.RuleTitle = rule.Title
+			}
+			err = s.notificationService.CreateNotification(ctx, authorID, constant.NOTIFICATION_ACTION_POST_DELETED, notifPayload)
+		case resolution.Action == constant.QUEUE_ACTION_REMOVE:
+			notifPayload := payload.CommentDeletedNotificationPayload{
+				CommentID: comment.ID,
+				PostID:    comment.PostID,
+			}
+			if rule != nil {
+				notifPayload.RuleID = &rule.ID
+				notifPayload.RuleTitle = rule.Title
+			}
+			err = s.notificationService.CreateNotification(ctx, authorID, constant.NOTIFICATION_ACTION_COMMENT_DELETED, notifPayload)
+		case resolution.Action == constant.QUEUE_ACTION_BAN_AUTHOR:
+			expiresAtStr := ""
+			if resolution.Restriction.ExpiresAt != nil {
+				expiresAtStr = resolution.Restriction.ExpiresAt.Format("2006-01-02 15:04:05")
+			}
+			err = s.notificationService.CreateNotification(ctx, authorID, constant.NOTIFICATION_ACTION_USER_BANNED, payload.UserBanNotificationPayload{
+				CommunityID:     community.ID,
+				CommunityName:   community.Name,
+				RestrictionType: resolution.Restriction.RestrictionType,
+				Reason:          resolution.Restriction.Reason,
+				ExpiresAt:       expiresAtStr,
+			})
+		}
+		if err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Error sending notification in ModerationQueueService.notifyQueueAction: %v", err)
+		}
+	}()
+}
+
+func (s *ModerationQueueService) checkModerator(ctx context.Context, userID, communityID uint64, permission, method string) (*model.Community, error) {
+	// Check if community exists
+	community, err := s.communityRepo.GetCommunityByID(communityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Community not found in ModerationQueueService.%s: %v", method, err)
+		return nil, fmt.Errorf("community not found")
+	}
+
+	if err := checkCommunityPermission(ctx, s.communityModeratorRepo, communityID, userID, permission, "ModerationQueueService."+method); err != nil {
+		return nil, err
+	}
+
+	return community, nil
+}
+
+// queueActionPermission is the permission a queue action needs on a post or a comment
+func queueActionPermission(targetType, action string) string {
+	switch action {
+	case constant.QUEUE_ACTION_BAN_AUTHOR:
+		return constant.COMMUNITY_PERMISSION_BAN_USERS
+	case constant.QUEUE_ACTION_IGNORE_REPORTS:
+		return constant.COMMUNITY_PERMISSION_VIEW_REPORTS
+	}
+	if targetType == constant.MODERATION_TARGET_COMMENT {
+		return constant.COMMUNITY_PERMISSION_MANAGE_COMMENTS
+	}
+	return constant.COMMUNITY_PERMISSION_MANAGE_POSTS
+}
@@ -0,0 +1,359 @@
+package service
+
+import (
+	"context"
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/interface/dto/request"
+	"social-platform-backend/internal/interface/dto/response"
+	"social-platform-backend/package/constant"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestModerationQueueService_GetModerationQueue_AggregatesReportsAndFlags(t *testing.T) {
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockModerationQueueRepo := new(MockModerationQueueRepository)
+	mockPostRepo := new(MockPostRepository)
+	mockCommentRepo := new(MockCommentRepository)
+	mockPostReportRepo := new(MockPostReportRepository)
+	mockCommentReportRepo := new(MockCommentReportRepository)
+	mockContentFlagRepo := new(MockContentFlagRepository)
+	mockCommunityRuleRepo := new(MockCommunityRuleRepository)
+	moderationQueueService := NewModerationQueueService(
+		mockCommunityRepo,
+		mockCommunityModeratorRepo,
+		mockModerationQueueRepo,
+		mockPostRepo,
+		mockCommentRepo,
+		mockPostReportRepo,
+		mockCommentReportRepo,
+		mockContentFlagRepo,
+		nil, nil, nil,
+		mockCommunityRuleRepo,
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, Name: "golang"}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(1)).Return(constant.ROLE_ADMIN, nil)
+	mockModerationQueueRepo.On("GetQueueItems", uint64(3), "", "", 1, 10).Return([]*model.ModerationQueueItem{
+		{TargetType: constant.MODERATION_TARGET_POST, TargetID: 10, ReportCount: 2, FlagCount: 1},
+		{TargetType: constant.MODERATION_TARGET_COMMENT, TargetID: 20, ReportCount: 1},
+	}, int64(2), nil)
+	mockPostRepo.On("GetPostsByIDs", []uint64{10}).Return([]*model.Post{{ID: 10, Title: "Buy now", Status: constant.POST_STATUS_APPROVED}}, nil)
+	mockCommentRepo.On("GetCommentsByIDs", []uint64{20}).Return([]*model.Comment{{ID: 20, PostID: 11, Content: "rude", Post: &model.Post{ID: 11, Title: "Question"}}}, nil)
+	mockPostReportRepo.On("GetOpenReportsByPostIDs", []uint64{10}).Return([]*model.PostReport{
+		{PostID: 10, Reporter: &model.User{ID: 5}, Reasons: pq.StringArray{"spam", "scam"}},
+		{PostID: 10, Reporter: &model.User{ID: 6}, Reasons: pq.StringArray{"spam"}, RuleIDs: pq.Int64Array{4}},
+	}, nil)
+	mockCommentReportRepo.On("GetOpenReportsByCommentIDs", []uint64{20}).Return([]*model.CommentReport{
+		{CommentID: 20, Reporter: &model.User{ID: 5}, Reasons: pq.StringArray{"harassment"}},
+	}, nil)
+	mockContentFlagRepo.On("GetOpenFlagsByTargets", constant.MODERATION_TARGET_POST, []uint64{10}).Return([]*model.ContentFlag{
+		{TargetType: constant.MODERATION_TARGET_POST, TargetID: 10, Source: constant.CONTENT_FLAG_SOURCE_DUPLICATE, Reason: "matches 2 recent posts"},
+	}, nil)
+	mockContentFlagRepo.On("GetOpenFlagsByTargets", constant.MODERATION_TARGET_COMMENT, []uint64{20}).Return([]*model.ContentFlag{}, nil)
+	mockCommunityRuleRepo.On("GetRulesByIDs", []uint64{4}).Return([]*model.CommunityRule{{ID: 4, CommunityID: 3, Title: "No spam"}}, nil)
+
+	result, pagination, err := moderationQueueService.GetModerationQueue(context.Background(), 1, 3, "", "", 1, 10)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, "Buy now", result[0].Content.PostTitle)
+	assert.Len(t, result[0].Reporters, 2)
+	assert.Equal(t, "spam", result[0].ReportReasons[0].Reason)
+	assert.Equal(t, 2, result[0].ReportReasons[0].Count)
+	assert.Equal(t, 1, result[0].ReportReasons[1].Count)
+	assert.Equal(t, []response.ReportRuleCount{{RuleID: 4, Title: "No spam", Count: 1}}, result[0].ReportRules)
+	assert.Len(t, result[0].Flags, 1)
+	assert.Equal(t, uint64(20), *result[1].Content.CommentID)
+	assert.Equal(t, "Question", result[1].Content.PostTitle)
+	assert.Empty(t, result[1].Flags)
+	assert.Empty(t, pagination.NextURL)
+}
+
+func TestModerationQueueService_GetModerationQueue_NotModerator(t *testing.T) {
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockModerationQueueRepo := new(MockModerationQueueRepository)
+	moderationQueueService := NewModerationQueueService(
+		mockCommunityRepo,
+		mockCommunityModeratorRepo,
+		mockModerationQueueRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, Name: "golang"}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(1)).Return("", assert.AnError)
+
+	result, _, err := moderationQueueService.GetModerationQueue(context.Background(), 1, 3, "", "", 1, 10)
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "permission denied")
+	mockModerationQueueRepo.AssertNotCalled(t, "GetQueueItems", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestModerationQueueService_ApplyQueueAction_RemoveComment(t *testing.T) {
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockModerationQueueRepo := new(MockModerationQueueRepository)
+	mockPostRepo := new(MockPostRepository)
+	mockCommentRepo := new(MockCommentRepository)
+	moderationQueueService := NewModerationQueueService(
+		mockCommunityRepo,
+		mockCommunityModeratorRepo,
+		mockModerationQueueRepo,
+		mockPostRepo,
+		mockCommentRepo,
+		nil, nil, nil, nil, nil, nil, nil,
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, Name: "golang"}, nil)
+	reason := "harassment"
+
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(1)).Return(constant.ROLE_SUPER_ADMIN, nil)
+	mockCommentRepo.On("GetCommentByID", uint64(20)).Return(&model.Comment{ID: 20, PostID: 11, AuthorID: 7}, nil)
+	mockPostRepo.On("GetPostByID", uint64(11)).Return(&model.Post{ID: 11, CommunityID: 3}, nil)
+	mockModerationQueueRepo.On("ResolveQueueItem", mock.MatchedBy(func(resolution *model.ModerationQueueResolution) bool {
+		return resolution.TargetType == constant.MODERATION_TARGET_COMMENT && resolution.TargetID == 20 &&
+			resolution.Action == constant.QUEUE_ACTION_REMOVE && resolution.ActorID == 1 && resolution.Restriction == nil &&
+			resolution.ReportStatus == constant.REPORT_STATUS_ACTIONED &&
+			resolution.ModerationLog.Action == constant.MODERATION_ACTION_REMOVE_COMMENT && *resolution.ModerationLog.Reason == reason
+	})).Return(nil, nil)
+
+	err := moderationQueueService.ApplyQueueAction(context.Background(), 1, 3, constant.MODERATION_TARGET_COMMENT, 20, &request.ModerationQueueActionRequest{
+		Action: constant.QUEUE_ACTION_REMOVE,
+		Reason: &reason,
+	})
+
+	assert.NoError(t, err)
+	mockModerationQueueRepo.AssertExpectations(t)
+}
+
+func TestModerationQueueService_ApplyQueueAction_RemoveCitesRule(t *testing.T) {
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockModerationQueueRepo := new(MockModerationQueueRepository)
+	mockPostRepo := new(MockPostRepository)
+	mockCommunityRuleRepo := new(MockCommunityRuleRepository)
+	moderationQueueService := NewModerationQueueService(
+		mockCommunityRepo,
+		mockCommunityModeratorRepo,
+		mockModerationQueueRepo,
+		mockPostRepo,
+		nil, nil, nil, nil, nil, nil, nil,
+		mockCommunityRuleRepo,
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, Name: "golang"}, nil)
+	ruleID := uint64(4)
+
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(1)).Return(constant.ROLE_ADMIN, nil)
+	mockPostRepo.On("GetPostByID", uint64(11)).Return(&model.Post{ID: 11, CommunityID: 3, AuthorID: 7}, nil)
+	mockCommunityRuleRepo.On("GetRuleByID", ruleID).Return(&model.CommunityRule{ID: ruleID, CommunityID: 3, Title: "No spam", AppliesTo: constant.COMMUNITY_RULE_APPLIES_TO_POSTS}, nil)
+	mockModerationQueueRepo.On("ResolveQueueItem", mock.MatchedBy(func(resolution *model.ModerationQueueResolution) bool {
+		return resolution.ModerationLog.Action == constant.MODERATION_ACTION_REMOVE_POST &&
+			resolution.ModerationLog.RuleID != nil && *resolution.ModerationLog.RuleID == ruleID
+	})).Return(nil, nil)
+
+	err := moderationQueueService.ApplyQueueAction(context.Background(), 1, 3, constant.MODERATION_TARGET_POST, 11, &request.ModerationQueueActionRequest{
+		Action: constant.QUEUE_ACTION_REMOVE,
+		RuleID: &ruleID,
+	})
+
+	assert.NoError(t, err)
+	mockModerationQueueRepo.AssertExpectations(t)
+}
+
+func TestModerationQueueService_ApplyQueueAction_RuleNotForComments(t *testing.T) {
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockModerationQueueRepo := new(MockModerationQueueRepository)
+	mockPostRepo := new(MockPostRepository)
+	mockCommentRepo := new(MockCommentRepository)
+	mockCommunityRuleRepo := new(MockCommunityRuleRepository)
+	moderationQueueService := NewModerationQueueService(
+		mockCommunityRepo,
+		mockCommunityModeratorRepo,
+		mockModerationQueueRepo,
+		mockPostRepo,
+		mockCommentRepo,
+		nil, nil, nil, nil, nil, nil,
+		mockCommunityRuleRepo,
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, Name: "golang"}, nil)
+	ruleID := uint64(4)
+
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(1)).Return(constant.ROLE_ADMIN, nil)
+	mockCommentRepo.On("GetCommentByID", uint64(20)).Return(&model.Comment{ID: 20, PostID: 11, AuthorID: 7}, nil)
+	mockPostRepo.On("GetPostByID", uint64(11)).Return(&model.Post{ID: 11, CommunityID: 3}, nil)
+	mockCommunityRuleRepo.On("GetRuleByID", ruleID).Return(&model.CommunityRule{ID: ruleID, CommunityID: 3, Title: "No spam", AppliesTo: constant.COMMUNITY_RULE_APPLIES_TO_POSTS}, nil)
+
+	err := moderationQueueService.ApplyQueueAction(context.Background(), 1, 3, constant.MODERATION_TARGET_COMMENT, 20, &request.ModerationQueueActionRequest{
+		Action: constant.QUEUE_ACTION_REMOVE,
+		RuleID: &ruleID,
+	})
+
+	assert.EqualError(t, err, "invalid rule")
+	mockModerationQueueRepo.AssertNotCalled(t, "ResolveQueueItem", mock.Anything)
+}
+
+func TestModerationQueueService_ApplyQueueAction_BanAuthor(t *testing.T) {
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockModerationQueueRepo := new(MockModerationQueueRepository)
+	mockPostRepo := new(MockPostRepository)
+	moderationQueueService := NewModerationQueueService(
+		mockCommunityRepo,
+		mockCommunityModeratorRepo,
+		mockModerationQueueRepo,
+		mockPostRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil,
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, Name: "golang"}, nil)
+	reason := "spam account"
+
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(1)).Return(constant.ROLE_ADMIN, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(7)).Return("", nil)
+	mockPostRepo.On("GetPostByID", uint64(10)).Return(&model.Post{ID: 10, CommunityID: 3, AuthorID: 7}, nil)
+	mockModerationQueueRepo.On("ResolveQueueItem", mock.MatchedBy(func(resolution *model.ModerationQueueResolution) bool {
+		return resolution.TargetType == constant.MODERATION_TARGET_POST && resolution.TargetID == 10 &&
+			resolution.Restriction != nil && resolution.Restriction.UserID == 7 && resolution.Restriction.IssuedBy == 1 &&
+			resolution.ModerationLog.Action == constant.MODERATION_ACTION_BAN_USER &&
+			resolution.ModerationLog.TargetType == constant.MODERATION_TARGET_USER && resolution.ModerationLog.TargetID == 7
+	})).Return(nil, nil)
+
+	err := moderationQueueService.ApplyQueueAction(context.Background(), 1, 3, constant.MODERATION_TARGET_POST, 10, &request.ModerationQueueActionRequest{
+		Action:          constant.QUEUE_ACTION_BAN_AUTHOR,
+		Reason:          &reason,
+		RestrictionType: constant.RESTRICTION_PERMANENT_BAN,
+	})
+
+	assert.NoError(t, err)
+	mockModerationQueueRepo.AssertExpectations(t)
+}
+
+func TestModerationQueueService_ApplyQueueAction_BanAuthorSuperAdmin(t *testing.T) {
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockModerationQueueRepo := new(MockModerationQueueRepository)
+	mockPostRepo := new(MockPostRepository)
+	moderationQueueService := NewModerationQueueService(
+		mockCommunityRepo,
+		mockCommunityModeratorRepo,
+		mockModerationQueueRepo,
+		mockPostRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil,
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, Name: "golang"}, nil)
+	reason := "spam account"
+
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(1)).Return(constant.ROLE_ADMIN, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(7)).Return(constant.ROLE_SUPER_ADMIN, nil)
+	mockPostRepo.On("GetPostByID", uint64(10)).Return(&model.Post{ID: 10, CommunityID: 3, AuthorID: 7}, nil)
+
+	err := moderationQueueService.ApplyQueueAction(context.Background(), 1, 3, constant.MODERATION_TARGET_POST, 10, &request.ModerationQueueActionRequest{
+		Action:          constant.QUEUE_ACTION_BAN_AUTHOR,
+		Reason:          &reason,
+		RestrictionType: constant.RESTRICTION_PERMANENT_BAN,
+	})
+
+	assert.EqualError(t, err, "cannot ban the super admin")
+	mockModerationQueueRepo.AssertNotCalled(t, "ResolveQueueItem", mock.Anything)
+}
+
+func TestModerationQueueService_ApplyQueueAction_BanAuthorWithMorePermissions(t *testing.T) {
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockModerationQueueRepo := new(MockModerationQueueRepository)
+	mockPostRepo := new(MockPostRepository)
+	moderationQueueService := NewModerationQueueService(
+		mockCommunityRepo,
+		mockCommunityModeratorRepo,
+		mockModerationQueueRepo,
+		mockPostRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil,
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, Name: "golang"}, nil)
+	reason := "spam account"
+
+	// a moderator who can only ban users tries to ban an admin
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(1)).Return(constant.ROLE_MODERATOR, nil)
+	mockCommunityModeratorRepo.On("GetModeratorCustomRole", uint64(3), uint64(1)).Return(&model.CommunityRole{
+		ID:          5,
+		CommunityID: 3,
+		Permissions: []string{constant.COMMUNITY_PERMISSION_BAN_USERS},
+	}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(7)).Return(constant.ROLE_ADMIN, nil)
+	mockPostRepo.On("GetPostByID", uint64(10)).Return(&model.Post{ID: 10, CommunityID: 3, AuthorID: 7}, nil)
+
+	err := moderationQueueService.ApplyQueueAction(context.Background(), 1, 3, constant.MODERATION_TARGET_POST, 10, &request.ModerationQueueActionRequest{
+		Action:          constant.QUEUE_ACTION_BAN_AUTHOR,
+		Reason:          &reason,
+		RestrictionType: constant.RESTRICTION_PERMANENT_BAN,
+	})
+
+	assert.EqualError(t, err, "role exceeds your permissions")
+	mockModerationQueueRepo.AssertNotCalled(t, "ResolveQueueItem", mock.Anything)
+}
+
+func TestModerationQueueService_ApplyQueueAction_PostOfAnotherCommunity(t *testing.T) {
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockModerationQueueRepo := new(MockModerationQueueRepository)
+	mockPostRepo := new(MockPostRepository)
+	moderationQueueService := NewModerationQueueService(
+		mockCommunityRepo,
+		mockCommunityModeratorRepo,
+		mockModerationQueueRepo,
+		mockPostRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil,
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, Name: "golang"}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(1)).Return(constant.ROLE_ADMIN, nil)
+	mockPostRepo.On("GetPostByID", uint64(10)).Return(&model.Post{ID: 10, CommunityID: 4}, nil)
+
+	err := moderationQueueService.ApplyQueueAction(context.Background(), 1, 3, constant.MODERATION_TARGET_POST, 10, &request.ModerationQueueActionRequest{
+		Action: constant.QUEUE_ACTION_APPROVE,
+	})
+
+	assert.EqualError(t, err, "post not found")
+	mockModerationQueueRepo.AssertNotCalled(t, "ResolveQueueItem", mock.Anything)
+}
+
+func TestModerationQueueService_ApplyQueueAction_CustomRoleLimitedToComments(t *testing.T) {
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockModerationQueueRepo := new(MockModerationQueueRepository)
+	mockPostRepo := new(MockPostRepository)
+	moderationQueueService := NewModerationQueueService(
+		mockCommunityRepo,
+		mockCommunityModeratorRepo,
+		mockModerationQueueRepo,
+		mockPostRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil,
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, Name: "golang"}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(1)).Return(constant.ROLE_MODERATOR, nil)
+	mockCommunityModeratorRepo.On("GetModeratorCustomRole", uint64(3), uint64(1)).Return(&model.CommunityRole{
+		ID:          7,
+		CommunityID: 3,
+		Permissions: []string{constant.COMMUNITY_PERMISSION_MANAGE_COMMENTS},
+	}, nil)
+
+	err := moderationQueueService.ApplyQueueAction(context.Background(), 1, 3, constant.MODERATION_TARGET_POST, 10, &request.ModerationQueueActionRequest{
+		Action: constant.QUEUE_ACTION_REMOVE,
+	})
+
+	assert.EqualError(t, err, "permission denied")
+	mockPostRepo.AssertNotCalled(t, "GetPostByID", mock.Anything)
+	mockModerationQueueRepo.AssertNotCalled(t, "ResolveQueueItem", mock.Anything)
+}
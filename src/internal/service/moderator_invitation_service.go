@@ -0,0 +1,419 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/domain/repository"
+	"social-platform-backend/internal/interface/dto/request"
+	"social-platform-backend/internal/interface/dto/response"
+	"social-platform-backend/package/constant"
+	"social-platform-backend/package/logger"
+	"social-platform-backend/package/template/payload"
+	"time"
+)
+
+// ModeratorInvitationService invites members to moderate a community and lets the super
+// admin hand the community over. Roles only change once the invitee accepts.
+type ModeratorInvitationService struct {
+	invitationRepo         repository.ModeratorInvitationRepository
+	communityRepo          repository.CommunityRepository
+	communityModeratorRepo repository.CommunityModeratorRepository
+	subscriptionRepo       repository.SubscriptionRepository
+	communityRoleRepo      repository.CommunityRoleRepository
+	userRepo               repository.UserRepository
+	moderationLogRepo      repository.ModerationLogRepository
+	notificationService    *NotificationService
+}
+
+func NewModeratorInvitationService(
+	invitationRepo repository.ModeratorInvitationRepository,
+	communityRepo repository.CommunityRepository,
+	communityModeratorRepo repository.CommunityModeratorRepository,
+	subscriptionRepo repository.SubscriptionRepository,
+	communityRoleRepo repository.CommunityRoleRepository,
+	userRepo repository.UserRepository,
+	moderationLogRepo repository.ModerationLogRepository,
+	notificationService *NotificationService,
+) *ModeratorInvitationService {
+	return &ModeratorInvitationService{
+		invitationRepo:         invitationRepo,
+		communityRepo:          communityRepo,
+		communityModeratorRepo: communityModeratorRepo,
+		subscriptionRepo:       subscriptionRepo,
+		communityRoleRepo:      communityRoleRepo,
+		userRepo:               userRepo,
+		moderationLogRepo:      moderationLogRepo,
+		notificationService:    notificationService,
+	}
+}
+
+// InviteModerator offers a member the admin role or one of the community's custom roles.
+// Like UpdateMemberRole, moderators can only offer roles whose permissions they hold.
+func (s *ModeratorInvitationService) InviteModerator(ctx context.Context, userID, communityID uint64, req *request.InviteModeratorRequest) (*response.ModeratorInvitationResponse, error) {
+	community, err := s.communityRepo.GetCommunityByID(communityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Community not found in ModeratorInvitationService.InviteModerator: %v", err)
+		return nil, fmt.Errorf("community not found")
+	}
+
+	access, err := getCommunityAccess(s.communityModeratorRepo, communityID, userID)
+	if err != nil || !access.can(constant.COMMUNITY_PERMISSION_MANAGE_ROLES) {
+		logger.ErrorfWithCtx(ctx, "[Err] User does not have permission in ModeratorInvitationService.InviteModerator: userID=%d, communityID=%d", userID, communityID)
+		return nil, fmt.Errorf("permission denied")
+	}
+
+	if req.UserID == userID {
+		return nil, fmt.Errorf("cannot invite yourself")
+	}
+	if err := s.checkMember(ctx, req.UserID, communityID, "InviteModerator"); err != nil {
+		return nil, err
+	}
+	if isCommunityModerator(s.communityModeratorRepo, communityID, req.UserID) {
+		return nil, fmt.Errorf("user is already a moderator")
+	}
+
+	invitation := &model.ModeratorInvitation{
+		CommunityID: communityID,
+		InviterID:   userID,
+		InviteeID:   req.UserID,
+		Type:        constant.MODERATOR_INVITATION_TYPE_MODERATOR,
+		Role:        req.Role,
+		Status:      constant.MODERATOR_INVITATION_STATUS_PENDING,
+	}
+	switch req.Role {
+	case constant.ROLE_ADMIN:
+		if !hasAllPermissions(access, constant.COMMUNITY_ROLE_PERMISSIONS[constant.ROLE_ADMIN]) {
+			return nil, fmt.Errorf("role exceeds your permissions")
+		}
+	case constant.ROLE_MODERATOR:
+		if req.RoleID == nil {
+			return nil, fmt.Errorf("role id is required")
+		}
+		customRole, err := s.communityRoleRepo.GetRoleByID(*req.RoleID)
+		if err != nil || customRole.CommunityID != communityID {
+			logger.ErrorfWithCtx(ctx, "[Err] Role not found in ModeratorInvitationService.InviteModerator: roleID=%d, communityID=%d, err=%v", *req.RoleID, communityID, err)
+			return nil, fmt.Errorf("role not found")
+		}
+		if !hasAllPermissions(access, customRole.Permissions) {
+			return nil, fmt.Errorf("role exceeds your permissions")
+		}
+		invitation.RoleID = &customRole.ID
+		invitation.CustomRole = customRole
+	default:
+		return nil, fmt.Errorf("invalid role")
+	}
+
+	if err := s.createInvitation(ctx, invitation, "InviteModerator"); err != nil {
+		return nil, err
+	}
+
+	roleName := invitationRoleName(invitation)
+	s.recordInvitationLog(ctx, communityID, userID, constant.MODERATION_ACTION_INVITE_MODERATOR, req.UserID, &roleName)
+	s.notifyInvitation(ctx, invitation.InviteeID, userID, constant.NOTIFICATION_ACTION_MODERATOR_INVITATION, invitation, community.Name, "")
+
+	invitation.Community = community
+	return response.NewModeratorInvitationResponse(invitation), nil
+}
+
+// TransferOwnership offers the super admin role to another member. The caller stays the
+// super admin until the member accepts, and becomes an admin then.
+func (s *ModeratorInvitationService) TransferOwnership(ctx context.Context, userID, communityID uint64, req *request.TransferOwnershipRequest) (*response.ModeratorInvitationResponse, error) {
+	community, err := s.communityRepo.GetCommunityByID(communityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Community not found in ModeratorInvitationService.TransferOwnership: %v", err)
+		return nil, fmt.Errorf("community not found")
+	}
+	if err := checkCommunityOwner(ctx, s.communityModeratorRepo, communityID, userID, "ModeratorInvitationService.TransferOwnership"); err != nil {
+		return nil, err
+	}
+
+	if req.UserID == userID {
+		return nil, fmt.Errorf("cannot transfer ownership to yourself")
+	}
+	if err := s.checkMember(ctx, req.UserID, communityID, "TransferOwnership"); err != nil {
+		return nil, err
+	}
+
+	invitation := &model.ModeratorInvitation{
+		CommunityID: communityID,
+		InviterID:   userID,
+		InviteeID:   req.UserID,
+		Type:        constant.MODERATOR_INVITATION_TYPE_OWNERSHIP,
+		Role:        constant.ROLE_SUPER_ADMIN,
+		Status:      constant.MODERATOR_INVITATION_STATUS_PENDING,
+	}
+	if err := s.createInvitation(ctx, invitation, "TransferOwnership"); err != nil {
+		return nil, err
+	}
+
+	s.recordInvitationLog(ctx, communityID, userID, constant.MODERATION_ACTION_OFFER_OWNERSHIP, req.UserID, nil)
+	s.notifyInvitation(ctx, invitation.InviteeID, userID, constant.NOTIFICATION_ACTION_MODERATOR_INVITATION, invitation, community.Name, "")
+
+	invitation.Community = community
+	return response.NewModeratorInvitationResponse(invitation), nil
+}
+
+// GetCommunityInvitations lists the pending invitations of a community to the moderators
+// who can manage roles
+func (s *ModeratorInvitationService) GetCommunityInvitations(ctx context.Context, userID, communityID uint64) ([]*response.ModeratorInvitationResponse, error) {
+	if _, err := s.communityRepo.GetCommunityByID(communityID); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Community not found in ModeratorInvitationService.GetCommunityInvitations: %v", err)
+		return nil, fmt.Errorf("community not found")
+	}
+	if err := checkCommunityPermission(ctx, s.communityModeratorRepo, communityID, userID, constant.COMMUNITY_PERMISSION_MANAGE_ROLES, "ModeratorInvitationService.GetCommunityInvitations"); err != nil {
+		return nil, err
+	}
+
+	invitations, err := s.invitationRepo.GetPendingCommunityInvitations(communityID, time.Now())
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting community invitations in ModeratorInvitationService.GetCommunityInvitations: %v", err)
+		return nil, fmt.Errorf("failed to get invitations")
+	}
+	return response.NewModeratorInvitationResponses(invitations), nil
+}
+
+// CancelInvitation withdraws a pending invitation. Only the super admin can withdraw an
+// ownership transfer.
+func (s *ModeratorInvitationService) CancelInvitation(ctx context.Context, userID, communityID, invitationID uint64) error {
+	if _, err := s.communityRepo.GetCommunityByID(communityID); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Community not found in ModeratorInvitationService.CancelInvitation: %v", err)
+		return fmt.Errorf("community not found")
+	}
+
+	invitation, err := s.invitationRepo.GetInvitationByID(invitationID)
+	if err != nil || invitation.CommunityID != communityID {
+		logger.ErrorfWithCtx(ctx, "[Err] Invitation not found in ModeratorInvitationService.CancelInvitation: invitationID=%d, communityID=%d, err=%v", invitationID, communityID, err)
+		return fmt.Errorf("invitation not found")
+	}
+
+	if invitation.Type == constant.MODERATOR_INVITATION_TYPE_OWNERSHIP {
+		err = checkCommunityOwner(ctx, s.communityModeratorRepo, communityID, userID, "ModeratorInvitationService.CancelInvitation")
+	} else {
+		err = checkCommunityPermission(ctx, s.communityModeratorRepo, communityID, userID, constant.COMMUNITY_PERMISSION_MANAGE_ROLES, "ModeratorInvitationService.CancelInvitation")
+	}
+	if err != nil {
+		return err
+	}
+
+	closed, err := s.invitationRepo.CloseInvitation(invitation.ID, constant.MODERATOR_INVITATION_STATUS_CANCELLED, time.Now())
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error cancelling invitation in ModeratorInvitationService.CancelInvitation: %v", err)
+		return fmt.Errorf("failed to cancel invitation")
+	}
+	if !closed {
+		return fmt.Errorf("invitation is no longer pending")
+	}
+
+	s.recordInvitationLog(ctx, communityID, userID, constant.MODERATION_ACTION_CANCEL_INVITATION, invitation.InviteeID, &invitation.Type)
+	return nil
+}
+
+// GetMyInvitations lists the pending invitations the user has received
+func (s *ModeratorInvitationService) GetMyInvitations(ctx context.Context, userID uint64) ([]*response.ModeratorInvitationResponse, error) {
+	invitations, err := s.invitationRepo.GetPendingUserInvitations(userID, time.Now())
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting user invitations in ModeratorInvitationService.GetMyInvitations: %v", err)
+		return nil, fmt.Errorf("failed to get invitations")
+	}
+	return response.NewModeratorInvitationResponses(invitations), nil
+}
+
+// AcceptInvitation gives the user the offered role. For an ownership transfer the inviter
+// has to still be the super admin, and is made an admin in the same transaction.
+func (s *ModeratorInvitationService) AcceptInvitation(ctx context.Context, userID, invitationID uint64) error {
+	invitation, err := s.getPendingInvitation(ctx, userID, invitationID, "AcceptInvitation")
+	if err != nil {
+		return err
+	}
+	if err := s.checkMember(ctx, userID, invitation.CommunityID, "AcceptInvitation"); err != nil {
+		return err
+	}
+	if invitation.Type == constant.MODERATOR_INVITATION_TYPE_MODERATOR && isCommunityModerator(s.communityModeratorRepo, invitation.CommunityID, userID) {
+		return fmt.Errorf("user is already a moderator")
+	}
+	if invitation.RoleID != nil && invitation.CustomRole == nil {
+		return fmt.Errorf("role not found")
+	}
+
+	now := time.Now()
+	moderationLog := &model.ModerationLog{
+		CommunityID: invitation.CommunityID,
+		ActorID:     invitation.InviterID,
+		Action:      constant.MODERATION_ACTION_ADD_MODERATOR,
+		TargetType:  constant.MODERATION_TARGET_USER,
+		TargetID:    userID,
+		CreatedAt:   now,
+	}
+	if invitation.Type == constant.MODERATOR_INVITATION_TYPE_OWNERSHIP {
+		moderationLog.Action = constant.MODERATION_ACTION_TRANSFER_OWNERSHIP
+	} else {
+		roleName := invitationRoleName(invitation)
+		moderationLog.Details = &roleName
+	}
+
+	accepted, err := s.invitationRepo.AcceptInvitation(invitation, moderationLog, now)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error accepting invitation in ModeratorInvitationService.AcceptInvitation: %v", err)
+		return fmt.Errorf("failed to accept invitation")
+	}
+	if !accepted {
+		return fmt.Errorf("invitation can no longer be accepted")
+	}
+
+	s.notifyInvitation(ctx, invitation.InviterID, userID, constant.NOTIFICATION_ACTION_MODERATOR_INVITATION_REPLY, invitation, invitationCommunityName(invitation), constant.MODERATOR_INVITATION_STATUS_ACCEPTED)
+	return nil
+}
+
+func (s *ModeratorInvitationService) DeclineInvitation(ctx context.Context, userID, invitationID uint64) error {
+	invitation, err := s.getPendingInvitation(ctx, userID, invitationID, "DeclineInvitation")
+	if err != nil {
+		return err
+	}
+
+	closed, err := s.invitationRepo.CloseInvitation(invitation.ID, constant.MODERATOR_INVITATION_STATUS_DECLINED, time.Now())
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error declining invitation in ModeratorInvitationService.DeclineInvitation: %v", err)
+		return fmt.Errorf("failed to decline invitation")
+	}
+	if !closed {
+		return fmt.Errorf("invitation is no longer pending")
+	}
+
+	s.notifyInvitation(ctx, invitation.InviterID, userID, constant.NOTIFICATION_ACTION_MODERATOR_INVITATION_REPLY, invitation, invitationCommunityName(invitation), constant.MODERATOR_INVITATION_STATUS_DECLINED)
+	return nil
+}
+
+// ExpireInvitations is run by the scheduler, pending invitations past their expiry are
+// already ignored until then
+func (s *ModeratorInvitationService) ExpireInvitations(ctx context.Context) error {
+	expired, err := s.invitationRepo.ExpireInvitations(time.Now())
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error expiring invitations in ModeratorInvitationService.ExpireInvitations: %v", err)
+		return fmt.Errorf("failed to expire moderator invitations")
+	}
+	if expired > 0 {
+		logger.InfofWithCtx(ctx, "[Info] Expired %d moderator invitations", expired)
+	}
+	return nil
+}
+
+// getPendingInvitation loads an invitation addressed to the user that can still be
+// answered, marking it expired if its time is up
+func (s *ModeratorInvitationService) getPendingInvitation(ctx context.Context, userID, invitationID uint64, method string) (*model.ModeratorInvitation, error) {
+	invitation, err := s.invitationRepo.GetInvitationByID(invitationID)
+	if err != nil || invitation.InviteeID != userID {
+		logger.ErrorfWithCtx(ctx, "[Err] Invitation not found in ModeratorInvitationService.%s: invitationID=%d, userID=%d, err=%v", method, invitationID, userID, err)
+		return nil, fmt.Errorf("invitation not found")
+	}
+	if invitation.Status != constant.MODERATOR_INVITATION_STATUS_PENDING {
+		return nil, fmt.Errorf("invitation is no longer pending")
+	}
+
+	now := time.Now()
+	if !now.Before(invitation.ExpiresAt) {
+		if _, err := s.invitationRepo.CloseInvitation(invitation.ID, constant.MODERATOR_INVITATION_STATUS_EXPIRED, now); err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Error expiring invitation in ModeratorInvitationService.%s: %v", method, err)
+		}
+		return nil, fmt.Errorf("invitation expired")
+	}
+	return invitation, nil
+}
+
+// createInvitation refuses a second pending invitation of the same type for the same member
+func (s *ModeratorInvitationService) createInvitation(ctx context.Context, invitation *model.ModeratorInvitation, method string) error {
+	pending, err := s.invitationRepo.GetPendingInvitation(invitation.CommunityID, invitation.InviteeID, invitation.Type)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error checking pending invitation in ModeratorInvitationService.%s: %v", method, err)
+		return fmt.Errorf("failed to create invitation")
+	}
+	if pending != nil {
+		return fmt.Errorf("invitation already pending")
+	}
+
+	now := time.Now()
+	invitation.CreatedAt = now
+	invitation.ExpiresAt = now.AddDate(0, 0, constant.MODERATOR_INVITATION_EXPIRY_DAYS)
+	if err := s.invitationRepo.CreateInvitation(invitation); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error creating invitation in ModeratorInvitationService.%s: %v", method, err)
+		return fmt.Errorf("failed to create invitation")
+	}
+	return nil
+}
+
+func (s *ModeratorInvitationService) checkMember(ctx context.Context, userID, communityID uint64, method string) error {
+	isSubscribed, err := s.subscriptionRepo.IsUserSubscribed(userID, communityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error checking subscription in ModeratorInvitationService.%s: %v", method, err)
+		return fmt.Errorf("failed to check subscription")
+	}
+	if !isSubscribed {
+		return fmt.Errorf("user is not a member of this community")
+	}
+	return nil
+}
+
+func (s *ModeratorInvitationService) recordInvitationLog(ctx context.Context, communityID, actorID uint64, action string, targetUserID uint64, details *string) {
+	if s.moderationLogRepo == nil {
+		return
+	}
+
+	moderationLog := &model.ModerationLog{
+		CommunityID: communityID,
+		ActorID:     actorID,
+		Action:      action,
+		TargetType:  constant.MODERATION_TARGET_USER,
+		TargetID:    targetUserID,
+		Details:     details,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.moderationLogRepo.CreateModerationLog(moderationLog); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error recording moderation log in ModeratorInvitationService.recordInvitationLog: %v", err)
+	}
+}
+
+// notifyInvitation tells recipientID about an invitation sent or answered by userID
+func (s *ModeratorInvitationService) notifyInvitation(ctx context.Context, recipientID, userID uint64, action string, invitation *model.ModeratorInvitation, communityName, status string) {
+	if s.notificationService == nil {
+		return
+	}
+
+	notificationPayload := payload.ModeratorInvitationNotificationPayload{
+		InvitationID:   invitation.ID,
+		CommunityID:    invitation.CommunityID,
+		CommunityName:  communityName,
+		InvitationType: invitation.Type,
+		Status:         status,
+	}
+	if invitation.Type == constant.MODERATOR_INVITATION_TYPE_MODERATOR {
+		notificationPayload.RoleName = invitationRoleName(invitation)
+	}
+
+	go func(recipientID, userID uint64, notificationPayload payload.ModeratorInvitationNotificationPayload) {
+		user, err := s.userRepo.GetUserByID(userID)
+		if err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Error getting user in goroutine (ModeratorInvitationService.notifyInvitation): %v", err)
+			return
+		}
+		notificationPayload.UserName = user.Username
+
+		if err := s.notificationService.CreateNotification(ctx, recipientID, action, notificationPayload); err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Error sending notification in goroutine (ModeratorInvitationService.notifyInvitation): %v", err)
+		}
+	}(recipientID, userID, notificationPayload)
+}
+
+// invitationRoleName names the offered role, custom roles by their own name
+func invitationRoleName(invitation *model.ModeratorInvitation) string {
+	if invitation.CustomRole != nil {
+		return invitation.CustomRole.Name
+	}
+	return invitation.Role
+}
+
+func invitationCommunityName(invitation *model.ModeratorInvitation) string {
+	if invitation.Community != nil {
+		return invitation.Community.Name
+	}
+	return ""
+}
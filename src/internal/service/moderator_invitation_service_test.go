@@ -0,0 +1,300 @@
+package service
+
+import (
+	"context"
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/interface/dto/request"
+	"social-platform-backend/package/constant"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestModeratorInvitationService_InviteModerator_CustomRole(t *testing.T) {
+	mockModeratorInvitationRepo := new(MockModeratorInvitationRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockSubscriptionRepo := new(MockSubscriptionRepository)
+	mockCommunityRoleRepo := new(MockCommunityRoleRepository)
+	mockModerationLogRepo := new(MockModerationLogRepository)
+	moderatorInvitationService := NewModeratorInvitationService(
+		mockModeratorInvitationRepo,
+		mockCommunityRepo,
+		mockCommunityModeratorRepo,
+		mockSubscriptionRepo,
+		mockCommunityRoleRepo,
+		nil,
+		mockModerationLogRepo,
+		nil,
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, Name: "golang"}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(4)).Return(constant.ROLE_MODERATOR, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), mock.Anything).Return("", nil)
+	mockCommunityModeratorRepo.On("GetModeratorCustomRole", uint64(3), uint64(4)).Return(&model.CommunityRole{
+		ID:          7,
+		CommunityID: 3,
+		Name:        "Role manager",
+		Permissions: pq.StringArray{constant.COMMUNITY_PERMISSION_MANAGE_ROLES, constant.COMMUNITY_PERMISSION_MANAGE_POSTS},
+	}, nil)
+	mockSubscriptionRepo.On("IsUserSubscribed", mock.Anything, uint64(3)).Return(true, nil)
+
+	roleID := uint64(8)
+
+	mockCommunityRoleRepo.On("GetRoleByID", roleID).Return(&model.CommunityRole{
+		ID:          roleID,
+		CommunityID: 3,
+		Name:        "Janitor",
+		Permissions: pq.StringArray{constant.COMMUNITY_PERMISSION_MANAGE_POSTS},
+	}, nil)
+	mockModeratorInvitationRepo.On("GetPendingInvitation", uint64(3), uint64(9), constant.MODERATOR_INVITATION_TYPE_MODERATOR).Return(nil, nil)
+	mockModeratorInvitationRepo.On("CreateInvitation", mock.MatchedBy(func(invitation *model.ModeratorInvitation) bool {
+		return invitation.InviterID == 4 && invitation.InviteeID == 9 && invitation.Role == constant.ROLE_MODERATOR &&
+			*invitation.RoleID == roleID && invitation.ExpiresAt.After(time.Now().AddDate(0, 0, constant.MODERATOR_INVITATION_EXPIRY_DAYS-1))
+	})).Return(nil)
+	mockModerationLogRepo.On("CreateModerationLog", mock.MatchedBy(func(log *model.ModerationLog) bool {
+		return log.Action == constant.MODERATION_ACTION_INVITE_MODERATOR && log.TargetID == 9 && *log.Details == "Janitor"
+	})).Return(nil)
+
+	result, err := moderatorInvitationService.InviteModerator(context.Background(), 4, 3, &request.InviteModeratorRequest{
+		UserID: 9,
+		Role:   constant.ROLE_MODERATOR,
+		RoleID: &roleID,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Janitor", *result.RoleName)
+	assert.Equal(t, constant.MODERATOR_INVITATION_STATUS_PENDING, result.Status)
+	mockCommunityModeratorRepo.AssertNotCalled(t, "UpsertModerator", mock.Anything)
+	mockModerationLogRepo.AssertExpectations(t)
+}
+
+func TestModeratorInvitationService_InviteModerator_CannotOfferAdmin(t *testing.T) {
+	mockModeratorInvitationRepo := new(MockModeratorInvitationRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockSubscriptionRepo := new(MockSubscriptionRepository)
+	moderatorInvitationService := NewModeratorInvitationService(
+		mockModeratorInvitationRepo,
+		mockCommunityRepo,
+		mockCommunityModeratorRepo,
+		mockSubscriptionRepo,
+		nil, nil, nil, nil,
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, Name: "golang"}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(4)).Return(constant.ROLE_MODERATOR, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), mock.Anything).Return("", nil)
+	mockCommunityModeratorRepo.On("GetModeratorCustomRole", uint64(3), uint64(4)).Return(&model.CommunityRole{
+		ID:          7,
+		CommunityID: 3,
+		Name:        "Role manager",
+		Permissions: pq.StringArray{constant.COMMUNITY_PERMISSION_MANAGE_ROLES, constant.COMMUNITY_PERMISSION_MANAGE_POSTS},
+	}, nil)
+	mockSubscriptionRepo.On("IsUserSubscribed", mock.Anything, uint64(3)).Return(true, nil)
+
+	_, err := moderatorInvitationService.InviteModerator(context.Background(), 4, 3, &request.InviteModeratorRequest{
+		UserID: 9,
+		Role:   constant.ROLE_ADMIN,
+	})
+
+	assert.EqualError(t, err, "role exceeds your permissions")
+	mockModeratorInvitationRepo.AssertNotCalled(t, "CreateInvitation", mock.Anything)
+}
+
+func TestModeratorInvitationService_InviteModerator_AlreadyPending(t *testing.T) {
+	mockModeratorInvitationRepo := new(MockModeratorInvitationRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockSubscriptionRepo := new(MockSubscriptionRepository)
+	moderatorInvitationService := NewModeratorInvitationService(
+		mockModeratorInvitationRepo,
+		mockCommunityRepo,
+		mockCommunityModeratorRepo,
+		mockSubscriptionRepo,
+		nil, nil, nil, nil,
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, Name: "golang"}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(1)).Return(constant.ROLE_SUPER_ADMIN, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), mock.Anything).Return("", nil)
+	mockSubscriptionRepo.On("IsUserSubscribed", mock.Anything, uint64(3)).Return(true, nil)
+
+	mockModeratorInvitationRepo.On("GetPendingInvitation", uint64(3), uint64(9), constant.MODERATOR_INVITATION_TYPE_MODERATOR).
+		Return(&model.ModeratorInvitation{ID: 11}, nil)
+
+	_, err := moderatorInvitationService.InviteModerator(context.Background(), 1, 3, &request.InviteModeratorRequest{
+		UserID: 9,
+		Role:   constant.ROLE_ADMIN,
+	})
+
+	assert.EqualError(t, err, "invitation already pending")
+	mockModeratorInvitationRepo.AssertNotCalled(t, "CreateInvitation", mock.Anything)
+}
+
+func TestModeratorInvitationService_TransferOwnership_NotSuperAdmin(t *testing.T) {
+	mockModeratorInvitationRepo := new(MockModeratorInvitationRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	moderatorInvitationService := NewModeratorInvitationService(
+		mockModeratorInvitationRepo,
+		mockCommunityRepo,
+		mockCommunityModeratorRepo,
+		nil, nil, nil, nil, nil,
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, Name: "golang"}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(2)).Return(constant.ROLE_ADMIN, nil)
+
+	_, err := moderatorInvitationService.TransferOwnership(context.Background(), 2, 3, &request.TransferOwnershipRequest{UserID: 9})
+
+	assert.EqualError(t, err, "permission denied")
+	mockModeratorInvitationRepo.AssertNotCalled(t, "CreateInvitation", mock.Anything)
+}
+
+func TestModeratorInvitationService_AcceptInvitation_OwnershipTransfer(t *testing.T) {
+	mockModeratorInvitationRepo := new(MockModeratorInvitationRepository)
+	mockSubscriptionRepo := new(MockSubscriptionRepository)
+	moderatorInvitationService := NewModeratorInvitationService(
+		mockModeratorInvitationRepo,
+		nil, nil,
+		mockSubscriptionRepo,
+		nil, nil, nil, nil,
+	)
+
+	mockSubscriptionRepo.On("IsUserSubscribed", mock.Anything, uint64(3)).Return(true, nil)
+
+	invitation := &model.ModeratorInvitation{
+		ID:          11,
+		CommunityID: 3,
+		InviterID:   1,
+		InviteeID:   2,
+		Type:        constant.MODERATOR_INVITATION_TYPE_OWNERSHIP,
+		Role:        constant.ROLE_SUPER_ADMIN,
+		Status:      constant.MODERATOR_INVITATION_STATUS_PENDING,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+	mockModeratorInvitationRepo.On("GetInvitationByID", uint64(11)).Return(invitation, nil)
+	mockModeratorInvitationRepo.On("AcceptInvitation", invitation, mock.MatchedBy(func(log *model.ModerationLog) bool {
+		return log.Action == constant.MODERATION_ACTION_TRANSFER_OWNERSHIP && log.ActorID == 1 && log.TargetID == 2
+	}), mock.Anything).Return(true, nil)
+
+	err := moderatorInvitationService.AcceptInvitation(context.Background(), 2, 11)
+
+	assert.NoError(t, err)
+	mockModeratorInvitationRepo.AssertExpectations(t)
+}
+
+func TestModeratorInvitationService_AcceptInvitation_InviterNoLongerOwner(t *testing.T) {
+	mockModeratorInvitationRepo := new(MockModeratorInvitationRepository)
+	mockSubscriptionRepo := new(MockSubscriptionRepository)
+	moderatorInvitationService := NewModeratorInvitationService(
+		mockModeratorInvitationRepo,
+		nil, nil,
+		mockSubscriptionRepo,
+		nil, nil, nil, nil,
+	)
+
+	mockSubscriptionRepo.On("IsUserSubscribed", mock.Anything, uint64(3)).Return(true, nil)
+
+	invitation := &model.ModeratorInvitation{
+		ID:          11,
+		CommunityID: 3,
+		InviterID:   5,
+		InviteeID:   2,
+		Type:        constant.MODERATOR_INVITATION_TYPE_OWNERSHIP,
+		Role:        constant.ROLE_SUPER_ADMIN,
+		Status:      constant.MODERATOR_INVITATION_STATUS_PENDING,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+	mockModeratorInvitationRepo.On("GetInvitationByID", uint64(11)).Return(invitation, nil)
+	mockModeratorInvitationRepo.On("AcceptInvitation", invitation, mock.Anything, mock.Anything).Return(false, nil)
+
+	err := moderatorInvitationService.AcceptInvitation(context.Background(), 2, 11)
+
+	assert.EqualError(t, err, "invitation can no longer be accepted")
+}
+
+func TestModeratorInvitationService_AcceptInvitation_Expired(t *testing.T) {
+	mockModeratorInvitationRepo := new(MockModeratorInvitationRepository)
+	moderatorInvitationService := NewModeratorInvitationService(
+		mockModeratorInvitationRepo,
+		nil, nil, nil, nil, nil, nil, nil,
+	)
+
+	mockModeratorInvitationRepo.On("GetInvitationByID", uint64(11)).Return(&model.ModeratorInvitation{
+		ID:          11,
+		CommunityID: 3,
+		InviterID:   1,
+		InviteeID:   9,
+		Type:        constant.MODERATOR_INVITATION_TYPE_MODERATOR,
+		Role:        constant.ROLE_ADMIN,
+		Status:      constant.MODERATOR_INVITATION_STATUS_PENDING,
+		ExpiresAt:   time.Now().Add(-time.Minute),
+	}, nil)
+	mockModeratorInvitationRepo.On("CloseInvitation", uint64(11), constant.MODERATOR_INVITATION_STATUS_EXPIRED, mock.Anything).Return(true, nil)
+
+	err := moderatorInvitationService.AcceptInvitation(context.Background(), 9, 11)
+
+	assert.EqualError(t, err, "invitation expired")
+	mockModeratorInvitationRepo.AssertNotCalled(t, "AcceptInvitation", mock.Anything, mock.Anything, mock.Anything)
+	mockModeratorInvitationRepo.AssertExpectations(t)
+}
+
+func TestModeratorInvitationService_DeclineInvitation_OtherUser(t *testing.T) {
+	mockModeratorInvitationRepo := new(MockModeratorInvitationRepository)
+	moderatorInvitationService := NewModeratorInvitationService(
+		mockModeratorInvitationRepo,
+		nil, nil, nil, nil, nil, nil, nil,
+	)
+
+	mockModeratorInvitationRepo.On("GetInvitationByID", uint64(11)).Return(&model.ModeratorInvitation{
+		ID:        11,
+		InviteeID: 9,
+		Status:    constant.MODERATOR_INVITATION_STATUS_PENDING,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}, nil)
+
+	err := moderatorInvitationService.DeclineInvitation(context.Background(), 4, 11)
+
+	assert.EqualError(t, err, "invitation not found")
+	mockModeratorInvitationRepo.AssertNotCalled(t, "CloseInvitation", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestModeratorInvitationService_AcceptInvitation_AlreadyModerator(t *testing.T) {
+	mockModeratorInvitationRepo := new(MockModeratorInvitationRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockSubscriptionRepo := new(MockSubscriptionRepository)
+	moderatorInvitationService := NewModeratorInvitationService(
+		mockModeratorInvitationRepo,
+		nil,
+		mockCommunityModeratorRepo,
+		mockSubscriptionRepo,
+		nil, nil, nil, nil,
+	)
+
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(2)).Return(constant.ROLE_ADMIN, nil)
+	mockSubscriptionRepo.On("IsUserSubscribed", mock.Anything, uint64(3)).Return(true, nil)
+
+	roleID := uint64(7)
+	mockModeratorInvitationRepo.On("GetInvitationByID", uint64(11)).Return(&model.ModeratorInvitation{
+		ID:          11,
+		CommunityID: 3,
+		InviterID:   1,
+		InviteeID:   2,
+		Type:        constant.MODERATOR_INVITATION_TYPE_MODERATOR,
+		Role:        constant.ROLE_MODERATOR,
+		RoleID:      &roleID,
+		Status:      constant.MODERATOR_INVITATION_STATUS_PENDING,
+		ExpiresAt:   time.Now().Add(time.Hour),
+		CustomRole:  &model.CommunityRole{ID: roleID, CommunityID: 3, Name: "Janitor"},
+	}, nil)
+
+	err := moderatorInvitationService.AcceptInvitation(context.Background(), 2, 11)
+
+	assert.EqualError(t, err, "user is already a moderator")
+	mockModeratorInvitationRepo.AssertNotCalled(t, "AcceptInvitation", mock.Anything, mock.Anything, mock.Anything)
+}
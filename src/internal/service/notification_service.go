@@ -12,6 +12,7 @@ import (
 	"social-platform-backend/package/logger"
 	"social-platform-backend/package/template/payload"
 	"social-platform-backend/package/util"
+	"strings"
 	"time"
 )
 
@@ -26,6 +27,14 @@ type NotificationTemplateData struct {
 	Reason          string
 	RestrictionType string
 	ExpiresAt       string
+	PostTitle       string
+	WinningOption   string
+	OtherCount      int
+	Emojis          string
+	RuleName        string
+	Message         string
+	InvitationType  string
+	RoleName        string
 	ClientURL       string
 }
 
@@ -124,6 +133,103 @@ func (s *NotificationService) CreateNotification(ctx context.Context, userID uin
 	return nil
 }
 
+// CreateNotifications notifies many users of the same event. Users are handled in batches
+// of NOTIFICATION_BATCH_SIZE, the push notifications of a batch are saved in one insert.
+// A failed batch is logged and the rest are still notified.
+func (s *NotificationService) CreateNotifications(ctx context.Context, userIDs []uint64, action string, notifPayload interface{}) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	templateData := s.prepareTemplateData(action, notifPayload)
+	body, err := util.RenderTemplate(s.getNotificationTemplatePath(action), templateData)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Failed to render notification body: %v", err)
+		return err
+	}
+	payloadBytes, err := json.Marshal(notifPayload)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Failed to marshal notification payload: %v", err)
+		return err
+	}
+	rawPayload := json.RawMessage(payloadBytes)
+
+	failed := 0
+	for start := 0; start < len(userIDs); start += constant.NOTIFICATION_BATCH_SIZE {
+		end := min(start+constant.NOTIFICATION_BATCH_SIZE, len(userIDs))
+		if err := s.createNotificationBatch(ctx, userIDs[start:end], action, body, &rawPayload, templateData); err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Failed to notify users %d to %d of %d in NotificationService.CreateNotifications: %v", start+1, end, len(userIDs), err)
+			failed += end - start
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to notify %d of %d users", failed, len(userIDs))
+	}
+	return nil
+}
+
+// createNotificationBatch saves the push notifications of a batch and queues its emails.
+// Users without a setting for the action get the defaults, push and no email.
+func (s *NotificationService) createNotificationBatch(ctx context.Context, userIDs []uint64, action, body string, rawPayload *json.RawMessage, templateData NotificationTemplateData) error {
+	settings, err := s.notificationSettingRepo.GetNotificationSettingsByUserIDs(userIDs, action)
+	if err != nil {
+		return err
+	}
+	settingByUserID := make(map[uint64]*model.NotificationSetting, len(settings))
+	for _, setting := range settings {
+		settingByUserID[setting.UserID] = setting
+	}
+
+	now := time.Now()
+	var notifications []*model.Notification
+	var mailUserIDs []uint64
+	for _, userID := range userIDs {
+		setting, ok := settingByUserID[userID]
+		if !ok || setting.IsPush {
+			notifications = append(notifications, &model.Notification{
+				UserID:    userID,
+				Body:      body,
+				Action:    action,
+				Payload:   rawPayload,
+				IsRead:    false,
+				CreatedAt: now,
+			})
+		}
+		if ok && setting.IsSendMail {
+			mailUserIDs = append(mailUserIDs, userID)
+		}
+	}
+
+	if err := s.notificationRepo.CreateNotifications(notifications); err != nil {
+		return err
+	}
+	for _, notification := range notifications {
+		s.broadcastNewNotification(ctx, notification.UserID, notification)
+	}
+
+	if len(mailUserIDs) > 0 {
+		s.sendEmailNotifications(ctx, mailUserIDs, action, templateData)
+	}
+	return nil
+}
+
+func (s *NotificationService) sendEmailNotifications(ctx context.Context, userIDs []uint64, action string, templateData NotificationTemplateData) {
+	emailBody, err := util.RenderTemplate(s.getNotificationEmailTemplatePath(action), templateData)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Failed to render notification email body: %v", err)
+		return
+	}
+	users, err := s.userRepo.GetUsersByIDs(userIDs)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Failed to get users for notification emails: %v", err)
+		return
+	}
+	emailSubject := constant.EmailSubjectMap[action]
+	for _, user := range users {
+		s.sendEmailNotification(ctx, user.Email, emailSubject, emailBody)
+	}
+}
+
 func (s *NotificationService) getNotificationTemplatePath(action string) string {
 	basePath := "package/template/notification/"
 	switch action {
@@ -149,6 +255,22 @@ func (s *NotificationService) getNotificationTemplatePath(action string) string
 		return basePath + "content_violation_post.txt"
 	case constant.NOTIFICATION_ACTION_CONTENT_VIOLATION_COMMENT:
 		return basePath + "content_violation_comment.txt"
+	case constant.NOTIFICATION_ACTION_COMMUNITY_ANNOUNCEMENT:
+		return basePath + "community_announcement.txt"
+	case constant.NOTIFICATION_ACTION_POLL_CLOSED:
+		return basePath + "poll_closed.txt"
+	case constant.NOTIFICATION_ACTION_GET_REACTION:
+		return basePath + "reaction.txt"
+	case constant.NOTIFICATION_ACTION_MENTIONED:
+		return basePath + "mention.txt"
+	case constant.NOTIFICATION_ACTION_REPORT_ACTIONED:
+		return basePath + "report_actioned.txt"
+	case constant.NOTIFICATION_ACTION_AUTOMOD_TRIGGERED:
+		return basePath + "automod_triggered.txt"
+	case constant.NOTIFICATION_ACTION_MODERATOR_INVITATION:
+		return basePath + "moderator_invitation.txt"
+	case constant.NOTIFICATION_ACTION_MODERATOR_INVITATION_REPLY:
+		return basePath + "moderator_invitation_reply.txt"
 	default:
 		return ""
 	}
@@ -173,6 +295,22 @@ func (s *NotificationService) getNotificationEmailTemplatePath(action string) st
 		return basePath + "comment_deleted_email.html"
 	case constant.NOTIFICATION_ACTION_SUBSCRIPTION_STATUS_UPDATED:
 		return basePath + "subscription_status_updated_email.html"
+	case constant.NOTIFICATION_ACTION_COMMUNITY_ANNOUNCEMENT:
+		return basePath + "community_announcement_email.html"
+	case constant.NOTIFICATION_ACTION_POLL_CLOSED:
+		return basePath + "poll_closed_email.html"
+	case constant.NOTIFICATION_ACTION_GET_REACTION:
+		return basePath + "reaction_email.html"
+	case constant.NOTIFICATION_ACTION_MENTIONED:
+		return basePath + "mention_email.html"
+	case constant.NOTIFICATION_ACTION_REPORT_ACTIONED:
+		return basePath + "report_actioned_email.html"
+	case constant.NOTIFICATION_ACTION_AUTOMOD_TRIGGERED:
+		return basePath + "automod_triggered_email.html"
+	case constant.NOTIFICATION_ACTION_MODERATOR_INVITATION:
+		return basePath + "moderator_invitation_email.html"
+	case constant.NOTIFICATION_ACTION_MODERATOR_INVITATION_REPLY:
+		return basePath + "moderator_invitation_reply_email.html"
 	default:
 		return ""
 	}
@@ -222,15 +360,15 @@ func (s *NotificationService) prepareTemplateData(action string, notifPayload in
 			data.Status = p.Status
 		}
 	case constant.NOTIFICATION_ACTION_POST_DELETED:
-		if p, ok := notifPayload.(map[string]interface{}); ok {
-			if postID, ok := p["postId"].(uint64); ok {
-				data.PostID = postID
-			}
+		if p, ok := notifPayload.(payload.PostDeletedNotificationPayload); ok {
+			data.PostID = p.PostID
+			data.RuleName = p.RuleTitle
 		}
 	case constant.NOTIFICATION_ACTION_COMMENT_DELETED:
 		if p, ok := notifPayload.(payload.CommentDeletedNotificationPayload); ok {
 			data.CommentID = p.CommentID
 			data.PostID = p.PostID
+			data.RuleName = p.RuleTitle
 		}
 	case constant.NOTIFICATION_ACTION_SUBSCRIPTION_STATUS_UPDATED:
 		if p, ok := notifPayload.(payload.SubscriptionStatusNotificationPayload); ok {
@@ -264,6 +402,67 @@ func (s *NotificationService) prepareTemplateData(action string, notifPayload in
 			data.PostID = p.PostID
 			data.Reason = p.Reason
 		}
+	case constant.NOTIFICATION_ACTION_COMMUNITY_ANNOUNCEMENT:
+		if p, ok := notifPayload.(payload.CommunityAnnouncementPayload); ok {
+			data.CommunityID = p.CommunityID
+			data.CommunityName = p.CommunityName
+			data.PostID = p.PostID
+			data.PostTitle = p.PostTitle
+		}
+	case constant.NOTIFICATION_ACTION_POLL_CLOSED:
+		if p, ok := notifPayload.(payload.PollClosedPayload); ok {
+			data.PostID = p.PostID
+			data.PostTitle = p.PostTitle
+			data.WinningOption = p.WinningOption
+		}
+	case constant.NOTIFICATION_ACTION_GET_REACTION:
+		if p, ok := notifPayload.(payload.ReactionNotificationPayload); ok {
+			data.UserName = p.UserName
+			data.PostID = p.PostID
+			if p.CommentID != nil {
+				data.CommentID = *p.CommentID
+			}
+			data.OtherCount = p.OtherCount
+			data.Emojis = strings.Join(p.Emojis, " ")
+		}
+	case constant.NOTIFICATION_ACTION_MENTIONED:
+		if p, ok := notifPayload.(payload.MentionNotificationPayload); ok {
+			data.UserName = p.UserName
+			data.PostID = p.PostID
+			data.PostTitle = p.PostTitle
+			if p.CommentID != nil {
+				data.CommentID = *p.CommentID
+			}
+		}
+	case constant.NOTIFICATION_ACTION_REPORT_ACTIONED:
+		if p, ok := notifPayload.(payload.ReportActionedNotificationPayload); ok {
+			data.CommunityID = p.CommunityID
+			data.CommunityName = p.CommunityName
+			data.PostID = p.PostID
+			if p.CommentID != nil {
+				data.CommentID = *p.CommentID
+			}
+		}
+	case constant.NOTIFICATION_ACTION_AUTOMOD_TRIGGERED:
+		if p, ok := notifPayload.(payload.AutoModTriggeredNotificationPayload); ok {
+			data.CommunityID = p.CommunityID
+			data.CommunityName = p.CommunityName
+			data.RuleName = p.RuleName
+			data.Message = p.Message
+			data.PostID = p.PostID
+			if p.CommentID != nil {
+				data.CommentID = *p.CommentID
+			}
+		}
+	case constant.NOTIFICATION_ACTION_MODERATOR_INVITATION, constant.NOTIFICATION_ACTION_MODERATOR_INVITATION_REPLY:
+		if p, ok := notifPayload.(payload.ModeratorInvitationNotificationPayload); ok {
+			data.CommunityID = p.CommunityID
+			data.CommunityName = p.CommunityName
+			data.UserName = p.UserName
+			data.InvitationType = p.InvitationType
+			data.RoleName = p.RoleName
+			data.Status = p.Status
+		}
 	}
 
 	return data
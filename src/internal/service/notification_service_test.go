@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"testing"
 
 	"social-platform-backend/internal/domain/model"
@@ -143,6 +144,78 @@ func TestNotificationService_CreateNotification_DefaultSettings(t *testing.T) {
 	mockNotificationRepo.AssertExpectations(t)
 }
 
+func TestNotificationService_CreateNotifications_Success(t *testing.T) {
+	mockNotificationRepo := new(MockNotificationRepository)
+	mockNotificationSettingRepo := new(MockNotificationSettingRepository)
+	mockUserRepo := new(MockUserRepository)
+
+	notificationService := NewNotificationService(
+		mockNotificationRepo,
+		mockNotificationSettingRepo,
+		nil,
+		mockUserRepo,
+		NewSSEService(),
+		nil,
+	)
+
+	action := constant.NOTIFICATION_ACTION_COMMUNITY_ANNOUNCEMENT
+	notifPayload := payload.CommunityAnnouncementPayload{
+		CommunityID:   3,
+		CommunityName: "golang",
+		PostID:        10,
+		PostTitle:     "Rules update",
+	}
+
+	// user 2 only wants emails, the others have no setting
+	mockNotificationSettingRepo.On("GetNotificationSettingsByUserIDs", []uint64{1, 2, 3}, action).Return([]*model.NotificationSetting{
+		{UserID: 2, Action: action, IsPush: false, IsSendMail: true},
+	}, nil)
+	mockNotificationRepo.On("CreateNotifications", mock.MatchedBy(func(notifications []*model.Notification) bool {
+		return len(notifications) == 2 && notifications[0].UserID == 1 && notifications[1].UserID == 3 &&
+			notifications[0].Action == action && notifications[0].Body != ""
+	})).Return(nil)
+	mockNotificationRepo.On("GetUnreadCount", mock.Anything).Return(int64(1), nil)
+	mockUserRepo.On("GetUsersByIDs", []uint64{2}).Return([]*model.User{{ID: 2, Email: "b@example.com"}}, nil)
+
+	err := notificationService.CreateNotifications(context.Background(), []uint64{1, 2, 3}, action, notifPayload)
+
+	assert.NoError(t, err)
+	mockNotificationRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestNotificationService_CreateNotifications_ContinuesAfterFailedBatch(t *testing.T) {
+	mockNotificationRepo := new(MockNotificationRepository)
+	mockNotificationSettingRepo := new(MockNotificationSettingRepository)
+
+	notificationService := NewNotificationService(
+		mockNotificationRepo,
+		mockNotificationSettingRepo,
+		nil,
+		nil,
+		NewSSEService(),
+		nil,
+	)
+
+	action := constant.NOTIFICATION_ACTION_COMMUNITY_ANNOUNCEMENT
+	userIDs := make([]uint64, constant.NOTIFICATION_BATCH_SIZE+1)
+	for i := range userIDs {
+		userIDs[i] = uint64(i + 1)
+	}
+
+	mockNotificationSettingRepo.On("GetNotificationSettingsByUserIDs", mock.Anything, action).Return([]*model.NotificationSetting{}, nil)
+	mockNotificationRepo.On("CreateNotifications", mock.Anything).Return(errors.New("insert failed")).Once()
+	mockNotificationRepo.On("CreateNotifications", mock.MatchedBy(func(notifications []*model.Notification) bool {
+		return len(notifications) == 1 && notifications[0].UserID == uint64(constant.NOTIFICATION_BATCH_SIZE+1)
+	})).Return(nil).Once()
+	mockNotificationRepo.On("GetUnreadCount", mock.Anything).Return(int64(1), nil)
+
+	err := notificationService.CreateNotifications(context.Background(), userIDs, action, payload.CommunityAnnouncementPayload{PostID: 10})
+
+	assert.EqualError(t, err, fmt.Sprintf("failed to notify %d of %d users", constant.NOTIFICATION_BATCH_SIZE, len(userIDs)))
+	mockNotificationRepo.AssertNumberOfCalls(t, "CreateNotifications", 2)
+}
+
 func TestNotificationService_GetUserNotifications_Success(t *testing.T) {
 	mockNotificationRepo := new(MockNotificationRepository)
 
@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/domain/repository"
+	"social-platform-backend/internal/interface/dto/response"
+	"social-platform-backend/package/constant"
+	"social-platform-backend/package/logger"
+	"sync"
+	"time"
+)
+
+type postViewKey struct {
+	postID              uint64
+	bucketStart         time.Time
+	referralCommunityID uint64
+}
+
+// PostAnalyticsService counts post views in memory and writes them to
+// post_view_stats in batches, so a view never touches the posts table. Viewers are
+// deduplicated per process, each API instance keeps its own window.
+type PostAnalyticsService struct {
+	postRepo               repository.PostRepository
+	communityModeratorRepo repository.CommunityModeratorRepository
+	postAnalyticsRepo      repository.PostAnalyticsRepository
+	communityRepo          repository.CommunityRepository
+	subscriptionRepo       repository.SubscriptionRepository
+
+	mu      sync.Mutex
+	seen    map[string]time.Time
+	pending map[postViewKey]int64
+	now     func() time.Time
+}
+
+func NewPostAnalyticsService(
+	postRepo repository.PostRepository,
+	communityModeratorRepo repository.CommunityModeratorRepository,
+	postAnalyticsRepo repository.PostAnalyticsRepository,
+	communityRepo repository.CommunityRepository,
+	subscriptionRepo repository.SubscriptionRepository,
+) *PostAnalyticsService {
+	return &PostAnalyticsService{
+		postRepo:               postRepo,
+		communityModeratorRepo: communityModeratorRepo,
+		postAnalyticsRepo:      postAnalyticsRepo,
+		communityRepo:          communityRepo,
+		subscriptionRepo:       subscriptionRepo,
+		seen:                   make(map[string]time.Time),
+		pending:                make(map[postViewKey]int64),
+		now:                    time.Now,
+	}
+}
+
+// RecordView counts a view of the post unless the same viewer was counted within the
+// dedup window. Logged in users are identified by their ID, anonymous viewers by a
+// hash of their IP address and user agent. Authors viewing their own post are not counted,
+// nor are new viewers while the dedup window is full, since they could not be told apart.
+func (s *PostAnalyticsService) RecordView(postID, authorID uint64, userID *uint64, clientIP, userAgent string, referralCommunityID uint64) {
+	if userID != nil && *userID == authorID {
+		return
+	}
+
+	seenKey := fmt.Sprintf("%d:%s", postID, viewerFingerprint(userID, clientIP, userAgent))
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lastSeen, ok := s.seen[seenKey]
+	if ok && now.Sub(lastSeen) < constant.POST_VIEW_DEDUP_WINDOW_MINUTES*time.Minute {
+		return
+	}
+	if !ok && len(s.seen) >= constant.POST_VIEW_MAX_TRACKED_VIEWERS {
+		return
+	}
+	s.seen[seenKey] = now
+
+	s.pending[postViewKey{
+		postID:              postID,
+		bucketStart:         now.UTC().Truncate(time.Hour),
+		referralCommunityID: referralCommunityID,
+	}]++
+}
+
+// ReferralCommunityID returns the community a view was referred from when it exists and
+// the viewer can see it, 0 otherwise, so made up referrals never reach the analytics
+func (s *PostAnalyticsService) ReferralCommunityID(ctx context.Context, communityID uint64, userID *uint64) uint64 {
+	if communityID == 0 {
+		return 0
+	}
+
+	community, err := s.communityRepo.GetCommunityByID(communityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Referral community not found in PostAnalyticsService.ReferralCommunityID: communityID=%d, err=%v", communityID, err)
+		return 0
+	}
+	if community.IsPrivate {
+		if userID == nil {
+			return 0
+		}
+		memberIDs, err := s.subscriptionRepo.FilterApprovedSubscriberIDs(communityID, []uint64{*userID})
+		if err != nil || len(memberIDs) == 0 {
+			return 0
+		}
+	}
+	return communityID
+}
+
+func viewerFingerprint(userID *uint64, clientIP, userAgent string) string {
+	if userID != nil {
+		return fmt.Sprintf("user:%d", *userID)
+	}
+	sum := sha256.Sum256([]byte(clientIP + "|" + userAgent))
+	return "anon:" + hex.EncodeToString(sum[:16])
+}
+
+// FlushPostViews writes the buffered view counts and forgets viewers whose dedup
+// window has passed. Counts are put back into the buffer if the write fails.
+func (s *PostAnalyticsService) FlushPostViews(ctx context.Context) error {
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = make(map[postViewKey]int64)
+	cutoff := s.now().Add(-constant.POST_VIEW_DEDUP_WINDOW_MINUTES * time.Minute)
+	for key, lastSeen := range s.seen {
+		if lastSeen.Before(cutoff) {
+			delete(s.seen, key)
+		}
+	}
+	s.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	stats := make([]*model.PostViewStat, 0, len(pending))
+	for key, views := range pending {
+		stats = append(stats, &model.PostViewStat{
+			PostID:              key.postID,
+			BucketStart:         key.bucketStart,
+			ReferralCommunityID: key.referralCommunityID,
+			Views:               views,
+		})
+	}
+
+	if err := s.postAnalyticsRepo.IncrementPostViews(stats); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error saving post views in PostAnalyticsService.FlushPostViews: %v", err)
+		s.mu.Lock()
+		for key, views := range pending {
+			s.pending[key] += views
+		}
+		s.mu.Unlock()
+		return fmt.Errorf("failed to save post views")
+	}
+
+	logger.InfofWithCtx(ctx, "[Info] Saved %d post view buckets", len(stats))
+	return nil
+}
+
+// GetPostAnalytics returns the reach of a post to its author or a moderator who manages
+// the posts of its community, with timelines over the last days bucketed by hour or day
+func (s *PostAnalyticsService) GetPostAnalytics(ctx context.Context, userID, postID uint64, interval string, days int) (*response.PostAnalyticsResponse, error) {
+	// Loaded without the visibility filter so authors and moderators of private communities pass
+	post, err := s.postRepo.GetPostByID(postID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Post not found in PostAnalyticsService.GetPostAnalytics: %v", err)
+		return nil, fmt.Errorf("post not found")
+	}
+
+	if post.AuthorID != userID {
+		if err := checkCommunityPermission(ctx, s.communityModeratorRepo, post.CommunityID, userID, constant.COMMUNITY_PERMISSION_MANAGE_POSTS, "PostAnalyticsService.GetPostAnalytics"); err != nil {
+			return nil, err
+		}
+	}
+
+	if days <= 0 {
+		days = constant.POST_ANALYTICS_DEFAULT_DAYS
+	}
+	if days > constant.POST_ANALYTICS_MAX_DAYS {
+		days = constant.POST_ANALYTICS_MAX_DAYS
+	}
+	if interval != constant.POST_ANALYTICS_INTERVAL_HOUR {
+		interval = constant.POST_ANALYTICS_INTERVAL_DAY
+	}
+	from := s.now().UTC().AddDate(0, 0, -days).Truncate(time.Hour)
+
+	totalViews, err := s.postAnalyticsRepo.GetPostTotalViews(postID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting total views in PostAnalyticsService.GetPostAnalytics: %v", err)
+		return nil, fmt.Errorf("failed to get post analytics")
+	}
+
+	saveCount, err := s.postAnalyticsRepo.CountPostSaves(postID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error counting saves in PostAnalyticsService.GetPostAnalytics: %v", err)
+		return nil, fmt.Errorf("failed to get post analytics")
+	}
+
+	vote, err := s.postAnalyticsRepo.GetPostVoteScore(postID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting vote score in PostAnalyticsService.GetPostAnalytics: %v", err)
+		return nil, fmt.Errorf("failed to get post analytics")
+	}
+
+	commentCount, err := s.postAnalyticsRepo.CountPostComments(postID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error counting comments in PostAnalyticsService.GetPostAnalytics: %v", err)
+		return nil, fmt.Errorf("failed to get post analytics")
+	}
+
+	viewTimeline, err := s.postAnalyticsRepo.GetPostViewTimeline(postID, interval, from)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting view timeline in PostAnalyticsService.GetPostAnalytics: %v", err)
+		return nil, fmt.Errorf("failed to get post analytics")
+	}
+
+	voteTimeline, err := s.postAnalyticsRepo.GetPostVoteTimeline(postID, interval, from)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting vote timeline in PostAnalyticsService.GetPostAnalytics: %v", err)
+		return nil, fmt.Errorf("failed to get post analytics")
+	}
+
+	commentTimeline, err := s.postAnalyticsRepo.GetPostCommentTimeline(postID, interval, from)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting comment timeline in PostAnalyticsService.GetPostAnalytics: %v", err)
+		return nil, fmt.Errorf("failed to get post analytics")
+	}
+
+	referrals, err := s.postAnalyticsRepo.GetPostReferrals(postID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting referrals in PostAnalyticsService.GetPostAnalytics: %v", err)
+		return nil, fmt.Errorf("failed to get post analytics")
+	}
+
+	return &response.PostAnalyticsResponse{
+		PostID:          post.ID,
+		Views:           totalViews,
+		Vote:            vote,
+		CommentCount:    commentCount,
+		SaveCount:       saveCount,
+		Interval:        interval,
+		From:            from,
+		ViewTimeline:    response.NewActivityBucketResponses(viewTimeline),
+		VoteTimeline:    response.NewVoteBucketResponses(voteTimeline),
+		CommentTimeline: response.NewActivityBucketResponses(commentTimeline),
+		Referrals:       response.NewReferralResponses(referrals),
+	}, nil
+}
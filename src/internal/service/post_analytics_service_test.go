@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/package/constant"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPostAnalyticsService_RecordView_DeduplicatesViewers(t *testing.T) {
+	mockAnalyticsRepo := new(MockPostAnalyticsRepository)
+	now := time.Date(2026, 3, 1, 10, 15, 0, 0, time.UTC)
+	postAnalyticsService := NewPostAnalyticsService(nil, nil, mockAnalyticsRepo, nil, nil)
+	postAnalyticsService.now = func() time.Time { return now }
+	userID := uint64(7)
+	authorID := uint64(1)
+
+	// same user twice, two anonymous viewers told apart by user agent, the author
+	postAnalyticsService.RecordView(10, authorID, &userID, "1.2.3.4", "firefox", 0)
+	postAnalyticsService.RecordView(10, authorID, &userID, "5.6.7.8", "chrome", 0)
+	postAnalyticsService.RecordView(10, authorID, nil, "1.2.3.4", "firefox", 3)
+	postAnalyticsService.RecordView(10, authorID, nil, "1.2.3.4", "chrome", 3)
+	postAnalyticsService.RecordView(10, authorID, nil, "1.2.3.4", "chrome", 3)
+	postAnalyticsService.RecordView(10, authorID, &authorID, "9.9.9.9", "safari", 0)
+
+	bucket := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	mockAnalyticsRepo.On("IncrementPostViews", mock.MatchedBy(func(stats []*model.PostViewStat) bool {
+		views := make(map[uint64]int64)
+		for _, stat := range stats {
+			if stat.PostID != 10 || !stat.BucketStart.Equal(bucket) {
+				return false
+			}
+			views[stat.ReferralCommunityID] += stat.Views
+		}
+		return len(stats) == 2 && views[0] == 1 && views[3] == 2
+	})).Return(nil).Once()
+
+	err := postAnalyticsService.FlushPostViews(context.Background())
+
+	assert.NoError(t, err)
+	mockAnalyticsRepo.AssertExpectations(t)
+}
+
+func TestPostAnalyticsService_RecordView_CountsAgainAfterWindow(t *testing.T) {
+	mockAnalyticsRepo := new(MockPostAnalyticsRepository)
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	postAnalyticsService := NewPostAnalyticsService(nil, nil, mockAnalyticsRepo, nil, nil)
+	postAnalyticsService.now = func() time.Time { return now }
+	userID := uint64(7)
+
+	postAnalyticsService.RecordView(10, 1, &userID, "", "", 0)
+	postAnalyticsService.now = func() time.Time {
+		return now.Add(constant.POST_VIEW_DEDUP_WINDOW_MINUTES * time.Minute)
+	}
+	postAnalyticsService.RecordView(10, 1, &userID, "", "", 0)
+
+	mockAnalyticsRepo.On("IncrementPostViews", mock.MatchedBy(func(stats []*model.PostViewStat) bool {
+		return len(stats) == 1 && stats[0].Views == 2
+	})).Return(nil).Once()
+
+	err := postAnalyticsService.FlushPostViews(context.Background())
+
+	assert.NoError(t, err)
+	mockAnalyticsRepo.AssertExpectations(t)
+}
+
+func TestPostAnalyticsService_RecordView_DropsNewViewersWhenFull(t *testing.T) {
+	mockAnalyticsRepo := new(MockPostAnalyticsRepository)
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	postAnalyticsService := NewPostAnalyticsService(nil, nil, mockAnalyticsRepo, nil, nil)
+	postAnalyticsService.now = func() time.Time { return now }
+	userID := uint64(7)
+
+	postAnalyticsService.RecordView(10, 1, &userID, "", "", 0)
+	for i := 1; i < constant.POST_VIEW_MAX_TRACKED_VIEWERS; i++ {
+		postAnalyticsService.seen[fmt.Sprintf("11:anon:%d", i)] = now
+	}
+	postAnalyticsService.RecordView(10, 1, nil, "1.2.3.4", "firefox", 0)
+	// a viewer already remembered is counted again once the window has passed
+	postAnalyticsService.now = func() time.Time {
+		return now.Add(constant.POST_VIEW_DEDUP_WINDOW_MINUTES * time.Minute)
+	}
+	postAnalyticsService.RecordView(10, 1, &userID, "", "", 0)
+
+	mockAnalyticsRepo.On("IncrementPostViews", mock.MatchedBy(func(stats []*model.PostViewStat) bool {
+		return len(stats) == 1 && stats[0].Views == 2
+	})).Return(nil).Once()
+
+	err := postAnalyticsService.FlushPostViews(context.Background())
+
+	assert.NoError(t, err)
+	mockAnalyticsRepo.AssertExpectations(t)
+}
+
+func TestPostAnalyticsService_FlushPostViews_KeepsCountsOnFailure(t *testing.T) {
+	mockAnalyticsRepo := new(MockPostAnalyticsRepository)
+	postAnalyticsService := NewPostAnalyticsService(nil, nil, mockAnalyticsRepo, nil, nil)
+	userID := uint64(7)
+
+	postAnalyticsService.RecordView(10, 1, &userID, "", "", 0)
+
+	mockAnalyticsRepo.On("IncrementPostViews", mock.Anything).Return(errors.New("db down")).Once()
+	err := postAnalyticsService.FlushPostViews(context.Background())
+	assert.Error(t, err)
+
+	mockAnalyticsRepo.On("IncrementPostViews", mock.MatchedBy(func(stats []*model.PostViewStat) bool {
+		return len(stats) == 1 && stats[0].Views == 1
+	})).Return(nil).Once()
+	err = postAnalyticsService.FlushPostViews(context.Background())
+	assert.NoError(t, err)
+
+	// nothing left to write
+	err = postAnalyticsService.FlushPostViews(context.Background())
+	assert.NoError(t, err)
+	mockAnalyticsRepo.AssertExpectations(t)
+}
+
+func TestPostAnalyticsService_GetPostAnalytics_PermissionDenied(t *testing.T) {
+	mockPostRepo := new(MockPostRepository)
+	mockModeratorRepo := new(MockCommunityModeratorRepository)
+	postAnalyticsService := NewPostAnalyticsService(mockPostRepo, mockModeratorRepo, new(MockPostAnalyticsRepository), nil, nil)
+
+	mockPostRepo.On("GetPostByID", uint64(10)).Return(&model.Post{ID: 10, AuthorID: 1, CommunityID: 2}, nil)
+	mockModeratorRepo.On("GetModeratorRole", uint64(2), uint64(7)).Return("", errors.New("record not found"))
+
+	result, err := postAnalyticsService.GetPostAnalytics(context.Background(), 7, 10, constant.POST_ANALYTICS_INTERVAL_DAY, 7)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "permission denied", err.Error())
+}
+
+func TestPostAnalyticsService_GetPostAnalytics_CustomRoleWithoutPermission(t *testing.T) {
+	mockPostRepo := new(MockPostRepository)
+	mockModeratorRepo := new(MockCommunityModeratorRepository)
+	mockAnalyticsRepo := new(MockPostAnalyticsRepository)
+	postAnalyticsService := NewPostAnalyticsService(mockPostRepo, mockModeratorRepo, mockAnalyticsRepo, nil, nil)
+
+	mockPostRepo.On("GetPostByID", uint64(10)).Return(&model.Post{ID: 10, AuthorID: 1, CommunityID: 2}, nil)
+	mockModeratorRepo.On("GetModeratorRole", uint64(2), uint64(7)).Return(constant.ROLE_MODERATOR, nil)
+	mockModeratorRepo.On("GetModeratorCustomRole", uint64(2), uint64(7)).Return(&model.CommunityRole{
+		ID:          5,
+		CommunityID: 2,
+		Permissions: []string{constant.COMMUNITY_PERMISSION_MANAGE_COMMENTS},
+	}, nil)
+
+	result, err := postAnalyticsService.GetPostAnalytics(context.Background(), 7, 10, constant.POST_ANALYTICS_INTERVAL_DAY, 7)
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "permission denied")
+	mockAnalyticsRepo.AssertNotCalled(t, "GetPostTotalViews", mock.Anything)
+}
+
+func TestPostAnalyticsService_GetPostAnalytics_Author(t *testing.T) {
+	mockPostRepo := new(MockPostRepository)
+	mockAnalyticsRepo := new(MockPostAnalyticsRepository)
+	now := time.Date(2026, 3, 8, 12, 30, 0, 0, time.UTC)
+	postAnalyticsService := NewPostAnalyticsService(mockPostRepo, new(MockCommunityModeratorRepository), mockAnalyticsRepo, nil, nil)
+	postAnalyticsService.now = func() time.Time { return now }
+	from := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	communityName := "golang"
+
+	mockPostRepo.On("GetPostByID", uint64(10)).Return(&model.Post{ID: 10, AuthorID: 1, CommunityID: 2}, nil)
+	mockAnalyticsRepo.On("GetPostTotalViews", uint64(10)).Return(int64(42), nil)
+	mockAnalyticsRepo.On("CountPostSaves", uint64(10)).Return(int64(4), nil)
+	mockAnalyticsRepo.On("GetPostVoteScore", uint64(10)).Return(int64(5), nil)
+	mockAnalyticsRepo.On("CountPostComments", uint64(10)).Return(int64(3), nil)
+	mockAnalyticsRepo.On("GetPostViewTimeline", uint64(10), constant.POST_ANALYTICS_INTERVAL_DAY, from).
+		Return([]*model.PostActivityBucket{{BucketStart: from, Count: 42}}, nil)
+	mockAnalyticsRepo.On("GetPostVoteTimeline", uint64(10), constant.POST_ANALYTICS_INTERVAL_DAY, from).
+		Return([]*model.PostVoteBucket{{BucketStart: from, Upvotes: 6, Downvotes: 1}}, nil)
+	mockAnalyticsRepo.On("GetPostCommentTimeline", uint64(10), constant.POST_ANALYTICS_INTERVAL_DAY, from).
+		Return([]*model.PostActivityBucket{}, nil)
+	mockAnalyticsRepo.On("GetPostReferrals", uint64(10)).
+		Return([]*model.PostReferral{{CommunityID: 0, Views: 30}, {CommunityID: 2, CommunityName: &communityName, Views: 12}}, nil)
+
+	result, err := postAnalyticsService.GetPostAnalytics(context.Background(), 1, 10, constant.POST_ANALYTICS_INTERVAL_DAY, 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), result.Views)
+	assert.Equal(t, int64(5), result.Vote)
+	assert.Equal(t, int64(4), result.SaveCount)
+	assert.Len(t, result.ViewTimeline, 1)
+	assert.Equal(t, int64(6), result.VoteTimeline[0].Upvotes)
+	assert.Nil(t, result.Referrals[0].CommunityID)
+	assert.Equal(t, uint64(2), *result.Referrals[1].CommunityID)
+	mockAnalyticsRepo.AssertExpectations(t)
+}
+
+func TestPostAnalyticsService_GetPostAnalytics_ModeratorOfPrivateCommunity(t *testing.T) {
+	mockPostRepo := new(MockPostRepository)
+	mockModeratorRepo := new(MockCommunityModeratorRepository)
+	mockAnalyticsRepo := new(MockPostAnalyticsRepository)
+	now := time.Date(2026, 3, 8, 12, 30, 0, 0, time.UTC)
+	postAnalyticsService := NewPostAnalyticsService(mockPostRepo, mockModeratorRepo, mockAnalyticsRepo, nil, nil)
+	postAnalyticsService.now = func() time.Time { return now }
+
+	mockPostRepo.On("GetPostByID", uint64(10)).Return(&model.Post{
+		ID:          10,
+		AuthorID:    1,
+		CommunityID: 2,
+		Community:   &model.Community{ID: 2, IsPrivate: true},
+	}, nil)
+	mockModeratorRepo.On("GetModeratorRole", uint64(2), uint64(7)).Return(constant.ROLE_SUPER_ADMIN, nil)
+	mockAnalyticsRepo.On("GetPostTotalViews", uint64(10)).Return(int64(9), nil)
+	mockAnalyticsRepo.On("CountPostSaves", uint64(10)).Return(int64(0), nil)
+	mockAnalyticsRepo.On("GetPostVoteScore", uint64(10)).Return(int64(2), nil)
+	mockAnalyticsRepo.On("CountPostComments", uint64(10)).Return(int64(1), nil)
+	mockAnalyticsRepo.On("GetPostViewTimeline", uint64(10), constant.POST_ANALYTICS_INTERVAL_DAY, mock.Anything).Return([]*model.PostActivityBucket{}, nil)
+	mockAnalyticsRepo.On("GetPostVoteTimeline", uint64(10), constant.POST_ANALYTICS_INTERVAL_DAY, mock.Anything).Return([]*model.PostVoteBucket{}, nil)
+	mockAnalyticsRepo.On("GetPostCommentTimeline", uint64(10), constant.POST_ANALYTICS_INTERVAL_DAY, mock.Anything).Return([]*model.PostActivityBucket{}, nil)
+	mockAnalyticsRepo.On("GetPostReferrals", uint64(10)).Return([]*model.PostReferral{}, nil)
+
+	result, err := postAnalyticsService.GetPostAnalytics(context.Background(), 7, 10, constant.POST_ANALYTICS_INTERVAL_DAY, 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9), result.Views)
+	assert.Equal(t, int64(2), result.Vote)
+	assert.Equal(t, int64(1), result.CommentCount)
+	mockPostRepo.AssertNotCalled(t, "GetPostDetailByID", mock.Anything, mock.Anything)
+}
+
+func TestPostAnalyticsService_ReferralCommunityID_PrivateCommunity(t *testing.T) {
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockSubscriptionRepo := new(MockSubscriptionRepository)
+	postAnalyticsService := NewPostAnalyticsService(nil, nil, nil, mockCommunityRepo, mockSubscriptionRepo)
+	memberID := uint64(7)
+	outsiderID := uint64(8)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, IsPrivate: true}, nil)
+	mockCommunityRepo.On("GetCommunityByID", uint64(4)).Return(nil, errors.New("record not found"))
+	mockSubscriptionRepo.On("FilterApprovedSubscriberIDs", uint64(3), []uint64{memberID}).Return([]uint64{memberID}, nil)
+	mockSubscriptionRepo.On("FilterApprovedSubscriberIDs", uint64(3), []uint64{outsiderID}).Return([]uint64{}, nil)
+
+	assert.Equal(t, uint64(3), postAnalyticsService.ReferralCommunityID(context.Background(), 3, &memberID))
+	assert.Equal(t, uint64(0), postAnalyticsService.ReferralCommunityID(context.Background(), 3, &outsiderID))
+	assert.Equal(t, uint64(0), postAnalyticsService.ReferralCommunityID(context.Background(), 3, nil))
+	assert.Equal(t, uint64(0), postAnalyticsService.ReferralCommunityID(context.Background(), 4, &memberID))
+}
@@ -11,8 +11,11 @@ import (
 	"social-platform-backend/package/constant"
 	"social-platform-backend/package/logger"
 	"social-platform-backend/package/template/payload"
+	"social-platform-backend/package/util"
 	"strings"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 type PostService struct {
@@ -28,6 +31,19 @@ type PostService struct {
 	botTaskService      *BotTaskService
 	recommendService    *RecommendationService
 	aiServiceClient     *AIServiceClient
+	userRestrictionRepo repository.UserRestrictionRepository
+	postRevisionRepo    repository.PostRevisionRepository
+	pollRepo            repository.PollRepository
+	linkPreviewService  *LinkPreviewService
+	contentSanitizer    *util.HTMLSanitizer
+
+	duplicateDetectionService *DuplicateDetectionService
+	mentionService            *MentionService
+	contentFlagRepo           repository.ContentFlagRepository
+	reportRepo                repository.ReportRepository
+	autoModService            *AutoModService
+	communityRuleRepo         repository.CommunityRuleRepository
+	communityModeratorRepo    repository.CommunityModeratorRepository
 }
 
 func NewPostService(
@@ -43,6 +59,18 @@ func NewPostService(
 	botTaskService *BotTaskService,
 	recommendService *RecommendationService,
 	aiServiceClient *AIServiceClient,
+	userRestrictionRepo repository.UserRestrictionRepository,
+	postRevisionRepo repository.PostRevisionRepository,
+	pollRepo repository.PollRepository,
+	linkPreviewService *LinkPreviewService,
+	contentSanitizer *util.HTMLSanitizer,
+	duplicateDetectionService *DuplicateDetectionService,
+	mentionService *MentionService,
+	contentFlagRepo repository.ContentFlagRepository,
+	reportRepo repository.ReportRepository,
+	autoModService *AutoModService,
+	communityRuleRepo repository.CommunityRuleRepository,
+	communityModeratorRepo repository.CommunityModeratorRepository,
 ) *PostService {
 	return &PostService{
 		postRepo:            postRepo,
@@ -57,6 +85,19 @@ func NewPostService(
 		botTaskService:      botTaskService,
 		recommendService:    recommendService,
 		aiServiceClient:     aiServiceClient,
+		userRestrictionRepo: userRestrictionRepo,
+		postRevisionRepo:    postRevisionRepo,
+		pollRepo:            pollRepo,
+		linkPreviewService:  linkPreviewService,
+		contentSanitizer:    contentSanitizer,
+
+		duplicateDetectionService: duplicateDetectionService,
+		mentionService:            mentionService,
+		contentFlagRepo:           contentFlagRepo,
+		reportRepo:                reportRepo,
+		autoModService:            autoModService,
+		communityRuleRepo:         communityRuleRepo,
+		communityModeratorRepo:    communityModeratorRepo,
 	}
 }
 
@@ -68,78 +109,221 @@ func (s *PostService) CreatePost(ctx context.Context, userID uint64, req *reques
 		return fmt.Errorf("community not found")
 	}
 
-	// Validate post type and required fields
-	switch req.Type {
+	content := s.contentSanitizer.SanitizeContent(req.Content, req.ContentFormat)
+	post := &model.Post{
+		CommunityID: req.CommunityID,
+		AuthorID:    userID,
+		Title:       req.Title,
+		Type:        req.Type,
+		Content:     content,
+		ContentText: util.HTMLToText(content),
+		URL:         req.URL,
+		MediaURLs:   req.MediaURLs,
+		PollData:    sanitizePollData(req.PollData, 1),
+		Tags:        req.Tags,
+		Status:      constant.POST_STATUS_DRAFT,
+		PublishAt:   req.PublishAt,
+	}
+
+	// Drafts may miss the payload of their type, they are fully validated when published
+	if req.IsDraft {
+		if err := s.validateDraftContent(ctx, req.Type, req.URL, req.MediaURLs, post.PollData); err != nil {
+			return err
+		}
+		if err := s.postRepo.CreatePost(post); err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Error creating draft post in PostService.CreatePost: %v", err)
+			return fmt.Errorf("failed to create post")
+		}
+		return nil
+	}
+
+	if err := s.validatePostContent(ctx, req.Type, req.URL, req.MediaURLs, post.PollData); err != nil {
+		return err
+	}
+	if content == "" {
+		logger.ErrorfWithCtx(ctx, "[Err] Content is empty after sanitization in PostService.CreatePost")
+		return fmt.Errorf("content is empty")
+	}
+
+	// Scheduled posts are moderated by the scheduler at publish time
+	if req.PublishAt != nil && req.PublishAt.After(time.Now()) {
+		post.Status = constant.POST_STATUS_SCHEDULED
+		if err := s.postRepo.CreatePost(post); err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Error creating scheduled post in PostService.CreatePost: %v", err)
+			return fmt.Errorf("failed to create post")
+		}
+		return nil
+	}
+
+	// Determine post status based on community settings
+	post.Status = constant.POST_STATUS_PENDING
+	if !community.RequiresPostApproval {
+		post.Status = constant.POST_STATUS_APPROVED
+	}
+	post.PublishAt = nil
+	publishedAt := time.Now()
+	post.PublishedAt = &publishedAt
+
+	duplicateCheck, err := s.checkDuplicate(ctx, post)
+	if err != nil {
+		return err
+	}
+	if duplicateCheck != nil && duplicateCheck.Action == constant.DUPLICATE_ACTION_HOLD {
+		post.Status = constant.POST_STATUS_PENDING
+	}
+
+	if err := s.postRepo.CreatePost(post); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error creating post in PostService.CreatePost: %v", err)
+		return fmt.Errorf("failed to create post")
+	}
+	s.saveFingerprint(ctx, post, duplicateCheck)
+
+	s.syncPoll(ctx, post)
+	s.refreshLinkPreview(ctx, post.Type, post.URL)
+	s.storeMentions(ctx, post)
+	if s.runAutoMod(ctx, constant.AUTOMOD_TRIGGER_POST_CREATED, post) {
+		return nil
+	}
+	s.moderatePostAsync(ctx, userID, post)
+
+	return nil
+}
+
+func (s *PostService) validatePostContent(ctx context.Context, postType string, url *string, mediaURLs *pq.StringArray, rawPollData *json.RawMessage) error {
+	switch postType {
 	case constant.PostTypeText:
 		// text post: only title and content required
 	case constant.PostTypeLink:
-		if req.URL == nil {
-			logger.ErrorfWithCtx(ctx, "[Err] URL is required for link post in PostService.CreatePost")
+		if url == nil {
+			logger.ErrorfWithCtx(ctx, "[Err] URL is required for link post in PostService.validatePostContent")
 			return fmt.Errorf("url is required for link post")
 		}
 	case constant.PostTypeMedia:
-		if req.MediaURLs == nil || len(*req.MediaURLs) == 0 {
-			logger.ErrorfWithCtx(ctx, "[Err] Media URLs are required for media post in PostService.CreatePost")
+		if mediaURLs == nil || len(*mediaURLs) == 0 {
+			logger.ErrorfWithCtx(ctx, "[Err] Media URLs are required for media post in PostService.validatePostContent")
 			return fmt.Errorf("media_urls are required for media post")
 		}
 	case constant.PostTypePoll:
-		if req.PollData == nil {
-			logger.ErrorfWithCtx(ctx, "[Err] Poll data is required for poll post in PostService.CreatePost")
+		if rawPollData == nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Poll data is required for poll post in PostService.validatePostContent")
 			return fmt.Errorf("poll_data is required for poll post")
 		}
 		// Validate poll data structure
 		var pollData payload.PollData
-		if err := json.Unmarshal(*req.PollData, &pollData); err != nil {
-			logger.ErrorfWithCtx(ctx, "[Err] Invalid poll data format in PostService.CreatePost: %v", err)
+		if err := json.Unmarshal(*rawPollData, &pollData); err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Invalid poll data format in PostService.validatePostContent: %v", err)
 			return fmt.Errorf("invalid poll data format")
 		}
 		if pollData.Question == "" {
-			logger.ErrorfWithCtx(ctx, "[Err] Poll question is required in PostService.CreatePost")
+			logger.ErrorfWithCtx(ctx, "[Err] Poll question is required in PostService.validatePostContent")
 			return fmt.Errorf("poll question is required")
 		}
 		if len(pollData.Options) < 2 {
-			logger.ErrorfWithCtx(ctx, "[Err] Poll must have at least 2 options in PostService.CreatePost")
+			logger.ErrorfWithCtx(ctx, "[Err] Poll must have at least 2 options in PostService.validatePostContent")
 			return fmt.Errorf("poll must have at least 2 options")
 		}
+		if len(pollData.Options) > constant.MAX_POLL_OPTIONS {
+			logger.ErrorfWithCtx(ctx, "[Err] Poll has too many options in PostService.validatePostContent")
+			return fmt.Errorf("poll cannot have more than %d options", constant.MAX_POLL_OPTIONS)
+		}
+		if pollData.RankedChoice && pollData.MultipleChoice {
+			logger.ErrorfWithCtx(ctx, "[Err] Poll is both ranked and multiple choice in PostService.validatePostContent")
+			return fmt.Errorf("poll cannot be both ranked and multiple choice")
+		}
+		optionIDs := make(map[int]bool, len(pollData.Options))
 		for i, option := range pollData.Options {
 			if option.Text == "" {
-				logger.ErrorfWithCtx(ctx, "[Err] Poll option %d text is required in PostService.CreatePost", i)
+				logger.ErrorfWithCtx(ctx, "[Err] Poll option %d text is required in PostService.validatePostContent", i)
 				return fmt.Errorf("poll option text is required")
 			}
+			if optionIDs[option.ID] {
+				logger.ErrorfWithCtx(ctx, "[Err] Duplicate poll option id %d in PostService.validatePostContent", option.ID)
+				return fmt.Errorf("poll option ids must be unique")
+			}
+			optionIDs[option.ID] = true
 		}
 	default:
-		logger.ErrorfWithCtx(ctx, "[Err] Invalid post type in PostService.CreatePost: %s", req.Type)
+		logger.ErrorfWithCtx(ctx, "[Err] Invalid post type in PostService.validatePostContent: %s", postType)
 		return fmt.Errorf("invalid post type")
 	}
 
-	// Determine post status based on community settings
-	postStatus := constant.POST_STATUS_PENDING
-	if !community.RequiresPostApproval {
-		postStatus = constant.POST_STATUS_APPROVED
+	return nil
+}
+
+// validateDraftContent checks the type of a draft and the url, media or poll it
+// already has. Unlike validatePostContent it lets them be missing until publishing.
+func (s *PostService) validateDraftContent(ctx context.Context, postType string, url *string, mediaURLs *pq.StringArray, rawPollData *json.RawMessage) error {
+	switch postType {
+	case constant.PostTypeLink:
+		if url == nil {
+			return nil
+		}
+	case constant.PostTypeMedia:
+		if mediaURLs == nil || len(*mediaURLs) == 0 {
+			return nil
+		}
+	case constant.PostTypePoll:
+		if rawPollData == nil {
+			return nil
+		}
 	}
+	return s.validatePostContent(ctx, postType, url, mediaURLs, rawPollData)
+}
 
-	post := &model.Post{
-		CommunityID: req.CommunityID,
-		AuthorID:    userID,
-		Title:       req.Title,
-		Type:        req.Type,
-		Content:     req.Content,
-		URL:         req.URL,
-		MediaURLs:   req.MediaURLs,
-		PollData:    req.PollData,
-		Tags:        req.Tags,
-		Status:      postStatus,
+// checkDuplicate rejects reposts of recent posts when configured to. Held and flagged
+// posts go through and are recorded for moderators.
+func (s *PostService) checkDuplicate(ctx context.Context, post *model.Post) (*DuplicateCheck, error) {
+	if s.duplicateDetectionService == nil {
+		return nil, nil
 	}
+	check := s.duplicateDetectionService.CheckPost(ctx, post)
+	if check != nil && check.Action == constant.DUPLICATE_ACTION_REJECT {
+		logger.ErrorfWithCtx(ctx, "[Err] Post duplicates a recent post in PostService.checkDuplicate: userID=%d", post.AuthorID)
+		return nil, fmt.Errorf("duplicate content")
+	}
+	return check, nil
+}
 
-	if err := s.postRepo.CreatePost(post); err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] Error creating post in PostService.CreatePost: %v", err)
-		return fmt.Errorf("failed to create post")
+func (s *PostService) saveFingerprint(ctx context.Context, post *model.Post, check *DuplicateCheck) {
+	if s.duplicateDetectionService == nil {
+		return
+	}
+	s.duplicateDetectionService.SaveFingerprint(ctx, post, check)
+}
+
+// refreshLinkPreview fetches the preview of a link post in the background
+func (s *PostService) refreshLinkPreview(ctx context.Context, postType string, url *string) {
+	if postType != constant.PostTypeLink || url == nil || s.linkPreviewService == nil {
+		return
+	}
+	s.linkPreviewService.RefreshLinkPreviewAsync(ctx, *url)
+}
+
+// runAutoMod applies the community's AutoModerator rules to the post and reports whether
+// they removed it
+func (s *PostService) runAutoMod(ctx context.Context, trigger string, post *model.Post) bool {
+	if s.autoModService == nil {
+		return false
+	}
+	return s.autoModService.Evaluate(ctx, trigger, post, nil)
+}
+
+// storeMentions saves the mentions of a new post without notifying anyone. It runs before
+// AutoModerator and the content check, so that a post they hold or reject still notifies
+// its mentions once a moderator approves it.
+func (s *PostService) storeMentions(ctx context.Context, post *model.Post) {
+	if s.mentionService == nil {
+		return
 	}
+	s.mentionService.SyncPostMentions(ctx, post, false)
+}
 
+// moderatePostAsync runs the AI content check for a newly published post in the background
+func (s *PostService) moderatePostAsync(ctx context.Context, userID uint64, post *model.Post) {
 	go func(userID uint64, post *model.Post, postType string) {
 		defer func() {
 			if r := recover(); r != nil {
-				logger.ErrorfWithCtx(ctx, "[Panic] Recovered in PostService.CreatePost background task: %v", r)
+				logger.ErrorfWithCtx(ctx, "[Panic] Recovered in PostService.moderatePostAsync background task: %v", r)
 			}
 		}()
 
@@ -151,7 +335,7 @@ func (s *PostService) CreatePost(ctx context.Context, userID uint64, req *reques
 		// if postType == constant.PostTypeText || postType == constant.PostTypeLink || postType == constant.PostTypePoll {
 		// 	combinedText := post.Title + " " + post.Content
 		// 	if textViolation, err := util.CheckTextContent(combinedText); err != nil {
-		// 		logger.ErrorfWithCtx(ctx, "[Err] Error checking text content in PostService.CreatePost: %v", err)
+		// 		logger.ErrorfWithCtx(ctx, "[Err] Error checking text content in PostService.moderatePostAsync: %v", err)
 		// 	} else if textViolation.IsViolation {
 		// 		violation = true
 		// 		violationReason = textViolation.Reason
@@ -162,7 +346,7 @@ func (s *PostService) CreatePost(ctx context.Context, userID uint64, req *reques
 		// if !violation && postType == constant.PostTypeMedia && post.MediaURLs != nil {
 		// 	for _, mediaURL := range *post.MediaURLs {
 		// 		if imageViolation, err := util.CheckImageContent(mediaURL); err != nil {
-		// 			logger.ErrorfWithCtx(ctx, "[Err] Error checking image content in PostService.CreatePost: %v", err)
+		// 			logger.ErrorfWithCtx(ctx, "[Err] Error checking image content in PostService.moderatePostAsync: %v", err)
 		// 		} else if imageViolation.IsViolation {
 		// 			violation = true
 		// 			violationReason = imageViolation.Reason
@@ -176,14 +360,14 @@ func (s *PostService) CreatePost(ctx context.Context, userID uint64, req *reques
 			imageURLs := []string{}
 
 			if postType == constant.PostTypeText || postType == constant.PostTypeLink || postType == constant.PostTypePoll {
-				content = strings.TrimSpace(post.Title + " " + post.Content)
+				content = strings.TrimSpace(post.Title + " " + post.ContentText)
 			}
 			if postType == constant.PostTypeMedia && post.MediaURLs != nil {
 				imageURLs = append(imageURLs, (*post.MediaURLs)...)
 			}
 
 			if moderationResult, err := s.aiServiceClient.CheckContent(ctx, content, imageURLs); err != nil {
-				logger.ErrorfWithCtx(ctx, "[Err] Error checking content via AI service in PostService.CreatePost: %v", err)
+				logger.ErrorfWithCtx(ctx, "[Err] Error checking content via AI service in PostService.moderatePostAsync: %v", err)
 			} else if moderationResult != nil && moderationResult.IsViolation {
 				violation = true
 				violationReason = moderationResult.Reason
@@ -197,6 +381,24 @@ func (s *PostService) CreatePost(ctx context.Context, userID uint64, req *reques
 				logger.ErrorfWithCtx(ctx, "[Err] Error updating post status to rejected: %v", err)
 			}
 
+			// Moderators can still approve a false positive from the moderation queue
+			if s.contentFlagRepo != nil {
+				flag := &model.ContentFlag{
+					CommunityID: post.CommunityID,
+					TargetType:  constant.MODERATION_TARGET_POST,
+					TargetID:    post.ID,
+					Source:      constant.CONTENT_FLAG_SOURCE_AI,
+					Reason:      violationReason,
+					CreatedAt:   time.Now(),
+				}
+				if violationCategory != "" {
+					flag.Category = &violationCategory
+				}
+				if err := s.contentFlagRepo.CreateContentFlag(flag); err != nil {
+					logger.ErrorfWithCtx(ctx, "[Err] Error flagging post in PostService.moderatePostAsync: %v", err)
+				}
+			}
+
 			if s.notificationService != nil {
 				notifPayload := &payload.ContentViolationPostPayload{
 					PostID:   post.ID,
@@ -211,14 +413,16 @@ func (s *PostService) CreatePost(ctx context.Context, userID uint64, req *reques
 			return
 		}
 
+		if s.mentionService != nil && post.Status == constant.POST_STATUS_APPROVED {
+			s.mentionService.NotifyPostMentions(ctx, post)
+		}
+
 		if s.botTaskService != nil {
 			if err := s.botTaskService.CreateKarmaTask(ctx, userID, nil, constant.KARMA_ACTION_CREATE_POST); err != nil {
-				logger.ErrorfWithCtx(ctx, "[Err] Error creating karma task in PostService.CreatePost: %v", err)
+				logger.ErrorfWithCtx(ctx, "[Err] Error creating karma task in PostService.moderatePostAsync: %v", err)
 			}
 		}
-	}(userID, post, req.Type)
-
-	return nil
+	}(userID, post, post.Type)
 }
 
 func (s *PostService) UpdatePost(ctx context.Context, userID, postID uint64, postType string, reqBody interface{}) error {
@@ -240,6 +444,20 @@ func (s *PostService) UpdatePost(ctx context.Context, userID, postID uint64, pos
 		return fmt.Errorf("post type mismatch")
 	}
 
+	// Keep the previous version so moderators can see what was reported. It is saved with
+	// the edit, once the edit is validated.
+	revision := &model.PostRevision{
+		PostID:    post.ID,
+		EditorID:  userID,
+		Title:     post.Title,
+		Content:   post.Content,
+		URL:       post.URL,
+		MediaURLs: post.MediaURLs,
+		PollData:  post.PollData,
+		Tags:      post.Tags,
+		CreatedAt: time.Now(),
+	}
+
 	// Update based on post type
 	switch postType {
 	case constant.PostTypeText:
@@ -247,7 +465,10 @@ func (s *PostService) UpdatePost(ctx context.Context, userID, postID uint64, pos
 		if !ok {
 			return fmt.Errorf("invalid request body for text post")
 		}
-		if err := s.postRepo.UpdatePostText(postID, req); err != nil {
+		if req.Content, err = s.sanitizeContent(ctx, req.Content, req.ContentFormat); err != nil {
+			return err
+		}
+		if err := s.postRepo.UpdatePostText(postID, req, revision); err != nil {
 			logger.ErrorfWithCtx(ctx, "[Err] Error updating text post in PostService.UpdatePost: %v", err)
 			return fmt.Errorf("failed to update post")
 		}
@@ -256,16 +477,23 @@ func (s *PostService) UpdatePost(ctx context.Context, userID, postID uint64, pos
 		if !ok {
 			return fmt.Errorf("invalid request body for link post")
 		}
-		if err := s.postRepo.UpdatePostLink(postID, req); err != nil {
+		if req.Content, err = s.sanitizeContent(ctx, req.Content, req.ContentFormat); err != nil {
+			return err
+		}
+		if err := s.postRepo.UpdatePostLink(postID, req, revision); err != nil {
 			logger.ErrorfWithCtx(ctx, "[Err] Error updating link post in PostService.UpdatePost: %v", err)
 			return fmt.Errorf("failed to update post")
 		}
+		s.refreshLinkPreview(ctx, postType, req.URL)
 	case constant.PostTypeMedia:
 		req, ok := reqBody.(*request.UpdatePostMediaRequest)
 		if !ok {
 			return fmt.Errorf("invalid request body for media post")
 		}
-		if err := s.postRepo.UpdatePostMedia(postID, req); err != nil {
+		if req.Content, err = s.sanitizeContent(ctx, req.Content, req.ContentFormat); err != nil {
+			return err
+		}
+		if err := s.postRepo.UpdatePostMedia(postID, req, revision); err != nil {
 			logger.ErrorfWithCtx(ctx, "[Err] Error updating media post in PostService.UpdatePost: %v", err)
 			return fmt.Errorf("failed to update post")
 		}
@@ -274,17 +502,101 @@ func (s *PostService) UpdatePost(ctx context.Context, userID, postID uint64, pos
 		if !ok {
 			return fmt.Errorf("invalid request body for poll post")
 		}
-		if err := s.postRepo.UpdatePostPoll(postID, req); err != nil {
+		if req.Content, err = s.sanitizeContent(ctx, req.Content, req.ContentFormat); err != nil {
+			return err
+		}
+		if req.PollData != nil {
+			minNewID := 1
+			if !isUnpublishedPost(post) {
+				if minNewID, err = s.checkPollEditable(ctx, post, req.PollData); err != nil {
+					return err
+				}
+			}
+			req.PollData = sanitizePollData(req.PollData, minNewID)
+			if err := s.validatePostContent(ctx, postType, nil, nil, req.PollData); err != nil {
+				return err
+			}
+		}
+		if err := s.postRepo.UpdatePostPoll(postID, req, revision); err != nil {
 			logger.ErrorfWithCtx(ctx, "[Err] Error updating poll post in PostService.UpdatePost: %v", err)
 			return fmt.Errorf("failed to update post")
 		}
+		if req.PollData != nil && !isUnpublishedPost(post) {
+			post.PollData = req.PollData
+			s.syncPoll(ctx, post)
+		}
 	default:
 		return fmt.Errorf("invalid post type")
 	}
 
+	// Drafts and scheduled posts resolve their mentions when published
+	if s.mentionService != nil && !isUnpublishedPost(post) {
+		if updatedPost, err := s.postRepo.GetPostByID(postID); err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Error getting updated post in PostService.UpdatePost: %v", err)
+		} else {
+			s.mentionService.SyncPostMentions(ctx, updatedPost, updatedPost.Status == constant.POST_STATUS_APPROVED)
+		}
+	}
+
 	return nil
 }
 
+// sanitizeContent sanitizes edited content, nil means the content is not changed
+func (s *PostService) sanitizeContent(ctx context.Context, content *string, format string) (*string, error) {
+	if content == nil {
+		return nil, nil
+	}
+	sanitized := s.contentSanitizer.SanitizeContent(*content, format)
+	if sanitized == "" {
+		logger.ErrorfWithCtx(ctx, "[Err] Content is empty after sanitization in PostService.sanitizeContent")
+		return nil, fmt.Errorf("content is empty")
+	}
+	return &sanitized, nil
+}
+
+// checkPollEditable rejects edits of closed polls and switching the poll type once
+// there are votes. Returns the first option id that is free for new options, which
+// also skips the ids of pending write-ins.
+func (s *PostService) checkPollEditable(ctx context.Context, post *model.Post, rawPollData *json.RawMessage) (int, error) {
+	currentPollData, err := parsePollPost(ctx, post, "checkPollEditable")
+	if err != nil {
+		return 0, err
+	}
+
+	poll, err := s.pollRepo.GetPollByPostID(post.ID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting poll in PostService.checkPollEditable: %v", err)
+		return 0, fmt.Errorf("failed to update post")
+	}
+	if isPollClosed(poll, currentPollData) {
+		return 0, fmt.Errorf("poll is closed")
+	}
+
+	options, err := s.pollRepo.GetPollOptionsByPostID(post.ID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting poll options in PostService.checkPollEditable: %v", err)
+		return 0, fmt.Errorf("failed to update post")
+	}
+	maxOptionID := 0
+	hasVotes := false
+	for _, option := range options {
+		if option.OptionID > maxOptionID {
+			maxOptionID = option.OptionID
+		}
+		if option.VoteCount > 0 {
+			hasVotes = true
+		}
+	}
+
+	var pollData payload.PollData
+	if err := json.Unmarshal(*rawPollData, &pollData); err == nil && hasVotes &&
+		(pollData.RankedChoice != currentPollData.RankedChoice || pollData.MultipleChoice != currentPollData.MultipleChoice) {
+		return 0, fmt.Errorf("poll type cannot be changed after voting")
+	}
+
+	return maxOptionID + 1, nil
+}
+
 func (s *PostService) DeletePost(ctx context.Context, userID, postID uint64) error {
 	post, err := s.postRepo.GetPostByID(postID)
 	if err != nil {
@@ -420,26 +732,8 @@ func (s *PostService) GetPostDetailByID(ctx context.Context, postID uint64, user
 		return nil, fmt.Errorf("post not found")
 	}
 
-	// Get community to check if it's private
-	community, err := s.communityRepo.GetCommunityByID(postExists.CommunityID)
-	if err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] Community not found in PostService.GetPostDetailByID: %v", err)
-		return nil, fmt.Errorf("failed to get post details")
-	}
-
-	// If private community, check if user has access
-	if community.IsPrivate {
-		if userID == nil {
-			// Not authenticated
-			logger.ErrorfWithCtx(ctx, "[Err] User not authenticated to view post from private community in PostService.GetPostDetailByID: postID=%d", postID)
-			return nil, fmt.Errorf("you do not have permission to view this post")
-		}
-		// Check if user is member of the private community
-		isSubscribed, err := s.subscriptionRepo.IsUserSubscribed(*userID, postExists.CommunityID)
-		if err != nil || !isSubscribed {
-			logger.ErrorfWithCtx(ctx, "[Err] User does not have permission to view post from private community in PostService.GetPostDetailByID: userID=%d, postID=%d", *userID, postID)
-			return nil, fmt.Errorf("you do not have permission to view this post")
-		}
+	if err := s.checkPostAccess(ctx, postExists, userID); err != nil {
+		return nil, err
 	}
 
 	// If we reach here, user has permission, get full post details
@@ -449,7 +743,19 @@ func (s *PostService) GetPostDetailByID(ctx context.Context, postID uint64, user
 		return nil, fmt.Errorf("failed to get post details")
 	}
 
-	return response.NewPostDetailResponse(post), nil
+	postResponse := response.NewPostDetailResponse(post)
+	if pollResponse := postResponse.PollData; pollResponse != nil && pollResponse.RankedChoice && !pollResponse.ResultsHidden {
+		optionIDs := make([]int, len(pollResponse.Options))
+		for i, option := range pollResponse.Options {
+			optionIDs[i] = option.ID
+		}
+		// The post is still shown without the runoff if the tally fails
+		if runoff, err := s.tallyRankedPoll(ctx, postID, optionIDs); err == nil {
+			pollResponse.Runoff = runoff
+		}
+	}
+
+	return postResponse, nil
 }
 
 func (s *PostService) VotePost(ctx context.Context, userID, postID uint64, vote bool) error {
@@ -460,6 +766,16 @@ func (s *PostService) VotePost(ctx context.Context, userID, postID uint64, vote
 		return fmt.Errorf("post not found")
 	}
 
+	if isUnpublishedPost(post) {
+		logger.ErrorfWithCtx(ctx, "[Err] Post is not published in PostService.VotePost: postID=%d", postID)
+		return fmt.Errorf("post not found")
+	}
+
+	if post.LockedAt != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Post is locked in PostService.VotePost: postID=%d", postID)
+		return fmt.Errorf("post is locked")
+	}
+
 	postVote := &model.PostVote{
 		UserID: userID,
 		PostID: postID,
@@ -556,6 +872,16 @@ func (s *PostService) VotePoll(ctx context.Context, userID, postID uint64, req *
 		return fmt.Errorf("post not found")
 	}
 
+	if isUnpublishedPost(post) {
+		logger.ErrorfWithCtx(ctx, "[Err] Post is not published in PostService.VotePoll: postID=%d", postID)
+		return fmt.Errorf("post not found")
+	}
+
+	if post.LockedAt != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Post is locked in PostService.VotePoll: postID=%d", postID)
+		return fmt.Errorf("post is locked")
+	}
+
 	if post.Type != constant.PostTypePoll {
 		return fmt.Errorf("post is not a poll")
 	}
@@ -576,89 +902,45 @@ func (s *PostService) VotePoll(ctx context.Context, userID, postID uint64, req *
 		return fmt.Errorf("poll has expired")
 	}
 
-	// Find the option
-	optionIndex := -1
-	for i, option := range pollData.Options {
-		if option.ID == req.OptionID {
-			optionIndex = i
-			break
+	var ballot []*model.PollVote
+	if pollData.RankedChoice {
+		if ballot, err = buildRankedBallot(&pollData, req.RankedOptionIDs); err != nil {
+			return err
 		}
-	}
-
-	if optionIndex == -1 {
+	} else if !hasPollOption(&pollData, req.OptionID) {
 		return fmt.Errorf("option not found")
 	}
 
-	// Check if user has already voted
-	hasVoted := false
-	var previousOptionIndex int
-	for i, option := range pollData.Options {
-		for _, voterID := range option.Voters {
-			if voterID == userID {
-				hasVoted = true
-				previousOptionIndex = i
-				break
-			}
-		}
-		if hasVoted {
-			break
-		}
+	poll, err := s.ensurePoll(ctx, postID, &pollData)
+	if err != nil {
+		return fmt.Errorf("failed to update poll")
 	}
-
-	if hasVoted {
-		// If not multiple choice and voting for same option, return error
-		if !pollData.MultipleChoice && previousOptionIndex == optionIndex {
-			return fmt.Errorf("already voted for this option")
-		}
-
-		// If not multiple choice and voting for different option, remove previous vote
-		if !pollData.MultipleChoice && previousOptionIndex != optionIndex {
-			// Remove from previous option
-			newVoters := []uint64{}
-			for _, voterID := range pollData.Options[previousOptionIndex].Voters {
-				if voterID != userID {
-					newVoters = append(newVoters, voterID)
-				}
-			}
-			pollData.Options[previousOptionIndex].Voters = newVoters
-			pollData.Options[previousOptionIndex].Votes = len(newVoters)
-		}
-
-		// If multiple choice, check if already voted for this option
-		if pollData.MultipleChoice {
-			for _, voterID := range pollData.Options[optionIndex].Voters {
-				if voterID == userID {
-					return fmt.Errorf("already voted for this option")
-				}
-			}
-		}
+	if isPollClosed(poll, &pollData) {
+		return fmt.Errorf("poll is closed")
 	}
 
-	// Add vote to the selected option
-	pollData.Options[optionIndex].Voters = append(pollData.Options[optionIndex].Voters, userID)
-	pollData.Options[optionIndex].Votes = len(pollData.Options[optionIndex].Voters)
-
-	// Recalculate total votes (count unique voters)
-	uniqueVoters := make(map[uint64]bool)
-	for _, option := range pollData.Options {
-		for _, voterID := range option.Voters {
-			uniqueVoters[voterID] = true
+	if pollData.RankedChoice {
+		if err := s.pollRepo.ReplaceRankedBallot(postID, userID, ballot); err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Error replacing ranked ballot in PostService.VotePoll: %v", err)
+			return fmt.Errorf("failed to update poll")
 		}
+		return nil
 	}
-	pollData.TotalVotes = len(uniqueVoters)
 
-	updatedPollData, err := json.Marshal(pollData)
-	if err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] Error marshalling poll data in PostService.VotePoll: %v", err)
-		return fmt.Errorf("failed to update poll data")
+	// Single choice polls move the user's previous vote in the same transaction
+	pollVote := &model.PollVote{
+		PostID:   postID,
+		OptionID: req.OptionID,
+		UserID:   userID,
 	}
-
-	rawMessage := json.RawMessage(updatedPollData)
-
-	if err := s.postRepo.UpdatePollData(postID, &rawMessage); err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] Error updating poll data in PostService.VotePoll: %v", err)
+	added, err := s.pollRepo.AddPollVote(pollVote, !pollData.MultipleChoice)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error adding poll vote in PostService.VotePoll: %v", err)
 		return fmt.Errorf("failed to update poll")
 	}
+	if !added {
+		return fmt.Errorf("already voted for this option")
+	}
 
 	return nil
 }
@@ -685,127 +967,602 @@ func (s *PostService) UnvotePoll(ctx context.Context, userID, postID uint64, req
 		return fmt.Errorf("invalid poll data")
 	}
 
-	// Find the option
-	optionIndex := -1
-	for i, option := range pollData.Options {
-		if option.ID == req.OptionID {
-			optionIndex = i
-			break
-		}
-	}
-
-	if optionIndex == -1 {
+	if !pollData.RankedChoice && !hasPollOption(&pollData, req.OptionID) {
 		return fmt.Errorf("option not found")
 	}
 
-	// Check if user has voted for this specific option
-	hasVoted := false
-	for _, voterID := range pollData.Options[optionIndex].Voters {
-		if voterID == userID {
-			hasVoted = true
-			break
-		}
+	poll, err := s.ensurePoll(ctx, postID, &pollData)
+	if err != nil {
+		return fmt.Errorf("failed to update poll")
 	}
-
-	if !hasVoted {
-		return fmt.Errorf("you have not voted for this option")
+	// Results are final once the poll is closed
+	if isPollClosed(poll, &pollData) {
+		return fmt.Errorf("poll is closed")
 	}
 
-	// Remove user's vote from this specific option
-	newVoters := []uint64{}
-	for _, voterID := range pollData.Options[optionIndex].Voters {
-		if voterID != userID {
-			newVoters = append(newVoters, voterID)
+	if pollData.RankedChoice {
+		removed, err := s.pollRepo.RemovePollBallot(postID, userID)
+		if err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Error removing ranked ballot in PostService.UnvotePoll: %v", err)
+			return fmt.Errorf("failed to update poll")
 		}
-	}
-	pollData.Options[optionIndex].Voters = newVoters
-	pollData.Options[optionIndex].Votes = len(newVoters)
-
-	// Recalculate total votes (count unique voters)
-	uniqueVoters := make(map[uint64]bool)
-	for _, option := range pollData.Options {
-		for _, voterID := range option.Voters {
-			uniqueVoters[voterID] = true
+		if !removed {
+			return fmt.Errorf("you have not voted in this poll")
 		}
+		return nil
 	}
-	pollData.TotalVotes = len(uniqueVoters)
 
-	updatedPollData, err := json.Marshal(pollData)
+	removed, err := s.pollRepo.RemovePollVote(postID, req.OptionID, userID)
 	if err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] Error marshalling poll data in PostService.UnvotePoll: %v", err)
-		return fmt.Errorf("failed to update poll data")
-	}
-
-	rawMessage := json.RawMessage(updatedPollData)
-
-	if err := s.postRepo.UpdatePollData(postID, &rawMessage); err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] Error updating poll data in PostService.UnvotePoll: %v", err)
+		logger.ErrorfWithCtx(ctx, "[Err] Error removing poll vote in PostService.UnvotePoll: %v", err)
 		return fmt.Errorf("failed to update poll")
 	}
+	if !removed {
+		return fmt.Errorf("you have not voted for this option")
+	}
 
 	return nil
 }
 
-func (s *PostService) GetPostsByUserID(ctx context.Context, userID uint64, sortBy string, page, limit int) ([]*response.PostListResponse, *response.Pagination, error) {
-	// Check if user exists
-	_, err := s.userRepo.GetUserByID(userID)
-	if err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] User not found in PostService.GetPostsByUserID: %v", err)
-		return nil, nil, fmt.Errorf("user not found")
+func hasPollOption(pollData *payload.PollData, optionID int) bool {
+	for _, option := range pollData.Options {
+		if option.ID == optionID {
+			return true
+		}
 	}
+	return false
+}
 
-	posts, total, err := s.postRepo.GetPostsByUserID(userID, sortBy, page, limit)
-	if err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] Error getting posts by user ID in PostService.GetPostsByUserID: %v", err)
-		return nil, nil, fmt.Errorf("failed to get posts")
+// buildRankedBallot turns the submitted ranking into votes, rank 1 being the most preferred
+func buildRankedBallot(pollData *payload.PollData, rankedOptionIDs []int) ([]*model.PollVote, error) {
+	if len(rankedOptionIDs) == 0 {
+		return nil, fmt.Errorf("ranked option ids are required")
 	}
 
-	postResponses := make([]*response.PostListResponse, len(posts))
-	for i, post := range posts {
-		postResponses[i] = response.NewPostListResponse(post)
+	ranked := make(map[int]bool, len(rankedOptionIDs))
+	ballot := make([]*model.PollVote, len(rankedOptionIDs))
+	for i, optionID := range rankedOptionIDs {
+		if !hasPollOption(pollData, optionID) {
+			return nil, fmt.Errorf("option not found")
+		}
+		if ranked[optionID] {
+			return nil, fmt.Errorf("each option can only be ranked once")
+		}
+		ranked[optionID] = true
+		ballot[i] = &model.PollVote{
+			OptionID: optionID,
+			Rank:     i + 1,
+		}
 	}
+	return ballot, nil
+}
 
-	pagination := &response.Pagination{
-		Total: total,
-		Page:  page,
-		Limit: limit,
+// isPollClosed is true once the poll expired or was closed by its author or a moderator.
+// poll is nil for polls that were never voted on.
+func isPollClosed(poll *model.Poll, pollData *payload.PollData) bool {
+	if pollData.ExpiresAt != nil && !time.Now().Before(*pollData.ExpiresAt) {
+		return true
 	}
-
-	return postResponses, pagination, nil
+	return poll != nil && poll.ClosedAt != nil
 }
 
-func (s *PostService) ReportPost(ctx context.Context, userID, postID uint64, req *request.ReportPostRequest) error {
-	// Check if post exists
-	_, err := s.postRepo.GetPostByID(postID)
+// ensurePoll returns the poll state, backfilling polls, poll_options and poll_votes for
+// polls created before votes were stored relationally from the voters still kept in
+// poll_data
+func (s *PostService) ensurePoll(ctx context.Context, postID uint64, pollData *payload.PollData) (*model.Poll, error) {
+	poll, err := s.pollRepo.GetPollByPostID(postID)
 	if err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] Post not found in PostService.ReportPost: %v", err)
-		return fmt.Errorf("post not found")
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting poll in PostService.ensurePoll: %v", err)
+		return nil, err
+	}
+	if poll != nil {
+		return poll, nil
 	}
 
-	// Check if user already reported this post
-	alreadyReported, err := s.postReportRepo.IsUserReportedPost(userID, postID)
-	if err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] Error checking if user reported post in PostService.ReportPost: %v", err)
-		return fmt.Errorf("failed to check report status")
+	now := time.Now()
+	poll = &model.Poll{
+		PostID:    postID,
+		ExpiresAt: pollData.ExpiresAt,
+		CreatedAt: now,
 	}
-	if alreadyReported {
-		return fmt.Errorf("you have already reported this post")
+	options := make([]*model.PollOption, len(pollData.Options))
+	votes := []*model.PollVote{}
+	for i, option := range pollData.Options {
+		options[i] = &model.PollOption{
+			PostID:    postID,
+			OptionID:  option.ID,
+			Text:      option.Text,
+			Status:    constant.POLL_OPTION_STATUS_APPROVED,
+			CreatedAt: now,
+		}
+		for _, voterID := range option.Voters {
+			votes = append(votes, &model.PollVote{
+				PostID:    postID,
+				OptionID:  option.ID,
+				UserID:    voterID,
+				CreatedAt: now,
+			})
+		}
 	}
 
-	// Create report
-	report := &model.PostReport{
-		PostID:     postID,
-		ReporterID: userID,
-		Reasons:    req.Reasons,
-		Note:       req.Note,
-		CreatedAt:  time.Now(),
+	if err := s.pollRepo.ImportPoll(poll, options, votes); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error importing poll in PostService.ensurePoll: %v", err)
+		return nil, err
 	}
+	return poll, nil
+}
 
-	if err := s.postReportRepo.CreatePostReport(report); err != nil {
-		logger.ErrorfWithCtx(ctx, "[Err] Error creating post report in PostService.ReportPost: %v", err)
-		return fmt.Errorf("failed to report post")
+// syncPoll keeps polls and poll_options in line with a published poll's definition. A
+// failure is only logged: the poll is backfilled on the next vote.
+func (s *PostService) syncPoll(ctx context.Context, post *model.Post) {
+	if post.Type != constant.PostTypePoll || post.PollData == nil {
+		return
 	}
 
+	var pollData payload.PollData
+	if err := json.Unmarshal(*post.PollData, &pollData); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error unmarshalling poll data in PostService.syncPoll: %v", err)
+		return
+	}
+
+	options := make([]*model.PollOption, len(pollData.Options))
+	for i, option := range pollData.Options {
+		options[i] = &model.PollOption{
+			OptionID: option.ID,
+			Text:     option.Text,
+		}
+	}
+
+	poll := &model.Poll{
+		PostID:    post.ID,
+		ExpiresAt: pollData.ExpiresAt,
+	}
+	if err := s.pollRepo.SyncPoll(poll, options); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error syncing poll in PostService.syncPoll: postID=%d, err=%v", post.ID, err)
+	}
+}
+
+// sanitizePollData drops any vote data sent by the client and gives options without
+// an id the next free one, starting at minNewID. Unparseable data is returned as is
+// for validation to reject.
+func sanitizePollData(rawPollData *json.RawMessage, minNewID int) *json.RawMessage {
+	if rawPollData == nil {
+		return nil
+	}
+
+	var pollData payload.PollData
+	if err := json.Unmarshal(*rawPollData, &pollData); err != nil {
+		return rawPollData
+	}
+
+	maxID := minNewID - 1
+	for _, option := range pollData.Options {
+		if option.ID > maxID {
+			maxID = option.ID
+		}
+	}
+	for i := range pollData.Options {
+		if pollData.Options[i].ID <= 0 {
+			maxID++
+			pollData.Options[i].ID = maxID
+		}
+		pollData.Options[i].Votes = 0
+		pollData.Options[i].Voters = nil
+	}
+	pollData.TotalVotes = 0
+
+	sanitized, err := json.Marshal(pollData)
+	if err != nil {
+		return rawPollData
+	}
+	rawMessage := json.RawMessage(sanitized)
+	return &rawMessage
+}
+
+// tallyRankedPoll runs the instant-runoff count over all ballots of a ranked choice poll
+func (s *PostService) tallyRankedPoll(ctx context.Context, postID uint64, optionIDs []int) (*util.RunoffResult, error) {
+	votes, err := s.pollRepo.GetPollVotes(postID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting poll votes in PostService.tallyRankedPoll: %v", err)
+		return nil, err
+	}
+
+	// Votes come ordered by user and rank
+	ballots := [][]int{}
+	var currentUserID uint64
+	for i, vote := range votes {
+		if i == 0 || vote.UserID != currentUserID {
+			ballots = append(ballots, []int{})
+			currentUserID = vote.UserID
+		}
+		ballots[len(ballots)-1] = append(ballots[len(ballots)-1], vote.OptionID)
+	}
+
+	result := util.InstantRunoff(optionIDs, ballots)
+	return &result, nil
+}
+
+// getAuthorPoll loads a published poll post of the given author
+func (s *PostService) getAuthorPoll(ctx context.Context, userID, postID uint64, method string) (*model.Post, *payload.PollData, error) {
+	post, err := s.postRepo.GetPostByID(postID)
+	if err != nil || isUnpublishedPost(post) {
+		logger.ErrorfWithCtx(ctx, "[Err] Post not found in PostService.%s: postID=%d", method, postID)
+		return nil, nil, fmt.Errorf("post not found")
+	}
+
+	if post.AuthorID != userID {
+		logger.ErrorfWithCtx(ctx, "[Err] User is not the poll author in PostService.%s: userID=%d, postID=%d", method, userID, postID)
+		return nil, nil, fmt.Errorf("permission denied")
+	}
+
+	pollData, err := parsePollPost(ctx, post, method)
+	if err != nil {
+		return nil, nil, err
+	}
+	return post, pollData, nil
+}
+
+func parsePollPost(ctx context.Context, post *model.Post, method string) (*payload.PollData, error) {
+	if post.Type != constant.PostTypePoll {
+		return nil, fmt.Errorf("post is not a poll")
+	}
+	if post.PollData == nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Poll data is nil in PostService.%s", method)
+		return nil, fmt.Errorf("poll data not found")
+	}
+
+	var pollData payload.PollData
+	if err := json.Unmarshal(*post.PollData, &pollData); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error unmarshalling poll data in PostService.%s: %v", method, err)
+		return nil, fmt.Errorf("invalid poll data")
+	}
+	return &pollData, nil
+}
+
+func (s *PostService) ClosePoll(ctx context.Context, userID, postID uint64) error {
+	post, _, err := s.getAuthorPoll(ctx, userID, postID, "ClosePoll")
+	if err != nil {
+		return err
+	}
+	return s.closePoll(ctx, userID, post)
+}
+
+// closePoll stops voting right away. Voters are notified when the finalize job picks
+// the poll up.
+func (s *PostService) closePoll(ctx context.Context, userID uint64, post *model.Post) error {
+	pollData, err := parsePollPost(ctx, post, "closePoll")
+	if err != nil {
+		return err
+	}
+
+	poll, err := s.ensurePoll(ctx, post.ID, pollData)
+	if err != nil {
+		return fmt.Errorf("failed to close poll")
+	}
+	if isPollClosed(poll, pollData) {
+		return fmt.Errorf("poll is already closed")
+	}
+
+	closed, err := s.pollRepo.ClosePoll(post.ID, userID, time.Now())
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error closing poll in PostService.closePoll: %v", err)
+		return fmt.Errorf("failed to close poll")
+	}
+	if !closed {
+		return fmt.Errorf("poll is already closed")
+	}
+
+	return nil
+}
+
+// FinalizePolls notifies the voters of polls that were closed or expired since the last run
+func (s *PostService) FinalizePolls(ctx context.Context) error {
+	polls, err := s.pollRepo.GetPollsToFinalize(time.Now(), constant.SCHEDULER_FINALIZE_POLLS_BATCH_SIZE)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting polls to finalize in PostService.FinalizePolls: %v", err)
+		return fmt.Errorf("failed to get polls to finalize")
+	}
+
+	for _, poll := range polls {
+		finalized, err := s.pollRepo.FinalizePoll(poll.PostID, time.Now())
+		if err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Error finalizing poll %d in PostService.FinalizePolls: %v", poll.PostID, err)
+			continue
+		}
+		// Already picked up by another run, or the post is gone
+		if !finalized || poll.Post == nil {
+			continue
+		}
+
+		s.notifyPollVoters(ctx, poll.Post)
+	}
+
+	return nil
+}
+
+func (s *PostService) notifyPollVoters(ctx context.Context, post *model.Post) {
+	voterIDs, err := s.pollRepo.GetPollVoterIDs(post.ID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting poll voters in PostService.notifyPollVoters: %v", err)
+		return
+	}
+	if len(voterIDs) == 0 {
+		return
+	}
+
+	notifPayload := payload.PollClosedPayload{
+		PostID:        post.ID,
+		PostTitle:     post.Title,
+		WinningOption: s.getPollWinner(ctx, post),
+	}
+	for _, voterID := range voterIDs {
+		s.notificationService.CreateNotification(ctx, voterID, constant.NOTIFICATION_ACTION_POLL_CLOSED, notifPayload)
+	}
+}
+
+// getPollWinner returns the text of the winning option, empty when the poll is tied
+func (s *PostService) getPollWinner(ctx context.Context, post *model.Post) string {
+	pollData, err := parsePollPost(ctx, post, "getPollWinner")
+	if err != nil {
+		return ""
+	}
+
+	optionTexts := make(map[int]string, len(pollData.Options))
+	optionIDs := make([]int, len(pollData.Options))
+	for i, option := range pollData.Options {
+		optionTexts[option.ID] = option.Text
+		optionIDs[i] = option.ID
+	}
+
+	if pollData.RankedChoice {
+		result, err := s.tallyRankedPoll(ctx, post.ID, optionIDs)
+		if err != nil || result.WinnerID == nil {
+			return ""
+		}
+		return optionTexts[*result.WinnerID]
+	}
+
+	options, err := s.pollRepo.GetPollOptionsByPostID(post.ID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting poll options in PostService.getPollWinner: %v", err)
+		return ""
+	}
+
+	winner := ""
+	var mostVotes int64
+	for _, option := range options {
+		if _, ok := optionTexts[option.OptionID]; !ok || option.VoteCount == 0 {
+			continue
+		}
+		if option.VoteCount > mostVotes {
+			winner, mostVotes = optionTexts[option.OptionID], option.VoteCount
+		} else if option.VoteCount == mostVotes {
+			winner = ""
+		}
+	}
+	return winner
+}
+
+func (s *PostService) SuggestPollOption(ctx context.Context, userID, postID uint64, req *request.SuggestPollOptionRequest) error {
+	post, err := s.postRepo.GetPostByID(postID)
+	if err != nil || isUnpublishedPost(post) {
+		logger.ErrorfWithCtx(ctx, "[Err] Post not found in PostService.SuggestPollOption: postID=%d", postID)
+		return fmt.Errorf("post not found")
+	}
+
+	if post.LockedAt != nil {
+		return fmt.Errorf("post is locked")
+	}
+
+	if err := s.checkCommunityBan(ctx, userID, post.CommunityID); err != nil {
+		return err
+	}
+
+	pollData, err := parsePollPost(ctx, post, "SuggestPollOption")
+	if err != nil {
+		return err
+	}
+	if !pollData.AllowWriteIns {
+		return fmt.Errorf("poll does not allow write-in options")
+	}
+	if len(pollData.Options) >= constant.MAX_POLL_OPTIONS {
+		return fmt.Errorf("poll has reached the maximum number of options")
+	}
+
+	poll, err := s.ensurePoll(ctx, postID, pollData)
+	if err != nil {
+		return fmt.Errorf("failed to suggest poll option")
+	}
+	if isPollClosed(poll, pollData) {
+		return fmt.Errorf("poll is closed")
+	}
+
+	text := strings.TrimSpace(req.Text)
+	if text == "" {
+		return fmt.Errorf("poll option text is required")
+	}
+
+	// Compare against approved and pending options alike
+	options, err := s.pollRepo.GetPollOptionsByPostID(postID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting poll options in PostService.SuggestPollOption: %v", err)
+		return fmt.Errorf("failed to suggest poll option")
+	}
+	for _, option := range options {
+		if strings.EqualFold(option.Text, text) {
+			return fmt.Errorf("poll option already exists")
+		}
+	}
+
+	pendingCount, err := s.pollRepo.CountPendingWriteIns(postID, userID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error counting pending write-ins in PostService.SuggestPollOption: %v", err)
+		return fmt.Errorf("failed to suggest poll option")
+	}
+	if pendingCount >= constant.MAX_PENDING_WRITE_INS_PER_USER {
+		return fmt.Errorf("too many pending write-in options")
+	}
+
+	option := &model.PollOption{
+		PostID:      postID,
+		Text:        text,
+		SuggestedBy: &userID,
+	}
+	if err := s.pollRepo.CreateWriteInOption(option); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error creating write-in option in PostService.SuggestPollOption: %v", err)
+		return fmt.Errorf("failed to suggest poll option")
+	}
+
+	return nil
+}
+
+func (s *PostService) GetPendingPollOptions(ctx context.Context, userID, postID uint64) ([]*response.PollWriteInResponse, error) {
+	if _, _, err := s.getAuthorPoll(ctx, userID, postID, "GetPendingPollOptions"); err != nil {
+		return nil, err
+	}
+
+	options, err := s.pollRepo.GetPendingWriteIns(postID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting pending write-ins in PostService.GetPendingPollOptions: %v", err)
+		return nil, fmt.Errorf("failed to get pending poll options")
+	}
+
+	optionResponses := make([]*response.PollWriteInResponse, len(options))
+	for i, option := range options {
+		optionResponses[i] = response.NewPollWriteInResponse(option)
+	}
+	return optionResponses, nil
+}
+
+func (s *PostService) ApprovePollOption(ctx context.Context, userID, postID uint64, optionID int) error {
+	post, pollData, err := s.getAuthorPoll(ctx, userID, postID, "ApprovePollOption")
+	if err != nil {
+		return err
+	}
+
+	poll, err := s.pollRepo.GetPollByPostID(post.ID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting poll in PostService.ApprovePollOption: %v", err)
+		return fmt.Errorf("failed to approve poll option")
+	}
+	if isPollClosed(poll, pollData) {
+		return fmt.Errorf("poll is closed")
+	}
+	if len(pollData.Options) >= constant.MAX_POLL_OPTIONS {
+		return fmt.Errorf("poll has reached the maximum number of options")
+	}
+
+	approved, err := s.pollRepo.ApproveWriteInOption(postID, optionID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error approving write-in option in PostService.ApprovePollOption: %v", err)
+		return fmt.Errorf("failed to approve poll option")
+	}
+	if !approved {
+		return fmt.Errorf("write-in option not found")
+	}
+
+	return nil
+}
+
+func (s *PostService) RejectPollOption(ctx context.Context, userID, postID uint64, optionID int) error {
+	if _, _, err := s.getAuthorPoll(ctx, userID, postID, "RejectPollOption"); err != nil {
+		return err
+	}
+
+	deleted, err := s.pollRepo.DeletePendingWriteIn(postID, optionID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error deleting write-in option in PostService.RejectPollOption: %v", err)
+		return fmt.Errorf("failed to reject poll option")
+	}
+	if !deleted {
+		return fmt.Errorf("write-in option not found")
+	}
+
+	return nil
+}
+
+func (s *PostService) GetPostsByUserID(ctx context.Context, userID uint64, sortBy string, page, limit int) ([]*response.PostListResponse, *response.Pagination, error) {
+	// Check if user exists
+	_, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] User not found in PostService.GetPostsByUserID: %v", err)
+		return nil, nil, fmt.Errorf("user not found")
+	}
+
+	posts, total, err := s.postRepo.GetPostsByUserID(userID, sortBy, page, limit)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting posts by user ID in PostService.GetPostsByUserID: %v", err)
+		return nil, nil, fmt.Errorf("failed to get posts")
+	}
+
+	postResponses := make([]*response.PostListResponse, len(posts))
+	for i, post := range posts {
+		postResponses[i] = response.NewPostListResponse(post)
+	}
+
+	pagination := &response.Pagination{
+		Total: total,
+		Page:  page,
+		Limit: limit,
+	}
+
+	return postResponses, pagination, nil
+}
+
+func (s *PostService) ReportPost(ctx context.Context, userID, postID uint64, req *request.ReportPostRequest) error {
+	if len(req.Reasons) == 0 && len(req.RuleIDs) == 0 {
+		return fmt.Errorf("report reason is required")
+	}
+
+	// Check if post exists
+	post, err := s.postRepo.GetPostByID(postID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Post not found in PostService.ReportPost: %v", err)
+		return fmt.Errorf("post not found")
+	}
+
+	if isUnpublishedPost(post) {
+		logger.ErrorfWithCtx(ctx, "[Err] Post is not published in PostService.ReportPost: postID=%d", postID)
+		return fmt.Errorf("post not found")
+	}
+
+	// Check if user already reported this post
+	alreadyReported, err := s.postReportRepo.IsUserReportedPost(userID, postID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error checking if user reported post in PostService.ReportPost: %v", err)
+		return fmt.Errorf("failed to check report status")
+	}
+	if alreadyReported {
+		return fmt.Errorf("you have already reported this post")
+	}
+
+	if err := checkReporterStanding(ctx, s.reportRepo, userID); err != nil {
+		return err
+	}
+
+	ruleIDs, err := getReportRuleIDs(ctx, s.communityRuleRepo, post.CommunityID, constant.MODERATION_TARGET_POST, req.RuleIDs)
+	if err != nil {
+		return err
+	}
+
+	// Create report
+	report := &model.PostReport{
+		PostID:     postID,
+		ReporterID: userID,
+		Reasons:    req.Reasons,
+		RuleIDs:    ruleIDs,
+		Note:       req.Note,
+		CreatedAt:  time.Now(),
+		Status:     constant.REPORT_STATUS_OPEN,
+	}
+
+	if err := s.postReportRepo.CreatePostReport(report); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error creating post report in PostService.ReportPost: %v", err)
+		return fmt.Errorf("failed to report post")
+	}
+
+	s.runAutoMod(ctx, constant.AUTOMOD_TRIGGER_POST_REPORTED, post)
+
 	return nil
 }
 
@@ -826,3 +1583,402 @@ func (s *PostService) GetAllTags(ctx context.Context, search *string) ([]*respon
 
 	return tagResponses, nil
 }
+
+func (s *PostService) GetPostRevisions(ctx context.Context, postID uint64, userID *uint64) ([]*response.PostRevisionResponse, error) {
+	post, err := s.postRepo.GetPostByID(postID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Post not found in PostService.GetPostRevisions: %v", err)
+		return nil, fmt.Errorf("post not found")
+	}
+
+	if err := s.checkPostAccess(ctx, post, userID); err != nil {
+		return nil, err
+	}
+	// The history of pending, rejected or removed posts would show what moderators took down
+	if !canViewHiddenRevisions(s.communityModeratorRepo, post, post.AuthorID, userID) {
+		logger.ErrorfWithCtx(ctx, "[Err] Post is not approved in PostService.GetPostRevisions: postID=%d, status=%s", postID, post.Status)
+		return nil, fmt.Errorf("post not found")
+	}
+
+	revisions, err := s.postRevisionRepo.GetPostRevisionsByPostID(postID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting post revisions in PostService.GetPostRevisions: %v", err)
+		return nil, fmt.Errorf("failed to get revisions")
+	}
+
+	return response.NewPostRevisionResponses(revisions, post), nil
+}
+
+// checkPostAccess applies the visibility rules of a post detail to the given viewer
+func (s *PostService) checkPostAccess(ctx context.Context, post *model.Post, userID *uint64) error {
+	// Drafts and scheduled posts are only visible to their author
+	if isUnpublishedPost(post) && (userID == nil || *userID != post.AuthorID) {
+		logger.ErrorfWithCtx(ctx, "[Err] Post is not published in PostService.checkPostAccess: postID=%d", post.ID)
+		return fmt.Errorf("post not found")
+	}
+
+	// Get community to check if it's private
+	community, err := s.communityRepo.GetCommunityByID(post.CommunityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Community not found in PostService.checkPostAccess: %v", err)
+		return fmt.Errorf("failed to get post details")
+	}
+
+	// If private community, check if user has access
+	if community.IsPrivate {
+		if userID == nil {
+			// Not authenticated
+			logger.ErrorfWithCtx(ctx, "[Err] User not authenticated to view post from private community in PostService.checkPostAccess: postID=%d", post.ID)
+			return fmt.Errorf("you do not have permission to view this post")
+		}
+		// Check if user is member of the private community
+		isSubscribed, err := s.subscriptionRepo.IsUserSubscribed(*userID, post.CommunityID)
+		if err != nil || !isSubscribed {
+			logger.ErrorfWithCtx(ctx, "[Err] User does not have permission to view post from private community in PostService.checkPostAccess: userID=%d, postID=%d", *userID, post.ID)
+			return fmt.Errorf("you do not have permission to view this post")
+		}
+	}
+
+	return nil
+}
+
+// checkCommunityBan rejects users with an active ban in the community
+func (s *PostService) checkCommunityBan(ctx context.Context, userID, communityID uint64) error {
+	restriction, err := s.userRestrictionRepo.GetActiveRestrictionByUserAndCommunity(userID, communityID)
+	if err == nil && restriction != nil &&
+		(restriction.RestrictionType == constant.RESTRICTION_TEMPORARY_BAN || restriction.RestrictionType == constant.RESTRICTION_PERMANENT_BAN) {
+		logger.ErrorfWithCtx(ctx, "[Err] User is banned from community in PostService.checkCommunityBan: userID=%d, communityID=%d", userID, communityID)
+		return fmt.Errorf("you are banned from this community")
+	}
+	return nil
+}
+
+// NotifyMentions notifies the users mentioned in a post that was approved by a moderator
+func (s *PostService) NotifyMentions(ctx context.Context, post *model.Post) {
+	if s.mentionService == nil {
+		return
+	}
+	s.mentionService.NotifyPostMentions(ctx, post)
+}
+
+// canViewHiddenRevisions tells whether the viewer may read the edit history of content
+// of the post. Approved posts have a public history, otherwise only the content's author
+// and the moderators who manage posts see it.
+func canViewHiddenRevisions(communityModeratorRepo repository.CommunityModeratorRepository, post *model.Post, authorID uint64, userID *uint64) bool {
+	if post.Status == constant.POST_STATUS_APPROVED {
+		return true
+	}
+	if userID == nil {
+		return false
+	}
+	if *userID == authorID {
+		return true
+	}
+	access, err := getCommunityAccess(communityModeratorRepo, post.CommunityID, *userID)
+	return err == nil && access.can(constant.COMMUNITY_PERMISSION_MANAGE_POSTS)
+}
+
+func isUnpublishedPost(post *model.Post) bool {
+	return post.Status == constant.POST_STATUS_DRAFT || post.Status == constant.POST_STATUS_SCHEDULED
+}
+
+func (s *PostService) GetUserDrafts(ctx context.Context, userID uint64, page, limit int) ([]*response.DraftPostResponse, *response.Pagination, error) {
+	posts, total, err := s.postRepo.GetDraftPostsByUserID(userID, page, limit)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting draft posts in PostService.GetUserDrafts: %v", err)
+		return nil, nil, fmt.Errorf("failed to get drafts")
+	}
+
+	draftResponses := make([]*response.DraftPostResponse, len(posts))
+	for i, post := range posts {
+		draftResponses[i] = response.NewDraftPostResponse(post)
+	}
+
+	pagination := &response.Pagination{
+		Total: total,
+		Page:  page,
+		Limit: limit,
+	}
+	if int64(page*limit) < total {
+		pagination.NextURL = fmt.Sprintf("/api/v1/users/me/drafts?page=%d&limit=%d", page+1, limit)
+	}
+
+	return draftResponses, pagination, nil
+}
+
+func (s *PostService) SaveDraft(ctx context.Context, userID, postID uint64, req *request.SaveDraftRequest) error {
+	post, err := s.postRepo.GetPostByID(postID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Post not found in PostService.SaveDraft: %v", err)
+		return fmt.Errorf("post not found")
+	}
+
+	if post.AuthorID != userID {
+		logger.ErrorfWithCtx(ctx, "[Err] User does not have permission to update draft in PostService.SaveDraft: userID=%d, postID=%d", userID, postID)
+		return fmt.Errorf("permission denied")
+	}
+
+	if !isUnpublishedPost(post) {
+		logger.ErrorfWithCtx(ctx, "[Err] Post is already published in PostService.SaveDraft: postID=%d", postID)
+		return fmt.Errorf("post is not a draft")
+	}
+
+	if req.ClearPublishAt && req.PublishAt != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Both publishAt and clearPublishAt given in PostService.SaveDraft: postID=%d", postID)
+		return fmt.Errorf("cannot set and clear publishAt together")
+	}
+
+	req.PollData = sanitizePollData(req.PollData, 1)
+	if err := s.validateDraftContent(ctx, post.Type, req.URL, req.MediaURLs, req.PollData); err != nil {
+		return err
+	}
+	if req.Content != nil {
+		content := s.contentSanitizer.SanitizeContent(*req.Content, req.ContentFormat)
+		req.Content = &content
+	}
+	if err := s.postRepo.UpdateDraftPost(postID, req); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error updating draft in PostService.SaveDraft: %v", err)
+		return fmt.Errorf("failed to save draft")
+	}
+
+	return nil
+}
+
+// PublishDraft publishes a draft now, or schedules it when it has a future publishAt
+func (s *PostService) PublishDraft(ctx context.Context, userID, postID uint64) error {
+	post, err := s.postRepo.GetPostByID(postID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Post not found in PostService.PublishDraft: %v", err)
+		return fmt.Errorf("post not found")
+	}
+
+	if post.AuthorID != userID {
+		logger.ErrorfWithCtx(ctx, "[Err] User does not have permission to publish draft in PostService.PublishDraft: userID=%d, postID=%d", userID, postID)
+		return fmt.Errorf("permission denied")
+	}
+
+	if !isUnpublishedPost(post) {
+		logger.ErrorfWithCtx(ctx, "[Err] Post is already published in PostService.PublishDraft: postID=%d", postID)
+		return fmt.Errorf("post is not a draft")
+	}
+
+	if post.Status == constant.POST_STATUS_DRAFT && post.PublishAt != nil && post.PublishAt.After(time.Now()) {
+		if err := s.validatePostContent(ctx, post.Type, post.URL, post.MediaURLs, post.PollData); err != nil {
+			return err
+		}
+		if err := s.postRepo.UpdatePostStatus(postID, constant.POST_STATUS_SCHEDULED); err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Error scheduling post in PostService.PublishDraft: %v", err)
+			return fmt.Errorf("failed to publish post")
+		}
+		return nil
+	}
+
+	return s.publishPost(ctx, post, constant.UNPUBLISHED_POST_STATUSES)
+}
+
+// PublishScheduledPosts publishes every scheduled post whose publishAt has passed.
+// Posts that can no longer be published are moved back to draft for the author to fix.
+func (s *PostService) PublishScheduledPosts(ctx context.Context) error {
+	posts, err := s.postRepo.GetDueScheduledPosts(time.Now(), constant.SCHEDULER_PUBLISH_POSTS_BATCH_SIZE)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting due scheduled posts in PostService.PublishScheduledPosts: %v", err)
+		return fmt.Errorf("failed to get scheduled posts")
+	}
+
+	for _, post := range posts {
+		if err := s.publishPost(ctx, post, []string{constant.POST_STATUS_SCHEDULED}); err != nil {
+			// Already picked up by another run
+			if err.Error() == "post is not a draft" {
+				continue
+			}
+			logger.ErrorfWithCtx(ctx, "[Err] Error publishing scheduled post %d in PostService.PublishScheduledPosts: %v", post.ID, err)
+			if err := s.postRepo.UpdatePostStatus(post.ID, constant.POST_STATUS_DRAFT); err != nil {
+				logger.ErrorfWithCtx(ctx, "[Err] Error moving scheduled post %d back to draft in PostService.PublishScheduledPosts: %v", post.ID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// publishPost applies the community rules as of now and hands the post to moderation.
+// fromStatuses guards against publishing the same post twice.
+func (s *PostService) publishPost(ctx context.Context, post *model.Post, fromStatuses []string) error {
+	community, err := s.communityRepo.GetCommunityByID(post.CommunityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Community not found in PostService.publishPost: %v", err)
+		return fmt.Errorf("community not found")
+	}
+
+	if err := s.checkCommunityBan(ctx, post.AuthorID, post.CommunityID); err != nil {
+		return err
+	}
+
+	if err := s.validatePostContent(ctx, post.Type, post.URL, post.MediaURLs, post.PollData); err != nil {
+		return err
+	}
+
+	postStatus := constant.POST_STATUS_PENDING
+	if !community.RequiresPostApproval {
+		postStatus = constant.POST_STATUS_APPROVED
+	}
+
+	duplicateCheck, err := s.checkDuplicate(ctx, post)
+	if err != nil {
+		return err
+	}
+	if duplicateCheck != nil && duplicateCheck.Action == constant.DUPLICATE_ACTION_HOLD {
+		postStatus = constant.POST_STATUS_PENDING
+	}
+
+	published, err := s.postRepo.PublishPost(post.ID, fromStatuses, postStatus, time.Now())
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error publishing post in PostService.publishPost: %v", err)
+		return fmt.Errorf("failed to publish post")
+	}
+	if !published {
+		return fmt.Errorf("post is not a draft")
+	}
+	post.Status = postStatus
+	s.saveFingerprint(ctx, post, duplicateCheck)
+
+	s.syncPoll(ctx, post)
+	s.storeMentions(ctx, post)
+	if s.runAutoMod(ctx, constant.AUTOMOD_TRIGGER_POST_CREATED, post) {
+		return nil
+	}
+	s.moderatePostAsync(ctx, post.AuthorID, post)
+
+	return nil
+}
+
+func (s *PostService) CrosspostPost(ctx context.Context, userID, postID uint64, req *request.CrosspostRequest) error {
+	source, err := s.postRepo.GetPostByID(postID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Post not found in PostService.CrosspostPost: %v", err)
+		return fmt.Errorf("post not found")
+	}
+
+	if source.Status != constant.POST_STATUS_APPROVED || isUnpublishedPost(source) {
+		logger.ErrorfWithCtx(ctx, "[Err] Post is not approved in PostService.CrosspostPost: postID=%d, status=%s", postID, source.Status)
+		return fmt.Errorf("post not found")
+	}
+
+	// Crossposting a crosspost links back to the original post, which must still be visible
+	if source.CrosspostParentID != nil {
+		source, err = s.postRepo.GetPostByID(*source.CrosspostParentID)
+		if err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Original post not found in PostService.CrosspostPost: %v", err)
+			return fmt.Errorf("post not found")
+		}
+		if source.Status != constant.POST_STATUS_APPROVED || isUnpublishedPost(source) {
+			logger.ErrorfWithCtx(ctx, "[Err] Original post is not approved in PostService.CrosspostPost: postID=%d, status=%s", source.ID, source.Status)
+			return fmt.Errorf("post not found")
+		}
+	}
+
+	if source.Type == constant.PostTypePoll {
+		return fmt.Errorf("poll posts cannot be crossposted")
+	}
+
+	sourceCommunity, err := s.communityRepo.GetCommunityByID(source.CommunityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Source community not found in PostService.CrosspostPost: %v", err)
+		return fmt.Errorf("post not found")
+	}
+	if sourceCommunity.IsPrivate {
+		logger.ErrorfWithCtx(ctx, "[Err] Cannot crosspost from private community in PostService.CrosspostPost: postID=%d", source.ID)
+		return fmt.Errorf("posts from private communities cannot be crossposted")
+	}
+
+	if req.CommunityID == source.CommunityID {
+		return fmt.Errorf("post already belongs to this community")
+	}
+
+	targetCommunity, err := s.communityRepo.GetCommunityByID(req.CommunityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Community not found in PostService.CrosspostPost: %v", err)
+		return fmt.Errorf("community not found")
+	}
+
+	isSubscribed, err := s.subscriptionRepo.IsUserSubscribed(userID, req.CommunityID)
+	if err != nil || !isSubscribed {
+		logger.ErrorfWithCtx(ctx, "[Err] User is not a member of target community in PostService.CrosspostPost: userID=%d, communityID=%d", userID, req.CommunityID)
+		return fmt.Errorf("you must join the community to crosspost")
+	}
+
+	if err := s.checkCommunityBan(ctx, userID, req.CommunityID); err != nil {
+		return err
+	}
+
+	exists, err := s.postRepo.IsCrosspostedToCommunity(source.ID, req.CommunityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error checking existing crosspost in PostService.CrosspostPost: %v", err)
+		return fmt.Errorf("failed to crosspost")
+	}
+	if exists {
+		return fmt.Errorf("post is already crossposted to this community")
+	}
+
+	title := source.Title
+	if req.Title != nil && strings.TrimSpace(*req.Title) != "" {
+		title = *req.Title
+	}
+
+	postStatus := constant.POST_STATUS_PENDING
+	if !targetCommunity.RequiresPostApproval {
+		postStatus = constant.POST_STATUS_APPROVED
+	}
+
+	crosspost := &model.Post{
+		CommunityID:       req.CommunityID,
+		AuthorID:          userID,
+		Title:             title,
+		Type:              source.Type,
+		Content:           source.Content,
+		ContentText:       source.ContentText,
+		URL:               source.URL,
+		MediaURLs:         source.MediaURLs,
+		Tags:              source.Tags,
+		Status:            postStatus,
+		CrosspostParentID: &source.ID,
+	}
+
+	if err := s.postRepo.CreatePost(crosspost); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error creating crosspost in PostService.CrosspostPost: %v", err)
+		return fmt.Errorf("failed to crosspost")
+	}
+
+	s.storeMentions(ctx, crosspost)
+	if s.runAutoMod(ctx, constant.AUTOMOD_TRIGGER_POST_CREATED, crosspost) {
+		return nil
+	}
+	s.moderatePostAsync(ctx, userID, crosspost)
+
+	return nil
+}
+
+func (s *PostService) GetCrossposts(ctx context.Context, postID uint64, userID *uint64) ([]*response.PostListResponse, error) {
+	post, err := s.postRepo.GetPostByID(postID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Post not found in PostService.GetCrossposts: %v", err)
+		return nil, fmt.Errorf("post not found")
+	}
+
+	if err := s.checkPostAccess(ctx, post, userID); err != nil {
+		return nil, err
+	}
+
+	crossposts, err := s.postRepo.GetCrosspostsByParentID(postID, userID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting crossposts in PostService.GetCrossposts: %v", err)
+		return nil, fmt.Errorf("failed to get crossposts")
+	}
+
+	postResponses := make([]*response.PostListResponse, len(crossposts))
+	for i, crosspost := range crossposts {
+		postResponses[i] = response.NewPostListResponse(crosspost)
+	}
+
+	return postResponses, nil
+}
@@ -2,8 +2,11 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"social-platform-backend/internal/domain/model"
 	"social-platform-backend/internal/interface/dto/request"
@@ -20,7 +23,15 @@ func TestPostService_CreatePost_TextSuccess(t *testing.T) {
 	postService := NewPostService(
 		mockPostRepo,
 		mockCommunityRepo,
-		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	userID := uint64(123)
@@ -53,7 +64,15 @@ func TestPostService_CreatePost_CommunityNotFound(t *testing.T) {
 	postService := NewPostService(
 		nil,
 		mockCommunityRepo,
-		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	req := &request.CreatePostRequest{
@@ -77,7 +96,15 @@ func TestPostService_CreatePost_LinkWithoutURL(t *testing.T) {
 	postService := NewPostService(
 		nil,
 		mockCommunityRepo,
-		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	req := &request.CreatePostRequest{
@@ -103,7 +130,15 @@ func TestPostService_CreatePost_MediaWithoutURLs(t *testing.T) {
 	postService := NewPostService(
 		nil,
 		mockCommunityRepo,
-		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	req := &request.CreatePostRequest{
@@ -129,7 +164,15 @@ func TestPostService_CreatePost_InvalidType(t *testing.T) {
 	postService := NewPostService(
 		nil,
 		mockCommunityRepo,
-		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	req := &request.CreatePostRequest{
@@ -150,10 +193,21 @@ func TestPostService_CreatePost_InvalidType(t *testing.T) {
 
 func TestPostService_UpdatePost_Success(t *testing.T) {
 	mockPostRepo := new(MockPostRepository)
+	mockRevisionRepo := new(MockPostRevisionRepository)
 
 	postService := NewPostService(
 		mockPostRepo,
-		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		mockRevisionRepo,
+		nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	userID := uint64(123)
@@ -170,7 +224,9 @@ func TestPostService_UpdatePost_Success(t *testing.T) {
 	}
 
 	mockPostRepo.On("GetPostByID", postID).Return(post, nil)
-	mockPostRepo.On("UpdatePostText", postID, updateReq).Return(nil)
+	mockPostRepo.On("UpdatePostText", postID, updateReq, mock.MatchedBy(func(revision *model.PostRevision) bool {
+		return revision.PostID == postID && revision.EditorID == userID
+	})).Return(nil)
 
 	err := postService.UpdatePost(context.Background(), userID, postID, constant.PostTypeText, updateReq)
 
@@ -183,7 +239,15 @@ func TestPostService_UpdatePost_NotAuthor(t *testing.T) {
 
 	postService := NewPostService(
 		mockPostRepo,
-		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	userID := uint64(123)
@@ -212,7 +276,15 @@ func TestPostService_UpdatePost_TypeMismatch(t *testing.T) {
 
 	postService := NewPostService(
 		mockPostRepo,
-		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	userID := uint64(123)
@@ -241,7 +313,15 @@ func TestPostService_DeletePost_Success(t *testing.T) {
 
 	postService := NewPostService(
 		mockPostRepo,
-		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	userID := uint64(123)
@@ -266,7 +346,15 @@ func TestPostService_DeletePost_NotFound(t *testing.T) {
 
 	postService := NewPostService(
 		mockPostRepo,
-		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	postID := uint64(999)
@@ -279,6 +367,1024 @@ func TestPostService_DeletePost_NotFound(t *testing.T) {
 	mockPostRepo.AssertExpectations(t)
 }
 
-func stringPtr(s string) *string {
-	return &s
+func TestPostService_CreatePost_DraftSkipsValidation(t *testing.T) {
+	mockPostRepo := new(MockPostRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+
+	postService := NewPostService(
+		mockPostRepo,
+		mockCommunityRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	req := &request.CreatePostRequest{
+		CommunityID: 1,
+		Title:       "Draft Link",
+		Type:        constant.PostTypeLink,
+		Content:     "Work in progress",
+		IsDraft:     true,
+	}
+
+	mockCommunityRepo.On("GetCommunityByID", req.CommunityID).Return(&model.Community{ID: 1}, nil)
+	mockPostRepo.On("CreatePost", mock.MatchedBy(func(post *model.Post) bool {
+		return post.Status == constant.POST_STATUS_DRAFT
+	})).Return(nil)
+
+	err := postService.CreatePost(context.Background(), 123, req)
+
+	assert.NoError(t, err)
+	mockPostRepo.AssertExpectations(t)
+}
+
+func TestPostService_CreatePost_FuturePublishAtIsScheduled(t *testing.T) {
+	mockPostRepo := new(MockPostRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+
+	postService := NewPostService(
+		mockPostRepo,
+		mockCommunityRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	publishAt := time.Now().Add(time.Hour)
+	req := &request.CreatePostRequest{
+		CommunityID: 1,
+		Title:       "Scheduled Post",
+		Type:        constant.PostTypeText,
+		Content:     "Later",
+		PublishAt:   &publishAt,
+	}
+
+	mockCommunityRepo.On("GetCommunityByID", req.CommunityID).Return(&model.Community{ID: 1}, nil)
+	mockPostRepo.On("CreatePost", mock.MatchedBy(func(post *model.Post) bool {
+		return post.Status == constant.POST_STATUS_SCHEDULED && post.PublishAt != nil
+	})).Return(nil)
+
+	err := postService.CreatePost(context.Background(), 123, req)
+
+	assert.NoError(t, err)
+	mockPostRepo.AssertExpectations(t)
+}
+
+func TestPostService_GetPostDetailByID_DraftHiddenFromOthers(t *testing.T) {
+	mockPostRepo := new(MockPostRepository)
+
+	postService := NewPostService(
+		mockPostRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	postID := uint64(456)
+	viewerID := uint64(789)
+	mockPostRepo.On("GetPostByID", postID).Return(&model.Post{
+		ID:       postID,
+		AuthorID: 123,
+		Status:   constant.POST_STATUS_DRAFT,
+	}, nil)
+
+	_, err := postService.GetPostDetailByID(context.Background(), postID, &viewerID)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "post not found")
+	mockPostRepo.AssertExpectations(t)
+}
+
+func TestPostService_PublishScheduledPosts_AppliesApprovalRules(t *testing.T) {
+	mockPostRepo := new(MockPostRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockRestrictionRepo := new(MockUserRestrictionRepository)
+
+	postService := NewPostService(
+		mockPostRepo,
+		mockCommunityRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		mockRestrictionRepo,
+		nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	post := &model.Post{
+		ID:          456,
+		CommunityID: 1,
+		AuthorID:    123,
+		Type:        constant.PostTypeText,
+		Status:      constant.POST_STATUS_SCHEDULED,
+	}
+
+	mockPostRepo.On("GetDueScheduledPosts", mock.AnythingOfType("time.Time"), constant.SCHEDULER_PUBLISH_POSTS_BATCH_SIZE).
+		Return([]*model.Post{post}, nil)
+	mockCommunityRepo.On("GetCommunityByID", uint64(1)).Return(&model.Community{ID: 1, RequiresPostApproval: true}, nil)
+	mockRestrictionRepo.On("GetActiveRestrictionByUserAndCommunity", uint64(123), uint64(1)).Return(nil, errors.New("not found"))
+	mockPostRepo.On("PublishPost", post.ID, []string{constant.POST_STATUS_SCHEDULED}, constant.POST_STATUS_PENDING, mock.AnythingOfType("time.Time")).
+		Return(true, nil)
+
+	err := postService.PublishScheduledPosts(context.Background())
+
+	assert.NoError(t, err)
+	mockPostRepo.AssertExpectations(t)
+	mockCommunityRepo.AssertExpectations(t)
+}
+
+func TestPostService_GetPostRevisions_DiffsAgainstNextVersion(t *testing.T) {
+	mockPostRepo := new(MockPostRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockRevisionRepo := new(MockPostRevisionRepository)
+
+	postService := NewPostService(
+		mockPostRepo,
+		mockCommunityRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		mockRevisionRepo,
+		nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	postID := uint64(456)
+	post := &model.Post{
+		ID:          postID,
+		CommunityID: 1,
+		Title:       "Title",
+		Content:     "third",
+		Status:      constant.POST_STATUS_APPROVED,
+	}
+	revisions := []*model.PostRevision{
+		{ID: 1, PostID: postID, Title: "Title", Content: "first"},
+		{ID: 2, PostID: postID, Title: "Title", Content: "second"},
+	}
+
+	mockPostRepo.On("GetPostByID", postID).Return(post, nil)
+	mockCommunityRepo.On("GetCommunityByID", uint64(1)).Return(&model.Community{ID: 1}, nil)
+	mockRevisionRepo.On("GetPostRevisionsByPostID", postID).Return(revisions, nil)
+
+	result, err := postService.GetPostRevisions(context.Background(), postID, nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, "second", result[0].ContentDiff[1].Text)
+	assert.Equal(t, "third", result[1].ContentDiff[1].Text)
+	mockRevisionRepo.AssertExpectations(t)
+}
+
+func TestPostService_GetPostRevisions_RejectedPost(t *testing.T) {
+	mockPostRepo := new(MockPostRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockRevisionRepo := new(MockPostRevisionRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+
+	postService := NewPostService(
+		mockPostRepo,
+		mockCommunityRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		mockRevisionRepo,
+		nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		mockCommunityModeratorRepo,
+	)
+
+	postID := uint64(456)
+	authorID := uint64(5)
+	moderatorID := uint64(2)
+	memberID := uint64(9)
+	post := &model.Post{ID: postID, CommunityID: 1, AuthorID: authorID, Content: "removed text", Status: constant.POST_STATUS_REJECTED}
+
+	mockPostRepo.On("GetPostByID", postID).Return(post, nil)
+	mockCommunityRepo.On("GetCommunityByID", uint64(1)).Return(&model.Community{ID: 1}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(1), memberID).Return("", nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(1), moderatorID).Return(constant.ROLE_ADMIN, nil)
+	mockRevisionRepo.On("GetPostRevisionsByPostID", postID).Return([]*model.PostRevision{{ID: 1, PostID: postID, Content: "first"}}, nil)
+
+	_, err := postService.GetPostRevisions(context.Background(), postID, nil)
+	assert.EqualError(t, err, "post not found")
+
+	_, err = postService.GetPostRevisions(context.Background(), postID, &memberID)
+	assert.EqualError(t, err, "post not found")
+
+	result, err := postService.GetPostRevisions(context.Background(), postID, &authorID)
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+
+	result, err = postService.GetPostRevisions(context.Background(), postID, &moderatorID)
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	mockRevisionRepo.AssertNumberOfCalls(t, "GetPostRevisionsByPostID", 2)
+}
+
+func TestPostService_CrosspostPost_RespectsTargetApproval(t *testing.T) {
+	mockPostRepo := new(MockPostRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockSubscriptionRepo := new(MockSubscriptionRepository)
+	mockRestrictionRepo := new(MockUserRestrictionRepository)
+
+	postService := NewPostService(
+		mockPostRepo,
+		mockCommunityRepo,
+		mockSubscriptionRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		mockRestrictionRepo,
+		nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	userID := uint64(123)
+	source := &model.Post{
+		ID:          456,
+		CommunityID: 1,
+		AuthorID:    789,
+		Title:       "Original",
+		Type:        constant.PostTypeText,
+		Content:     "content",
+		Status:      constant.POST_STATUS_APPROVED,
+	}
+	req := &request.CrosspostRequest{CommunityID: 2}
+
+	mockPostRepo.On("GetPostByID", source.ID).Return(source, nil)
+	mockCommunityRepo.On("GetCommunityByID", uint64(1)).Return(&model.Community{ID: 1}, nil)
+	mockCommunityRepo.On("GetCommunityByID", uint64(2)).Return(&model.Community{ID: 2, RequiresPostApproval: true}, nil)
+	mockSubscriptionRepo.On("IsUserSubscribed", userID, uint64(2)).Return(true, nil)
+	mockRestrictionRepo.On("GetActiveRestrictionByUserAndCommunity", userID, uint64(2)).Return(nil, errors.New("not found"))
+	mockPostRepo.On("IsCrosspostedToCommunity", source.ID, uint64(2)).Return(false, nil)
+	mockPostRepo.On("CreatePost", mock.MatchedBy(func(post *model.Post) bool {
+		return post.CommunityID == 2 &&
+			post.Status == constant.POST_STATUS_PENDING &&
+			post.CrosspostParentID != nil && *post.CrosspostParentID == source.ID
+	})).Return(nil)
+
+	err := postService.CrosspostPost(context.Background(), userID, source.ID, req)
+
+	assert.NoError(t, err)
+	mockPostRepo.AssertExpectations(t)
+}
+
+func TestPostService_CrosspostPost_StoresMentionsOfHeldPost(t *testing.T) {
+	mockPostRepo := new(MockPostRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockSubscriptionRepo := new(MockSubscriptionRepository)
+	mockRestrictionRepo := new(MockUserRestrictionRepository)
+	mockMentionRepo := new(MockMentionRepository)
+	mockUserRepo := new(MockUserRepository)
+	mentionService := NewMentionService(
+		mockMentionRepo,
+		mockUserRepo,
+		mockCommunityRepo,
+		mockSubscriptionRepo,
+		mockRestrictionRepo,
+		NewNotificationService(nil, nil, nil, nil, nil, nil),
+	)
+
+	postService := NewPostService(
+		mockPostRepo,
+		mockCommunityRepo,
+		mockSubscriptionRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		mockRestrictionRepo,
+		nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		mentionService,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	userID := uint64(123)
+	source := &model.Post{
+		ID:          456,
+		CommunityID: 1,
+		AuthorID:    789,
+		Title:       "Hi @alice",
+		Type:        constant.PostTypeText,
+		Content:     "content",
+		Status:      constant.POST_STATUS_APPROVED,
+	}
+	req := &request.CrosspostRequest{CommunityID: 2}
+
+	mockPostRepo.On("GetPostByID", source.ID).Return(source, nil)
+	mockCommunityRepo.On("GetCommunityByID", uint64(1)).Return(&model.Community{ID: 1}, nil)
+	mockCommunityRepo.On("GetCommunityByID", uint64(2)).Return(&model.Community{ID: 2, RequiresPostApproval: true}, nil)
+	mockSubscriptionRepo.On("IsUserSubscribed", userID, uint64(2)).Return(true, nil)
+	mockRestrictionRepo.On("GetActiveRestrictionByUserAndCommunity", userID, uint64(2)).Return(nil, errors.New("not found"))
+	mockRestrictionRepo.On("FilterBannedUserIDs", uint64(2), []uint64{5}).Return([]uint64{}, nil)
+	mockPostRepo.On("IsCrosspostedToCommunity", source.ID, uint64(2)).Return(false, nil)
+	mockPostRepo.On("CreatePost", mock.AnythingOfType("*model.Post")).Run(func(args mock.Arguments) {
+		args.Get(0).(*model.Post).ID = 900
+	}).Return(nil)
+	mockUserRepo.On("GetUsersByUsernames", []string{"alice"}).Return([]*model.User{{ID: 5, Username: "alice"}}, nil)
+	mockMentionRepo.On("ReplaceMentions", constant.MENTION_TARGET_POST, uint64(900), userID, []uint64{5}).Return(nil)
+
+	err := postService.CrosspostPost(context.Background(), userID, source.ID, req)
+
+	assert.NoError(t, err)
+	mockMentionRepo.AssertExpectations(t)
+	mockMentionRepo.AssertNotCalled(t, "GetUnnotifiedMentions", mock.Anything, mock.Anything)
+}
+
+func TestPostService_CrosspostPost_BannedInTarget(t *testing.T) {
+	mockPostRepo := new(MockPostRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockSubscriptionRepo := new(MockSubscriptionRepository)
+	mockRestrictionRepo := new(MockUserRestrictionRepository)
+
+	postService := NewPostService(
+		mockPostRepo,
+		mockCommunityRepo,
+		mockSubscriptionRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		mockRestrictionRepo,
+		nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	userID := uint64(123)
+	source := &model.Post{
+		ID:          456,
+		CommunityID: 1,
+		Type:        constant.PostTypeText,
+		Status:      constant.POST_STATUS_APPROVED,
+	}
+
+	mockPostRepo.On("GetPostByID", source.ID).Return(source, nil)
+	mockCommunityRepo.On("GetCommunityByID", uint64(1)).Return(&model.Community{ID: 1}, nil)
+	mockCommunityRepo.On("GetCommunityByID", uint64(2)).Return(&model.Community{ID: 2}, nil)
+	mockSubscriptionRepo.On("IsUserSubscribed", userID, uint64(2)).Return(true, nil)
+	mockRestrictionRepo.On("GetActiveRestrictionByUserAndCommunity", userID, uint64(2)).
+		Return(&model.UserRestriction{RestrictionType: constant.RESTRICTION_PERMANENT_BAN}, nil)
+
+	err := postService.CrosspostPost(context.Background(), userID, source.ID, &request.CrosspostRequest{CommunityID: 2})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "banned")
+	mockPostRepo.AssertNotCalled(t, "CreatePost", mock.Anything)
+}
+
+func newPollPost(postID uint64, multipleChoice bool, voters []uint64) *model.Post {
+	pollData := json.RawMessage(fmt.Sprintf(
+		`{"question":"Q?","options":[{"id":1,"text":"A","voters":%s},{"id":2,"text":"B"}],"multipleChoice":%t}`,
+		mustMarshal(voters), multipleChoice,
+	))
+	return &model.Post{
+		ID:       postID,
+		Type:     constant.PostTypePoll,
+		Status:   constant.POST_STATUS_APPROVED,
+		PollData: &pollData,
+	}
+}
+
+func mustMarshal(v interface{}) string {
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
+func TestPostService_VotePoll_SingleChoiceReplacesVote(t *testing.T) {
+	mockPostRepo := new(MockPostRepository)
+	mockPollRepo := new(MockPollRepository)
+
+	postService := NewPostService(
+		mockPostRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		mockPollRepo,
+		nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	userID := uint64(123)
+	post := newPollPost(456, false, nil)
+
+	mockPostRepo.On("GetPostByID", post.ID).Return(post, nil)
+	mockPollRepo.On("GetPollByPostID", post.ID).Return(&model.Poll{PostID: post.ID}, nil)
+	mockPollRepo.On("AddPollVote", &model.PollVote{PostID: post.ID, OptionID: 2, UserID: userID}, true).Return(true, nil)
+
+	err := postService.VotePoll(context.Background(), userID, post.ID, &request.VotePollRequest{OptionID: 2})
+
+	assert.NoError(t, err)
+	mockPollRepo.AssertExpectations(t)
+	mockPollRepo.AssertNotCalled(t, "ImportPoll", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPostService_VotePoll_AlreadyVoted(t *testing.T) {
+	mockPostRepo := new(MockPostRepository)
+	mockPollRepo := new(MockPollRepository)
+
+	postService := NewPostService(
+		mockPostRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		mockPollRepo,
+		nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	userID := uint64(123)
+	post := newPollPost(456, true, nil)
+
+	mockPostRepo.On("GetPostByID", post.ID).Return(post, nil)
+	mockPollRepo.On("GetPollByPostID", post.ID).Return(&model.Poll{PostID: post.ID}, nil)
+	mockPollRepo.On("AddPollVote", mock.AnythingOfType("*model.PollVote"), false).Return(false, nil)
+
+	err := postService.VotePoll(context.Background(), userID, post.ID, &request.VotePollRequest{OptionID: 1})
+
+	assert.Error(t, err)
+	assert.Equal(t, "already voted for this option", err.Error())
+}
+
+func TestPostService_VotePoll_BackfillsLegacyVoters(t *testing.T) {
+	mockPostRepo := new(MockPostRepository)
+	mockPollRepo := new(MockPollRepository)
+
+	postService := NewPostService(
+		mockPostRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		mockPollRepo,
+		nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	userID := uint64(123)
+	post := newPollPost(456, false, []uint64{7, 8})
+
+	mockPostRepo.On("GetPostByID", post.ID).Return(post, nil)
+	mockPollRepo.On("GetPollByPostID", post.ID).Return(nil, nil)
+	mockPollRepo.On("ImportPoll", mock.AnythingOfType("*model.Poll"), mock.AnythingOfType("[]*model.PollOption"), mock.AnythingOfType("[]*model.PollVote")).
+		Return(nil)
+	mockPollRepo.On("AddPollVote", mock.AnythingOfType("*model.PollVote"), true).Return(true, nil)
+
+	err := postService.VotePoll(context.Background(), userID, post.ID, &request.VotePollRequest{OptionID: 1})
+
+	assert.NoError(t, err)
+	importCall := mockPollRepo.Calls[1]
+	assert.Len(t, importCall.Arguments.Get(1).([]*model.PollOption), 2)
+	assert.Len(t, importCall.Arguments.Get(2).([]*model.PollVote), 2)
+}
+
+func TestPostService_UnvotePoll_NotVoted(t *testing.T) {
+	mockPostRepo := new(MockPostRepository)
+	mockPollRepo := new(MockPollRepository)
+
+	postService := NewPostService(
+		mockPostRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		mockPollRepo,
+		nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	userID := uint64(123)
+	post := newPollPost(456, false, nil)
+
+	mockPostRepo.On("GetPostByID", post.ID).Return(post, nil)
+	mockPollRepo.On("GetPollByPostID", post.ID).Return(&model.Poll{PostID: post.ID}, nil)
+	mockPollRepo.On("RemovePollVote", post.ID, 1, userID).Return(false, nil)
+
+	err := postService.UnvotePoll(context.Background(), userID, post.ID, &request.UnvotePollRequest{OptionID: 1})
+
+	assert.Error(t, err)
+	assert.Equal(t, "you have not voted for this option", err.Error())
+}
+
+func TestSanitizePollData_StripsVotesAndAssignsIDs(t *testing.T) {
+	raw := json.RawMessage(`{"question":"Q?","options":[{"id":3,"text":"A","votes":5,"voters":[1,2]},{"text":"B"}],"totalVotes":5}`)
+
+	sanitized := sanitizePollData(&raw, 1)
+
+	assert.JSONEq(t, `{"question":"Q?","options":[{"id":3,"text":"A"},{"id":4,"text":"B"}],"multipleChoice":false}`, string(*sanitized))
+}
+
+func newRankedPollPost(postID uint64) *model.Post {
+	pollData := json.RawMessage(`{"question":"Q?","options":[{"id":1,"text":"A"},{"id":2,"text":"B"},{"id":3,"text":"C"}],"rankedChoice":true}`)
+	return &model.Post{
+		ID:       postID,
+		AuthorID: 1,
+		Type:     constant.PostTypePoll,
+		Status:   constant.POST_STATUS_APPROVED,
+		PollData: &pollData,
+	}
+}
+
+func TestPostService_VotePoll_RankedBallot(t *testing.T) {
+	mockPostRepo := new(MockPostRepository)
+	mockPollRepo := new(MockPollRepository)
+
+	postService := NewPostService(
+		mockPostRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		mockPollRepo,
+		nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	userID := uint64(123)
+	post := newRankedPollPost(456)
+	expectedBallot := []*model.PollVote{
+		{OptionID: 3, Rank: 1},
+		{OptionID: 1, Rank: 2},
+	}
+
+	mockPostRepo.On("GetPostByID", post.ID).Return(post, nil)
+	mockPollRepo.On("GetPollByPostID", post.ID).Return(&model.Poll{PostID: post.ID}, nil)
+	mockPollRepo.On("ReplaceRankedBallot", post.ID, userID, expectedBallot).Return(nil)
+
+	err := postService.VotePoll(context.Background(), userID, post.ID, &request.VotePollRequest{RankedOptionIDs: []int{3, 1}})
+
+	assert.NoError(t, err)
+	mockPollRepo.AssertExpectations(t)
+}
+
+func TestPostService_VotePoll_RankedDuplicateOption(t *testing.T) {
+	mockPostRepo := new(MockPostRepository)
+	mockPollRepo := new(MockPollRepository)
+
+	postService := NewPostService(
+		mockPostRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		mockPollRepo,
+		nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	post := newRankedPollPost(456)
+	mockPostRepo.On("GetPostByID", post.ID).Return(post, nil)
+
+	err := postService.VotePoll(context.Background(), 123, post.ID, &request.VotePollRequest{RankedOptionIDs: []int{1, 2, 1}})
+
+	assert.Error(t, err)
+	assert.Equal(t, "each option can only be ranked once", err.Error())
+	mockPollRepo.AssertNotCalled(t, "ReplaceRankedBallot", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPostService_VotePoll_ClosedPoll(t *testing.T) {
+	mockPostRepo := new(MockPostRepository)
+	mockPollRepo := new(MockPollRepository)
+
+	postService := NewPostService(
+		mockPostRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		mockPollRepo,
+		nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	closedAt := time.Now().Add(-time.Hour)
+	post := newPollPost(456, false, nil)
+
+	mockPostRepo.On("GetPostByID", post.ID).Return(post, nil)
+	mockPollRepo.On("GetPollByPostID", post.ID).Return(&model.Poll{PostID: post.ID, ClosedAt: &closedAt}, nil)
+
+	err := postService.VotePoll(context.Background(), 123, post.ID, &request.VotePollRequest{OptionID: 1})
+
+	assert.Error(t, err)
+	assert.Equal(t, "poll is closed", err.Error())
+	mockPollRepo.AssertNotCalled(t, "AddPollVote", mock.Anything, mock.Anything)
+}
+
+func TestPostService_ClosePoll_OnlyAuthor(t *testing.T) {
+	mockPostRepo := new(MockPostRepository)
+	mockPollRepo := new(MockPollRepository)
+
+	postService := NewPostService(
+		mockPostRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		mockPollRepo,
+		nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	post := newRankedPollPost(456)
+	mockPostRepo.On("GetPostByID", post.ID).Return(post, nil)
+
+	err := postService.ClosePoll(context.Background(), 999, post.ID)
+
+	assert.Error(t, err)
+	assert.Equal(t, "permission denied", err.Error())
+	mockPollRepo.AssertNotCalled(t, "ClosePoll", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPostService_ClosePoll_Success(t *testing.T) {
+	mockPostRepo := new(MockPostRepository)
+	mockPollRepo := new(MockPollRepository)
+
+	postService := NewPostService(
+		mockPostRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		mockPollRepo,
+		nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	post := newRankedPollPost(456)
+	mockPostRepo.On("GetPostByID", post.ID).Return(post, nil)
+	mockPollRepo.On("GetPollByPostID", post.ID).Return(&model.Poll{PostID: post.ID}, nil)
+	mockPollRepo.On("ClosePoll", post.ID, post.AuthorID, mock.AnythingOfType("time.Time")).Return(true, nil)
+
+	err := postService.ClosePoll(context.Background(), post.AuthorID, post.ID)
+
+	assert.NoError(t, err)
+	mockPollRepo.AssertExpectations(t)
+}
+
+func TestPostService_FinalizePolls_SkipsAlreadyFinalized(t *testing.T) {
+	mockPollRepo := new(MockPollRepository)
+
+	postService := NewPostService(
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		mockPollRepo,
+		nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	post := newRankedPollPost(456)
+	mockPollRepo.On("GetPollsToFinalize", mock.AnythingOfType("time.Time"), constant.SCHEDULER_FINALIZE_POLLS_BATCH_SIZE).
+		Return([]*model.Poll{{PostID: post.ID, Post: post}}, nil)
+	mockPollRepo.On("FinalizePoll", post.ID, mock.AnythingOfType("time.Time")).Return(false, nil)
+
+	err := postService.FinalizePolls(context.Background())
+
+	assert.NoError(t, err)
+	mockPollRepo.AssertNotCalled(t, "GetPollVoterIDs", mock.Anything)
+}
+
+func TestPostService_SuggestPollOption_WriteInsDisabled(t *testing.T) {
+	mockPostRepo := new(MockPostRepository)
+	mockRestrictionRepo := new(MockUserRestrictionRepository)
+	mockPollRepo := new(MockPollRepository)
+
+	postService := NewPostService(
+		mockPostRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		mockRestrictionRepo,
+		nil,
+		mockPollRepo,
+		nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	userID := uint64(123)
+	post := newRankedPollPost(456)
+	mockPostRepo.On("GetPostByID", post.ID).Return(post, nil)
+	mockRestrictionRepo.On("GetActiveRestrictionByUserAndCommunity", userID, post.CommunityID).Return(nil, nil)
+
+	err := postService.SuggestPollOption(context.Background(), userID, post.ID, &request.SuggestPollOptionRequest{Text: "D"})
+
+	assert.Error(t, err)
+	assert.Equal(t, "poll does not allow write-in options", err.Error())
+	mockPollRepo.AssertNotCalled(t, "CreateWriteInOption", mock.Anything)
+}
+
+func TestSanitizePollData_SkipsReservedIDs(t *testing.T) {
+	raw := json.RawMessage(`{"question":"Q?","options":[{"id":1,"text":"A"},{"text":"B"}]}`)
+
+	sanitized := sanitizePollData(&raw, 5)
+
+	assert.JSONEq(t, `{"question":"Q?","options":[{"id":1,"text":"A"},{"id":5,"text":"B"}],"multipleChoice":false}`, string(*sanitized))
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestPostService_CreatePost_RendersMarkdown(t *testing.T) {
+	mockPostRepo := new(MockPostRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+
+	postService := NewPostService(
+		mockPostRepo,
+		mockCommunityRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	req := &request.CreatePostRequest{
+		CommunityID:   1,
+		Title:         "Markdown post",
+		Type:          constant.PostTypeText,
+		Content:       "## Hello\n\nSee [docs](javascript:void) and **this**",
+		ContentFormat: constant.CONTENT_FORMAT_MARKDOWN,
+	}
+
+	mockCommunityRepo.On("GetCommunityByID", req.CommunityID).Return(&model.Community{ID: 1}, nil)
+	mockPostRepo.On("CreatePost", mock.MatchedBy(func(post *model.Post) bool {
+		return post.Content == "<h2>Hello</h2>\n<p>See <a rel=\"nofollow ugc noopener\">docs</a> and <strong>this</strong></p>" &&
+			post.ContentText == "Hello See docs and this"
+	})).Return(nil)
+
+	err := postService.CreatePost(context.Background(), 123, req)
+
+	assert.NoError(t, err)
+	mockPostRepo.AssertExpectations(t)
+}
+
+func TestPostService_UpdatePost_SanitizesContent(t *testing.T) {
+	mockPostRepo := new(MockPostRepository)
+	mockRevisionRepo := new(MockPostRevisionRepository)
+
+	postService := NewPostService(
+		mockPostRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		mockRevisionRepo,
+		nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	updateReq := &request.UpdatePostTextRequest{
+		Content: stringPtr(`<img src="x" onerror="alert(1)"><b>edited</b>`),
+	}
+
+	mockPostRepo.On("GetPostByID", uint64(456)).Return(&model.Post{ID: 456, AuthorID: 123, Type: constant.PostTypeText}, nil)
+	mockPostRepo.On("UpdatePostText", uint64(456), mock.MatchedBy(func(req *request.UpdatePostTextRequest) bool {
+		return *req.Content == `<img src="x"><b>edited</b>`
+	}), mock.AnythingOfType("*model.PostRevision")).Return(nil)
+
+	err := postService.UpdatePost(context.Background(), 123, 456, constant.PostTypeText, updateReq)
+
+	assert.NoError(t, err)
+	mockPostRepo.AssertExpectations(t)
+}
+
+func TestPostService_CrosspostPost_RemovedOriginal(t *testing.T) {
+	mockPostRepo := new(MockPostRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+
+	postService := NewPostService(
+		mockPostRepo,
+		mockCommunityRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	originalID := uint64(400)
+	crosspost := &model.Post{
+		ID:                456,
+		CommunityID:       1,
+		Type:              constant.PostTypeText,
+		Status:            constant.POST_STATUS_APPROVED,
+		CrosspostParentID: &originalID,
+	}
+
+	mockPostRepo.On("GetPostByID", crosspost.ID).Return(crosspost, nil)
+	mockPostRepo.On("GetPostByID", originalID).Return(&model.Post{
+		ID:          originalID,
+		CommunityID: 3,
+		Type:        constant.PostTypeText,
+		Status:      constant.POST_STATUS_REJECTED,
+	}, nil)
+
+	err := postService.CrosspostPost(context.Background(), 123, crosspost.ID, &request.CrosspostRequest{CommunityID: 2})
+
+	assert.EqualError(t, err, "post not found")
+	mockCommunityRepo.AssertNotCalled(t, "GetCommunityByID", mock.Anything)
+	mockPostRepo.AssertNotCalled(t, "CreatePost", mock.Anything)
+}
+
+func TestPostService_CreatePost_DraftValidatesTypeAndPayload(t *testing.T) {
+	mockPostRepo := new(MockPostRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+
+	postService := NewPostService(
+		mockPostRepo,
+		mockCommunityRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+	mockCommunityRepo.On("GetCommunityByID", uint64(1)).Return(&model.Community{ID: 1}, nil)
+
+	err := postService.CreatePost(context.Background(), 123, &request.CreatePostRequest{
+		CommunityID: 1,
+		Title:       "Draft",
+		Type:        "video",
+		Content:     "Work in progress",
+		IsDraft:     true,
+	})
+	assert.EqualError(t, err, "invalid post type")
+
+	pollData := json.RawMessage(`{"question":"Which one?","options":[{"id":1,"text":"Only option"}]}`)
+	err = postService.CreatePost(context.Background(), 123, &request.CreatePostRequest{
+		CommunityID: 1,
+		Title:       "Draft Poll",
+		Type:        constant.PostTypePoll,
+		Content:     "Work in progress",
+		PollData:    &pollData,
+		IsDraft:     true,
+	})
+	assert.EqualError(t, err, "poll must have at least 2 options")
+
+	mockPostRepo.AssertNotCalled(t, "CreatePost", mock.Anything)
+}
+
+func TestPostService_SaveDraft_ValidatesPayload(t *testing.T) {
+	mockPostRepo := new(MockPostRepository)
+
+	postService := NewPostService(
+		mockPostRepo,
+		nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+	mockPostRepo.On("GetPostByID", uint64(10)).Return(&model.Post{ID: 10, AuthorID: 123, Type: constant.PostTypePoll, Status: constant.POST_STATUS_DRAFT}, nil)
+
+	pollData := json.RawMessage(`{"question":"","options":[{"id":1,"text":"A"},{"id":2,"text":"B"}]}`)
+	err := postService.SaveDraft(context.Background(), 123, 10, &request.SaveDraftRequest{PollData: &pollData})
+
+	assert.EqualError(t, err, "poll question is required")
+	mockPostRepo.AssertNotCalled(t, "UpdateDraftPost", mock.Anything, mock.Anything)
+}
+
+func TestPostService_SaveDraft_ClearPublishAt(t *testing.T) {
+	mockPostRepo := new(MockPostRepository)
+
+	postService := NewPostService(
+		mockPostRepo,
+		nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		newTestContentSanitizer(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+	publishAt := time.Now().Add(time.Hour)
+	mockPostRepo.On("GetPostByID", uint64(10)).Return(&model.Post{ID: 10, AuthorID: 123, Type: constant.PostTypeText, Status: constant.POST_STATUS_SCHEDULED, PublishAt: &publishAt}, nil)
+
+	err := postService.SaveDraft(context.Background(), 123, 10, &request.SaveDraftRequest{ClearPublishAt: true, PublishAt: &publishAt})
+	assert.EqualError(t, err, "cannot set and clear publishAt together")
+
+	mockPostRepo.On("UpdateDraftPost", uint64(10), mock.MatchedBy(func(req *request.SaveDraftRequest) bool {
+		return req.ClearPublishAt && req.PublishAt == nil
+	})).Return(nil)
+
+	err = postService.SaveDraft(context.Background(), 123, 10, &request.SaveDraftRequest{ClearPublishAt: true})
+
+	assert.NoError(t, err)
+	mockPostRepo.AssertExpectations(t)
 }
@@ -0,0 +1,420 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"social-platform-backend/config"
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/domain/repository"
+	"social-platform-backend/internal/interface/dto/response"
+	"social-platform-backend/package/constant"
+	"social-platform-backend/package/logger"
+	"social-platform-backend/package/template/payload"
+	"sync"
+	"time"
+)
+
+type reactionNotificationKey struct {
+	recipientID uint64
+	targetType  string
+	targetID    uint64
+}
+
+// pendingReactionNotification collects the reactions to one post or comment until
+// they are sent to its author as a single notification
+type pendingReactionNotification struct {
+	postID    uint64
+	commentID *uint64
+	// Reacting users in the order they first reacted, with their emojis
+	reactorIDs []uint64
+	emojis     map[uint64][]string
+	firstAt    time.Time
+}
+
+// reactionTarget is the post, comment or message a reaction is added to
+type reactionTarget struct {
+	authorID       uint64
+	postID         uint64
+	commentID      *uint64
+	conversation   *model.Conversation
+	allowedEmojis  []string
+	notifiesAuthor bool
+}
+
+// ReactionService toggles emoji reactions on posts, comments and messages. Reactions to
+// a post or comment are not notified one by one, they are buffered per target and
+// flushed by the scheduler as one notification.
+type ReactionService struct {
+	reactionRepo        repository.ReactionRepository
+	postRepo            repository.PostRepository
+	commentRepo         repository.CommentRepository
+	messageRepo         repository.MessageRepository
+	conversationRepo    repository.ConversationRepository
+	communityRepo       repository.CommunityRepository
+	userRepo            repository.UserRepository
+	sseService          *SSEService
+	notificationService *NotificationService
+	subscriptionRepo    repository.SubscriptionRepository
+	defaultEmojis       []string
+
+	mu      sync.Mutex
+	pending map[reactionNotificationKey]*pendingReactionNotification
+	now     func() time.Time
+}
+
+func NewReactionService(
+	reactionRepo repository.ReactionRepository,
+	postRepo repository.PostRepository,
+	commentRepo repository.CommentRepository,
+	messageRepo repository.MessageRepository,
+	conversationRepo repository.ConversationRepository,
+	communityRepo repository.CommunityRepository,
+	userRepo repository.UserRepository,
+	sseService *SSEService,
+	notificationService *NotificationService,
+	conf *config.Config,
+	subscriptionRepo repository.SubscriptionRepository,
+) *ReactionService {
+	defaultEmojis := conf.Reaction.Emojis
+	if len(defaultEmojis) == 0 {
+		defaultEmojis = constant.DEFAULT_REACTION_EMOJIS
+	}
+
+	return &ReactionService{
+		reactionRepo:        reactionRepo,
+		postRepo:            postRepo,
+		commentRepo:         commentRepo,
+		messageRepo:         messageRepo,
+		conversationRepo:    conversationRepo,
+		communityRepo:       communityRepo,
+		userRepo:            userRepo,
+		sseService:          sseService,
+		notificationService: notificationService,
+		subscriptionRepo:    subscriptionRepo,
+		defaultEmojis:       defaultEmojis,
+		pending:             make(map[reactionNotificationKey]*pendingReactionNotification),
+		now:                 time.Now,
+	}
+}
+
+// GetReactionEmojis returns the emojis that can be used in a community, or the
+// platform-wide set when no community is given
+func (s *ReactionService) GetReactionEmojis(ctx context.Context, communityID *uint64) (*response.ReactionEmojisResponse, error) {
+	if communityID == nil {
+		return &response.ReactionEmojisResponse{Emojis: s.defaultEmojis}, nil
+	}
+
+	community, err := s.communityRepo.GetCommunityByID(*communityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Community not found in ReactionService.GetReactionEmojis: %v", err)
+		return nil, fmt.Errorf("community not found")
+	}
+
+	return &response.ReactionEmojisResponse{
+		Emojis:   s.communityEmojis(community),
+		IsCustom: len(community.ReactionEmojis) > 0,
+	}, nil
+}
+
+// ToggleReaction adds the emoji reaction of the user to the target, or removes it if
+// the user already reacted with that emoji
+func (s *ReactionService) ToggleReaction(ctx context.Context, userID uint64, targetType string, targetID uint64, emoji string) (*response.ToggleReactionResponse, error) {
+	var (
+		target *reactionTarget
+		err    error
+	)
+	switch targetType {
+	case constant.REACTION_TARGET_POST:
+		target, err = s.getPostTarget(ctx, userID, targetID)
+	case constant.REACTION_TARGET_COMMENT:
+		target, err = s.getCommentTarget(ctx, userID, targetID)
+	case constant.REACTION_TARGET_MESSAGE:
+		target, err = s.getMessageTarget(ctx, userID, targetID)
+	default:
+		return nil, fmt.Errorf("invalid reaction target")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(target.allowedEmojis, emoji) {
+		logger.ErrorfWithCtx(ctx, "[Err] Emoji is not allowed in ReactionService.ToggleReaction: %s %d, emoji=%q", targetType, targetID, emoji)
+		return nil, fmt.Errorf("invalid emoji")
+	}
+
+	reaction := &model.Reaction{
+		TargetType: targetType,
+		TargetID:   targetID,
+		UserID:     userID,
+		Emoji:      emoji,
+		CreatedAt:  s.now(),
+	}
+
+	added, err := s.reactionRepo.ToggleReaction(reaction)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error toggling reaction in ReactionService.ToggleReaction: %v", err)
+		return nil, fmt.Errorf("failed to toggle reaction")
+	}
+
+	counts, err := s.reactionRepo.GetReactionCounts(targetType, targetID, &userID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting reaction counts in ReactionService.ToggleReaction: %v", err)
+		return nil, fmt.Errorf("failed to toggle reaction")
+	}
+
+	go s.broadcastReactionUpdate(ctx, reaction, added, counts, target)
+
+	if target.notifiesAuthor && userID != target.authorID {
+		key := reactionNotificationKey{recipientID: target.authorID, targetType: targetType, targetID: targetID}
+		if added {
+			s.queueReactionNotification(key, target, userID, emoji)
+		} else {
+			s.dequeueReactionNotification(key, userID, emoji)
+		}
+	}
+
+	return response.NewToggleReactionResponse(emoji, added, counts), nil
+}
+
+func (s *ReactionService) getPostTarget(ctx context.Context, userID, postID uint64) (*reactionTarget, error) {
+	post, err := s.postRepo.GetPostByID(postID)
+	if err != nil || isUnpublishedPost(post) {
+		logger.ErrorfWithCtx(ctx, "[Err] Post not found in ReactionService.ToggleReaction: postID=%d", postID)
+		return nil, fmt.Errorf("post not found")
+	}
+
+	if post.LockedAt != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Post is locked in ReactionService.ToggleReaction: postID=%d", postID)
+		return nil, fmt.Errorf("post is locked")
+	}
+
+	emojis, err := s.allowedCommunityEmojis(ctx, userID, post.CommunityID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reactionTarget{
+		authorID:       post.AuthorID,
+		postID:         post.ID,
+		allowedEmojis:  emojis,
+		notifiesAuthor: true,
+	}, nil
+}
+
+func (s *ReactionService) getCommentTarget(ctx context.Context, userID, commentID uint64) (*reactionTarget, error) {
+	comment, err := s.commentRepo.GetCommentByID(commentID)
+	if err != nil || comment.DeletedAt != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Comment not found in ReactionService.ToggleReaction: %v", err)
+		return nil, fmt.Errorf("comment not found")
+	}
+
+	post, err := s.postRepo.GetPostByID(comment.PostID)
+	if err != nil || isUnpublishedPost(post) {
+		logger.ErrorfWithCtx(ctx, "[Err] Post not found in ReactionService.ToggleReaction: commentID=%d, err=%v", commentID, err)
+		return nil, fmt.Errorf("post not found")
+	}
+
+	if post.LockedAt != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Post is locked in ReactionService.ToggleReaction: postID=%d", post.ID)
+		return nil, fmt.Errorf("post is locked")
+	}
+
+	lockedComment, err := s.commentRepo.GetLockedAncestor(commentID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error checking comment thread lock in ReactionService.ToggleReaction: %v", err)
+		return nil, fmt.Errorf("failed to check comment thread")
+	}
+	if lockedComment != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Comment thread is locked in ReactionService.ToggleReaction: commentID=%d", commentID)
+		return nil, fmt.Errorf("comment thread is locked")
+	}
+
+	emojis, err := s.allowedCommunityEmojis(ctx, userID, post.CommunityID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reactionTarget{
+		authorID:       comment.AuthorID,
+		postID:         post.ID,
+		commentID:      &comment.ID,
+		allowedEmojis:  emojis,
+		notifiesAuthor: true,
+	}, nil
+}
+
+// Messages always use the platform-wide emoji set. The other participant already gets
+// the SSE event, so no notification is created.
+func (s *ReactionService) getMessageTarget(ctx context.Context, userID, messageID uint64) (*reactionTarget, error) {
+	message, err := s.messageRepo.GetMessageByID(messageID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Message not found in ReactionService.ToggleReaction: %v", err)
+		return nil, fmt.Errorf("message not found")
+	}
+
+	conversation, err := s.conversationRepo.GetConversationByID(message.ConversationID)
+	if err != nil || (conversation.User1ID != userID && conversation.User2ID != userID) {
+		logger.ErrorfWithCtx(ctx, "[Err] User not in conversation in ReactionService.ToggleReaction: userID=%d, messageID=%d", userID, messageID)
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	return &reactionTarget{
+		authorID:      message.SenderID,
+		conversation:  conversation,
+		allowedEmojis: s.defaultEmojis,
+	}, nil
+}
+
+// allowedCommunityEmojis returns the emojis of the community the post or comment is in.
+// Only approved members can react to content of a private community.
+func (s *ReactionService) allowedCommunityEmojis(ctx context.Context, userID, communityID uint64) ([]string, error) {
+	community, err := s.communityRepo.GetCommunityByID(communityID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Community not found in ReactionService.ToggleReaction: %v", err)
+		return nil, fmt.Errorf("community not found")
+	}
+
+	if community.IsPrivate {
+		memberIDs, err := s.subscriptionRepo.FilterApprovedSubscriberIDs(communityID, []uint64{userID})
+		if err != nil || len(memberIDs) == 0 {
+			logger.ErrorfWithCtx(ctx, "[Err] User is not a member of private community in ReactionService.ToggleReaction: userID=%d, communityID=%d", userID, communityID)
+			return nil, fmt.Errorf("you are not a member of this community")
+		}
+	}
+	return s.communityEmojis(community), nil
+}
+
+func (s *ReactionService) communityEmojis(community *model.Community) []string {
+	if len(community.ReactionEmojis) > 0 {
+		return community.ReactionEmojis
+	}
+	return s.defaultEmojis
+}
+
+// SSE is delivered per user, so post and comment updates reach the author and the
+// reacting user's other sessions, message updates reach both participants
+func (s *ReactionService) broadcastReactionUpdate(ctx context.Context, reaction *model.Reaction, added bool, counts []*model.ReactionCount, target *reactionTarget) {
+	data := response.NewReactionUpdatedEvent(reaction, added, counts)
+
+	recipients := []uint64{reaction.UserID}
+	if target.conversation != nil {
+		data.ConversationID = &target.conversation.ID
+		recipients = []uint64{target.conversation.User1ID, target.conversation.User2ID}
+	} else {
+		data.PostID = &target.postID
+		if target.authorID != reaction.UserID {
+			recipients = append(recipients, target.authorID)
+		}
+	}
+
+	event := &response.SSEEvent{
+		Event: "reaction_updated",
+		Data:  data,
+	}
+	for _, recipientID := range recipients {
+		s.sseService.BroadcastToUser(ctx, recipientID, event)
+	}
+}
+
+func (s *ReactionService) queueReactionNotification(key reactionNotificationKey, target *reactionTarget, userID uint64, emoji string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pending, ok := s.pending[key]
+	if !ok {
+		pending = &pendingReactionNotification{
+			postID:    target.postID,
+			commentID: target.commentID,
+			emojis:    make(map[uint64][]string),
+			firstAt:   s.now(),
+		}
+		s.pending[key] = pending
+	}
+
+	if _, ok := pending.emojis[userID]; !ok {
+		pending.reactorIDs = append(pending.reactorIDs, userID)
+	}
+	pending.emojis[userID] = append(pending.emojis[userID], emoji)
+}
+
+// A reaction removed before the notification was sent is taken out of it again
+func (s *ReactionService) dequeueReactionNotification(key reactionNotificationKey, userID uint64, emoji string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pending, ok := s.pending[key]
+	if !ok {
+		return
+	}
+
+	emojis := slices.DeleteFunc(pending.emojis[userID], func(e string) bool { return e == emoji })
+	if len(emojis) > 0 {
+		pending.emojis[userID] = emojis
+		return
+	}
+
+	delete(pending.emojis, userID)
+	pending.reactorIDs = slices.DeleteFunc(pending.reactorIDs, func(id uint64) bool { return id == userID })
+	if len(pending.reactorIDs) == 0 {
+		delete(s.pending, key)
+	}
+}
+
+// FlushReactionNotifications sends one notification per post or comment whose first
+// buffered reaction is older than the notification delay
+func (s *ReactionService) FlushReactionNotifications(ctx context.Context) error {
+	cutoff := s.now().Add(-constant.REACTION_NOTIFICATION_DELAY_SECONDS * time.Second)
+
+	due := make(map[reactionNotificationKey]*pendingReactionNotification)
+	s.mu.Lock()
+	for key, pending := range s.pending {
+		if !pending.firstAt.After(cutoff) {
+			due[key] = pending
+			delete(s.pending, key)
+		}
+	}
+	s.mu.Unlock()
+
+	if len(due) == 0 || s.notificationService == nil {
+		return nil
+	}
+
+	failed := 0
+	for key, pending := range due {
+		latestReactorID := pending.reactorIDs[len(pending.reactorIDs)-1]
+		reactor, err := s.userRepo.GetUserByID(latestReactorID)
+		if err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Error getting reactor in ReactionService.FlushReactionNotifications: %v", err)
+			failed++
+			continue
+		}
+
+		// Each emoji is listed once, in the order it was first used
+		var emojis []string
+		for _, reactorID := range pending.reactorIDs {
+			for _, emoji := range pending.emojis[reactorID] {
+				if !slices.Contains(emojis, emoji) {
+					emojis = append(emojis, emoji)
+				}
+			}
+		}
+
+		notifPayload := payload.ReactionNotificationPayload{
+			PostID:     pending.postID,
+			CommentID:  pending.commentID,
+			UserName:   reactor.Username,
+			OtherCount: len(pending.reactorIDs) - 1,
+			Emojis:     emojis,
+		}
+		if err := s.notificationService.CreateNotification(ctx, key.recipientID, constant.NOTIFICATION_ACTION_GET_REACTION, notifPayload); err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Error creating reaction notification in ReactionService.FlushReactionNotifications: %v", err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to send %d reaction notifications", failed)
+	}
+	return nil
+}
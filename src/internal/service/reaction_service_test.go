@@ -0,0 +1,299 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"social-platform-backend/config"
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/package/constant"
+	"social-platform-backend/package/template/payload"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReactionService_ToggleReaction_AddsPostReaction(t *testing.T) {
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	mockReactionRepo := new(MockReactionRepository)
+	mockPostRepo := new(MockPostRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	reactionService := NewReactionService(
+		mockReactionRepo,
+		mockPostRepo,
+		nil, nil, nil,
+		mockCommunityRepo,
+		nil,
+		NewSSEService(),
+		nil,
+		&config.Config{},
+		nil,
+	)
+	reactionService.now = func() time.Time { return now }
+	userID := uint64(7)
+
+	mockPostRepo.On("GetPostByID", uint64(10)).Return(&model.Post{ID: 10, CommunityID: 3, AuthorID: 1, Status: constant.POST_STATUS_APPROVED}, nil)
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3}, nil)
+	mockReactionRepo.On("ToggleReaction", mock.MatchedBy(func(reaction *model.Reaction) bool {
+		return reaction.TargetType == constant.REACTION_TARGET_POST && reaction.TargetID == 10 && reaction.UserID == userID && reaction.Emoji == "🎉"
+	})).Return(true, nil)
+	mockReactionRepo.On("GetReactionCounts", constant.REACTION_TARGET_POST, uint64(10), &userID).Return([]*model.ReactionCount{
+		{TargetID: 10, Emoji: "👍", Count: 4},
+		{TargetID: 10, Emoji: "🎉", Count: 1, Reacted: true},
+	}, nil)
+
+	result, err := reactionService.ToggleReaction(context.Background(), userID, constant.REACTION_TARGET_POST, 10, "🎉")
+
+	assert.NoError(t, err)
+	assert.True(t, result.Added)
+	assert.Len(t, result.Reactions, 2)
+	assert.True(t, result.Reactions[1].Reacted)
+	assert.Len(t, reactionService.pending, 1)
+	mockReactionRepo.AssertExpectations(t)
+}
+
+func TestReactionService_ToggleReaction_UsesCommunityEmojis(t *testing.T) {
+	now := time.Now()
+	mockReactionRepo := new(MockReactionRepository)
+	mockPostRepo := new(MockPostRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	reactionService := NewReactionService(
+		mockReactionRepo,
+		mockPostRepo,
+		nil, nil, nil,
+		mockCommunityRepo,
+		nil,
+		NewSSEService(),
+		nil,
+		&config.Config{},
+		nil,
+	)
+	reactionService.now = func() time.Time { return now }
+
+	mockPostRepo.On("GetPostByID", uint64(10)).Return(&model.Post{ID: 10, CommunityID: 3, AuthorID: 1, Status: constant.POST_STATUS_APPROVED}, nil)
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, ReactionEmojis: pq.StringArray{"🦀", "🚀"}}, nil)
+
+	// a default emoji the community replaced
+	result, err := reactionService.ToggleReaction(context.Background(), 7, constant.REACTION_TARGET_POST, 10, "👍")
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "invalid emoji")
+	mockReactionRepo.AssertNotCalled(t, "ToggleReaction", mock.Anything)
+}
+
+func TestReactionService_ToggleReaction_LockedCommentThread(t *testing.T) {
+	now := time.Now()
+	mockPostRepo := new(MockPostRepository)
+	mockCommentRepo := new(MockCommentRepository)
+	reactionService := NewReactionService(
+		nil,
+		mockPostRepo,
+		mockCommentRepo,
+		nil, nil, nil, nil,
+		NewSSEService(),
+		nil,
+		&config.Config{},
+		nil,
+	)
+	reactionService.now = func() time.Time { return now }
+
+	mockCommentRepo.On("GetCommentByID", uint64(20)).Return(&model.Comment{ID: 20, PostID: 10, AuthorID: 2}, nil)
+	mockPostRepo.On("GetPostByID", uint64(10)).Return(&model.Post{ID: 10, CommunityID: 3, Status: constant.POST_STATUS_APPROVED}, nil)
+	mockCommentRepo.On("GetLockedAncestor", uint64(20)).Return(&model.Comment{ID: 15}, nil)
+
+	result, err := reactionService.ToggleReaction(context.Background(), 7, constant.REACTION_TARGET_COMMENT, 20, "👍")
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "comment thread is locked")
+}
+
+func TestReactionService_ToggleReaction_CommentOnDraftPost(t *testing.T) {
+	mockPostRepo := new(MockPostRepository)
+	mockCommentRepo := new(MockCommentRepository)
+	reactionService := NewReactionService(
+		nil,
+		mockPostRepo,
+		mockCommentRepo,
+		nil, nil, nil, nil,
+		NewSSEService(),
+		nil,
+		&config.Config{},
+		nil,
+	)
+
+	mockCommentRepo.On("GetCommentByID", uint64(20)).Return(&model.Comment{ID: 20, PostID: 10, AuthorID: 2}, nil)
+	mockPostRepo.On("GetPostByID", uint64(10)).Return(&model.Post{ID: 10, CommunityID: 3, Status: constant.POST_STATUS_DRAFT}, nil)
+
+	result, err := reactionService.ToggleReaction(context.Background(), 7, constant.REACTION_TARGET_COMMENT, 20, "👍")
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "post not found")
+	mockCommentRepo.AssertNotCalled(t, "GetLockedAncestor", mock.Anything)
+}
+
+func TestReactionService_ToggleReaction_PrivateCommunityNonMember(t *testing.T) {
+	mockReactionRepo := new(MockReactionRepository)
+	mockPostRepo := new(MockPostRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockSubscriptionRepo := new(MockSubscriptionRepository)
+	reactionService := NewReactionService(
+		mockReactionRepo,
+		mockPostRepo,
+		nil, nil, nil,
+		mockCommunityRepo,
+		nil,
+		NewSSEService(),
+		nil,
+		&config.Config{},
+		mockSubscriptionRepo,
+	)
+
+	mockPostRepo.On("GetPostByID", uint64(10)).Return(&model.Post{ID: 10, CommunityID: 3, AuthorID: 1, Status: constant.POST_STATUS_APPROVED}, nil)
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, IsPrivate: true}, nil)
+	// the membership request of user 7 is still pending
+	mockSubscriptionRepo.On("FilterApprovedSubscriberIDs", uint64(3), []uint64{7}).Return([]uint64{}, nil)
+
+	result, err := reactionService.ToggleReaction(context.Background(), 7, constant.REACTION_TARGET_POST, 10, "👍")
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "you are not a member of this community")
+	mockReactionRepo.AssertNotCalled(t, "ToggleReaction", mock.Anything)
+}
+
+func TestReactionService_ToggleReaction_MessageOutsideConversation(t *testing.T) {
+	now := time.Now()
+	mockMessageRepo := new(MockMessageRepository)
+	mockConversationRepo := new(MockConversationRepository)
+	reactionService := NewReactionService(
+		nil, nil, nil,
+		mockMessageRepo,
+		mockConversationRepo,
+		nil, nil,
+		NewSSEService(),
+		nil,
+		&config.Config{},
+		nil,
+	)
+	reactionService.now = func() time.Time { return now }
+
+	mockMessageRepo.On("GetMessageByID", uint64(30)).Return(&model.Message{ID: 30, ConversationID: 5, SenderID: 1}, nil)
+	mockConversationRepo.On("GetConversationByID", uint64(5)).Return(&model.Conversation{ID: 5, User1ID: 1, User2ID: 2}, nil)
+
+	result, err := reactionService.ToggleReaction(context.Background(), 9, constant.REACTION_TARGET_MESSAGE, 30, "👍")
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "unauthorized")
+}
+
+func TestReactionService_ToggleReaction_MessageIsNotNotified(t *testing.T) {
+	now := time.Now()
+	mockReactionRepo := new(MockReactionRepository)
+	mockMessageRepo := new(MockMessageRepository)
+	mockConversationRepo := new(MockConversationRepository)
+	reactionService := NewReactionService(
+		mockReactionRepo,
+		nil, nil,
+		mockMessageRepo,
+		mockConversationRepo,
+		nil, nil,
+		NewSSEService(),
+		nil,
+		&config.Config{},
+		nil,
+	)
+	reactionService.now = func() time.Time { return now }
+	userID := uint64(2)
+
+	mockMessageRepo.On("GetMessageByID", uint64(30)).Return(&model.Message{ID: 30, ConversationID: 5, SenderID: 1}, nil)
+	mockConversationRepo.On("GetConversationByID", uint64(5)).Return(&model.Conversation{ID: 5, User1ID: 1, User2ID: 2}, nil)
+	mockReactionRepo.On("ToggleReaction", mock.AnythingOfType("*model.Reaction")).Return(true, nil)
+	mockReactionRepo.On("GetReactionCounts", constant.REACTION_TARGET_MESSAGE, uint64(30), &userID).Return([]*model.ReactionCount{{TargetID: 30, Emoji: "😂", Count: 1, Reacted: true}}, nil)
+
+	result, err := reactionService.ToggleReaction(context.Background(), userID, constant.REACTION_TARGET_MESSAGE, 30, "😂")
+
+	assert.NoError(t, err)
+	assert.True(t, result.Added)
+	assert.Empty(t, reactionService.pending)
+}
+
+func TestReactionService_FlushReactionNotifications_AggregatesReactions(t *testing.T) {
+	mockNotificationRepo := new(MockNotificationRepository)
+	mockNotificationSettingRepo := new(MockNotificationSettingRepository)
+	mockNotificationUserRepo := new(MockUserRepository)
+	notificationService := NewNotificationService(mockNotificationRepo, mockNotificationSettingRepo, nil, mockNotificationUserRepo, NewSSEService(), nil)
+
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	mockReactionRepo := new(MockReactionRepository)
+	mockPostRepo := new(MockPostRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockUserRepo := new(MockUserRepository)
+	reactionService := NewReactionService(
+		mockReactionRepo,
+		mockPostRepo,
+		nil, nil, nil,
+		mockCommunityRepo,
+		mockUserRepo,
+		NewSSEService(),
+		notificationService,
+		&config.Config{},
+		nil,
+	)
+	reactionService.now = func() time.Time { return now }
+	authorID := uint64(1)
+
+	mockPostRepo.On("GetPostByID", uint64(10)).Return(&model.Post{ID: 10, CommunityID: 3, AuthorID: authorID, Status: constant.POST_STATUS_APPROVED}, nil)
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3}, nil)
+	mockReactionRepo.On("GetReactionCounts", constant.REACTION_TARGET_POST, uint64(10), mock.Anything).Return([]*model.ReactionCount{}, nil)
+	mockReactionRepo.On("ToggleReaction", mock.MatchedBy(func(reaction *model.Reaction) bool { return reaction.UserID != 9 })).Return(true, nil)
+	// user 9 takes the reaction back before the notification goes out
+	mockReactionRepo.On("ToggleReaction", mock.MatchedBy(func(reaction *model.Reaction) bool { return reaction.UserID == 9 })).Return(true, nil).Once()
+	mockReactionRepo.On("ToggleReaction", mock.MatchedBy(func(reaction *model.Reaction) bool { return reaction.UserID == 9 })).Return(false, nil).Once()
+
+	ctx := context.Background()
+	for _, click := range []struct {
+		userID uint64
+		emoji  string
+	}{
+		{7, "👍"}, {8, "🎉"}, {7, "🎉"}, {9, "😂"}, {9, "😂"}, {authorID, "👍"}, {6, "👍"},
+	} {
+		_, err := reactionService.ToggleReaction(ctx, click.userID, constant.REACTION_TARGET_POST, 10, click.emoji)
+		assert.NoError(t, err)
+	}
+
+	// nothing is due before the delay has passed
+	assert.NoError(t, reactionService.FlushReactionNotifications(ctx))
+	mockNotificationRepo.AssertNotCalled(t, "CreateNotification", mock.Anything)
+
+	now = now.Add(constant.REACTION_NOTIFICATION_DELAY_SECONDS * time.Second)
+	mockUserRepo.On("GetUserByID", uint64(6)).Return(&model.User{ID: 6, Username: "latest"}, nil)
+	mockNotificationUserRepo.On("GetUserByID", authorID).Return(&model.User{ID: authorID, Email: "author@example.com"}, nil)
+	mockNotificationSettingRepo.On("GetUserNotificationSetting", authorID, constant.NOTIFICATION_ACTION_GET_REACTION).Return(nil, errors.New("not found"))
+	mockNotificationRepo.On("CreateNotification", mock.MatchedBy(func(notification *model.Notification) bool {
+		return notification.UserID == authorID && notification.Body == "latest and 2 others reacted 👍 🎉 to your post"
+	})).Return(nil).Once()
+	mockNotificationRepo.On("GetUnreadCount", authorID).Return(int64(1), nil).Maybe()
+
+	assert.NoError(t, reactionService.FlushReactionNotifications(ctx))
+	assert.Empty(t, reactionService.pending)
+	mockNotificationRepo.AssertExpectations(t)
+}
+
+func TestNotificationService_PrepareTemplateData_Reaction(t *testing.T) {
+	notificationService := NewNotificationService(nil, nil, nil, nil, nil, nil)
+	commentID := uint64(20)
+
+	data := notificationService.prepareTemplateData(constant.NOTIFICATION_ACTION_GET_REACTION, payload.ReactionNotificationPayload{
+		PostID:     10,
+		CommentID:  &commentID,
+		UserName:   "someone",
+		OtherCount: 1,
+		Emojis:     []string{"👍", "😂"},
+	})
+
+	assert.Equal(t, "someone", data.UserName)
+	assert.Equal(t, commentID, data.CommentID)
+	assert.Equal(t, 1, data.OtherCount)
+	assert.Equal(t, "👍 😂", data.Emojis)
+}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/domain/repository"
+	"social-platform-backend/internal/interface/dto/request"
+	"social-platform-backend/internal/interface/dto/response"
+	"social-platform-backend/package/constant"
+	"social-platform-backend/package/logger"
+	"social-platform-backend/package/template/payload"
+	"time"
+)
+
+// ReportService handles what happens to reports around the moderation queue: reporter
+// feedback and escalation of reports to the platform admins
+type ReportService struct {
+	reportRepo             repository.ReportRepository
+	communityRepo          repository.CommunityRepository
+	communityModeratorRepo repository.CommunityModeratorRepository
+	postRepo               repository.PostRepository
+	commentRepo            repository.CommentRepository
+	postReportRepo         repository.PostReportRepository
+	commentReportRepo      repository.CommentReportRepository
+	moderationLogRepo      repository.ModerationLogRepository
+	notificationService    *NotificationService
+}
+
+func NewReportService(
+	reportRepo repository.ReportRepository,
+	communityRepo repository.CommunityRepository,
+	communityModeratorRepo repository.CommunityModeratorRepository,
+	postRepo repository.PostRepository,
+	commentRepo repository.CommentRepository,
+	postReportRepo repository.PostReportRepository,
+	commentReportRepo repository.CommentReportRepository,
+	moderationLogRepo repository.ModerationLogRepository,
+	notificationService *NotificationService,
+) *ReportService {
+	return &ReportService{
+		reportRepo:             reportRepo,
+		communityRepo:          communityRepo,
+		communityModeratorRepo: communityModeratorRepo,
+		postRepo:               postRepo,
+		commentRepo:            commentRepo,
+		postReportRepo:         postReportRepo,
+		commentReportRepo:      commentReportRepo,
+		moderationLogRepo:      moderationLogRepo,
+		notificationService:    notificationService,
+	}
+}
+
+// checkReporterStanding refuses new reports from users whose recent reports were mostly
+// dismissed. A failed lookup lets the report through.
+func checkReporterStanding(ctx context.Context, reportRepo repository.ReportRepository, reporterID uint64) error {
+	if reportRepo == nil {
+		return nil
+	}
+
+	since := time.Now().AddDate(0, 0, -constant.REPORT_ABUSE_WINDOW_DAYS)
+	resolved, dismissed, err := reportRepo.GetReporterStats(reporterID, since)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting reporter stats in checkReporterStanding: %v", err)
+		return nil
+	}
+
+	if resolved >= constant.REPORT_ABUSE_MIN_RESOLVED && float64(dismissed)/float64(resolved) >= constant.REPORT_ABUSE_MAX_DISMISSED_RATIO {
+		logger.InfofWithCtx(ctx, "[Info] Reporting restricted for user %d: %d of %d recent reports dismissed", reporterID, dismissed, resolved)
+		return fmt.Errorf("reporting temporarily restricted")
+	}
+	return nil
+}
+
+// NotifyReporters tells the reporters that action was taken on the content they reported,
+// without naming the moderator
+func (s *ReportService) NotifyReporters(ctx context.Context, community *model.Community, reporterIDs []uint64, postID uint64, commentID *uint64) {
+	if s.notificationService == nil || len(reporterIDs) == 0 {
+		return
+	}
+
+	notifPayload := payload.ReportActionedNotificationPayload{
+		CommunityID:   community.ID,
+		CommunityName: community.Name,
+		PostID:        postID,
+		CommentID:     commentID,
+	}
+	for _, reporterID := range reporterIDs {
+		if err := s.notificationService.CreateNotification(ctx, reporterID, constant.NOTIFICATION_ACTION_REPORT_ACTIONED, notifPayload); err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Error notifying reporter %d in ReportService.NotifyReporters: %v", reporterID, err)
+		}
+	}
+}
+
+// EscalateReports hands the open reports of a post or comment over to the platform admins
+func (s *ReportService) EscalateReports(ctx context.Context, userID, communityID uint64, targetType string, targetID uint64, req *request.EscalateReportsRequest) error {
+	// Check if community exists
+	if _, err := s.communityRepo.GetCommunityByID(communityID); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Community not found in ReportService.EscalateReports: %v", err)
+		return fmt.Errorf("community not found")
+	}
+
+	if err := checkCommunityPermission(ctx, s.communityModeratorRepo, communityID, userID, constant.COMMUNITY_PERMISSION_VIEW_REPORTS, "ReportService.EscalateReports"); err != nil {
+		return err
+	}
+
+	openReports, err := s.countOpenReports(ctx, communityID, targetType, targetID)
+	if err != nil {
+		return err
+	}
+	if openReports == 0 {
+		return fmt.Errorf("no open reports")
+	}
+
+	openEscalation, err := s.reportRepo.GetOpenEscalation(targetType, targetID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting open escalation in ReportService.EscalateReports: %v", err)
+		return fmt.Errorf("failed to escalate reports")
+	}
+	if openEscalation != nil {
+		return fmt.Errorf("reports already escalated")
+	}
+
+	now := time.Now()
+	escalation := &model.ReportEscalation{
+		CommunityID: communityID,
+		TargetType:  targetType,
+		TargetID:    targetID,
+		EscalatedBy: userID,
+		Note:        req.Note,
+		Status:      constant.REPORT_STATUS_OPEN,
+		CreatedAt:   now,
+	}
+	if err := s.reportRepo.CreateEscalation(escalation); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error creating escalation in ReportService.EscalateReports: %v", err)
+		return fmt.Errorf("failed to escalate reports")
+	}
+
+	moderationLog := &model.ModerationLog{
+		CommunityID: communityID,
+		ActorID:     userID,
+		Action:      constant.MODERATION_ACTION_ESCALATE_REPORTS,
+		TargetType:  targetType,
+		TargetID:    targetID,
+		Reason:      req.Note,
+		CreatedAt:   now,
+	}
+	if err := s.moderationLogRepo.CreateModerationLog(moderationLog); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error recording moderation log in ReportService.EscalateReports: %v", err)
+	}
+	return nil
+}
+
+// countOpenReports checks that the post or comment belongs to the community and counts
+// its open reports
+func (s *ReportService) countOpenReports(ctx context.Context, communityID uint64, targetType string, targetID uint64) (int, error) {
+	switch targetType {
+	case constant.MODERATION_TARGET_POST:
+		post, err := s.postRepo.GetPostByID(targetID)
+		if err != nil || post.CommunityID != communityID {
+			logger.ErrorfWithCtx(ctx, "[Err] Post not found in ReportService.countOpenReports: postID=%d, communityID=%d, err=%v", targetID, communityID, err)
+			return 0, fmt.Errorf("post not found")
+		}
+		reports, err := s.postReportRepo.GetOpenReportsByPostIDs([]uint64{targetID})
+		if err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Error getting post reports in ReportService.countOpenReports: %v", err)
+			return 0, fmt.Errorf("failed to escalate reports")
+		}
+		return len(reports), nil
+	case constant.MODERATION_TARGET_COMMENT:
+		comment, err := s.commentRepo.GetCommentByID(targetID)
+		if err != nil || comment.DeletedAt != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Comment not found in ReportService.countOpenReports: %v", err)
+			return 0, fmt.Errorf("comment not found")
+		}
+		post, err := s.postRepo.GetPostByID(comment.PostID)
+		if err != nil || post.CommunityID != communityID {
+			logger.ErrorfWithCtx(ctx, "[Err] Comment does not belong to community in ReportService.countOpenReports: commentID=%d, communityID=%d", targetID, communityID)
+			return 0, fmt.Errorf("comment not found")
+		}
+		reports, err := s.commentReportRepo.GetOpenReportsByCommentIDs([]uint64{targetID})
+		if err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Error getting comment reports in ReportService.countOpenReports: %v", err)
+			return 0, fmt.Errorf("failed to escalate reports")
+		}
+		return len(reports), nil
+	default:
+		return 0, fmt.Errorf("invalid target type")
+	}
+}
+
+// GetReportEscalations lists escalations for the platform admins
+func (s *ReportService) GetReportEscalations(ctx context.Context, status string, page, limit int) ([]*response.ReportEscalationResponse, *response.Pagination, error) {
+	escalations, total, err := s.reportRepo.GetEscalations(status, page, limit)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting escalations in ReportService.GetReportEscalations: %v", err)
+		return nil, nil, fmt.Errorf("failed to get report escalations")
+	}
+
+	escalationResponses := make([]*response.ReportEscalationResponse, len(escalations))
+	for i, escalation := range escalations {
+		escalationResponses[i] = response.NewReportEscalationResponse(escalation)
+	}
+
+	pagination := &response.Pagination{
+		Total: total,
+		Page:  page,
+		Limit: limit,
+	}
+	if int64(page*limit) < total {
+		pagination.NextURL = fmt.Sprintf("/api/v1/admin/report-escalations?page=%d&limit=%d", page+1, limit)
+		if status != "" {
+			pagination.NextURL += "&status=" + status
+		}
+	}
+
+	return escalationResponses, pagination, nil
+}
+
+// ResolveReportEscalation records the decision of a platform admin, the open reports of
+// the escalated content get the same status
+func (s *ReportService) ResolveReportEscalation(ctx context.Context, adminID, escalationID uint64, req *request.ResolveReportEscalationRequest) error {
+	escalation, err := s.reportRepo.GetEscalationByID(escalationID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Escalation not found in ReportService.ResolveReportEscalation: %v", err)
+		return fmt.Errorf("escalation not found")
+	}
+	if escalation.Status != constant.REPORT_STATUS_OPEN {
+		return fmt.Errorf("escalation already resolved")
+	}
+
+	now := time.Now()
+	resolution := constant.REPORT_RESOLUTION_ADMIN_DECISION
+	escalation.Status = req.Status
+	escalation.Resolution = &resolution
+	escalation.ResolutionReason = req.Reason
+	escalation.ResolvedAt = &now
+	escalation.ResolvedBy = &adminID
+	resolved, err := s.reportRepo.ResolveEscalation(escalation)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error resolving escalation in ReportService.ResolveReportEscalation: %v", err)
+		return fmt.Errorf("failed to resolve escalation")
+	}
+	if !resolved {
+		return fmt.Errorf("escalation already resolved")
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/interface/dto/request"
+	"social-platform-backend/package/constant"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReportService_EscalateReports_Success(t *testing.T) {
+	mockReportRepo := new(MockReportRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockPostRepo := new(MockPostRepository)
+	mockPostReportRepo := new(MockPostReportRepository)
+	mockModerationLogRepo := new(MockModerationLogRepository)
+	reportService := NewReportService(
+		mockReportRepo,
+		mockCommunityRepo,
+		mockCommunityModeratorRepo,
+		mockPostRepo,
+		nil,
+		mockPostReportRepo,
+		nil,
+		mockModerationLogRepo,
+		nil,
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, Name: "golang"}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(1)).Return(constant.ROLE_ADMIN, nil)
+	note := "possible illegal content"
+
+	mockPostRepo.On("GetPostByID", uint64(10)).Return(&model.Post{ID: 10, CommunityID: 3}, nil)
+	mockPostReportRepo.On("GetOpenReportsByPostIDs", []uint64{10}).Return([]*model.PostReport{{ID: 1, PostID: 10, ReporterID: 5}}, nil)
+	mockReportRepo.On("GetOpenEscalation", constant.MODERATION_TARGET_POST, uint64(10)).Return(nil, nil)
+	mockReportRepo.On("CreateEscalation", mock.MatchedBy(func(escalation *model.ReportEscalation) bool {
+		return escalation.CommunityID == 3 && escalation.TargetID == 10 && escalation.EscalatedBy == 1 &&
+			escalation.Status == constant.REPORT_STATUS_OPEN && *escalation.Note == note
+	})).Return(nil)
+	mockModerationLogRepo.On("CreateModerationLog", mock.MatchedBy(func(log *model.ModerationLog) bool {
+		return log.Action == constant.MODERATION_ACTION_ESCALATE_REPORTS && log.TargetID == 10
+	})).Return(nil)
+
+	err := reportService.EscalateReports(context.Background(), 1, 3, constant.MODERATION_TARGET_POST, 10, &request.EscalateReportsRequest{Note: &note})
+
+	assert.NoError(t, err)
+	mockReportRepo.AssertExpectations(t)
+	mockModerationLogRepo.AssertExpectations(t)
+}
+
+func TestReportService_EscalateReports_AlreadyEscalated(t *testing.T) {
+	mockReportRepo := new(MockReportRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockPostRepo := new(MockPostRepository)
+	mockPostReportRepo := new(MockPostReportRepository)
+	reportService := NewReportService(
+		mockReportRepo,
+		mockCommunityRepo,
+		mockCommunityModeratorRepo,
+		mockPostRepo,
+		nil,
+		mockPostReportRepo,
+		nil, nil, nil,
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, Name: "golang"}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(1)).Return(constant.ROLE_ADMIN, nil)
+
+	mockPostRepo.On("GetPostByID", uint64(10)).Return(&model.Post{ID: 10, CommunityID: 3}, nil)
+	mockPostReportRepo.On("GetOpenReportsByPostIDs", []uint64{10}).Return([]*model.PostReport{{ID: 1, PostID: 10, ReporterID: 5}}, nil)
+	mockReportRepo.On("GetOpenEscalation", constant.MODERATION_TARGET_POST, uint64(10)).Return(&model.ReportEscalation{ID: 2}, nil)
+
+	err := reportService.EscalateReports(context.Background(), 1, 3, constant.MODERATION_TARGET_POST, 10, &request.EscalateReportsRequest{})
+
+	assert.EqualError(t, err, "reports already escalated")
+	mockReportRepo.AssertNotCalled(t, "CreateEscalation", mock.Anything)
+}
+
+func TestReportService_EscalateReports_LookupError(t *testing.T) {
+	mockReportRepo := new(MockReportRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockPostRepo := new(MockPostRepository)
+	mockPostReportRepo := new(MockPostReportRepository)
+	reportService := NewReportService(
+		mockReportRepo,
+		mockCommunityRepo,
+		mockCommunityModeratorRepo,
+		mockPostRepo,
+		nil,
+		mockPostReportRepo,
+		nil, nil, nil,
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, Name: "golang"}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(1)).Return(constant.ROLE_ADMIN, nil)
+
+	mockPostRepo.On("GetPostByID", uint64(10)).Return(&model.Post{ID: 10, CommunityID: 3}, nil)
+	mockPostReportRepo.On("GetOpenReportsByPostIDs", []uint64{10}).Return([]*model.PostReport{{ID: 1, PostID: 10, ReporterID: 5}}, nil)
+	mockReportRepo.On("GetOpenEscalation", constant.MODERATION_TARGET_POST, uint64(10)).Return(nil, assert.AnError)
+
+	err := reportService.EscalateReports(context.Background(), 1, 3, constant.MODERATION_TARGET_POST, 10, &request.EscalateReportsRequest{})
+
+	assert.EqualError(t, err, "failed to escalate reports")
+	mockReportRepo.AssertNotCalled(t, "CreateEscalation", mock.Anything)
+}
+
+func TestReportService_EscalateReports_NoOpenReports(t *testing.T) {
+	mockReportRepo := new(MockReportRepository)
+	mockCommunityRepo := new(MockCommunityRepository)
+	mockCommunityModeratorRepo := new(MockCommunityModeratorRepository)
+	mockPostRepo := new(MockPostRepository)
+	mockPostReportRepo := new(MockPostReportRepository)
+	reportService := NewReportService(
+		mockReportRepo,
+		mockCommunityRepo,
+		mockCommunityModeratorRepo,
+		mockPostRepo,
+		nil,
+		mockPostReportRepo,
+		nil, nil, nil,
+	)
+
+	mockCommunityRepo.On("GetCommunityByID", uint64(3)).Return(&model.Community{ID: 3, Name: "golang"}, nil)
+	mockCommunityModeratorRepo.On("GetModeratorRole", uint64(3), uint64(1)).Return(constant.ROLE_ADMIN, nil)
+
+	mockPostRepo.On("GetPostByID", uint64(10)).Return(&model.Post{ID: 10, CommunityID: 3}, nil)
+	mockPostReportRepo.On("GetOpenReportsByPostIDs", []uint64{10}).Return([]*model.PostReport{}, nil)
+
+	err := reportService.EscalateReports(context.Background(), 1, 3, constant.MODERATION_TARGET_POST, 10, &request.EscalateReportsRequest{})
+
+	assert.EqualError(t, err, "no open reports")
+	mockReportRepo.AssertNotCalled(t, "CreateEscalation", mock.Anything)
+}
+
+func TestReportService_ResolveReportEscalation(t *testing.T) {
+	mockReportRepo := new(MockReportRepository)
+	reportService := NewReportService(
+		mockReportRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil,
+	)
+
+	reason := "content is legal"
+
+	mockReportRepo.On("GetEscalationByID", uint64(2)).Return(&model.ReportEscalation{ID: 2, Status: constant.REPORT_STATUS_OPEN}, nil)
+	mockReportRepo.On("ResolveEscalation", mock.MatchedBy(func(escalation *model.ReportEscalation) bool {
+		return escalation.ID == 2 && escalation.Status == constant.REPORT_STATUS_DISMISSED &&
+			*escalation.Resolution == constant.REPORT_RESOLUTION_ADMIN_DECISION && *escalation.ResolvedBy == 9 &&
+			*escalation.ResolutionReason == reason && escalation.ResolvedAt != nil
+	})).Return(true, nil)
+
+	err := reportService.ResolveReportEscalation(context.Background(), 9, 2, &request.ResolveReportEscalationRequest{
+		Status: constant.REPORT_STATUS_DISMISSED,
+		Reason: &reason,
+	})
+
+	assert.NoError(t, err)
+	mockReportRepo.AssertExpectations(t)
+}
+
+func TestReportService_ResolveReportEscalation_AlreadyResolved(t *testing.T) {
+	mockReportRepo := new(MockReportRepository)
+	reportService := NewReportService(
+		mockReportRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil,
+	)
+
+	mockReportRepo.On("GetEscalationByID", uint64(2)).Return(&model.ReportEscalation{ID: 2, Status: constant.REPORT_STATUS_ACTIONED}, nil)
+
+	err := reportService.ResolveReportEscalation(context.Background(), 9, 2, &request.ResolveReportEscalationRequest{
+		Status: constant.REPORT_STATUS_DISMISSED,
+	})
+
+	assert.EqualError(t, err, "escalation already resolved")
+	mockReportRepo.AssertNotCalled(t, "ResolveEscalation", mock.Anything)
+}
+
+func TestReportService_ResolveReportEscalation_ClosedFromQueue(t *testing.T) {
+	mockReportRepo := new(MockReportRepository)
+	reportService := NewReportService(
+		mockReportRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil,
+	)
+
+	mockReportRepo.On("GetEscalationByID", uint64(2)).Return(&model.ReportEscalation{ID: 2, Status: constant.REPORT_STATUS_OPEN}, nil)
+	// a moderator acted on the content before the admins got to it
+	mockReportRepo.On("ResolveEscalation", mock.AnythingOfType("*model.ReportEscalation")).Return(false, nil)
+
+	err := reportService.ResolveReportEscalation(context.Background(), 9, 2, &request.ResolveReportEscalationRequest{
+		Status: constant.REPORT_STATUS_ACTIONED,
+	})
+
+	assert.EqualError(t, err, "escalation already resolved")
+}
@@ -0,0 +1,286 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/domain/repository"
+	"social-platform-backend/internal/interface/dto/request"
+	"social-platform-backend/internal/interface/dto/response"
+	"social-platform-backend/package/constant"
+	"social-platform-backend/package/logger"
+	"social-platform-backend/package/util"
+	"strings"
+)
+
+type SavedPostCollectionService struct {
+	collectionRepo    repository.SavedPostCollectionRepository
+	userSavedPostRepo repository.UserSavedPostRepository
+	postRepo          repository.PostRepository
+}
+
+func NewSavedPostCollectionService(
+	collectionRepo repository.SavedPostCollectionRepository,
+	userSavedPostRepo repository.UserSavedPostRepository,
+	postRepo repository.PostRepository,
+) *SavedPostCollectionService {
+	return &SavedPostCollectionService{
+		collectionRepo:    collectionRepo,
+		userSavedPostRepo: userSavedPostRepo,
+		postRepo:          postRepo,
+	}
+}
+
+func (s *SavedPostCollectionService) GetUserCollections(ctx context.Context, userID uint64) ([]*response.SavedPostCollectionResponse, error) {
+	collections, err := s.collectionRepo.GetUserCollections(userID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting collections in SavedPostCollectionService.GetUserCollections: %v", err)
+		return nil, fmt.Errorf("failed to get collections")
+	}
+
+	collectionResponses := make([]*response.SavedPostCollectionResponse, len(collections))
+	for i, collection := range collections {
+		collectionResponses[i] = response.NewSavedPostCollectionResponse(collection)
+	}
+	return collectionResponses, nil
+}
+
+func (s *SavedPostCollectionService) CreateCollection(ctx context.Context, userID uint64, req *request.CreateSavedPostCollectionRequest) (*response.SavedPostCollectionResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("collection name is required")
+	}
+
+	count, err := s.collectionRepo.CountUserCollections(userID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error counting collections in SavedPostCollectionService.CreateCollection: %v", err)
+		return nil, fmt.Errorf("failed to create collection")
+	}
+	if count >= constant.MAX_SAVED_POST_COLLECTIONS {
+		return nil, fmt.Errorf("collection limit reached")
+	}
+
+	collection := &model.SavedPostCollection{
+		UserID:   userID,
+		Name:     name,
+		Position: int(count),
+		IsPublic: req.IsPublic,
+	}
+	if req.IsPublic {
+		shareToken, err := util.GenerateToken(constant.SAVED_POST_COLLECTION_TOKEN_BYTES)
+		if err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Error generating share token in SavedPostCollectionService.CreateCollection: %v", err)
+			return nil, fmt.Errorf("failed to create collection")
+		}
+		collection.ShareToken = &shareToken
+	}
+
+	if err := s.collectionRepo.CreateCollection(collection); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error creating collection in SavedPostCollectionService.CreateCollection: %v", err)
+		return nil, fmt.Errorf("failed to create collection")
+	}
+
+	return response.NewSavedPostCollectionResponse(collection), nil
+}
+
+func (s *SavedPostCollectionService) UpdateCollection(ctx context.Context, userID, collectionID uint64, req *request.UpdateSavedPostCollectionRequest) (*response.SavedPostCollectionResponse, error) {
+	collection, err := s.getOwnCollection(ctx, userID, collectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, fmt.Errorf("collection name is required")
+		}
+		collection.Name = name
+	}
+
+	if req.IsPublic != nil {
+		collection.IsPublic = *req.IsPublic
+		if !collection.IsPublic {
+			// Making the collection private revokes the old link
+			collection.ShareToken = nil
+		} else if collection.ShareToken == nil {
+			shareToken, err := util.GenerateToken(constant.SAVED_POST_COLLECTION_TOKEN_BYTES)
+			if err != nil {
+				logger.ErrorfWithCtx(ctx, "[Err] Error generating share token in SavedPostCollectionService.UpdateCollection: %v", err)
+				return nil, fmt.Errorf("failed to update collection")
+			}
+			collection.ShareToken = &shareToken
+		}
+	}
+
+	if err := s.collectionRepo.UpdateCollection(collection.ID, collection.Name, collection.IsPublic, collection.ShareToken); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error updating collection in SavedPostCollectionService.UpdateCollection: %v", err)
+		return nil, fmt.Errorf("failed to update collection")
+	}
+
+	return response.NewSavedPostCollectionResponse(collection), nil
+}
+
+func (s *SavedPostCollectionService) ReorderCollections(ctx context.Context, userID uint64, req *request.ReorderSavedPostCollectionsRequest) error {
+	collections, err := s.collectionRepo.GetUserCollections(userID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting collections in SavedPostCollectionService.ReorderCollections: %v", err)
+		return fmt.Errorf("failed to reorder collections")
+	}
+
+	// The new order has to list every collection of the user exactly once
+	if len(req.CollectionIDs) != len(collections) {
+		return fmt.Errorf("invalid collection order")
+	}
+	owned := make(map[uint64]bool, len(collections))
+	for _, collection := range collections {
+		owned[collection.ID] = true
+	}
+	for _, collectionID := range req.CollectionIDs {
+		if !owned[collectionID] {
+			return fmt.Errorf("invalid collection order")
+		}
+		delete(owned, collectionID)
+	}
+
+	if err := s.collectionRepo.ReorderCollections(userID, req.CollectionIDs); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error reordering collections in SavedPostCollectionService.ReorderCollections: %v", err)
+		return fmt.Errorf("failed to reorder collections")
+	}
+	return nil
+}
+
+func (s *SavedPostCollectionService) DeleteCollection(ctx context.Context, userID, collectionID uint64) error {
+	if _, err := s.getOwnCollection(ctx, userID, collectionID); err != nil {
+		return err
+	}
+
+	if err := s.collectionRepo.DeleteCollection(collectionID); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error deleting collection in SavedPostCollectionService.DeleteCollection: %v", err)
+		return fmt.Errorf("failed to delete collection")
+	}
+	return nil
+}
+
+// AddPostToCollection saves the post first when the user has not saved it yet
+func (s *SavedPostCollectionService) AddPostToCollection(ctx context.Context, userID, collectionID uint64, req *request.AddPostToCollectionRequest) error {
+	if _, err := s.getOwnCollection(ctx, userID, collectionID); err != nil {
+		return err
+	}
+
+	exists, err := s.userSavedPostRepo.CheckUserSavedPostExists(userID, req.PostID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error checking saved post in SavedPostCollectionService.AddPostToCollection: %v", err)
+		return fmt.Errorf("failed to add post to collection")
+	}
+
+	if !exists {
+		post, err := s.postRepo.GetPostByID(req.PostID)
+		if err != nil || isUnpublishedPost(post) {
+			logger.ErrorfWithCtx(ctx, "[Err] Post not found in SavedPostCollectionService.AddPostToCollection: postID=%d", req.PostID)
+			return fmt.Errorf("post not found")
+		}
+		if err := s.userSavedPostRepo.CreateUserSavedPost(userID, &request.UserSavedPostRequest{PostID: req.PostID}); err != nil {
+			logger.ErrorfWithCtx(ctx, "[Err] Error saving post in SavedPostCollectionService.AddPostToCollection: %v", err)
+			return fmt.Errorf("failed to add post to collection")
+		}
+	}
+
+	item := &model.SavedPostCollectionItem{
+		CollectionID: collectionID,
+		PostID:       req.PostID,
+		UserID:       userID,
+	}
+	if err := s.collectionRepo.AddCollectionItem(item); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error adding collection item in SavedPostCollectionService.AddPostToCollection: %v", err)
+		return fmt.Errorf("failed to add post to collection")
+	}
+	return nil
+}
+
+// RemovePostFromCollection keeps the post saved, it only leaves the collection
+func (s *SavedPostCollectionService) RemovePostFromCollection(ctx context.Context, userID, collectionID, postID uint64) error {
+	if _, err := s.getOwnCollection(ctx, userID, collectionID); err != nil {
+		return err
+	}
+
+	if err := s.collectionRepo.RemoveCollectionItem(collectionID, postID); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error removing collection item in SavedPostCollectionService.RemovePostFromCollection: %v", err)
+		return fmt.Errorf("failed to remove post from collection")
+	}
+	return nil
+}
+
+func (s *SavedPostCollectionService) UpdateSavedPostNote(ctx context.Context, userID, postID uint64, req *request.UpdateSavedPostNoteRequest) error {
+	exists, err := s.userSavedPostRepo.CheckUserSavedPostExists(userID, postID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error checking saved post in SavedPostCollectionService.UpdateSavedPostNote: %v", err)
+		return fmt.Errorf("failed to update note")
+	}
+	if !exists {
+		return fmt.Errorf("saved post not found")
+	}
+
+	var note *string
+	if trimmed := strings.TrimSpace(req.Note); trimmed != "" {
+		note = &trimmed
+	}
+
+	if err := s.userSavedPostRepo.UpdateNote(userID, postID, note); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error updating note in SavedPostCollectionService.UpdateSavedPostNote: %v", err)
+		return fmt.Errorf("failed to update note")
+	}
+	return nil
+}
+
+func (s *SavedPostCollectionService) GetSharedCollection(ctx context.Context, shareToken string, page, limit int) (*response.SharedSavedPostCollectionResponse, *response.Pagination, error) {
+	collection, err := s.collectionRepo.GetCollectionByShareToken(shareToken)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Collection not found in SavedPostCollectionService.GetSharedCollection: %v", err)
+		return nil, nil, fmt.Errorf("collection not found")
+	}
+
+	savedPosts, total, err := s.collectionRepo.GetCollectionPosts(collection.ID, page, limit)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error getting collection posts in SavedPostCollectionService.GetSharedCollection: %v", err)
+		return nil, nil, fmt.Errorf("failed to get collection")
+	}
+
+	pagination := &response.Pagination{
+		Total: total,
+		Page:  page,
+		Limit: limit,
+	}
+	totalPages := (total + int64(limit) - 1) / int64(limit)
+	if int64(page) < totalPages {
+		pagination.NextURL = fmt.Sprintf("/api/v1/collections/shared/%s?page=%d&limit=%d", shareToken, page+1, limit)
+	}
+
+	return response.NewSharedSavedPostCollectionResponse(collection, savedPosts, total), pagination, nil
+}
+
+// RefreshSavedPostSnapshots keeps the copied title and author of saved posts in sync
+// with the original post and flags saved posts whose post was edited or deleted
+func (s *SavedPostCollectionService) RefreshSavedPostSnapshots(ctx context.Context) error {
+	refreshed, flagged, err := s.userSavedPostRepo.RefreshSnapshots(constant.SCHEDULER_REFRESH_SAVED_POST_SNAPSHOTS_BATCH_SIZE)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Error refreshing snapshots in SavedPostCollectionService.RefreshSavedPostSnapshots: %v", err)
+		return fmt.Errorf("failed to refresh saved post snapshots")
+	}
+
+	if refreshed > 0 || flagged > 0 {
+		logger.InfofWithCtx(ctx, "[Info] Refreshed %d saved post snapshots, flagged %d deleted posts", refreshed, flagged)
+	}
+	return nil
+}
+
+func (s *SavedPostCollectionService) getOwnCollection(ctx context.Context, userID, collectionID uint64) (*model.SavedPostCollection, error) {
+	collection, err := s.collectionRepo.GetCollectionByID(collectionID)
+	if err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Collection not found in SavedPostCollectionService: %v", err)
+		return nil, fmt.Errorf("collection not found")
+	}
+	if collection.UserID != userID {
+		return nil, fmt.Errorf("permission denied")
+	}
+	return collection, nil
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"social-platform-backend/internal/domain/model"
+	"social-platform-backend/internal/interface/dto/request"
+	"social-platform-backend/package/constant"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSavedPostCollectionService_CreateCollection_AppendsAtEnd(t *testing.T) {
+	mockCollectionRepo := new(MockSavedPostCollectionRepository)
+	collectionService := NewSavedPostCollectionService(mockCollectionRepo, nil, nil)
+
+	mockCollectionRepo.On("CountUserCollections", uint64(1)).Return(int64(3), nil)
+	mockCollectionRepo.On("CreateCollection", mock.MatchedBy(func(collection *model.SavedPostCollection) bool {
+		return collection.UserID == 1 && collection.Name == "Recipes" && collection.Position == 3 &&
+			collection.IsPublic && collection.ShareToken != nil && len(*collection.ShareToken) == 2*constant.SAVED_POST_COLLECTION_TOKEN_BYTES
+	})).Return(nil)
+
+	result, err := collectionService.CreateCollection(context.Background(), 1, &request.CreateSavedPostCollectionRequest{Name: "  Recipes ", IsPublic: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Recipes", result.Name)
+	assert.NotNil(t, result.ShareToken)
+	mockCollectionRepo.AssertExpectations(t)
+}
+
+func TestSavedPostCollectionService_CreateCollection_LimitReached(t *testing.T) {
+	mockCollectionRepo := new(MockSavedPostCollectionRepository)
+	collectionService := NewSavedPostCollectionService(mockCollectionRepo, nil, nil)
+
+	mockCollectionRepo.On("CountUserCollections", uint64(1)).Return(int64(constant.MAX_SAVED_POST_COLLECTIONS), nil)
+
+	result, err := collectionService.CreateCollection(context.Background(), 1, &request.CreateSavedPostCollectionRequest{Name: "One more"})
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "collection limit reached")
+	mockCollectionRepo.AssertNotCalled(t, "CreateCollection", mock.Anything)
+}
+
+func TestSavedPostCollectionService_UpdateCollection_MakePrivateRevokesLink(t *testing.T) {
+	mockCollectionRepo := new(MockSavedPostCollectionRepository)
+	collectionService := NewSavedPostCollectionService(mockCollectionRepo, nil, nil)
+	shareToken := "abc"
+	isPublic := false
+
+	mockCollectionRepo.On("GetCollectionByID", uint64(5)).Return(&model.SavedPostCollection{ID: 5, UserID: 1, Name: "Old", IsPublic: true, ShareToken: &shareToken}, nil)
+	mockCollectionRepo.On("UpdateCollection", uint64(5), "Old", false, (*string)(nil)).Return(nil)
+
+	result, err := collectionService.UpdateCollection(context.Background(), 1, 5, &request.UpdateSavedPostCollectionRequest{IsPublic: &isPublic})
+
+	assert.NoError(t, err)
+	assert.False(t, result.IsPublic)
+	assert.Nil(t, result.ShareToken)
+	mockCollectionRepo.AssertExpectations(t)
+}
+
+func TestSavedPostCollectionService_UpdateCollection_NotOwner(t *testing.T) {
+	mockCollectionRepo := new(MockSavedPostCollectionRepository)
+	collectionService := NewSavedPostCollectionService(mockCollectionRepo, nil, nil)
+	name := "Mine now"
+
+	mockCollectionRepo.On("GetCollectionByID", uint64(5)).Return(&model.SavedPostCollection{ID: 5, UserID: 2, Name: "Theirs"}, nil)
+
+	result, err := collectionService.UpdateCollection(context.Background(), 1, 5, &request.UpdateSavedPostCollectionRequest{Name: &name})
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "permission denied")
+	mockCollectionRepo.AssertNotCalled(t, "UpdateCollection", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSavedPostCollectionService_ReorderCollections_RequiresEveryCollection(t *testing.T) {
+	mockCollectionRepo := new(MockSavedPostCollectionRepository)
+	collectionService := NewSavedPostCollectionService(mockCollectionRepo, nil, nil)
+
+	mockCollectionRepo.On("GetUserCollections", uint64(1)).Return([]*model.SavedPostCollection{{ID: 4}, {ID: 5}, {ID: 6}}, nil)
+
+	err := collectionService.ReorderCollections(context.Background(), 1, &request.ReorderSavedPostCollectionsRequest{CollectionIDs: []uint64{6, 4, 4}})
+	assert.EqualError(t, err, "invalid collection order")
+
+	mockCollectionRepo.On("ReorderCollections", uint64(1), []uint64{6, 4, 5}).Return(nil)
+	err = collectionService.ReorderCollections(context.Background(), 1, &request.ReorderSavedPostCollectionsRequest{CollectionIDs: []uint64{6, 4, 5}})
+	assert.NoError(t, err)
+	mockCollectionRepo.AssertExpectations(t)
+}
+
+func TestSavedPostCollectionService_AddPostToCollection_SavesPostFirst(t *testing.T) {
+	mockCollectionRepo := new(MockSavedPostCollectionRepository)
+	mockUserSavedPostRepo := new(MockUserSavedPostRepository)
+	mockPostRepo := new(MockPostRepository)
+	collectionService := NewSavedPostCollectionService(mockCollectionRepo, mockUserSavedPostRepo, mockPostRepo)
+
+	mockCollectionRepo.On("GetCollectionByID", uint64(5)).Return(&model.SavedPostCollection{ID: 5, UserID: 1}, nil)
+	mockUserSavedPostRepo.On("CheckUserSavedPostExists", uint64(1), uint64(10)).Return(false, nil)
+	mockPostRepo.On("GetPostByID", uint64(10)).Return(&model.Post{ID: 10, Status: constant.POST_STATUS_APPROVED}, nil)
+	mockUserSavedPostRepo.On("CreateUserSavedPost", uint64(1), &request.UserSavedPostRequest{PostID: 10}).Return(nil)
+	mockCollectionRepo.On("AddCollectionItem", &model.SavedPostCollectionItem{CollectionID: 5, PostID: 10, UserID: 1}).Return(nil)
+
+	err := collectionService.AddPostToCollection(context.Background(), 1, 5, &request.AddPostToCollectionRequest{PostID: 10})
+
+	assert.NoError(t, err)
+	mockUserSavedPostRepo.AssertExpectations(t)
+	mockCollectionRepo.AssertExpectations(t)
+}
+
+func TestSavedPostCollectionService_AddPostToCollection_PostNotFound(t *testing.T) {
+	mockCollectionRepo := new(MockSavedPostCollectionRepository)
+	mockUserSavedPostRepo := new(MockUserSavedPostRepository)
+	mockPostRepo := new(MockPostRepository)
+	collectionService := NewSavedPostCollectionService(mockCollectionRepo, mockUserSavedPostRepo, mockPostRepo)
+
+	mockCollectionRepo.On("GetCollectionByID", uint64(5)).Return(&model.SavedPostCollection{ID: 5, UserID: 1}, nil)
+	mockUserSavedPostRepo.On("CheckUserSavedPostExists", uint64(1), uint64(10)).Return(false, nil)
+	mockPostRepo.On("GetPostByID", uint64(10)).Return(nil, errors.New("record not found"))
+
+	err := collectionService.AddPostToCollection(context.Background(), 1, 5, &request.AddPostToCollectionRequest{PostID: 10})
+
+	assert.EqualError(t, err, "post not found")
+	mockCollectionRepo.AssertNotCalled(t, "AddCollectionItem", mock.Anything)
+}
+
+func TestSavedPostCollectionService_UpdateSavedPostNote_EmptyClearsNote(t *testing.T) {
+	mockUserSavedPostRepo := new(MockUserSavedPostRepository)
+	collectionService := NewSavedPostCollectionService(nil, mockUserSavedPostRepo, nil)
+
+	mockUserSavedPostRepo.On("CheckUserSavedPostExists", uint64(1), uint64(10)).Return(true, nil)
+	mockUserSavedPostRepo.On("UpdateNote", uint64(1), uint64(10), (*string)(nil)).Return(nil)
+
+	err := collectionService.UpdateSavedPostNote(context.Background(), 1, 10, &request.UpdateSavedPostNoteRequest{Note: "   "})
+
+	assert.NoError(t, err)
+	mockUserSavedPostRepo.AssertExpectations(t)
+}
+
+func TestSavedPostCollectionService_GetSharedCollection_HidesNotes(t *testing.T) {
+	mockCollectionRepo := new(MockSavedPostCollectionRepository)
+	collectionService := NewSavedPostCollectionService(mockCollectionRepo, nil, nil)
+	note := "private thoughts"
+
+	mockCollectionRepo.On("GetCollectionByShareToken", "abc").Return(&model.SavedPostCollection{ID: 5, UserID: 1, Name: "Reading list", IsPublic: true, ItemCount: 1}, nil)
+	mockCollectionRepo.On("GetCollectionPosts", uint64(5), 1, 10).Return([]*model.UserSavedPost{
+		{UserID: 1, PostID: 10, PostTitle: "Hello", Note: &note, CollectionItems: []*model.SavedPostCollectionItem{{CollectionID: 5}, {CollectionID: 6}}},
+	}, int64(1), nil)
+
+	result, pagination, err := collectionService.GetSharedCollection(context.Background(), "abc", 1, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Reading list", result.Name)
+	assert.Len(t, result.Posts, 1)
+	assert.Nil(t, result.Posts[0].Note)
+	assert.Empty(t, result.Posts[0].CollectionIDs)
+	assert.Empty(t, pagination.NextURL)
+}
+
+func TestSavedPostCollectionService_GetSharedCollection_CountsVisiblePosts(t *testing.T) {
+	mockCollectionRepo := new(MockSavedPostCollectionRepository)
+	collectionService := NewSavedPostCollectionService(mockCollectionRepo, nil, nil)
+
+	// Two of the three saved posts are in private communities or no longer approved
+	mockCollectionRepo.On("GetCollectionByShareToken", "abc").Return(&model.SavedPostCollection{ID: 5, UserID: 1, Name: "Reading list", IsPublic: true, ItemCount: 3}, nil)
+	mockCollectionRepo.On("GetCollectionPosts", uint64(5), 1, 10).Return([]*model.UserSavedPost{
+		{UserID: 1, PostID: 10, PostTitle: "Hello"},
+	}, int64(1), nil)
+
+	result, _, err := collectionService.GetSharedCollection(context.Background(), "abc", 1, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.ItemCount)
+}
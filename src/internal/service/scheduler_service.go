@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"social-platform-backend/package/constant"
+	"social-platform-backend/package/logger"
+	"sync"
+	"time"
+)
+
+type scheduledJob struct {
+	name     string
+	interval time.Duration
+	run      func(ctx context.Context) error
+	// runOnStop runs the job one last time when the scheduler stops, for jobs that
+	// write out data buffered in memory
+	runOnStop bool
+}
+
+// SchedulerService runs periodic background jobs inside the API process
+type SchedulerService struct {
+	jobs []scheduledJob
+	wg   sync.WaitGroup
+}
+
+func NewSchedulerService(postService *PostService, mediaService *MediaService, postAnalyticsService *PostAnalyticsService, reactionService *ReactionService, savedPostCollectionService *SavedPostCollectionService, commentService *CommentService, moderatorInvitationService *ModeratorInvitationService) *SchedulerService {
+	return &SchedulerService{
+		jobs: []scheduledJob{
+			{
+				name:     "publish_scheduled_posts",
+				interval: constant.SCHEDULER_PUBLISH_POSTS_INTERVAL_SECONDS * time.Second,
+				run:      postService.PublishScheduledPosts,
+			},
+			{
+				name:     "finalize_polls",
+				interval: constant.SCHEDULER_FINALIZE_POLLS_INTERVAL_SECONDS * time.Second,
+				run:      postService.FinalizePolls,
+			},
+			{
+				name:     "cleanup_orphan_media",
+				interval: constant.SCHEDULER_CLEANUP_ORPHAN_MEDIA_INTERVAL_SECONDS * time.Second,
+				run:      mediaService.CleanupOrphanMedia,
+			},
+			{
+				name:      "flush_post_views",
+				interval:  constant.SCHEDULER_FLUSH_POST_VIEWS_INTERVAL_SECONDS * time.Second,
+				run:       postAnalyticsService.FlushPostViews,
+				runOnStop: true,
+			},
+			{
+				name:      "flush_reaction_notifications",
+				interval:  constant.SCHEDULER_FLUSH_REACTION_NOTIFICATIONS_INTERVAL_SECONDS * time.Second,
+				run:       reactionService.FlushReactionNotifications,
+				runOnStop: true,
+			},
+			{
+				name:     "refresh_saved_post_snapshots",
+				interval: constant.SCHEDULER_REFRESH_SAVED_POST_SNAPSHOTS_INTERVAL_SECONDS * time.Second,
+				run:      savedPostCollectionService.RefreshSavedPostSnapshots,
+			},
+			{
+				name:     "purge_comment_tombstones",
+				interval: constant.SCHEDULER_PURGE_COMMENT_TOMBSTONES_INTERVAL_SECONDS * time.Second,
+				run:      commentService.PurgeCommentTombstones,
+			},
+			{
+				name:     "expire_moderator_invitations",
+				interval: constant.SCHEDULER_EXPIRE_MODERATOR_INVITATIONS_INTERVAL_SECONDS * time.Second,
+				run:      moderatorInvitationService.ExpireInvitations,
+			},
+		},
+	}
+}
+
+// Start runs the jobs until ctx is cancelled
+func (s *SchedulerService) Start(ctx context.Context) {
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.runJob(ctx, job)
+	}
+}
+
+// Wait blocks until every job has stopped after the context passed to Start was
+// cancelled, including their last runs
+func (s *SchedulerService) Wait() {
+	s.wg.Wait()
+}
+
+func (s *SchedulerService) runJob(ctx context.Context, job scheduledJob) {
+	defer s.wg.Done()
+	ticker := time.NewTicker(job.interval)
+	defer ticker.Stop()
+
+	logger.InfofWithCtx(ctx, "[Info] Scheduler job %s started, interval %s", job.name, job.interval)
+	for {
+		select {
+		case <-ctx.Done():
+			if job.runOnStop {
+				stopCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), constant.SCHEDULER_STOP_TIMEOUT_SECONDS*time.Second)
+				s.runOnce(stopCtx, job)
+				cancel()
+			}
+			return
+		case <-ticker.C:
+			s.runOnce(ctx, job)
+		}
+	}
+}
+
+func (s *SchedulerService) runOnce(ctx context.Context, job scheduledJob) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.ErrorfWithCtx(ctx, "[Panic] Recovered in SchedulerService job %s: %v", job.name, r)
+		}
+	}()
+
+	if err := job.run(ctx); err != nil {
+		logger.ErrorfWithCtx(ctx, "[Err] Scheduler job %s failed: %v", job.name, err)
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"social-platform-backend/internal/domain/repository"
 	"social-platform-backend/internal/interface/dto/request"
 	"social-platform-backend/internal/interface/dto/response"
-	"social-platform-backend/package/constant"
 	"social-platform-backend/package/logger"
 	"social-platform-backend/package/util"
 )
@@ -182,8 +181,8 @@ func (s *UserService) GetUserBadgeHistory(ctx context.Context, userID uint64) ([
 	return badgeResponses, nil
 }
 
-func (s *UserService) GetUserSavedPosts(ctx context.Context, userID uint64, searchTitle string, isFollowed *bool, page, limit int) ([]*response.SavedPostResponse, *response.Pagination, error) {
-	savedPosts, total, err := s.userSavedPostRepo.GetUserSavedPosts(userID, searchTitle, isFollowed, page, limit)
+func (s *UserService) GetUserSavedPosts(ctx context.Context, userID uint64, searchTitle string, isFollowed *bool, collectionID *uint64, page, limit int) ([]*response.SavedPostResponse, *response.Pagination, error) {
+	savedPosts, total, err := s.userSavedPostRepo.GetUserSavedPosts(userID, searchTitle, isFollowed, collectionID, page, limit)
 	if err != nil {
 		logger.ErrorfWithCtx(ctx, "[Err] Error getting user saved posts in UserService.GetUserSavedPosts: %v", err)
 		return nil, nil, fmt.Errorf("failed to get saved posts")
@@ -208,6 +207,9 @@ func (s *UserService) GetUserSavedPosts(ctx context.Context, userID uint64, sear
 		if isFollowed != nil {
 			nextURL += fmt.Sprintf("&isFollowed=%t", *isFollowed)
 		}
+		if collectionID != nil {
+			nextURL += fmt.Sprintf("&collectionId=%d", *collectionID)
+		}
 		pagination.NextURL = nextURL
 	}
 
@@ -289,7 +291,7 @@ func (s *UserService) DeleteUserSavedPost(ctx context.Context, userID, postID ui
 }
 
 func (s *UserService) SearchUsers(ctx context.Context, searchTerm string, page, limit int) ([]*response.UserSearchResponse, *response.Pagination, error) {
-	users, total, err := s.userRepo.SearchUsers(searchTerm, page, limit)
+	users, total, err := s.userRepo.SearchUsers(searchTerm, nil, page, limit)
 	if err != nil {
 		logger.ErrorfWithCtx(ctx, "[Err] Error searching users in UserService.SearchUsers: %v", err)
 		return nil, nil, fmt.Errorf("failed to search users")
@@ -341,8 +343,9 @@ func (s *UserService) GetUserSuperAdminCommunities(ctx context.Context, userID u
 	return communityResponses, nil
 }
 
+// GetUserAdminCommunities lists the communities the user moderates, with any moderator role
 func (s *UserService) GetUserAdminCommunities(ctx context.Context, userID uint64) ([]*response.CommunityListResponse, error) {
-	communities, err := s.communityRepo.GetCommunitiesByModeratorID(userID, constant.ROLE_ADMIN)
+	communities, err := s.communityRepo.GetCommunitiesByModeratorID(userID, "")
 	if err != nil {
 		logger.ErrorfWithCtx(ctx, "[Err] Error getting communities by moderator in UserService.GetUserAdminCommunities: %v", err)
 		return nil, fmt.Errorf("failed to get admin communities")
@@ -433,3 +433,31 @@ func TestUserService_GetUserConfig_UserNotFound(t *testing.T) {
 	assert.Contains(t, err.Error(), "user not found")
 	mockUserRepo.AssertExpectations(t)
 }
+
+func TestUserService_GetUserAdminCommunities_AllModeratorRoles(t *testing.T) {
+	mockCommunityRepo := new(MockCommunityRepository)
+
+	userService := NewUserService(
+		nil,
+		mockCommunityRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	userID := uint64(1)
+	mockCommunityRepo.On("GetCommunitiesByModeratorID", userID, "").Return([]*model.Community{
+		{ID: 1, Name: "golang", MemberCount: 10},
+		{ID: 2, Name: "rust", MemberCount: 5},
+	}, nil)
+
+	communities, err := userService.GetUserAdminCommunities(context.Background(), userID)
+
+	assert.NoError(t, err)
+	assert.Len(t, communities, 2)
+	assert.Equal(t, int64(10), communities[0].TotalMembers)
+	mockCommunityRepo.AssertExpectations(t)
+}
@@ -7,23 +7,37 @@ import (
 	"social-platform-backend/config"
 	domainrepo "social-platform-backend/internal/domain/repository"
 	dbrepository "social-platform-backend/internal/infrastructure/db/repository"
+	"social-platform-backend/internal/infrastructure/storage"
 	"social-platform-backend/internal/interface/handler"
 	"social-platform-backend/internal/service"
+	"social-platform-backend/package/util"
 
 	"github.com/google/wire"
 	"gorm.io/gorm"
 )
 
 type AppHandler struct {
-	AuthHandler         *handler.AuthHandler
-	UserHandler         *handler.UserHandler
-	CommunityHandler    *handler.CommunityHandler
-	PostHandler         *handler.PostHandler
-	CommentHandler      *handler.CommentHandler
-	MessageHandler      *handler.MessageHandler
-	NotificationHandler *handler.NotificationHandler
-	SSEHandler          *handler.SSEHandler
-	ChatbotHandler      *handler.ChatbotHandler
+	AuthHandler                *handler.AuthHandler
+	UserHandler                *handler.UserHandler
+	CommunityHandler           *handler.CommunityHandler
+	PostHandler                *handler.PostHandler
+	CommentHandler             *handler.CommentHandler
+	MessageHandler             *handler.MessageHandler
+	NotificationHandler        *handler.NotificationHandler
+	SSEHandler                 *handler.SSEHandler
+	ChatbotHandler             *handler.ChatbotHandler
+	MediaHandler               *handler.MediaHandler
+	ReactionHandler            *handler.ReactionHandler
+	SavedPostCollectionHandler *handler.SavedPostCollectionHandler
+	MentionHandler             *handler.MentionHandler
+	ModerationQueueHandler     *handler.ModerationQueueHandler
+	ReportHandler              *handler.ReportHandler
+	AutoModHandler             *handler.AutoModHandler
+	CommunityRuleHandler       *handler.CommunityRuleHandler
+	CommunityRoleHandler       *handler.CommunityRoleHandler
+	ModeratorInvitationHandler *handler.ModeratorInvitationHandler
+
+	Scheduler *service.SchedulerService
 
 	// Repos dùng trực tiếp trong route middleware
 	UserRestrictionRepo domainrepo.UserRestrictionRepository
@@ -57,10 +71,38 @@ var RepositorySet = wire.NewSet(
 	dbrepository.NewUserTagPreferenceRepository,
 	dbrepository.NewTagRepository,
 	dbrepository.NewTopicRepository,
+	dbrepository.NewPostRevisionRepository,
+	dbrepository.NewCommentRevisionRepository,
+	dbrepository.NewModerationLogRepository,
+	dbrepository.NewPollRepository,
+	dbrepository.NewLinkPreviewRepository,
+	dbrepository.NewMediaRepository,
+	dbrepository.NewPostAnalyticsRepository,
+	dbrepository.NewPostFingerprintRepository,
+	dbrepository.NewReactionRepository,
+	dbrepository.NewSavedPostCollectionRepository,
+	dbrepository.NewMentionRepository,
+	dbrepository.NewContentFlagRepository,
+	dbrepository.NewModerationQueueRepository,
+	dbrepository.NewReportRepository,
+	dbrepository.NewAutoModRuleRepository,
+	dbrepository.NewCommunityRuleRepository,
+	dbrepository.NewCommunityRoleRepository,
+	dbrepository.NewModeratorInvitationRepository,
 )
 
 var ServiceSet = wire.NewSet(
 	service.NewAIServiceClient,
+	util.NewContentSanitizer,
+	service.NewLinkPreviewFetcher,
+	service.NewLinkPreviewService,
+	storage.NewStorage,
+	service.NewMediaService,
+	service.NewPostAnalyticsService,
+	service.NewDuplicateDetectionService,
+	service.NewReactionService,
+	service.NewSavedPostCollectionService,
+	service.NewMentionService,
 	service.NewSSEService,
 	service.NewBotTaskService,
 	service.NewRecommendationService,
@@ -71,7 +113,14 @@ var ServiceSet = wire.NewSet(
 	service.NewPostService,
 	service.NewCommentService,
 	service.NewCommunityService,
+	service.NewModerationQueueService,
+	service.NewReportService,
+	service.NewAutoModService,
+	service.NewCommunityRuleService,
+	service.NewCommunityRoleService,
+	service.NewModeratorInvitationService,
 	service.NewChatbotService,
+	service.NewSchedulerService,
 )
 
 var HandlerSet = wire.NewSet(
@@ -84,6 +133,16 @@ var HandlerSet = wire.NewSet(
 	handler.NewNotificationHandler,
 	handler.NewSSEHandler,
 	handler.NewChatbotHandler,
+	handler.NewMediaHandler,
+	handler.NewReactionHandler,
+	handler.NewSavedPostCollectionHandler,
+	handler.NewMentionHandler,
+	handler.NewModerationQueueHandler,
+	handler.NewReportHandler,
+	handler.NewAutoModHandler,
+	handler.NewCommunityRuleHandler,
+	handler.NewCommunityRoleHandler,
+	handler.NewModeratorInvitationHandler,
 )
 
 var ProviderSet = wire.NewSet(
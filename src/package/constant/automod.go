@@ -0,0 +1,30 @@
+package constant
+
+// When an AutoModerator rule is evaluated
+const (
+	AUTOMOD_TRIGGER_POST_CREATED     = "post_created"
+	AUTOMOD_TRIGGER_COMMENT_CREATED  = "comment_created"
+	AUTOMOD_TRIGGER_POST_REPORTED    = "post_reported"
+	AUTOMOD_TRIGGER_COMMENT_REPORTED = "comment_reported"
+)
+
+const (
+	// Sent to the moderation queue, posts also wait for approval
+	AUTOMOD_ACTION_HOLD = "hold"
+	// Posts are rejected and comments deleted, a removed post can still be approved
+	// from the moderation queue
+	AUTOMOD_ACTION_REMOVE            = "remove"
+	AUTOMOD_ACTION_SET_FLAIR         = "set_flair"
+	AUTOMOD_ACTION_COMMENT           = "comment"
+	AUTOMOD_ACTION_NOTIFY_MODERATORS = "notify_moderators"
+)
+
+const (
+	AUTOMOD_MAX_RULES_PER_COMMUNITY = 50
+	AUTOMOD_MAX_ACTIONS_PER_RULE    = 5
+	AUTOMOD_MAX_REGEX_LENGTH        = 500
+	AUTOMOD_MAX_FLAIR_LENGTH        = 64
+	AUTOMOD_MAX_COMMENT_LENGTH      = 2000
+	AUTOMOD_DEFAULT_DRY_RUN_LIMIT   = 50
+	AUTOMOD_MAX_DRY_RUN_LIMIT       = 200
+)
@@ -0,0 +1,18 @@
+package constant
+
+// Who deleted a comment that was kept as a tombstone
+const (
+	COMMENT_DELETED_BY_AUTHOR    = "author"
+	COMMENT_DELETED_BY_MODERATOR = "moderator"
+)
+
+// Shown in place of the content of a tombstone
+const (
+	COMMENT_DELETED_PLACEHOLDER = "[deleted]"
+	COMMENT_REMOVED_PLACEHOLDER = "[removed by moderator]"
+)
+
+const (
+	// Tombstones left without replies are purged once they are this old
+	COMMENT_TOMBSTONE_RETENTION_HOURS = 24 * 7
+)
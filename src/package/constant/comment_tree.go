@@ -0,0 +1,15 @@
+package constant
+
+const (
+	// Reply levels loaded below each top-level comment
+	COMMENT_TREE_DEFAULT_DEPTH = 5
+	COMMENT_TREE_MAX_DEPTH     = 10
+
+	// Replies loaded under each comment before it is truncated with a continuation
+	COMMENT_TREE_DEFAULT_REPLIES = 10
+	COMMENT_TREE_MAX_REPLIES     = 50
+
+	// Ancestors shown above a comment opened from a permalink
+	COMMENT_PERMALINK_DEFAULT_CONTEXT = 3
+	COMMENT_PERMALINK_MAX_CONTEXT     = 10
+)
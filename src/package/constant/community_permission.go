@@ -0,0 +1,42 @@
+package constant
+
+// What a community moderator can be allowed to do
+const (
+	COMMUNITY_PERMISSION_MANAGE_POSTS    = "manage_posts"
+	COMMUNITY_PERMISSION_MANAGE_COMMENTS = "manage_comments"
+	COMMUNITY_PERMISSION_MANAGE_MEMBERS  = "manage_members"
+	COMMUNITY_PERMISSION_BAN_USERS       = "ban_users"
+	COMMUNITY_PERMISSION_EDIT_SETTINGS   = "edit_settings"
+	COMMUNITY_PERMISSION_MANAGE_RULES    = "manage_rules"
+	COMMUNITY_PERMISSION_VIEW_REPORTS    = "view_reports"
+	COMMUNITY_PERMISSION_MANAGE_ROLES    = "manage_roles"
+)
+
+var COMMUNITY_PERMISSIONS = []string{
+	COMMUNITY_PERMISSION_MANAGE_POSTS,
+	COMMUNITY_PERMISSION_MANAGE_COMMENTS,
+	COMMUNITY_PERMISSION_MANAGE_MEMBERS,
+	COMMUNITY_PERMISSION_BAN_USERS,
+	COMMUNITY_PERMISSION_EDIT_SETTINGS,
+	COMMUNITY_PERMISSION_MANAGE_RULES,
+	COMMUNITY_PERMISSION_VIEW_REPORTS,
+	COMMUNITY_PERMISSION_MANAGE_ROLES,
+}
+
+// Permissions of the built-in moderator roles. Moderators with ROLE_MODERATOR get the
+// permissions of their community's custom role instead.
+var COMMUNITY_ROLE_PERMISSIONS = map[string][]string{
+	ROLE_SUPER_ADMIN: COMMUNITY_PERMISSIONS,
+	ROLE_ADMIN: {
+		COMMUNITY_PERMISSION_MANAGE_POSTS,
+		COMMUNITY_PERMISSION_MANAGE_COMMENTS,
+		COMMUNITY_PERMISSION_MANAGE_MEMBERS,
+		COMMUNITY_PERMISSION_BAN_USERS,
+		COMMUNITY_PERMISSION_MANAGE_RULES,
+		COMMUNITY_PERMISSION_VIEW_REPORTS,
+	},
+}
+
+const (
+	COMMUNITY_ROLE_MAX_PER_COMMUNITY = 20
+)
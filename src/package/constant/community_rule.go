@@ -0,0 +1,15 @@
+package constant
+
+// What a community rule can be cited for, in reports and removals
+const (
+	COMMUNITY_RULE_APPLIES_TO_POSTS    = "posts"
+	COMMUNITY_RULE_APPLIES_TO_COMMENTS = "comments"
+	COMMUNITY_RULE_APPLIES_TO_ALL      = "all"
+)
+
+const (
+	COMMUNITY_RULE_MAX_PER_COMMUNITY  = 15
+	COMMUNITY_RULE_MAX_PER_REPORT     = 5
+	COMMUNITY_RULE_DEFAULT_STATS_DAYS = 30
+	COMMUNITY_RULE_MAX_STATS_DAYS     = 365
+)
@@ -0,0 +1,28 @@
+package constant
+
+const (
+	CONTENT_FORMAT_HTML     = "html"
+	CONTENT_FORMAT_MARKDOWN = "markdown"
+)
+
+// Revision diffs with more changed old x new lines than this show the text as replaced
+// as a whole, the line diff needs memory for every pair
+const DIFF_MAX_LINE_PAIRS = 1_000_000
+
+// Used when the content config does not define an allowlist
+var DEFAULT_ALLOWED_HTML_TAGS = []string{
+	"p", "br", "hr", "div", "span",
+	"strong", "b", "em", "i", "u", "s", "del", "sub", "sup", "mark",
+	"h1", "h2", "h3", "h4", "h5", "h6",
+	"ul", "ol", "li", "blockquote", "pre", "code",
+	"a", "img",
+	"table", "thead", "tbody", "tr", "th", "td",
+}
+
+var DEFAULT_ALLOWED_HTML_ATTRIBUTES = map[string][]string{
+	"a":   {"href", "title"},
+	"img": {"src", "alt", "title", "width", "height"},
+	"th":  {"colspan", "rowspan"},
+	"td":  {"colspan", "rowspan"},
+	"ol":  {"start"},
+}
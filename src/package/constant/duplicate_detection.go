@@ -0,0 +1,28 @@
+package constant
+
+const (
+	// Published, but shown to moderators together with the posts it matches
+	DUPLICATE_ACTION_FLAG = "flag"
+	// Sent to the approval queue
+	DUPLICATE_ACTION_HOLD   = "hold"
+	DUPLICATE_ACTION_REJECT = "reject"
+	DUPLICATE_ACTION_NONE   = "none"
+)
+
+const (
+	DUPLICATE_MATCH_TEXT = "text"
+	DUPLICATE_MATCH_URL  = "url"
+)
+
+const (
+	DEFAULT_DUPLICATE_MIN_SIMILARITY = 0.7
+	// Shorter texts ("thanks", "hello world") are only compared by link
+	DEFAULT_DUPLICATE_MIN_WORDS           = 8
+	DEFAULT_DUPLICATE_AUTHOR_WINDOW_HOURS = 72
+	DEFAULT_DUPLICATE_GLOBAL_WINDOW_HOURS = 24
+	DEFAULT_DUPLICATE_AUTHOR_ACTION       = DUPLICATE_ACTION_HOLD
+	DEFAULT_DUPLICATE_GLOBAL_ACTION       = DUPLICATE_ACTION_FLAG
+	DUPLICATE_MAX_CANDIDATES              = 200
+	// Matches recorded for one post, most similar first
+	DUPLICATE_MAX_RECORDED_MATCHES = 10
+)
@@ -0,0 +1,20 @@
+package constant
+
+const (
+	LINK_PREVIEW_STATUS_OK     = "ok"
+	LINK_PREVIEW_STATUS_FAILED = "failed"
+)
+
+const (
+	LINK_PREVIEW_FETCH_TIMEOUT_SECONDS = 5
+	// Only the document head is needed, the rest of the page is not read
+	LINK_PREVIEW_MAX_BODY_BYTES = 512 * 1024
+	LINK_PREVIEW_MAX_REDIRECTS  = 3
+	// How long a preview is served before the page is fetched again
+	LINK_PREVIEW_CACHE_TTL_HOURS = 24 * 7
+	// Failed fetches are retried sooner
+	LINK_PREVIEW_FAILED_RETRY_MINUTES = 60
+
+	LINK_PREVIEW_MAX_TITLE_LENGTH       = 300
+	LINK_PREVIEW_MAX_DESCRIPTION_LENGTH = 1000
+)
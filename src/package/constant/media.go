@@ -0,0 +1,59 @@
+package constant
+
+const (
+	MEDIA_USE_CASE_POST       = "post"
+	MEDIA_USE_CASE_COMMENT    = "comment"
+	MEDIA_USE_CASE_AVATAR     = "avatar"
+	MEDIA_USE_CASE_COVER      = "cover"
+	MEDIA_USE_CASE_ATTACHMENT = "attachment"
+)
+
+const (
+	MEDIA_VARIANT_THUMBNAIL = "thumbnail"
+	MEDIA_VARIANT_MEDIUM    = "medium"
+)
+
+const (
+	MEDIA_VARIANT_THUMBNAIL_SIZE = 320
+	MEDIA_VARIANT_MEDIUM_SIZE    = 1280
+	MEDIA_JPEG_QUALITY           = 85
+
+	// Images are decoded in memory, so their dimensions are capped before decoding
+	MEDIA_MAX_IMAGE_PIXELS = 50_000_000
+	// Upper bound for any upload request, the per use-case limits are lower
+	MEDIA_MAX_REQUEST_BYTES = 55 << 20
+
+	// Uploads not referenced by any post, comment, message or profile after this long are deleted
+	MEDIA_ORPHAN_GRACE_HOURS = 24
+)
+
+var MEDIA_IMAGE_MIME_TYPES = []string{"image/jpeg", "image/png", "image/gif"}
+
+var MEDIA_VIDEO_MIME_TYPES = []string{"video/mp4", "video/webm"}
+
+// Allowed sniffed MIME types per use-case
+var MEDIA_ALLOWED_MIME_TYPES = map[string][]string{
+	MEDIA_USE_CASE_POST:       append(append([]string{}, MEDIA_IMAGE_MIME_TYPES...), MEDIA_VIDEO_MIME_TYPES...),
+	MEDIA_USE_CASE_COMMENT:    MEDIA_IMAGE_MIME_TYPES,
+	MEDIA_USE_CASE_AVATAR:     MEDIA_IMAGE_MIME_TYPES,
+	MEDIA_USE_CASE_COVER:      MEDIA_IMAGE_MIME_TYPES,
+	MEDIA_USE_CASE_ATTACHMENT: append(append([]string{"application/pdf"}, MEDIA_IMAGE_MIME_TYPES...), MEDIA_VIDEO_MIME_TYPES...),
+}
+
+// Maximum upload size in bytes per use-case
+var MEDIA_MAX_SIZE_BYTES = map[string]int64{
+	MEDIA_USE_CASE_POST:       50 << 20,
+	MEDIA_USE_CASE_COMMENT:    10 << 20,
+	MEDIA_USE_CASE_AVATAR:     5 << 20,
+	MEDIA_USE_CASE_COVER:      10 << 20,
+	MEDIA_USE_CASE_ATTACHMENT: 25 << 20,
+}
+
+var MEDIA_FILE_EXTENSIONS = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/gif":       ".gif",
+	"video/mp4":       ".mp4",
+	"video/webm":      ".webm",
+	"application/pdf": ".pdf",
+}
@@ -0,0 +1,14 @@
+package constant
+
+const (
+	MENTION_TARGET_POST    = "post"
+	MENTION_TARGET_COMMENT = "comment"
+	MENTION_TARGET_MESSAGE = "message"
+)
+
+const (
+	// Only the first mentions of a post, comment or message are resolved, so a
+	// single piece of content cannot be used to ping a large number of users
+	MAX_MENTIONS_PER_CONTENT = 20
+	MAX_MENTION_SUGGESTIONS  = 10
+)
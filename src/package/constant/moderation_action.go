@@ -0,0 +1,94 @@
+package constant
+
+const (
+	MODERATION_ACTION_LOCK_POST      = "lock_post"
+	MODERATION_ACTION_UNLOCK_POST    = "unlock_post"
+	MODERATION_ACTION_LOCK_COMMENT   = "lock_comment"
+	MODERATION_ACTION_UNLOCK_COMMENT = "unlock_comment"
+	MODERATION_ACTION_CLOSE_POLL     = "close_poll"
+)
+
+// Actions taken from the moderation queue
+const (
+	MODERATION_ACTION_APPROVE_POST    = "approve_post"
+	MODERATION_ACTION_REMOVE_POST     = "remove_post"
+	MODERATION_ACTION_APPROVE_COMMENT = "approve_comment"
+	MODERATION_ACTION_REMOVE_COMMENT  = "remove_comment"
+	MODERATION_ACTION_IGNORE_REPORTS  = "ignore_reports"
+	MODERATION_ACTION_BAN_USER        = "ban_user"
+)
+
+// Report lifecycle actions
+const (
+	MODERATION_ACTION_DISMISS_REPORTS  = "dismiss_reports"
+	MODERATION_ACTION_ESCALATE_REPORTS = "escalate_reports"
+)
+
+// Community management actions
+const (
+	MODERATION_ACTION_REJECT_POST        = "reject_post"
+	MODERATION_ACTION_UPDATE_POST_STATUS = "update_post_status"
+	MODERATION_ACTION_WARN_USER          = "warn_user"
+	MODERATION_ACTION_REMOVE_RESTRICTION = "remove_restriction"
+	MODERATION_ACTION_REMOVE_MEMBER      = "remove_member"
+	MODERATION_ACTION_APPROVE_MEMBER     = "approve_member"
+	MODERATION_ACTION_REJECT_MEMBER      = "reject_member"
+	MODERATION_ACTION_ADD_MODERATOR      = "add_moderator"
+	MODERATION_ACTION_REMOVE_MODERATOR   = "remove_moderator"
+	MODERATION_ACTION_UPDATE_SETTINGS    = "update_settings"
+)
+
+// AutoModerator rule changes
+const (
+	MODERATION_ACTION_CREATE_AUTOMOD_RULE = "create_automod_rule"
+	MODERATION_ACTION_UPDATE_AUTOMOD_RULE = "update_automod_rule"
+	MODERATION_ACTION_DELETE_AUTOMOD_RULE = "delete_automod_rule"
+)
+
+// Community rule changes
+const (
+	MODERATION_ACTION_CREATE_COMMUNITY_RULE   = "create_community_rule"
+	MODERATION_ACTION_UPDATE_COMMUNITY_RULE   = "update_community_rule"
+	MODERATION_ACTION_DELETE_COMMUNITY_RULE   = "delete_community_rule"
+	MODERATION_ACTION_REORDER_COMMUNITY_RULES = "reorder_community_rules"
+)
+
+// Custom moderator role changes
+const (
+	MODERATION_ACTION_CREATE_COMMUNITY_ROLE = "create_community_role"
+	MODERATION_ACTION_UPDATE_COMMUNITY_ROLE = "update_community_role"
+	MODERATION_ACTION_DELETE_COMMUNITY_ROLE = "delete_community_role"
+)
+
+// Moderator invitations and ownership transfers
+const (
+	MODERATION_ACTION_INVITE_MODERATOR   = "invite_moderator"
+	MODERATION_ACTION_CANCEL_INVITATION  = "cancel_moderator_invitation"
+	MODERATION_ACTION_OFFER_OWNERSHIP    = "offer_ownership"
+	MODERATION_ACTION_TRANSFER_OWNERSHIP = "transfer_ownership"
+)
+
+const (
+	MODERATION_TARGET_POST    = "post"
+	MODERATION_TARGET_COMMENT = "comment"
+	MODERATION_TARGET_USER    = "user"
+)
+
+const (
+	MODERATION_TARGET_COMMUNITY      = "community"
+	MODERATION_TARGET_AUTOMOD_RULE   = "automod_rule"
+	MODERATION_TARGET_COMMUNITY_RULE = "community_rule"
+	MODERATION_TARGET_COMMUNITY_ROLE = "community_role"
+)
+
+// Report handling stays out of the members' view of the mod log, it would tell them
+// what was reported and by how many. AutoModerator rule changes would tell them what
+// the filters look for.
+var MOD_LOG_MEMBER_HIDDEN_ACTIONS = []string{
+	MODERATION_ACTION_IGNORE_REPORTS,
+	MODERATION_ACTION_DISMISS_REPORTS,
+	MODERATION_ACTION_ESCALATE_REPORTS,
+	MODERATION_ACTION_CREATE_AUTOMOD_RULE,
+	MODERATION_ACTION_UPDATE_AUTOMOD_RULE,
+	MODERATION_ACTION_DELETE_AUTOMOD_RULE,
+}
@@ -0,0 +1,24 @@
+package constant
+
+// Why content is waiting in the moderation queue
+const (
+	MODERATION_QUEUE_PENDING  = "pending"
+	MODERATION_QUEUE_FLAGGED  = "flagged"
+	MODERATION_QUEUE_REPORTED = "reported"
+)
+
+// Actions a moderator can take on a queue item, each one resolves its reports and flags
+const (
+	QUEUE_ACTION_APPROVE        = "approve"
+	QUEUE_ACTION_REMOVE         = "remove"
+	QUEUE_ACTION_IGNORE_REPORTS = "ignore_reports"
+	QUEUE_ACTION_BAN_AUTHOR     = "ban_author"
+	QUEUE_ACTION_LOCK           = "lock"
+)
+
+// Where a content flag comes from
+const (
+	CONTENT_FLAG_SOURCE_AI        = "ai"
+	CONTENT_FLAG_SOURCE_DUPLICATE = "duplicate"
+	CONTENT_FLAG_SOURCE_AUTOMOD   = "automod"
+)
@@ -0,0 +1,20 @@
+package constant
+
+// A moderator invitation offers a moderator role, an ownership transfer offers the
+// super admin role of the inviter
+const (
+	MODERATOR_INVITATION_TYPE_MODERATOR = "moderator"
+	MODERATOR_INVITATION_TYPE_OWNERSHIP = "ownership"
+)
+
+const (
+	MODERATOR_INVITATION_STATUS_PENDING   = "pending"
+	MODERATOR_INVITATION_STATUS_ACCEPTED  = "accepted"
+	MODERATOR_INVITATION_STATUS_DECLINED  = "declined"
+	MODERATOR_INVITATION_STATUS_CANCELLED = "cancelled"
+	MODERATOR_INVITATION_STATUS_EXPIRED   = "expired"
+)
+
+const (
+	MODERATOR_INVITATION_EXPIRY_DAYS = 7
+)
@@ -0,0 +1,6 @@
+package constant
+
+const (
+	// Number of users notified per query when one event notifies many users
+	NOTIFICATION_BATCH_SIZE = 500
+)
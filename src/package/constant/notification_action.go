@@ -12,6 +12,14 @@ const (
 	NOTIFICATION_ACTION_USER_BANNED                 = "user_banned"
 	NOTIFICATION_ACTION_CONTENT_VIOLATION_POST      = "content_violation_post"
 	NOTIFICATION_ACTION_CONTENT_VIOLATION_COMMENT   = "content_violation_comment"
+	NOTIFICATION_ACTION_COMMUNITY_ANNOUNCEMENT      = "community_announcement"
+	NOTIFICATION_ACTION_POLL_CLOSED                 = "poll_closed"
+	NOTIFICATION_ACTION_GET_REACTION                = "get_reaction"
+	NOTIFICATION_ACTION_MENTIONED                   = "mentioned"
+	NOTIFICATION_ACTION_REPORT_ACTIONED             = "report_actioned"
+	NOTIFICATION_ACTION_AUTOMOD_TRIGGERED           = "automod_triggered"
+	NOTIFICATION_ACTION_MODERATOR_INVITATION        = "moderator_invitation"
+	NOTIFICATION_ACTION_MODERATOR_INVITATION_REPLY  = "moderator_invitation_reply"
 )
 
 var EmailSubjectMap = map[string]string{
@@ -26,4 +34,12 @@ var EmailSubjectMap = map[string]string{
 	NOTIFICATION_ACTION_USER_BANNED:                 "User Restriction Notification",
 	NOTIFICATION_ACTION_CONTENT_VIOLATION_POST:      "Content Violation - Post",
 	NOTIFICATION_ACTION_CONTENT_VIOLATION_COMMENT:   "Content Violation - Comment",
+	NOTIFICATION_ACTION_COMMUNITY_ANNOUNCEMENT:      "New Community Announcement",
+	NOTIFICATION_ACTION_POLL_CLOSED:                 "Poll Results Are In",
+	NOTIFICATION_ACTION_GET_REACTION:                "New Reactions",
+	NOTIFICATION_ACTION_MENTIONED:                   "You Were Mentioned",
+	NOTIFICATION_ACTION_REPORT_ACTIONED:             "Your Report Was Actioned",
+	NOTIFICATION_ACTION_AUTOMOD_TRIGGERED:           "AutoModerator Rule Triggered",
+	NOTIFICATION_ACTION_MODERATOR_INVITATION:        "Community Moderator Invitation",
+	NOTIFICATION_ACTION_MODERATOR_INVITATION_REPLY:  "Moderator Invitation Answered",
 }
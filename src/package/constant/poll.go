@@ -0,0 +1,11 @@
+package constant
+
+const (
+	POLL_OPTION_STATUS_APPROVED = "approved"
+	// Write-in options waiting for the poll author
+	POLL_OPTION_STATUS_PENDING = "pending"
+
+	// Maximum number of write-in options a user can have waiting for approval per poll
+	MAX_PENDING_WRITE_INS_PER_USER = 3
+	MAX_POLL_OPTIONS               = 20
+)
@@ -0,0 +1,16 @@
+package constant
+
+const (
+	// A viewer is counted once per post within this window
+	POST_VIEW_DEDUP_WINDOW_MINUTES = 30
+	// Upper bound on remembered viewers, views of new viewers are dropped once it is reached
+	POST_VIEW_MAX_TRACKED_VIEWERS = 200000
+)
+
+const (
+	POST_ANALYTICS_INTERVAL_HOUR = "hour"
+	POST_ANALYTICS_INTERVAL_DAY  = "day"
+
+	POST_ANALYTICS_DEFAULT_DAYS = 7
+	POST_ANALYTICS_MAX_DAYS     = 90
+)
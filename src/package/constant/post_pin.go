@@ -0,0 +1,6 @@
+package constant
+
+const (
+	// Maximum number of posts a community can keep pinned at the top of its feed
+	MAX_PINNED_POSTS_PER_COMMUNITY = 3
+)
@@ -1,7 +1,12 @@
 package constant
 
 const (
-	POST_STATUS_PENDING  = "pending"
-	POST_STATUS_APPROVED = "approved"
-	POST_STATUS_REJECTED = "rejected"
+	POST_STATUS_PENDING   = "pending"
+	POST_STATUS_APPROVED  = "approved"
+	POST_STATUS_REJECTED  = "rejected"
+	POST_STATUS_DRAFT     = "draft"
+	POST_STATUS_SCHEDULED = "scheduled"
 )
+
+// Posts in these statuses are not published yet and only visible to their author
+var UNPUBLISHED_POST_STATUSES = []string{POST_STATUS_DRAFT, POST_STATUS_SCHEDULED}
@@ -0,0 +1,19 @@
+package constant
+
+const (
+	REACTION_TARGET_POST    = "post"
+	REACTION_TARGET_COMMENT = "comment"
+	REACTION_TARGET_MESSAGE = "message"
+)
+
+// Used when neither the reaction config nor the community define an emoji set
+var DEFAULT_REACTION_EMOJIS = []string{"👍", "❤️", "😂", "😮", "😢", "🎉"}
+
+const (
+	MAX_COMMUNITY_REACTION_EMOJIS = 20
+	MAX_REACTION_EMOJI_BYTES      = 32
+
+	// Reactions to the same post or comment are collected for this long and sent
+	// to the author as a single notification
+	REACTION_NOTIFICATION_DELAY_SECONDS = 5 * 60
+)
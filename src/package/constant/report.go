@@ -0,0 +1,18 @@
+package constant
+
+// Reports of the same post or comment share a status
+const (
+	REPORT_STATUS_OPEN      = "open"
+	REPORT_STATUS_ACTIONED  = "actioned"
+	REPORT_STATUS_DISMISSED = "dismissed"
+)
+
+// Resolution of escalations, and of their reports, decided by the platform admins
+const REPORT_RESOLUTION_ADMIN_DECISION = "admin_decision"
+
+// Users whose recent reports are mostly dismissed cannot report for a while
+const (
+	REPORT_ABUSE_WINDOW_DAYS         = 30
+	REPORT_ABUSE_MIN_RESOLVED        = 10
+	REPORT_ABUSE_MAX_DISMISSED_RATIO = 0.8
+)
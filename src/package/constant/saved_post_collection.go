@@ -0,0 +1,6 @@
+package constant
+
+const (
+	MAX_SAVED_POST_COLLECTIONS        = 100
+	SAVED_POST_COLLECTION_TOKEN_BYTES = 16
+)
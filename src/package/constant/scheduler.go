@@ -0,0 +1,46 @@
+package constant
+
+const (
+	// Scheduled post publisher
+	SCHEDULER_PUBLISH_POSTS_INTERVAL_SECONDS = 30
+	SCHEDULER_PUBLISH_POSTS_BATCH_SIZE       = 100
+
+	// Finalizes closed and expired polls and notifies their voters
+	SCHEDULER_FINALIZE_POLLS_INTERVAL_SECONDS = 60
+	SCHEDULER_FINALIZE_POLLS_BATCH_SIZE       = 100
+
+	// Deletes uploads that were never attached to anything
+	SCHEDULER_CLEANUP_ORPHAN_MEDIA_INTERVAL_SECONDS = 60 * 60
+	SCHEDULER_CLEANUP_ORPHAN_MEDIA_BATCH_SIZE       = 100
+)
+
+const (
+	// Time left to the last runs of the jobs when the scheduler stops
+	SCHEDULER_STOP_TIMEOUT_SECONDS = 10
+)
+
+const (
+	// Writes buffered post view counts to post_view_stats, also when the server stops
+	SCHEDULER_FLUSH_POST_VIEWS_INTERVAL_SECONDS = 60
+)
+
+const (
+	// Sends the reaction notifications collected since the last run
+	SCHEDULER_FLUSH_REACTION_NOTIFICATIONS_INTERVAL_SECONDS = 60
+)
+
+const (
+	// Copies edited titles into saved post snapshots and flags deleted posts
+	SCHEDULER_REFRESH_SAVED_POST_SNAPSHOTS_INTERVAL_SECONDS = 15 * 60
+	SCHEDULER_REFRESH_SAVED_POST_SNAPSHOTS_BATCH_SIZE       = 500
+)
+
+const (
+	// Purges comment tombstones that no longer have replies
+	SCHEDULER_PURGE_COMMENT_TOMBSTONES_INTERVAL_SECONDS = 60 * 60
+)
+
+const (
+	// Expires moderator invitations and ownership transfers nobody answered in time
+	SCHEDULER_EXPIRE_MODERATOR_INVITATIONS_INTERVAL_SECONDS = 60 * 60
+)
@@ -4,4 +4,5 @@ const (
 	ROLE_USER        = "user"
 	ROLE_ADMIN       = "admin"
 	ROLE_SUPER_ADMIN = "super_admin"
+	ROLE_MODERATOR   = "moderator" // moderator with a custom community role
 )
@@ -0,0 +1,35 @@
+package payload
+
+// AutoModConditions all have to match for a rule to fire, conditions left empty are
+// ignored. Title, tags and post types only match posts.
+type AutoModConditions struct {
+	AuthorKarmaBelow    *uint64 `json:"authorKarmaBelow,omitempty"`
+	AccountAgeDaysBelow *int    `json:"accountAgeDaysBelow,omitempty"`
+	// Regular expressions, (?i) makes them case-insensitive
+	TitleRegex   string `json:"titleRegex,omitempty"`
+	ContentRegex string `json:"contentRegex,omitempty"`
+	// Matches links to any of the domains or their subdomains
+	Domains   []string `json:"domains,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+	PostTypes []string `json:"postTypes,omitempty"`
+	// Open reports, a rule fires once when the content reaches this many reports
+	MinReports int `json:"minReports,omitempty"`
+}
+
+// AutoModAction is one thing a rule does when it fires. Flair is used by set_flair,
+// Message is the text of comment and the note sent by notify_moderators.
+type AutoModAction struct {
+	Type    string `json:"type"`
+	Flair   string `json:"flair,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type AutoModTriggeredNotificationPayload struct {
+	CommunityID   uint64  `json:"communityId"`
+	CommunityName string  `json:"communityName"`
+	RuleID        uint64  `json:"ruleId"`
+	RuleName      string  `json:"ruleName"`
+	PostID        uint64  `json:"postId"`
+	CommentID     *uint64 `json:"commentId,omitempty"`
+	Message       string  `json:"message,omitempty"`
+}
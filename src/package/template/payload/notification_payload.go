@@ -41,9 +41,19 @@ type UserBanNotificationPayload struct {
 	ExpiresAt       string `json:"expiresAt,omitempty"`
 }
 
+// PostDeletedNotificationPayload names the community rule the moderators cited, if any
+type PostDeletedNotificationPayload struct {
+	PostID    uint64  `json:"postId"`
+	RuleID    *uint64 `json:"ruleId,omitempty"`
+	RuleTitle string  `json:"ruleTitle,omitempty"`
+}
+
+// CommentDeletedNotificationPayload names the community rule the moderators cited, if any
 type CommentDeletedNotificationPayload struct {
-	CommentID uint64 `json:"commentId"`
-	PostID    uint64 `json:"postId"`
+	CommentID uint64  `json:"commentId"`
+	PostID    uint64  `json:"postId"`
+	RuleID    *uint64 `json:"ruleId,omitempty"`
+	RuleTitle string  `json:"ruleTitle,omitempty"`
 }
 
 type ContentViolationPostPayload struct {
@@ -58,3 +68,54 @@ type ContentViolationCommentPayload struct {
 	Reason    string `json:"reason"`
 	Category  string `json:"category"`
 }
+
+type CommunityAnnouncementPayload struct {
+	CommunityID   uint64 `json:"communityId"`
+	CommunityName string `json:"communityName"`
+	PostID        uint64 `json:"postId"`
+	PostTitle     string `json:"postTitle"`
+}
+
+type PollClosedPayload struct {
+	PostID    uint64 `json:"postId"`
+	PostTitle string `json:"postTitle"`
+	// Empty when the poll ended in a tie
+	WinningOption string `json:"winningOption,omitempty"`
+}
+
+// Sent once for all reactions collected on a post or comment over a short window
+type ReactionNotificationPayload struct {
+	PostID    uint64  `json:"postId"`
+	CommentID *uint64 `json:"commentId,omitempty"`
+	// Latest user who reacted
+	UserName   string   `json:"userName"`
+	OtherCount int      `json:"otherCount"`
+	Emojis     []string `json:"emojis"`
+}
+
+type MentionNotificationPayload struct {
+	PostID    uint64  `json:"postId"`
+	PostTitle string  `json:"postTitle"`
+	CommentID *uint64 `json:"commentId,omitempty"`
+	UserName  string  `json:"userName"`
+}
+
+// ReportActionedNotificationPayload does not name the moderator who took the action
+type ReportActionedNotificationPayload struct {
+	CommunityID   uint64  `json:"communityId"`
+	CommunityName string  `json:"communityName"`
+	PostID        uint64  `json:"postId"`
+	CommentID     *uint64 `json:"commentId,omitempty"`
+}
+
+// ModeratorInvitationNotificationPayload is sent to the invitee, and back to the inviter
+// with Status once the invitee answers. RoleName is empty for ownership transfers.
+type ModeratorInvitationNotificationPayload struct {
+	InvitationID   uint64 `json:"invitationId"`
+	CommunityID    uint64 `json:"communityId"`
+	CommunityName  string `json:"communityName"`
+	UserName       string `json:"userName"`
+	InvitationType string `json:"invitationType"`
+	RoleName       string `json:"roleName,omitempty"`
+	Status         string `json:"status,omitempty"`
+}
@@ -3,10 +3,13 @@ package payload
 import "time"
 
 type PollOption struct {
-	ID     int      `json:"id"`
-	Text   string   `json:"text"`
-	Votes  int      `json:"votes"`
-	Voters []uint64 `json:"voters"`
+	ID   int    `json:"id"`
+	Text string `json:"text"`
+
+	// Deprecated: votes are stored in poll_votes. Older polls still carry them in
+	// poll_data and they are only read to backfill those polls.
+	Votes  int      `json:"votes,omitempty"`
+	Voters []uint64 `json:"voters,omitempty"`
 }
 
 type PollData struct {
@@ -14,5 +17,13 @@ type PollData struct {
 	Options        []PollOption `json:"options"`
 	MultipleChoice bool         `json:"multipleChoice"`
 	ExpiresAt      *time.Time   `json:"expiresAt,omitempty"`
-	TotalVotes     int          `json:"totalVotes"`
+	// Voters rank the options and the winner is found by instant-runoff
+	RankedChoice bool `json:"rankedChoice,omitempty"`
+	// Vote counts are only shown once the poll is closed
+	HideResultsUntilClose bool `json:"hideResultsUntilClose,omitempty"`
+	// Voters can suggest options, which are added once the author approves them
+	AllowWriteIns bool `json:"allowWriteIns,omitempty"`
+
+	// Deprecated: see PollOption.Voters
+	TotalVotes int `json:"totalVotes,omitempty"`
 }
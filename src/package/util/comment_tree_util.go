@@ -0,0 +1,36 @@
+package util
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// EncodeReplyContinuation builds the opaque token used to load the replies of a
+// comment that were left out of a truncated comment tree, starting at offset
+func EncodeReplyContinuation(parentID uint64, offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%d", parentID, offset)))
+}
+
+// DecodeReplyContinuation reverses EncodeReplyContinuation
+func DecodeReplyContinuation(token string) (uint64, int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid continuation token")
+	}
+
+	parts := strings.Split(string(raw), ":")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid continuation token")
+	}
+	parentID, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid continuation token")
+	}
+	offset, err := strconv.Atoi(parts[1])
+	if err != nil || offset < 0 {
+		return 0, 0, fmt.Errorf("invalid continuation token")
+	}
+	return parentID, offset, nil
+}
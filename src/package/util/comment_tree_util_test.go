@@ -0,0 +1,24 @@
+package util
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplyContinuation_RoundTrip(t *testing.T) {
+	parentID, offset, err := DecodeReplyContinuation(EncodeReplyContinuation(42, 10))
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(42), parentID)
+	assert.Equal(t, 10, offset)
+}
+
+func TestDecodeReplyContinuation_Invalid(t *testing.T) {
+	invalid := []string{"", "not base64!", base64.RawURLEncoding.EncodeToString([]byte("42")), base64.RawURLEncoding.EncodeToString([]byte("42:-1")), base64.RawURLEncoding.EncodeToString([]byte("x:1"))}
+	for _, token := range invalid {
+		_, _, err := DecodeReplyContinuation(token)
+		assert.Error(t, err, token)
+	}
+}
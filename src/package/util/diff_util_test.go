@@ -0,0 +1,62 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffLines_NoChanges(t *testing.T) {
+	result := DiffLines("a\nb", "a\nb")
+
+	assert.Equal(t, []DiffLine{
+		{Type: DiffEqual, Text: "a"},
+		{Type: DiffEqual, Text: "b"},
+	}, result)
+}
+
+func TestDiffLines_ChangedLine(t *testing.T) {
+	result := DiffLines("first\nsecond\nthird", "first\nchanged\nthird\nfourth")
+
+	assert.Equal(t, []DiffLine{
+		{Type: DiffEqual, Text: "first"},
+		{Type: DiffDelete, Text: "second"},
+		{Type: DiffInsert, Text: "changed"},
+		{Type: DiffEqual, Text: "third"},
+		{Type: DiffInsert, Text: "fourth"},
+	}, result)
+}
+
+func TestDiffLines_EmptyOldText(t *testing.T) {
+	result := DiffLines("", "new line")
+
+	assert.Equal(t, []DiffLine{{Type: DiffInsert, Text: "new line"}}, result)
+}
+
+func TestDiffLines_CRLF(t *testing.T) {
+	result := DiffLines("a\r\nb", "a\nb")
+
+	assert.Len(t, result, 2)
+	for _, line := range result {
+		assert.Equal(t, DiffEqual, line.Type)
+	}
+}
+
+func TestDiffLines_LargeChangeIsReplacedWhole(t *testing.T) {
+	oldLines := make([]string, 0, 1200)
+	newLines := make([]string, 0, 1200)
+	for i := 0; i < 1200; i++ {
+		oldLines = append(oldLines, fmt.Sprintf("old %d", i))
+		newLines = append(newLines, fmt.Sprintf("new %d", i))
+	}
+
+	result := DiffLines("title\n"+strings.Join(oldLines, "\n")+"\nend", "title\n"+strings.Join(newLines, "\n")+"\nend")
+
+	assert.Len(t, result, 2402)
+	assert.Equal(t, DiffLine{Type: DiffEqual, Text: "title"}, result[0])
+	assert.Equal(t, DiffLine{Type: DiffDelete, Text: "old 0"}, result[1])
+	assert.Equal(t, DiffLine{Type: DiffInsert, Text: "new 0"}, result[1201])
+	assert.Equal(t, DiffLine{Type: DiffEqual, Text: "end"}, result[2401])
+}
@@ -0,0 +1,170 @@
+package util
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+	"net/url"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	minHashShingleSize = 2
+	// MinHashSize is the number of hash functions in a signature
+	MinHashSize = 64
+	// Signatures are split into bands of MinHashBandRows values for candidate lookup.
+	// Texts with a Jaccard similarity of 0.7 share a band 99% of the time, 0.2 about 3%.
+	MinHashBandRows = 4
+)
+
+// Query parameters that only track where a link was shared from
+var trackingQueryParams = map[string]bool{
+	"fbclid": true, "gclid": true, "dclid": true, "msclkid": true, "igshid": true,
+	"mc_cid": true, "mc_eid": true, "ref": true, "ref_src": true, "si": true,
+}
+
+var minHashSeeds = func() [MinHashSize]uint64 {
+	var seeds [MinHashSize]uint64
+	for i := range seeds {
+		seeds[i] = mixHash(uint64(i) + 1)
+	}
+	return seeds
+}()
+
+// FingerprintWords normalizes text for fingerprinting: accents, case and punctuation
+// are dropped and the remaining words returned
+func FingerprintWords(text string) []string {
+	return strings.FieldsFunc(NormalizeString(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// MinHashSignature computes the MinHash signature of the word shingles of a text.
+// The share of equal values between two signatures estimates the Jaccard similarity
+// of their shingle sets. Returns nil for no words.
+func MinHashSignature(words []string) []int64 {
+	if len(words) == 0 {
+		return nil
+	}
+
+	var shingles []string
+	if len(words) < minHashShingleSize {
+		shingles = []string{strings.Join(words, " ")}
+	}
+	for i := 0; i+minHashShingleSize <= len(words); i++ {
+		shingles = append(shingles, strings.Join(words[i:i+minHashShingleSize], " "))
+	}
+
+	signature := make([]uint64, MinHashSize)
+	for i := range signature {
+		signature[i] = math.MaxUint64
+	}
+	for _, shingle := range shingles {
+		hasher := fnv.New64a()
+		_, _ = hasher.Write([]byte(shingle))
+		base := hasher.Sum64()
+		for i, seed := range minHashSeeds {
+			if value := mixHash(base ^ seed); value < signature[i] {
+				signature[i] = value
+			}
+		}
+	}
+
+	// stored as bigint, only equality matters
+	result := make([]int64, MinHashSize)
+	for i, value := range signature {
+		result[i] = int64(value)
+	}
+	return result
+}
+
+// MinHashSimilarity estimates the Jaccard similarity of two signatures
+func MinHashSimilarity(a, b []int64) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	equal := 0
+	for i := range a {
+		if a[i] == b[i] {
+			equal++
+		}
+	}
+	return float64(equal) / float64(len(a))
+}
+
+// MinHashBands hashes each band of a signature together with its position, so the
+// values can be stored in one array column and matched with an overlap query
+func MinHashBands(signature []int64) []int64 {
+	bands := make([]int64, 0, len(signature)/MinHashBandRows)
+	buf := make([]byte, 8)
+	for start := 0; start+MinHashBandRows <= len(signature); start += MinHashBandRows {
+		hasher := fnv.New64a()
+		binary.BigEndian.PutUint64(buf, uint64(start))
+		_, _ = hasher.Write(buf)
+		for _, value := range signature[start : start+MinHashBandRows] {
+			binary.BigEndian.PutUint64(buf, uint64(value))
+			_, _ = hasher.Write(buf)
+		}
+		bands = append(bands, int64(hasher.Sum64()))
+	}
+	return bands
+}
+
+// mixHash is the splitmix64 finalizer, used to derive independent hash functions
+func mixHash(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// CanonicalizeURL reduces a link to the form shared copies have in common: https,
+// lowercase host without www, no default port, fragment, tracking parameters or
+// trailing slash, and sorted query parameters. Returns "" for invalid URLs.
+func CanonicalizeURL(rawURL string) string {
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || parsed.Host == "" {
+		return ""
+	}
+	scheme := strings.ToLower(parsed.Scheme)
+	if scheme != "http" && scheme != "https" {
+		return ""
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	host = strings.TrimPrefix(host, "www.")
+	if port := parsed.Port(); port != "" && port != "80" && port != "443" {
+		host += ":" + port
+	}
+
+	query := parsed.Query()
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		lowerKey := strings.ToLower(key)
+		if strings.HasPrefix(lowerKey, "utm_") || trackingQueryParams[lowerKey] {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	sb.WriteString("https://" + host + strings.TrimRight(parsed.EscapedPath(), "/"))
+	for i, key := range keys {
+		values := query[key]
+		sort.Strings(values)
+		for j, value := range values {
+			if i == 0 && j == 0 {
+				sb.WriteString("?")
+			} else {
+				sb.WriteString("&")
+			}
+			sb.WriteString(url.QueryEscape(key) + "=" + url.QueryEscape(value))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,57 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const spamText = "Buy cheap followers today at our amazing store, the best prices on the whole internet guaranteed for everyone who visits our shop this week only"
+
+func TestFingerprintWords_Normalizes(t *testing.T) {
+	assert.Equal(t, []string{"cafe", "deals", "50", "off"}, FingerprintWords("Café DEALS!!! -- 50% off..."))
+}
+
+func TestMinHashSimilarity_NearDuplicates(t *testing.T) {
+	original := MinHashSignature(FingerprintWords(spamText))
+	reworded := MinHashSignature(FingerprintWords("buy CHEAP followers today at our amazing shop - the best prices on the whole internet guaranteed for everyone who visits our shop this week"))
+	unrelated := MinHashSignature(FingerprintWords("Does anyone know a good tutorial for learning goroutines and channels in depth with real world examples"))
+
+	assert.Len(t, original, MinHashSize)
+	assert.GreaterOrEqual(t, MinHashSimilarity(original, reworded), 0.6)
+	assert.Less(t, MinHashSimilarity(original, unrelated), 0.2)
+	assert.Equal(t, 1.0, MinHashSimilarity(original, MinHashSignature(FingerprintWords(spamText+"!!!"))))
+}
+
+func TestMinHashSignature_Empty(t *testing.T) {
+	assert.Nil(t, MinHashSignature(nil))
+	assert.Equal(t, 0.0, MinHashSimilarity(nil, nil))
+}
+
+func TestMinHashBands(t *testing.T) {
+	signature := MinHashSignature(FingerprintWords(spamText))
+	changed := append([]int64{}, signature...)
+	changed[0]++
+
+	bands := MinHashBands(signature)
+	changedBands := MinHashBands(changed)
+
+	assert.Len(t, bands, MinHashSize/MinHashBandRows)
+	assert.NotEqual(t, bands[0], changedBands[0])
+	assert.Equal(t, bands[1:], changedBands[1:])
+}
+
+func TestCanonicalizeURL(t *testing.T) {
+	tests := map[string]string{
+		"http://WWW.Example.com/Article/?utm_source=x&b=2&a=1#top": "https://example.com/Article?a=1&b=2",
+		"https://example.com:443/path":                             "https://example.com/path",
+		"https://example.com:8080/path?fbclid=abc":                 "https://example.com:8080/path",
+		"https://example.com":                                      "https://example.com",
+		"ftp://example.com/file":                                   "",
+		"not a url":                                                "",
+	}
+
+	for input, expected := range tests {
+		assert.Equal(t, expected, CanonicalizeURL(input), input)
+	}
+}
@@ -0,0 +1,62 @@
+package util
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResizeToFit_KeepsAspectRatio(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 400, 100))
+
+	resized := ResizeToFit(img, 200)
+
+	assert.Equal(t, 200, resized.Bounds().Dx())
+	assert.Equal(t, 50, resized.Bounds().Dy())
+}
+
+func TestResizeToFit_AlreadyFits(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 100, 80))
+
+	assert.Nil(t, ResizeToFit(img, 100))
+}
+
+func TestResizeToFit_AveragesPixels(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	img.Set(0, 0, color.RGBA{R: 255, A: 255})
+	img.Set(1, 0, color.RGBA{R: 255, A: 255})
+	img.Set(0, 1, color.RGBA{B: 255, A: 255})
+	img.Set(1, 1, color.RGBA{B: 255, A: 255})
+
+	resized := ResizeToFit(img, 1)
+
+	assert.Equal(t, color.RGBA{R: 128, B: 128, A: 255}, resized.RGBAAt(0, 0))
+}
+
+func TestJPEGOrientation(t *testing.T) {
+	exif := []byte("Exif\x00\x00" +
+		"MM\x00\x2a\x00\x00\x00\x08" + // big endian TIFF header, IFD0 at offset 8
+		"\x00\x01" + // one entry
+		"\x01\x12\x00\x03\x00\x00\x00\x01\x00\x06\x00\x00") // orientation = 6
+	data := []byte{0xFF, 0xD8, 0xFF, 0xE1, 0x00, byte(len(exif) + 2)}
+	data = append(data, exif...)
+	data = append(data, 0xFF, 0xDA)
+
+	assert.Equal(t, 6, JPEGOrientation(data))
+	assert.Equal(t, 1, JPEGOrientation([]byte{0xFF, 0xD8, 0xFF, 0xDA}))
+	assert.Equal(t, 1, JPEGOrientation([]byte("not a jpeg")))
+}
+
+func TestApplyOrientation_RotatesClockwise(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 3, 2))
+	marker := color.RGBA{R: 255, A: 255}
+	// bottom-left pixel ends up top-left after a 90 degree clockwise rotation
+	img.Set(0, 1, marker)
+
+	rotated := ApplyOrientation(img, 6)
+
+	assert.Equal(t, image.Rect(0, 0, 2, 3), rotated.Bounds())
+	assert.Equal(t, marker, rotated.(*image.RGBA).RGBAAt(0, 0))
+}
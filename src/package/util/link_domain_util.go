@@ -0,0 +1,45 @@
+package util
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Links in plain text and in href attributes of HTML content
+var linkPattern = regexp.MustCompile(`https?://[^\s"'<>]+`)
+
+// ExtractLinkDomains returns the distinct hosts linked from text, lowercased and without
+// a leading www.
+func ExtractLinkDomains(text string) []string {
+	var domains []string
+	seen := make(map[string]bool)
+	for _, link := range linkPattern.FindAllString(text, -1) {
+		domain := LinkDomain(link)
+		if domain == "" || seen[domain] {
+			continue
+		}
+		seen[domain] = true
+		domains = append(domains, domain)
+	}
+	return domains
+}
+
+// LinkDomain returns the host of a link, lowercased and without a leading www., or an
+// empty string when it cannot be parsed
+func LinkDomain(link string) string {
+	parsed, err := url.Parse(strings.TrimSpace(link))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+}
+
+// MatchesDomain reports whether host is domain or one of its subdomains
+func MatchesDomain(host, domain string) bool {
+	domain = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(domain)), "www.")
+	if domain == "" {
+		return false
+	}
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
@@ -0,0 +1,21 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractLinkDomains(t *testing.T) {
+	text := `See <a href="https://www.Example.com/a">this</a>, http://blog.example.com/b and https://example.com/c. Not example.org`
+
+	assert.Equal(t, []string{"example.com", "blog.example.com"}, ExtractLinkDomains(text))
+	assert.Empty(t, ExtractLinkDomains("no links here"))
+}
+
+func TestMatchesDomain(t *testing.T) {
+	assert.True(t, MatchesDomain("example.com", "example.com"))
+	assert.True(t, MatchesDomain("blog.example.com", "www.Example.com"))
+	assert.False(t, MatchesDomain("badexample.com", "example.com"))
+	assert.False(t, MatchesDomain("example.com", ""))
+}
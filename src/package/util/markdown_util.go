@@ -0,0 +1,165 @@
+package util
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	markdownHeadingRegex     = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	markdownRuleRegex        = regexp.MustCompile(`^\s{0,3}(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+	markdownBulletRegex      = regexp.MustCompile(`^\s{0,3}[-*+]\s+(.*)$`)
+	markdownOrderedRegex     = regexp.MustCompile(`^\s{0,3}(\d{1,9})[.)]\s+(.*)$`)
+	markdownFenceRegex       = regexp.MustCompile("^\\s{0,3}(```|~~~)")
+	markdownImageRegex       = regexp.MustCompile(`!\[([^\]]*)\]\(\s*([^)\s]+)(?:\s+&#34;([^)]*)&#34;)?\s*\)`)
+	markdownLinkRegex        = regexp.MustCompile(`\[([^\]]+)\]\(\s*([^)\s]+)(?:\s+&#34;([^)]*)&#34;)?\s*\)`)
+	markdownBoldRegex        = regexp.MustCompile(`\*\*(\S(?:.*?\S)?)\*\*|__(\S(?:.*?\S)?)__`)
+	markdownItalicRegex      = regexp.MustCompile(`\*(\S(?:.*?\S)?)\*|(^|[^\w])_(\S(?:.*?\S)?)_([^\w]|$)`)
+	markdownStrikeRegex      = regexp.MustCompile(`~~(\S(?:.*?\S)?)~~`)
+	markdownAutolinkRegex    = regexp.MustCompile(`&lt;(https?://[^\s&]+)&gt;`)
+	markdownCodeSpanSplitter = regexp.MustCompile("`+")
+)
+
+// RenderMarkdown converts a practical subset of Markdown (headings, paragraphs,
+// emphasis, strikethrough, code, block quotes, lists, rules, links and images) to HTML.
+// Raw HTML in the input is escaped. The output still has to go through the sanitizer,
+// which validates link and image URLs.
+func RenderMarkdown(input string) string {
+	input = strings.ReplaceAll(input, "\r\n", "\n")
+	return renderMarkdownBlocks(strings.Split(input, "\n"))
+}
+
+func renderMarkdownBlocks(lines []string) string {
+	var sb strings.Builder
+	var paragraph []string
+
+	flushParagraph := func() {
+		if len(paragraph) > 0 {
+			sb.WriteString("<p>" + renderMarkdownInline(strings.Join(paragraph, "\n")) + "</p>\n")
+			paragraph = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			flushParagraph()
+
+		case markdownFenceRegex.MatchString(line):
+			flushParagraph()
+			fence := markdownFenceRegex.FindStringSubmatch(line)[1]
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence); i++ {
+				code = append(code, lines[i])
+			}
+			sb.WriteString("<pre><code>" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+
+		case markdownHeadingRegex.MatchString(trimmed):
+			flushParagraph()
+			match := markdownHeadingRegex.FindStringSubmatch(trimmed)
+			level := strconv.Itoa(len(match[1]))
+			sb.WriteString("<h" + level + ">" + renderMarkdownInline(match[2]) + "</h" + level + ">\n")
+
+		case markdownRuleRegex.MatchString(line):
+			flushParagraph()
+			sb.WriteString("<hr>\n")
+
+		case strings.HasPrefix(trimmed, ">"):
+			flushParagraph()
+			var quoted []string
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">"); i++ {
+				quotedLine := strings.TrimPrefix(strings.TrimSpace(lines[i]), ">")
+				quoted = append(quoted, strings.TrimPrefix(quotedLine, " "))
+			}
+			i--
+			sb.WriteString("<blockquote>\n" + renderMarkdownBlocks(quoted) + "</blockquote>\n")
+
+		case markdownBulletRegex.MatchString(line):
+			flushParagraph()
+			sb.WriteString("<ul>\n")
+			for ; i < len(lines) && markdownBulletRegex.MatchString(lines[i]); i++ {
+				item := markdownBulletRegex.FindStringSubmatch(lines[i])[1]
+				sb.WriteString("<li>" + renderMarkdownInline(item) + "</li>\n")
+			}
+			i--
+			sb.WriteString("</ul>\n")
+
+		case markdownOrderedRegex.MatchString(line):
+			flushParagraph()
+			start := markdownOrderedRegex.FindStringSubmatch(line)[1]
+			if start != "1" {
+				sb.WriteString(`<ol start="` + strings.TrimLeft(start, "0") + `">` + "\n")
+			} else {
+				sb.WriteString("<ol>\n")
+			}
+			for ; i < len(lines) && markdownOrderedRegex.MatchString(lines[i]); i++ {
+				item := markdownOrderedRegex.FindStringSubmatch(lines[i])[2]
+				sb.WriteString("<li>" + renderMarkdownInline(item) + "</li>\n")
+			}
+			i--
+			sb.WriteString("</ol>\n")
+
+		default:
+			paragraph = append(paragraph, strings.TrimLeft(line, " \t"))
+		}
+	}
+	flushParagraph()
+
+	return sb.String()
+}
+
+// renderMarkdownInline handles code spans first so their content is left untouched
+func renderMarkdownInline(text string) string {
+	var sb strings.Builder
+	for {
+		loc := markdownCodeSpanSplitter.FindStringIndex(text)
+		if loc == nil {
+			sb.WriteString(renderMarkdownEmphasis(text))
+			return sb.String()
+		}
+
+		ticks := text[loc[0]:loc[1]]
+		end := strings.Index(text[loc[1]:], ticks)
+		if end < 0 {
+			sb.WriteString(renderMarkdownEmphasis(text))
+			return sb.String()
+		}
+
+		sb.WriteString(renderMarkdownEmphasis(text[:loc[0]]))
+		code := strings.TrimSpace(text[loc[1] : loc[1]+end])
+		sb.WriteString("<code>" + html.EscapeString(code) + "</code>")
+		text = text[loc[1]+end+len(ticks):]
+	}
+}
+
+func renderMarkdownEmphasis(text string) string {
+	text = html.EscapeString(text)
+	text = markdownImageRegex.ReplaceAllStringFunc(text, func(match string) string {
+		groups := markdownImageRegex.FindStringSubmatch(match)
+		return `<img src="` + groups[2] + `" alt="` + groups[1] + `"` + markdownTitleAttribute(groups[3]) + ">"
+	})
+	text = markdownLinkRegex.ReplaceAllStringFunc(text, func(match string) string {
+		groups := markdownLinkRegex.FindStringSubmatch(match)
+		return `<a href="` + groups[2] + `"` + markdownTitleAttribute(groups[3]) + ">" + groups[1] + "</a>"
+	})
+	text = markdownAutolinkRegex.ReplaceAllString(text, `<a href="$1">$1</a>`)
+	text = markdownBoldRegex.ReplaceAllString(text, "<strong>$1$2</strong>")
+	text = markdownItalicRegex.ReplaceAllString(text, "$2<em>$1$3</em>$4")
+	text = markdownStrikeRegex.ReplaceAllString(text, "<del>$1</del>")
+	// two trailing spaces or a backslash mark a hard line break
+	text = strings.ReplaceAll(text, "  \n", "<br>\n")
+	text = strings.ReplaceAll(text, "\\\n", "<br>\n")
+	return text
+}
+
+func markdownTitleAttribute(title string) string {
+	if title == "" {
+		return ""
+	}
+	return ` title="` + title + `"`
+}
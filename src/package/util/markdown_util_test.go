@@ -0,0 +1,32 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderMarkdown_Blocks(t *testing.T) {
+	input := "# Title\n\nSome *text*\nnext line\n\n- one\n- two\n\n3. three\n4. four\n\n> quoted\n\n---\n\n```\n<b>code</b>\n```"
+
+	expected := "<h1>Title</h1>\n" +
+		"<p>Some <em>text</em>\nnext line</p>\n" +
+		"<ul>\n<li>one</li>\n<li>two</li>\n</ul>\n" +
+		"<ol start=\"3\">\n<li>three</li>\n<li>four</li>\n</ol>\n" +
+		"<blockquote>\n<p>quoted</p>\n</blockquote>\n" +
+		"<hr>\n" +
+		"<pre><code>&lt;b&gt;code&lt;/b&gt;</code></pre>\n"
+	assert.Equal(t, expected, RenderMarkdown(input))
+}
+
+func TestRenderMarkdown_Inline(t *testing.T) {
+	input := "**bold** _em_ ~~gone~~ `a*b*c` snake_case_name ![alt](/img.png \"pic\")"
+
+	expected := "<p><strong>bold</strong> <em>em</em> <del>gone</del> <code>a*b*c</code> snake_case_name " +
+		"<img src=\"/img.png\" alt=\"alt\" title=\"pic\"></p>\n"
+	assert.Equal(t, expected, RenderMarkdown(input))
+}
+
+func TestRenderMarkdown_EscapesHTML(t *testing.T) {
+	assert.Equal(t, "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>\n", RenderMarkdown("<script>alert(1)</script>"))
+}
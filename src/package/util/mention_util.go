@@ -0,0 +1,32 @@
+package util
+
+import (
+	"regexp"
+	"strings"
+)
+
+// A mention starts with @ that does not follow a word character, so email addresses
+// and handles like a@b are not treated as mentions
+var mentionPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_@.])@([\p{L}\p{N}_][\p{L}\p{N}_.-]*)`)
+
+// ExtractMentions returns the distinct usernames mentioned as @username in text, in
+// the order they first appear, compared case-insensitively. At most max usernames
+// are returned.
+func ExtractMentions(text string, max int) []string {
+	var usernames []string
+	seen := make(map[string]bool)
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		// Trailing punctuation ends the sentence, not the username
+		username := strings.TrimRight(match[1], ".-")
+		key := strings.ToLower(username)
+		if username == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		usernames = append(usernames, username)
+		if len(usernames) == max {
+			break
+		}
+	}
+	return usernames
+}
@@ -0,0 +1,15 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractMentions(t *testing.T) {
+	text := "@alice thanks! cc @Bob_1, @bob_1 and @trần.văn. Mail me at carol@example.com or @@dave"
+
+	assert.Equal(t, []string{"alice", "Bob_1", "trần.văn"}, ExtractMentions(text, 20))
+	assert.Equal(t, []string{"alice"}, ExtractMentions(text, 1))
+	assert.Empty(t, ExtractMentions("no mentions here, just an @ sign", 20))
+}
@@ -0,0 +1,25 @@
+package util
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// IsReactionEmoji reports whether s can be used as a reaction. It accepts a single
+// emoji sequence (including modifiers, ZWJ sequences and flags) and rejects anything
+// containing letters, digits, spaces or control characters.
+func IsReactionEmoji(s string, maxBytes int) bool {
+	if s == "" || len(s) > maxBytes || !utf8.ValidString(s) || strings.TrimSpace(s) != s {
+		return false
+	}
+	for _, r := range s {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsSpace(r) || unicode.IsControl(r) {
+			return false
+		}
+		if r < utf8.RuneSelf && r != '#' && r != '*' {
+			return false
+		}
+	}
+	return true
+}
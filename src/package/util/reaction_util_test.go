@@ -0,0 +1,19 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsReactionEmoji(t *testing.T) {
+	valid := []string{"👍", "❤️", "🎉", "👍🏽", "👨‍👩‍👧", "🇻🇳", "#️⃣"}
+	for _, emoji := range valid {
+		assert.True(t, IsReactionEmoji(emoji, 32), emoji)
+	}
+
+	invalid := []string{"", "a", "ok", " 👍", "👍 👍", "1️⃣", "<b>", "\n", "👍👍👍👍👍👍👍👍👍"}
+	for _, emoji := range invalid {
+		assert.False(t, IsReactionEmoji(emoji, 32), emoji)
+	}
+}
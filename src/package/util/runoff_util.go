@@ -0,0 +1,96 @@
+package util
+
+type RunoffTally struct {
+	OptionID int   `json:"optionId"`
+	Votes    int64 `json:"votes"`
+}
+
+type RunoffRound struct {
+	Tallies []RunoffTally `json:"tallies"`
+	// Ballots with no remaining option ranked
+	Exhausted  int64 `json:"exhausted"`
+	Eliminated []int `json:"eliminated,omitempty"`
+}
+
+type RunoffResult struct {
+	Rounds []RunoffRound `json:"rounds"`
+	// Nil when there are no ballots or the last options are tied
+	WinnerID *int `json:"winnerId,omitempty"`
+}
+
+// InstantRunoff tallies ranked ballots (option ids, most preferred first). Each round
+// counts every ballot for its highest ranked remaining option; an option with a
+// majority of the continuing ballots wins, otherwise the options with the fewest votes
+// are eliminated together.
+func InstantRunoff(optionIDs []int, ballots [][]int) RunoffResult {
+	active := make(map[int]bool, len(optionIDs))
+	for _, optionID := range optionIDs {
+		active[optionID] = true
+	}
+
+	result := RunoffResult{Rounds: []RunoffRound{}}
+	for len(active) > 0 {
+		counts := make(map[int]int64, len(active))
+		var exhausted int64
+		for _, ballot := range ballots {
+			counted := false
+			for _, optionID := range ballot {
+				if active[optionID] {
+					counts[optionID]++
+					counted = true
+					break
+				}
+			}
+			if !counted {
+				exhausted++
+			}
+		}
+
+		round := RunoffRound{Exhausted: exhausted}
+		for _, optionID := range optionIDs {
+			if active[optionID] {
+				round.Tallies = append(round.Tallies, RunoffTally{OptionID: optionID, Votes: counts[optionID]})
+			}
+		}
+
+		continuing := int64(len(ballots)) - exhausted
+		if continuing == 0 {
+			result.Rounds = append(result.Rounds, round)
+			return result
+		}
+
+		for _, tally := range round.Tallies {
+			if tally.Votes*2 > continuing {
+				winnerID := tally.OptionID
+				result.WinnerID = &winnerID
+				result.Rounds = append(result.Rounds, round)
+				return result
+			}
+		}
+
+		fewest := round.Tallies[0].Votes
+		for _, tally := range round.Tallies {
+			if tally.Votes < fewest {
+				fewest = tally.Votes
+			}
+		}
+		for _, tally := range round.Tallies {
+			if tally.Votes == fewest {
+				round.Eliminated = append(round.Eliminated, tally.OptionID)
+			}
+		}
+		result.Rounds = append(result.Rounds, round)
+
+		// Everyone left is tied, nobody can be eliminated
+		if len(round.Eliminated) == len(round.Tallies) {
+			round.Eliminated = nil
+			result.Rounds[len(result.Rounds)-1] = round
+			return result
+		}
+		for _, optionID := range round.Eliminated {
+			delete(active, optionID)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,52 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstantRunoff_FirstRoundMajority(t *testing.T) {
+	result := InstantRunoff([]int{1, 2, 3}, [][]int{{1, 2}, {1}, {2, 1}})
+
+	assert.Len(t, result.Rounds, 1)
+	assert.Equal(t, 1, *result.WinnerID)
+}
+
+func TestInstantRunoff_TransfersEliminatedVotes(t *testing.T) {
+	ballots := [][]int{
+		{1}, {1},
+		{2}, {2},
+		{3, 2},
+	}
+
+	result := InstantRunoff([]int{1, 2, 3}, ballots)
+
+	assert.Len(t, result.Rounds, 2)
+	assert.Equal(t, []int{3}, result.Rounds[0].Eliminated)
+	assert.Equal(t, []RunoffTally{{OptionID: 1, Votes: 2}, {OptionID: 2, Votes: 3}}, result.Rounds[1].Tallies)
+	assert.Equal(t, 2, *result.WinnerID)
+}
+
+func TestInstantRunoff_ExhaustedBallots(t *testing.T) {
+	ballots := [][]int{
+		{1}, {1},
+		{2}, {2},
+		{3},
+	}
+
+	result := InstantRunoff([]int{1, 2, 3}, ballots)
+
+	// Ballot for 3 is exhausted after it is eliminated, leaving 1 and 2 tied
+	assert.Len(t, result.Rounds, 2)
+	assert.Equal(t, int64(1), result.Rounds[1].Exhausted)
+	assert.Empty(t, result.Rounds[1].Eliminated)
+	assert.Nil(t, result.WinnerID)
+}
+
+func TestInstantRunoff_NoBallots(t *testing.T) {
+	result := InstantRunoff([]int{1, 2}, nil)
+
+	assert.Len(t, result.Rounds, 1)
+	assert.Nil(t, result.WinnerID)
+}
@@ -0,0 +1,242 @@
+package util
+
+import (
+	"net/url"
+	"strings"
+
+	"social-platform-backend/config"
+	"social-platform-backend/package/constant"
+
+	"golang.org/x/net/html"
+)
+
+// Elements removed together with everything inside them, whatever the allowlist says
+var htmlDropContentTags = map[string]bool{
+	"script": true, "style": true, "iframe": true, "frame": true, "frameset": true,
+	"object": true, "embed": true, "applet": true, "template": true, "noscript": true,
+	"textarea": true, "select": true, "svg": true, "math": true, "title": true, "head": true,
+}
+
+var htmlVoidTags = map[string]bool{
+	"br": true, "hr": true, "img": true, "wbr": true,
+}
+
+// Attributes holding a URL, only safe schemes are kept
+var htmlURLAttributes = map[string]bool{
+	"href": true, "src": true, "cite": true,
+}
+
+// Block elements that separate words when content is flattened to text
+var htmlBlockTags = map[string]bool{
+	"p": true, "br": true, "hr": true, "div": true, "li": true, "blockquote": true, "pre": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"tr": true, "td": true, "th": true, "ul": true, "ol": true, "table": true,
+}
+
+// HTMLSanitizer keeps only allowlisted tags and attributes of user supplied HTML
+type HTMLSanitizer struct {
+	allowedTags       map[string]bool
+	allowedAttributes map[string]map[string]bool
+}
+
+func NewHTMLSanitizer(allowedTags []string, allowedAttributes map[string][]string) *HTMLSanitizer {
+	sanitizer := &HTMLSanitizer{
+		allowedTags:       make(map[string]bool, len(allowedTags)),
+		allowedAttributes: make(map[string]map[string]bool, len(allowedAttributes)),
+	}
+
+	for _, tag := range allowedTags {
+		sanitizer.allowedTags[strings.ToLower(strings.TrimSpace(tag))] = true
+	}
+	for tag, attributes := range allowedAttributes {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		sanitizer.allowedAttributes[tag] = make(map[string]bool, len(attributes))
+		for _, attribute := range attributes {
+			sanitizer.allowedAttributes[tag][strings.ToLower(strings.TrimSpace(attribute))] = true
+		}
+	}
+
+	return sanitizer
+}
+
+// NewContentSanitizer builds the sanitizer for posts and comments from the content
+// config, falling back to the default allowlist
+func NewContentSanitizer(conf *config.Config) *HTMLSanitizer {
+	allowedTags := conf.Content.AllowedTags
+	if len(allowedTags) == 0 {
+		allowedTags = constant.DEFAULT_ALLOWED_HTML_TAGS
+	}
+	allowedAttributes := conf.Content.AllowedAttributes
+	if len(allowedAttributes) == 0 {
+		allowedAttributes = constant.DEFAULT_ALLOWED_HTML_ATTRIBUTES
+	}
+	return NewHTMLSanitizer(allowedTags, allowedAttributes)
+}
+
+// SanitizeContent renders Markdown input to HTML first, then sanitizes it
+func (s *HTMLSanitizer) SanitizeContent(content, format string) string {
+	if format == constant.CONTENT_FORMAT_MARKDOWN {
+		content = RenderMarkdown(content)
+	}
+	return strings.TrimSpace(s.Sanitize(content))
+}
+
+// Sanitize drops disallowed tags but keeps their text, removes dangerous elements with
+// their content, strips event handler attributes and unsafe URLs, balances the
+// remaining tags and marks every link rel="nofollow ugc".
+func (s *HTMLSanitizer) Sanitize(input string) string {
+	tokenizer := html.NewTokenizer(strings.NewReader(input))
+	var sb strings.Builder
+	var open []string
+	dropDepth := 0
+
+	for {
+		tokenType := tokenizer.Next()
+		switch tokenType {
+		case html.ErrorToken:
+			for i := len(open) - 1; i >= 0; i-- {
+				sb.WriteString("</" + open[i] + ">")
+			}
+			return sb.String()
+
+		case html.TextToken:
+			if dropDepth == 0 {
+				sb.WriteString(html.EscapeString(string(tokenizer.Text())))
+			}
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			name := token.Data
+			if htmlDropContentTags[name] {
+				if tokenType == html.StartTagToken {
+					dropDepth++
+				}
+				continue
+			}
+			if dropDepth > 0 || !s.allowedTags[name] {
+				continue
+			}
+
+			sb.WriteString("<" + name)
+			for _, attr := range token.Attr {
+				key := strings.ToLower(attr.Key)
+				if attr.Namespace != "" || strings.HasPrefix(key, "on") || !s.allowedAttributes[name][key] {
+					continue
+				}
+				// rel is always set below
+				if name == "a" && key == "rel" {
+					continue
+				}
+				if htmlURLAttributes[key] && !isSafeContentURL(attr.Val, key == "href") {
+					continue
+				}
+				sb.WriteString(" " + key + `="` + html.EscapeString(attr.Val) + `"`)
+			}
+			if name == "a" {
+				sb.WriteString(` rel="nofollow ugc noopener"`)
+			}
+			sb.WriteString(">")
+
+			if !htmlVoidTags[name] && tokenType == html.StartTagToken {
+				open = append(open, name)
+			}
+
+		case html.EndTagToken:
+			name := tokenizer.Token().Data
+			if htmlDropContentTags[name] {
+				if dropDepth > 0 {
+					dropDepth--
+				}
+				continue
+			}
+			if dropDepth > 0 || !s.allowedTags[name] {
+				continue
+			}
+
+			// close everything opened after the matching tag, ignore stray end tags
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] != name {
+					continue
+				}
+				for j := len(open) - 1; j >= i; j-- {
+					sb.WriteString("</" + open[j] + ">")
+				}
+				open = open[:i]
+				break
+			}
+		}
+	}
+}
+
+// isSafeContentURL allows relative URLs and http(s), plus mailto for links
+func isSafeContentURL(rawURL string, isLink bool) bool {
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return false
+	}
+
+	switch strings.ToLower(parsed.Scheme) {
+	case "", "http", "https":
+		return true
+	case "mailto":
+		return isLink
+	default:
+		return false
+	}
+}
+
+// HTMLToText flattens HTML to plain text for search and moderation. Block elements
+// become spaces and runs of whitespace are collapsed.
+func HTMLToText(input string) string {
+	tokenizer := html.NewTokenizer(strings.NewReader(input))
+	var sb strings.Builder
+	dropDepth := 0
+
+	for {
+		tokenType := tokenizer.Next()
+		switch tokenType {
+		case html.ErrorToken:
+			return strings.Join(strings.Fields(sb.String()), " ")
+		case html.TextToken:
+			if dropDepth == 0 {
+				sb.Write(tokenizer.Text())
+			}
+		case html.StartTagToken, html.SelfClosingTagToken, html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			tag := string(name)
+			if htmlDropContentTags[tag] {
+				if tokenType == html.StartTagToken {
+					dropDepth++
+				} else if tokenType == html.EndTagToken && dropDepth > 0 {
+					dropDepth--
+				}
+				continue
+			}
+			if htmlBlockTags[tag] {
+				sb.WriteString(" ")
+			}
+		}
+	}
+}
+
+// ContentURLs returns the link and image URLs of HTML content, each once
+func ContentURLs(input string) []string {
+	tokenizer := html.NewTokenizer(strings.NewReader(input))
+	var urls []string
+	seen := make(map[string]bool)
+
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return urls
+		case html.StartTagToken, html.SelfClosingTagToken:
+			for _, attr := range tokenizer.Token().Attr {
+				key := strings.ToLower(attr.Key)
+				if (key == "src" || key == "href") && attr.Val != "" && !seen[attr.Val] {
+					seen[attr.Val] = true
+					urls = append(urls, attr.Val)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package util
+
+import (
+	"testing"
+
+	"social-platform-backend/config"
+	"social-platform-backend/package/constant"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newDefaultSanitizer() *HTMLSanitizer {
+	return NewContentSanitizer(&config.Config{})
+}
+
+func TestSanitize_RemovesScriptsAndEventHandlers(t *testing.T) {
+	input := `<p onclick="steal()">Hello<script>alert(1)</script> <b>world</b></p><style>p{}</style>`
+
+	assert.Equal(t, `<p>Hello <b>world</b></p>`, newDefaultSanitizer().Sanitize(input))
+}
+
+func TestSanitize_DropsDisallowedTagsButKeepsText(t *testing.T) {
+	input := `<form><input value="x">Click <blink>here</blink></form>`
+
+	assert.Equal(t, `Click here`, newDefaultSanitizer().Sanitize(input))
+}
+
+func TestSanitize_RewritesLinks(t *testing.T) {
+	input := `<a href="https://example.com/?a=1&b=2" rel="follow" target="_blank">link</a>`
+
+	assert.Equal(t, `<a href="https://example.com/?a=1&amp;b=2" rel="nofollow ugc noopener">link</a>`, newDefaultSanitizer().Sanitize(input))
+}
+
+func TestSanitize_RemovesUnsafeURLs(t *testing.T) {
+	sanitizer := newDefaultSanitizer()
+
+	assert.Equal(t, `<a rel="nofollow ugc noopener">x</a>`, sanitizer.Sanitize(`<a href="javascript:alert(1)">x</a>`))
+	assert.Equal(t, `<a rel="nofollow ugc noopener">x</a>`, sanitizer.Sanitize(`<a href="jav&#x61;script:alert(1)">x</a>`))
+	assert.Equal(t, `<img alt="y">`, sanitizer.Sanitize(`<img src="data:image/svg+xml;base64,AAAA" alt="y">`))
+	assert.Equal(t, `<img src="/media/a.png">`, sanitizer.Sanitize(`<img src="/media/a.png" onerror="x()">`))
+}
+
+func TestSanitize_BalancesTags(t *testing.T) {
+	sanitizer := newDefaultSanitizer()
+
+	assert.Equal(t, `<p><b>bold</b></p>`, sanitizer.Sanitize(`<p><b>bold</p>`))
+	assert.Equal(t, `<em>text</em>`, sanitizer.Sanitize(`</b><em>text`))
+}
+
+func TestSanitize_EscapesText(t *testing.T) {
+	assert.Equal(t, `1 &lt; 2 &amp;&amp; &#34;quoted&#34;`, newDefaultSanitizer().Sanitize(`1 &lt; 2 && "quoted"`))
+}
+
+func TestSanitize_UsesConfiguredAllowlist(t *testing.T) {
+	sanitizer := NewContentSanitizer(&config.Config{Content: config.Content{
+		AllowedTags:       []string{"p", "span"},
+		AllowedAttributes: map[string][]string{"span": {"class"}},
+	}})
+
+	assert.Equal(t, `<p><span class="spoiler">x</span>y</p>`, sanitizer.Sanitize(`<p><span class="spoiler" id="a">x</span><b>y</b></p>`))
+}
+
+func TestSanitizeContent_RendersMarkdown(t *testing.T) {
+	content := newDefaultSanitizer().SanitizeContent("Hello **world** [site](https://example.com)", constant.CONTENT_FORMAT_MARKDOWN)
+
+	assert.Equal(t, `<p>Hello <strong>world</strong> <a href="https://example.com" rel="nofollow ugc noopener">site</a></p>`, content)
+}
+
+func TestHTMLToText(t *testing.T) {
+	input := `<h1>Title</h1><p>First&nbsp;line<br>second <b>bold</b></p><script>var x;</script><ul><li>a</li><li>b</li></ul>`
+
+	assert.Equal(t, "Title First line second bold a b", HTMLToText(input))
+}
+
+func TestContentURLs(t *testing.T) {
+	input := `<p><img src="https://cdn.example.com/a.png"> <a href="https://example.com/?a=1&amp;b=2">x</a><img src="https://cdn.example.com/a.png"></p>`
+
+	assert.Equal(t, []string{"https://cdn.example.com/a.png", "https://example.com/?a=1&b=2"}, ContentURLs(input))
+}